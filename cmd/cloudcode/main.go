@@ -3,23 +3,31 @@ package main
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	_ "github.com/lib/pq"
 
 	"github.com/logan/cloudcode/internal/api"
+	"github.com/logan/cloudcode/internal/auth"
+	"github.com/logan/cloudcode/internal/backup"
 	"github.com/logan/cloudcode/internal/config"
 	"github.com/logan/cloudcode/internal/ent"
 	"github.com/logan/cloudcode/internal/ent/migrate"
 	"github.com/logan/cloudcode/internal/netbird"
+	"github.com/logan/cloudcode/internal/provider"
 	"github.com/logan/cloudcode/internal/provider/factory"
 	"github.com/logan/cloudcode/internal/service"
+	"github.com/logan/cloudcode/internal/sso"
 	"github.com/logan/cloudcode/internal/telemetry"
+	"github.com/logan/cloudcode/internal/vault"
 )
 
 // version is set by -ldflags at build time.
@@ -52,6 +60,30 @@ func main() {
 	}
 	defer otelShutdown(context.Background())
 
+	// Master keys for the secrets vault and column encryption. The column
+	// codec reads them from vault, so they are installed before the first query.
+	var keySource vault.Source
+	switch {
+	case cfg.SecretsMasterKeysFile != "":
+		keySource = vault.FileSource(cfg.SecretsMasterKeysFile)
+	case cfg.SecretsMasterKeys != "":
+		keySource = vault.EnvSource("SECRETS_MASTER_KEYS")
+	}
+	var keyring *vault.Keyring
+	if keySource != nil {
+		keyring, err = keySource.Load()
+		if err != nil {
+			logger.Error("failed to load master keys", "error", err)
+			os.Exit(1)
+		}
+		if cfg.ColumnEncryption != "on" && cfg.ColumnEncryption != "read" {
+			logger.Error("invalid COLUMN_ENCRYPTION", "value", cfg.ColumnEncryption)
+			os.Exit(1)
+		}
+		vault.SetFieldKeyring(keyring, cfg.ColumnEncryption == "on")
+		logger.Info("master keys loaded", "primary", keyring.PrimaryID(), "column_encryption", cfg.ColumnEncryption)
+	}
+
 	// Database
 	sqlDB, err := sql.Open("postgres", cfg.DatabaseURL)
 	if err != nil {
@@ -74,6 +106,14 @@ func main() {
 	}
 	logger.Info("database migrations applied")
 
+	if len(os.Args) > 1 && os.Args[1] == "reencrypt" {
+		if err := reencrypt(context.Background(), db, keyring, logger); err != nil {
+			logger.Error("reencrypt failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Provider
 	prov, err := factory.NewProvisioner(cfg)
 	if err != nil {
@@ -83,7 +123,21 @@ func main() {
 	logger.Info("provider initialized", "provider", cfg.Provider)
 
 	// Service layer
+	auditSvc := service.NewAuditService(db)
 	instanceSvc := service.NewInstanceService(db, prov, cfg.AnthropicAPIKey)
+	instanceSvc.SetAuditService(auditSvc)
+	instanceLimits, err := service.ParsePlanLimits(cfg.InstanceLimits)
+	if err != nil {
+		logger.Error("invalid INSTANCE_LIMITS", "error", err)
+		os.Exit(1)
+	}
+	instanceSvc.SetPlanLimits(instanceLimits)
+	planClasses, err := service.ParsePlanClasses(cfg.PlanClasses)
+	if err != nil {
+		logger.Error("invalid PLAN_CLASSES", "error", err)
+		os.Exit(1)
+	}
+	instanceSvc.SetClasses(provider.DefaultClasses, planClasses)
 
 	// Netbird (Hetzner only)
 	var cronSvc *service.CronService
@@ -99,6 +153,144 @@ func main() {
 		cronSvc.Start()
 	}
 
+	// Warm pool (only for providers that can boot unassigned instances)
+	var warmPool *service.WarmPoolService
+	if cfg.WarmPool != "" {
+		sizes, err := service.ParseWarmPool(cfg.WarmPool, provider.DefaultClasses)
+		if err != nil {
+			logger.Error("invalid WARM_POOL", "error", err)
+			os.Exit(1)
+		}
+		interval, err := time.ParseDuration(cfg.WarmPoolInterval)
+		if err != nil {
+			interval = time.Minute
+		}
+		warmPool, err = service.NewWarmPoolService(db, prov, logger, provider.DefaultClasses, sizes, interval)
+		if err != nil {
+			logger.Error("warm pool not supported by provider", "provider", cfg.Provider)
+			os.Exit(1)
+		}
+		instanceSvc.SetWarmPool(warmPool)
+		warmPool.Start()
+	}
+
+	// Secrets vault
+	var secretSvc *service.SecretService
+	if keyring != nil {
+		secretSvc = service.NewSecretService(db, keyring, logger)
+		instanceSvc.SetSecrets(secretSvc)
+		secretSvc.SetAuditService(auditSvc)
+
+		// Finish any master key rotation started by a config change
+		n, err := secretSvc.Rewrap(context.Background())
+		if err != nil {
+			logger.Error("failed to rewrap secrets", "error", err)
+			os.Exit(1)
+		}
+		if n > 0 {
+			logger.Info("rewrapped secrets under the primary master key", "count", n, "key_id", keyring.PrimaryID())
+		}
+	}
+
+	// Async job queue for instance lifecycle operations
+	jobWorkers, err := strconv.Atoi(cfg.JobWorkers)
+	if err != nil {
+		jobWorkers = 4
+	}
+	jobPollInterval, err := time.ParseDuration(cfg.JobPollInterval)
+	if err != nil {
+		jobPollInterval = 2 * time.Second
+	}
+	jobSvc := service.NewJobService(db, instanceSvc, logger, jobWorkers, jobPollInterval)
+	jobSvc.SetAuditService(auditSvc)
+	wakeTimeout, err := time.ParseDuration(cfg.WakeTimeout)
+	if err != nil {
+		wakeTimeout = service.DefaultWakeTimeout
+	}
+	jobSvc.SetWakeTimeout(wakeTimeout)
+
+	// Volume backups (only for providers that can archive volumes)
+	var backupSvc *service.BackupService
+	if cfg.BackupStore != "" {
+		archiver, ok := prov.(provider.VolumeArchiver)
+		if !ok {
+			logger.Error("backups not supported by provider", "provider", cfg.Provider)
+			os.Exit(1)
+		}
+		var store backup.Store
+		switch cfg.BackupStore {
+		case "local":
+			store, err = backup.NewLocalStore(cfg.BackupLocalDir)
+		case "s3":
+			store, err = backup.NewS3Store(backup.S3Options{
+				Endpoint:  cfg.BackupS3Endpoint,
+				Bucket:    cfg.BackupS3Bucket,
+				AccessKey: cfg.BackupS3AccessKey,
+				SecretKey: cfg.BackupS3SecretKey,
+				Region:    cfg.BackupS3Region,
+				UseSSL:    cfg.BackupS3UseSSL,
+			})
+		default:
+			err = fmt.Errorf("unknown BACKUP_STORE %q", cfg.BackupStore)
+		}
+		if err != nil {
+			logger.Error("failed to create backup store", "error", err)
+			os.Exit(1)
+		}
+		schedule, err := service.ParseBackupSchedule(cfg.BackupSchedule)
+		if err != nil {
+			logger.Error("invalid BACKUP_SCHEDULE", "error", err)
+			os.Exit(1)
+		}
+		retention, err := strconv.Atoi(cfg.BackupRetention)
+		if err != nil {
+			retention = 7
+		}
+		checkInterval, err := time.ParseDuration(cfg.BackupCheckInterval)
+		if err != nil {
+			checkInterval = 15 * time.Minute
+		}
+		backupSvc = service.NewBackupService(db, archiver, store, jobSvc, logger, schedule, retention, checkInterval)
+		jobSvc.SetBackupService(backupSvc)
+		backupSvc.Start()
+		logger.Info("backups enabled", "store", cfg.BackupStore)
+	}
+	jobSvc.Start()
+
+	// Terminal recordings
+	var recordingSvc *service.RecordingService
+	if cfg.RecordingStore != "" {
+		var store backup.Store
+		switch cfg.RecordingStore {
+		case "local":
+			store, err = backup.NewLocalStore(cfg.RecordingLocalDir)
+		case "s3":
+			store, err = backup.NewS3Store(backup.S3Options{
+				Endpoint:  cfg.BackupS3Endpoint,
+				Bucket:    cfg.RecordingS3Bucket,
+				AccessKey: cfg.BackupS3AccessKey,
+				SecretKey: cfg.BackupS3SecretKey,
+				Region:    cfg.BackupS3Region,
+				UseSSL:    cfg.BackupS3UseSSL,
+			})
+		default:
+			err = fmt.Errorf("unknown RECORDING_STORE %q", cfg.RecordingStore)
+		}
+		if err != nil {
+			logger.Error("failed to create recording store", "error", err)
+			os.Exit(1)
+		}
+		retention, err := service.ParseRecordingRetention(cfg.RecordingRetention)
+		if err != nil {
+			logger.Error("invalid RECORDING_RETENTION", "error", err)
+			os.Exit(1)
+		}
+		recordingSvc = service.NewRecordingService(db, store, logger, retention)
+		recordingSvc.SetAuditService(auditSvc)
+		recordingSvc.Start()
+		logger.Info("terminal recording enabled", "store", cfg.RecordingStore)
+	}
+
 	// Mailer
 	var mailer service.Mailer
 	if cfg.SMTPHost != "" {
@@ -109,14 +301,70 @@ func main() {
 		logger.Info("mailer initialized", "type", "log")
 	}
 
+	// JWT keys
+	var keyFiles, previousSecrets []string
+	for _, f := range strings.Split(cfg.JWTKeyFiles, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			keyFiles = append(keyFiles, f)
+		}
+	}
+	for _, s := range strings.Split(cfg.JWTPreviousSecrets, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			previousSecrets = append(previousSecrets, s)
+		}
+	}
+	var secretsUntil time.Time
+	if cfg.JWTSecretsUntil != "" {
+		if secretsUntil, err = time.Parse(time.RFC3339, cfg.JWTSecretsUntil); err != nil {
+			logger.Error("invalid JWT_SECRETS_UNTIL", "error", err)
+			os.Exit(1)
+		}
+	}
+	jwtKeys, err := auth.LoadKeyring(cfg.JWTSecret, keyFiles, previousSecrets, secretsUntil)
+	if err != nil {
+		logger.Error("failed to load JWT keys", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("JWT keys loaded", "kid", jwtKeys.SigningKey().ID, "alg", jwtKeys.SigningKey().Algorithm())
+
 	// Auth service
 	authSvc := service.NewAuthService(db, cfg.JWTSecret, cfg.BaseURL, cfg.FrontendURL, mailer)
+	authSvc.SetKeyring(jwtKeys)
+	authSvc.SetAuditService(auditSvc)
+	if len(cfg.SSOProviders) > 0 {
+		var providers []sso.Provider
+		for _, pc := range cfg.SSOProviders {
+			var domains []string
+			for _, d := range strings.Split(pc.AllowedDomains, ",") {
+				if d = strings.TrimSpace(d); d != "" {
+					domains = append(domains, d)
+				}
+			}
+			p, err := sso.New(sso.Config{
+				Name:           pc.Name,
+				Kind:           pc.Kind,
+				Issuer:         pc.Issuer,
+				ClientID:       pc.ClientID,
+				ClientSecret:   pc.ClientSecret,
+				Scopes:         strings.Fields(pc.Scopes),
+				RedirectURL:    cfg.BaseURL + "/auth/sso/" + pc.Name + "/callback",
+				AllowedDomains: domains,
+			})
+			if err != nil {
+				logger.Error("invalid SSO provider", "error", err)
+				os.Exit(1)
+			}
+			providers = append(providers, p)
+		}
+		authSvc.SetSSOProviders(providers)
+		logger.Info("single sign-on enabled", "providers", len(providers))
+	}
 
 	// Billing service (only if Stripe is configured)
 	var billingSvc *service.BillingService
 	if cfg.StripeSecretKey != "" {
 		billingSvc = service.NewBillingService(
-			db, instanceSvc,
+			db, instanceSvc, jobSvc,
 			cfg.StripeSecretKey, cfg.StripeWebhookSecret,
 			cfg.StripePriceStarter, cfg.StripePricePro,
 			cfg.FrontendURL, logger,
@@ -143,14 +391,41 @@ func main() {
 
 	actSvc.Start()
 
+	// Reconciler (only for providers that can enumerate their resources)
+	var reconcileSvc *service.ReconcileService
+	if inv, ok := prov.(provider.Inventory); ok {
+		reconcileInterval, err := time.ParseDuration(cfg.ReconcileInterval)
+		if err != nil {
+			reconcileInterval = 10 * time.Minute
+		}
+		reconcileSvc = service.NewReconcileService(db, inv, cfg.Provider, logger,
+			reconcileInterval, cfg.ReconcileDryRun, cfg.ReconcileGCOrphans)
+		reconcileSvc.Start()
+	}
+
 	// Router
 	conversationSvc := service.NewConversationService(db)
+	templateSvc := service.NewTemplateService(db)
+	tokenSvc := service.NewTokenService(db)
+	tokenSvc.SetAuditService(auditSvc)
+	orgSvc := service.NewOrgService(db)
+	adminSvc := service.NewAdminService(db, jobSvc, authSvc, auditSvc)
 
 	svcs := &api.Services{
 		Instance:     instanceSvc,
+		Jobs:         jobSvc,
+		Backups:      backupSvc,
+		Recordings:   recordingSvc,
+		Templates:    templateSvc,
+		Secrets:      secretSvc,
+		Tokens:       tokenSvc,
+		Orgs:         orgSvc,
 		Auth:         authSvc,
 		Billing:      billingSvc,
 		Conversation: conversationSvc,
+		Admin:        adminSvc,
+		Audit:        auditSvc,
+		Keys:         jwtKeys,
 		DB:           sqlDB,
 		Version:      version,
 		Logger:       logger,
@@ -182,6 +457,19 @@ func main() {
 	logger.Info("shutting down")
 
 	actSvc.Stop()
+	if backupSvc != nil {
+		backupSvc.Stop()
+	}
+	if recordingSvc != nil {
+		recordingSvc.Stop()
+	}
+	jobSvc.Stop()
+	if warmPool != nil {
+		warmPool.Stop()
+	}
+	if reconcileSvc != nil {
+		reconcileSvc.Stop()
+	}
 	if cronSvc != nil {
 		cronSvc.Stop()
 	}
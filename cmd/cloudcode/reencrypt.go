@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/logan/cloudcode/internal/ent"
+	"github.com/logan/cloudcode/internal/service"
+	"github.com/logan/cloudcode/internal/vault"
+)
+
+// reencrypt implements "cloudcode reencrypt": it moves everything still
+// encrypted under an old master key, or not encrypted at all, to the primary
+// key, after which the old key can be dropped from the keyring. It runs
+// alongside serving replicas.
+func reencrypt(ctx context.Context, db *ent.Client, keyring *vault.Keyring, logger *slog.Logger) error {
+	if keyring == nil {
+		return errors.New("no master keys configured; set SECRETS_MASTER_KEYS or SECRETS_MASTER_KEYS_FILE")
+	}
+	columns, err := service.ReencryptColumns(ctx, db)
+	if err != nil {
+		return fmt.Errorf("columns: %w", err)
+	}
+	secrets, err := service.NewSecretService(db, keyring, logger).Rewrap(ctx)
+	if err != nil {
+		return fmt.Errorf("secrets: %w", err)
+	}
+	logger.Info("reencrypted under the primary master key",
+		"key_id", keyring.PrimaryID(), "column_values", columns, "secrets", secrets)
+	return nil
+}
@@ -5,5 +5,8 @@ set -e
 # button, which triggers Claude Code's OAuth flow in a background Zellij tab.
 # Credentials persist in ~/.claude/.credentials.json on the volume across restarts.
 
+# Clone the template's repos and run its setup script on first boot
+/opt/cloudcode/apply-template.sh
+
 # Delegate all process management to the supervisor
 exec /supervisor.sh
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// AdminHandler serves the operator API under /admin. Every route requires
+// the admin API key.
+type AdminHandler struct {
+	admin *service.AdminService
+}
+
+// NewAdminHandler creates a new AdminHandler.
+func NewAdminHandler(admin *service.AdminService) *AdminHandler {
+	return &AdminHandler{admin: admin}
+}
+
+// ListUsers handles GET /admin/users?email=&plan=&subscription_status=&limit=&cursor=.
+func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	page, ok := pageRequest(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	users, err := h.admin.ListUsers(r.Context(), service.UserFilter{
+		Email:              q.Get("email"),
+		Plan:               q.Get("plan"),
+		SubscriptionStatus: q.Get("subscription_status"),
+	}, page)
+	if err != nil {
+		handleAdminError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, users)
+}
+
+// GetUser handles GET /admin/users/{id}.
+func (h *AdminHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	u, err := h.admin.GetUser(r.Context(), id)
+	if err != nil {
+		handleAdminError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, u)
+}
+
+type setPlanRequest struct {
+	Plan string `json:"plan"`
+}
+
+// SetPlan handles PUT /admin/users/{id}/plan.
+func (h *AdminHandler) SetPlan(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	var req setPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	u, err := h.admin.SetPlan(r.Context(), id, req.Plan)
+	if err != nil {
+		handleAdminError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, u)
+}
+
+// Impersonate handles POST /admin/users/{id}/impersonate. The returned
+// access token works like the user's own and cannot be refreshed.
+func (h *AdminHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	tokens, err := h.admin.Impersonate(r.Context(), id)
+	if err != nil {
+		handleAdminError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{
+		"access_token": tokens.AccessToken,
+		"token_type":   "Bearer",
+		"expires_in":   tokens.ExpiresIn,
+	})
+}
+
+// DestroyUserInstances handles DELETE /admin/users/{id}/instances.
+func (h *AdminHandler) DestroyUserInstances(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	result, err := h.admin.DestroyUserInstances(r.Context(), id)
+	if err != nil {
+		handleAdminError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusAccepted, result)
+}
+
+// ListInstances handles GET /admin/instances?user_id=&status=&provider=&limit=&cursor=.
+func (h *AdminHandler) ListInstances(w http.ResponseWriter, r *http.Request) {
+	page, ok := pageRequest(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	filter := service.InstanceFilter{Status: q.Get("status"), Provider: q.Get("provider")}
+	if v := q.Get("user_id"); v != "" {
+		id, err := service.ParseID(v)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid user_id")
+			return
+		}
+		filter.UserID = id
+	}
+	instances, err := h.admin.ListInstances(r.Context(), filter, page)
+	if err != nil {
+		handleAdminError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, instances)
+}
+
+// PauseInstance handles POST /admin/instances/{id}/pause.
+func (h *AdminHandler) PauseInstance(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid instance ID")
+		return
+	}
+	job, err := h.admin.PauseInstance(r.Context(), id)
+	if err != nil {
+		handleAdminError(w, err)
+		return
+	}
+	writeAccepted(w, job)
+}
+
+// DestroyInstance handles DELETE /admin/instances/{id}.
+func (h *AdminHandler) DestroyInstance(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid instance ID")
+		return
+	}
+	job, err := h.admin.DestroyInstance(r.Context(), id)
+	if err != nil {
+		handleAdminError(w, err)
+		return
+	}
+	writeAccepted(w, job)
+}
+
+type pauseIdleRequest struct {
+	IdleFor string `json:"idle_for"` // Go duration, e.g. "2h"
+}
+
+// PauseIdle handles POST /admin/instances/pause-idle.
+func (h *AdminHandler) PauseIdle(w http.ResponseWriter, r *http.Request) {
+	var req pauseIdleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	idleFor, err := time.ParseDuration(req.IdleFor)
+	if err != nil || idleFor < time.Minute {
+		response.Error(w, http.StatusBadRequest, "idle_for must be a duration of at least 1m")
+		return
+	}
+	result, err := h.admin.PauseIdle(r.Context(), idleFor)
+	if err != nil {
+		handleAdminError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusAccepted, result)
+}
+
+// Usage handles GET /admin/usage.
+func (h *AdminHandler) Usage(w http.ResponseWriter, r *http.Request) {
+	report, err := h.admin.Usage(r.Context())
+	if err != nil {
+		handleAdminError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, report)
+}
+
+// pageRequest reads the limit and cursor query parameters.
+func pageRequest(w http.ResponseWriter, r *http.Request) (service.PageRequest, bool) {
+	page := service.PageRequest{Cursor: r.URL.Query().Get("cursor")}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			response.Error(w, http.StatusBadRequest, "invalid limit")
+			return page, false
+		}
+		page.Limit = n
+	}
+	return page, true
+}
+
+func handleAdminError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		response.Error(w, http.StatusNotFound, "user not found")
+	case errors.Is(err, service.ErrInvalidPlan):
+		response.Error(w, http.StatusBadRequest, "invalid plan")
+	case errors.Is(err, service.ErrInvalidCursor):
+		response.Error(w, http.StatusBadRequest, "invalid cursor")
+	default:
+		handleServiceError(w, err)
+	}
+}
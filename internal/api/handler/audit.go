@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/logan/cloudcode/internal/api/middleware"
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// AuditHandler serves the audit log: all of it to the admin, and to each
+// user the events that concern them.
+type AuditHandler struct {
+	audit *service.AuditService
+}
+
+// NewAuditHandler creates a new AuditHandler.
+func NewAuditHandler(audit *service.AuditService) *AuditHandler {
+	return &AuditHandler{audit: audit}
+}
+
+// List handles GET /admin/audit?actor=&actor_id=&action=&target_type=&target_id=&subject_id=&since=&until=&limit=&cursor=.
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	filter, ok := auditFilter(w, r, true)
+	if !ok {
+		return
+	}
+	h.list(w, r, filter)
+}
+
+// Export handles GET /admin/audit/export, taking the same filters as List.
+func (h *AuditHandler) Export(w http.ResponseWriter, r *http.Request) {
+	filter, ok := auditFilter(w, r, true)
+	if !ok {
+		return
+	}
+	h.export(w, r, filter)
+}
+
+// Verify handles GET /admin/audit/verify.
+func (h *AuditHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	result, err := h.audit.Verify(r.Context())
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, result)
+}
+
+// Mine handles GET /auth/audit — the caller's own events, with List's
+// filters other than actor and subject.
+func (h *AuditHandler) Mine(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.userFilter(w, r)
+	if !ok {
+		return
+	}
+	h.list(w, r, filter)
+}
+
+// ExportMine handles GET /auth/audit/export.
+func (h *AuditHandler) ExportMine(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.userFilter(w, r)
+	if !ok {
+		return
+	}
+	h.export(w, r, filter)
+}
+
+func (h *AuditHandler) userFilter(w http.ResponseWriter, r *http.Request) (service.AuditFilter, bool) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == 0 {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return service.AuditFilter{}, false
+	}
+	filter, ok := auditFilter(w, r, false)
+	filter.SubjectID = userID
+	return filter, ok
+}
+
+func (h *AuditHandler) list(w http.ResponseWriter, r *http.Request, filter service.AuditFilter) {
+	page, ok := pageRequest(w, r)
+	if !ok {
+		return
+	}
+	events, err := h.audit.List(r.Context(), filter, page)
+	if err != nil {
+		handleAdminError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, events)
+}
+
+// export streams events as JSON Lines. Once the first line is out an error
+// can only cut the stream short.
+func (h *AuditHandler) export(w http.ResponseWriter, r *http.Request, filter service.AuditFilter) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", `attachment; filename="audit.jsonl"`)
+	if err := h.audit.Export(r.Context(), filter, w); err != nil {
+		slog.Error("audit export failed", "error", err)
+	}
+}
+
+// auditFilter reads the audit query parameters. Actor and subject filters
+// are read only for the admin.
+func auditFilter(w http.ResponseWriter, r *http.Request, admin bool) (service.AuditFilter, bool) {
+	q := r.URL.Query()
+	filter := service.AuditFilter{
+		Action:     q.Get("action"),
+		TargetType: q.Get("target_type"),
+		TargetID:   q.Get("target_id"),
+	}
+	for name, dst := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if v := q.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid "+name+": use RFC 3339")
+				return filter, false
+			}
+			*dst = t
+		}
+	}
+	if !admin {
+		return filter, true
+	}
+	filter.Actor = q.Get("actor")
+	for name, dst := range map[string]*int{"actor_id": &filter.ActorID, "subject_id": &filter.SubjectID} {
+		if v := q.Get(name); v != "" {
+			id, err := service.ParseID(v)
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid "+name)
+				return filter, false
+			}
+			*dst = id
+		}
+	}
+	return filter, true
+}
@@ -1,9 +1,16 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+
 	"github.com/logan/cloudcode/internal/api/middleware"
 	"github.com/logan/cloudcode/internal/api/response"
 	"github.com/logan/cloudcode/internal/service"
@@ -23,6 +30,9 @@ func NewAuthHandler(auth *service.AuthService, frontendURL string, devMode bool)
 
 type loginRequest struct {
 	Email string `json:"email"`
+	// BindBrowser makes the link work only in the browser that asked for
+	// it. The CLI leaves it unset, since its links are opened elsewhere.
+	BindBrowser bool `json:"bind_browser"`
 }
 
 // Login handles POST /auth/login.
@@ -39,19 +49,19 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 	// Dev mode: skip email, issue session token directly
 	if h.devMode {
-		token, err := h.auth.DevLogin(r.Context(), w, req.Email)
+		tokens, err := h.auth.DevLogin(clientContext(r), w, req.Email)
 		if err != nil {
 			response.Error(w, http.StatusInternalServerError, "failed to login")
 			return
 		}
 		response.JSON(w, http.StatusOK, map[string]string{
 			"message": "dev login successful",
-			"token":   token,
+			"token":   tokens.AccessToken,
 		})
 		return
 	}
 
-	if err := h.auth.SendMagicLink(r.Context(), req.Email); err != nil {
+	if err := h.auth.SendMagicLink(r.Context(), w, req.Email, req.BindBrowser); err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to send magic link")
 		return
 	}
@@ -69,16 +79,30 @@ func (h *AuthHandler) Verify(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sessionToken, err := h.auth.VerifyMagicLink(r.Context(), w, token)
+	var nonce string
+	if c, err := r.Cookie(service.MagicLinkNonceCookie); err == nil {
+		nonce = c.Value
+	}
+	tokens, err := h.auth.VerifyMagicLink(clientContext(r), w, token, nonce)
 	if err != nil {
-		response.Error(w, http.StatusUnauthorized, "invalid or expired token")
+		switch {
+		case errors.Is(err, service.ErrMagicLinkThrottled):
+			response.Error(w, http.StatusTooManyRequests, "too many failed sign-in attempts, try again later")
+		case errors.Is(err, service.ErrMagicLinkInvalid):
+			response.Error(w, http.StatusUnauthorized, "invalid or expired token")
+		default:
+			slog.Error("magic link verification failed", "error", err)
+			response.Error(w, http.StatusInternalServerError, "failed to verify token")
+		}
 		return
 	}
 
-	// If Accept header wants JSON, return token
+	// If Accept header wants JSON, return the tokens (CLI login)
 	if r.Header.Get("Accept") == "application/json" {
-		response.JSON(w, http.StatusOK, map[string]string{
-			"token": sessionToken,
+		response.JSON(w, http.StatusOK, map[string]any{
+			"token":         tokens.AccessToken,
+			"refresh_token": tokens.RefreshToken,
+			"expires_in":    tokens.ExpiresIn,
 		})
 		return
 	}
@@ -104,9 +128,163 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, user)
 }
 
+// Refresh handles POST /auth/refresh — exchanges a refresh token for a new
+// access token and a new refresh token. Browsers send the refresh cookie and
+// get new cookies; other clients send refresh_token as a form or JSON field
+// and get both tokens back in the body.
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var tokens *service.SessionTokens
+	var err error
+	fromCookie := false
+	if c, cerr := r.Cookie(service.RefreshCookie); cerr == nil && c.Value != "" {
+		fromCookie = true
+		tokens, err = h.auth.RefreshSession(clientContext(r), w, c.Value)
+	} else {
+		params, perr := formOrJSON(r, "refresh_token")
+		if perr != nil || params["refresh_token"] == "" {
+			response.Error(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		tokens, err = h.auth.RefreshSession(clientContext(r), nil, params["refresh_token"])
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrRefreshRaced) {
+			// The concurrent refresh has set new cookies, or handed the
+			// client new tokens; these must stay
+			response.Error(w, http.StatusConflict, "refresh_in_progress")
+			return
+		}
+		if errors.Is(err, service.ErrRefreshInvalid) {
+			if fromCookie {
+				service.ClearSessionCookies(w)
+			}
+			response.Error(w, http.StatusUnauthorized, "invalid_grant")
+			return
+		}
+		slog.Error("session refresh failed", "error", err)
+		response.Error(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+
+	body := map[string]any{
+		"access_token": tokens.AccessToken,
+		"token_type":   "Bearer",
+		"expires_in":   tokens.ExpiresIn,
+	}
+	if !fromCookie {
+		body["refresh_token"] = tokens.RefreshToken
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	response.JSON(w, http.StatusOK, body)
+}
+
+// Logout handles POST /auth/logout — ends the session whose refresh token
+// is in the refresh cookie or the refresh_token field, and clears the
+// session cookies. It works without a valid access token.
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var refreshToken string
+	if c, err := r.Cookie(service.RefreshCookie); err == nil {
+		refreshToken = c.Value
+	} else if params, err := formOrJSON(r, "refresh_token"); err == nil {
+		refreshToken = params["refresh_token"]
+	}
+
+	if err := h.auth.Logout(clientContext(r), w, refreshToken); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"status": "logged out"})
+}
+
+// Sessions handles GET /auth/sessions — the caller's signed-in devices.
+func (h *AuthHandler) Sessions(w http.ResponseWriter, r *http.Request) {
+	userID := sessionUserID(w, r)
+	if userID == 0 {
+		return
+	}
+
+	sessions, err := h.auth.ListSessions(r.Context(), userID, middleware.SessionIDFromContext(r.Context()))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, sessions)
+}
+
+// RevokeSession handles DELETE /auth/sessions/{id} — signs out a device.
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID := sessionUserID(w, r)
+	if userID == 0 {
+		return
+	}
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid session ID")
+		return
+	}
+
+	if err := h.auth.RevokeSession(r.Context(), userID, id); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"status": "revoked"})
+}
+
+// RevokeOtherSessions handles DELETE /auth/sessions — signs out every device
+// but the caller's.
+func (h *AuthHandler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	userID := sessionUserID(w, r)
+	if userID == 0 {
+		return
+	}
+
+	n, err := h.auth.RevokeOtherSessions(r.Context(), userID, middleware.SessionIDFromContext(r.Context()))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{"status": "revoked", "revoked": n})
+}
+
+// clientContext returns the request context carrying the client's address,
+// user agent, request ID and, once authenticated, who is acting, to be
+// recorded on sessions and audit events.
+func clientContext(r *http.Request) context.Context {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	ctx := service.WithClientInfo(r.Context(), ip, r.UserAgent())
+	ctx = service.WithRequestID(ctx, chimiddleware.GetReqID(r.Context()))
+	switch {
+	case middleware.IsAdminContext(ctx):
+		ctx = service.WithActor(ctx, service.ActorAdmin, 0)
+	case middleware.ImpersonatedFromContext(ctx):
+		ctx = service.WithImpersonation(ctx, middleware.UserIDFromContext(ctx))
+	case middleware.UserIDFromContext(ctx) != 0:
+		ctx = service.WithActor(ctx, service.ActorUser, middleware.UserIDFromContext(ctx))
+	}
+	return ctx
+}
+
+// ClientContext is middleware that gives every request the context from
+// clientContext, so services can audit without each handler asking.
+func ClientContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(clientContext(r)))
+	})
+}
+
 type updateSettingsRequest struct {
 	AnthropicAPIKey  *string `json:"anthropic_api_key"`
 	ClaudeOAuthToken *string `json:"claude_oauth_token"`
+	// "off", "output" or "full"; the user is told in the terminal when
+	// it is being recorded
+	TerminalRecording *string `json:"terminal_recording"`
 }
 
 // GetSettings handles GET /auth/settings.
@@ -126,13 +304,17 @@ func (h *AuthHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, settings)
 }
 
-// UpdateSettings handles PUT /auth/settings.
+// UpdateSettings handles PUT /auth/settings. Support sessions cannot use it,
+// since the settings hold the user's Claude credentials and recording mode.
 func (h *AuthHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.UserIDFromContext(r.Context())
 	if userID == 0 {
 		response.Error(w, http.StatusUnauthorized, "not authenticated")
 		return
 	}
+	if impersonated(w, r) {
+		return
+	}
 
 	var req updateSettingsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -140,7 +322,12 @@ func (h *AuthHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.auth.UpdateSettings(r.Context(), userID, req.AnthropicAPIKey, req.ClaudeOAuthToken); err != nil {
+	err := h.auth.UpdateSettings(r.Context(), userID, req.AnthropicAPIKey, req.ClaudeOAuthToken, req.TerminalRecording)
+	if errors.Is(err, service.ErrInvalidRecordingMode) {
+		response.Error(w, http.StatusBadRequest, "terminal_recording must be off, output or full")
+		return
+	}
+	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to update settings")
 		return
 	}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/logan/cloudcode/internal/api/middleware"
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// BackupHandler serves volume backups and restores for an instance.
+// Backups and restores are queued as jobs.
+type BackupHandler struct {
+	backups *service.BackupService
+	jobs    *service.JobService
+}
+
+// NewBackupHandler creates a new BackupHandler.
+func NewBackupHandler(backups *service.BackupService, jobs *service.JobService) *BackupHandler {
+	return &BackupHandler{backups: backups, jobs: jobs}
+}
+
+// List handles GET /instances/{id}/backups.
+// Users can only list backups of their own instances; admin can list any.
+func (h *BackupHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid instance ID")
+		return
+	}
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	backups, err := h.backups.List(r.Context(), id, userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, backups)
+}
+
+// Create handles POST /instances/{id}/backups.
+func (h *BackupHandler) Create(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid instance ID")
+		return
+	}
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.jobs.EnqueueBackup(r.Context(), id, userID, service.BackupManual)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	writeAccepted(w, job)
+}
+
+// Restore handles POST /instances/{id}/backups/{backupID}/restore.
+// The instance must be stopped; the job restores the volume and then wakes it.
+func (h *BackupHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid instance ID")
+		return
+	}
+	backupID, err := service.ParseID(chi.URLParam(r, "backupID"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid backup ID")
+		return
+	}
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.jobs.EnqueueRestore(r.Context(), id, userID, backupID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	writeAccepted(w, job)
+}
+
+// callerID returns the authenticated user ID, or 0 for admin (X-API-Key)
+// callers. Writes 401 and returns false if neither is present.
+func callerID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == 0 && !middleware.IsAdminContext(r.Context()) {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return 0, false
+	}
+	return userID, true
+}
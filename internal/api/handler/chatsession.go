@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// chatSessions keeps agent chat connections open for a grace period after
+// their client drops, so it can resume where it left off.
+type chatSessions struct {
+	grace time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*chatSession // by resume token
+}
+
+func newChatSessions(grace time.Duration) *chatSessions {
+	return &chatSessions{grace: grace, sessions: make(map[string]*chatSession)}
+}
+
+// chatSession is one agent chat connection and the client using it.
+type chatSession struct {
+	sessions   *chatSessions
+	token      string
+	instanceID int
+	userID     int
+	readOnly   bool
+	backend    *websocket.Conn
+	writeMu    sync.Mutex // one writer at a time on backend
+
+	mu     sync.Mutex
+	ring   *frameRing
+	conn   *clientConn // nil while the client is away
+	away   *time.Timer // closes the session when the grace period ends
+	closed bool
+}
+
+// start begins a session on backend for the caller and returns its first
+// connection.
+func (cs *chatSessions) start(instanceID, userID int, readOnly bool, backend *websocket.Conn) (*chatSession, *clientConn) {
+	s := &chatSession{
+		sessions:   cs,
+		token:      newResumeToken(),
+		instanceID: instanceID,
+		userID:     userID,
+		readOnly:   readOnly,
+		backend:    backend,
+		ring:       newFrameRing(replayBytes),
+		conn:       newClientConn(),
+	}
+	s.conn.backlog = []wsMessage{resumeMessage(s.token, 0, false, 0)}
+
+	cs.mu.Lock()
+	cs.sessions[s.token] = s
+	cs.mu.Unlock()
+	go s.run()
+	return s, s.conn
+}
+
+// resume gives the session holding token a new connection, which starts
+// with the frames after after. It returns nil unless the session is the
+// caller's on the instance.
+func (cs *chatSessions) resume(token string, instanceID, userID int, after uint64) (*chatSession, *clientConn) {
+	cs.mu.Lock()
+	s := cs.sessions[token]
+	cs.mu.Unlock()
+	if s == nil || s.instanceID != instanceID || s.userID != userID {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, nil
+	}
+	if s.conn != nil {
+		s.conn.end(websocket.CloseNormalClosure, "resumed on another connection")
+	}
+	if s.away != nil {
+		s.away.Stop()
+		s.away = nil
+	}
+	s.conn = newClientConn()
+	frames, missed := s.ring.since(after)
+	s.conn.backlog = append([]wsMessage{resumeMessage(s.token, s.ring.last-uint64(len(frames)), true, missed)}, frames...)
+	return s, s.conn
+}
+
+// run reads the backend until it closes, passing every frame to the client.
+func (s *chatSession) run() {
+	for {
+		msgType, msg, err := s.backend.ReadMessage()
+		if err != nil {
+			slog.Debug("chat proxy: backend read error", "instance_id", s.instanceID, "error", err)
+			s.close(websocket.CloseGoingAway, "chat closed")
+			return
+		}
+
+		m := wsMessage{msgType, msg}
+		s.mu.Lock()
+		s.ring.push(m)
+		if s.conn != nil && !s.conn.queue(m) {
+			// Too far behind; the client may resume from the ring
+			s.conn.end(websocket.CloseTryAgainLater, "too slow to keep up")
+			s.awayLocked()
+		}
+		s.mu.Unlock()
+	}
+}
+
+// fromClient forwards a message from the client on cc to the agent.
+// Read-only viewers follow the conversation but cannot prompt.
+func (s *chatSession) fromClient(cc *clientConn, msgType int, msg []byte) {
+	s.mu.Lock()
+	current := s.conn == cc
+	s.mu.Unlock()
+	if !current || s.readOnly {
+		return
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if err := s.backend.WriteMessage(msgType, msg); err != nil {
+		slog.Debug("chat proxy: backend write error", "instance_id", s.instanceID, "error", err)
+	}
+}
+
+// release ends the connection cc. If it dropped rather than being closed,
+// the session waits for the client for the grace period.
+func (s *chatSession) release(cc *clientConn, resumable bool) {
+	s.mu.Lock()
+	if s.conn != cc {
+		// Resumed on another connection, or ended by the session
+		s.mu.Unlock()
+		return
+	}
+	cc.end(0, "")
+	s.conn = nil
+	if resumable && s.sessions.grace > 0 {
+		s.awayLocked()
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+	s.close(websocket.CloseNormalClosure, "")
+}
+
+// awayLocked starts the grace period of a client whose connection has
+// ended.
+func (s *chatSession) awayLocked() {
+	s.conn = nil
+	s.away = time.AfterFunc(s.sessions.grace, func() {
+		s.mu.Lock()
+		away := s.conn == nil
+		s.mu.Unlock()
+		if away {
+			s.close(websocket.CloseNormalClosure, "")
+		}
+	})
+}
+
+// close ends the client's connection, if any, and the backend.
+func (s *chatSession) close(code int, reason string) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	if s.conn != nil {
+		s.conn.end(code, reason)
+		s.conn = nil
+	}
+	if s.away != nil {
+		s.away.Stop()
+		s.away = nil
+	}
+	s.mu.Unlock()
+
+	s.sessions.mu.Lock()
+	delete(s.sessions.sessions, s.token)
+	s.sessions.mu.Unlock()
+	s.backend.Close()
+}
@@ -1,36 +1,73 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/logan/cloudcode/internal/api/middleware"
 	"github.com/logan/cloudcode/internal/auth"
+	"github.com/logan/cloudcode/internal/provider"
 	"github.com/logan/cloudcode/internal/service"
 )
 
 // ConnectHandler serves the connect script endpoint.
 type ConnectHandler struct {
-	svc       *service.InstanceService
-	jwtSecret string
+	svc      *service.InstanceService
+	keys     *auth.Keyring
+	tokens   middleware.TokenAuthenticator // nil disables access tokens
+	sessions middleware.SessionChecker     // nil skips the revocation check
+	jobs     *service.JobService           // nil leaves stopped instances asleep
+	devMode  bool                          // accept ?user_id without credentials
 }
 
 // NewConnectHandler creates a new ConnectHandler.
-func NewConnectHandler(svc *service.InstanceService, jwtSecret string) *ConnectHandler {
-	return &ConnectHandler{svc: svc, jwtSecret: jwtSecret}
+func NewConnectHandler(svc *service.InstanceService, keys *auth.Keyring, tokens middleware.TokenAuthenticator, sessions middleware.SessionChecker) *ConnectHandler {
+	return &ConnectHandler{svc: svc, keys: keys, tokens: tokens, sessions: sessions}
+}
+
+// SetJobService lets the connect script wake a stopped instance.
+func (h *ConnectHandler) SetJobService(jobs *service.JobService) {
+	h.jobs = jobs
+}
+
+// SetDevMode accepts ?user_id in place of credentials, for local
+// development. Such requests only get scripts for running instances; they
+// never wake one.
+func (h *ConnectHandler) SetDevMode(dev bool) {
+	h.devMode = dev
 }
 
 // ServeScript handles GET /connect.sh.
-// Supports Bearer JWT, session cookie, or ?user_id parameter.
-// Returns a shell script that connects to the user's running instance.
+// Supports Bearer JWT or access token, session cookie, or in dev mode a
+// ?user_id parameter. Returns a shell script that connects to one of the
+// user's running instances, selected by ?instance=<name or ID> (default
+// instance if omitted). A stopped instance is woken for callers with
+// credentials, and until it is ready the script waits and asks to be
+// fetched again.
 func (h *ConnectHandler) ServeScript(w http.ResponseWriter, r *http.Request) {
 	var userID int
 
-	// Try Bearer JWT auth
+	// Try Bearer JWT auth. Credentials that do not check out are refused
+	// rather than passed over for the next kind.
 	if authHeader := r.Header.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
 		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-		if claims, err := auth.ValidateToken(h.jwtSecret, tokenStr); err == nil && claims.Purpose == "session" {
+		if h.tokens != nil && auth.IsAccessToken(tokenStr) {
+			// A terminal is full access, so the token needs instances:write
+			id, _, scopes, err := h.tokens.AuthenticateToken(r.Context(), tokenStr)
+			if err != nil || !auth.HasScope(scopes, auth.ScopeInstances, true) {
+				writeErrorScript(w, http.StatusUnauthorized, "invalid token or missing scope instances:write")
+				return
+			}
+			userID = id
+		} else {
+			claims, err := middleware.ValidateSessionToken(r.Context(), h.keys, h.sessions, tokenStr)
+			if err != nil {
+				writeErrorScript(w, http.StatusUnauthorized, "invalid or expired session")
+				return
+			}
 			userID = claims.UserID
 		}
 	}
@@ -38,14 +75,22 @@ func (h *ConnectHandler) ServeScript(w http.ResponseWriter, r *http.Request) {
 	// Try session cookie
 	if userID == 0 {
 		if cookie, err := r.Cookie("session"); err == nil {
-			if claims, err := auth.ValidateToken(h.jwtSecret, cookie.Value); err == nil && claims.Purpose == "session" {
-				userID = claims.UserID
+			claims, err := middleware.ValidateSessionToken(r.Context(), h.keys, h.sessions, cookie.Value)
+			if err != nil {
+				writeErrorScript(w, http.StatusUnauthorized, "invalid or expired session")
+				return
 			}
+			userID = claims.UserID
 		}
 	}
+	authenticated := userID != 0
 
-	// Fall back to ?user_id parameter
+	// Fall back to ?user_id parameter in dev mode
 	if userID == 0 {
+		if !h.devMode {
+			writeErrorScript(w, http.StatusUnauthorized, "authentication required")
+			return
+		}
 		userIDStr := r.URL.Query().Get("user_id")
 		if userIDStr == "" {
 			writeErrorScript(w, http.StatusBadRequest, "missing authentication or user_id parameter")
@@ -59,18 +104,43 @@ func (h *ConnectHandler) ServeScript(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	info, err := h.svc.GetConnectInfo(r.Context(), userID)
-	if err != nil {
+	// Only credentials may wake an instance
+	wake := h.jobs != nil && authenticated
+	info, err := h.svc.GetConnectInfo(r.Context(), userID, r.URL.Query().Get("instance"))
+	if err != nil || (info.Status != "running" && !wake) {
 		writeErrorScript(w, http.StatusNotFound, "no running instance found for this user")
 		return
 	}
+	if wake {
+		state, err := h.jobs.PollWake(r.Context(), info.ID, userID)
+		switch {
+		case errors.Is(err, service.ErrWakeTimeout):
+			writeErrorScript(w, http.StatusServiceUnavailable, service.ErrWakeTimeout.Error())
+			return
+		case errors.Is(err, service.ErrWakeFailed):
+			writeErrorScript(w, http.StatusServiceUnavailable, service.ErrWakeFailed.Error())
+			return
+		case err != nil:
+			writeErrorScript(w, http.StatusInternalServerError, "failed to wake instance")
+			return
+		case !state.Ready:
+			w.Header().Set("Content-Type", "text/x-shellscript")
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, wakeScript(info.Name, state.Step))
+			return
+		}
+	}
 
 	var script string
 	switch info.Provider {
 	case "docker", "mock":
-		script = dockerConnectScript(info.UserID)
+		script = dockerConnectScript(provider.InstanceKey(info.UserID, info.Name))
+	case "podman":
+		script = podmanConnectScript(provider.InstanceKey(info.UserID, info.Name))
 	case "hetzner":
 		script = hetznerConnectScript(info)
+	case "kubernetes":
+		script = kubernetesConnectScript(info)
 	default:
 		writeErrorScript(w, http.StatusInternalServerError, "unknown provider")
 		return
@@ -81,13 +151,41 @@ func (h *ConnectHandler) ServeScript(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, script)
 }
 
-func dockerConnectScript(userID int) string {
+func dockerConnectScript(key string) string {
 	return fmt.Sprintf(`#!/bin/bash
 set -e
 
 echo "Connecting to Claude instance (Docker)..."
-exec docker exec -it claude-%d zellij attach claude
-`, userID)
+exec docker exec -it claude-%s zellij attach claude
+`, key)
+}
+
+func podmanConnectScript(key string) string {
+	return fmt.Sprintf(`#!/bin/bash
+set -e
+
+echo "Connecting to Claude instance (Podman)..."
+exec podman exec -it claude-%s zellij attach claude
+`, key)
+}
+
+func kubernetesConnectScript(info *service.ConnectInfo) string {
+	// Host is the headless Service DNS name: {statefulset}.{namespace}.svc
+	namespace := "cloudcode"
+	if parts := strings.Split(info.Host, "."); len(parts) >= 2 {
+		namespace = parts[1]
+	}
+	return fmt.Sprintf(`#!/bin/bash
+set -e
+
+if ! command -v kubectl &>/dev/null; then
+    echo "Error: kubectl is required to connect to Kubernetes instances"
+    exit 1
+fi
+
+echo "Connecting to Claude instance (Kubernetes)..."
+exec kubectl exec -it -n %s %s-0 -c claude -- zellij attach claude
+`, namespace, info.ProviderID)
 }
 
 func hetznerConnectScript(info *service.ConnectInfo) string {
@@ -3,13 +3,16 @@ package handler
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 
+	"github.com/logan/cloudcode/internal/auth"
 	"github.com/logan/cloudcode/internal/ent/enttest"
 	"github.com/logan/cloudcode/internal/provider"
 	"github.com/logan/cloudcode/internal/service"
@@ -22,7 +25,8 @@ func setupConnectTest(t *testing.T) (*ConnectHandler, *service.InstanceService,
 
 	mock := provider.NewMock()
 	svc := service.NewInstanceService(client, mock, "")
-	ch := NewConnectHandler(svc, "test-jwt-secret")
+	ch := NewConnectHandler(svc, auth.NewHMACKeyring("test-jwt-secret"), nil, nil)
+	ch.SetDevMode(true)
 
 	// Create test user
 	u, err := client.User.Create().
@@ -39,7 +43,7 @@ func TestConnectScript_Docker(t *testing.T) {
 	ch, svc, userID := setupConnectTest(t)
 
 	// Create instance
-	_, err := svc.Create(context.Background(), userID)
+	_, err := svc.Create(context.Background(), userID, service.InstanceSpec{})
 	if err != nil {
 		t.Fatalf("create instance: %v", err)
 	}
@@ -64,6 +68,42 @@ func TestConnectScript_Docker(t *testing.T) {
 	}
 }
 
+func TestConnectScript_NamedInstance(t *testing.T) {
+	ch, svc, userID := setupConnectTest(t)
+	svc.SetPlanLimits(map[string]int{"free": 2})
+
+	ctx := context.Background()
+	if _, err := svc.Create(ctx, userID, service.InstanceSpec{}); err != nil {
+		t.Fatalf("create default: %v", err)
+	}
+	if _, err := svc.Create(ctx, userID, service.InstanceSpec{Name: "feature-x"}); err != nil {
+		t.Fatalf("create named: %v", err)
+	}
+
+	for query, container := range map[string]string{
+		"":                    fmt.Sprintf("claude-%d ", userID),
+		"&instance=feature-x": fmt.Sprintf("claude-%d-feature-x ", userID),
+	} {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/connect.sh?user_id=%d%s", userID, query), nil)
+		rec := httptest.NewRecorder()
+		ch.ServeScript(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("%q: expected 200, got %d", query, rec.Code)
+		}
+		if body := rec.Body.String(); !strings.Contains(body, "docker exec -it "+container) {
+			t.Errorf("%q: expected container %q in script, got:\n%s", query, container, body)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/connect.sh?user_id=%d&instance=missing", userID), nil)
+	rec := httptest.NewRecorder()
+	ch.ServeScript(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown instance: expected 404, got %d", rec.Code)
+	}
+}
+
 func TestConnectScript_MissingUserID(t *testing.T) {
 	ch, _, _ := setupConnectTest(t)
 
@@ -103,3 +143,97 @@ func TestConnectScript_InvalidUserID(t *testing.T) {
 		t.Errorf("expected 400, got %d", rec.Code)
 	}
 }
+
+// revokedSessions reports every session as signed out.
+type revokedSessions struct{}
+
+func (revokedSessions) SessionActive(ctx context.Context, sessionID string) bool { return false }
+
+func TestConnectScript_Credentials(t *testing.T) {
+	ch, svc, userID := setupConnectTest(t)
+	if _, err := svc.Create(context.Background(), userID, service.InstanceSpec{}); err != nil {
+		t.Fatalf("create instance: %v", err)
+	}
+	token, _ := auth.GenerateSessionToken("test-jwt-secret", userID, "connect-test@example.com", "sid", time.Hour)
+	serve := func(bearer string) int {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/connect.sh?user_id=%d", userID), nil)
+		if bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+bearer)
+		}
+		rec := httptest.NewRecorder()
+		ch.ServeScript(rec, req)
+		return rec.Code
+	}
+
+	if code := serve(token); code != http.StatusOK {
+		t.Errorf("session: expected 200, got %d", code)
+	}
+	// A bad or revoked session is refused, not passed over for ?user_id
+	if code := serve("not-a-jwt"); code != http.StatusUnauthorized {
+		t.Errorf("bad token: expected 401, got %d", code)
+	}
+	ch.sessions = revokedSessions{}
+	if code := serve(token); code != http.StatusUnauthorized {
+		t.Errorf("revoked session: expected 401, got %d", code)
+	}
+	// ?user_id alone is for development only
+	ch.SetDevMode(false)
+	if code := serve(""); code != http.StatusUnauthorized {
+		t.Errorf("user_id outside dev mode: expected 401, got %d", code)
+	}
+}
+
+func TestConnectScript_WakesStoppedInstance(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_connect_wake?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	svc := service.NewInstanceService(client, provider.NewMock(), "")
+	ch := NewConnectHandler(svc, auth.NewHMACKeyring("test-jwt-secret"), nil, nil)
+	ch.SetDevMode(true)
+
+	ctx := context.Background()
+	u := client.User.Create().SetEmail("wake@example.com").SaveX(ctx)
+	inst, err := svc.Create(ctx, u.ID, service.InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create instance: %v", err)
+	}
+	if err := svc.Pause(ctx, inst.ID); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	token, _ := auth.GenerateSessionToken("test-jwt-secret", u.ID, u.Email, "sid", time.Hour)
+	serve := func(authenticated bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/connect.sh?user_id=%d", u.ID), nil)
+		if authenticated {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		ch.ServeScript(rec, req)
+		return rec
+	}
+
+	// Without the job queue a stopped instance stays asleep
+	if rec := serve(true); rec.Code != http.StatusNotFound {
+		t.Errorf("no job service: expected 404, got %d", rec.Code)
+	}
+
+	// Nor does ?user_id wake it
+	ch.SetJobService(service.NewJobService(client, svc, slog.Default(), 1, time.Hour))
+	if rec := serve(false); rec.Code != http.StatusNotFound {
+		t.Errorf("user_id: expected 404, got %d", rec.Code)
+	}
+	if n := client.Job.Query().CountX(ctx); n != 0 {
+		t.Errorf("user_id queued %d wake jobs", n)
+	}
+
+	for range 2 {
+		rec := serve(true)
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d", rec.Code)
+		}
+		if body := rec.Body.String(); !strings.Contains(body, "(queued)") || !strings.Contains(body, "exit 75") {
+			t.Errorf("expected wait-and-retry script, got:\n%s", body)
+		}
+	}
+	if n := client.Job.Query().CountX(ctx); n != 1 {
+		t.Errorf("queued %d wake jobs, want 1", n)
+	}
+}
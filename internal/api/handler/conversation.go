@@ -22,8 +22,9 @@ func NewConversationHandler(svc *service.ConversationService) *ConversationHandl
 	return &ConversationHandler{svc: svc}
 }
 
-// GetOrCreate handles GET /conversations?project=<path>
-// Returns existing conversation for this project or creates a new one.
+// GetOrCreate handles GET /conversations?project=<path>&instance=<id>
+// Returns existing conversation for this project or creates a new one. With
+// an instance the conversation is shared with everyone who can open it.
 func (h *ConversationHandler) GetOrCreate(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.UserIDFromContext(r.Context())
 	if userID == 0 {
@@ -32,10 +33,19 @@ func (h *ConversationHandler) GetOrCreate(w http.ResponseWriter, r *http.Request
 	}
 
 	projectPath := r.URL.Query().Get("project")
+	var instanceID int
+	if v := r.URL.Query().Get("instance"); v != "" {
+		id, err := service.ParseID(v)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid instance ID")
+			return
+		}
+		instanceID = id
+	}
 
-	conv, err := h.svc.GetOrCreateByProject(r.Context(), userID, projectPath)
+	conv, err := h.svc.GetOrCreateByProject(r.Context(), userID, instanceID, projectPath)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, err.Error())
+		handleServiceError(w, err)
 		return
 	}
 
@@ -76,7 +86,7 @@ func (h *ConversationHandler) GetMessages(w http.ResponseWriter, r *http.Request
 
 	msgs, err := h.svc.GetMessages(r.Context(), convID, userID)
 	if err != nil {
-		response.Error(w, http.StatusNotFound, err.Error())
+		handleServiceError(w, err)
 		return
 	}
 
@@ -116,7 +126,7 @@ func (h *ConversationHandler) AddMessage(w http.ResponseWriter, r *http.Request)
 
 	msg, err := h.svc.AddMessage(r.Context(), convID, userID, req.Role, req.Content, req.ToolEvents)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, err.Error())
+		handleServiceError(w, err)
 		return
 	}
 
@@ -138,7 +148,7 @@ func (h *ConversationHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.svc.DeleteConversation(r.Context(), convID, userID); err != nil {
-		response.Error(w, http.StatusNotFound, err.Error())
+		handleServiceError(w, err)
 		return
 	}
 
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// deviceGrantType is the grant_type of device access token requests.
+const deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// formOrJSON reads the named parameters from a form-encoded body, as RFC 8628
+// specifies, or from a JSON object for clients that find that easier.
+func formOrJSON(r *http.Request, names ...string) (map[string]string, error) {
+	out := make(map[string]string, len(names))
+	if ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); ct == "application/json" {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			out[name] = body[name]
+		}
+		return out, nil
+	}
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		out[name] = r.PostForm.Get(name)
+	}
+	return out, nil
+}
+
+// DeviceCode handles POST /auth/device/code — starts a device authorization
+// (RFC 8628 section 3.1).
+func (h *AuthHandler) DeviceCode(w http.ResponseWriter, r *http.Request) {
+	params, err := formOrJSON(r, "client_id")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	da, err := h.auth.StartDeviceAuthorization(r.Context(), params["client_id"])
+	if err != nil {
+		slog.Error("device authorization failed", "error", err)
+		response.Error(w, http.StatusInternalServerError, "failed to start device authorization")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, da)
+}
+
+// DeviceToken handles POST /auth/device/token — the device polls here until
+// the user has decided (RFC 8628 section 3.4). Errors use the OAuth error
+// response format.
+func (h *AuthHandler) DeviceToken(w http.ResponseWriter, r *http.Request) {
+	params, err := formOrJSON(r, "grant_type", "device_code")
+	if err != nil || params["device_code"] == "" {
+		response.Error(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+	if params["grant_type"] != deviceGrantType {
+		response.Error(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	tokens, err := h.auth.PollDeviceToken(clientContext(r), params["device_code"])
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrAuthorizationPending),
+		errors.Is(err, service.ErrSlowDown),
+		errors.Is(err, service.ErrAccessDenied),
+		errors.Is(err, service.ErrExpiredToken),
+		errors.Is(err, service.ErrInvalidGrant):
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	default:
+		slog.Error("device token poll failed", "error", err)
+		response.Error(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	response.JSON(w, http.StatusOK, map[string]any{
+		"access_token":  tokens.AccessToken,
+		"token_type":    "Bearer",
+		"expires_in":    tokens.ExpiresIn,
+		"refresh_token": tokens.RefreshToken,
+	})
+}
+
+// DeviceInfo handles GET /auth/device?user_code={code} — shows the signed-in
+// user which device they are about to approve.
+func (h *AuthHandler) DeviceInfo(w http.ResponseWriter, r *http.Request) {
+	if sessionUserID(w, r) == 0 {
+		return
+	}
+
+	info, err := h.auth.LookupDevice(r.Context(), r.URL.Query().Get("user_code"))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, info)
+}
+
+type deviceDecisionRequest struct {
+	UserCode string `json:"user_code"`
+	Approve  bool   `json:"approve"`
+}
+
+// DeviceDecide handles POST /auth/device/approve — approves or denies a
+// device for the signed-in user.
+func (h *AuthHandler) DeviceDecide(w http.ResponseWriter, r *http.Request) {
+	userID := sessionUserID(w, r)
+	if userID == 0 {
+		return
+	}
+
+	var req deviceDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.auth.DecideDevice(r.Context(), userID, req.UserCode, req.Approve); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	status := "denied"
+	if req.Approve {
+		status = "approved"
+	}
+	response.JSON(w, http.StatusOK, map[string]string{"status": status})
+}
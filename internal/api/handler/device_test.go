@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/api/middleware"
+	"github.com/logan/cloudcode/internal/auth"
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+func TestDeviceEndpoints(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_device_handler?mode=memory&_fk=1")
+	defer client.Close()
+	authSvc := service.NewAuthService(client, "test-jwt-secret", "http://api", "http://app", service.NewLogMailer(slog.Default()))
+	h := NewAuthHandler(authSvc, "http://app", false)
+
+	post := func(handler http.HandlerFunc, form url.Values) (int, map[string]any) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		var body map[string]any
+		json.NewDecoder(rec.Body).Decode(&body)
+		return rec.Code, body
+	}
+
+	code, body := post(h.DeviceCode, url.Values{"client_id": {"claude-cloud"}})
+	if code != http.StatusOK || body["device_code"] == "" || body["user_code"] == "" {
+		t.Fatalf("device code: %d %v", code, body)
+	}
+	deviceCode, _ := body["device_code"].(string)
+
+	for _, tc := range []struct {
+		form url.Values
+		want string
+	}{
+		{url.Values{"grant_type": {"password"}, "device_code": {deviceCode}}, "unsupported_grant_type"},
+		{url.Values{"grant_type": {deviceGrantType}, "device_code": {deviceCode}}, "authorization_pending"},
+		{url.Values{"grant_type": {deviceGrantType}, "device_code": {"nope"}}, "invalid_grant"},
+	} {
+		code, body := post(h.DeviceToken, tc.form)
+		if code != http.StatusBadRequest || body["error"] != tc.want {
+			t.Errorf("token %v: %d %v, want 400 %s", tc.form, code, body, tc.want)
+		}
+	}
+}
+
+func TestSessionEndpoints(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_session_handler?mode=memory&_fk=1")
+	defer client.Close()
+	authSvc := service.NewAuthService(client, "test-jwt-secret", "http://api", "http://app", service.NewLogMailer(slog.Default()))
+	h := NewAuthHandler(authSvc, "http://app", true)
+
+	post := func(handler http.HandlerFunc, body string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+	refreshCookie := func(rec *httptest.ResponseRecorder) *http.Cookie {
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == service.RefreshCookie {
+				return c
+			}
+		}
+		return nil
+	}
+
+	login := post(h.Login, `{"email":"dev@example.com"}`)
+	first := refreshCookie(login)
+	if login.Code != http.StatusOK || first == nil || first.Path != "/auth/" || !first.HttpOnly {
+		t.Fatalf("login: %d, refresh cookie %v", login.Code, first)
+	}
+
+	// Browsers refresh with the cookie and never see the refresh token
+	rec := post(h.Refresh, "", first)
+	second := refreshCookie(rec)
+	var body map[string]any
+	json.NewDecoder(rec.Body).Decode(&body)
+	if rec.Code != http.StatusOK || second == nil || second.Value == first.Value || body["refresh_token"] != nil {
+		t.Fatalf("refresh: %d %v, cookie %v", rec.Code, body, second)
+	}
+
+	// A second tab refreshing with it at the same time keeps the new cookies
+	rec = post(h.Refresh, "", first)
+	if rec.Code != http.StatusConflict || len(rec.Result().Cookies()) != 0 {
+		t.Errorf("raced refresh: %d, cookies %v", rec.Code, rec.Result().Cookies())
+	}
+
+	// Replaying the old cookie a minute on revokes the session and clears cookies
+	client.Session.Update().SetRotatedAt(time.Now().Add(-time.Minute)).ExecX(t.Context())
+	rec = post(h.Refresh, "", first)
+	if c := refreshCookie(rec); rec.Code != http.StatusUnauthorized || c == nil || c.MaxAge >= 0 {
+		t.Errorf("replayed refresh: %d, cookie %v", rec.Code, c)
+	}
+	if rec := post(h.Refresh, "", second); rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after reuse: %d", rec.Code)
+	}
+
+	// CLIs send the refresh token in the body and get the next one back
+	login = post(h.Login, `{"email":"dev@example.com"}`)
+	rec = post(h.Refresh, `{"refresh_token":"`+refreshCookie(login).Value+`"}`)
+	body = nil
+	json.NewDecoder(rec.Body).Decode(&body)
+	next, _ := body["refresh_token"].(string)
+	if rec.Code != http.StatusOK || next == "" || body["access_token"] == "" {
+		t.Fatalf("body refresh: %d %v", rec.Code, body)
+	}
+	if rec := post(h.Logout, `{"refresh_token":"`+next+`"}`); rec.Code != http.StatusOK {
+		t.Errorf("logout: %d", rec.Code)
+	}
+	if rec := post(h.Refresh, `{"refresh_token":"`+next+`"}`); rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after logout: %d", rec.Code)
+	}
+}
+
+func TestImpersonatedSessionCannotManageCredentials(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_impersonation_handler?mode=memory&_fk=1")
+	defer client.Close()
+	authSvc := service.NewAuthService(client, "test-jwt-secret", "http://api", "http://app", service.NewLogMailer(slog.Default()))
+	h := NewAuthHandler(authSvc, "http://app", false)
+	u := client.User.Create().SetEmail("dev@example.com").SaveX(t.Context())
+
+	support, err := authSvc.Impersonate(t.Context(), u.ID)
+	if err != nil {
+		t.Fatalf("impersonate: %v", err)
+	}
+	own, _ := auth.GenerateToken("test-jwt-secret", u.ID, u.Email, "session", time.Hour)
+	authed := middleware.UserAuth(auth.NewHMACKeyring("test-jwt-secret"), "", nil, nil)
+
+	for _, tc := range []struct {
+		name    string
+		handler http.HandlerFunc
+		body    string
+	}{
+		{"sessions", h.Sessions, ""},
+		{"device approval", h.DeviceDecide, `{"user_code":"ABCD-EFGH","approve":true}`},
+		{"settings", h.UpdateSettings, `{"terminal_recording":"off"}`},
+	} {
+		for _, sess := range []struct {
+			who, token string
+			forbidden  bool
+		}{
+			{"support", support.AccessToken, true},
+			{"own", own, false},
+		} {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(tc.body))
+			req.Header.Set("Authorization", "Bearer "+sess.token)
+			rec := httptest.NewRecorder()
+			authed(tc.handler).ServeHTTP(rec, req)
+			if (rec.Code == http.StatusForbidden) != sess.forbidden {
+				t.Errorf("%s with %s session: status %d", tc.name, sess.who, rec.Code)
+			}
+		}
+	}
+}
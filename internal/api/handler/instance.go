@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -15,17 +16,22 @@ import (
 )
 
 // InstanceHandler holds handlers for instance CRUD operations.
+// Lifecycle operations (create, pause, wake, delete) are queued as jobs.
 type InstanceHandler struct {
-	svc *service.InstanceService
+	svc  *service.InstanceService
+	jobs *service.JobService
 }
 
 // NewInstanceHandler creates a new InstanceHandler.
-func NewInstanceHandler(svc *service.InstanceService) *InstanceHandler {
-	return &InstanceHandler{svc: svc}
+func NewInstanceHandler(svc *service.InstanceService, jobs *service.JobService) *InstanceHandler {
+	return &InstanceHandler{svc: svc, jobs: jobs}
 }
 
 type createRequest struct {
-	UserID int `json:"user_id"`
+	UserID   int    `json:"user_id"`
+	Name     string `json:"name"`     // empty creates the user's default instance
+	Class    string `json:"class"`    // empty uses the plan's default class
+	Template string `json:"template"` // template ID or name; empty boots the stock environment
 }
 
 // Create handles POST /instances.
@@ -51,13 +57,54 @@ func (h *InstanceHandler) Create(w http.ResponseWriter, r *http.Request) {
 		userID = req.UserID
 	}
 
-	inst, err := h.svc.Create(r.Context(), userID)
+	job, err := h.jobs.EnqueueCreate(r.Context(), userID, service.InstanceSpec{
+		Name:     req.Name,
+		Class:    req.Class,
+		Template: req.Template,
+	})
 	if err != nil {
 		handleServiceError(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, inst)
+	writeAccepted(w, job)
+}
+
+// List handles GET /instances — returns the calling user's live instances.
+func (h *InstanceHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == 0 {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	instances, err := h.svc.List(r.Context(), userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, instances)
+}
+
+// Classes handles GET /instances/classes — returns the instance classes the
+// caller's plan may use, default first. Admin callers get the whole catalog.
+func (h *InstanceHandler) Classes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	classes, err := h.svc.AllowedClasses(r.Context(), userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+	if classes == nil {
+		classes = []provider.Class{}
+	}
+
+	response.JSON(w, http.StatusOK, classes)
 }
 
 // Get handles GET /instances/{id}.
@@ -68,6 +115,10 @@ func (h *InstanceHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !h.authorize(w, r, id, service.AccessRead) {
+		return
+	}
+
 	inst, err := h.svc.Get(r.Context(), id)
 	if err != nil {
 		handleServiceError(w, err)
@@ -84,13 +135,17 @@ func (h *InstanceHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusBadRequest, "invalid instance ID")
 		return
 	}
+	if !h.authorize(w, r, id, service.AccessManage) {
+		return
+	}
 
-	if err := h.svc.Delete(r.Context(), id); err != nil {
+	job, err := h.jobs.EnqueueInstanceOp(r.Context(), service.JobDestroy, id)
+	if err != nil {
 		handleServiceError(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, map[string]string{"status": "destroyed"})
+	writeAccepted(w, job)
 }
 
 // Pause handles POST /instances/{id}/pause.
@@ -100,13 +155,17 @@ func (h *InstanceHandler) Pause(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusBadRequest, "invalid instance ID")
 		return
 	}
+	if !h.authorize(w, r, id, service.AccessWrite) {
+		return
+	}
 
-	if err := h.svc.Pause(r.Context(), id); err != nil {
+	job, err := h.jobs.EnqueueInstanceOp(r.Context(), service.JobPause, id)
+	if err != nil {
 		handleServiceError(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, map[string]string{"status": "stopped"})
+	writeAccepted(w, job)
 }
 
 // Wake handles POST /instances/{id}/wake.
@@ -116,13 +175,101 @@ func (h *InstanceHandler) Wake(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusBadRequest, "invalid instance ID")
 		return
 	}
+	if !h.authorize(w, r, id, service.AccessWrite) {
+		return
+	}
 
-	if err := h.svc.Wake(r.Context(), id); err != nil {
+	job, err := h.jobs.EnqueueInstanceOp(r.Context(), service.JobWake, id)
+	if err != nil {
 		handleServiceError(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, map[string]string{"status": "running"})
+	writeAccepted(w, job)
+}
+
+type resizeRequest struct {
+	Class string `json:"class"`
+}
+
+// Resize handles POST /instances/{id}/resize.
+func (h *InstanceHandler) Resize(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid instance ID")
+		return
+	}
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	var req resizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Class == "" {
+		response.Error(w, http.StatusBadRequest, "class is required")
+		return
+	}
+
+	job, err := h.jobs.EnqueueResize(r.Context(), id, userID, req.Class)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	writeAccepted(w, job)
+}
+
+type sharingRequest struct {
+	OrganizationID *int   `json:"organization_id"` // null stops sharing
+	TeamAccess     string `json:"team_access"`     // none, read or write
+}
+
+// Sharing handles PUT /instances/{id}/sharing — shares the instance with an
+// organization the owner belongs to, or stops sharing it.
+func (h *InstanceHandler) Sharing(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid instance ID")
+		return
+	}
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	var req sharingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	inst, err := h.svc.Share(r.Context(), id, userID, req.OrganizationID, req.TeamAccess)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, inst)
+}
+
+// authorize checks the caller has at least need access to the instance,
+// writing the error response if not.
+func (h *InstanceHandler) authorize(w http.ResponseWriter, r *http.Request, id int, need service.Access) bool {
+	userID, ok := callerID(w, r)
+	if !ok {
+		return false
+	}
+	if err := h.svc.Authorize(r.Context(), id, userID, need); err != nil {
+		handleServiceError(w, err)
+		return false
+	}
+	return true
+}
+
+// writeAccepted responds 202 with the queued job and a Location header for polling.
+func writeAccepted(w http.ResponseWriter, job *service.JobResponse) {
+	w.Header().Set("Location", fmt.Sprintf("/jobs/%d", job.ID))
+	response.JSON(w, http.StatusAccepted, job)
 }
 
 func handleServiceError(w http.ResponseWriter, err error) {
@@ -131,6 +278,60 @@ func handleServiceError(w http.ResponseWriter, err error) {
 		response.Error(w, http.StatusNotFound, "instance not found")
 	case errors.Is(err, provider.ErrAlreadyExists):
 		response.Error(w, http.StatusConflict, "instance already exists for user")
+	case errors.Is(err, service.ErrInvalidInstanceName):
+		response.Error(w, http.StatusBadRequest, "invalid instance name: use up to 32 lowercase letters, digits and hyphens, starting with a letter")
+	case errors.Is(err, service.ErrInstanceLimit):
+		response.Error(w, http.StatusForbidden, "instance limit reached for your plan")
+	case errors.Is(err, service.ErrUnknownClass):
+		response.Error(w, http.StatusBadRequest, "unknown instance class")
+	case errors.Is(err, service.ErrClassNotAllowed):
+		response.Error(w, http.StatusForbidden, "instance class not available on your plan")
+	case errors.Is(err, service.ErrTemplateNotFound):
+		response.Error(w, http.StatusNotFound, "template not found")
+	case errors.Is(err, service.ErrTemplateExists):
+		response.Error(w, http.StatusConflict, "template already exists")
+	case errors.Is(err, service.ErrTemplateReadOnly):
+		response.Error(w, http.StatusForbidden, "global templates can only be changed by an admin")
+	case errors.Is(err, service.ErrInvalidTemplate):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrSecretNotFound):
+		response.Error(w, http.StatusNotFound, "secret not found")
+	case errors.Is(err, service.ErrInvalidSecret):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrTokenNotFound):
+		response.Error(w, http.StatusNotFound, "access token not found")
+	case errors.Is(err, service.ErrInvalidTokenSpec):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrDeviceCodeNotFound):
+		response.Error(w, http.StatusNotFound, "unknown or expired code")
+	case errors.Is(err, service.ErrPermissionDenied):
+		response.Error(w, http.StatusForbidden, "insufficient access to this resource")
+	case errors.Is(err, service.ErrOrgNotFound):
+		response.Error(w, http.StatusNotFound, "organization not found")
+	case errors.Is(err, service.ErrMemberNotFound):
+		response.Error(w, http.StatusNotFound, "member not found")
+	case errors.Is(err, service.ErrInvalidMembership):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrConversationNotFound):
+		response.Error(w, http.StatusNotFound, "conversation not found")
+	case errors.Is(err, service.ErrSessionNotFound):
+		response.Error(w, http.StatusNotFound, "session not found")
+	case errors.Is(err, provider.ErrNotSupported):
+		response.Error(w, http.StatusNotImplemented, "operation not supported by provider")
+	case errors.Is(err, service.ErrJobNotFound):
+		response.Error(w, http.StatusNotFound, "job not found")
+	case errors.Is(err, service.ErrBackupNotFound):
+		response.Error(w, http.StatusNotFound, "backup not found")
+	case errors.Is(err, service.ErrRecordingNotFound):
+		response.Error(w, http.StatusNotFound, "recording not found")
+	case errors.Is(err, service.ErrInvalidRecordingMode):
+		response.Error(w, http.StatusBadRequest, "terminal_recording must be off, output or full")
+	case errors.Is(err, service.ErrTerminalShareNotFound):
+		response.Error(w, http.StatusNotFound, "terminal share not found")
+	case errors.Is(err, service.ErrInvalidTerminalShare):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrShareRejected):
+		response.Error(w, http.StatusUnauthorized, err.Error())
 	case errors.Is(err, provider.ErrInvalidState):
 		response.Error(w, http.StatusConflict, "invalid instance state for operation")
 	default:
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/logan/cloudcode/internal/api/middleware"
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// JobHandler serves async job status.
+type JobHandler struct {
+	jobs *service.JobService
+}
+
+// NewJobHandler creates a new JobHandler.
+func NewJobHandler(jobs *service.JobService) *JobHandler {
+	return &JobHandler{jobs: jobs}
+}
+
+// Get handles GET /jobs/{id}.
+// Users can only see their own jobs; admin (X-API-Key) can see any job.
+func (h *JobHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid job ID")
+		return
+	}
+
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == 0 && !middleware.IsAdminContext(r.Context()) {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	job, err := h.jobs.Get(r.Context(), id, userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, job)
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/auth"
+)
+
+// JWKS returns a handler that publishes the public keys JWTs are signed
+// with, for services that verify our tokens themselves. Verifiers cache the
+// set, so a new signing key should be published here before it is used.
+func JWKS(keys *auth.Keyring) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		response.JSON(w, http.StatusOK, keys.JWKS())
+	}
+}
@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/logan/cloudcode/internal/api/middleware"
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// OrgHandler serves organizations, their members, shared instances and
+// billing. Roles are per user, so every endpoint needs a signed-in user.
+type OrgHandler struct {
+	orgs    *service.OrgService
+	billing *service.BillingService // nil if Stripe not configured
+}
+
+// NewOrgHandler creates a new OrgHandler.
+func NewOrgHandler(orgs *service.OrgService, billing *service.BillingService) *OrgHandler {
+	return &OrgHandler{orgs: orgs, billing: billing}
+}
+
+// orgCaller returns the caller's user ID and the {id} URL parameter, writing
+// an error response if either is missing.
+func orgCaller(w http.ResponseWriter, r *http.Request) (userID, orgID int, ok bool) {
+	userID = middleware.UserIDFromContext(r.Context())
+	if userID == 0 {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return 0, 0, false
+	}
+	orgID, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid organization ID")
+		return 0, 0, false
+	}
+	return userID, orgID, true
+}
+
+type createOrgRequest struct {
+	Name string `json:"name"`
+}
+
+// List handles GET /orgs — the caller's organizations and their role in each.
+func (h *OrgHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == 0 {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	orgs, err := h.orgs.List(r.Context(), userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, orgs)
+}
+
+// Create handles POST /orgs. The caller becomes its owner.
+func (h *OrgHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == 0 {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	var req createOrgRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	org, err := h.orgs.Create(r.Context(), userID, req.Name)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, org)
+}
+
+// Get handles GET /orgs/{id}.
+func (h *OrgHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgCaller(w, r)
+	if !ok {
+		return
+	}
+
+	org, err := h.orgs.Get(r.Context(), orgID, userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, org)
+}
+
+// Delete handles DELETE /orgs/{id}. Owner only.
+func (h *OrgHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgCaller(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.orgs.Delete(r.Context(), orgID, userID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+type memberRequest struct {
+	Email string `json:"email"` // POST only
+	Role  string `json:"role"`
+}
+
+// Members handles GET /orgs/{id}/members.
+func (h *OrgHandler) Members(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgCaller(w, r)
+	if !ok {
+		return
+	}
+
+	members, err := h.orgs.Members(r.Context(), orgID, userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, members)
+}
+
+// AddMember handles POST /orgs/{id}/members.
+func (h *OrgHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgCaller(w, r)
+	if !ok {
+		return
+	}
+
+	var req memberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		response.Error(w, http.StatusBadRequest, "email is required")
+		return
+	}
+	if req.Role == "" {
+		req.Role = service.RoleMember
+	}
+
+	member, err := h.orgs.AddMember(r.Context(), orgID, userID, req.Email, req.Role)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, member)
+}
+
+type recordingRequest struct {
+	TerminalRecording string `json:"terminal_recording"` // off, output or full
+}
+
+// SetRecording handles PUT /orgs/{id}/recording — whether terminals on the
+// organization's shared instances are recorded.
+func (h *OrgHandler) SetRecording(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgCaller(w, r)
+	if !ok {
+		return
+	}
+
+	var req recordingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	org, err := h.orgs.SetTerminalRecording(r.Context(), orgID, userID, req.TerminalRecording)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, org)
+}
+
+// UpdateMember handles PUT /orgs/{id}/members/{userID} — changes a role.
+func (h *OrgHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgCaller(w, r)
+	if !ok {
+		return
+	}
+	memberID, err := service.ParseID(chi.URLParam(r, "userID"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var req memberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Role == "" {
+		response.Error(w, http.StatusBadRequest, "role is required")
+		return
+	}
+
+	if err := h.orgs.UpdateMember(r.Context(), orgID, userID, memberID, req.Role); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"status": "updated"})
+}
+
+// RemoveMember handles DELETE /orgs/{id}/members/{userID}. Members can remove
+// themselves to leave.
+func (h *OrgHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgCaller(w, r)
+	if !ok {
+		return
+	}
+	memberID, err := service.ParseID(chi.URLParam(r, "userID"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	if err := h.orgs.RemoveMember(r.Context(), orgID, userID, memberID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"status": "removed"})
+}
+
+// Instances handles GET /orgs/{id}/instances — the shared instances the
+// caller can see.
+func (h *OrgHandler) Instances(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgCaller(w, r)
+	if !ok {
+		return
+	}
+
+	instances, err := h.orgs.Instances(r.Context(), orgID, userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, instances)
+}
+
+// CreateCheckout handles POST /orgs/{id}/billing/checkout. Admins and owners
+// only.
+func (h *OrgHandler) CreateCheckout(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgCaller(w, r)
+	if !ok {
+		return
+	}
+	if _, err := h.orgs.Authorize(r.Context(), orgID, userID, service.RoleAdmin); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	var req checkoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Plan == "" {
+		req.Plan = "starter"
+	}
+
+	url, err := h.billing.CreateOrgCheckoutSession(r.Context(), orgID, req.Plan)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to create checkout session")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"url": url})
+}
+
+// GetPortal handles GET /orgs/{id}/billing/portal. Admins and owners only.
+func (h *OrgHandler) GetPortal(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgCaller(w, r)
+	if !ok {
+		return
+	}
+	if _, err := h.orgs.Authorize(r.Context(), orgID, userID, service.RoleAdmin); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	url, err := h.billing.GetOrgBillingPortalURL(r.Context(), orgID)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "no billing account")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"url": url})
+}
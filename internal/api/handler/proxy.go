@@ -1,10 +1,16 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/gorilla/websocket"
@@ -21,13 +27,33 @@ var proxyTracer = otel.Tracer("cloudcode/handler/proxy")
 
 // ProxyHandler proxies requests to instance ttyd and agent services.
 type ProxyHandler struct {
-	svc       *service.InstanceService
-	jwtSecret string
+	svc        *service.InstanceService
+	keys       *auth.Keyring
+	sessions   middleware.SessionChecker // nil skips the revocation check
+	recordings *service.RecordingService // nil disables terminal recording
+	jobs       *service.JobService       // nil leaves stopped instances asleep
+	hubs       *terminalHubs
+	chats      *chatSessions
 }
 
 // NewProxyHandler creates a new ProxyHandler.
-func NewProxyHandler(svc *service.InstanceService, jwtSecret string) *ProxyHandler {
-	return &ProxyHandler{svc: svc, jwtSecret: jwtSecret}
+func NewProxyHandler(svc *service.InstanceService, keys *auth.Keyring, sessions middleware.SessionChecker, recordings *service.RecordingService) *ProxyHandler {
+	return &ProxyHandler{svc: svc, keys: keys, sessions: sessions, recordings: recordings,
+		hubs: newTerminalHubs(DefaultResumeGrace), chats: newChatSessions(DefaultResumeGrace)}
+}
+
+// SetResumeGrace sets how long terminal and chat backends are kept open for
+// a client whose connection dropped; 0 closes them at once. It must be
+// called before the handler serves.
+func (h *ProxyHandler) SetResumeGrace(d time.Duration) {
+	h.hubs.grace = d
+	h.chats.grace = d
+}
+
+// SetJobService lets terminal and chat connections wake a stopped instance
+// they are opened on.
+func (h *ProxyHandler) SetJobService(jobs *service.JobService) {
+	h.jobs = jobs
 }
 
 var upgrader = websocket.Upgrader{
@@ -42,53 +68,195 @@ func (h *ProxyHandler) extractUserID(r *http.Request) int {
 	}
 	// WebSocket fallback: ?token=JWT
 	if tok := r.URL.Query().Get("token"); tok != "" {
-		claims, err := auth.ValidateToken(h.jwtSecret, tok)
-		if err == nil && claims.Purpose == "session" {
+		claims, err := middleware.ValidateSessionToken(r.Context(), h.keys, h.sessions, tok)
+		if err == nil {
 			return claims.UserID
 		}
 	}
 	return 0
 }
 
-// resolveInstance extracts the instance ID and verifies ownership, returning the host and agent secret.
-func (h *ProxyHandler) resolveInstance(w http.ResponseWriter, r *http.Request) (host, agentSecret string, ok bool) {
+// resolveInstance extracts the instance ID and verifies the caller may use
+// it, returning the host, agent secret and the caller's access level. With
+// wake set, a stopped instance the caller may wake resolves with an empty
+// host, to be woken with wakeInstance once the connection is upgraded.
+func (h *ProxyHandler) resolveInstance(w http.ResponseWriter, r *http.Request, wake bool) (host, agentSecret string, access service.Access, ok bool) {
 	userID := h.extractUserID(r)
 	if userID == 0 {
 		response.Error(w, http.StatusUnauthorized, "authentication required")
-		return "", "", false
+		return "", "", service.AccessNone, false
 	}
 
 	id, err := service.ParseID(chi.URLParam(r, "id"))
 	if err != nil {
 		response.Error(w, http.StatusBadRequest, "invalid instance ID")
-		return "", "", false
+		return "", "", service.AccessNone, false
 	}
 
-	host, agentSecret, err = h.svc.GetInstanceHost(r.Context(), id, userID)
+	host, agentSecret, access, err = h.svc.GetInstanceHost(r.Context(), id, userID)
+	if errors.Is(err, service.ErrInstanceStopped) && wake && h.jobs != nil && access >= service.AccessWrite {
+		return "", "", access, true
+	}
 	if err != nil {
 		handleServiceError(w, err)
-		return "", "", false
+		return "", "", service.AccessNone, false
+	}
+	return host, agentSecret, access, true
+}
+
+// recordingNotice is a ttyd output message telling the user their session
+// is recorded, shown before anything else.
+func recordingNotice(input bool) []byte {
+	what := "output"
+	if input {
+		what = "output and keystrokes"
+	}
+	return []byte("0\x1b[33m[This terminal session is being recorded: " + what + "]\x1b[0m\r\n")
+}
+
+// recordClientMessage feeds a ttyd client message to the recorder: input
+// ('0') as keystrokes and resize ('1') as the new size.
+func recordClientMessage(rec *service.Recorder, msg []byte) {
+	if len(msg) == 0 {
+		return
+	}
+	switch msg[0] {
+	case '0':
+		rec.Keys(msg[1:])
+	case '1':
+		var size struct {
+			Columns int `json:"columns"`
+			Rows    int `json:"rows"`
+		}
+		if json.Unmarshal(msg[1:], &size) == nil {
+			rec.Resize(size.Columns, size.Rows)
+		}
 	}
-	return host, agentSecret, true
 }
 
-// Terminal proxies WebSocket connections to ttyd (port 7681).
+// Terminal proxies WebSocket connections to ttyd (port 7681). Everyone with
+// the instance's terminal open shares one ttyd connection: the first caller
+// with write access types, the rest watch. A stopped instance is woken first.
 func (h *ProxyHandler) Terminal(w http.ResponseWriter, r *http.Request) {
 	_, span := proxyTracer.Start(r.Context(), "proxy.terminal")
 	defer span.End()
 
-	host, _, ok := h.resolveInstance(w, r)
+	host, _, access, ok := h.resolveInstance(w, r, true)
 	if !ok {
 		return
 	}
-	span.SetAttributes(attribute.String("host", host))
+	readOnly := access < service.AccessWrite
+	span.SetAttributes(attribute.String("host", host), attribute.Bool("read_only", readOnly))
 
-	// Upgrade client connection
-	clientConn, err := upgrader.Upgrade(w, r, nil)
+	// resolveInstance has checked both already
+	id, _ := service.ParseID(chi.URLParam(r, "id"))
+	userID := h.extractUserID(r)
+
+	c := newHubClient(terminalUserName(r, userID), userID, !readOnly, 0)
+	h.serveTerminal(w, r, id, host, c, time.Time{}, func() {
+		h.svc.TerminalOpened(clientContext(r), id, userID, readOnly)
+	})
+}
+
+// SharedTerminal handles GET /terminal/shared?token=, letting the holder of
+// a share link watch the instance's terminal until the link expires or is
+// revoked.
+func (h *ProxyHandler) SharedTerminal(w http.ResponseWriter, r *http.Request) {
+	_, span := proxyTracer.Start(r.Context(), "proxy.terminal_shared")
+	defer span.End()
+
+	grant, err := h.svc.ResolveTerminalShare(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+	span.SetAttributes(attribute.String("host", grant.Host), attribute.Int("share_id", grant.ShareID))
+
+	name := grant.Label
+	if name == "" {
+		name = "guest"
+	}
+	c := newHubClient(name, 0, false, grant.ShareID)
+	ctx := service.WithActor(clientContext(r), service.ActorShare, 0)
+	h.serveTerminal(w, r, grant.InstanceID, grant.Host, c, grant.ExpiresAt, func() {
+		h.svc.TerminalWatched(ctx, grant)
+	})
+}
+
+// errRecordingUnavailable fails a terminal that must be recorded but whose
+// recording could not start.
+var errRecordingUnavailable = errors.New("recording unavailable")
+
+// serveTerminal upgrades the request and joins c to the instance's terminal
+// hub, or resumes the client c was before if asked to, until either side
+// closes, or until expires if it is set. An empty host wakes the instance
+// first. opened runs once c has joined afresh. c's user starts the
+// recording if c opens the hub.
+func (h *ProxyHandler) serveTerminal(w http.ResponseWriter, r *http.Request, id int, host string, c *hubClient, expires time.Time, opened func()) {
+	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		return
 	}
-	defer clientConn.Close()
+	defer conn.Close()
+	if host == "" {
+		var ok bool
+		if host, _, ok = h.wakeInstance(conn, r, id, c.userID); !ok {
+			return
+		}
+	}
+
+	var (
+		hub *terminalHub
+		cc  *clientConn
+	)
+	if token, after, ok := resumeParams(r); ok {
+		hub, c, cc = h.hubs.resume(id, token, c, after)
+	}
+	if cc == nil {
+		hub, cc, err = h.hubs.join(id, c, func() (*websocket.Conn, *service.Recorder, context.Context, error) {
+			return h.openTerminal(r, id, host, c.userID)
+		})
+		if err != nil {
+			reason := "backend unavailable"
+			if errors.Is(err, errRecordingUnavailable) {
+				reason = err.Error()
+			}
+			conn.WriteMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseInternalServerErr, reason))
+			return
+		}
+		opened()
+	}
+
+	if !expires.IsZero() {
+		t := time.AfterFunc(time.Until(expires), func() {
+			hub.drop(c, websocket.ClosePolicyViolation, "share link expired")
+		})
+		defer t.Stop()
+	}
+
+	go cc.pump(conn)
+	resumable := readClient(conn, func(msgType int, msg []byte) {
+		hub.fromClient(c, cc, msgType, msg)
+	})
+	hub.release(c, cc, resumable)
+}
+
+// openTerminal dials ttyd for a new terminal hub and starts its recording.
+// A session that must be recorded does not start unrecorded.
+func (h *ProxyHandler) openTerminal(r *http.Request, id int, host string, userID int) (*websocket.Conn, *service.Recorder, context.Context, error) {
+	// The request may be over when the hub closes; the upload must still happen
+	ctx := context.WithoutCancel(clientContext(r))
+
+	var rec *service.Recorder
+	if h.recordings != nil {
+		var err error
+		rec, err = h.recordings.Begin(ctx, id, userID)
+		if err != nil {
+			slog.Error("terminal proxy: recording failed to start", "instance_id", id, "error", err)
+			return nil, nil, nil, errRecordingUnavailable
+		}
+	}
 
 	// Connect to ttyd — must negotiate the "tty" subprotocol
 	targetURL := "ws://" + host + ":7681/ws"
@@ -96,102 +264,86 @@ func (h *ProxyHandler) Terminal(w http.ResponseWriter, r *http.Request) {
 		Subprotocols: []string{"tty"},
 	}
 	backendConn, _, err := ttydDialer.Dial(targetURL, nil)
+	if err == nil {
+		// The hub speaks for its clients; theirs are not forwarded
+		err = backendConn.WriteMessage(websocket.TextMessage, []byte(`{"AuthToken":""}`))
+	}
 	if err != nil {
 		slog.Error("terminal proxy: backend dial failed", "host", host, "error", err)
-		clientConn.WriteMessage(websocket.CloseMessage,
-			websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "backend unavailable"))
-		return
-	}
-	defer backendConn.Close()
-
-	// Bidirectional proxy
-	done := make(chan struct{}, 2)
-	go func() {
-		defer func() { done <- struct{}{} }()
-		for {
-			msgType, msg, err := backendConn.ReadMessage()
-			if err != nil {
-				slog.Debug("terminal proxy: backend→client read error", "host", host, "error", err)
-				return
-			}
-			if err := clientConn.WriteMessage(msgType, msg); err != nil {
-				slog.Debug("terminal proxy: backend→client write error", "host", host, "error", err)
-				return
-			}
+		if backendConn != nil {
+			backendConn.Close()
 		}
-	}()
-	go func() {
-		defer func() { done <- struct{}{} }()
-		for {
-			msgType, msg, err := clientConn.ReadMessage()
-			if err != nil {
-				slog.Debug("terminal proxy: client→backend read error", "host", host, "error", err)
-				return
-			}
-			if err := backendConn.WriteMessage(msgType, msg); err != nil {
-				slog.Debug("terminal proxy: client→backend write error", "host", host, "error", err)
-				return
-			}
+		if rec != nil {
+			rec.Close(ctx) // nothing recorded yet, so nothing is saved
 		}
-	}()
-	<-done
+		return nil, nil, nil, err
+	}
+	return backendConn, rec, ctx, nil
+}
+
+// terminalUserName is how a user appears to others watching a terminal.
+func terminalUserName(r *http.Request, userID int) string {
+	if local, _, _ := strings.Cut(middleware.EmailFromContext(r.Context()), "@"); local != "" {
+		return local
+	}
+	return "user " + strconv.Itoa(userID)
 }
 
 // Chat proxies WebSocket connections to the instance agent chat (port 3001).
+// The agent connection outlives a dropped client for the grace period, so
+// the client can resume it. A stopped instance is woken first.
 func (h *ProxyHandler) Chat(w http.ResponseWriter, r *http.Request) {
 	_, span := proxyTracer.Start(r.Context(), "proxy.chat")
 	defer span.End()
 
-	host, agentSecret, ok := h.resolveInstance(w, r)
+	host, agentSecret, access, ok := h.resolveInstance(w, r, true)
 	if !ok {
 		return
 	}
-	span.SetAttributes(attribute.String("host", host))
+	readOnly := access < service.AccessWrite
+	span.SetAttributes(attribute.String("host", host), attribute.Bool("read_only", readOnly))
 
-	clientConn, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		return
-	}
-	defer clientConn.Close()
+	// resolveInstance has checked both already
+	id, _ := service.ParseID(chi.URLParam(r, "id"))
+	userID := h.extractUserID(r)
 
-	targetURL := "ws://" + host + ":3001/chat?secret=" + agentSecret
-	backendHeader := http.Header{}
-	backendHeader.Set("Authorization", "Bearer "+agentSecret)
-	backendConn, _, err := websocket.DefaultDialer.Dial(targetURL, backendHeader)
+	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		slog.Error("chat proxy: backend dial failed", "host", host, "error", err)
-		clientConn.WriteMessage(websocket.CloseMessage,
-			websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "agent unavailable"))
 		return
 	}
-	defer backendConn.Close()
-
-	done := make(chan struct{}, 2)
-	go func() {
-		defer func() { done <- struct{}{} }()
-		for {
-			msgType, msg, err := backendConn.ReadMessage()
-			if err != nil {
-				return
-			}
-			if err := clientConn.WriteMessage(msgType, msg); err != nil {
-				return
-			}
+	defer conn.Close()
+	if host == "" {
+		if host, agentSecret, ok = h.wakeInstance(conn, r, id, userID); !ok {
+			return
 		}
-	}()
-	go func() {
-		defer func() { done <- struct{}{} }()
-		for {
-			msgType, msg, err := clientConn.ReadMessage()
-			if err != nil {
-				return
-			}
-			if err := backendConn.WriteMessage(msgType, msg); err != nil {
-				return
-			}
+	}
+
+	var (
+		sess *chatSession
+		cc   *clientConn
+	)
+	if token, after, ok := resumeParams(r); ok {
+		sess, cc = h.chats.resume(token, id, userID, after)
+	}
+	if cc == nil {
+		targetURL := "ws://" + host + ":3001/chat?secret=" + agentSecret
+		backendHeader := http.Header{}
+		backendHeader.Set("Authorization", "Bearer "+agentSecret)
+		backendConn, _, err := websocket.DefaultDialer.Dial(targetURL, backendHeader)
+		if err != nil {
+			slog.Error("chat proxy: backend dial failed", "host", host, "error", err)
+			conn.WriteMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "agent unavailable"))
+			return
 		}
-	}()
-	<-done
+		sess, cc = h.chats.start(id, userID, readOnly, backendConn)
+	}
+
+	go cc.pump(conn)
+	resumable := readClient(conn, func(msgType int, msg []byte) {
+		sess.fromClient(cc, msgType, msg)
+	})
+	sess.release(cc, resumable)
 }
 
 // Files proxies GET /instances/{id}/files to the agent.
@@ -246,10 +398,14 @@ func (h *ProxyHandler) proxyHTTP(w http.ResponseWriter, r *http.Request, agentPa
 	_, span := proxyTracer.Start(r.Context(), "proxy.files")
 	defer span.End()
 
-	host, agentSecret, ok := h.resolveInstance(w, r)
+	host, agentSecret, access, ok := h.resolveInstance(w, r, false)
 	if !ok {
 		return
 	}
+	if r.Method != http.MethodGet && access < service.AccessWrite {
+		handleServiceError(w, service.ErrPermissionDenied)
+		return
+	}
 	span.SetAttributes(attribute.String("host", host), attribute.String("path", agentPath))
 
 	target, _ := url.Parse("http://" + host + ":3001")
@@ -277,7 +433,8 @@ func (h *ProxyHandler) proxyHTTP(w http.ResponseWriter, r *http.Request, agentPa
 	proxy.ServeHTTP(w, r)
 }
 
-// GetMine handles GET /instances/mine — returns the calling user's active instance.
+// GetMine handles GET /instances/mine — returns one of the calling user's
+// active instances, selected by ?instance=<name or ID> (default instance if omitted).
 func GetMine(svc *service.InstanceService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		userID := middleware.UserIDFromContext(r.Context())
@@ -286,7 +443,7 @@ func GetMine(svc *service.InstanceService) http.HandlerFunc {
 			return
 		}
 
-		inst, err := svc.GetByUserID(r.Context(), userID)
+		inst, err := svc.GetByUserID(r.Context(), userID, r.URL.Query().Get("instance"))
 		if err != nil {
 			handleServiceError(w, err)
 			return
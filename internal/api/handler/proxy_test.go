@@ -2,14 +2,20 @@ package handler
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/logan/cloudcode/internal/api/middleware"
+	"github.com/logan/cloudcode/internal/auth"
 	"github.com/logan/cloudcode/internal/ent/enttest"
 	"github.com/logan/cloudcode/internal/provider"
 	"github.com/logan/cloudcode/internal/service"
@@ -22,7 +28,7 @@ func setupProxyTest(t *testing.T) (*ProxyHandler, *service.InstanceService, int)
 
 	mock := provider.NewMock()
 	svc := service.NewInstanceService(client, mock, "")
-	ph := NewProxyHandler(svc, "test-jwt-secret")
+	ph := NewProxyHandler(svc, auth.NewHMACKeyring("test-jwt-secret"), nil, nil)
 
 	u, err := client.User.Create().
 		SetEmail("proxy-test@example.com").
@@ -77,7 +83,7 @@ func TestGetMine_WithInstance(t *testing.T) {
 	}
 
 	// Create an instance
-	_, err = svc.Create(context.Background(), u.ID)
+	_, err = svc.Create(context.Background(), u.ID, service.InstanceSpec{})
 	if err != nil {
 		t.Fatalf("create instance: %v", err)
 	}
@@ -113,3 +119,73 @@ func TestProxy_Unauthorized(t *testing.T) {
 		t.Errorf("expected 401, got %d", rr.Code)
 	}
 }
+
+func TestProxy_ReadOnlyTeammate(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_proxy_team?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	ctx := context.Background()
+
+	svc := service.NewInstanceService(client, provider.NewMock(), "")
+	ph := NewProxyHandler(svc, auth.NewHMACKeyring("test-jwt-secret"), nil, nil)
+	orgs := service.NewOrgService(client)
+	owner := client.User.Create().SetEmail("owner@example.com").SaveX(ctx)
+	mate := client.User.Create().SetEmail("mate@example.com").SaveX(ctx)
+	org, _ := orgs.Create(ctx, owner.ID, "Acme")
+	if _, err := orgs.AddMember(ctx, org.ID, owner.ID, mate.Email, service.RoleMember); err != nil {
+		t.Fatal(err)
+	}
+	inst, _ := svc.Create(ctx, owner.ID, service.InstanceSpec{})
+	if _, err := svc.Share(ctx, inst.ID, owner.ID, &org.ID, "read"); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest("POST", "/instances/1/projects/clone", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", strconv.Itoa(inst.ID))
+	req = req.WithContext(context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), middleware.TestUserIDKey(), mate.ID))
+
+	rr := httptest.NewRecorder()
+	ph.ProjectsClone(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rr.Code)
+	}
+}
+
+func TestProxy_ChatWakesStoppedInstance(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_proxy_wake?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	ctx := context.Background()
+
+	svc := service.NewInstanceService(client, provider.NewMock(), "")
+	ph := NewProxyHandler(svc, auth.NewHMACKeyring("test-jwt-secret"), nil, nil)
+	u := client.User.Create().SetEmail("wake@example.com").SaveX(ctx)
+	inst, _ := svc.Create(ctx, u.ID, service.InstanceSpec{})
+	if err := svc.Pause(ctx, inst.ID); err != nil {
+		t.Fatal(err)
+	}
+	// No workers run the wake, so it times out
+	jobs := service.NewJobService(client, svc, slog.Default(), 1, time.Hour)
+	jobs.SetWakeTimeout(100 * time.Millisecond)
+	ph.SetJobService(jobs)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", strconv.Itoa(inst.ID))
+		ctx := context.WithValue(context.WithValue(r.Context(), chi.RouteCtxKey, rctx), middleware.TestUserIDKey(), u.ID)
+		ph.Chat(w, r.WithContext(ctx))
+	}))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	if _, msg, err := conn.ReadMessage(); err != nil || string(msg) != `{"type":"wake","step":"queued"}` {
+		t.Fatalf("first message = %q, %v", msg, err)
+	}
+	_, _, err = conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseTryAgainLater) || !strings.Contains(err.Error(), service.ErrWakeTimeout.Error()) {
+		t.Errorf("expected wake timeout close, got %v", err)
+	}
+}
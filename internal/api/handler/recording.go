@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/asciicast"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// maxReplayPause caps the pause between replayed events, so idle stretches
+// of a session do not stall the replay.
+const maxReplayPause = 2 * time.Second
+
+// RecordingHandler serves the terminal recordings of an instance.
+type RecordingHandler struct {
+	recordings *service.RecordingService
+}
+
+// NewRecordingHandler creates a new RecordingHandler.
+func NewRecordingHandler(recordings *service.RecordingService) *RecordingHandler {
+	return &RecordingHandler{recordings: recordings}
+}
+
+// List handles GET /instances/{id}/recordings.
+func (h *RecordingHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid instance ID")
+		return
+	}
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	recordings, err := h.recordings.List(r.Context(), id, userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, recordings)
+}
+
+// Download handles GET /instances/{id}/recordings/{recordingID}, returning
+// the asciicast v2 file.
+func (h *RecordingHandler) Download(w http.ResponseWriter, r *http.Request) {
+	id, recordingID, userID, ok := recordingParams(w, r)
+	if !ok {
+		return
+	}
+
+	rec, rc, err := h.recordings.Open(r.Context(), id, recordingID, userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", asciicast.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(rec.SizeBytes, 10))
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="instance-%d-%s.cast"`, id, rec.StartedAt.UTC().Format("20060102T150405Z")))
+	if _, err := io.Copy(w, rc); err != nil {
+		slog.Debug("recording download interrupted", "recording_id", recordingID, "error", err)
+	}
+}
+
+// Replay handles GET /instances/{id}/recordings/{recordingID}/replay?speed=,
+// a WebSocket that plays the recording's output back in the ttyd protocol
+// at its original pace, so the terminal client can show it. Pauses are
+// capped at two seconds; speed (0.25 to 16) scales the pace.
+func (h *RecordingHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	id, recordingID, userID, ok := recordingParams(w, r)
+	if !ok {
+		return
+	}
+	speed := 1.0
+	if v := r.URL.Query().Get("speed"); v != "" {
+		s, err := strconv.ParseFloat(v, 64)
+		if err != nil || s < 0.25 || s > 16 {
+			response.Error(w, http.StatusBadRequest, "speed must be between 0.25 and 16")
+			return
+		}
+		speed = s
+	}
+
+	_, rc, err := h.recordings.Open(r.Context(), id, recordingID, userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+	defer rc.Close()
+	cast, err := asciicast.NewReader(rc)
+	if err != nil {
+		slog.Error("recording replay: unreadable recording", "recording_id", recordingID, "error", err)
+		response.Error(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// Client messages are ignored, but reading them notices a close
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	var last float64
+	for {
+		e, err := cast.Next()
+		if err != nil {
+			if err != io.EOF {
+				slog.Error("recording replay: read failed", "recording_id", recordingID, "error", err)
+			}
+			break
+		}
+		if e.Type != asciicast.Output {
+			continue
+		}
+		pause := min(time.Duration((e.Time-last)/speed*float64(time.Second)), maxReplayPause)
+		last = e.Time
+		select {
+		case <-closed:
+			return
+		case <-time.After(pause):
+		}
+		if err := conn.WriteMessage(websocket.BinaryMessage, append([]byte{'0'}, e.Data...)); err != nil {
+			return
+		}
+	}
+	conn.WriteMessage(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, "end of recording"))
+}
+
+// recordingParams reads the instance and recording IDs and the caller.
+func recordingParams(w http.ResponseWriter, r *http.Request) (id, recordingID, userID int, ok bool) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid instance ID")
+		return 0, 0, 0, false
+	}
+	recordingID, err = service.ParseID(chi.URLParam(r, "recordingID"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid recording ID")
+		return 0, 0, 0, false
+	}
+	userID, ok = callerID(w, r)
+	return id, recordingID, userID, ok
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+
+	"github.com/logan/cloudcode/internal/api/middleware"
+	"github.com/logan/cloudcode/internal/backup"
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+func TestRecordingHandler_Replay(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_recording_replay?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	ctx := context.Background()
+	store, err := backup.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordings := service.NewRecordingService(client, store, slog.Default(), nil)
+
+	u := client.User.Create().
+		SetEmail("replay@example.com").
+		SetTerminalRecording(entuser.TerminalRecordingOutput).
+		SaveX(ctx)
+	inst := client.Instance.Create().SetProvider("mock").SetProviderID("mock-1").SetOwner(u).SaveX(ctx)
+
+	// Record a session the way the terminal proxy feeds it
+	rec, err := recordings.Begin(ctx, inst.ID, u.ID)
+	if err != nil || rec == nil {
+		t.Fatalf("begin = %v, %v", rec, err)
+	}
+	recordClientMessage(rec, []byte(`{"AuthToken":""}`))
+	recordClientMessage(rec, []byte(`1{"columns":132,"rows":43}`))
+	rec.Output([]byte("$ "))
+	recordClientMessage(rec, []byte("0secret\r")) // output only: not kept
+	rec.Output([]byte("done\r\n"))
+	if err := rec.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	list, _ := recordings.List(ctx, inst.ID, u.ID)
+	if len(list) != 1 || list[0].Width != 132 || list[0].Height != 43 || list[0].Input {
+		t.Fatalf("recordings = %+v", list)
+	}
+
+	h := NewRecordingHandler(recordings)
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), middleware.TestUserIDKey(), u.ID)))
+		})
+	})
+	r.Get("/instances/{id}/recordings/{recordingID}", h.Download)
+	r.Get("/instances/{id}/recordings/{recordingID}/replay", h.Replay)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	path := "/instances/" + strconv.Itoa(inst.ID) + "/recordings/" + strconv.Itoa(list[0].ID)
+
+	resp, err := http.Get(srv.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/x-asciicast" {
+		t.Errorf("download = %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+path+"/replay?speed=16", nil)
+	if err != nil {
+		t.Fatalf("dial replay: %v", err)
+	}
+	defer conn.Close()
+	var frames []string
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				t.Fatalf("replay: %v", err)
+			}
+			break
+		}
+		frames = append(frames, string(msg))
+	}
+	if strings.Join(frames, "|") != "0$ |0done\r\n" {
+		t.Errorf("replayed frames = %q", frames)
+	}
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// DefaultResumeGrace is how long the proxy keeps a terminal or chat
+	// backend open for a client whose connection dropped.
+	DefaultResumeGrace = 30 * time.Second
+	// replayBytes bounds the backend frames kept per session for clients
+	// that resume or join late.
+	replayBytes = 256 << 10
+	// clientBuffer is how many messages a client may fall behind before
+	// its connection is ended, so one slow client cannot stall a session.
+	clientBuffer = 256
+	// Clients are pinged so that a connection that silently died, say on
+	// a laptop that went to sleep, is noticed and can be resumed.
+	pingPeriod = 30 * time.Second
+	pongWait   = 2 * pingPeriod
+)
+
+// wsMessage is a WebSocket message as read or to be written.
+type wsMessage struct {
+	typ  int
+	data []byte
+}
+
+// frameRing keeps the most recent backend frames of a session, numbered
+// from 1, within a byte budget.
+type frameRing struct {
+	frames []wsMessage
+	last   uint64 // sequence number of the newest frame
+	bytes  int
+	max    int
+}
+
+func newFrameRing(max int) *frameRing {
+	return &frameRing{max: max}
+}
+
+// push appends m, evicting the oldest frames beyond the budget. The newest
+// frame is always kept.
+func (r *frameRing) push(m wsMessage) {
+	r.frames = append(r.frames, m)
+	r.last++
+	r.bytes += len(m.data)
+	for r.bytes > r.max && len(r.frames) > 1 {
+		r.bytes -= len(r.frames[0].data)
+		r.frames[0] = wsMessage{}
+		r.frames = r.frames[1:]
+	}
+}
+
+// since returns a copy of the frames numbered after after, and how many of
+// those have already been evicted.
+func (r *frameRing) since(after uint64) (frames []wsMessage, missed uint64) {
+	if after >= r.last {
+		return nil, 0
+	}
+	first := r.last - uint64(len(r.frames)) + 1
+	if after+1 < first {
+		missed = first - (after + 1)
+		after = first - 1
+	}
+	return append([]wsMessage(nil), r.frames[after+1-first:]...), missed
+}
+
+// clientConn is one WebSocket connection of a resumable client. backlog is
+// written first, then whatever is queued on send until the session closes
+// send to end the connection.
+type clientConn struct {
+	backlog []wsMessage
+	send    chan wsMessage
+	code    int // close code and reason, set before send is closed
+	reason  string
+}
+
+func newClientConn() *clientConn {
+	return &clientConn{send: make(chan wsMessage, clientBuffer)}
+}
+
+// queue adds m without blocking. It returns false if the client has fallen
+// too far behind.
+func (cc *clientConn) queue(m wsMessage) bool {
+	select {
+	case cc.send <- m:
+		return true
+	default:
+		return false
+	}
+}
+
+// end closes the connection with code and reason; code 0 closes it without
+// a close message, for connections already dead.
+func (cc *clientConn) end(code int, reason string) {
+	cc.code, cc.reason = code, reason
+	close(cc.send)
+}
+
+// pump writes cc's messages to conn, pinging it meanwhile, until the
+// session ends cc, then closes conn.
+func (cc *clientConn) pump(conn *websocket.Conn) {
+	defer conn.Close()
+	for _, m := range cc.backlog {
+		if err := conn.WriteMessage(m.typ, m.data); err != nil {
+			return
+		}
+	}
+	ping := time.NewTicker(pingPeriod)
+	defer ping.Stop()
+	for {
+		select {
+		case m, ok := <-cc.send:
+			if !ok {
+				if cc.code != 0 {
+					conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(cc.code, cc.reason))
+				}
+				return
+			}
+			if err := conn.WriteMessage(m.typ, m.data); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readClient passes each message from conn to handle until the connection
+// fails. It reports whether the client may resume: connections the client
+// closed on purpose may not, ones that dropped or stopped answering may.
+func readClient(conn *websocket.Conn, handle func(msgType int, msg []byte)) (resumable bool) {
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		msgType, msg, err := conn.ReadMessage()
+		if err != nil {
+			return !websocket.IsCloseError(err,
+				websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseNoStatusReceived)
+		}
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		handle(msgType, msg)
+	}
+}
+
+// resumeEvent is sent to clients as a text message when they connect. To
+// resume after the connection drops, a client reconnects within the grace
+// period with ?resume=Token&seq=N, N being the number of the last backend
+// frame it received. Backend frames after this message are numbered from
+// Seq+1. Resumed is false if a resume was asked for but not possible, in
+// which case the session is new; Missed counts frames lost meanwhile.
+type resumeEvent struct {
+	Type    string `json:"type"` // always "resume"
+	Token   string `json:"token"`
+	Seq     uint64 `json:"seq"`
+	Resumed bool   `json:"resumed"`
+	Missed  uint64 `json:"missed,omitempty"`
+}
+
+func resumeMessage(token string, seq uint64, resumed bool, missed uint64) wsMessage {
+	b, _ := json.Marshal(resumeEvent{Type: "resume", Token: token, Seq: seq, Resumed: resumed, Missed: missed})
+	return wsMessage{websocket.TextMessage, b}
+}
+
+func newResumeToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// resumeParams reads ?resume= and ?seq=. ok is false if the client is not
+// resuming.
+func resumeParams(r *http.Request) (token string, after uint64, ok bool) {
+	token = r.URL.Query().Get("resume")
+	if token == "" {
+		return "", 0, false
+	}
+	after, _ = strconv.ParseUint(r.URL.Query().Get("seq"), 10, 64)
+	return token, after, true
+}
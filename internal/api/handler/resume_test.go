@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestFrameRing(t *testing.T) {
+	ring := newFrameRing(8)
+	if frames, missed := ring.since(0); frames != nil || missed != 0 {
+		t.Errorf("empty ring = %v, %d", frames, missed)
+	}
+	for _, f := range []string{"aaa", "bbb", "ccc", "dddddddddd"} {
+		ring.push(wsMessage{websocket.BinaryMessage, []byte(f)})
+	}
+	// Only the newest frame fits, and it is kept though over budget
+	if ring.last != 4 || len(ring.frames) != 1 {
+		t.Fatalf("ring holds %d frames up to %d", len(ring.frames), ring.last)
+	}
+	ring.push(wsMessage{websocket.BinaryMessage, []byte("e")})
+	ring.push(wsMessage{websocket.BinaryMessage, []byte("f")})
+
+	for _, tc := range []struct {
+		after  uint64
+		want   string
+		missed uint64
+	}{
+		{0, "e,f", 4},
+		{2, "e,f", 2},
+		{4, "e,f", 0},
+		{5, "f", 0},
+		{6, "", 0},
+		{9, "", 0},
+	} {
+		frames, missed := ring.since(tc.after)
+		var got []string
+		for _, f := range frames {
+			got = append(got, string(f.data))
+		}
+		if strings.Join(got, ",") != tc.want || missed != tc.missed {
+			t.Errorf("since(%d) = %q, %d missed; want %q, %d", tc.after, got, missed, tc.want, tc.missed)
+		}
+	}
+}
+
+func TestChatSession_Resume(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"text","content":"`+string(msg)+`"}`))
+		}
+	}))
+	defer srv.Close()
+	backend, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chats := newChatSessions(time.Minute)
+	sess, cc := chats.start(1, 7, false, backend)
+	var resume resumeEvent
+	json.Unmarshal(expect(t, cc, `"type":"resume"`).data, &resume)
+	sess.fromClient(cc, websocket.TextMessage, []byte("one"))
+	expect(t, cc, `"content":"one"`)
+
+	// The connection drops; the agent answers meanwhile
+	sess.release(cc, true)
+	sess.fromClient(cc, websocket.TextMessage, []byte("ignored"))
+	sess.writeMu.Lock()
+	backend.WriteMessage(websocket.TextMessage, []byte("two"))
+	sess.writeMu.Unlock()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		sess.mu.Lock()
+		last := sess.ring.last
+		sess.mu.Unlock()
+		if last == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("reply while away not buffered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if _, stranger := chats.resume(resume.Token, 1, 8, 1); stranger != nil {
+		t.Error("resumed someone else's chat")
+	}
+	if _, elsewhere := chats.resume(resume.Token, 2, 7, 1); elsewhere != nil {
+		t.Error("resumed the chat on another instance")
+	}
+	resumed, cc := chats.resume(resume.Token, 1, 7, 1)
+	if resumed != sess || cc == nil {
+		t.Fatalf("resume = %p, %p", resumed, cc)
+	}
+	json.Unmarshal(expect(t, cc, `"type":"resume"`).data, &resume)
+	if resume.Seq != 1 || !resume.Resumed {
+		t.Errorf("resumed event = %+v", resume)
+	}
+	if len(cc.backlog) != 1 || !strings.Contains(string(cc.backlog[0].data), `"content":"two"`) {
+		t.Errorf("replayed = %v", cc.backlog)
+	}
+
+	// Closing on purpose ends the session at once
+	sess.release(cc, false)
+	if _, cc := chats.resume(resume.Token, 1, 7, 2); cc != nil {
+		t.Error("resumed a closed chat")
+	}
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/logan/cloudcode/internal/api/middleware"
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// SecretHandler serves the caller's secrets vault. Values are write-only:
+// they go in through Put and only ever come out inside the user's instances.
+type SecretHandler struct {
+	secrets *service.SecretService
+}
+
+// NewSecretHandler creates a new SecretHandler.
+func NewSecretHandler(secrets *service.SecretService) *SecretHandler {
+	return &SecretHandler{secrets: secrets}
+}
+
+// List handles GET /secrets.
+func (h *SecretHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == 0 {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	secrets, err := h.secrets.List(r.Context(), userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, secrets)
+}
+
+// Put handles PUT /secrets/{name} — creates or rotates a secret and pushes
+// it to the caller's running instances.
+func (h *SecretHandler) Put(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == 0 {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	var spec service.SecretSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	secret, err := h.secrets.Put(r.Context(), userID, chi.URLParam(r, "name"), spec)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, secret)
+}
+
+// Delete handles DELETE /secrets/{name}.
+func (h *SecretHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == 0 {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	if err := h.secrets.Delete(r.Context(), userID, chi.URLParam(r, "name")); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// Sync handles POST /secrets/sync — pushes the caller's secrets to their
+// running instances again, e.g. after a pod was rescheduled.
+func (h *SecretHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == 0 {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	if err := h.secrets.Sync(r.Context(), userID); err != nil {
+		slog.Warn("secret sync failed", "user_id", userID, "error", err)
+		response.Error(w, http.StatusBadGateway, "could not push secrets to every running instance")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"status": "synced"})
+}
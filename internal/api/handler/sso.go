@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/service"
+	"github.com/logan/cloudcode/internal/sso"
+)
+
+// ssoStateCookie carries the sealed login state between SSOLogin and
+// SSOCallback. It is scoped to the SSO routes.
+const ssoStateCookie = "sso_state"
+
+// SSOProviders handles GET /auth/sso/providers — lists the providers the
+// login page can offer.
+func (h *AuthHandler) SSOProviders(w http.ResponseWriter, r *http.Request) {
+	response.JSON(w, http.StatusOK, h.auth.SSOProviders())
+}
+
+// SSOLogin handles GET /auth/sso/{provider}?redirect={path} — sends the
+// browser to the provider to sign in.
+func (h *AuthHandler) SSOLogin(w http.ResponseWriter, r *http.Request) {
+	authURL, sealed, err := h.auth.StartSSO(r.Context(), chi.URLParam(r, "provider"), r.URL.Query().Get("redirect"))
+	if err != nil {
+		if errors.Is(err, service.ErrSSOProviderNotFound) {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		slog.Error("sso login failed", "provider", chi.URLParam(r, "provider"), "error", err)
+		response.Error(w, http.StatusBadGateway, "sign-in provider unavailable")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoStateCookie,
+		Value:    sealed,
+		Path:     "/auth/sso/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(service.SSOStateTTL.Seconds()),
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// SSOCallback handles GET /auth/sso/{provider}/callback — the provider
+// returns here with a code. On success the session cookie is set and the
+// browser goes on to the frontend; on failure it goes back to the login page
+// with an error code.
+func (h *AuthHandler) SSOCallback(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "provider")
+	q := r.URL.Query()
+	http.SetCookie(w, &http.Cookie{Name: ssoStateCookie, Path: "/auth/sso/", MaxAge: -1})
+
+	fail := func(code string) {
+		http.Redirect(w, r, h.frontendURL+"/auth/login?error="+url.QueryEscape(code), http.StatusFound)
+	}
+	if q.Get("error") != "" {
+		// Typically access_denied: the user cancelled at the provider
+		fail("sso_cancelled")
+		return
+	}
+	var sealed string
+	if c, err := r.Cookie(ssoStateCookie); err == nil {
+		sealed = c.Value
+	}
+
+	_, redirect, err := h.auth.CompleteSSO(clientContext(r), w, name, sealed, q.Get("state"), q.Get("code"))
+	switch {
+	case err == nil:
+		http.Redirect(w, r, h.frontendURL+redirect, http.StatusFound)
+	case errors.Is(err, sso.ErrEmailNotVerified):
+		fail("sso_email_unverified")
+	case errors.Is(err, service.ErrSSODomainNotAllowed):
+		fail("sso_domain_not_allowed")
+	case errors.Is(err, service.ErrSSOStateInvalid), errors.Is(err, service.ErrSSOProviderNotFound):
+		fail("sso_expired")
+	default:
+		slog.Error("sso callback failed", "provider", name, "error", err)
+		fail("sso_failed")
+	}
+}
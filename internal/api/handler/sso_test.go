@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	"github.com/logan/cloudcode/internal/service"
+	"github.com/logan/cloudcode/internal/sso"
+	"github.com/logan/cloudcode/internal/sso/ssotest"
+)
+
+func TestSSOEndpoints(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_sso_handler?mode=memory&_fk=1")
+	defer client.Close()
+	issuer := ssotest.NewIssuer(t)
+	p, err := sso.New(sso.Config{
+		Name:         "keycloak",
+		Issuer:       issuer.URL,
+		ClientID:     issuer.ClientID,
+		ClientSecret: issuer.ClientSecret,
+		RedirectURL:  "http://api/auth/sso/keycloak/callback",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	authSvc := service.NewAuthService(client, "test-jwt-secret", "http://api", "http://app", service.NewLogMailer(slog.Default()))
+	authSvc.SetSSOProviders([]sso.Provider{p})
+	h := NewAuthHandler(authSvc, "http://app", false)
+	r := chi.NewRouter()
+	r.Get("/auth/sso/{provider}", h.SSOLogin)
+	r.Get("/auth/sso/{provider}/callback", h.SSOCallback)
+
+	get := func(path string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+	cookie := func(rec *httptest.ResponseRecorder, name string) *http.Cookie {
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == name && c.MaxAge >= 0 {
+				return c
+			}
+		}
+		return nil
+	}
+
+	if rec := get("/auth/sso/nope"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown provider: %d", rec.Code)
+	}
+
+	rec := get("/auth/sso/keycloak?redirect=/dashboard/chat")
+	state := cookie(rec, ssoStateCookie)
+	if rec.Code != http.StatusFound || state == nil {
+		t.Fatalf("login: %d, state cookie %v", rec.Code, state)
+	}
+	code, st := issuer.Authorize(t, rec.Header().Get("Location"), ssotest.User{Subject: "kc-1", Email: "dev@example.com", EmailVerified: true})
+	callback := "/auth/sso/keycloak/callback?" + url.Values{"code": {code}, "state": {st}}.Encode()
+
+	// Without the state cookie the callback is refused
+	if rec := get(callback); rec.Header().Get("Location") != "http://app/auth/login?error=sso_expired" {
+		t.Errorf("callback without cookie -> %s", rec.Header().Get("Location"))
+	}
+	if rec := get("/auth/sso/keycloak/callback?error=access_denied&state="+st, state); rec.Header().Get("Location") != "http://app/auth/login?error=sso_cancelled" {
+		t.Errorf("cancelled -> %s", rec.Header().Get("Location"))
+	}
+
+	rec = get(callback, state)
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != "http://app/dashboard/chat" {
+		t.Fatalf("callback: %d -> %s", rec.Code, rec.Header().Get("Location"))
+	}
+	if cookie(rec, "session") == nil {
+		t.Error("no session cookie")
+	}
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// TemplateHandler serves instance environment templates.
+// Templates an admin (X-API-Key) creates are global.
+type TemplateHandler struct {
+	templates *service.TemplateService
+}
+
+// NewTemplateHandler creates a new TemplateHandler.
+func NewTemplateHandler(templates *service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{templates: templates}
+}
+
+// List handles GET /templates — returns the global templates and the
+// caller's own. Admin callers get every template.
+func (h *TemplateHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	templates, err := h.templates.List(r.Context(), userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, templates)
+}
+
+// Create handles POST /templates.
+func (h *TemplateHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	var spec service.TemplateSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	t, err := h.templates.Create(r.Context(), userID, spec)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, t)
+}
+
+// Get handles GET /templates/{id}.
+func (h *TemplateHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid template ID")
+		return
+	}
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	t, err := h.templates.Get(r.Context(), userID, id)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, t)
+}
+
+// Delete handles DELETE /templates/{id}.
+// Users can only delete their own templates; admin can delete any.
+func (h *TemplateHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid template ID")
+		return
+	}
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.templates.Delete(r.Context(), userID, id); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,517 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// Client roles in a shared terminal. There is at most one writer; everyone
+// else watches.
+const (
+	roleWriter = "writer"
+	roleViewer = "viewer"
+)
+
+// backendWriteTimeout is how long a write to ttyd may take before the hub
+// gives up on the terminal.
+var backendWriteTimeout = 10 * time.Second
+
+// terminalHubs shares one ttyd connection per instance among everyone with
+// its terminal open.
+type terminalHubs struct {
+	grace time.Duration // how long a dropped client is waited for
+
+	mu   sync.Mutex
+	hubs map[int]*terminalHub
+}
+
+func newTerminalHubs(grace time.Duration) *terminalHubs {
+	return &terminalHubs{grace: grace, hubs: make(map[int]*terminalHub)}
+}
+
+// hubOpener dials the backend for a new hub and starts its recording, if
+// the instance is recorded. ctx is what the recording is saved with.
+type hubOpener func() (backend *websocket.Conn, rec *service.Recorder, ctx context.Context, err error)
+
+// join adds c to the instance's hub, starting the hub with open if there is
+// none. Clients joining while it starts wait for it.
+func (hs *terminalHubs) join(instanceID int, c *hubClient, open hubOpener) (*terminalHub, *clientConn, error) {
+	for {
+		hs.mu.Lock()
+		hub := hs.hubs[instanceID]
+		starting := hub == nil
+		if starting {
+			hub = &terminalHub{hubs: hs, instanceID: instanceID, grace: hs.grace, ready: make(chan struct{})}
+			hs.hubs[instanceID] = hub
+		}
+		hs.mu.Unlock()
+
+		if starting {
+			hub.start(open)
+		}
+		<-hub.ready
+		if hub.err != nil {
+			return nil, nil, hub.err
+		}
+		if cc := hub.add(c); cc != nil {
+			return hub, cc, nil
+		}
+		// The hub closed as we arrived; start another
+	}
+}
+
+// resume reattaches the client holding token to the instance's hub, if it
+// is still there and is the same caller as c.
+func (hs *terminalHubs) resume(instanceID int, token string, c *hubClient, after uint64) (*terminalHub, *hubClient, *clientConn) {
+	hs.mu.Lock()
+	hub := hs.hubs[instanceID]
+	hs.mu.Unlock()
+	if hub == nil {
+		return nil, nil, nil
+	}
+	resumed, cc := hub.resume(token, c, after)
+	if cc == nil {
+		return nil, nil, nil
+	}
+	return hub, resumed, cc
+}
+
+// dropShare disconnects everyone watching the instance through a share link.
+func (hs *terminalHubs) dropShare(instanceID, shareID int) {
+	hs.mu.Lock()
+	hub := hs.hubs[instanceID]
+	hs.mu.Unlock()
+	if hub == nil {
+		return
+	}
+	hub.mu.Lock()
+	for _, c := range append([]*hubClient(nil), hub.clients...) {
+		if c.shareID == shareID {
+			hub.dropLocked(c, websocket.ClosePolicyViolation, "share link revoked")
+		}
+	}
+	empty := len(hub.clients) == 0
+	hub.mu.Unlock()
+	if empty {
+		hub.close(websocket.CloseNormalClosure, "")
+	}
+}
+
+func (hs *terminalHubs) remove(instanceID int, hub *terminalHub) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	if hs.hubs[instanceID] == hub {
+		delete(hs.hubs, instanceID)
+	}
+}
+
+// terminalHub fans one ttyd connection out to many clients. Output goes to
+// everyone; input and resizes are taken from the writer alone. Clients whose
+// connection drops stay in the hub, away, for the grace period, and the
+// backend stays open while anyone is there.
+type terminalHub struct {
+	hubs       *terminalHubs
+	instanceID int
+	grace      time.Duration
+	ready      chan struct{} // closed once started, or failed with err
+	err        error
+
+	backend *websocket.Conn
+	writeMu sync.Mutex // one writer at a time on backend
+	rec     *service.Recorder
+	recCtx  context.Context
+
+	mu      sync.Mutex
+	clients []*hubClient // in the order they joined
+	writer  *hubClient
+	ring    *frameRing // recent backend frames, for late joiners and resumes
+	nextID  int
+	closed  bool
+}
+
+// hubClient is someone in a hub. They reach it through one connection at a
+// time, none while away.
+type hubClient struct {
+	name     string
+	userID   int
+	canWrite bool // may become the writer
+	shareID  int  // share link the client watches through, if any
+
+	// Set by the hub
+	id       int
+	token    string // resumes the client
+	joinedAt time.Time
+	cols     int
+	rows     int
+	conn     *clientConn // nil while away
+	away     *time.Timer // drops the client when the grace period ends
+}
+
+func newHubClient(name string, userID int, canWrite bool, shareID int) *hubClient {
+	return &hubClient{name: name, userID: userID, canWrite: canWrite, shareID: shareID}
+}
+
+// presenceClient describes a client in presence events.
+type presenceClient struct {
+	ID       int       `json:"id"`
+	Name     string    `json:"name"`
+	Role     string    `json:"role"`            // writer or viewer
+	Guest    bool      `json:"guest,omitempty"` // watching through a share link
+	Away     bool      `json:"away,omitempty"`  // connection dropped; may resume
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// presenceEvent is sent to clients as a text message, which ttyd clients
+// ignore. A connecting client gets "hello" with itself as Client; everyone
+// else gets "presence" when a client joins, leaves, goes away, comes back
+// or becomes the writer.
+type presenceEvent struct {
+	Type    string           `json:"type"`            // hello or presence
+	Event   string           `json:"event,omitempty"` // join, leave, away, back or writer
+	Client  presenceClient   `json:"client"`
+	Clients []presenceClient `json:"clients"`
+}
+
+func (h *terminalHub) start(open hubOpener) {
+	defer close(h.ready)
+	backend, rec, ctx, err := open()
+	if err != nil {
+		h.err = err
+		h.hubs.remove(h.instanceID, h)
+		return
+	}
+	h.backend, h.rec, h.recCtx = backend, rec, ctx
+	h.ring = newFrameRing(replayBytes)
+	go h.run()
+}
+
+// run reads the backend until it closes, sending every frame to the
+// clients.
+func (h *terminalHub) run() {
+	for {
+		msgType, msg, err := h.backend.ReadMessage()
+		if err != nil {
+			slog.Debug("terminal hub: backend read error", "instance_id", h.instanceID, "error", err)
+			h.close(websocket.CloseGoingAway, "terminal closed")
+			return
+		}
+		if h.rec != nil && len(msg) > 0 && msg[0] == '0' {
+			h.rec.Output(msg[1:])
+		}
+
+		m := wsMessage{msgType, msg}
+		h.mu.Lock()
+		h.ring.push(m)
+		// Sending can drop clients, which changes h.clients
+		for _, c := range append([]*hubClient(nil), h.clients...) {
+			h.sendLocked(c, m)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// add joins c to the hub and returns its connection, which starts with
+// recent output. It returns nil if the hub has closed.
+func (h *terminalHub) add(c *hubClient) *clientConn {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil
+	}
+	h.nextID++
+	c.id = h.nextID
+	c.token = newResumeToken()
+	c.joinedAt = time.Now()
+	c.conn = newClientConn()
+	h.clients = append(h.clients, c)
+	if h.writer == nil && c.canWrite {
+		h.writer = c
+	}
+
+	frames, _ := h.ring.since(0)
+	c.conn.backlog = append(c.conn.backlog, h.presenceLocked(c, "hello", ""))
+	if h.rec != nil {
+		c.conn.backlog = append(c.conn.backlog, wsMessage{websocket.BinaryMessage, recordingNotice(h.rec.Input())})
+	}
+	c.conn.backlog = append(c.conn.backlog, resumeMessage(c.token, h.ring.last-uint64(len(frames)), false, 0))
+	c.conn.backlog = append(c.conn.backlog, frames...)
+	h.broadcastPresenceLocked(c, "join")
+	return c.conn
+}
+
+// resume gives the client holding token a new connection, which starts
+// with the frames after after, and ends the old one if it is still open.
+// It returns nil if there is no such client or it is not the caller in c.
+func (h *terminalHub) resume(token string, c *hubClient, after uint64) (*hubClient, *clientConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var found *hubClient
+	for _, other := range h.clients {
+		if other.token == token {
+			found = other
+			break
+		}
+	}
+	if found == nil || found.userID != c.userID || found.shareID != c.shareID {
+		return nil, nil
+	}
+	c = found
+
+	if c.conn != nil {
+		c.conn.end(websocket.CloseNormalClosure, "resumed on another connection")
+	}
+	if c.away != nil {
+		c.away.Stop()
+		c.away = nil
+	}
+	c.conn = newClientConn()
+
+	frames, missed := h.ring.since(after)
+	c.conn.backlog = append(c.conn.backlog, h.presenceLocked(c, "hello", ""))
+	c.conn.backlog = append(c.conn.backlog, resumeMessage(c.token, h.ring.last-uint64(len(frames)), true, missed))
+	c.conn.backlog = append(c.conn.backlog, frames...)
+	h.broadcastPresenceLocked(c, "back")
+	return c, c.conn
+}
+
+// release ends c's connection cc. If the connection dropped rather than
+// being closed, c stays in the hub, away, for the grace period. The hub
+// closes when its last client is gone.
+func (h *terminalHub) release(c *hubClient, cc *clientConn, resumable bool) {
+	h.mu.Lock()
+	if c.conn != cc {
+		// Resumed on another connection, or dropped by the hub
+		h.mu.Unlock()
+		return
+	}
+	if resumable && h.grace > 0 && !h.closed {
+		cc.end(0, "")
+		c.conn = nil
+		c.away = time.AfterFunc(h.grace, func() { h.expire(c) })
+		h.broadcastPresenceLocked(c, "away")
+		h.mu.Unlock()
+		return
+	}
+	h.mu.Unlock()
+	h.drop(c, 0, "")
+}
+
+// expire drops c if it is still away when its grace period ends.
+func (h *terminalHub) expire(c *hubClient) {
+	h.mu.Lock()
+	away := c.conn == nil && c.away != nil
+	h.mu.Unlock()
+	if away {
+		h.drop(c, 0, "")
+	}
+}
+
+// drop disconnects c with the given close code and reason. The hub closes
+// when its last client is gone.
+func (h *terminalHub) drop(c *hubClient, code int, reason string) {
+	h.mu.Lock()
+	h.dropLocked(c, code, reason)
+	empty := len(h.clients) == 0
+	h.mu.Unlock()
+	if empty {
+		h.close(websocket.CloseNormalClosure, "")
+	}
+}
+
+func (h *terminalHub) dropLocked(c *hubClient, code int, reason string) {
+	i := -1
+	for j, other := range h.clients {
+		if other == c {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		return
+	}
+	h.clients = append(h.clients[:i], h.clients[i+1:]...)
+	if c.conn != nil {
+		c.conn.end(code, reason)
+		c.conn = nil
+	}
+	if c.away != nil {
+		c.away.Stop()
+		c.away = nil
+	}
+
+	h.broadcastPresenceLocked(c, "leave")
+	if h.writer != c {
+		return
+	}
+	h.writer = nil
+	for _, next := range h.clients {
+		if next.canWrite {
+			h.writer = next
+			break
+		}
+	}
+	if h.writer == nil {
+		return
+	}
+	// The terminal takes the new writer's size, written once h.mu is free
+	if h.writer.cols > 0 {
+		msg := h.resizeLocked(h.writer.cols, h.writer.rows)
+		go h.writeBackend(websocket.BinaryMessage, msg)
+	}
+	h.broadcastPresenceLocked(h.writer, "writer")
+}
+
+// fromClient handles a ttyd message from c on connection cc. Viewers'
+// messages only update their own state; the writer's reach the terminal.
+func (h *terminalHub) fromClient(c *hubClient, cc *clientConn, msgType int, msg []byte) {
+	if len(msg) == 0 {
+		return
+	}
+	h.mu.Lock()
+	forward := h.acceptLocked(c, cc, msg)
+	h.mu.Unlock()
+	if forward {
+		h.writeBackend(msgType, msg)
+	}
+}
+
+// acceptLocked updates c's state from msg and reports whether msg goes on
+// to the terminal.
+func (h *terminalHub) acceptLocked(c *hubClient, cc *clientConn, msg []byte) bool {
+	if c.conn != cc {
+		return false
+	}
+	if msg[0] == '1' {
+		var size struct {
+			Columns int `json:"columns"`
+			Rows    int `json:"rows"`
+		}
+		if json.Unmarshal(msg[1:], &size) != nil {
+			return false
+		}
+		c.cols, c.rows = size.Columns, size.Rows
+	}
+	if h.writer != c || h.closed {
+		return false
+	}
+	switch msg[0] {
+	case '0', '1', '2', '3':
+		// Input, resize and flow control. The JSON handshake was sent when
+		// the hub started; ttyd takes only one.
+	default:
+		return false
+	}
+	if h.rec != nil {
+		recordClientMessage(h.rec, msg)
+	}
+	return true
+}
+
+// resizeLocked records a resize to cols x rows and returns the ttyd message
+// for it.
+func (h *terminalHub) resizeLocked(cols, rows int) []byte {
+	msg, _ := json.Marshal(map[string]int{"columns": cols, "rows": rows})
+	if h.rec != nil {
+		h.rec.Resize(cols, rows)
+	}
+	return append([]byte{'1'}, msg...)
+}
+
+// writeBackend writes msg to ttyd. It must be called without h.mu, so a
+// terminal that stops reading holds up only its writers; one that takes
+// longer than backendWriteTimeout closes the hub.
+func (h *terminalHub) writeBackend(msgType int, msg []byte) {
+	h.writeMu.Lock()
+	h.backend.SetWriteDeadline(time.Now().Add(backendWriteTimeout))
+	err := h.backend.WriteMessage(msgType, msg)
+	h.writeMu.Unlock()
+	if err != nil {
+		slog.Debug("terminal hub: backend write error", "instance_id", h.instanceID, "error", err)
+		h.close(websocket.CloseGoingAway, "terminal not responding")
+	}
+}
+
+// sendLocked queues m for c, dropping c if it has fallen too far behind.
+// Clients that are away catch up when they resume.
+func (h *terminalHub) sendLocked(c *hubClient, m wsMessage) {
+	if c.conn == nil || c.conn.queue(m) {
+		return
+	}
+	slog.Debug("terminal hub: dropping slow client", "instance_id", h.instanceID, "client", c.id)
+	h.dropLocked(c, websocket.CloseTryAgainLater, "too slow to keep up")
+}
+
+func (h *terminalHub) presenceLocked(c *hubClient, typ, event string) wsMessage {
+	ev := presenceEvent{Type: typ, Event: event, Client: h.describeLocked(c), Clients: []presenceClient{}}
+	for _, other := range h.clients {
+		ev.Clients = append(ev.Clients, h.describeLocked(other))
+	}
+	b, _ := json.Marshal(ev)
+	return wsMessage{websocket.TextMessage, b}
+}
+
+// broadcastPresenceLocked tells every client but subject what happened
+// to it.
+func (h *terminalHub) broadcastPresenceLocked(subject *hubClient, event string) {
+	m := h.presenceLocked(subject, "presence", event)
+	// Sending can drop clients, which changes h.clients
+	for _, c := range append([]*hubClient(nil), h.clients...) {
+		if c != subject {
+			h.sendLocked(c, m)
+		}
+	}
+}
+
+func (h *terminalHub) describeLocked(c *hubClient) presenceClient {
+	role := roleViewer
+	if c == h.writer {
+		role = roleWriter
+	}
+	return presenceClient{
+		ID:       c.id,
+		Name:     c.name,
+		Role:     role,
+		Guest:    c.shareID != 0,
+		Away:     c.conn == nil,
+		JoinedAt: c.joinedAt,
+	}
+}
+
+// close disconnects every client and the backend and saves the recording.
+func (h *terminalHub) close(code int, reason string) {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return
+	}
+	h.closed = true
+	for _, c := range h.clients {
+		if c.conn != nil {
+			c.conn.end(code, reason)
+			c.conn = nil
+		}
+		if c.away != nil {
+			c.away.Stop()
+			c.away = nil
+		}
+	}
+	h.clients, h.writer = nil, nil
+	h.mu.Unlock()
+
+	h.hubs.remove(h.instanceID, h)
+	h.backend.Close()
+	if h.rec != nil {
+		ctx, cancel := context.WithTimeout(h.recCtx, time.Minute)
+		defer cancel()
+		if err := h.rec.Close(ctx); err != nil {
+			slog.Error("terminal hub: saving recording failed", "instance_id", h.instanceID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// fakeTTYD echoes input as output and reports every message it receives.
+func fakeTTYD(t *testing.T) (open hubOpener, received <-chan string) {
+	t.Helper()
+	msgs := make(chan string, 64)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				close(msgs)
+				return
+			}
+			msgs <- string(msg)
+			if msg[0] == '0' {
+				conn.WriteMessage(websocket.BinaryMessage, append([]byte("0echo:"), msg[1:]...))
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	open = func() (*websocket.Conn, *service.Recorder, context.Context, error) {
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"AuthToken":""}`))
+		return conn, nil, context.Background(), nil
+	}
+	return open, msgs
+}
+
+// expect reads cc's messages, its backlog first, until one contains want.
+func expect(t *testing.T, cc *clientConn, want string) wsMessage {
+	t.Helper()
+	for len(cc.backlog) > 0 {
+		m := cc.backlog[0]
+		cc.backlog = cc.backlog[1:]
+		if strings.Contains(string(m.data), want) {
+			return m
+		}
+	}
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case m, ok := <-cc.send:
+			if !ok {
+				t.Fatalf("connection ended (%d %s) waiting for %q", cc.code, cc.reason, want)
+			}
+			if strings.Contains(string(m.data), want) {
+				return m
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %q", want)
+		}
+	}
+}
+
+func expectBackend(t *testing.T, received <-chan string, want string) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case msg := <-received:
+			if msg == want {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("backend: timed out waiting for %q", want)
+		}
+	}
+}
+
+func TestTerminalHub_WriterAndViewers(t *testing.T) {
+	open, received := fakeTTYD(t)
+	hubs := newTerminalHubs(0)
+
+	alice := newHubClient("alice", 1, true, 0)
+	hub, aliceCC, err := hubs.join(1, alice, open)
+	if err != nil {
+		t.Fatalf("join: %v", err)
+	}
+	var hello presenceEvent
+	json.Unmarshal(expect(t, aliceCC, `"hello"`).data, &hello)
+	if hello.Client.Role != roleWriter || hello.Client.Name != "alice" {
+		t.Errorf("alice hello = %+v", hello)
+	}
+	expectBackend(t, received, `{"AuthToken":""}`)
+
+	guest := newHubClient("guest", 0, false, 7)
+	bob := newHubClient("bob", 2, true, 0)
+	conns := map[*hubClient]*clientConn{alice: aliceCC}
+	for _, c := range []*hubClient{guest, bob} {
+		other, cc, err := hubs.join(1, c, open)
+		if err != nil || other != hub {
+			t.Fatalf("join %s = %p, %v; want the running hub", c.name, other, err)
+		}
+		conns[c] = cc
+	}
+	expect(t, aliceCC, `"event":"join","client":{"id":3,"name":"bob","role":"viewer"`)
+
+	// Only the writer reaches the terminal
+	hub.fromClient(guest, conns[guest], websocket.BinaryMessage, []byte("0rm -rf /\r"))
+	hub.fromClient(bob, conns[bob], websocket.BinaryMessage, []byte(`1{"columns":100,"rows":30}`))
+	hub.fromClient(alice, aliceCC, websocket.BinaryMessage, []byte("0ls"))
+	expectBackend(t, received, "0ls")
+	for _, cc := range conns {
+		expect(t, cc, "0echo:ls")
+	}
+
+	// The writer role passes on, and the terminal takes the new size
+	hub.release(alice, aliceCC, false)
+	expectBackend(t, received, `1{"columns":100,"rows":30}`)
+	var ev presenceEvent
+	json.Unmarshal(expect(t, conns[guest], `"event":"writer"`).data, &ev)
+	if ev.Client.Name != "bob" || ev.Client.Role != roleWriter || len(ev.Clients) != 2 {
+		t.Errorf("writer event = %+v", ev)
+	}
+
+	// Revoking the link drops whoever watches through it
+	hubs.dropShare(1, 7)
+	for range conns[guest].send {
+	}
+	if conns[guest].code != websocket.ClosePolicyViolation {
+		t.Errorf("guest closed with %d %q", conns[guest].code, conns[guest].reason)
+	}
+
+	// Late joiners catch up on recent output
+	carol := newHubClient("carol", 3, false, 0)
+	_, carolCC, err := hubs.join(1, carol, open)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := expect(t, carolCC, "echo:ls"); m.data[0] != '0' {
+		t.Errorf("replayed = %q", m.data)
+	}
+
+	// The last one out closes the backend
+	hub.release(bob, conns[bob], false)
+	hub.release(carol, carolCC, false)
+	expectClosed(t, received)
+	hubs.mu.Lock()
+	defer hubs.mu.Unlock()
+	if len(hubs.hubs) != 0 {
+		t.Errorf("hubs left: %v", hubs.hubs)
+	}
+}
+
+func TestTerminalHub_Resume(t *testing.T) {
+	open, received := fakeTTYD(t)
+	hubs := newTerminalHubs(time.Minute)
+
+	alice := newHubClient("alice", 1, true, 0)
+	hub, cc, err := hubs.join(1, alice, open)
+	if err != nil {
+		t.Fatalf("join: %v", err)
+	}
+	var resume resumeEvent
+	json.Unmarshal(expect(t, cc, `"type":"resume"`).data, &resume)
+	if resume.Token == "" || resume.Seq != 0 || resume.Resumed {
+		t.Errorf("resume event = %+v", resume)
+	}
+	hub.fromClient(alice, cc, websocket.BinaryMessage, []byte("0one"))
+	expect(t, cc, "echo:one")
+
+	// The connection drops; output goes on meanwhile
+	bob := newHubClient("bob", 2, false, 0)
+	_, bobCC, _ := hubs.join(1, bob, open)
+	hub.release(alice, cc, true)
+	expect(t, bobCC, `"event":"away"`)
+	hub.writeBackend(websocket.BinaryMessage, []byte("0two"))
+	expect(t, bobCC, "echo:two")
+
+	if _, _, stranger := hubs.resume(1, resume.Token, newHubClient("mallory", 2, true, 0), 0); stranger != nil {
+		t.Error("resumed someone else's client")
+	}
+	resumed, c, cc := hubs.resume(1, resume.Token, newHubClient("alice", 1, true, 0), 1)
+	if resumed != hub || c != alice || cc == nil {
+		t.Fatalf("resume = %p, %p, %p", resumed, c, cc)
+	}
+	json.Unmarshal(expect(t, cc, `"type":"resume"`).data, &resume)
+	if resume.Seq != 1 || !resume.Resumed || resume.Missed != 0 {
+		t.Errorf("resumed event = %+v", resume)
+	}
+	if len(cc.backlog) != 1 || string(cc.backlog[0].data) != "0echo:two" {
+		t.Errorf("replayed %d frames", len(cc.backlog))
+	}
+	expect(t, bobCC, `"event":"back"`)
+
+	// Still the writer
+	hub.fromClient(alice, cc, websocket.BinaryMessage, []byte("0three"))
+	expectBackend(t, received, "0three")
+
+	// Away clients keep the backend open until their grace period ends
+	hub.mu.Lock()
+	hub.grace = 10 * time.Millisecond
+	hub.mu.Unlock()
+	hub.release(alice, cc, true)
+	hub.release(bob, bobCC, false)
+	expectClosed(t, received)
+}
+
+// expectClosed waits for the fake backend connection to close.
+func expectClosed(t *testing.T, received <-chan string) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for open := true; open; {
+		select {
+		case _, open = <-received:
+		case <-timeout:
+			t.Fatal("backend still open")
+		}
+	}
+}
+
+func TestTerminalHub_BackendUnavailable(t *testing.T) {
+	hubs := newTerminalHubs(0)
+	open := func() (*websocket.Conn, *service.Recorder, context.Context, error) {
+		return nil, nil, nil, errRecordingUnavailable
+	}
+	if _, _, err := hubs.join(1, newHubClient("alice", 1, true, 0), open); err != errRecordingUnavailable {
+		t.Errorf("join = %v", err)
+	}
+	if len(hubs.hubs) != 0 {
+		t.Errorf("failed hub kept: %v", hubs.hubs)
+	}
+}
+
+func TestTerminalHub_StalledBackend(t *testing.T) {
+	defer func(d time.Duration) { backendWriteTimeout = d }(backendWriteTimeout)
+	backendWriteTimeout = time.Second
+
+	// A terminal that takes the connection and then never reads
+	stalled := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		<-stalled
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(stalled) })
+	open := func() (*websocket.Conn, *service.Recorder, context.Context, error) {
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+		return conn, nil, context.Background(), err
+	}
+
+	hubs := newTerminalHubs(0)
+	alice := newHubClient("alice", 1, true, 0)
+	hub, cc, err := hubs.join(1, alice, open)
+	if err != nil {
+		t.Fatalf("join: %v", err)
+	}
+	closed := func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return hub.closed
+	}
+	go func() {
+		input := append([]byte{'0'}, strings.Repeat("x", 64<<10)...)
+		for !closed() {
+			hub.fromClient(alice, cc, websocket.BinaryMessage, input)
+		}
+	}()
+	for hub.writeMu.TryLock() {
+		hub.writeMu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The blocked write holds up no one else
+	joined := make(chan struct{})
+	go func() {
+		hub.add(newHubClient("bob", 2, false, 0))
+		close(joined)
+	}()
+	select {
+	case <-joined:
+	case <-time.After(backendWriteTimeout / 2):
+		t.Fatal("join blocked behind the backend write")
+	}
+
+	for {
+		select {
+		case _, ok := <-cc.send:
+			if ok {
+				continue
+			}
+			if cc.reason != "terminal not responding" {
+				t.Errorf("closed with %d %q", cc.code, cc.reason)
+			}
+			return
+		case <-time.After(5 * time.Second):
+			t.Fatal("hub still open")
+		}
+	}
+}
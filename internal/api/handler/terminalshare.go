@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+type createTerminalShareRequest struct {
+	Label string `json:"label"`
+	TTL   string `json:"ttl"` // Go duration, e.g. "30m"; one hour if empty
+}
+
+// ListShares handles GET /instances/{id}/terminal/shares.
+func (h *ProxyHandler) ListShares(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid instance ID")
+		return
+	}
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	shares, err := h.svc.ListTerminalShares(r.Context(), id, userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, shares)
+}
+
+// CreateShare handles POST /instances/{id}/terminal/shares, minting a link
+// to watch the terminal. The token is in this response only.
+func (h *ProxyHandler) CreateShare(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid instance ID")
+		return
+	}
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	var req createTerminalShareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	var ttl time.Duration
+	if req.TTL != "" {
+		if ttl, err = time.ParseDuration(req.TTL); err != nil {
+			response.Error(w, http.StatusBadRequest, "ttl must be a duration, e.g. 30m")
+			return
+		}
+	}
+
+	share, err := h.svc.CreateTerminalShare(r.Context(), id, userID, req.Label, ttl)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, share)
+}
+
+// RevokeShare handles DELETE /instances/{id}/terminal/shares/{shareID}.
+// Anyone watching through the link is disconnected.
+func (h *ProxyHandler) RevokeShare(w http.ResponseWriter, r *http.Request) {
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid instance ID")
+		return
+	}
+	shareID, err := service.ParseID(chi.URLParam(r, "shareID"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid share ID")
+		return
+	}
+	userID, ok := callerID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RevokeTerminalShare(r.Context(), id, userID, shareID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+	h.hubs.dropShare(id, shareID)
+
+	response.JSON(w, http.StatusOK, map[string]string{"status": "revoked"})
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/logan/cloudcode/internal/api/middleware"
+	"github.com/logan/cloudcode/internal/api/response"
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// TokenHandler serves the caller's personal access tokens. Tokens are
+// managed from a browser session only: a token cannot mint or revoke tokens.
+type TokenHandler struct {
+	tokens *service.TokenService
+}
+
+// NewTokenHandler creates a new TokenHandler.
+func NewTokenHandler(tokens *service.TokenService) *TokenHandler {
+	return &TokenHandler{tokens: tokens}
+}
+
+// sessionUserID returns the caller's user ID, writing an error and returning
+// 0 for admin, token-authenticated and impersonated requests. It guards
+// endpoints that hand out or revoke credentials, so a token cannot be used
+// to mint more and support staff cannot act on the user's sign-ins.
+func sessionUserID(w http.ResponseWriter, r *http.Request) int {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == 0 {
+		response.Error(w, http.StatusUnauthorized, "authentication required")
+		return 0
+	}
+	if _, ok := middleware.ScopesFromContext(r.Context()); ok {
+		response.Error(w, http.StatusForbidden, "this endpoint requires a browser session, not an access token")
+		return 0
+	}
+	if impersonated(w, r) {
+		return 0
+	}
+	return userID
+}
+
+// impersonated reports whether the request comes from a support session,
+// writing a 403 if so.
+func impersonated(w http.ResponseWriter, r *http.Request) bool {
+	if middleware.ImpersonatedFromContext(r.Context()) {
+		response.Error(w, http.StatusForbidden, "this endpoint is not available in a support session")
+		return true
+	}
+	return false
+}
+
+// List handles GET /auth/tokens.
+func (h *TokenHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := sessionUserID(w, r)
+	if userID == 0 {
+		return
+	}
+
+	tokens, err := h.tokens.List(r.Context(), userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, tokens)
+}
+
+// Create handles POST /auth/tokens. The response is the only time the token
+// is shown.
+func (h *TokenHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := sessionUserID(w, r)
+	if userID == 0 {
+		return
+	}
+
+	var spec service.TokenSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	token, err := h.tokens.Create(r.Context(), userID, spec)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, token)
+}
+
+// Revoke handles DELETE /auth/tokens/{id}.
+func (h *TokenHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	userID := sessionUserID(w, r)
+	if userID == 0 {
+		return
+	}
+
+	id, err := service.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid token ID")
+		return
+	}
+
+	if err := h.tokens.Revoke(r.Context(), userID, id); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"status": "revoked"})
+}
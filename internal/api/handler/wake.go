@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/logan/cloudcode/internal/service"
+)
+
+// wakeRetryAfter is how long a connect script for a waking instance waits
+// before fetching the script again.
+const wakeRetryAfter = 5 * time.Second
+
+// wakeEvent is sent to clients as a text message while the stopped instance
+// they connected to wakes: Step is "queued", "waking" and so on as each
+// begins, then "ready" just before the session starts.
+type wakeEvent struct {
+	Type string `json:"type"` // always "wake"
+	Step string `json:"step"`
+}
+
+// wakeInstance wakes the stopped instance id for userID, keeping the client
+// on conn posted with wake events, and returns the instance's host and
+// agent secret once its agent answers. If the instance does not wake it
+// closes conn instead and returns ok false.
+func (h *ProxyHandler) wakeInstance(conn *websocket.Conn, r *http.Request, id, userID int) (host, agentSecret string, ok bool) {
+	send := func(step string) {
+		b, _ := json.Marshal(wakeEvent{Type: "wake", Step: step})
+		conn.WriteMessage(websocket.TextMessage, b)
+	}
+	ctx := service.WithProgress(clientContext(r), send)
+
+	err := h.jobs.WakeForConnect(ctx, id, userID)
+	if err == nil {
+		host, agentSecret, _, err = h.svc.GetInstanceHost(ctx, id, userID)
+	}
+	if err != nil {
+		slog.Warn("proxy: wake on connect failed", "instance_id", id, "error", err)
+		reason := service.ErrWakeFailed.Error()
+		if errors.Is(err, service.ErrWakeTimeout) {
+			reason = err.Error()
+		}
+		conn.WriteMessage(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseTryAgainLater, reason))
+		return "", "", false
+	}
+	send("ready")
+	return host, agentSecret, true
+}
+
+// wakeScript is the connect script for an instance that is still waking.
+// It waits, then exits 75 (EX_TEMPFAIL), which claude-cloud connect takes
+// as its cue to fetch the script again.
+func wakeScript(name, step string) string {
+	return fmt.Sprintf(`#!/bin/bash
+
+echo "Waking Claude instance %s (%s)..."
+if [ -z "${CLAUDE_CLOUD_RETRY:-}" ]; then
+    echo "Run the connect command again in a few seconds."
+    exit 75
+fi
+sleep %d
+exit 75
+`, name, step, int(wakeRetryAfter.Seconds()))
+}
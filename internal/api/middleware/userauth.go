@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -15,39 +16,79 @@ const (
 	userIDKey  contextKey = "user_id"
 	emailKey   contextKey = "email"
 	isAdminKey contextKey = "is_admin"
+	scopesKey  contextKey = "scopes"
+	sessionKey contextKey = "session_id"
+	imperKey   contextKey = "impersonated"
 )
 
-// UserAuth returns middleware that supports dual-mode authentication:
-//  1. Bearer JWT token (Authorization header or "session" cookie)
-//  2. X-API-Key header (admin/backwards compat)
-func UserAuth(jwtSecret, adminAPIKey string) func(http.Handler) http.Handler {
+// errSessionRevoked is returned for session tokens whose session has been
+// signed out or revoked.
+var errSessionRevoked = errors.New("session revoked")
+
+// TokenAuthenticator resolves personal access tokens to their owner and
+// granted scopes.
+type TokenAuthenticator interface {
+	AuthenticateToken(ctx context.Context, token string) (userID int, email string, scopes []string, err error)
+}
+
+// SessionChecker reports whether the server-side session a session JWT was
+// issued for is still live.
+type SessionChecker interface {
+	SessionActive(ctx context.Context, sessionID string) bool
+}
+
+// ValidateSessionToken validates a session JWT. If sessions is non-nil the
+// token must name a session that has not been revoked.
+func ValidateSessionToken(ctx context.Context, keys *auth.Keyring, sessions SessionChecker, token string) (*auth.Claims, error) {
+	claims, err := keys.Validate(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "session" {
+		return nil, errors.New("invalid token purpose")
+	}
+	if sessions != nil && (claims.SessionID == "" || !sessions.SessionActive(ctx, claims.SessionID)) {
+		return nil, errSessionRevoked
+	}
+	return claims, nil
+}
+
+// UserAuth returns middleware that supports three authentication modes:
+//  1. Bearer JWT token (Authorization header or "session" cookie), whose
+//     session is checked against sessions if it is non-nil
+//  2. Bearer personal access token, if tokens is non-nil
+//  3. X-API-Key header (admin/backwards compat)
+func UserAuth(keys *auth.Keyring, adminAPIKey string, tokens TokenAuthenticator, sessions SessionChecker) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Try Bearer JWT first
 			if authHeader := r.Header.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
 				tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-				claims, err := auth.ValidateToken(jwtSecret, tokenStr)
-				if err != nil {
-					response.Error(w, http.StatusUnauthorized, "invalid token")
+				if tokens != nil && auth.IsAccessToken(tokenStr) {
+					userID, email, scopes, err := tokens.AuthenticateToken(r.Context(), tokenStr)
+					if err != nil {
+						response.Error(w, http.StatusUnauthorized, "invalid token")
+						return
+					}
+					ctx := context.WithValue(r.Context(), userIDKey, userID)
+					ctx = context.WithValue(ctx, emailKey, email)
+					ctx = context.WithValue(ctx, scopesKey, scopes)
+					next.ServeHTTP(w, r.WithContext(ctx))
 					return
 				}
-				if claims.Purpose != "session" {
-					response.Error(w, http.StatusUnauthorized, "invalid token purpose")
+				claims, err := ValidateSessionToken(r.Context(), keys, sessions, tokenStr)
+				if err != nil {
+					response.Error(w, http.StatusUnauthorized, "invalid token")
 					return
 				}
-				ctx := context.WithValue(r.Context(), userIDKey, claims.UserID)
-				ctx = context.WithValue(ctx, emailKey, claims.Email)
-				next.ServeHTTP(w, r.WithContext(ctx))
+				next.ServeHTTP(w, r.WithContext(sessionContext(r.Context(), claims)))
 				return
 			}
 
 			// Try session cookie
 			if cookie, err := r.Cookie("session"); err == nil {
-				claims, err := auth.ValidateToken(jwtSecret, cookie.Value)
-				if err == nil && claims.Purpose == "session" {
-					ctx := context.WithValue(r.Context(), userIDKey, claims.UserID)
-					ctx = context.WithValue(ctx, emailKey, claims.Email)
-					next.ServeHTTP(w, r.WithContext(ctx))
+				if claims, err := ValidateSessionToken(r.Context(), keys, sessions, cookie.Value); err == nil {
+					next.ServeHTTP(w, r.WithContext(sessionContext(r.Context(), claims)))
 					return
 				}
 			}
@@ -68,6 +109,15 @@ func UserAuth(jwtSecret, adminAPIKey string) func(http.Handler) http.Handler {
 	}
 }
 
+func sessionContext(ctx context.Context, claims *auth.Claims) context.Context {
+	ctx = context.WithValue(ctx, userIDKey, claims.UserID)
+	ctx = context.WithValue(ctx, emailKey, claims.Email)
+	if claims.Impersonated {
+		ctx = context.WithValue(ctx, imperKey, true)
+	}
+	return context.WithValue(ctx, sessionKey, claims.SessionID)
+}
+
 // UserIDFromContext returns the authenticated user's ID, or 0 if not set.
 func UserIDFromContext(ctx context.Context) int {
 	if id, ok := ctx.Value(userIDKey).(int); ok {
@@ -84,6 +134,23 @@ func EmailFromContext(ctx context.Context) string {
 	return ""
 }
 
+// SessionIDFromContext returns the ID of the session that authenticated the
+// request, or empty string for access tokens, admin requests and sessions
+// issued without one.
+func SessionIDFromContext(ctx context.Context) string {
+	if sid, ok := ctx.Value(sessionKey).(string); ok {
+		return sid
+	}
+	return ""
+}
+
+// ImpersonatedFromContext reports whether the request comes from a support
+// session an admin started as the user.
+func ImpersonatedFromContext(ctx context.Context) bool {
+	imp, _ := ctx.Value(imperKey).(bool)
+	return imp
+}
+
 // IsAdminContext returns true if the request was authenticated via X-API-Key.
 func IsAdminContext(ctx context.Context) bool {
 	if isAdmin, ok := ctx.Value(isAdminKey).(bool); ok {
@@ -92,5 +159,45 @@ func IsAdminContext(ctx context.Context) bool {
 	return false
 }
 
+// ScopesFromContext returns the scopes of the personal access token that
+// authenticated the request. ok is false for sessions and admin requests,
+// which are not restricted by scope.
+func ScopesFromContext(ctx context.Context) (scopes []string, ok bool) {
+	scopes, ok = ctx.Value(scopesKey).([]string)
+	return scopes, ok
+}
+
+// RequireScope returns middleware that rejects requests authenticated by a
+// personal access token lacking resource:read, or resource:write for methods
+// other than GET and HEAD.
+func RequireScope(resource string) func(http.Handler) http.Handler {
+	return requireScope(resource, false)
+}
+
+// RequireWriteScope is like RequireScope but always requires resource:write,
+// for routes such as the terminal proxy where reading is as good as writing.
+func RequireWriteScope(resource string) func(http.Handler) http.Handler {
+	return requireScope(resource, true)
+}
+
+func requireScope(resource string, alwaysWrite bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if scopes, ok := ScopesFromContext(r.Context()); ok {
+				write := alwaysWrite || (r.Method != http.MethodGet && r.Method != http.MethodHead)
+				if !auth.HasScope(scopes, resource, write) {
+					level := "read"
+					if write {
+						level = "write"
+					}
+					response.Error(w, http.StatusForbidden, "token lacks scope "+resource+":"+level)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // TestUserIDKey returns the context key for user_id (for testing only).
 func TestUserIDKey() contextKey { return userIDKey }
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,7 +15,7 @@ func TestUserAuth_BearerJWT(t *testing.T) {
 	secret := "test-secret"
 	token, _ := auth.GenerateToken(secret, 42, "user@test.com", "session", time.Hour)
 
-	handler := UserAuth(secret, "admin-key")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := UserAuth(auth.NewHMACKeyring(secret), "admin-key", nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if uid := UserIDFromContext(r.Context()); uid != 42 {
 			t.Errorf("userID = %d, want 42", uid)
 		}
@@ -40,7 +42,7 @@ func TestUserAuth_Cookie(t *testing.T) {
 	secret := "test-secret"
 	token, _ := auth.GenerateToken(secret, 42, "user@test.com", "session", time.Hour)
 
-	handler := UserAuth(secret, "admin-key")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := UserAuth(auth.NewHMACKeyring(secret), "admin-key", nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if uid := UserIDFromContext(r.Context()); uid != 42 {
 			t.Errorf("userID = %d, want 42", uid)
 		}
@@ -58,7 +60,7 @@ func TestUserAuth_Cookie(t *testing.T) {
 }
 
 func TestUserAuth_APIKey(t *testing.T) {
-	handler := UserAuth("secret", "admin-key")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := UserAuth(auth.NewHMACKeyring("secret"), "admin-key", nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !IsAdminContext(r.Context()) {
 			t.Error("expected admin context")
 		}
@@ -79,7 +81,7 @@ func TestUserAuth_APIKey(t *testing.T) {
 }
 
 func TestUserAuth_InvalidAPIKey(t *testing.T) {
-	handler := UserAuth("secret", "admin-key")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := UserAuth(auth.NewHMACKeyring("secret"), "admin-key", nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Error("handler should not be called")
 	}))
 
@@ -94,7 +96,7 @@ func TestUserAuth_InvalidAPIKey(t *testing.T) {
 }
 
 func TestUserAuth_NoAuth(t *testing.T) {
-	handler := UserAuth("secret", "key")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := UserAuth(auth.NewHMACKeyring("secret"), "key", nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Error("handler should not be called")
 	}))
 
@@ -108,7 +110,7 @@ func TestUserAuth_NoAuth(t *testing.T) {
 }
 
 func TestUserAuth_InvalidJWT(t *testing.T) {
-	handler := UserAuth("secret", "key")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := UserAuth(auth.NewHMACKeyring("secret"), "key", nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Error("handler should not be called")
 	}))
 
@@ -126,7 +128,7 @@ func TestUserAuth_MagicLinkTokenRejected(t *testing.T) {
 	secret := "test-secret"
 	token, _ := auth.GenerateToken(secret, 1, "user@test.com", "magic_link", time.Hour)
 
-	handler := UserAuth(secret, "key")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := UserAuth(auth.NewHMACKeyring(secret), "key", nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Error("handler should not be called")
 	}))
 
@@ -139,3 +141,102 @@ func TestUserAuth_MagicLinkTokenRejected(t *testing.T) {
 		t.Errorf("status = %d, want 401", rec.Code)
 	}
 }
+
+// fakeTokens accepts a single access token.
+type fakeTokens struct{ scopes []string }
+
+func (f fakeTokens) AuthenticateToken(_ context.Context, token string) (int, string, []string, error) {
+	if token != "ccp_good" {
+		return 0, "", nil, errors.New("rejected")
+	}
+	return 7, "ci@test.com", f.scopes, nil
+}
+
+func TestUserAuth_AccessToken(t *testing.T) {
+	tokens := fakeTokens{scopes: []string{"instances:read"}}
+	handler := UserAuth(auth.NewHMACKeyring("secret"), "key", tokens, nil)(RequireScope("instances")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if uid := UserIDFromContext(r.Context()); uid != 7 {
+			t.Errorf("userID = %d, want 7", uid)
+		}
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	for _, tc := range []struct {
+		method, token string
+		want          int
+	}{
+		{"GET", "ccp_good", http.StatusOK},
+		{"POST", "ccp_good", http.StatusForbidden}, // read scope only
+		{"GET", "ccp_revoked", http.StatusUnauthorized},
+	} {
+		req := httptest.NewRequest(tc.method, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+tc.token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s with %s: status = %d, want %d", tc.method, tc.token, rec.Code, tc.want)
+		}
+	}
+}
+
+func TestRequireScope_SessionUnrestricted(t *testing.T) {
+	secret := "test-secret"
+	token, _ := auth.GenerateToken(secret, 42, "user@test.com", "session", time.Hour)
+	handler := UserAuth(auth.NewHMACKeyring(secret), "key", fakeTokens{}, nil)(RequireWriteScope("secrets")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", rec.Code)
+	}
+}
+
+// fakeSessions knows which sessions are still signed in.
+type fakeSessions map[string]bool
+
+func (f fakeSessions) SessionActive(_ context.Context, sessionID string) bool {
+	return f[sessionID]
+}
+
+func TestUserAuth_RevokedSession(t *testing.T) {
+	secret := "test-secret"
+	sessions := fakeSessions{"live": true, "revoked": false}
+	handler := UserAuth(auth.NewHMACKeyring(secret), "key", nil, sessions)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sid := SessionIDFromContext(r.Context()); sid != "live" {
+			t.Errorf("session = %q, want live", sid)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	legacy, _ := auth.GenerateToken(secret, 42, "user@test.com", "session", time.Hour)
+	for _, tc := range []struct {
+		name, token string
+		want        int
+	}{
+		{"live", mustSessionToken(t, secret, "live"), http.StatusOK},
+		{"revoked", mustSessionToken(t, secret, "revoked"), http.StatusUnauthorized},
+		{"no session ID", legacy, http.StatusUnauthorized},
+	} {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.AddCookie(&http.Cookie{Name: "session", Value: tc.token})
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: status = %d, want %d", tc.name, rec.Code, tc.want)
+		}
+	}
+}
+
+func mustSessionToken(t *testing.T, secret, sessionID string) string {
+	t.Helper()
+	token, err := auth.GenerateSessionToken(secret, 42, "user@test.com", sessionID, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/logan/cloudcode/internal/api/handler"
 	"github.com/logan/cloudcode/internal/api/middleware"
+	"github.com/logan/cloudcode/internal/auth"
 	"github.com/logan/cloudcode/internal/config"
 	"github.com/logan/cloudcode/internal/service"
 )
@@ -18,9 +20,19 @@ import (
 // Services bundles all service dependencies for the router.
 type Services struct {
 	Instance     *service.InstanceService
+	Jobs         *service.JobService
+	Backups      *service.BackupService // nil if no backup store configured
+	Recordings   *service.RecordingService // nil if no recording store configured
+	Templates    *service.TemplateService
+	Secrets      *service.SecretService // nil if no master key configured
+	Tokens       *service.TokenService
+	Orgs         *service.OrgService
 	Auth         *service.AuthService
 	Billing      *service.BillingService      // nil if Stripe not configured
 	Conversation *service.ConversationService
+	Admin        *service.AdminService // nil disables /admin
+	Audit        *service.AuditService // nil disables the audit log API
+	Keys         *auth.Keyring // nil = HS256 with the JWT secret
 	DB           *sql.DB
 	Version      string
 	Logger       *slog.Logger
@@ -51,8 +63,30 @@ func NewRouter(cfg *config.Config, svcs *Services) http.Handler {
 	// Prometheus metrics (no auth)
 	r.Handle("/metrics", promhttp.Handler())
 
-	// Connect script (no user auth — supports Bearer, cookie, or ?user_id)
-	ch := handler.NewConnectHandler(svcs.Instance, cfg.JWTSecret)
+	// JWT keys; public keys are published so other services can verify our
+	// tokens
+	keys := svcs.Keys
+	if keys == nil {
+		keys = auth.NewHMACKeyring(cfg.JWTSecret)
+	}
+	r.Get("/.well-known/jwks.json", handler.JWKS(keys))
+
+	// Personal access tokens, accepted wherever a session is
+	var tokens middleware.TokenAuthenticator
+	if svcs.Tokens != nil {
+		tokens = svcs.Tokens
+	}
+
+	// Server-side sessions, so signed-out session JWTs stop working at once
+	var sessions middleware.SessionChecker
+	if svcs.Auth != nil {
+		sessions = svcs.Auth
+	}
+
+	// Connect script (no user auth — supports Bearer, cookie, or ?user_id in development)
+	ch := handler.NewConnectHandler(svcs.Instance, keys, tokens, sessions)
+	ch.SetJobService(svcs.Jobs)
+	ch.SetDevMode(cfg.Environment != "production")
 	r.Get("/connect.sh", ch.ServeScript)
 
 	// Install script (no auth)
@@ -64,8 +98,19 @@ func NewRouter(cfg *config.Config, svcs *Services) http.Handler {
 	r.Group(func(r chi.Router) {
 		r.Use(middleware.RateLimit(5.0/60.0, 5)) // 5 req/min
 		r.Post("/auth/login", ah.Login)
+		r.Post("/auth/device/code", ah.DeviceCode)
+		r.Get("/auth/sso/{provider}", ah.SSOLogin)
 	})
 	r.Get("/auth/verify", ah.Verify)
+	r.Post("/auth/logout", ah.Logout)
+	r.Get("/auth/sso/providers", ah.SSOProviders)
+	r.Get("/auth/sso/{provider}/callback", ah.SSOCallback)
+
+	// Device token polling; clients are also held to the per-code interval
+	r.With(middleware.RateLimit(1, 10)).Post("/auth/device/token", ah.DeviceToken)
+
+	// Session refresh; refresh tokens rotate on every use
+	r.With(middleware.RateLimit(1, 10)).Post("/auth/refresh", ah.Refresh)
 
 	// Billing webhook (no user auth — verified by Stripe signature)
 	var bh *handler.BillingHandler
@@ -74,47 +119,186 @@ func NewRouter(cfg *config.Config, svcs *Services) http.Handler {
 		r.Post("/billing/webhook", bh.Webhook)
 	}
 
+	// Operator API; the admin API key only, never user credentials
+	if svcs.Admin != nil && cfg.APIKey != "" {
+		adminH := handler.NewAdminHandler(svcs.Admin)
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(middleware.APIKeyAuth(cfg.APIKey))
+			r.Use(handler.ClientContext)
+			r.Get("/users", adminH.ListUsers)
+			r.Get("/users/{id}", adminH.GetUser)
+			r.Put("/users/{id}/plan", adminH.SetPlan)
+			r.Post("/users/{id}/impersonate", adminH.Impersonate)
+			r.Delete("/users/{id}/instances", adminH.DestroyUserInstances)
+			r.Get("/instances", adminH.ListInstances)
+			r.Post("/instances/pause-idle", adminH.PauseIdle)
+			r.Post("/instances/{id}/pause", adminH.PauseInstance)
+			r.Delete("/instances/{id}", adminH.DestroyInstance)
+			r.Get("/usage", adminH.Usage)
+			if svcs.Audit != nil {
+				auditH := handler.NewAuditHandler(svcs.Audit)
+				r.Get("/audit", auditH.List)
+				r.Get("/audit/export", auditH.Export)
+				r.Get("/audit/verify", auditH.Verify)
+			}
+		})
+	}
+
 	// Proxy handler for instance terminal/chat/files
-	proxyH := handler.NewProxyHandler(svcs.Instance, cfg.JWTSecret)
+	proxyH := handler.NewProxyHandler(svcs.Instance, keys, sessions, svcs.Recordings)
+	resumeGrace, err := time.ParseDuration(cfg.ResumeGrace)
+	if err != nil {
+		resumeGrace = handler.DefaultResumeGrace
+	}
+	proxyH.SetResumeGrace(resumeGrace)
+	proxyH.SetJobService(svcs.Jobs)
+
+	// Shared terminals (no user auth — the share token is the credential)
+	r.With(middleware.RateLimit(1, 10), handler.ClientContext).Get("/terminal/shared", proxyH.SharedTerminal)
 
-	// Authenticated routes (dual-mode: JWT + API key)
+	// Authenticated routes (JWT, access token or API key). Access tokens are
+	// further limited by the scopes they were granted.
 	r.Group(func(r chi.Router) {
-		r.Use(middleware.UserAuth(cfg.JWTSecret, cfg.APIKey))
+		r.Use(middleware.UserAuth(keys, cfg.APIKey, tokens, sessions))
 		r.Use(middleware.RateLimit(1, 60)) // 60 req/min burst
+		r.Use(handler.ClientContext)       // client and actor for audit events
 
 		// Auth (me + settings)
 		r.Get("/auth/me", ah.Me)
-		r.Get("/auth/settings", ah.GetSettings)
-		r.Put("/auth/settings", ah.UpdateSettings)
+		r.With(middleware.RequireScope(auth.ScopeAccount)).Get("/auth/settings", ah.GetSettings)
+		r.With(middleware.RequireScope(auth.ScopeAccount)).Put("/auth/settings", ah.UpdateSettings)
+
+		// The caller's own audit trail
+		if svcs.Audit != nil {
+			auditH := handler.NewAuditHandler(svcs.Audit)
+			r.With(middleware.RequireScope(auth.ScopeAccount)).Get("/auth/audit", auditH.Mine)
+			r.With(middleware.RequireScope(auth.ScopeAccount)).Get("/auth/audit/export", auditH.ExportMine)
+		}
+
+		// Signed-in browsers and CLIs
+		r.Get("/auth/sessions", ah.Sessions)
+		r.Delete("/auth/sessions", ah.RevokeOtherSessions)
+		r.Delete("/auth/sessions/{id}", ah.RevokeSession)
+
+		// Device authorization approval (browser side of the CLI login)
+		r.Get("/auth/device", ah.DeviceInfo)
+		r.Post("/auth/device/approve", ah.DeviceDecide)
+
+		// Personal access tokens
+		if svcs.Tokens != nil {
+			tokenH := handler.NewTokenHandler(svcs.Tokens)
+			r.Route("/auth/tokens", func(r chi.Router) {
+				r.Get("/", tokenH.List)
+				r.Post("/", tokenH.Create)
+				r.Delete("/{id}", tokenH.Revoke)
+			})
+		}
 
 		// Instance routes
-		instH := handler.NewInstanceHandler(svcs.Instance)
+		instH := handler.NewInstanceHandler(svcs.Instance, svcs.Jobs)
 		r.Route("/instances", func(r chi.Router) {
+			r.Use(middleware.RequireScope(auth.ScopeInstances))
+			r.Get("/", instH.List)
 			r.Post("/", instH.Create)
 			r.Get("/mine", handler.GetMine(svcs.Instance))
+			r.Get("/classes", instH.Classes)
 			r.Get("/{id}", instH.Get)
 			r.Delete("/{id}", instH.Delete)
 			r.Post("/{id}/pause", instH.Pause)
 			r.Post("/{id}/wake", instH.Wake)
+			r.Post("/{id}/resize", instH.Resize)
+			r.Put("/{id}/sharing", instH.Sharing)
+
+			// Volume backups
+			if svcs.Backups != nil {
+				backupH := handler.NewBackupHandler(svcs.Backups, svcs.Jobs)
+				r.Get("/{id}/backups", backupH.List)
+				r.Post("/{id}/backups", backupH.Create)
+				r.Post("/{id}/backups/{backupID}/restore", backupH.Restore)
+			}
+
+			// Terminal recordings
+			if svcs.Recordings != nil {
+				recH := handler.NewRecordingHandler(svcs.Recordings)
+				r.Get("/{id}/recordings", recH.List)
+				r.Get("/{id}/recordings/{recordingID}", recH.Download)
+				r.Get("/{id}/recordings/{recordingID}/replay", recH.Replay)
+			}
+
+			// Proxy routes to instance services. They reach into the instance,
+			// so tokens need write access even to read.
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireWriteScope(auth.ScopeInstances))
+				r.Get("/{id}/terminal", proxyH.Terminal)
+				r.Get("/{id}/terminal/shares", proxyH.ListShares)
+				r.Post("/{id}/terminal/shares", proxyH.CreateShare)
+				r.Delete("/{id}/terminal/shares/{shareID}", proxyH.RevokeShare)
+				r.Get("/{id}/chat", proxyH.Chat)
+				r.Get("/{id}/files", proxyH.Files)
+				r.Get("/{id}/files/read", proxyH.FilesRead)
+				r.Get("/{id}/projects", proxyH.Projects)
+				r.Post("/{id}/projects/clone", proxyH.ProjectsClone)
+				r.Post("/{id}/tabs", proxyH.Tabs)
+				r.Get("/{id}/sessions", proxyH.Sessions)
+				r.Get("/{id}/sessions/{project}/conversations", proxyH.SessionConversations)
+				r.Delete("/{id}/tabs/{name}", proxyH.DeleteTab)
+				r.Get("/{id}/auth/status", proxyH.AuthStatus)
+			})
+		})
 
-			// Proxy routes to instance services
-			r.Get("/{id}/terminal", proxyH.Terminal)
-			r.Get("/{id}/chat", proxyH.Chat)
-			r.Get("/{id}/files", proxyH.Files)
-			r.Get("/{id}/files/read", proxyH.FilesRead)
-			r.Get("/{id}/projects", proxyH.Projects)
-			r.Post("/{id}/projects/clone", proxyH.ProjectsClone)
-			r.Post("/{id}/tabs", proxyH.Tabs)
-			r.Get("/{id}/sessions", proxyH.Sessions)
-			r.Get("/{id}/sessions/{project}/conversations", proxyH.SessionConversations)
-			r.Delete("/{id}/tabs/{name}", proxyH.DeleteTab)
-			r.Get("/{id}/auth/status", proxyH.AuthStatus)
+		// Environment templates
+		tmplH := handler.NewTemplateHandler(svcs.Templates)
+		r.Route("/templates", func(r chi.Router) {
+			r.Use(middleware.RequireScope(auth.ScopeTemplates))
+			r.Get("/", tmplH.List)
+			r.Post("/", tmplH.Create)
+			r.Get("/{id}", tmplH.Get)
+			r.Delete("/{id}", tmplH.Delete)
 		})
 
+		// Secrets vault
+		if svcs.Secrets != nil {
+			secretH := handler.NewSecretHandler(svcs.Secrets)
+			r.Route("/secrets", func(r chi.Router) {
+				r.Use(middleware.RequireScope(auth.ScopeSecrets))
+				r.Get("/", secretH.List)
+				r.Post("/sync", secretH.Sync)
+				r.Put("/{name}", secretH.Put)
+				r.Delete("/{name}", secretH.Delete)
+			})
+		}
+
+		// Organizations
+		if svcs.Orgs != nil {
+			orgH := handler.NewOrgHandler(svcs.Orgs, svcs.Billing)
+			r.Route("/orgs", func(r chi.Router) {
+				r.Use(middleware.RequireScope(auth.ScopeOrgs))
+				r.Get("/", orgH.List)
+				r.Post("/", orgH.Create)
+				r.Get("/{id}", orgH.Get)
+				r.Delete("/{id}", orgH.Delete)
+				r.Put("/{id}/recording", orgH.SetRecording)
+				r.Get("/{id}/members", orgH.Members)
+				r.Post("/{id}/members", orgH.AddMember)
+				r.Put("/{id}/members/{userID}", orgH.UpdateMember)
+				r.Delete("/{id}/members/{userID}", orgH.RemoveMember)
+				r.Get("/{id}/instances", orgH.Instances)
+				if svcs.Billing != nil {
+					r.Post("/{id}/billing/checkout", orgH.CreateCheckout)
+					r.Get("/{id}/billing/portal", orgH.GetPortal)
+				}
+			})
+		}
+
+		// Async job status
+		jobH := handler.NewJobHandler(svcs.Jobs)
+		r.With(middleware.RequireScope(auth.ScopeInstances)).Get("/jobs/{id}", jobH.Get)
+
 		// Conversation routes
 		if svcs.Conversation != nil {
 			convH := handler.NewConversationHandler(svcs.Conversation)
 			r.Route("/conversations", func(r chi.Router) {
+				r.Use(middleware.RequireScope(auth.ScopeConversations))
 				r.Get("/", convH.GetOrCreate)   // ?project=<path>
 				r.Get("/list", convH.List)
 				r.Get("/{id}/messages", convH.GetMessages)
@@ -125,9 +309,12 @@ func NewRouter(cfg *config.Config, svcs *Services) http.Handler {
 
 		// Billing routes (authed)
 		if bh != nil {
-			r.Post("/billing/checkout", bh.CreateCheckout)
-			r.Get("/billing/portal", bh.GetPortal)
-			r.Get("/billing/usage", bh.GetUsage)
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireScope(auth.ScopeAccount))
+				r.Post("/billing/checkout", bh.CreateCheckout)
+				r.Get("/billing/portal", bh.GetPortal)
+				r.Get("/billing/usage", bh.GetUsage)
+			})
 		}
 	})
 
@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/logan/cloudcode/internal/auth"
 	"github.com/logan/cloudcode/internal/config"
 	"github.com/logan/cloudcode/internal/service"
 )
@@ -19,6 +21,7 @@ func TestRoutes(t *testing.T) {
 	svcs := &Services{
 		Instance: (*service.InstanceService)(nil),
 		Auth:     (*service.AuthService)(nil),
+		Admin:    new(service.AdminService),
 		Version:  "test",
 	}
 	router := NewRouter(cfg, svcs)
@@ -39,6 +42,20 @@ func TestRoutes(t *testing.T) {
 		}
 	})
 
+	t.Run("jwks does not publish the shared secret", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		var body struct {
+			Keys []map[string]string `json:"keys"`
+		}
+		json.NewDecoder(rec.Body).Decode(&body)
+		if rec.Code != http.StatusOK || body.Keys == nil || len(body.Keys) != 0 {
+			t.Fatalf("got %d %+v, want 200 with no keys", rec.Code, body)
+		}
+	})
+
 	t.Run("instances without key returns 401", func(t *testing.T) {
 		req := httptest.NewRequest("POST", "/instances/", nil)
 		rec := httptest.NewRecorder()
@@ -48,4 +65,22 @@ func TestRoutes(t *testing.T) {
 			t.Fatalf("got %d, want %d", rec.Code, http.StatusUnauthorized)
 		}
 	})
+
+	t.Run("admin routes take the API key only", func(t *testing.T) {
+		token, err := auth.GenerateToken(cfg.JWTSecret, 1, "a@example.com", "session", time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, header := range [][2]string{{"", ""}, {"Authorization", "Bearer " + token}, {"X-API-Key", "wrong"}} {
+			req := httptest.NewRequest("GET", "/admin/users", nil)
+			if header[0] != "" {
+				req.Header.Set(header[0], header[1])
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("%s: got %d, want %d", header[0], rec.Code, http.StatusUnauthorized)
+			}
+		}
+	})
 }
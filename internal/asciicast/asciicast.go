@@ -0,0 +1,188 @@
+// Package asciicast reads and writes terminal recordings in the asciicast v2
+// format: a JSON header line followed by one JSON array per event, each
+// [seconds since start, type, data].
+// See https://docs.asciinema.org/manual/asciicast/v2/.
+package asciicast
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+)
+
+// Event types.
+const (
+	Output = "o" // data written to the terminal
+	Input  = "i" // keystrokes sent to the terminal
+	Resize = "r" // new size as "COLSxROWS"
+	Marker = "m" // a label for navigation
+)
+
+// ContentType is the media type of asciicast files.
+const ContentType = "application/x-asciicast"
+
+// Header is the first line of a recording.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"` // Unix seconds
+	Duration  float64           `json:"duration,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Event is one line after the header.
+type Event struct {
+	Time float64 // seconds since the start of the recording
+	Type string
+	Data string
+}
+
+// MarshalJSON encodes the event as a [time, type, data] array.
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{e.Time, e.Type, e.Data})
+}
+
+// UnmarshalJSON decodes a [time, type, data] array.
+func (e *Event) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 3 {
+		return fmt.Errorf("event has %d elements, want 3", len(raw))
+	}
+	if err := json.Unmarshal(raw[0], &e.Time); err != nil {
+		return fmt.Errorf("event time: %w", err)
+	}
+	if err := json.Unmarshal(raw[1], &e.Type); err != nil {
+		return fmt.Errorf("event type: %w", err)
+	}
+	if err := json.Unmarshal(raw[2], &e.Data); err != nil {
+		return fmt.Errorf("event data: %w", err)
+	}
+	return nil
+}
+
+// Writer writes the events of a recording. Terminal streams are bytes, and
+// a frame can end part way through a UTF-8 sequence, so the Writer holds
+// back incomplete sequences until the rest arrives. Writer is not safe for
+// concurrent use.
+type Writer struct {
+	w       *bufio.Writer
+	start   time.Time
+	last    float64
+	pending map[string][]byte // incomplete UTF-8 tail per event type
+}
+
+// NewWriter returns a Writer whose event times count from start. Callers
+// write the header themselves with WriteHeader, first.
+func NewWriter(w io.Writer, start time.Time) *Writer {
+	return &Writer{w: bufio.NewWriter(w), start: start, pending: make(map[string][]byte)}
+}
+
+// WriteHeader writes h as the header line, setting its version.
+func (w *Writer) WriteHeader(h Header) error {
+	h.Version = 2
+	return w.line(h)
+}
+
+// Write records data as an event of type typ at time at. Times before the
+// previous event's are clamped so the file stays in order.
+func (w *Writer) Write(at time.Time, typ string, data []byte) error {
+	if pending := w.pending[typ]; len(pending) > 0 {
+		data = append(pending, data...)
+	}
+	data, w.pending[typ] = splitUTF8(data)
+	if len(data) == 0 {
+		return nil
+	}
+	t := max(at.Sub(w.start).Seconds(), w.last)
+	w.last = t
+	return w.line(Event{Time: t, Type: typ, Data: string(data)})
+}
+
+// Duration returns the time of the last event written.
+func (w *Writer) Duration() float64 {
+	return w.last
+}
+
+// Flush writes buffered events to the underlying writer. Incomplete UTF-8
+// sequences still held back are dropped.
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
+func (w *Writer) line(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	_, err = w.w.Write(b)
+	return err
+}
+
+// splitUTF8 splits b before a trailing incomplete UTF-8 sequence, if any.
+// Other invalid bytes are left in place; JSON encoding replaces them.
+func splitUTF8(b []byte) (complete, rest []byte) {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(b[i]) {
+			continue
+		}
+		if !utf8.FullRune(b[i:]) {
+			return b[:i], append([]byte(nil), b[i:]...)
+		}
+		break
+	}
+	return b, nil
+}
+
+// Reader reads a recording.
+type Reader struct {
+	Header Header
+	s      *bufio.Scanner
+}
+
+// NewReader reads the header from r and returns a Reader for its events.
+func NewReader(r io.Reader) (*Reader, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 16<<20)
+	rd := &Reader{s: s}
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("asciicast: empty recording")
+	}
+	if err := json.Unmarshal(s.Bytes(), &rd.Header); err != nil {
+		return nil, fmt.Errorf("asciicast: header: %w", err)
+	}
+	if rd.Header.Version != 2 {
+		return nil, fmt.Errorf("asciicast: unsupported version %d", rd.Header.Version)
+	}
+	return rd, nil
+}
+
+// Next returns the next event, or io.EOF after the last one.
+func (r *Reader) Next() (Event, error) {
+	for r.s.Scan() {
+		if len(r.s.Bytes()) == 0 {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(r.s.Bytes(), &e); err != nil {
+			return Event{}, fmt.Errorf("asciicast: %w", err)
+		}
+		return e, nil
+	}
+	if err := r.s.Err(); err != nil {
+		return Event{}, err
+	}
+	return Event{}, io.EOF
+}
@@ -0,0 +1,81 @@
+package asciicast
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriterReader_RoundTrip(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	var buf bytes.Buffer
+	w := NewWriter(&buf, start)
+	if err := w.WriteHeader(Header{Width: 120, Height: 40, Timestamp: start.Unix()}); err != nil {
+		t.Fatalf("header: %v", err)
+	}
+
+	// "é" split across two frames is written once, whole
+	w.Write(start.Add(500*time.Millisecond), Output, []byte("caf\xc3"))
+	w.Write(start.Add(time.Second), Output, []byte("\xa9\r\n"))
+	w.Write(start.Add(2*time.Second), Input, []byte("ls\r"))
+	// An event out of order keeps the previous time
+	w.Write(start.Add(time.Second), Resize, []byte("100x30"))
+	if err := w.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if w.Duration() != 2 {
+		t.Errorf("duration = %v", w.Duration())
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != `{"version":2,"width":120,"height":40,"timestamp":1700000000}` {
+		t.Errorf("header line = %s", lines[0])
+	}
+	if lines[1] != `[0.5,"o","caf"]` || lines[2] != `[1,"o","é\r\n"]` {
+		t.Errorf("output lines = %q", lines[1:3])
+	}
+
+	r, err := NewReader(&buf)
+	if err != nil {
+		t.Fatalf("reader: %v", err)
+	}
+	if r.Header.Width != 120 || r.Header.Height != 40 {
+		t.Errorf("header = %+v", r.Header)
+	}
+	var events []Event
+	for {
+		e, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("next: %v", err)
+		}
+		events = append(events, e)
+	}
+	want := []Event{
+		{0.5, Output, "caf"},
+		{1, Output, "é\r\n"},
+		{2, Input, "ls\r"},
+		{2, Resize, "100x30"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v", events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, events[i], want[i])
+		}
+	}
+}
+
+func TestNewReader_RejectsOtherVersions(t *testing.T) {
+	if _, err := NewReader(strings.NewReader(`{"version":1,"width":80,"height":24}` + "\n")); err == nil {
+		t.Error("version 1 accepted")
+	}
+	if _, err := NewReader(strings.NewReader("")); err == nil {
+		t.Error("empty file accepted")
+	}
+}
@@ -1,51 +1,66 @@
 package auth
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Issuer is the iss claim of every CloudCode token.
+const Issuer = "cloudcode"
+
+// Audience returns the aud claim of tokens for purpose. Services verifying
+// tokens against the published keys check it, so they cannot take a magic
+// link for a session.
+func Audience(purpose string) string {
+	return Issuer + ":" + purpose
+}
+
 // Claims represents the JWT claims for CloudCode tokens.
 type Claims struct {
 	UserID  int    `json:"user_id"`
 	Email   string `json:"email"`
 	Purpose string `json:"purpose"` // "session" or "magic_link"
+	// SessionID names the server-side session a session token belongs to,
+	// so it can be revoked before it expires.
+	SessionID string `json:"sid,omitempty"`
+	// Impersonated marks a support session an admin started as the user.
+	Impersonated bool `json:"imp,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken creates a signed JWT with the given claims.
+// GenerateToken creates an HS256 JWT with the given claims, signed with a
+// shared secret. Use Keyring.Sign to sign with the configured keys.
 func GenerateToken(secret string, userID int, email, purpose string, expiry time.Duration) (string, error) {
-	claims := Claims{
-		UserID:  userID,
-		Email:   email,
-		Purpose: purpose,
+	return NewHMACKeyring(secret).Sign(NewClaims(userID, email, purpose, "", expiry))
+}
+
+// GenerateSessionToken creates an HS256 session JWT for the given session,
+// signed with a shared secret.
+func GenerateSessionToken(secret string, userID int, email, sessionID string, expiry time.Duration) (string, error) {
+	return NewHMACKeyring(secret).Sign(NewClaims(userID, email, "session", sessionID, expiry))
+}
+
+// NewClaims returns claims for a token that expires after expiry. sessionID
+// is empty except for session tokens.
+func NewClaims(userID int, email, purpose, sessionID string, expiry time.Duration) *Claims {
+	now := time.Now()
+	return &Claims{
+		UserID:    userID,
+		Email:     email,
+		Purpose:   purpose,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    Issuer,
+			Audience:  jwt.ClaimStrings{Audience(purpose)},
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
 }
 
-// ValidateToken parses and validates a JWT, returning the claims.
+// ValidateToken parses and validates an HS256 JWT signed with a shared
+// secret, returning the claims.
 func ValidateToken(secret, tokenStr string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-		return []byte(secret), nil
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
-		return nil, fmt.Errorf("invalid token claims")
-	}
-	return claims, nil
+	return NewHMACKeyring(secret).Validate(tokenStr)
 }
@@ -0,0 +1,318 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSABits is the smallest RSA modulus accepted for signing or verifying.
+const minRSABits = 2048
+
+// Key is a JWT signing or verification key, named by its key ID. The ID goes
+// in the kid header of every token the key signs.
+type Key struct {
+	ID     string
+	method jwt.SigningMethod
+	sign   crypto.PrivateKey // nil for verification-only keys
+	verify crypto.PublicKey
+	until  time.Time // tokens are refused once past; zero for never
+}
+
+// Algorithm returns the JWS algorithm the key is used with.
+func (k *Key) Algorithm() string {
+	return k.method.Alg()
+}
+
+// HMACKey returns an HS256 key for a shared secret. Its ID is derived from
+// the secret, so the same secret always has the same ID.
+func HMACKey(secret string) *Key {
+	sum := sha256.Sum256([]byte("cloudcode jwt kid\x00" + secret))
+	return &Key{
+		ID:     "hs-" + base64.RawURLEncoding.EncodeToString(sum[:9]),
+		method: jwt.SigningMethodHS256,
+		sign:   []byte(secret),
+		verify: []byte(secret),
+	}
+}
+
+// ParseKey parses a PEM encoded Ed25519 or RSA key. Private keys (PKCS #8,
+// or PKCS #1 for RSA) can sign; public keys (PKIX) only verify. Ed25519 keys
+// sign with EdDSA and RSA keys with RS256. The key ID is the RFC 7638
+// thumbprint of the public key.
+func ParseKey(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", strings.ToLower(block.Type), err)
+	}
+
+	k := &Key{}
+	switch key := parsed.(type) {
+	case ed25519.PrivateKey:
+		k.method, k.sign, k.verify = jwt.SigningMethodEdDSA, key, key.Public()
+	case ed25519.PublicKey:
+		k.method, k.verify = jwt.SigningMethodEdDSA, key
+	case *rsa.PrivateKey:
+		k.method, k.sign, k.verify = jwt.SigningMethodRS256, key, &key.PublicKey
+	case *rsa.PublicKey:
+		k.method, k.verify = jwt.SigningMethodRS256, key
+	default:
+		return nil, fmt.Errorf("unsupported key type %T; want Ed25519 or RSA", parsed)
+	}
+	if pub, ok := k.verify.(*rsa.PublicKey); ok && pub.N.BitLen() < minRSABits {
+		return nil, fmt.Errorf("RSA key is %d bits; want at least %d", pub.N.BitLen(), minRSABits)
+	}
+
+	k.ID, err = k.thumbprint()
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// LoadKeyFile reads a key in ParseKey format from a file.
+func LoadKeyFile(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read key: %w", err)
+	}
+	k, err := ParseKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return k, nil
+}
+
+// Keyring signs tokens with one key and verifies them with any of its keys,
+// so keys can be rotated without signing everyone out: add the new key for
+// verification, promote it to signing once every verifier has it, and drop
+// the old key after its tokens expire.
+type Keyring struct {
+	signer *Key
+	keys   map[string]*Key
+}
+
+// NewKeyring creates a keyring that signs with signer and also accepts
+// tokens signed by verifyOnly.
+func NewKeyring(signer *Key, verifyOnly ...*Key) (*Keyring, error) {
+	if signer == nil || signer.sign == nil {
+		return nil, errors.New("signing key has no private key")
+	}
+	k := &Keyring{signer: signer, keys: map[string]*Key{signer.ID: signer}}
+	for _, key := range verifyOnly {
+		if _, dup := k.keys[key.ID]; dup {
+			return nil, fmt.Errorf("duplicate key ID %q", key.ID)
+		}
+		k.keys[key.ID] = key
+	}
+	return k, nil
+}
+
+// NewHMACKeyring returns a keyring that signs and verifies with HS256 and a
+// shared secret.
+func NewHMACKeyring(secret string) *Keyring {
+	k, _ := NewKeyring(HMACKey(secret)) // cannot fail: HMAC keys always sign
+	return k
+}
+
+// SigningKey returns the key new tokens are signed with.
+func (k *Keyring) SigningKey() *Key {
+	return k.signer
+}
+
+// Sign returns a JWT for claims, signed with the signing key.
+func (k *Keyring) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.signer.method, claims)
+	token.Header["kid"] = k.signer.ID
+	return token.SignedString(k.signer.sign)
+}
+
+// Validate parses and validates a JWT signed by any key in the keyring,
+// returning the claims. Tokens without a kid header predate key IDs and are
+// tried against the HS256 keys. The token must be issued by CloudCode for the
+// audience of its purpose.
+func (k *Keyring) Validate(tokenStr string) (*Claims, error) {
+	now := time.Now()
+	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			var legacy jwt.VerificationKeySet
+			for _, key := range k.keys {
+				if key.method == jwt.SigningMethodHS256 && t.Method.Alg() == key.method.Alg() && !key.retired(now) {
+					legacy.Keys = append(legacy.Keys, key.verify)
+				}
+			}
+			if len(legacy.Keys) == 0 {
+				return nil, errors.New("token has no key ID")
+			}
+			return legacy, nil
+		}
+		key, ok := k.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key ID %q", kid)
+		}
+		if t.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		if key.retired(now) {
+			return nil, fmt.Errorf("key %q is retired", kid)
+		}
+		return key.verify, nil
+	}, jwt.WithIssuer(Issuer))
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid token claims")
+	}
+	if claims.Purpose == "" || !slices.Contains(claims.Audience, Audience(claims.Purpose)) {
+		return nil, fmt.Errorf("token audience does not match its purpose")
+	}
+	return claims, nil
+}
+
+// retired reports whether the key no longer verifies tokens at now.
+func (k *Key) retired(now time.Time) bool {
+	return !k.until.IsZero() && now.After(k.until)
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Crv string `json:"crv,omitempty"` // OKP
+	X   string `json:"x,omitempty"`   // OKP
+	N   string `json:"n,omitempty"`   // RSA
+	E   string `json:"e,omitempty"`   // RSA
+}
+
+// JWKSet is the document served at /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the keyring's public keys, signing key first. Shared secrets
+// are never published, so an HS256-only keyring has an empty set.
+func (k *Keyring) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	add := func(key *Key) {
+		if key.method == jwt.SigningMethodHS256 {
+			return
+		}
+		jwk := key.jwk()
+		jwk.Kid, jwk.Use, jwk.Alg = key.ID, "sig", key.method.Alg()
+		set.Keys = append(set.Keys, jwk)
+	}
+	add(k.signer)
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		if id != k.signer.ID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		add(k.keys[id])
+	}
+	return set
+}
+
+// jwk returns the required members of the key's public JWK.
+func (k *Key) jwk() JWK {
+	switch pub := k.verify.(type) {
+	case ed25519.PublicKey:
+		return JWK{Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(pub)}
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	}
+	return JWK{}
+}
+
+// thumbprint returns the RFC 7638 thumbprint of the key's public JWK: the
+// SHA-256 of its required members, which encoding/json writes in the
+// lexical order the RFC asks for when they are map keys.
+func (k *Key) thumbprint() (string, error) {
+	jwk := k.jwk()
+	members := map[string]string{"kty": jwk.Kty}
+	switch jwk.Kty {
+	case "OKP":
+		members["crv"], members["x"] = jwk.Crv, jwk.X
+	case "RSA":
+		members["e"], members["n"] = jwk.E, jwk.N
+	}
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// LoadKeyring builds a keyring from configuration. The first key file signs
+// and the others only verify; with no key files the secret signs with HS256.
+// The secret and the previous secrets are also accepted, so moving to key
+// files or rotating the secret does not sign anyone out. Once there are key
+// files, they stop being accepted after secretsUntil if it is set: anyone
+// who has a secret could otherwise forge tokens for good.
+func LoadKeyring(secret string, keyFiles, previousSecrets []string, secretsUntil time.Time) (*Keyring, error) {
+	var keys []*Key
+	for _, path := range keyFiles {
+		k, err := LoadKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	secrets := []*Key{HMACKey(secret)}
+	for _, s := range previousSecrets {
+		if s != secret {
+			secrets = append(secrets, HMACKey(s))
+		}
+	}
+	if len(keys) > 0 {
+		for _, k := range secrets {
+			k.until = secretsUntil
+		}
+	}
+	keys = append(keys, secrets...)
+	return NewKeyring(keys[0], keys[1:]...)
+}
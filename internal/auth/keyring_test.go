@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func pemKey(t *testing.T, typ string, der []byte, err error) []byte {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+}
+
+func newEd25519Key(t *testing.T) *Key {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	k, err := ParseKey(pemKey(t, "PRIVATE KEY", der, err))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return k
+}
+
+func TestKeyring_Algorithms(t *testing.T) {
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := ParseKey(pemKey(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPriv), nil))
+	if err != nil {
+		t.Fatalf("parse RSA: %v", err)
+	}
+
+	for _, key := range []*Key{newEd25519Key(t), rsaKey, HMACKey("secret")} {
+		keys, err := NewKeyring(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err := keys.Sign(NewClaims(1, "a@example.com", "session", "sid", time.Hour))
+		if err != nil {
+			t.Fatalf("%s: sign: %v", key.Algorithm(), err)
+		}
+		parsed, _, _ := jwt.NewParser().ParseUnverified(token, &Claims{})
+		if parsed.Header["kid"] != key.ID || parsed.Header["alg"] != key.Algorithm() {
+			t.Errorf("%s: header = %v", key.Algorithm(), parsed.Header)
+		}
+		claims, err := keys.Validate(token)
+		if err != nil || claims.UserID != 1 || claims.SessionID != "sid" {
+			t.Errorf("%s: validate = %+v, %v", key.Algorithm(), claims, err)
+		}
+	}
+
+	small, _ := rsa.GenerateKey(rand.Reader, 1024)
+	if _, err := ParseKey(pemKey(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(small), nil)); err == nil {
+		t.Error("accepted a 1024-bit RSA key")
+	}
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	old, next := newEd25519Key(t), newEd25519Key(t)
+	before, _ := NewKeyring(old)
+	token, _ := before.Sign(NewClaims(1, "a@example.com", "session", "", time.Hour))
+
+	// The old key keeps verifying after the new one takes over signing
+	after, err := NewKeyring(next, old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := after.Validate(token); err != nil {
+		t.Errorf("token from retired key: %v", err)
+	}
+	newToken, _ := after.Sign(NewClaims(1, "a@example.com", "session", "", time.Hour))
+	if _, err := before.Validate(newToken); err == nil || !strings.Contains(err.Error(), "unknown key ID") {
+		t.Errorf("keyring without the new key: %v", err)
+	}
+
+	// Verification-only keys cannot sign
+	pub, err := x509.MarshalPKIXPublicKey(old.verify)
+	pubKey, err := ParseKey(pemKey(t, "PUBLIC KEY", pub, err))
+	if err != nil || pubKey.ID != old.ID {
+		t.Fatalf("public key = %+v, %v", pubKey, err)
+	}
+	if _, err := NewKeyring(pubKey); err == nil {
+		t.Error("public key accepted as signing key")
+	}
+	if _, err := NewKeyring(next, old, pubKey); err == nil {
+		t.Error("duplicate key ID accepted")
+	}
+}
+
+func TestKeyring_RejectsAlgorithmConfusion(t *testing.T) {
+	edKey := newEd25519Key(t)
+	keys, _ := NewKeyring(edKey, HMACKey("secret"))
+
+	// An HS256 token keyed with the public key and claiming the Ed25519 kid
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, NewClaims(1, "a@example.com", "session", "", time.Hour))
+	forged.Header["kid"] = edKey.ID
+	token, _ := forged.SignedString([]byte(edKey.verify.(ed25519.PublicKey)))
+	if _, err := keys.Validate(token); err == nil {
+		t.Error("accepted HS256 token for an EdDSA key")
+	}
+
+	// Tokens from before key IDs verify against the shared secret only
+	legacy, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, NewClaims(1, "a@example.com", "session", "", time.Hour)).SignedString([]byte("secret"))
+	if _, err := keys.Validate(legacy); err != nil {
+		t.Errorf("legacy token: %v", err)
+	}
+	edOnly, _ := NewKeyring(edKey)
+	if _, err := edOnly.Validate(legacy); err == nil {
+		t.Error("legacy token accepted without a shared secret")
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	// RFC 8037 appendix A.3
+	x, _ := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	pub, err := x509.MarshalPKIXPublicKey(ed25519.PublicKey(x))
+	key, err := ParseKey(pemKey(t, "PUBLIC KEY", pub, err))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.ID != "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k" {
+		t.Errorf("thumbprint = %s", key.ID)
+	}
+
+	signer := newEd25519Key(t)
+	keys, _ := NewKeyring(signer, HMACKey("secret"), key)
+	set := keys.JWKS()
+	if len(set.Keys) != 2 {
+		t.Fatalf("keys = %+v; shared secrets must not be published", set.Keys)
+	}
+	if set.Keys[0].Kid != signer.ID || set.Keys[1].Kid != key.ID {
+		t.Errorf("order = %s, %s", set.Keys[0].Kid, set.Keys[1].Kid)
+	}
+	if k := set.Keys[1]; k.Kty != "OKP" || k.Crv != "Ed25519" || k.Alg != "EdDSA" || k.Use != "sig" || k.X != "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo" {
+		t.Errorf("jwk = %+v", k)
+	}
+	if n := len(NewHMACKeyring("secret").JWKS().Keys); n != 0 {
+		t.Errorf("HS256 keyring publishes %d keys", n)
+	}
+}
+
+func TestLoadKeyring(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	path := filepath.Join(t.TempDir(), "jwt.pem")
+	if err := os.WriteFile(path, pemKey(t, "PRIVATE KEY", der, err), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tokens signed with the secret survive the move to a key file
+	oldToken, _ := GenerateToken("old", 1, "a@example.com", "session", time.Hour)
+	keys, err := LoadKeyring("current", []string{path}, []string{"old"}, time.Time{})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if keys.SigningKey().Algorithm() != "EdDSA" {
+		t.Errorf("signing with %s", keys.SigningKey().Algorithm())
+	}
+	if _, err := keys.Validate(oldToken); err != nil {
+		t.Errorf("previous secret: %v", err)
+	}
+
+	// and stop verifying once the secrets are retired
+	keys, err = LoadKeyring("current", []string{path}, []string{"old"}, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	current, _ := GenerateToken("current", 1, "a@example.com", "session", time.Hour)
+	for _, token := range []string{oldToken, current} {
+		if _, err := keys.Validate(token); err == nil {
+			t.Error("retired secret accepted")
+		}
+	}
+
+	keys, err = LoadKeyring("current", nil, nil, time.Now().Add(-time.Minute))
+	if err != nil || keys.SigningKey().Algorithm() != "HS256" {
+		t.Errorf("secret only = %v", err)
+	}
+	if _, err := keys.Validate(current); err != nil {
+		t.Errorf("signing secret retired: %v", err)
+	}
+	if _, err := LoadKeyring("current", []string{filepath.Join(t.TempDir(), "missing.pem")}, nil, time.Time{}); err == nil {
+		t.Error("missing key file accepted")
+	}
+}
+
+func TestKeyring_Audience(t *testing.T) {
+	keys, _ := NewKeyring(newEd25519Key(t))
+	token, _ := keys.Sign(NewClaims(1, "a@example.com", "magic_link", "", time.Hour))
+	claims, err := keys.Validate(token)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if claims.Issuer != Issuer || len(claims.Audience) != 1 || claims.Audience[0] != "cloudcode:magic_link" {
+		t.Errorf("iss = %q, aud = %v", claims.Issuer, claims.Audience)
+	}
+
+	for name, mutate := range map[string]func(*Claims){
+		"no audience":    func(c *Claims) { c.Audience = nil },
+		"other audience": func(c *Claims) { c.Purpose = "session" },
+		"other issuer":   func(c *Claims) { c.Issuer = "elsewhere" },
+	} {
+		c := NewClaims(1, "a@example.com", "magic_link", "", time.Hour)
+		mutate(c)
+		token, _ := keys.Sign(c)
+		if _, err := keys.Validate(token); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AccessTokenPrefix starts every personal access token, which tells them
+// apart from session JWTs and makes leaked tokens easy to scan for.
+const AccessTokenPrefix = "ccp_"
+
+// Resources a personal access token can be scoped to. A scope is a resource
+// with ":read" or ":write"; write implies read.
+const (
+	ScopeInstances     = "instances"     // instances, jobs, backups and the instance proxy
+	ScopeTemplates     = "templates"     // environment templates
+	ScopeSecrets       = "secrets"       // secrets vault
+	ScopeConversations = "conversations" // chat history
+	ScopeAccount       = "account"       // settings and billing
+	ScopeOrgs          = "orgs"          // organizations, members and their billing
+)
+
+// Scopes lists every scope a token may be granted.
+var Scopes = func() []string {
+	var out []string
+	for _, r := range []string{ScopeInstances, ScopeTemplates, ScopeSecrets, ScopeConversations, ScopeAccount, ScopeOrgs} {
+		out = append(out, r+":read", r+":write")
+	}
+	return out
+}()
+
+// ShareTokenPrefix starts every terminal share token, the credential in a
+// link to watch someone's terminal.
+const ShareTokenPrefix = "ccs_"
+
+// GenerateAccessToken returns a new personal access token and the hash to
+// store in its place.
+func GenerateAccessToken() (token, hash string, err error) {
+	return generateToken(AccessTokenPrefix)
+}
+
+// GenerateShareToken returns a new terminal share token and the hash to
+// store in its place.
+func GenerateShareToken() (token, hash string, err error) {
+	return generateToken(ShareTokenPrefix)
+}
+
+func generateToken(prefix string) (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("generate token: %w", err)
+	}
+	token = prefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, HashAccessToken(token), nil
+}
+
+// HashAccessToken returns the stored form of a token. Tokens carry 256 bits of
+// randomness, so a plain SHA-256 is enough to make a leaked table useless.
+func HashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAccessToken reports whether s looks like a personal access token.
+func IsAccessToken(s string) bool {
+	return strings.HasPrefix(s, AccessTokenPrefix)
+}
+
+// HasScope reports whether granted allows access to resource, for writing
+// if write is set.
+func HasScope(granted []string, resource string, write bool) bool {
+	if slices.Contains(granted, resource+":write") {
+		return true
+	}
+	return !write && slices.Contains(granted, resource+":read")
+}
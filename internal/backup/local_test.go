@@ -0,0 +1,91 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore_PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := NewLocalStore(dir)
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+
+	key := "users/1/instances/2/backup.tar.gz"
+	n, err := s.Put(ctx, key, strings.NewReader("archive"))
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if n != int64(len("archive")) {
+		t.Errorf("expected 7 bytes written, got %d", n)
+	}
+
+	rc, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "archive" {
+		t.Errorf("expected %q, got %q", "archive", data)
+	}
+
+	// No temp files left behind
+	entries, _ := os.ReadDir(filepath.Join(dir, "users", "1", "instances", "2"))
+	if len(entries) != 1 {
+		t.Errorf("expected 1 file in key dir, got %d", len(entries))
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("deleting a missing key should succeed, got %v", err)
+	}
+}
+
+func TestLocalStore_FailedPutKeepsPrevious(t *testing.T) {
+	ctx := context.Background()
+	s, _ := NewLocalStore(t.TempDir())
+
+	if _, err := s.Put(ctx, "a.tar.gz", strings.NewReader("v1")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	r := io.MultiReader(strings.NewReader("partial"), &errReader{errors.New("boom")})
+	if _, err := s.Put(ctx, "a.tar.gz", r); err == nil {
+		t.Fatal("expected error from failing reader")
+	}
+
+	rc, err := s.Get(ctx, "a.tar.gz")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	if string(data) != "v1" {
+		t.Errorf("expected previous archive to survive, got %q", data)
+	}
+}
+
+func TestLocalStore_RejectsEscapingKeys(t *testing.T) {
+	s, _ := NewLocalStore(t.TempDir())
+	for _, key := range []string{"../x", "/etc/passwd", "a/../../x"} {
+		if _, err := s.Put(context.Background(), key, strings.NewReader("x")); err == nil {
+			t.Errorf("expected %q to be rejected", key)
+		}
+	}
+}
+
+type errReader struct{ err error }
+
+func (r *errReader) Read([]byte) (int, error) { return 0, r.err }
@@ -0,0 +1,84 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// partSize bounds memory per upload: archives are streamed with unknown
+// length, so minio-go buffers one part at a time. 16 MiB parts allow archives
+// up to ~156 GiB within the 10,000-part limit.
+const partSize = 16 << 20
+
+// S3Options configures an S3-compatible store (AWS S3, MinIO, R2, ...).
+type S3Options struct {
+	Endpoint  string // host[:port], without scheme
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	Region    string // avoids a bucket location lookup when set
+	UseSSL    bool
+}
+
+// S3Store keeps archives as objects in an S3-compatible bucket.
+type S3Store struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3Store creates an S3Store. The bucket must already exist.
+func NewS3Store(opts S3Options) (*S3Store, error) {
+	if opts.Endpoint == "" || opts.Bucket == "" {
+		return nil, fmt.Errorf("s3 endpoint and bucket are required")
+	}
+	client, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.AccessKey, opts.SecretKey, ""),
+		Secure: opts.UseSSL,
+		Region: opts.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 client: %w", err)
+	}
+	return &S3Store{client: client, bucket: opts.Bucket}, nil
+}
+
+// Put uploads r to key as a multipart stream.
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	info, err := s.client.PutObject(ctx, s.bucket, key, r, -1, minio.PutObjectOptions{
+		ContentType: "application/gzip",
+		PartSize:    partSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("put %s: %w", key, err)
+	}
+	return info.Size, nil
+}
+
+// Get opens the object at key.
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", key, err)
+	}
+	// GetObject is lazy; Stat surfaces a missing key before the caller reads
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("stat %s: %w", key, err)
+	}
+	return obj, nil
+}
+
+// Delete removes the object at key.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+	return nil
+}
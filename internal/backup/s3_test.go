@@ -0,0 +1,178 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeS3 implements just enough of the S3 API for minio-go's streaming
+// multipart upload, GetObject/Stat and RemoveObject.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads map[string]map[int][]byte
+	nextID  int
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *S3Store) {
+	t.Helper()
+	f := &fakeS3{objects: make(map[string][]byte), uploads: make(map[string]map[int][]byte)}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	s, err := NewS3Store(S3Options{
+		Endpoint:  strings.TrimPrefix(srv.URL, "http://"),
+		Bucket:    "backups",
+		AccessKey: "minio",
+		SecretKey: "minio123",
+		Region:    "us-east-1",
+	})
+	if err != nil {
+		t.Fatalf("NewS3Store: %v", err)
+	}
+	return f, s
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/backups/")
+	q := r.URL.Query()
+
+	switch {
+	case r.Method == http.MethodPost && q.Has("uploads"):
+		f.nextID++
+		id := strconv.Itoa(f.nextID)
+		f.uploads[id] = make(map[int][]byte)
+		fmt.Fprintf(w, `<InitiateMultipartUploadResult><Bucket>backups</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>`, key, id)
+
+	case r.Method == http.MethodPut && q.Has("uploadId"):
+		parts, ok := f.uploads[q.Get("uploadId")]
+		if !ok {
+			http.Error(w, "no such upload", http.StatusNotFound)
+			return
+		}
+		n, _ := strconv.Atoi(q.Get("partNumber"))
+		parts[n] = readPayload(r)
+		w.Header().Set("ETag", fmt.Sprintf(`"part-%d"`, n))
+
+	case r.Method == http.MethodPost && q.Has("uploadId"):
+		parts := f.uploads[q.Get("uploadId")]
+		nums := make([]int, 0, len(parts))
+		for n := range parts {
+			nums = append(nums, n)
+		}
+		sort.Ints(nums)
+		var buf bytes.Buffer
+		for _, n := range nums {
+			buf.Write(parts[n])
+		}
+		f.objects[key] = buf.Bytes()
+		delete(f.uploads, q.Get("uploadId"))
+		fmt.Fprintf(w, `<CompleteMultipartUploadResult><Bucket>backups</Bucket><Key>%s</Key><ETag>"obj"</ETag></CompleteMultipartUploadResult>`, key)
+
+	case r.Method == http.MethodDelete && q.Has("uploadId"):
+		delete(f.uploads, q.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodHead || r.Method == http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				fmt.Fprint(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			}
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("ETag", `"obj"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "unsupported", http.StatusNotImplemented)
+	}
+}
+
+// readPayload returns the request body, decoding aws-chunked framing
+// ("<hex-size>;chunk-signature=...\r\n<data>\r\n") used for signed streams.
+func readPayload(r *http.Request) []byte {
+	body, _ := io.ReadAll(r.Body)
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return body
+	}
+	var out []byte
+	for len(body) > 0 {
+		header, rest, _ := bytes.Cut(body, []byte("\r\n"))
+		sizeHex, _, _ := bytes.Cut(header, []byte(";"))
+		size, err := strconv.ParseInt(string(sizeHex), 16, 64)
+		if err != nil || size == 0 || int64(len(rest)) < size {
+			break
+		}
+		out = append(out, rest[:size]...)
+		body = bytes.TrimPrefix(rest[size:], []byte("\r\n"))
+	}
+	return out
+}
+
+func TestS3Store_PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	f, s := newFakeS3(t)
+
+	key := "users/1/instances/2/backup.tar.gz"
+	payload := bytes.Repeat([]byte("volume-data"), 1000)
+	n, err := s.Put(ctx, key, bytes.NewReader(payload))
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if n != int64(len(payload)) {
+		t.Errorf("expected %d bytes, got %d", len(payload), n)
+	}
+	if !bytes.Equal(f.objects[key], payload) {
+		t.Error("stored object does not match payload")
+	}
+
+	rc, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatalf("read object: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Error("downloaded object does not match payload")
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestS3Store_RequiresBucket(t *testing.T) {
+	if _, err := NewS3Store(S3Options{Endpoint: "localhost:9000"}); err == nil {
+		t.Error("expected error without bucket")
+	}
+}
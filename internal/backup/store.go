@@ -0,0 +1,24 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound indicates no object exists under the requested key.
+var ErrNotFound = errors.New("backup object not found")
+
+// Store is a minimal object store for volume archives. Keys are
+// slash-separated paths such as "users/1/instances/2/20260102T150405Z.tar.gz".
+type Store interface {
+	// Put streams r to key, replacing any existing object, and returns the
+	// number of bytes written.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+
+	// Get opens the object at key. The caller must close the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the object at key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
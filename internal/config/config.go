@@ -15,6 +15,23 @@ type Config struct {
 	ListenAddr  string
 	HCloudToken string
 
+	// Hetzner pause snapshots kept per user; older ones are pruned on Wake
+	HetznerSnapshotRetention string
+	// SSH key warm pool servers are created with and bound through
+	HetznerSSHKeyName string // key name in the Hetzner project
+	HetznerSSHKeyFile string // path to its private key
+
+	// Podman (only needed when PROVIDER=podman)
+	PodmanSocket string // empty = $CONTAINER_HOST or rootless default
+	PodmanUserNS string
+
+	// Kubernetes (only needed when PROVIDER=kubernetes)
+	KubeConfig       string // empty = in-cluster service account
+	KubeNamespace    string
+	KubeImage        string
+	KubeStorageClass string
+	KubeVolumeSize   string
+
 	// Netbird (only needed when PROVIDER=hetzner)
 	NetbirdAPIURL   string
 	NetbirdAPIToken string
@@ -23,8 +40,68 @@ type Config struct {
 	ActivityCheckInterval string
 	IdleThreshold         string
 
-	// JWT auth
-	JWTSecret string
+	// Reconciliation between DB and provider state
+	ReconcileInterval  string
+	ReconcileDryRun    bool // report drift and orphans without changing anything
+	ReconcileGCOrphans bool // delete orphaned containers/volumes/workspaces
+
+	// Live instances per user by plan, e.g. "free=1,starter=2,pro=5"
+	InstanceLimits string
+	// Instance classes each plan may use, default first, e.g. "free=small,pro=small|medium|large"
+	PlanClasses string
+
+	// Warm pool of pre-booted instances per class, e.g. "small=2,medium=1" (empty = disabled)
+	WarmPool         string
+	WarmPoolInterval string
+
+	// Async job queue
+	JobWorkers      string
+	JobPollInterval string
+
+	// Volume backups (BackupStore empty = disabled)
+	BackupStore         string // "local" or "s3"
+	BackupLocalDir      string
+	BackupS3Endpoint    string
+	BackupS3Bucket      string
+	BackupS3AccessKey   string
+	BackupS3SecretKey   string
+	BackupS3Region      string
+	BackupS3UseSSL      bool
+	BackupSchedule      string // plan=interval pairs, e.g. "starter=24h,pro=6h"
+	BackupRetention     string // scheduled backups kept per instance
+	BackupCheckInterval string
+
+	// Terminal recordings (RecordingStore empty = disabled). The S3 store
+	// shares the backup endpoint and credentials.
+	RecordingStore     string // "local" or "s3"
+	RecordingLocalDir  string
+	RecordingS3Bucket  string
+	RecordingRetention string // plan=duration pairs, e.g. "free=168h,pro=2160h"
+
+	// How long terminal and chat backends stay open for a client whose
+	// connection dropped, so it can resume ("0s" = not at all)
+	ResumeGrace string
+
+	// How long opening a terminal, chat or connect script on a stopped
+	// instance waits for it to wake and its agent to answer
+	WakeTimeout string
+
+	// Master keys for the secrets vault and column encryption, "id:base64key,..."
+	// with the primary first, or a file of one key per line (both empty = disabled)
+	SecretsMasterKeys     string
+	SecretsMasterKeysFile string
+	ColumnEncryption      string // "on" or "read" (decrypt only, for rolling out a new keyring)
+
+	// JWT auth. JWTSecret signs with HS256 unless JWTKeyFiles is set, and
+	// always seals SSO state.
+	JWTSecret          string
+	JWTKeyFiles        string // comma-separated PEM key files, signing key first
+	JWTPreviousSecrets string // comma-separated retired secrets still accepted
+	JWTSecretsUntil    string // RFC 3339 time the secrets stop being accepted once there are key files (empty = never)
+
+	// Single sign-on providers from SSO_PROVIDERS, e.g. "google,okta" (empty =
+	// magic links only)
+	SSOProviders []SSOProvider
 
 	// URLs
 	BaseURL     string // Backend URL (e.g., http://localhost:8080)
@@ -60,13 +137,68 @@ func Load() *Config {
 		ListenAddr:  envOrDefault("LISTEN_ADDR", ":8080"),
 		HCloudToken: os.Getenv("HCLOUD_TOKEN"),
 
+		HetznerSnapshotRetention: envOrDefault("HETZNER_SNAPSHOT_RETENTION", "3"),
+		HetznerSSHKeyName:        os.Getenv("HETZNER_SSH_KEY_NAME"),
+		HetznerSSHKeyFile:        os.Getenv("HETZNER_SSH_KEY_FILE"),
+
+		PodmanSocket: os.Getenv("PODMAN_SOCKET"),
+		PodmanUserNS: envOrDefault("PODMAN_USERNS", "keep-id"),
+
+		KubeConfig:       os.Getenv("KUBECONFIG"),
+		KubeNamespace:    envOrDefault("KUBE_NAMESPACE", "cloudcode"),
+		KubeImage:        envOrDefault("KUBE_INSTANCE_IMAGE", "claude-instance:latest"),
+		KubeStorageClass: os.Getenv("KUBE_STORAGE_CLASS"),
+		KubeVolumeSize:   envOrDefault("KUBE_VOLUME_SIZE", "20Gi"),
+
 		NetbirdAPIURL:   envOrDefault("NETBIRD_API_URL", "https://api.netbird.io"),
 		NetbirdAPIToken: os.Getenv("NETBIRD_API_TOKEN"),
 
 		ActivityCheckInterval: envOrDefault("ACTIVITY_CHECK_INTERVAL", "5m"),
 		IdleThreshold:         envOrDefault("IDLE_THRESHOLD", "2h"),
 
-		JWTSecret: envOrDefault("JWT_SECRET", "dev-jwt-secret-change-in-production"),
+		ReconcileInterval:  envOrDefault("RECONCILE_INTERVAL", "10m"),
+		ReconcileDryRun:    os.Getenv("RECONCILE_DRY_RUN") == "true",
+		ReconcileGCOrphans: os.Getenv("RECONCILE_GC_ORPHANS") == "true",
+
+		InstanceLimits: envOrDefault("INSTANCE_LIMITS", "free=1,starter=2,pro=5"),
+		PlanClasses:    envOrDefault("PLAN_CLASSES", "free=small,starter=small|medium,pro=small|medium|large"),
+
+		WarmPool:         os.Getenv("WARM_POOL"),
+		WarmPoolInterval: envOrDefault("WARM_POOL_INTERVAL", "1m"),
+
+		JobWorkers:      envOrDefault("JOB_WORKERS", "4"),
+		JobPollInterval: envOrDefault("JOB_POLL_INTERVAL", "2s"),
+
+		BackupStore:         os.Getenv("BACKUP_STORE"),
+		BackupLocalDir:      envOrDefault("BACKUP_LOCAL_DIR", "backups"),
+		BackupS3Endpoint:    os.Getenv("BACKUP_S3_ENDPOINT"),
+		BackupS3Bucket:      os.Getenv("BACKUP_S3_BUCKET"),
+		BackupS3AccessKey:   os.Getenv("BACKUP_S3_ACCESS_KEY"),
+		BackupS3SecretKey:   os.Getenv("BACKUP_S3_SECRET_KEY"),
+		BackupS3Region:      os.Getenv("BACKUP_S3_REGION"),
+		BackupS3UseSSL:      os.Getenv("BACKUP_S3_USE_SSL") != "false",
+		BackupSchedule:      os.Getenv("BACKUP_SCHEDULE"),
+		BackupRetention:     envOrDefault("BACKUP_RETENTION", "7"),
+		BackupCheckInterval: envOrDefault("BACKUP_CHECK_INTERVAL", "15m"),
+
+		RecordingStore:     os.Getenv("RECORDING_STORE"),
+		RecordingLocalDir:  envOrDefault("RECORDING_LOCAL_DIR", "recordings"),
+		RecordingS3Bucket:  os.Getenv("RECORDING_S3_BUCKET"),
+		RecordingRetention: envOrDefault("RECORDING_RETENTION", "free=168h,starter=720h,pro=2160h"),
+
+		ResumeGrace: envOrDefault("RESUME_GRACE", "30s"),
+		WakeTimeout: envOrDefault("WAKE_TIMEOUT", "3m"),
+
+		SecretsMasterKeys:     os.Getenv("SECRETS_MASTER_KEYS"),
+		SecretsMasterKeysFile: os.Getenv("SECRETS_MASTER_KEYS_FILE"),
+		ColumnEncryption:      envOrDefault("COLUMN_ENCRYPTION", "on"),
+
+		JWTSecret:          envOrDefault("JWT_SECRET", "dev-jwt-secret-change-in-production"),
+		JWTKeyFiles:        os.Getenv("JWT_KEY_FILES"),
+		JWTPreviousSecrets: os.Getenv("JWT_PREVIOUS_SECRETS"),
+		JWTSecretsUntil:    os.Getenv("JWT_SECRETS_UNTIL"),
+
+		SSOProviders: loadSSOProviders(os.Getenv("SSO_PROVIDERS")),
 
 		BaseURL:     envOrDefault("BASE_URL", "http://localhost:8080"),
 		FrontendURL: envOrDefault("FRONTEND_URL", "http://localhost:3000"),
@@ -88,6 +220,39 @@ func Load() *Config {
 	}
 }
 
+// SSOProvider configures one single sign-on provider. Each field is read
+// from SSO_<NAME>_<FIELD>, e.g. SSO_OKTA_CLIENT_ID.
+type SSOProvider struct {
+	Name           string
+	Kind           string // "oidc" or "github"; empty = github for "github", else oidc
+	Issuer         string // empty = well-known issuer for "google" and "github"
+	ClientID       string
+	ClientSecret   string
+	Scopes         string // space-separated, in addition to the defaults
+	AllowedDomains string // comma-separated email domains (empty = any)
+}
+
+func loadSSOProviders(names string) []SSOProvider {
+	var out []SSOProvider
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		prefix := "SSO_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		out = append(out, SSOProvider{
+			Name:           name,
+			Kind:           os.Getenv(prefix + "KIND"),
+			Issuer:         os.Getenv(prefix + "ISSUER"),
+			ClientID:       os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret:   os.Getenv(prefix + "CLIENT_SECRET"),
+			Scopes:         os.Getenv(prefix + "SCOPES"),
+			AllowedDomains: os.Getenv(prefix + "ALLOWED_DOMAINS"),
+		})
+	}
+	return out
+}
+
 // Validate checks that critical configuration is set for production mode.
 // Returns nil if everything is okay, or an error describing what's missing.
 func (c *Config) Validate() error {
@@ -0,0 +1,160 @@
+// Package devcontainer reads the parts of a devcontainer.json that map onto
+// an instance environment: the image, environment variables and the
+// lifecycle commands run when the container is first created.
+package devcontainer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrBuildUnsupported indicates a devcontainer.json that builds its image
+// from a Dockerfile. Instances boot prebuilt images only.
+var ErrBuildUnsupported = errors.New("devcontainer build is not supported; reference a prebuilt image")
+
+// Config is the supported subset of devcontainer.json. Features, ports,
+// mounts and customizations are ignored.
+type Config struct {
+	Image             string            `json:"image"`
+	ContainerEnv      map[string]string `json:"containerEnv"`
+	RemoteEnv         map[string]string `json:"remoteEnv"`
+	OnCreateCommand   Command           `json:"onCreateCommand"`
+	PostCreateCommand Command           `json:"postCreateCommand"`
+
+	Build      json.RawMessage `json:"build"`
+	DockerFile string          `json:"dockerFile"`
+}
+
+// Command is a lifecycle command as shell lines. devcontainer.json allows a
+// string (run by a shell), an array (run without one) or an object of named
+// commands; named commands run one after another in name order rather than
+// in parallel.
+type Command []string
+
+// UnmarshalJSON accepts the three lifecycle command forms.
+func (c *Command) UnmarshalJSON(data []byte) error {
+	line, err := commandLine(data)
+	if err == nil {
+		*c = nil
+		if line != "" {
+			*c = Command{line}
+		}
+		return nil
+	}
+
+	var named map[string]json.RawMessage
+	if json.Unmarshal(data, &named) != nil {
+		return err
+	}
+	*c = nil
+	for _, name := range slices.Sorted(maps.Keys(named)) {
+		line, err := commandLine(named[name])
+		if err != nil {
+			return fmt.Errorf("command %q: %w", name, err)
+		}
+		if line != "" {
+			*c = append(*c, line)
+		}
+	}
+	return nil
+}
+
+// commandLine converts a string or argv array command to a shell line.
+func commandLine(data []byte) (string, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s, nil
+	}
+	var argv []string
+	if err := json.Unmarshal(data, &argv); err != nil {
+		return "", errors.New("want a string, an array of strings or an object")
+	}
+	quoted := make([]string, len(argv))
+	for i, arg := range argv {
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " "), nil
+}
+
+// Parse reads a devcontainer.json. Comments and trailing commas are allowed,
+// as in the files VS Code writes.
+func Parse(data []byte) (*Config, error) {
+	var c Config
+	if err := json.Unmarshal(standardize(data), &c); err != nil {
+		return nil, fmt.Errorf("parse devcontainer.json: %w", err)
+	}
+	if len(c.Build) > 0 || c.DockerFile != "" {
+		return nil, ErrBuildUnsupported
+	}
+	return &c, nil
+}
+
+// Env returns containerEnv overlaid with remoteEnv.
+func (c *Config) Env() map[string]string {
+	env := make(map[string]string, len(c.ContainerEnv)+len(c.RemoteEnv))
+	maps.Copy(env, c.ContainerEnv)
+	maps.Copy(env, c.RemoteEnv)
+	return env
+}
+
+// SetupScript returns onCreateCommand followed by postCreateCommand as a
+// script, or "" if there are none.
+func (c *Config) SetupScript() string {
+	lines := slices.Concat(c.OnCreateCommand, c.PostCreateCommand)
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// standardize turns JSON with comments into plain JSON: comments become
+// spaces and commas before a closing bracket are dropped. String contents
+// are left alone.
+func standardize(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		switch c := data[i]; {
+		case c == '"':
+			start := i
+			for i++; i < len(data) && data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+			out = append(out, data[start:min(i+1, len(data))]...)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			out = append(out, '\n')
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := strings.Index(string(data[i+2:]), "*/")
+			if end < 0 {
+				return append(out, ' ')
+			}
+			i += end + 3
+			out = append(out, ' ')
+		case c == ']' || c == '}':
+			// Drop a trailing comma before the closing bracket
+			j := len(out) - 1
+			for j >= 0 && isSpace(out[j]) {
+				j--
+			}
+			if j >= 0 && out[j] == ',' {
+				out = append(out[:j], out[j+1:]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,52 @@
+package devcontainer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	data := []byte(`{
+		// Go toolchain
+		"name": "go",
+		"image": "mcr.microsoft.com/devcontainers/go:1.24",
+		"containerEnv": {"GOFLAGS": "-mod=mod", "EDITOR": "vi"},
+		"remoteEnv": {"EDITOR": "nvim", /* overrides containerEnv */ "URL": "http://example.com//x"},
+		"onCreateCommand": ["go", "install", "it's/tool@latest"],
+		"postCreateCommand": {
+			"b-deps": "go mod download",
+			"a-lint": "make lint",
+		},
+		"features": {"ghcr.io/devcontainers/features/node:1": {}},
+	}`)
+
+	c, err := Parse(data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if c.Image != "mcr.microsoft.com/devcontainers/go:1.24" {
+		t.Errorf("image = %q", c.Image)
+	}
+	env := c.Env()
+	if env["GOFLAGS"] != "-mod=mod" || env["EDITOR"] != "nvim" || env["URL"] != "http://example.com//x" {
+		t.Errorf("env = %v", env)
+	}
+	want := "'go' 'install' 'it'\\''s/tool@latest'\nmake lint\ngo mod download\n"
+	if got := c.SetupScript(); got != want {
+		t.Errorf("setup script = %q, want %q", got, want)
+	}
+}
+
+func TestParseRejectsBuild(t *testing.T) {
+	for _, data := range []string{
+		`{"build": {"dockerfile": "Dockerfile"}}`,
+		`{"dockerFile": "Dockerfile"}`,
+	} {
+		if _, err := Parse([]byte(data)); !errors.Is(err, ErrBuildUnsupported) {
+			t.Errorf("Parse(%s): got %v, want ErrBuildUnsupported", data, err)
+		}
+	}
+	if _, err := Parse([]byte(`{"postCreateCommand": 42}`)); err == nil {
+		t.Error("expected an error for a numeric command")
+	}
+}
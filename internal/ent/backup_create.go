@@ -0,0 +1,394 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/backup"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// BackupCreate is the builder for creating a Backup entity.
+type BackupCreate struct {
+	config
+	mutation *BackupMutation
+	hooks    []Hook
+}
+
+// SetInstanceID sets the "instance_id" field.
+func (_c *BackupCreate) SetInstanceID(v int) *BackupCreate {
+	_c.mutation.SetInstanceID(v)
+	return _c
+}
+
+// SetVolumeID sets the "volume_id" field.
+func (_c *BackupCreate) SetVolumeID(v string) *BackupCreate {
+	_c.mutation.SetVolumeID(v)
+	return _c
+}
+
+// SetNillableVolumeID sets the "volume_id" field if the given value is not nil.
+func (_c *BackupCreate) SetNillableVolumeID(v *string) *BackupCreate {
+	if v != nil {
+		_c.SetVolumeID(*v)
+	}
+	return _c
+}
+
+// SetKey sets the "key" field.
+func (_c *BackupCreate) SetKey(v string) *BackupCreate {
+	_c.mutation.SetKey(v)
+	return _c
+}
+
+// SetSizeBytes sets the "size_bytes" field.
+func (_c *BackupCreate) SetSizeBytes(v int64) *BackupCreate {
+	_c.mutation.SetSizeBytes(v)
+	return _c
+}
+
+// SetNillableSizeBytes sets the "size_bytes" field if the given value is not nil.
+func (_c *BackupCreate) SetNillableSizeBytes(v *int64) *BackupCreate {
+	if v != nil {
+		_c.SetSizeBytes(*v)
+	}
+	return _c
+}
+
+// SetStatus sets the "status" field.
+func (_c *BackupCreate) SetStatus(v string) *BackupCreate {
+	_c.mutation.SetStatus(v)
+	return _c
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_c *BackupCreate) SetNillableStatus(v *string) *BackupCreate {
+	if v != nil {
+		_c.SetStatus(*v)
+	}
+	return _c
+}
+
+// SetTrigger sets the "trigger" field.
+func (_c *BackupCreate) SetTrigger(v string) *BackupCreate {
+	_c.mutation.SetTrigger(v)
+	return _c
+}
+
+// SetNillableTrigger sets the "trigger" field if the given value is not nil.
+func (_c *BackupCreate) SetNillableTrigger(v *string) *BackupCreate {
+	if v != nil {
+		_c.SetTrigger(*v)
+	}
+	return _c
+}
+
+// SetError sets the "error" field.
+func (_c *BackupCreate) SetError(v string) *BackupCreate {
+	_c.mutation.SetError(v)
+	return _c
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (_c *BackupCreate) SetNillableError(v *string) *BackupCreate {
+	if v != nil {
+		_c.SetError(*v)
+	}
+	return _c
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (_c *BackupCreate) SetFinishedAt(v time.Time) *BackupCreate {
+	_c.mutation.SetFinishedAt(v)
+	return _c
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (_c *BackupCreate) SetNillableFinishedAt(v *time.Time) *BackupCreate {
+	if v != nil {
+		_c.SetFinishedAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *BackupCreate) SetCreatedAt(v time.Time) *BackupCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *BackupCreate) SetNillableCreatedAt(v *time.Time) *BackupCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_c *BackupCreate) SetOwnerID(id int) *BackupCreate {
+	_c.mutation.SetOwnerID(id)
+	return _c
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_c *BackupCreate) SetOwner(v *User) *BackupCreate {
+	return _c.SetOwnerID(v.ID)
+}
+
+// Mutation returns the BackupMutation object of the builder.
+func (_c *BackupCreate) Mutation() *BackupMutation {
+	return _c.mutation
+}
+
+// Save creates the Backup in the database.
+func (_c *BackupCreate) Save(ctx context.Context) (*Backup, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *BackupCreate) SaveX(ctx context.Context) *Backup {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *BackupCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *BackupCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *BackupCreate) defaults() {
+	if _, ok := _c.mutation.SizeBytes(); !ok {
+		v := backup.DefaultSizeBytes
+		_c.mutation.SetSizeBytes(v)
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		v := backup.DefaultStatus
+		_c.mutation.SetStatus(v)
+	}
+	if _, ok := _c.mutation.Trigger(); !ok {
+		v := backup.DefaultTrigger
+		_c.mutation.SetTrigger(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := backup.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *BackupCreate) check() error {
+	if _, ok := _c.mutation.InstanceID(); !ok {
+		return &ValidationError{Name: "instance_id", err: errors.New(`ent: missing required field "Backup.instance_id"`)}
+	}
+	if _, ok := _c.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "Backup.key"`)}
+	}
+	if v, ok := _c.mutation.Key(); ok {
+		if err := backup.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "Backup.key": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.SizeBytes(); !ok {
+		return &ValidationError{Name: "size_bytes", err: errors.New(`ent: missing required field "Backup.size_bytes"`)}
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Backup.status"`)}
+	}
+	if _, ok := _c.mutation.Trigger(); !ok {
+		return &ValidationError{Name: "trigger", err: errors.New(`ent: missing required field "Backup.trigger"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Backup.created_at"`)}
+	}
+	if len(_c.mutation.OwnerIDs()) == 0 {
+		return &ValidationError{Name: "owner", err: errors.New(`ent: missing required edge "Backup.owner"`)}
+	}
+	return nil
+}
+
+func (_c *BackupCreate) sqlSave(ctx context.Context) (*Backup, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *BackupCreate) createSpec() (*Backup, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Backup{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(backup.Table, sqlgraph.NewFieldSpec(backup.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.InstanceID(); ok {
+		_spec.SetField(backup.FieldInstanceID, field.TypeInt, value)
+		_node.InstanceID = value
+	}
+	if value, ok := _c.mutation.VolumeID(); ok {
+		_spec.SetField(backup.FieldVolumeID, field.TypeString, value)
+		_node.VolumeID = value
+	}
+	if value, ok := _c.mutation.Key(); ok {
+		_spec.SetField(backup.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := _c.mutation.SizeBytes(); ok {
+		_spec.SetField(backup.FieldSizeBytes, field.TypeInt64, value)
+		_node.SizeBytes = value
+	}
+	if value, ok := _c.mutation.Status(); ok {
+		_spec.SetField(backup.FieldStatus, field.TypeString, value)
+		_node.Status = value
+	}
+	if value, ok := _c.mutation.Trigger(); ok {
+		_spec.SetField(backup.FieldTrigger, field.TypeString, value)
+		_node.Trigger = value
+	}
+	if value, ok := _c.mutation.Error(); ok {
+		_spec.SetField(backup.FieldError, field.TypeString, value)
+		_node.Error = value
+	}
+	if value, ok := _c.mutation.FinishedAt(); ok {
+		_spec.SetField(backup.FieldFinishedAt, field.TypeTime, value)
+		_node.FinishedAt = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(backup.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   backup.OwnerTable,
+			Columns: []string{backup.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_backups = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// BackupCreateBulk is the builder for creating many Backup entities in bulk.
+type BackupCreateBulk struct {
+	config
+	err      error
+	builders []*BackupCreate
+}
+
+// Save creates the Backup entities in the database.
+func (_c *BackupCreateBulk) Save(ctx context.Context) ([]*Backup, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Backup, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*BackupMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *BackupCreateBulk) SaveX(ctx context.Context) []*Backup {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *BackupCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *BackupCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
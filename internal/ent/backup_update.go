@@ -0,0 +1,667 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/backup"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// BackupUpdate is the builder for updating Backup entities.
+type BackupUpdate struct {
+	config
+	hooks    []Hook
+	mutation *BackupMutation
+}
+
+// Where appends a list predicates to the BackupUpdate builder.
+func (_u *BackupUpdate) Where(ps ...predicate.Backup) *BackupUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetInstanceID sets the "instance_id" field.
+func (_u *BackupUpdate) SetInstanceID(v int) *BackupUpdate {
+	_u.mutation.ResetInstanceID()
+	_u.mutation.SetInstanceID(v)
+	return _u
+}
+
+// SetNillableInstanceID sets the "instance_id" field if the given value is not nil.
+func (_u *BackupUpdate) SetNillableInstanceID(v *int) *BackupUpdate {
+	if v != nil {
+		_u.SetInstanceID(*v)
+	}
+	return _u
+}
+
+// AddInstanceID adds value to the "instance_id" field.
+func (_u *BackupUpdate) AddInstanceID(v int) *BackupUpdate {
+	_u.mutation.AddInstanceID(v)
+	return _u
+}
+
+// SetVolumeID sets the "volume_id" field.
+func (_u *BackupUpdate) SetVolumeID(v string) *BackupUpdate {
+	_u.mutation.SetVolumeID(v)
+	return _u
+}
+
+// SetNillableVolumeID sets the "volume_id" field if the given value is not nil.
+func (_u *BackupUpdate) SetNillableVolumeID(v *string) *BackupUpdate {
+	if v != nil {
+		_u.SetVolumeID(*v)
+	}
+	return _u
+}
+
+// ClearVolumeID clears the value of the "volume_id" field.
+func (_u *BackupUpdate) ClearVolumeID() *BackupUpdate {
+	_u.mutation.ClearVolumeID()
+	return _u
+}
+
+// SetKey sets the "key" field.
+func (_u *BackupUpdate) SetKey(v string) *BackupUpdate {
+	_u.mutation.SetKey(v)
+	return _u
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (_u *BackupUpdate) SetNillableKey(v *string) *BackupUpdate {
+	if v != nil {
+		_u.SetKey(*v)
+	}
+	return _u
+}
+
+// SetSizeBytes sets the "size_bytes" field.
+func (_u *BackupUpdate) SetSizeBytes(v int64) *BackupUpdate {
+	_u.mutation.ResetSizeBytes()
+	_u.mutation.SetSizeBytes(v)
+	return _u
+}
+
+// SetNillableSizeBytes sets the "size_bytes" field if the given value is not nil.
+func (_u *BackupUpdate) SetNillableSizeBytes(v *int64) *BackupUpdate {
+	if v != nil {
+		_u.SetSizeBytes(*v)
+	}
+	return _u
+}
+
+// AddSizeBytes adds value to the "size_bytes" field.
+func (_u *BackupUpdate) AddSizeBytes(v int64) *BackupUpdate {
+	_u.mutation.AddSizeBytes(v)
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *BackupUpdate) SetStatus(v string) *BackupUpdate {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *BackupUpdate) SetNillableStatus(v *string) *BackupUpdate {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetTrigger sets the "trigger" field.
+func (_u *BackupUpdate) SetTrigger(v string) *BackupUpdate {
+	_u.mutation.SetTrigger(v)
+	return _u
+}
+
+// SetNillableTrigger sets the "trigger" field if the given value is not nil.
+func (_u *BackupUpdate) SetNillableTrigger(v *string) *BackupUpdate {
+	if v != nil {
+		_u.SetTrigger(*v)
+	}
+	return _u
+}
+
+// SetError sets the "error" field.
+func (_u *BackupUpdate) SetError(v string) *BackupUpdate {
+	_u.mutation.SetError(v)
+	return _u
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (_u *BackupUpdate) SetNillableError(v *string) *BackupUpdate {
+	if v != nil {
+		_u.SetError(*v)
+	}
+	return _u
+}
+
+// ClearError clears the value of the "error" field.
+func (_u *BackupUpdate) ClearError() *BackupUpdate {
+	_u.mutation.ClearError()
+	return _u
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (_u *BackupUpdate) SetFinishedAt(v time.Time) *BackupUpdate {
+	_u.mutation.SetFinishedAt(v)
+	return _u
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (_u *BackupUpdate) SetNillableFinishedAt(v *time.Time) *BackupUpdate {
+	if v != nil {
+		_u.SetFinishedAt(*v)
+	}
+	return _u
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (_u *BackupUpdate) ClearFinishedAt() *BackupUpdate {
+	_u.mutation.ClearFinishedAt()
+	return _u
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_u *BackupUpdate) SetOwnerID(id int) *BackupUpdate {
+	_u.mutation.SetOwnerID(id)
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *BackupUpdate) SetOwner(v *User) *BackupUpdate {
+	return _u.SetOwnerID(v.ID)
+}
+
+// Mutation returns the BackupMutation object of the builder.
+func (_u *BackupUpdate) Mutation() *BackupMutation {
+	return _u.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *BackupUpdate) ClearOwner() *BackupUpdate {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *BackupUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *BackupUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *BackupUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *BackupUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *BackupUpdate) check() error {
+	if v, ok := _u.mutation.Key(); ok {
+		if err := backup.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "Backup.key": %w`, err)}
+		}
+	}
+	if _u.mutation.OwnerCleared() && len(_u.mutation.OwnerIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Backup.owner"`)
+	}
+	return nil
+}
+
+func (_u *BackupUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(backup.Table, backup.Columns, sqlgraph.NewFieldSpec(backup.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.InstanceID(); ok {
+		_spec.SetField(backup.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedInstanceID(); ok {
+		_spec.AddField(backup.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.VolumeID(); ok {
+		_spec.SetField(backup.FieldVolumeID, field.TypeString, value)
+	}
+	if _u.mutation.VolumeIDCleared() {
+		_spec.ClearField(backup.FieldVolumeID, field.TypeString)
+	}
+	if value, ok := _u.mutation.Key(); ok {
+		_spec.SetField(backup.FieldKey, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.SizeBytes(); ok {
+		_spec.SetField(backup.FieldSizeBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedSizeBytes(); ok {
+		_spec.AddField(backup.FieldSizeBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(backup.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Trigger(); ok {
+		_spec.SetField(backup.FieldTrigger, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Error(); ok {
+		_spec.SetField(backup.FieldError, field.TypeString, value)
+	}
+	if _u.mutation.ErrorCleared() {
+		_spec.ClearField(backup.FieldError, field.TypeString)
+	}
+	if value, ok := _u.mutation.FinishedAt(); ok {
+		_spec.SetField(backup.FieldFinishedAt, field.TypeTime, value)
+	}
+	if _u.mutation.FinishedAtCleared() {
+		_spec.ClearField(backup.FieldFinishedAt, field.TypeTime)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   backup.OwnerTable,
+			Columns: []string{backup.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   backup.OwnerTable,
+			Columns: []string{backup.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{backup.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// BackupUpdateOne is the builder for updating a single Backup entity.
+type BackupUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *BackupMutation
+}
+
+// SetInstanceID sets the "instance_id" field.
+func (_u *BackupUpdateOne) SetInstanceID(v int) *BackupUpdateOne {
+	_u.mutation.ResetInstanceID()
+	_u.mutation.SetInstanceID(v)
+	return _u
+}
+
+// SetNillableInstanceID sets the "instance_id" field if the given value is not nil.
+func (_u *BackupUpdateOne) SetNillableInstanceID(v *int) *BackupUpdateOne {
+	if v != nil {
+		_u.SetInstanceID(*v)
+	}
+	return _u
+}
+
+// AddInstanceID adds value to the "instance_id" field.
+func (_u *BackupUpdateOne) AddInstanceID(v int) *BackupUpdateOne {
+	_u.mutation.AddInstanceID(v)
+	return _u
+}
+
+// SetVolumeID sets the "volume_id" field.
+func (_u *BackupUpdateOne) SetVolumeID(v string) *BackupUpdateOne {
+	_u.mutation.SetVolumeID(v)
+	return _u
+}
+
+// SetNillableVolumeID sets the "volume_id" field if the given value is not nil.
+func (_u *BackupUpdateOne) SetNillableVolumeID(v *string) *BackupUpdateOne {
+	if v != nil {
+		_u.SetVolumeID(*v)
+	}
+	return _u
+}
+
+// ClearVolumeID clears the value of the "volume_id" field.
+func (_u *BackupUpdateOne) ClearVolumeID() *BackupUpdateOne {
+	_u.mutation.ClearVolumeID()
+	return _u
+}
+
+// SetKey sets the "key" field.
+func (_u *BackupUpdateOne) SetKey(v string) *BackupUpdateOne {
+	_u.mutation.SetKey(v)
+	return _u
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (_u *BackupUpdateOne) SetNillableKey(v *string) *BackupUpdateOne {
+	if v != nil {
+		_u.SetKey(*v)
+	}
+	return _u
+}
+
+// SetSizeBytes sets the "size_bytes" field.
+func (_u *BackupUpdateOne) SetSizeBytes(v int64) *BackupUpdateOne {
+	_u.mutation.ResetSizeBytes()
+	_u.mutation.SetSizeBytes(v)
+	return _u
+}
+
+// SetNillableSizeBytes sets the "size_bytes" field if the given value is not nil.
+func (_u *BackupUpdateOne) SetNillableSizeBytes(v *int64) *BackupUpdateOne {
+	if v != nil {
+		_u.SetSizeBytes(*v)
+	}
+	return _u
+}
+
+// AddSizeBytes adds value to the "size_bytes" field.
+func (_u *BackupUpdateOne) AddSizeBytes(v int64) *BackupUpdateOne {
+	_u.mutation.AddSizeBytes(v)
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *BackupUpdateOne) SetStatus(v string) *BackupUpdateOne {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *BackupUpdateOne) SetNillableStatus(v *string) *BackupUpdateOne {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetTrigger sets the "trigger" field.
+func (_u *BackupUpdateOne) SetTrigger(v string) *BackupUpdateOne {
+	_u.mutation.SetTrigger(v)
+	return _u
+}
+
+// SetNillableTrigger sets the "trigger" field if the given value is not nil.
+func (_u *BackupUpdateOne) SetNillableTrigger(v *string) *BackupUpdateOne {
+	if v != nil {
+		_u.SetTrigger(*v)
+	}
+	return _u
+}
+
+// SetError sets the "error" field.
+func (_u *BackupUpdateOne) SetError(v string) *BackupUpdateOne {
+	_u.mutation.SetError(v)
+	return _u
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (_u *BackupUpdateOne) SetNillableError(v *string) *BackupUpdateOne {
+	if v != nil {
+		_u.SetError(*v)
+	}
+	return _u
+}
+
+// ClearError clears the value of the "error" field.
+func (_u *BackupUpdateOne) ClearError() *BackupUpdateOne {
+	_u.mutation.ClearError()
+	return _u
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (_u *BackupUpdateOne) SetFinishedAt(v time.Time) *BackupUpdateOne {
+	_u.mutation.SetFinishedAt(v)
+	return _u
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (_u *BackupUpdateOne) SetNillableFinishedAt(v *time.Time) *BackupUpdateOne {
+	if v != nil {
+		_u.SetFinishedAt(*v)
+	}
+	return _u
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (_u *BackupUpdateOne) ClearFinishedAt() *BackupUpdateOne {
+	_u.mutation.ClearFinishedAt()
+	return _u
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_u *BackupUpdateOne) SetOwnerID(id int) *BackupUpdateOne {
+	_u.mutation.SetOwnerID(id)
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *BackupUpdateOne) SetOwner(v *User) *BackupUpdateOne {
+	return _u.SetOwnerID(v.ID)
+}
+
+// Mutation returns the BackupMutation object of the builder.
+func (_u *BackupUpdateOne) Mutation() *BackupMutation {
+	return _u.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *BackupUpdateOne) ClearOwner() *BackupUpdateOne {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
+// Where appends a list predicates to the BackupUpdate builder.
+func (_u *BackupUpdateOne) Where(ps ...predicate.Backup) *BackupUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *BackupUpdateOne) Select(field string, fields ...string) *BackupUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Backup entity.
+func (_u *BackupUpdateOne) Save(ctx context.Context) (*Backup, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *BackupUpdateOne) SaveX(ctx context.Context) *Backup {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *BackupUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *BackupUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *BackupUpdateOne) check() error {
+	if v, ok := _u.mutation.Key(); ok {
+		if err := backup.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "Backup.key": %w`, err)}
+		}
+	}
+	if _u.mutation.OwnerCleared() && len(_u.mutation.OwnerIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Backup.owner"`)
+	}
+	return nil
+}
+
+func (_u *BackupUpdateOne) sqlSave(ctx context.Context) (_node *Backup, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(backup.Table, backup.Columns, sqlgraph.NewFieldSpec(backup.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Backup.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, backup.FieldID)
+		for _, f := range fields {
+			if !backup.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != backup.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.InstanceID(); ok {
+		_spec.SetField(backup.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedInstanceID(); ok {
+		_spec.AddField(backup.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.VolumeID(); ok {
+		_spec.SetField(backup.FieldVolumeID, field.TypeString, value)
+	}
+	if _u.mutation.VolumeIDCleared() {
+		_spec.ClearField(backup.FieldVolumeID, field.TypeString)
+	}
+	if value, ok := _u.mutation.Key(); ok {
+		_spec.SetField(backup.FieldKey, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.SizeBytes(); ok {
+		_spec.SetField(backup.FieldSizeBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedSizeBytes(); ok {
+		_spec.AddField(backup.FieldSizeBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(backup.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Trigger(); ok {
+		_spec.SetField(backup.FieldTrigger, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Error(); ok {
+		_spec.SetField(backup.FieldError, field.TypeString, value)
+	}
+	if _u.mutation.ErrorCleared() {
+		_spec.ClearField(backup.FieldError, field.TypeString)
+	}
+	if value, ok := _u.mutation.FinishedAt(); ok {
+		_spec.SetField(backup.FieldFinishedAt, field.TypeTime, value)
+	}
+	if _u.mutation.FinishedAtCleared() {
+		_spec.ClearField(backup.FieldFinishedAt, field.TypeTime)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   backup.OwnerTable,
+			Columns: []string{backup.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   backup.OwnerTable,
+			Columns: []string{backup.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Backup{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{backup.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
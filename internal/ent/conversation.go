@@ -10,6 +10,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"github.com/logan/cloudcode/internal/ent/conversation"
+	"github.com/logan/cloudcode/internal/ent/instance"
 	"github.com/logan/cloudcode/internal/ent/user"
 )
 
@@ -28,9 +29,10 @@ type Conversation struct {
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the ConversationQuery when eager-loading is set.
-	Edges              ConversationEdges `json:"edges"`
-	user_conversations *int
-	selectValues       sql.SelectValues
+	Edges                  ConversationEdges `json:"edges"`
+	instance_conversations *int
+	user_conversations     *int
+	selectValues           sql.SelectValues
 }
 
 // ConversationEdges holds the relations/edges for other nodes in the graph.
@@ -39,9 +41,11 @@ type ConversationEdges struct {
 	Owner *User `json:"owner,omitempty"`
 	// Messages holds the value of the messages edge.
 	Messages []*ChatMessage `json:"messages,omitempty"`
+	// Instance holds the value of the instance edge.
+	Instance *Instance `json:"instance,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [2]bool
+	loadedTypes [3]bool
 }
 
 // OwnerOrErr returns the Owner value or an error if the edge
@@ -64,6 +68,17 @@ func (e ConversationEdges) MessagesOrErr() ([]*ChatMessage, error) {
 	return nil, &NotLoadedError{edge: "messages"}
 }
 
+// InstanceOrErr returns the Instance value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ConversationEdges) InstanceOrErr() (*Instance, error) {
+	if e.Instance != nil {
+		return e.Instance, nil
+	} else if e.loadedTypes[2] {
+		return nil, &NotFoundError{label: instance.Label}
+	}
+	return nil, &NotLoadedError{edge: "instance"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Conversation) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -75,7 +90,9 @@ func (*Conversation) scanValues(columns []string) ([]any, error) {
 			values[i] = new(sql.NullString)
 		case conversation.FieldCreatedAt, conversation.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
-		case conversation.ForeignKeys[0]: // user_conversations
+		case conversation.ForeignKeys[0]: // instance_conversations
+			values[i] = new(sql.NullInt64)
+		case conversation.ForeignKeys[1]: // user_conversations
 			values[i] = new(sql.NullInt64)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -123,6 +140,13 @@ func (_m *Conversation) assignValues(columns []string, values []any) error {
 				_m.UpdatedAt = value.Time
 			}
 		case conversation.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field instance_conversations", value)
+			} else if value.Valid {
+				_m.instance_conversations = new(int)
+				*_m.instance_conversations = int(value.Int64)
+			}
+		case conversation.ForeignKeys[1]:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for edge-field user_conversations", value)
 			} else if value.Valid {
@@ -152,6 +176,11 @@ func (_m *Conversation) QueryMessages() *ChatMessageQuery {
 	return NewConversationClient(_m.config).QueryMessages(_m)
 }
 
+// QueryInstance queries the "instance" edge of the Conversation entity.
+func (_m *Conversation) QueryInstance() *InstanceQuery {
+	return NewConversationClient(_m.config).QueryInstance(_m)
+}
+
 // Update returns a builder for updating this Conversation.
 // Note that you need to call Conversation.Unwrap() before calling this method if this Conversation
 // was returned from a transaction, and the transaction was committed or rolled back.
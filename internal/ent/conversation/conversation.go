@@ -26,6 +26,8 @@ const (
 	EdgeOwner = "owner"
 	// EdgeMessages holds the string denoting the messages edge name in mutations.
 	EdgeMessages = "messages"
+	// EdgeInstance holds the string denoting the instance edge name in mutations.
+	EdgeInstance = "instance"
 	// Table holds the table name of the conversation in the database.
 	Table = "conversations"
 	// OwnerTable is the table that holds the owner relation/edge.
@@ -42,6 +44,13 @@ const (
 	MessagesInverseTable = "chat_messages"
 	// MessagesColumn is the table column denoting the messages relation/edge.
 	MessagesColumn = "conversation_messages"
+	// InstanceTable is the table that holds the instance relation/edge.
+	InstanceTable = "conversations"
+	// InstanceInverseTable is the table name for the Instance entity.
+	// It exists in this package in order to avoid circular dependency with the "instance" package.
+	InstanceInverseTable = "instances"
+	// InstanceColumn is the table column denoting the instance relation/edge.
+	InstanceColumn = "instance_conversations"
 )
 
 // Columns holds all SQL columns for conversation fields.
@@ -56,6 +65,7 @@ var Columns = []string{
 // ForeignKeys holds the SQL foreign-keys that are owned by the "conversations"
 // table and are not defined as standalone fields in the schema.
 var ForeignKeys = []string{
+	"instance_conversations",
 	"user_conversations",
 }
 
@@ -135,6 +145,13 @@ func ByMessages(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newMessagesStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// ByInstanceField orders the results by instance field.
+func ByInstanceField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newInstanceStep(), sql.OrderByField(field, opts...))
+	}
+}
 func newOwnerStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -149,3 +166,10 @@ func newMessagesStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, MessagesTable, MessagesColumn),
 	)
 }
+func newInstanceStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(InstanceInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, InstanceTable, InstanceColumn),
+	)
+}
@@ -12,6 +12,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/logan/cloudcode/internal/ent/chatmessage"
 	"github.com/logan/cloudcode/internal/ent/conversation"
+	"github.com/logan/cloudcode/internal/ent/instance"
 	"github.com/logan/cloudcode/internal/ent/user"
 )
 
@@ -104,6 +105,25 @@ func (_c *ConversationCreate) AddMessages(v ...*ChatMessage) *ConversationCreate
 	return _c.AddMessageIDs(ids...)
 }
 
+// SetInstanceID sets the "instance" edge to the Instance entity by ID.
+func (_c *ConversationCreate) SetInstanceID(id int) *ConversationCreate {
+	_c.mutation.SetInstanceID(id)
+	return _c
+}
+
+// SetNillableInstanceID sets the "instance" edge to the Instance entity by ID if the given value is not nil.
+func (_c *ConversationCreate) SetNillableInstanceID(id *int) *ConversationCreate {
+	if id != nil {
+		_c = _c.SetInstanceID(*id)
+	}
+	return _c
+}
+
+// SetInstance sets the "instance" edge to the Instance entity.
+func (_c *ConversationCreate) SetInstance(v *Instance) *ConversationCreate {
+	return _c.SetInstanceID(v.ID)
+}
+
 // Mutation returns the ConversationMutation object of the builder.
 func (_c *ConversationCreate) Mutation() *ConversationMutation {
 	return _c.mutation
@@ -249,6 +269,23 @@ func (_c *ConversationCreate) createSpec() (*Conversation, *sqlgraph.CreateSpec)
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.InstanceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   conversation.InstanceTable,
+			Columns: []string{conversation.InstanceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(instance.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.instance_conversations = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
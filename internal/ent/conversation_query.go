@@ -14,6 +14,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/logan/cloudcode/internal/ent/chatmessage"
 	"github.com/logan/cloudcode/internal/ent/conversation"
+	"github.com/logan/cloudcode/internal/ent/instance"
 	"github.com/logan/cloudcode/internal/ent/predicate"
 	"github.com/logan/cloudcode/internal/ent/user"
 )
@@ -27,6 +28,7 @@ type ConversationQuery struct {
 	predicates   []predicate.Conversation
 	withOwner    *UserQuery
 	withMessages *ChatMessageQuery
+	withInstance *InstanceQuery
 	withFKs      bool
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
@@ -108,6 +110,28 @@ func (_q *ConversationQuery) QueryMessages() *ChatMessageQuery {
 	return query
 }
 
+// QueryInstance chains the current query on the "instance" edge.
+func (_q *ConversationQuery) QueryInstance() *InstanceQuery {
+	query := (&InstanceClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(conversation.Table, conversation.FieldID, selector),
+			sqlgraph.To(instance.Table, instance.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, conversation.InstanceTable, conversation.InstanceColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Conversation entity from the query.
 // Returns a *NotFoundError when no Conversation was found.
 func (_q *ConversationQuery) First(ctx context.Context) (*Conversation, error) {
@@ -302,6 +326,7 @@ func (_q *ConversationQuery) Clone() *ConversationQuery {
 		predicates:   append([]predicate.Conversation{}, _q.predicates...),
 		withOwner:    _q.withOwner.Clone(),
 		withMessages: _q.withMessages.Clone(),
+		withInstance: _q.withInstance.Clone(),
 		// clone intermediate query.
 		sql:  _q.sql.Clone(),
 		path: _q.path,
@@ -330,6 +355,17 @@ func (_q *ConversationQuery) WithMessages(opts ...func(*ChatMessageQuery)) *Conv
 	return _q
 }
 
+// WithInstance tells the query-builder to eager-load the nodes that are connected to
+// the "instance" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *ConversationQuery) WithInstance(opts ...func(*InstanceQuery)) *ConversationQuery {
+	query := (&InstanceClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withInstance = query
+	return _q
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -409,12 +445,13 @@ func (_q *ConversationQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]
 		nodes       = []*Conversation{}
 		withFKs     = _q.withFKs
 		_spec       = _q.querySpec()
-		loadedTypes = [2]bool{
+		loadedTypes = [3]bool{
 			_q.withOwner != nil,
 			_q.withMessages != nil,
+			_q.withInstance != nil,
 		}
 	)
-	if _q.withOwner != nil {
+	if _q.withOwner != nil || _q.withInstance != nil {
 		withFKs = true
 	}
 	if withFKs {
@@ -451,6 +488,12 @@ func (_q *ConversationQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]
 			return nil, err
 		}
 	}
+	if query := _q.withInstance; query != nil {
+		if err := _q.loadInstance(ctx, query, nodes, nil,
+			func(n *Conversation, e *Instance) { n.Edges.Instance = e }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -517,6 +560,38 @@ func (_q *ConversationQuery) loadMessages(ctx context.Context, query *ChatMessag
 	}
 	return nil
 }
+func (_q *ConversationQuery) loadInstance(ctx context.Context, query *InstanceQuery, nodes []*Conversation, init func(*Conversation), assign func(*Conversation, *Instance)) error {
+	ids := make([]int, 0, len(nodes))
+	nodeids := make(map[int][]*Conversation)
+	for i := range nodes {
+		if nodes[i].instance_conversations == nil {
+			continue
+		}
+		fk := *nodes[i].instance_conversations
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(instance.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "instance_conversations" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
 
 func (_q *ConversationQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := _q.querySpec()
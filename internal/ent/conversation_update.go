@@ -13,6 +13,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/logan/cloudcode/internal/ent/chatmessage"
 	"github.com/logan/cloudcode/internal/ent/conversation"
+	"github.com/logan/cloudcode/internal/ent/instance"
 	"github.com/logan/cloudcode/internal/ent/predicate"
 	"github.com/logan/cloudcode/internal/ent/user"
 )
@@ -90,6 +91,25 @@ func (_u *ConversationUpdate) AddMessages(v ...*ChatMessage) *ConversationUpdate
 	return _u.AddMessageIDs(ids...)
 }
 
+// SetInstanceID sets the "instance" edge to the Instance entity by ID.
+func (_u *ConversationUpdate) SetInstanceID(id int) *ConversationUpdate {
+	_u.mutation.SetInstanceID(id)
+	return _u
+}
+
+// SetNillableInstanceID sets the "instance" edge to the Instance entity by ID if the given value is not nil.
+func (_u *ConversationUpdate) SetNillableInstanceID(id *int) *ConversationUpdate {
+	if id != nil {
+		_u = _u.SetInstanceID(*id)
+	}
+	return _u
+}
+
+// SetInstance sets the "instance" edge to the Instance entity.
+func (_u *ConversationUpdate) SetInstance(v *Instance) *ConversationUpdate {
+	return _u.SetInstanceID(v.ID)
+}
+
 // Mutation returns the ConversationMutation object of the builder.
 func (_u *ConversationUpdate) Mutation() *ConversationMutation {
 	return _u.mutation
@@ -122,6 +142,12 @@ func (_u *ConversationUpdate) RemoveMessages(v ...*ChatMessage) *ConversationUpd
 	return _u.RemoveMessageIDs(ids...)
 }
 
+// ClearInstance clears the "instance" edge to the Instance entity.
+func (_u *ConversationUpdate) ClearInstance() *ConversationUpdate {
+	_u.mutation.ClearInstance()
+	return _u
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *ConversationUpdate) Save(ctx context.Context) (int, error) {
 	_u.defaults()
@@ -261,6 +287,35 @@ func (_u *ConversationUpdate) sqlSave(ctx context.Context) (_node int, err error
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.InstanceCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   conversation.InstanceTable,
+			Columns: []string{conversation.InstanceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(instance.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.InstanceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   conversation.InstanceTable,
+			Columns: []string{conversation.InstanceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(instance.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{conversation.Label}
@@ -341,6 +396,25 @@ func (_u *ConversationUpdateOne) AddMessages(v ...*ChatMessage) *ConversationUpd
 	return _u.AddMessageIDs(ids...)
 }
 
+// SetInstanceID sets the "instance" edge to the Instance entity by ID.
+func (_u *ConversationUpdateOne) SetInstanceID(id int) *ConversationUpdateOne {
+	_u.mutation.SetInstanceID(id)
+	return _u
+}
+
+// SetNillableInstanceID sets the "instance" edge to the Instance entity by ID if the given value is not nil.
+func (_u *ConversationUpdateOne) SetNillableInstanceID(id *int) *ConversationUpdateOne {
+	if id != nil {
+		_u = _u.SetInstanceID(*id)
+	}
+	return _u
+}
+
+// SetInstance sets the "instance" edge to the Instance entity.
+func (_u *ConversationUpdateOne) SetInstance(v *Instance) *ConversationUpdateOne {
+	return _u.SetInstanceID(v.ID)
+}
+
 // Mutation returns the ConversationMutation object of the builder.
 func (_u *ConversationUpdateOne) Mutation() *ConversationMutation {
 	return _u.mutation
@@ -373,6 +447,12 @@ func (_u *ConversationUpdateOne) RemoveMessages(v ...*ChatMessage) *Conversation
 	return _u.RemoveMessageIDs(ids...)
 }
 
+// ClearInstance clears the "instance" edge to the Instance entity.
+func (_u *ConversationUpdateOne) ClearInstance() *ConversationUpdateOne {
+	_u.mutation.ClearInstance()
+	return _u
+}
+
 // Where appends a list predicates to the ConversationUpdate builder.
 func (_u *ConversationUpdateOne) Where(ps ...predicate.Conversation) *ConversationUpdateOne {
 	_u.mutation.Where(ps...)
@@ -542,6 +622,35 @@ func (_u *ConversationUpdateOne) sqlSave(ctx context.Context) (_node *Conversati
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.InstanceCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   conversation.InstanceTable,
+			Columns: []string{conversation.InstanceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(instance.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.InstanceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   conversation.InstanceTable,
+			Columns: []string{conversation.InstanceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(instance.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Conversation{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,224 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/logan/cloudcode/internal/ent/devicecode"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// DeviceCode is the model entity for the DeviceCode schema.
+type DeviceCode struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// SHA-256 of the device code the client polls with
+	DeviceCodeHash string `json:"-"`
+	// Short code the user confirms in the browser, e.g. WDJB-MJHT
+	UserCode string `json:"user_code,omitempty"`
+	// ClientID holds the value of the "client_id" field.
+	ClientID string `json:"client_id,omitempty"`
+	// pending, approved or denied
+	Status string `json:"status,omitempty"`
+	// Minimum seconds between polls; raised on slow_down
+	Interval int `json:"interval,omitempty"`
+	// LastPolledAt holds the value of the "last_polled_at" field.
+	LastPolledAt *time.Time `json:"last_polled_at,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the DeviceCodeQuery when eager-loading is set.
+	Edges             DeviceCodeEdges `json:"edges"`
+	user_device_codes *int
+	selectValues      sql.SelectValues
+}
+
+// DeviceCodeEdges holds the relations/edges for other nodes in the graph.
+type DeviceCodeEdges struct {
+	// The user who approved or denied the device
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e DeviceCodeEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*DeviceCode) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case devicecode.FieldID, devicecode.FieldInterval:
+			values[i] = new(sql.NullInt64)
+		case devicecode.FieldDeviceCodeHash, devicecode.FieldUserCode, devicecode.FieldClientID, devicecode.FieldStatus:
+			values[i] = new(sql.NullString)
+		case devicecode.FieldLastPolledAt, devicecode.FieldExpiresAt, devicecode.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case devicecode.ForeignKeys[0]: // user_device_codes
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the DeviceCode fields.
+func (_m *DeviceCode) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case devicecode.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case devicecode.FieldDeviceCodeHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field device_code_hash", values[i])
+			} else if value.Valid {
+				_m.DeviceCodeHash = value.String
+			}
+		case devicecode.FieldUserCode:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user_code", values[i])
+			} else if value.Valid {
+				_m.UserCode = value.String
+			}
+		case devicecode.FieldClientID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field client_id", values[i])
+			} else if value.Valid {
+				_m.ClientID = value.String
+			}
+		case devicecode.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				_m.Status = value.String
+			}
+		case devicecode.FieldInterval:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field interval", values[i])
+			} else if value.Valid {
+				_m.Interval = int(value.Int64)
+			}
+		case devicecode.FieldLastPolledAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_polled_at", values[i])
+			} else if value.Valid {
+				_m.LastPolledAt = new(time.Time)
+				*_m.LastPolledAt = value.Time
+			}
+		case devicecode.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				_m.ExpiresAt = value.Time
+			}
+		case devicecode.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case devicecode.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_device_codes", value)
+			} else if value.Valid {
+				_m.user_device_codes = new(int)
+				*_m.user_device_codes = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the DeviceCode.
+// This includes values selected through modifiers, order, etc.
+func (_m *DeviceCode) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the DeviceCode entity.
+func (_m *DeviceCode) QueryUser() *UserQuery {
+	return NewDeviceCodeClient(_m.config).QueryUser(_m)
+}
+
+// Update returns a builder for updating this DeviceCode.
+// Note that you need to call DeviceCode.Unwrap() before calling this method if this DeviceCode
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *DeviceCode) Update() *DeviceCodeUpdateOne {
+	return NewDeviceCodeClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the DeviceCode entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *DeviceCode) Unwrap() *DeviceCode {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: DeviceCode is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *DeviceCode) String() string {
+	var builder strings.Builder
+	builder.WriteString("DeviceCode(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("device_code_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("user_code=")
+	builder.WriteString(_m.UserCode)
+	builder.WriteString(", ")
+	builder.WriteString("client_id=")
+	builder.WriteString(_m.ClientID)
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(_m.Status)
+	builder.WriteString(", ")
+	builder.WriteString("interval=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Interval))
+	builder.WriteString(", ")
+	if v := _m.LastPolledAt; v != nil {
+		builder.WriteString("last_polled_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(_m.ExpiresAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// DeviceCodes is a parsable slice of DeviceCode.
+type DeviceCodes []*DeviceCode
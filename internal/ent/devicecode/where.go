@@ -0,0 +1,564 @@
+// Code generated by ent, DO NOT EDIT.
+
+package devicecode
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLTE(FieldID, id))
+}
+
+// DeviceCodeHash applies equality check predicate on the "device_code_hash" field. It's identical to DeviceCodeHashEQ.
+func DeviceCodeHash(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldDeviceCodeHash, v))
+}
+
+// UserCode applies equality check predicate on the "user_code" field. It's identical to UserCodeEQ.
+func UserCode(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldUserCode, v))
+}
+
+// ClientID applies equality check predicate on the "client_id" field. It's identical to ClientIDEQ.
+func ClientID(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldClientID, v))
+}
+
+// Status applies equality check predicate on the "status" field. It's identical to StatusEQ.
+func Status(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldStatus, v))
+}
+
+// Interval applies equality check predicate on the "interval" field. It's identical to IntervalEQ.
+func Interval(v int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldInterval, v))
+}
+
+// LastPolledAt applies equality check predicate on the "last_polled_at" field. It's identical to LastPolledAtEQ.
+func LastPolledAt(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldLastPolledAt, v))
+}
+
+// ExpiresAt applies equality check predicate on the "expires_at" field. It's identical to ExpiresAtEQ.
+func ExpiresAt(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// DeviceCodeHashEQ applies the EQ predicate on the "device_code_hash" field.
+func DeviceCodeHashEQ(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldDeviceCodeHash, v))
+}
+
+// DeviceCodeHashNEQ applies the NEQ predicate on the "device_code_hash" field.
+func DeviceCodeHashNEQ(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNEQ(FieldDeviceCodeHash, v))
+}
+
+// DeviceCodeHashIn applies the In predicate on the "device_code_hash" field.
+func DeviceCodeHashIn(vs ...string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldIn(FieldDeviceCodeHash, vs...))
+}
+
+// DeviceCodeHashNotIn applies the NotIn predicate on the "device_code_hash" field.
+func DeviceCodeHashNotIn(vs ...string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNotIn(FieldDeviceCodeHash, vs...))
+}
+
+// DeviceCodeHashGT applies the GT predicate on the "device_code_hash" field.
+func DeviceCodeHashGT(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGT(FieldDeviceCodeHash, v))
+}
+
+// DeviceCodeHashGTE applies the GTE predicate on the "device_code_hash" field.
+func DeviceCodeHashGTE(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGTE(FieldDeviceCodeHash, v))
+}
+
+// DeviceCodeHashLT applies the LT predicate on the "device_code_hash" field.
+func DeviceCodeHashLT(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLT(FieldDeviceCodeHash, v))
+}
+
+// DeviceCodeHashLTE applies the LTE predicate on the "device_code_hash" field.
+func DeviceCodeHashLTE(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLTE(FieldDeviceCodeHash, v))
+}
+
+// DeviceCodeHashContains applies the Contains predicate on the "device_code_hash" field.
+func DeviceCodeHashContains(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldContains(FieldDeviceCodeHash, v))
+}
+
+// DeviceCodeHashHasPrefix applies the HasPrefix predicate on the "device_code_hash" field.
+func DeviceCodeHashHasPrefix(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldHasPrefix(FieldDeviceCodeHash, v))
+}
+
+// DeviceCodeHashHasSuffix applies the HasSuffix predicate on the "device_code_hash" field.
+func DeviceCodeHashHasSuffix(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldHasSuffix(FieldDeviceCodeHash, v))
+}
+
+// DeviceCodeHashEqualFold applies the EqualFold predicate on the "device_code_hash" field.
+func DeviceCodeHashEqualFold(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEqualFold(FieldDeviceCodeHash, v))
+}
+
+// DeviceCodeHashContainsFold applies the ContainsFold predicate on the "device_code_hash" field.
+func DeviceCodeHashContainsFold(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldContainsFold(FieldDeviceCodeHash, v))
+}
+
+// UserCodeEQ applies the EQ predicate on the "user_code" field.
+func UserCodeEQ(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldUserCode, v))
+}
+
+// UserCodeNEQ applies the NEQ predicate on the "user_code" field.
+func UserCodeNEQ(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNEQ(FieldUserCode, v))
+}
+
+// UserCodeIn applies the In predicate on the "user_code" field.
+func UserCodeIn(vs ...string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldIn(FieldUserCode, vs...))
+}
+
+// UserCodeNotIn applies the NotIn predicate on the "user_code" field.
+func UserCodeNotIn(vs ...string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNotIn(FieldUserCode, vs...))
+}
+
+// UserCodeGT applies the GT predicate on the "user_code" field.
+func UserCodeGT(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGT(FieldUserCode, v))
+}
+
+// UserCodeGTE applies the GTE predicate on the "user_code" field.
+func UserCodeGTE(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGTE(FieldUserCode, v))
+}
+
+// UserCodeLT applies the LT predicate on the "user_code" field.
+func UserCodeLT(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLT(FieldUserCode, v))
+}
+
+// UserCodeLTE applies the LTE predicate on the "user_code" field.
+func UserCodeLTE(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLTE(FieldUserCode, v))
+}
+
+// UserCodeContains applies the Contains predicate on the "user_code" field.
+func UserCodeContains(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldContains(FieldUserCode, v))
+}
+
+// UserCodeHasPrefix applies the HasPrefix predicate on the "user_code" field.
+func UserCodeHasPrefix(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldHasPrefix(FieldUserCode, v))
+}
+
+// UserCodeHasSuffix applies the HasSuffix predicate on the "user_code" field.
+func UserCodeHasSuffix(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldHasSuffix(FieldUserCode, v))
+}
+
+// UserCodeEqualFold applies the EqualFold predicate on the "user_code" field.
+func UserCodeEqualFold(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEqualFold(FieldUserCode, v))
+}
+
+// UserCodeContainsFold applies the ContainsFold predicate on the "user_code" field.
+func UserCodeContainsFold(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldContainsFold(FieldUserCode, v))
+}
+
+// ClientIDEQ applies the EQ predicate on the "client_id" field.
+func ClientIDEQ(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldClientID, v))
+}
+
+// ClientIDNEQ applies the NEQ predicate on the "client_id" field.
+func ClientIDNEQ(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNEQ(FieldClientID, v))
+}
+
+// ClientIDIn applies the In predicate on the "client_id" field.
+func ClientIDIn(vs ...string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldIn(FieldClientID, vs...))
+}
+
+// ClientIDNotIn applies the NotIn predicate on the "client_id" field.
+func ClientIDNotIn(vs ...string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNotIn(FieldClientID, vs...))
+}
+
+// ClientIDGT applies the GT predicate on the "client_id" field.
+func ClientIDGT(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGT(FieldClientID, v))
+}
+
+// ClientIDGTE applies the GTE predicate on the "client_id" field.
+func ClientIDGTE(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGTE(FieldClientID, v))
+}
+
+// ClientIDLT applies the LT predicate on the "client_id" field.
+func ClientIDLT(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLT(FieldClientID, v))
+}
+
+// ClientIDLTE applies the LTE predicate on the "client_id" field.
+func ClientIDLTE(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLTE(FieldClientID, v))
+}
+
+// ClientIDContains applies the Contains predicate on the "client_id" field.
+func ClientIDContains(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldContains(FieldClientID, v))
+}
+
+// ClientIDHasPrefix applies the HasPrefix predicate on the "client_id" field.
+func ClientIDHasPrefix(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldHasPrefix(FieldClientID, v))
+}
+
+// ClientIDHasSuffix applies the HasSuffix predicate on the "client_id" field.
+func ClientIDHasSuffix(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldHasSuffix(FieldClientID, v))
+}
+
+// ClientIDEqualFold applies the EqualFold predicate on the "client_id" field.
+func ClientIDEqualFold(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEqualFold(FieldClientID, v))
+}
+
+// ClientIDContainsFold applies the ContainsFold predicate on the "client_id" field.
+func ClientIDContainsFold(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldContainsFold(FieldClientID, v))
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldStatus, v))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNEQ(FieldStatus, v))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldIn(FieldStatus, vs...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNotIn(FieldStatus, vs...))
+}
+
+// StatusGT applies the GT predicate on the "status" field.
+func StatusGT(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGT(FieldStatus, v))
+}
+
+// StatusGTE applies the GTE predicate on the "status" field.
+func StatusGTE(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGTE(FieldStatus, v))
+}
+
+// StatusLT applies the LT predicate on the "status" field.
+func StatusLT(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLT(FieldStatus, v))
+}
+
+// StatusLTE applies the LTE predicate on the "status" field.
+func StatusLTE(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLTE(FieldStatus, v))
+}
+
+// StatusContains applies the Contains predicate on the "status" field.
+func StatusContains(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldContains(FieldStatus, v))
+}
+
+// StatusHasPrefix applies the HasPrefix predicate on the "status" field.
+func StatusHasPrefix(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldHasPrefix(FieldStatus, v))
+}
+
+// StatusHasSuffix applies the HasSuffix predicate on the "status" field.
+func StatusHasSuffix(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldHasSuffix(FieldStatus, v))
+}
+
+// StatusEqualFold applies the EqualFold predicate on the "status" field.
+func StatusEqualFold(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEqualFold(FieldStatus, v))
+}
+
+// StatusContainsFold applies the ContainsFold predicate on the "status" field.
+func StatusContainsFold(v string) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldContainsFold(FieldStatus, v))
+}
+
+// IntervalEQ applies the EQ predicate on the "interval" field.
+func IntervalEQ(v int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldInterval, v))
+}
+
+// IntervalNEQ applies the NEQ predicate on the "interval" field.
+func IntervalNEQ(v int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNEQ(FieldInterval, v))
+}
+
+// IntervalIn applies the In predicate on the "interval" field.
+func IntervalIn(vs ...int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldIn(FieldInterval, vs...))
+}
+
+// IntervalNotIn applies the NotIn predicate on the "interval" field.
+func IntervalNotIn(vs ...int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNotIn(FieldInterval, vs...))
+}
+
+// IntervalGT applies the GT predicate on the "interval" field.
+func IntervalGT(v int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGT(FieldInterval, v))
+}
+
+// IntervalGTE applies the GTE predicate on the "interval" field.
+func IntervalGTE(v int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGTE(FieldInterval, v))
+}
+
+// IntervalLT applies the LT predicate on the "interval" field.
+func IntervalLT(v int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLT(FieldInterval, v))
+}
+
+// IntervalLTE applies the LTE predicate on the "interval" field.
+func IntervalLTE(v int) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLTE(FieldInterval, v))
+}
+
+// LastPolledAtEQ applies the EQ predicate on the "last_polled_at" field.
+func LastPolledAtEQ(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldLastPolledAt, v))
+}
+
+// LastPolledAtNEQ applies the NEQ predicate on the "last_polled_at" field.
+func LastPolledAtNEQ(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNEQ(FieldLastPolledAt, v))
+}
+
+// LastPolledAtIn applies the In predicate on the "last_polled_at" field.
+func LastPolledAtIn(vs ...time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldIn(FieldLastPolledAt, vs...))
+}
+
+// LastPolledAtNotIn applies the NotIn predicate on the "last_polled_at" field.
+func LastPolledAtNotIn(vs ...time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNotIn(FieldLastPolledAt, vs...))
+}
+
+// LastPolledAtGT applies the GT predicate on the "last_polled_at" field.
+func LastPolledAtGT(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGT(FieldLastPolledAt, v))
+}
+
+// LastPolledAtGTE applies the GTE predicate on the "last_polled_at" field.
+func LastPolledAtGTE(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGTE(FieldLastPolledAt, v))
+}
+
+// LastPolledAtLT applies the LT predicate on the "last_polled_at" field.
+func LastPolledAtLT(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLT(FieldLastPolledAt, v))
+}
+
+// LastPolledAtLTE applies the LTE predicate on the "last_polled_at" field.
+func LastPolledAtLTE(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLTE(FieldLastPolledAt, v))
+}
+
+// LastPolledAtIsNil applies the IsNil predicate on the "last_polled_at" field.
+func LastPolledAtIsNil() predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldIsNull(FieldLastPolledAt))
+}
+
+// LastPolledAtNotNil applies the NotNil predicate on the "last_polled_at" field.
+func LastPolledAtNotNil() predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNotNull(FieldLastPolledAt))
+}
+
+// ExpiresAtEQ applies the EQ predicate on the "expires_at" field.
+func ExpiresAtEQ(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtNEQ applies the NEQ predicate on the "expires_at" field.
+func ExpiresAtNEQ(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtIn applies the In predicate on the "expires_at" field.
+func ExpiresAtIn(vs ...time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtNotIn applies the NotIn predicate on the "expires_at" field.
+func ExpiresAtNotIn(vs ...time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNotIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtGT applies the GT predicate on the "expires_at" field.
+func ExpiresAtGT(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGT(FieldExpiresAt, v))
+}
+
+// ExpiresAtGTE applies the GTE predicate on the "expires_at" field.
+func ExpiresAtGTE(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGTE(FieldExpiresAt, v))
+}
+
+// ExpiresAtLT applies the LT predicate on the "expires_at" field.
+func ExpiresAtLT(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLT(FieldExpiresAt, v))
+}
+
+// ExpiresAtLTE applies the LTE predicate on the "expires_at" field.
+func ExpiresAtLTE(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLTE(FieldExpiresAt, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// HasUser applies the HasEdge predicate on the "user" edge.
+func HasUser() predicate.DeviceCode {
+	return predicate.DeviceCode(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, UserTable, UserColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasUserWith applies the HasEdge predicate on the "user" edge with a given conditions (other predicates).
+func HasUserWith(preds ...predicate.User) predicate.DeviceCode {
+	return predicate.DeviceCode(func(s *sql.Selector) {
+		step := newUserStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.DeviceCode) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.DeviceCode) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.DeviceCode) predicate.DeviceCode {
+	return predicate.DeviceCode(sql.NotPredicates(p))
+}
@@ -0,0 +1,347 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/devicecode"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// DeviceCodeCreate is the builder for creating a DeviceCode entity.
+type DeviceCodeCreate struct {
+	config
+	mutation *DeviceCodeMutation
+	hooks    []Hook
+}
+
+// SetDeviceCodeHash sets the "device_code_hash" field.
+func (_c *DeviceCodeCreate) SetDeviceCodeHash(v string) *DeviceCodeCreate {
+	_c.mutation.SetDeviceCodeHash(v)
+	return _c
+}
+
+// SetUserCode sets the "user_code" field.
+func (_c *DeviceCodeCreate) SetUserCode(v string) *DeviceCodeCreate {
+	_c.mutation.SetUserCode(v)
+	return _c
+}
+
+// SetClientID sets the "client_id" field.
+func (_c *DeviceCodeCreate) SetClientID(v string) *DeviceCodeCreate {
+	_c.mutation.SetClientID(v)
+	return _c
+}
+
+// SetStatus sets the "status" field.
+func (_c *DeviceCodeCreate) SetStatus(v string) *DeviceCodeCreate {
+	_c.mutation.SetStatus(v)
+	return _c
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_c *DeviceCodeCreate) SetNillableStatus(v *string) *DeviceCodeCreate {
+	if v != nil {
+		_c.SetStatus(*v)
+	}
+	return _c
+}
+
+// SetInterval sets the "interval" field.
+func (_c *DeviceCodeCreate) SetInterval(v int) *DeviceCodeCreate {
+	_c.mutation.SetInterval(v)
+	return _c
+}
+
+// SetLastPolledAt sets the "last_polled_at" field.
+func (_c *DeviceCodeCreate) SetLastPolledAt(v time.Time) *DeviceCodeCreate {
+	_c.mutation.SetLastPolledAt(v)
+	return _c
+}
+
+// SetNillableLastPolledAt sets the "last_polled_at" field if the given value is not nil.
+func (_c *DeviceCodeCreate) SetNillableLastPolledAt(v *time.Time) *DeviceCodeCreate {
+	if v != nil {
+		_c.SetLastPolledAt(*v)
+	}
+	return _c
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_c *DeviceCodeCreate) SetExpiresAt(v time.Time) *DeviceCodeCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *DeviceCodeCreate) SetCreatedAt(v time.Time) *DeviceCodeCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *DeviceCodeCreate) SetNillableCreatedAt(v *time.Time) *DeviceCodeCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_c *DeviceCodeCreate) SetUserID(id int) *DeviceCodeCreate {
+	_c.mutation.SetUserID(id)
+	return _c
+}
+
+// SetNillableUserID sets the "user" edge to the User entity by ID if the given value is not nil.
+func (_c *DeviceCodeCreate) SetNillableUserID(id *int) *DeviceCodeCreate {
+	if id != nil {
+		_c = _c.SetUserID(*id)
+	}
+	return _c
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_c *DeviceCodeCreate) SetUser(v *User) *DeviceCodeCreate {
+	return _c.SetUserID(v.ID)
+}
+
+// Mutation returns the DeviceCodeMutation object of the builder.
+func (_c *DeviceCodeCreate) Mutation() *DeviceCodeMutation {
+	return _c.mutation
+}
+
+// Save creates the DeviceCode in the database.
+func (_c *DeviceCodeCreate) Save(ctx context.Context) (*DeviceCode, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *DeviceCodeCreate) SaveX(ctx context.Context) *DeviceCode {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *DeviceCodeCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *DeviceCodeCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *DeviceCodeCreate) defaults() {
+	if _, ok := _c.mutation.Status(); !ok {
+		v := devicecode.DefaultStatus
+		_c.mutation.SetStatus(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := devicecode.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *DeviceCodeCreate) check() error {
+	if _, ok := _c.mutation.DeviceCodeHash(); !ok {
+		return &ValidationError{Name: "device_code_hash", err: errors.New(`ent: missing required field "DeviceCode.device_code_hash"`)}
+	}
+	if _, ok := _c.mutation.UserCode(); !ok {
+		return &ValidationError{Name: "user_code", err: errors.New(`ent: missing required field "DeviceCode.user_code"`)}
+	}
+	if _, ok := _c.mutation.ClientID(); !ok {
+		return &ValidationError{Name: "client_id", err: errors.New(`ent: missing required field "DeviceCode.client_id"`)}
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "DeviceCode.status"`)}
+	}
+	if _, ok := _c.mutation.Interval(); !ok {
+		return &ValidationError{Name: "interval", err: errors.New(`ent: missing required field "DeviceCode.interval"`)}
+	}
+	if _, ok := _c.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "DeviceCode.expires_at"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "DeviceCode.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *DeviceCodeCreate) sqlSave(ctx context.Context) (*DeviceCode, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *DeviceCodeCreate) createSpec() (*DeviceCode, *sqlgraph.CreateSpec) {
+	var (
+		_node = &DeviceCode{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(devicecode.Table, sqlgraph.NewFieldSpec(devicecode.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.DeviceCodeHash(); ok {
+		_spec.SetField(devicecode.FieldDeviceCodeHash, field.TypeString, value)
+		_node.DeviceCodeHash = value
+	}
+	if value, ok := _c.mutation.UserCode(); ok {
+		_spec.SetField(devicecode.FieldUserCode, field.TypeString, value)
+		_node.UserCode = value
+	}
+	if value, ok := _c.mutation.ClientID(); ok {
+		_spec.SetField(devicecode.FieldClientID, field.TypeString, value)
+		_node.ClientID = value
+	}
+	if value, ok := _c.mutation.Status(); ok {
+		_spec.SetField(devicecode.FieldStatus, field.TypeString, value)
+		_node.Status = value
+	}
+	if value, ok := _c.mutation.Interval(); ok {
+		_spec.SetField(devicecode.FieldInterval, field.TypeInt, value)
+		_node.Interval = value
+	}
+	if value, ok := _c.mutation.LastPolledAt(); ok {
+		_spec.SetField(devicecode.FieldLastPolledAt, field.TypeTime, value)
+		_node.LastPolledAt = &value
+	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(devicecode.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(devicecode.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   devicecode.UserTable,
+			Columns: []string{devicecode.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_device_codes = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// DeviceCodeCreateBulk is the builder for creating many DeviceCode entities in bulk.
+type DeviceCodeCreateBulk struct {
+	config
+	err      error
+	builders []*DeviceCodeCreate
+}
+
+// Save creates the DeviceCode entities in the database.
+func (_c *DeviceCodeCreateBulk) Save(ctx context.Context) ([]*DeviceCode, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*DeviceCode, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*DeviceCodeMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *DeviceCodeCreateBulk) SaveX(ctx context.Context) []*DeviceCode {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *DeviceCodeCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *DeviceCodeCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,561 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/devicecode"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// DeviceCodeUpdate is the builder for updating DeviceCode entities.
+type DeviceCodeUpdate struct {
+	config
+	hooks    []Hook
+	mutation *DeviceCodeMutation
+}
+
+// Where appends a list predicates to the DeviceCodeUpdate builder.
+func (_u *DeviceCodeUpdate) Where(ps ...predicate.DeviceCode) *DeviceCodeUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetDeviceCodeHash sets the "device_code_hash" field.
+func (_u *DeviceCodeUpdate) SetDeviceCodeHash(v string) *DeviceCodeUpdate {
+	_u.mutation.SetDeviceCodeHash(v)
+	return _u
+}
+
+// SetNillableDeviceCodeHash sets the "device_code_hash" field if the given value is not nil.
+func (_u *DeviceCodeUpdate) SetNillableDeviceCodeHash(v *string) *DeviceCodeUpdate {
+	if v != nil {
+		_u.SetDeviceCodeHash(*v)
+	}
+	return _u
+}
+
+// SetUserCode sets the "user_code" field.
+func (_u *DeviceCodeUpdate) SetUserCode(v string) *DeviceCodeUpdate {
+	_u.mutation.SetUserCode(v)
+	return _u
+}
+
+// SetNillableUserCode sets the "user_code" field if the given value is not nil.
+func (_u *DeviceCodeUpdate) SetNillableUserCode(v *string) *DeviceCodeUpdate {
+	if v != nil {
+		_u.SetUserCode(*v)
+	}
+	return _u
+}
+
+// SetClientID sets the "client_id" field.
+func (_u *DeviceCodeUpdate) SetClientID(v string) *DeviceCodeUpdate {
+	_u.mutation.SetClientID(v)
+	return _u
+}
+
+// SetNillableClientID sets the "client_id" field if the given value is not nil.
+func (_u *DeviceCodeUpdate) SetNillableClientID(v *string) *DeviceCodeUpdate {
+	if v != nil {
+		_u.SetClientID(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *DeviceCodeUpdate) SetStatus(v string) *DeviceCodeUpdate {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *DeviceCodeUpdate) SetNillableStatus(v *string) *DeviceCodeUpdate {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetInterval sets the "interval" field.
+func (_u *DeviceCodeUpdate) SetInterval(v int) *DeviceCodeUpdate {
+	_u.mutation.ResetInterval()
+	_u.mutation.SetInterval(v)
+	return _u
+}
+
+// SetNillableInterval sets the "interval" field if the given value is not nil.
+func (_u *DeviceCodeUpdate) SetNillableInterval(v *int) *DeviceCodeUpdate {
+	if v != nil {
+		_u.SetInterval(*v)
+	}
+	return _u
+}
+
+// AddInterval adds value to the "interval" field.
+func (_u *DeviceCodeUpdate) AddInterval(v int) *DeviceCodeUpdate {
+	_u.mutation.AddInterval(v)
+	return _u
+}
+
+// SetLastPolledAt sets the "last_polled_at" field.
+func (_u *DeviceCodeUpdate) SetLastPolledAt(v time.Time) *DeviceCodeUpdate {
+	_u.mutation.SetLastPolledAt(v)
+	return _u
+}
+
+// SetNillableLastPolledAt sets the "last_polled_at" field if the given value is not nil.
+func (_u *DeviceCodeUpdate) SetNillableLastPolledAt(v *time.Time) *DeviceCodeUpdate {
+	if v != nil {
+		_u.SetLastPolledAt(*v)
+	}
+	return _u
+}
+
+// ClearLastPolledAt clears the value of the "last_polled_at" field.
+func (_u *DeviceCodeUpdate) ClearLastPolledAt() *DeviceCodeUpdate {
+	_u.mutation.ClearLastPolledAt()
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *DeviceCodeUpdate) SetExpiresAt(v time.Time) *DeviceCodeUpdate {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *DeviceCodeUpdate) SetNillableExpiresAt(v *time.Time) *DeviceCodeUpdate {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_u *DeviceCodeUpdate) SetUserID(id int) *DeviceCodeUpdate {
+	_u.mutation.SetUserID(id)
+	return _u
+}
+
+// SetNillableUserID sets the "user" edge to the User entity by ID if the given value is not nil.
+func (_u *DeviceCodeUpdate) SetNillableUserID(id *int) *DeviceCodeUpdate {
+	if id != nil {
+		_u = _u.SetUserID(*id)
+	}
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *DeviceCodeUpdate) SetUser(v *User) *DeviceCodeUpdate {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the DeviceCodeMutation object of the builder.
+func (_u *DeviceCodeUpdate) Mutation() *DeviceCodeMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *DeviceCodeUpdate) ClearUser() *DeviceCodeUpdate {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *DeviceCodeUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DeviceCodeUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *DeviceCodeUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DeviceCodeUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *DeviceCodeUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(devicecode.Table, devicecode.Columns, sqlgraph.NewFieldSpec(devicecode.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.DeviceCodeHash(); ok {
+		_spec.SetField(devicecode.FieldDeviceCodeHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UserCode(); ok {
+		_spec.SetField(devicecode.FieldUserCode, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ClientID(); ok {
+		_spec.SetField(devicecode.FieldClientID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(devicecode.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Interval(); ok {
+		_spec.SetField(devicecode.FieldInterval, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedInterval(); ok {
+		_spec.AddField(devicecode.FieldInterval, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.LastPolledAt(); ok {
+		_spec.SetField(devicecode.FieldLastPolledAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastPolledAtCleared() {
+		_spec.ClearField(devicecode.FieldLastPolledAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(devicecode.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   devicecode.UserTable,
+			Columns: []string{devicecode.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   devicecode.UserTable,
+			Columns: []string{devicecode.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{devicecode.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// DeviceCodeUpdateOne is the builder for updating a single DeviceCode entity.
+type DeviceCodeUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *DeviceCodeMutation
+}
+
+// SetDeviceCodeHash sets the "device_code_hash" field.
+func (_u *DeviceCodeUpdateOne) SetDeviceCodeHash(v string) *DeviceCodeUpdateOne {
+	_u.mutation.SetDeviceCodeHash(v)
+	return _u
+}
+
+// SetNillableDeviceCodeHash sets the "device_code_hash" field if the given value is not nil.
+func (_u *DeviceCodeUpdateOne) SetNillableDeviceCodeHash(v *string) *DeviceCodeUpdateOne {
+	if v != nil {
+		_u.SetDeviceCodeHash(*v)
+	}
+	return _u
+}
+
+// SetUserCode sets the "user_code" field.
+func (_u *DeviceCodeUpdateOne) SetUserCode(v string) *DeviceCodeUpdateOne {
+	_u.mutation.SetUserCode(v)
+	return _u
+}
+
+// SetNillableUserCode sets the "user_code" field if the given value is not nil.
+func (_u *DeviceCodeUpdateOne) SetNillableUserCode(v *string) *DeviceCodeUpdateOne {
+	if v != nil {
+		_u.SetUserCode(*v)
+	}
+	return _u
+}
+
+// SetClientID sets the "client_id" field.
+func (_u *DeviceCodeUpdateOne) SetClientID(v string) *DeviceCodeUpdateOne {
+	_u.mutation.SetClientID(v)
+	return _u
+}
+
+// SetNillableClientID sets the "client_id" field if the given value is not nil.
+func (_u *DeviceCodeUpdateOne) SetNillableClientID(v *string) *DeviceCodeUpdateOne {
+	if v != nil {
+		_u.SetClientID(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *DeviceCodeUpdateOne) SetStatus(v string) *DeviceCodeUpdateOne {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *DeviceCodeUpdateOne) SetNillableStatus(v *string) *DeviceCodeUpdateOne {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetInterval sets the "interval" field.
+func (_u *DeviceCodeUpdateOne) SetInterval(v int) *DeviceCodeUpdateOne {
+	_u.mutation.ResetInterval()
+	_u.mutation.SetInterval(v)
+	return _u
+}
+
+// SetNillableInterval sets the "interval" field if the given value is not nil.
+func (_u *DeviceCodeUpdateOne) SetNillableInterval(v *int) *DeviceCodeUpdateOne {
+	if v != nil {
+		_u.SetInterval(*v)
+	}
+	return _u
+}
+
+// AddInterval adds value to the "interval" field.
+func (_u *DeviceCodeUpdateOne) AddInterval(v int) *DeviceCodeUpdateOne {
+	_u.mutation.AddInterval(v)
+	return _u
+}
+
+// SetLastPolledAt sets the "last_polled_at" field.
+func (_u *DeviceCodeUpdateOne) SetLastPolledAt(v time.Time) *DeviceCodeUpdateOne {
+	_u.mutation.SetLastPolledAt(v)
+	return _u
+}
+
+// SetNillableLastPolledAt sets the "last_polled_at" field if the given value is not nil.
+func (_u *DeviceCodeUpdateOne) SetNillableLastPolledAt(v *time.Time) *DeviceCodeUpdateOne {
+	if v != nil {
+		_u.SetLastPolledAt(*v)
+	}
+	return _u
+}
+
+// ClearLastPolledAt clears the value of the "last_polled_at" field.
+func (_u *DeviceCodeUpdateOne) ClearLastPolledAt() *DeviceCodeUpdateOne {
+	_u.mutation.ClearLastPolledAt()
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *DeviceCodeUpdateOne) SetExpiresAt(v time.Time) *DeviceCodeUpdateOne {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *DeviceCodeUpdateOne) SetNillableExpiresAt(v *time.Time) *DeviceCodeUpdateOne {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_u *DeviceCodeUpdateOne) SetUserID(id int) *DeviceCodeUpdateOne {
+	_u.mutation.SetUserID(id)
+	return _u
+}
+
+// SetNillableUserID sets the "user" edge to the User entity by ID if the given value is not nil.
+func (_u *DeviceCodeUpdateOne) SetNillableUserID(id *int) *DeviceCodeUpdateOne {
+	if id != nil {
+		_u = _u.SetUserID(*id)
+	}
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *DeviceCodeUpdateOne) SetUser(v *User) *DeviceCodeUpdateOne {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the DeviceCodeMutation object of the builder.
+func (_u *DeviceCodeUpdateOne) Mutation() *DeviceCodeMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *DeviceCodeUpdateOne) ClearUser() *DeviceCodeUpdateOne {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Where appends a list predicates to the DeviceCodeUpdate builder.
+func (_u *DeviceCodeUpdateOne) Where(ps ...predicate.DeviceCode) *DeviceCodeUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *DeviceCodeUpdateOne) Select(field string, fields ...string) *DeviceCodeUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated DeviceCode entity.
+func (_u *DeviceCodeUpdateOne) Save(ctx context.Context) (*DeviceCode, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DeviceCodeUpdateOne) SaveX(ctx context.Context) *DeviceCode {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *DeviceCodeUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DeviceCodeUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *DeviceCodeUpdateOne) sqlSave(ctx context.Context) (_node *DeviceCode, err error) {
+	_spec := sqlgraph.NewUpdateSpec(devicecode.Table, devicecode.Columns, sqlgraph.NewFieldSpec(devicecode.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "DeviceCode.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, devicecode.FieldID)
+		for _, f := range fields {
+			if !devicecode.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != devicecode.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.DeviceCodeHash(); ok {
+		_spec.SetField(devicecode.FieldDeviceCodeHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UserCode(); ok {
+		_spec.SetField(devicecode.FieldUserCode, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ClientID(); ok {
+		_spec.SetField(devicecode.FieldClientID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(devicecode.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Interval(); ok {
+		_spec.SetField(devicecode.FieldInterval, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedInterval(); ok {
+		_spec.AddField(devicecode.FieldInterval, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.LastPolledAt(); ok {
+		_spec.SetField(devicecode.FieldLastPolledAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastPolledAtCleared() {
+		_spec.ClearField(devicecode.FieldLastPolledAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(devicecode.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   devicecode.UserTable,
+			Columns: []string{devicecode.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   devicecode.UserTable,
+			Columns: []string{devicecode.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &DeviceCode{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{devicecode.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
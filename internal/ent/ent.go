@@ -12,10 +12,25 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/logan/cloudcode/internal/ent/accesstoken"
+	"github.com/logan/cloudcode/internal/ent/auditevent"
+	"github.com/logan/cloudcode/internal/ent/backup"
 	"github.com/logan/cloudcode/internal/ent/chatmessage"
 	"github.com/logan/cloudcode/internal/ent/conversation"
+	"github.com/logan/cloudcode/internal/ent/devicecode"
+	"github.com/logan/cloudcode/internal/ent/identity"
 	"github.com/logan/cloudcode/internal/ent/instance"
+	"github.com/logan/cloudcode/internal/ent/job"
+	"github.com/logan/cloudcode/internal/ent/magiclink"
+	"github.com/logan/cloudcode/internal/ent/membership"
+	"github.com/logan/cloudcode/internal/ent/organization"
+	"github.com/logan/cloudcode/internal/ent/recording"
+	"github.com/logan/cloudcode/internal/ent/secret"
+	"github.com/logan/cloudcode/internal/ent/session"
+	"github.com/logan/cloudcode/internal/ent/template"
+	"github.com/logan/cloudcode/internal/ent/terminalshare"
 	"github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/ent/warminstance"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -76,10 +91,25 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			chatmessage.Table:  chatmessage.ValidColumn,
-			conversation.Table: conversation.ValidColumn,
-			instance.Table:     instance.ValidColumn,
-			user.Table:         user.ValidColumn,
+			accesstoken.Table:   accesstoken.ValidColumn,
+			auditevent.Table:    auditevent.ValidColumn,
+			backup.Table:        backup.ValidColumn,
+			chatmessage.Table:   chatmessage.ValidColumn,
+			conversation.Table:  conversation.ValidColumn,
+			devicecode.Table:    devicecode.ValidColumn,
+			identity.Table:      identity.ValidColumn,
+			instance.Table:      instance.ValidColumn,
+			job.Table:           job.ValidColumn,
+			magiclink.Table:     magiclink.ValidColumn,
+			membership.Table:    membership.ValidColumn,
+			organization.Table:  organization.ValidColumn,
+			recording.Table:     recording.ValidColumn,
+			secret.Table:        secret.ValidColumn,
+			session.Table:       session.ValidColumn,
+			template.Table:      template.ValidColumn,
+			terminalshare.Table: terminalshare.ValidColumn,
+			user.Table:          user.ValidColumn,
+			warminstance.Table:  warminstance.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"github.com/logan/cloudcode/internal/ent/instance"
+	"github.com/logan/cloudcode/internal/ent/organization"
 	"github.com/logan/cloudcode/internal/ent/user"
 )
 
@@ -18,6 +20,8 @@ type Instance struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID int `json:"id,omitempty"`
+	// User-chosen instance name, unique among the owner's live instances
+	Name string `json:"name,omitempty"`
 	// Provider holds the value of the "provider" field.
 	Provider string `json:"provider,omitempty"`
 	// ProviderID holds the value of the "provider_id" field.
@@ -30,12 +34,24 @@ type Instance struct {
 	Status string `json:"status,omitempty"`
 	// VolumeID holds the value of the "volume_id" field.
 	VolumeID string `json:"volume_id,omitempty"`
+	// Instance class name; empty for instances created before classes existed
+	Class string `json:"class,omitempty"`
+	// Template the instance was created from; applied on first boot only
+	TemplateID *int `json:"template_id,omitempty"`
 	// JSON-encoded Netbird config (group ID, route ID, policy ID, setup key ID)
 	NetbirdConfig string `json:"netbird_config,omitempty"`
 	// Per-instance secret for agent authentication
 	AgentSecret string `json:"-"`
 	// Last detected activity timestamp for idle detection
 	LastActivityAt *time.Time `json:"last_activity_at,omitempty"`
+	// Provider snapshot IDs taken on Pause, oldest first (snapshot-capable providers only)
+	SnapshotIds []string `json:"snapshot_ids,omitempty"`
+	// When the instance was last paused; cleared on Wake
+	PausedAt *time.Time `json:"paused_at,omitempty"`
+	// Organization the instance is shared with, if any
+	OrganizationID *int `json:"organization_id,omitempty"`
+	// What organization members get on the terminal and chat; admins can always manage
+	TeamAccess instance.TeamAccess `json:"team_access,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// UpdatedAt holds the value of the "updated_at" field.
@@ -51,9 +67,13 @@ type Instance struct {
 type InstanceEdges struct {
 	// Owner holds the value of the owner edge.
 	Owner *User `json:"owner,omitempty"`
+	// Organization holds the value of the organization edge.
+	Organization *Organization `json:"organization,omitempty"`
+	// Conversations holds the value of the conversations edge.
+	Conversations []*Conversation `json:"conversations,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [1]bool
+	loadedTypes [3]bool
 }
 
 // OwnerOrErr returns the Owner value or an error if the edge
@@ -67,17 +87,41 @@ func (e InstanceEdges) OwnerOrErr() (*User, error) {
 	return nil, &NotLoadedError{edge: "owner"}
 }
 
+// OrganizationOrErr returns the Organization value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e InstanceEdges) OrganizationOrErr() (*Organization, error) {
+	if e.Organization != nil {
+		return e.Organization, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: organization.Label}
+	}
+	return nil, &NotLoadedError{edge: "organization"}
+}
+
+// ConversationsOrErr returns the Conversations value or an error if the edge
+// was not loaded in eager-loading.
+func (e InstanceEdges) ConversationsOrErr() ([]*Conversation, error) {
+	if e.loadedTypes[2] {
+		return e.Conversations, nil
+	}
+	return nil, &NotLoadedError{edge: "conversations"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Instance) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case instance.FieldID, instance.FieldPort:
+		case instance.FieldSnapshotIds:
+			values[i] = new([]byte)
+		case instance.FieldID, instance.FieldPort, instance.FieldTemplateID, instance.FieldOrganizationID:
 			values[i] = new(sql.NullInt64)
-		case instance.FieldProvider, instance.FieldProviderID, instance.FieldHost, instance.FieldStatus, instance.FieldVolumeID, instance.FieldNetbirdConfig, instance.FieldAgentSecret:
+		case instance.FieldName, instance.FieldProvider, instance.FieldProviderID, instance.FieldHost, instance.FieldStatus, instance.FieldVolumeID, instance.FieldClass, instance.FieldNetbirdConfig, instance.FieldTeamAccess:
 			values[i] = new(sql.NullString)
-		case instance.FieldLastActivityAt, instance.FieldCreatedAt, instance.FieldUpdatedAt:
+		case instance.FieldLastActivityAt, instance.FieldPausedAt, instance.FieldCreatedAt, instance.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
+		case instance.FieldAgentSecret:
+			values[i] = instance.ValueScanner.AgentSecret.ScanValue()
 		case instance.ForeignKeys[0]: // user_instances
 			values[i] = new(sql.NullInt64)
 		default:
@@ -101,6 +145,12 @@ func (_m *Instance) assignValues(columns []string, values []any) error {
 				return fmt.Errorf("unexpected type %T for field id", value)
 			}
 			_m.ID = int(value.Int64)
+		case instance.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
 		case instance.FieldProvider:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field provider", values[i])
@@ -137,6 +187,19 @@ func (_m *Instance) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.VolumeID = value.String
 			}
+		case instance.FieldClass:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field class", values[i])
+			} else if value.Valid {
+				_m.Class = value.String
+			}
+		case instance.FieldTemplateID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field template_id", values[i])
+			} else if value.Valid {
+				_m.TemplateID = new(int)
+				*_m.TemplateID = int(value.Int64)
+			}
 		case instance.FieldNetbirdConfig:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field netbird_config", values[i])
@@ -144,10 +207,10 @@ func (_m *Instance) assignValues(columns []string, values []any) error {
 				_m.NetbirdConfig = value.String
 			}
 		case instance.FieldAgentSecret:
-			if value, ok := values[i].(*sql.NullString); !ok {
-				return fmt.Errorf("unexpected type %T for field agent_secret", values[i])
-			} else if value.Valid {
-				_m.AgentSecret = value.String
+			if value, err := instance.ValueScanner.AgentSecret.FromValue(values[i]); err != nil {
+				return err
+			} else {
+				_m.AgentSecret = value
 			}
 		case instance.FieldLastActivityAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
@@ -156,6 +219,34 @@ func (_m *Instance) assignValues(columns []string, values []any) error {
 				_m.LastActivityAt = new(time.Time)
 				*_m.LastActivityAt = value.Time
 			}
+		case instance.FieldSnapshotIds:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field snapshot_ids", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.SnapshotIds); err != nil {
+					return fmt.Errorf("unmarshal field snapshot_ids: %w", err)
+				}
+			}
+		case instance.FieldPausedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field paused_at", values[i])
+			} else if value.Valid {
+				_m.PausedAt = new(time.Time)
+				*_m.PausedAt = value.Time
+			}
+		case instance.FieldOrganizationID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field organization_id", values[i])
+			} else if value.Valid {
+				_m.OrganizationID = new(int)
+				*_m.OrganizationID = int(value.Int64)
+			}
+		case instance.FieldTeamAccess:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field team_access", values[i])
+			} else if value.Valid {
+				_m.TeamAccess = instance.TeamAccess(value.String)
+			}
 		case instance.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -193,6 +284,16 @@ func (_m *Instance) QueryOwner() *UserQuery {
 	return NewInstanceClient(_m.config).QueryOwner(_m)
 }
 
+// QueryOrganization queries the "organization" edge of the Instance entity.
+func (_m *Instance) QueryOrganization() *OrganizationQuery {
+	return NewInstanceClient(_m.config).QueryOrganization(_m)
+}
+
+// QueryConversations queries the "conversations" edge of the Instance entity.
+func (_m *Instance) QueryConversations() *ConversationQuery {
+	return NewInstanceClient(_m.config).QueryConversations(_m)
+}
+
 // Update returns a builder for updating this Instance.
 // Note that you need to call Instance.Unwrap() before calling this method if this Instance
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -216,6 +317,9 @@ func (_m *Instance) String() string {
 	var builder strings.Builder
 	builder.WriteString("Instance(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
 	builder.WriteString("provider=")
 	builder.WriteString(_m.Provider)
 	builder.WriteString(", ")
@@ -234,6 +338,14 @@ func (_m *Instance) String() string {
 	builder.WriteString("volume_id=")
 	builder.WriteString(_m.VolumeID)
 	builder.WriteString(", ")
+	builder.WriteString("class=")
+	builder.WriteString(_m.Class)
+	builder.WriteString(", ")
+	if v := _m.TemplateID; v != nil {
+		builder.WriteString("template_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
 	builder.WriteString("netbird_config=")
 	builder.WriteString(_m.NetbirdConfig)
 	builder.WriteString(", ")
@@ -244,6 +356,22 @@ func (_m *Instance) String() string {
 		builder.WriteString(v.Format(time.ANSIC))
 	}
 	builder.WriteString(", ")
+	builder.WriteString("snapshot_ids=")
+	builder.WriteString(fmt.Sprintf("%v", _m.SnapshotIds))
+	builder.WriteString(", ")
+	if v := _m.PausedAt; v != nil {
+		builder.WriteString("paused_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.OrganizationID; v != nil {
+		builder.WriteString("organization_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("team_access=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TeamAccess))
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
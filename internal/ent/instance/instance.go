@@ -3,10 +3,12 @@
 package instance
 
 import (
+	"fmt"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
 )
 
 const (
@@ -14,6 +16,8 @@ const (
 	Label = "instance"
 	// FieldID holds the string denoting the id field in the database.
 	FieldID = "id"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
 	// FieldProvider holds the string denoting the provider field in the database.
 	FieldProvider = "provider"
 	// FieldProviderID holds the string denoting the provider_id field in the database.
@@ -26,18 +30,34 @@ const (
 	FieldStatus = "status"
 	// FieldVolumeID holds the string denoting the volume_id field in the database.
 	FieldVolumeID = "volume_id"
+	// FieldClass holds the string denoting the class field in the database.
+	FieldClass = "class"
+	// FieldTemplateID holds the string denoting the template_id field in the database.
+	FieldTemplateID = "template_id"
 	// FieldNetbirdConfig holds the string denoting the netbird_config field in the database.
 	FieldNetbirdConfig = "netbird_config"
 	// FieldAgentSecret holds the string denoting the agent_secret field in the database.
 	FieldAgentSecret = "agent_secret"
 	// FieldLastActivityAt holds the string denoting the last_activity_at field in the database.
 	FieldLastActivityAt = "last_activity_at"
+	// FieldSnapshotIds holds the string denoting the snapshot_ids field in the database.
+	FieldSnapshotIds = "snapshot_ids"
+	// FieldPausedAt holds the string denoting the paused_at field in the database.
+	FieldPausedAt = "paused_at"
+	// FieldOrganizationID holds the string denoting the organization_id field in the database.
+	FieldOrganizationID = "organization_id"
+	// FieldTeamAccess holds the string denoting the team_access field in the database.
+	FieldTeamAccess = "team_access"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
 	FieldUpdatedAt = "updated_at"
 	// EdgeOwner holds the string denoting the owner edge name in mutations.
 	EdgeOwner = "owner"
+	// EdgeOrganization holds the string denoting the organization edge name in mutations.
+	EdgeOrganization = "organization"
+	// EdgeConversations holds the string denoting the conversations edge name in mutations.
+	EdgeConversations = "conversations"
 	// Table holds the table name of the instance in the database.
 	Table = "instances"
 	// OwnerTable is the table that holds the owner relation/edge.
@@ -47,20 +67,41 @@ const (
 	OwnerInverseTable = "users"
 	// OwnerColumn is the table column denoting the owner relation/edge.
 	OwnerColumn = "user_instances"
+	// OrganizationTable is the table that holds the organization relation/edge.
+	OrganizationTable = "instances"
+	// OrganizationInverseTable is the table name for the Organization entity.
+	// It exists in this package in order to avoid circular dependency with the "organization" package.
+	OrganizationInverseTable = "organizations"
+	// OrganizationColumn is the table column denoting the organization relation/edge.
+	OrganizationColumn = "organization_id"
+	// ConversationsTable is the table that holds the conversations relation/edge.
+	ConversationsTable = "conversations"
+	// ConversationsInverseTable is the table name for the Conversation entity.
+	// It exists in this package in order to avoid circular dependency with the "conversation" package.
+	ConversationsInverseTable = "conversations"
+	// ConversationsColumn is the table column denoting the conversations relation/edge.
+	ConversationsColumn = "instance_conversations"
 )
 
 // Columns holds all SQL columns for instance fields.
 var Columns = []string{
 	FieldID,
+	FieldName,
 	FieldProvider,
 	FieldProviderID,
 	FieldHost,
 	FieldPort,
 	FieldStatus,
 	FieldVolumeID,
+	FieldClass,
+	FieldTemplateID,
 	FieldNetbirdConfig,
 	FieldAgentSecret,
 	FieldLastActivityAt,
+	FieldSnapshotIds,
+	FieldPausedAt,
+	FieldOrganizationID,
+	FieldTeamAccess,
 	FieldCreatedAt,
 	FieldUpdatedAt,
 }
@@ -87,6 +128,8 @@ func ValidColumn(column string) bool {
 }
 
 var (
+	// DefaultName holds the default value on creation for the "name" field.
+	DefaultName string
 	// ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
 	ProviderValidator func(string) error
 	// ProviderIDValidator is a validator for the "provider_id" field. It is called by the builders before save.
@@ -99,8 +142,39 @@ var (
 	DefaultUpdatedAt func() time.Time
 	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
 	UpdateDefaultUpdatedAt func() time.Time
+	// ValueScanner of all Instance fields.
+	ValueScanner struct {
+		AgentSecret field.TypeValueScanner[string]
+	}
+)
+
+// TeamAccess defines the type for the "team_access" enum field.
+type TeamAccess string
+
+// TeamAccessNone is the default value of the TeamAccess enum.
+const DefaultTeamAccess = TeamAccessNone
+
+// TeamAccess values.
+const (
+	TeamAccessNone  TeamAccess = "none"
+	TeamAccessRead  TeamAccess = "read"
+	TeamAccessWrite TeamAccess = "write"
 )
 
+func (ta TeamAccess) String() string {
+	return string(ta)
+}
+
+// TeamAccessValidator is a validator for the "team_access" field enum values. It is called by the builders before save.
+func TeamAccessValidator(ta TeamAccess) error {
+	switch ta {
+	case TeamAccessNone, TeamAccessRead, TeamAccessWrite:
+		return nil
+	default:
+		return fmt.Errorf("instance: invalid enum value for team_access field: %q", ta)
+	}
+}
+
 // OrderOption defines the ordering options for the Instance queries.
 type OrderOption func(*sql.Selector)
 
@@ -109,6 +183,11 @@ func ByID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldID, opts...).ToFunc()
 }
 
+// ByName orders the results by the name field.
+func ByName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldName, opts...).ToFunc()
+}
+
 // ByProvider orders the results by the provider field.
 func ByProvider(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldProvider, opts...).ToFunc()
@@ -139,6 +218,16 @@ func ByVolumeID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldVolumeID, opts...).ToFunc()
 }
 
+// ByClass orders the results by the class field.
+func ByClass(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldClass, opts...).ToFunc()
+}
+
+// ByTemplateID orders the results by the template_id field.
+func ByTemplateID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTemplateID, opts...).ToFunc()
+}
+
 // ByNetbirdConfig orders the results by the netbird_config field.
 func ByNetbirdConfig(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldNetbirdConfig, opts...).ToFunc()
@@ -154,6 +243,21 @@ func ByLastActivityAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldLastActivityAt, opts...).ToFunc()
 }
 
+// ByPausedAt orders the results by the paused_at field.
+func ByPausedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPausedAt, opts...).ToFunc()
+}
+
+// ByOrganizationID orders the results by the organization_id field.
+func ByOrganizationID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOrganizationID, opts...).ToFunc()
+}
+
+// ByTeamAccess orders the results by the team_access field.
+func ByTeamAccess(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTeamAccess, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -170,6 +274,27 @@ func ByOwnerField(field string, opts ...sql.OrderTermOption) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newOwnerStep(), sql.OrderByField(field, opts...))
 	}
 }
+
+// ByOrganizationField orders the results by organization field.
+func ByOrganizationField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newOrganizationStep(), sql.OrderByField(field, opts...))
+	}
+}
+
+// ByConversationsCount orders the results by conversations count.
+func ByConversationsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newConversationsStep(), opts...)
+	}
+}
+
+// ByConversations orders the results by conversations terms.
+func ByConversations(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newConversationsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newOwnerStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -177,3 +302,17 @@ func newOwnerStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.M2O, true, OwnerTable, OwnerColumn),
 	)
 }
+func newOrganizationStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(OrganizationInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, OrganizationTable, OrganizationColumn),
+	)
+}
+func newConversationsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ConversationsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, ConversationsTable, ConversationsColumn),
+	)
+}
@@ -3,6 +3,7 @@
 package instance
 
 import (
+	"fmt"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -55,6 +56,11 @@ func IDLTE(id int) predicate.Instance {
 	return predicate.Instance(sql.FieldLTE(FieldID, id))
 }
 
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldEQ(FieldName, v))
+}
+
 // Provider applies equality check predicate on the "provider" field. It's identical to ProviderEQ.
 func Provider(v string) predicate.Instance {
 	return predicate.Instance(sql.FieldEQ(FieldProvider, v))
@@ -85,6 +91,16 @@ func VolumeID(v string) predicate.Instance {
 	return predicate.Instance(sql.FieldEQ(FieldVolumeID, v))
 }
 
+// Class applies equality check predicate on the "class" field. It's identical to ClassEQ.
+func Class(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldEQ(FieldClass, v))
+}
+
+// TemplateID applies equality check predicate on the "template_id" field. It's identical to TemplateIDEQ.
+func TemplateID(v int) predicate.Instance {
+	return predicate.Instance(sql.FieldEQ(FieldTemplateID, v))
+}
+
 // NetbirdConfig applies equality check predicate on the "netbird_config" field. It's identical to NetbirdConfigEQ.
 func NetbirdConfig(v string) predicate.Instance {
 	return predicate.Instance(sql.FieldEQ(FieldNetbirdConfig, v))
@@ -92,7 +108,8 @@ func NetbirdConfig(v string) predicate.Instance {
 
 // AgentSecret applies equality check predicate on the "agent_secret" field. It's identical to AgentSecretEQ.
 func AgentSecret(v string) predicate.Instance {
-	return predicate.Instance(sql.FieldEQ(FieldAgentSecret, v))
+	vc, err := ValueScanner.AgentSecret.Value(v)
+	return predicate.InstanceOrErr(sql.FieldEQ(FieldAgentSecret, vc), err)
 }
 
 // LastActivityAt applies equality check predicate on the "last_activity_at" field. It's identical to LastActivityAtEQ.
@@ -100,6 +117,16 @@ func LastActivityAt(v time.Time) predicate.Instance {
 	return predicate.Instance(sql.FieldEQ(FieldLastActivityAt, v))
 }
 
+// PausedAt applies equality check predicate on the "paused_at" field. It's identical to PausedAtEQ.
+func PausedAt(v time.Time) predicate.Instance {
+	return predicate.Instance(sql.FieldEQ(FieldPausedAt, v))
+}
+
+// OrganizationID applies equality check predicate on the "organization_id" field. It's identical to OrganizationIDEQ.
+func OrganizationID(v int) predicate.Instance {
+	return predicate.Instance(sql.FieldEQ(FieldOrganizationID, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.Instance {
 	return predicate.Instance(sql.FieldEQ(FieldCreatedAt, v))
@@ -110,6 +137,71 @@ func UpdatedAt(v time.Time) predicate.Instance {
 	return predicate.Instance(sql.FieldEQ(FieldUpdatedAt, v))
 }
 
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.Instance {
+	return predicate.Instance(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.Instance {
+	return predicate.Instance(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldContainsFold(FieldName, v))
+}
+
 // ProviderEQ applies the EQ predicate on the "provider" field.
 func ProviderEQ(v string) predicate.Instance {
 	return predicate.Instance(sql.FieldEQ(FieldProvider, v))
@@ -505,6 +597,131 @@ func VolumeIDContainsFold(v string) predicate.Instance {
 	return predicate.Instance(sql.FieldContainsFold(FieldVolumeID, v))
 }
 
+// ClassEQ applies the EQ predicate on the "class" field.
+func ClassEQ(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldEQ(FieldClass, v))
+}
+
+// ClassNEQ applies the NEQ predicate on the "class" field.
+func ClassNEQ(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldNEQ(FieldClass, v))
+}
+
+// ClassIn applies the In predicate on the "class" field.
+func ClassIn(vs ...string) predicate.Instance {
+	return predicate.Instance(sql.FieldIn(FieldClass, vs...))
+}
+
+// ClassNotIn applies the NotIn predicate on the "class" field.
+func ClassNotIn(vs ...string) predicate.Instance {
+	return predicate.Instance(sql.FieldNotIn(FieldClass, vs...))
+}
+
+// ClassGT applies the GT predicate on the "class" field.
+func ClassGT(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldGT(FieldClass, v))
+}
+
+// ClassGTE applies the GTE predicate on the "class" field.
+func ClassGTE(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldGTE(FieldClass, v))
+}
+
+// ClassLT applies the LT predicate on the "class" field.
+func ClassLT(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldLT(FieldClass, v))
+}
+
+// ClassLTE applies the LTE predicate on the "class" field.
+func ClassLTE(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldLTE(FieldClass, v))
+}
+
+// ClassContains applies the Contains predicate on the "class" field.
+func ClassContains(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldContains(FieldClass, v))
+}
+
+// ClassHasPrefix applies the HasPrefix predicate on the "class" field.
+func ClassHasPrefix(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldHasPrefix(FieldClass, v))
+}
+
+// ClassHasSuffix applies the HasSuffix predicate on the "class" field.
+func ClassHasSuffix(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldHasSuffix(FieldClass, v))
+}
+
+// ClassIsNil applies the IsNil predicate on the "class" field.
+func ClassIsNil() predicate.Instance {
+	return predicate.Instance(sql.FieldIsNull(FieldClass))
+}
+
+// ClassNotNil applies the NotNil predicate on the "class" field.
+func ClassNotNil() predicate.Instance {
+	return predicate.Instance(sql.FieldNotNull(FieldClass))
+}
+
+// ClassEqualFold applies the EqualFold predicate on the "class" field.
+func ClassEqualFold(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldEqualFold(FieldClass, v))
+}
+
+// ClassContainsFold applies the ContainsFold predicate on the "class" field.
+func ClassContainsFold(v string) predicate.Instance {
+	return predicate.Instance(sql.FieldContainsFold(FieldClass, v))
+}
+
+// TemplateIDEQ applies the EQ predicate on the "template_id" field.
+func TemplateIDEQ(v int) predicate.Instance {
+	return predicate.Instance(sql.FieldEQ(FieldTemplateID, v))
+}
+
+// TemplateIDNEQ applies the NEQ predicate on the "template_id" field.
+func TemplateIDNEQ(v int) predicate.Instance {
+	return predicate.Instance(sql.FieldNEQ(FieldTemplateID, v))
+}
+
+// TemplateIDIn applies the In predicate on the "template_id" field.
+func TemplateIDIn(vs ...int) predicate.Instance {
+	return predicate.Instance(sql.FieldIn(FieldTemplateID, vs...))
+}
+
+// TemplateIDNotIn applies the NotIn predicate on the "template_id" field.
+func TemplateIDNotIn(vs ...int) predicate.Instance {
+	return predicate.Instance(sql.FieldNotIn(FieldTemplateID, vs...))
+}
+
+// TemplateIDGT applies the GT predicate on the "template_id" field.
+func TemplateIDGT(v int) predicate.Instance {
+	return predicate.Instance(sql.FieldGT(FieldTemplateID, v))
+}
+
+// TemplateIDGTE applies the GTE predicate on the "template_id" field.
+func TemplateIDGTE(v int) predicate.Instance {
+	return predicate.Instance(sql.FieldGTE(FieldTemplateID, v))
+}
+
+// TemplateIDLT applies the LT predicate on the "template_id" field.
+func TemplateIDLT(v int) predicate.Instance {
+	return predicate.Instance(sql.FieldLT(FieldTemplateID, v))
+}
+
+// TemplateIDLTE applies the LTE predicate on the "template_id" field.
+func TemplateIDLTE(v int) predicate.Instance {
+	return predicate.Instance(sql.FieldLTE(FieldTemplateID, v))
+}
+
+// TemplateIDIsNil applies the IsNil predicate on the "template_id" field.
+func TemplateIDIsNil() predicate.Instance {
+	return predicate.Instance(sql.FieldIsNull(FieldTemplateID))
+}
+
+// TemplateIDNotNil applies the NotNil predicate on the "template_id" field.
+func TemplateIDNotNil() predicate.Instance {
+	return predicate.Instance(sql.FieldNotNull(FieldTemplateID))
+}
+
 // NetbirdConfigEQ applies the EQ predicate on the "netbird_config" field.
 func NetbirdConfigEQ(v string) predicate.Instance {
 	return predicate.Instance(sql.FieldEQ(FieldNetbirdConfig, v))
@@ -582,57 +799,96 @@ func NetbirdConfigContainsFold(v string) predicate.Instance {
 
 // AgentSecretEQ applies the EQ predicate on the "agent_secret" field.
 func AgentSecretEQ(v string) predicate.Instance {
-	return predicate.Instance(sql.FieldEQ(FieldAgentSecret, v))
+	vc, err := ValueScanner.AgentSecret.Value(v)
+	return predicate.InstanceOrErr(sql.FieldEQ(FieldAgentSecret, vc), err)
 }
 
 // AgentSecretNEQ applies the NEQ predicate on the "agent_secret" field.
 func AgentSecretNEQ(v string) predicate.Instance {
-	return predicate.Instance(sql.FieldNEQ(FieldAgentSecret, v))
+	vc, err := ValueScanner.AgentSecret.Value(v)
+	return predicate.InstanceOrErr(sql.FieldNEQ(FieldAgentSecret, vc), err)
 }
 
 // AgentSecretIn applies the In predicate on the "agent_secret" field.
 func AgentSecretIn(vs ...string) predicate.Instance {
-	return predicate.Instance(sql.FieldIn(FieldAgentSecret, vs...))
+	var (
+		err error
+		v   = make([]any, len(vs))
+	)
+	for i := range v {
+		if v[i], err = ValueScanner.AgentSecret.Value(vs[i]); err != nil {
+			break
+		}
+	}
+	return predicate.InstanceOrErr(sql.FieldIn(FieldAgentSecret, v...), err)
 }
 
 // AgentSecretNotIn applies the NotIn predicate on the "agent_secret" field.
 func AgentSecretNotIn(vs ...string) predicate.Instance {
-	return predicate.Instance(sql.FieldNotIn(FieldAgentSecret, vs...))
+	var (
+		err error
+		v   = make([]any, len(vs))
+	)
+	for i := range v {
+		if v[i], err = ValueScanner.AgentSecret.Value(vs[i]); err != nil {
+			break
+		}
+	}
+	return predicate.InstanceOrErr(sql.FieldNotIn(FieldAgentSecret, v...), err)
 }
 
 // AgentSecretGT applies the GT predicate on the "agent_secret" field.
 func AgentSecretGT(v string) predicate.Instance {
-	return predicate.Instance(sql.FieldGT(FieldAgentSecret, v))
+	vc, err := ValueScanner.AgentSecret.Value(v)
+	return predicate.InstanceOrErr(sql.FieldGT(FieldAgentSecret, vc), err)
 }
 
 // AgentSecretGTE applies the GTE predicate on the "agent_secret" field.
 func AgentSecretGTE(v string) predicate.Instance {
-	return predicate.Instance(sql.FieldGTE(FieldAgentSecret, v))
+	vc, err := ValueScanner.AgentSecret.Value(v)
+	return predicate.InstanceOrErr(sql.FieldGTE(FieldAgentSecret, vc), err)
 }
 
 // AgentSecretLT applies the LT predicate on the "agent_secret" field.
 func AgentSecretLT(v string) predicate.Instance {
-	return predicate.Instance(sql.FieldLT(FieldAgentSecret, v))
+	vc, err := ValueScanner.AgentSecret.Value(v)
+	return predicate.InstanceOrErr(sql.FieldLT(FieldAgentSecret, vc), err)
 }
 
 // AgentSecretLTE applies the LTE predicate on the "agent_secret" field.
 func AgentSecretLTE(v string) predicate.Instance {
-	return predicate.Instance(sql.FieldLTE(FieldAgentSecret, v))
+	vc, err := ValueScanner.AgentSecret.Value(v)
+	return predicate.InstanceOrErr(sql.FieldLTE(FieldAgentSecret, vc), err)
 }
 
 // AgentSecretContains applies the Contains predicate on the "agent_secret" field.
 func AgentSecretContains(v string) predicate.Instance {
-	return predicate.Instance(sql.FieldContains(FieldAgentSecret, v))
+	vc, err := ValueScanner.AgentSecret.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("agent_secret value is not a string: %T", vc)
+	}
+	return predicate.InstanceOrErr(sql.FieldContains(FieldAgentSecret, vcs), err)
 }
 
 // AgentSecretHasPrefix applies the HasPrefix predicate on the "agent_secret" field.
 func AgentSecretHasPrefix(v string) predicate.Instance {
-	return predicate.Instance(sql.FieldHasPrefix(FieldAgentSecret, v))
+	vc, err := ValueScanner.AgentSecret.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("agent_secret value is not a string: %T", vc)
+	}
+	return predicate.InstanceOrErr(sql.FieldHasPrefix(FieldAgentSecret, vcs), err)
 }
 
 // AgentSecretHasSuffix applies the HasSuffix predicate on the "agent_secret" field.
 func AgentSecretHasSuffix(v string) predicate.Instance {
-	return predicate.Instance(sql.FieldHasSuffix(FieldAgentSecret, v))
+	vc, err := ValueScanner.AgentSecret.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("agent_secret value is not a string: %T", vc)
+	}
+	return predicate.InstanceOrErr(sql.FieldHasSuffix(FieldAgentSecret, vcs), err)
 }
 
 // AgentSecretIsNil applies the IsNil predicate on the "agent_secret" field.
@@ -647,12 +903,22 @@ func AgentSecretNotNil() predicate.Instance {
 
 // AgentSecretEqualFold applies the EqualFold predicate on the "agent_secret" field.
 func AgentSecretEqualFold(v string) predicate.Instance {
-	return predicate.Instance(sql.FieldEqualFold(FieldAgentSecret, v))
+	vc, err := ValueScanner.AgentSecret.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("agent_secret value is not a string: %T", vc)
+	}
+	return predicate.InstanceOrErr(sql.FieldEqualFold(FieldAgentSecret, vcs), err)
 }
 
 // AgentSecretContainsFold applies the ContainsFold predicate on the "agent_secret" field.
 func AgentSecretContainsFold(v string) predicate.Instance {
-	return predicate.Instance(sql.FieldContainsFold(FieldAgentSecret, v))
+	vc, err := ValueScanner.AgentSecret.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("agent_secret value is not a string: %T", vc)
+	}
+	return predicate.InstanceOrErr(sql.FieldContainsFold(FieldAgentSecret, vcs), err)
 }
 
 // LastActivityAtEQ applies the EQ predicate on the "last_activity_at" field.
@@ -705,6 +971,116 @@ func LastActivityAtNotNil() predicate.Instance {
 	return predicate.Instance(sql.FieldNotNull(FieldLastActivityAt))
 }
 
+// SnapshotIdsIsNil applies the IsNil predicate on the "snapshot_ids" field.
+func SnapshotIdsIsNil() predicate.Instance {
+	return predicate.Instance(sql.FieldIsNull(FieldSnapshotIds))
+}
+
+// SnapshotIdsNotNil applies the NotNil predicate on the "snapshot_ids" field.
+func SnapshotIdsNotNil() predicate.Instance {
+	return predicate.Instance(sql.FieldNotNull(FieldSnapshotIds))
+}
+
+// PausedAtEQ applies the EQ predicate on the "paused_at" field.
+func PausedAtEQ(v time.Time) predicate.Instance {
+	return predicate.Instance(sql.FieldEQ(FieldPausedAt, v))
+}
+
+// PausedAtNEQ applies the NEQ predicate on the "paused_at" field.
+func PausedAtNEQ(v time.Time) predicate.Instance {
+	return predicate.Instance(sql.FieldNEQ(FieldPausedAt, v))
+}
+
+// PausedAtIn applies the In predicate on the "paused_at" field.
+func PausedAtIn(vs ...time.Time) predicate.Instance {
+	return predicate.Instance(sql.FieldIn(FieldPausedAt, vs...))
+}
+
+// PausedAtNotIn applies the NotIn predicate on the "paused_at" field.
+func PausedAtNotIn(vs ...time.Time) predicate.Instance {
+	return predicate.Instance(sql.FieldNotIn(FieldPausedAt, vs...))
+}
+
+// PausedAtGT applies the GT predicate on the "paused_at" field.
+func PausedAtGT(v time.Time) predicate.Instance {
+	return predicate.Instance(sql.FieldGT(FieldPausedAt, v))
+}
+
+// PausedAtGTE applies the GTE predicate on the "paused_at" field.
+func PausedAtGTE(v time.Time) predicate.Instance {
+	return predicate.Instance(sql.FieldGTE(FieldPausedAt, v))
+}
+
+// PausedAtLT applies the LT predicate on the "paused_at" field.
+func PausedAtLT(v time.Time) predicate.Instance {
+	return predicate.Instance(sql.FieldLT(FieldPausedAt, v))
+}
+
+// PausedAtLTE applies the LTE predicate on the "paused_at" field.
+func PausedAtLTE(v time.Time) predicate.Instance {
+	return predicate.Instance(sql.FieldLTE(FieldPausedAt, v))
+}
+
+// PausedAtIsNil applies the IsNil predicate on the "paused_at" field.
+func PausedAtIsNil() predicate.Instance {
+	return predicate.Instance(sql.FieldIsNull(FieldPausedAt))
+}
+
+// PausedAtNotNil applies the NotNil predicate on the "paused_at" field.
+func PausedAtNotNil() predicate.Instance {
+	return predicate.Instance(sql.FieldNotNull(FieldPausedAt))
+}
+
+// OrganizationIDEQ applies the EQ predicate on the "organization_id" field.
+func OrganizationIDEQ(v int) predicate.Instance {
+	return predicate.Instance(sql.FieldEQ(FieldOrganizationID, v))
+}
+
+// OrganizationIDNEQ applies the NEQ predicate on the "organization_id" field.
+func OrganizationIDNEQ(v int) predicate.Instance {
+	return predicate.Instance(sql.FieldNEQ(FieldOrganizationID, v))
+}
+
+// OrganizationIDIn applies the In predicate on the "organization_id" field.
+func OrganizationIDIn(vs ...int) predicate.Instance {
+	return predicate.Instance(sql.FieldIn(FieldOrganizationID, vs...))
+}
+
+// OrganizationIDNotIn applies the NotIn predicate on the "organization_id" field.
+func OrganizationIDNotIn(vs ...int) predicate.Instance {
+	return predicate.Instance(sql.FieldNotIn(FieldOrganizationID, vs...))
+}
+
+// OrganizationIDIsNil applies the IsNil predicate on the "organization_id" field.
+func OrganizationIDIsNil() predicate.Instance {
+	return predicate.Instance(sql.FieldIsNull(FieldOrganizationID))
+}
+
+// OrganizationIDNotNil applies the NotNil predicate on the "organization_id" field.
+func OrganizationIDNotNil() predicate.Instance {
+	return predicate.Instance(sql.FieldNotNull(FieldOrganizationID))
+}
+
+// TeamAccessEQ applies the EQ predicate on the "team_access" field.
+func TeamAccessEQ(v TeamAccess) predicate.Instance {
+	return predicate.Instance(sql.FieldEQ(FieldTeamAccess, v))
+}
+
+// TeamAccessNEQ applies the NEQ predicate on the "team_access" field.
+func TeamAccessNEQ(v TeamAccess) predicate.Instance {
+	return predicate.Instance(sql.FieldNEQ(FieldTeamAccess, v))
+}
+
+// TeamAccessIn applies the In predicate on the "team_access" field.
+func TeamAccessIn(vs ...TeamAccess) predicate.Instance {
+	return predicate.Instance(sql.FieldIn(FieldTeamAccess, vs...))
+}
+
+// TeamAccessNotIn applies the NotIn predicate on the "team_access" field.
+func TeamAccessNotIn(vs ...TeamAccess) predicate.Instance {
+	return predicate.Instance(sql.FieldNotIn(FieldTeamAccess, vs...))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Instance {
 	return predicate.Instance(sql.FieldEQ(FieldCreatedAt, v))
@@ -808,6 +1184,52 @@ func HasOwnerWith(preds ...predicate.User) predicate.Instance {
 	})
 }
 
+// HasOrganization applies the HasEdge predicate on the "organization" edge.
+func HasOrganization() predicate.Instance {
+	return predicate.Instance(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, OrganizationTable, OrganizationColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOrganizationWith applies the HasEdge predicate on the "organization" edge with a given conditions (other predicates).
+func HasOrganizationWith(preds ...predicate.Organization) predicate.Instance {
+	return predicate.Instance(func(s *sql.Selector) {
+		step := newOrganizationStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasConversations applies the HasEdge predicate on the "conversations" edge.
+func HasConversations() predicate.Instance {
+	return predicate.Instance(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, ConversationsTable, ConversationsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasConversationsWith applies the HasEdge predicate on the "conversations" edge with a given conditions (other predicates).
+func HasConversationsWith(preds ...predicate.Conversation) predicate.Instance {
+	return predicate.Instance(func(s *sql.Selector) {
+		step := newConversationsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Instance) predicate.Instance {
 	return predicate.Instance(sql.AndPredicates(predicates...))
@@ -10,7 +10,9 @@ import (
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/conversation"
 	"github.com/logan/cloudcode/internal/ent/instance"
+	"github.com/logan/cloudcode/internal/ent/organization"
 	"github.com/logan/cloudcode/internal/ent/user"
 )
 
@@ -21,6 +23,20 @@ type InstanceCreate struct {
 	hooks    []Hook
 }
 
+// SetName sets the "name" field.
+func (_c *InstanceCreate) SetName(v string) *InstanceCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_c *InstanceCreate) SetNillableName(v *string) *InstanceCreate {
+	if v != nil {
+		_c.SetName(*v)
+	}
+	return _c
+}
+
 // SetProvider sets the "provider" field.
 func (_c *InstanceCreate) SetProvider(v string) *InstanceCreate {
 	_c.mutation.SetProvider(v)
@@ -89,6 +105,34 @@ func (_c *InstanceCreate) SetNillableVolumeID(v *string) *InstanceCreate {
 	return _c
 }
 
+// SetClass sets the "class" field.
+func (_c *InstanceCreate) SetClass(v string) *InstanceCreate {
+	_c.mutation.SetClass(v)
+	return _c
+}
+
+// SetNillableClass sets the "class" field if the given value is not nil.
+func (_c *InstanceCreate) SetNillableClass(v *string) *InstanceCreate {
+	if v != nil {
+		_c.SetClass(*v)
+	}
+	return _c
+}
+
+// SetTemplateID sets the "template_id" field.
+func (_c *InstanceCreate) SetTemplateID(v int) *InstanceCreate {
+	_c.mutation.SetTemplateID(v)
+	return _c
+}
+
+// SetNillableTemplateID sets the "template_id" field if the given value is not nil.
+func (_c *InstanceCreate) SetNillableTemplateID(v *int) *InstanceCreate {
+	if v != nil {
+		_c.SetTemplateID(*v)
+	}
+	return _c
+}
+
 // SetNetbirdConfig sets the "netbird_config" field.
 func (_c *InstanceCreate) SetNetbirdConfig(v string) *InstanceCreate {
 	_c.mutation.SetNetbirdConfig(v)
@@ -131,6 +175,54 @@ func (_c *InstanceCreate) SetNillableLastActivityAt(v *time.Time) *InstanceCreat
 	return _c
 }
 
+// SetSnapshotIds sets the "snapshot_ids" field.
+func (_c *InstanceCreate) SetSnapshotIds(v []string) *InstanceCreate {
+	_c.mutation.SetSnapshotIds(v)
+	return _c
+}
+
+// SetPausedAt sets the "paused_at" field.
+func (_c *InstanceCreate) SetPausedAt(v time.Time) *InstanceCreate {
+	_c.mutation.SetPausedAt(v)
+	return _c
+}
+
+// SetNillablePausedAt sets the "paused_at" field if the given value is not nil.
+func (_c *InstanceCreate) SetNillablePausedAt(v *time.Time) *InstanceCreate {
+	if v != nil {
+		_c.SetPausedAt(*v)
+	}
+	return _c
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_c *InstanceCreate) SetOrganizationID(v int) *InstanceCreate {
+	_c.mutation.SetOrganizationID(v)
+	return _c
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (_c *InstanceCreate) SetNillableOrganizationID(v *int) *InstanceCreate {
+	if v != nil {
+		_c.SetOrganizationID(*v)
+	}
+	return _c
+}
+
+// SetTeamAccess sets the "team_access" field.
+func (_c *InstanceCreate) SetTeamAccess(v instance.TeamAccess) *InstanceCreate {
+	_c.mutation.SetTeamAccess(v)
+	return _c
+}
+
+// SetNillableTeamAccess sets the "team_access" field if the given value is not nil.
+func (_c *InstanceCreate) SetNillableTeamAccess(v *instance.TeamAccess) *InstanceCreate {
+	if v != nil {
+		_c.SetTeamAccess(*v)
+	}
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *InstanceCreate) SetCreatedAt(v time.Time) *InstanceCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -170,6 +262,26 @@ func (_c *InstanceCreate) SetOwner(v *User) *InstanceCreate {
 	return _c.SetOwnerID(v.ID)
 }
 
+// SetOrganization sets the "organization" edge to the Organization entity.
+func (_c *InstanceCreate) SetOrganization(v *Organization) *InstanceCreate {
+	return _c.SetOrganizationID(v.ID)
+}
+
+// AddConversationIDs adds the "conversations" edge to the Conversation entity by IDs.
+func (_c *InstanceCreate) AddConversationIDs(ids ...int) *InstanceCreate {
+	_c.mutation.AddConversationIDs(ids...)
+	return _c
+}
+
+// AddConversations adds the "conversations" edges to the Conversation entity.
+func (_c *InstanceCreate) AddConversations(v ...*Conversation) *InstanceCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddConversationIDs(ids...)
+}
+
 // Mutation returns the InstanceMutation object of the builder.
 func (_c *InstanceCreate) Mutation() *InstanceMutation {
 	return _c.mutation
@@ -205,10 +317,18 @@ func (_c *InstanceCreate) ExecX(ctx context.Context) {
 
 // defaults sets the default values of the builder before save.
 func (_c *InstanceCreate) defaults() {
+	if _, ok := _c.mutation.Name(); !ok {
+		v := instance.DefaultName
+		_c.mutation.SetName(v)
+	}
 	if _, ok := _c.mutation.Status(); !ok {
 		v := instance.DefaultStatus
 		_c.mutation.SetStatus(v)
 	}
+	if _, ok := _c.mutation.TeamAccess(); !ok {
+		v := instance.DefaultTeamAccess
+		_c.mutation.SetTeamAccess(v)
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		v := instance.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
@@ -221,6 +341,9 @@ func (_c *InstanceCreate) defaults() {
 
 // check runs all checks and user-defined validators on the builder.
 func (_c *InstanceCreate) check() error {
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Instance.name"`)}
+	}
 	if _, ok := _c.mutation.Provider(); !ok {
 		return &ValidationError{Name: "provider", err: errors.New(`ent: missing required field "Instance.provider"`)}
 	}
@@ -240,6 +363,14 @@ func (_c *InstanceCreate) check() error {
 	if _, ok := _c.mutation.Status(); !ok {
 		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Instance.status"`)}
 	}
+	if _, ok := _c.mutation.TeamAccess(); !ok {
+		return &ValidationError{Name: "team_access", err: errors.New(`ent: missing required field "Instance.team_access"`)}
+	}
+	if v, ok := _c.mutation.TeamAccess(); ok {
+		if err := instance.TeamAccessValidator(v); err != nil {
+			return &ValidationError{Name: "team_access", err: fmt.Errorf(`ent: validator failed for field "Instance.team_access": %w`, err)}
+		}
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Instance.created_at"`)}
 	}
@@ -256,7 +387,10 @@ func (_c *InstanceCreate) sqlSave(ctx context.Context) (*Instance, error) {
 	if err := _c.check(); err != nil {
 		return nil, err
 	}
-	_node, _spec := _c.createSpec()
+	_node, _spec, err := _c.createSpec()
+	if err != nil {
+		return nil, err
+	}
 	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
 		if sqlgraph.IsConstraintError(err) {
 			err = &ConstraintError{msg: err.Error(), wrap: err}
@@ -270,11 +404,15 @@ func (_c *InstanceCreate) sqlSave(ctx context.Context) (*Instance, error) {
 	return _node, nil
 }
 
-func (_c *InstanceCreate) createSpec() (*Instance, *sqlgraph.CreateSpec) {
+func (_c *InstanceCreate) createSpec() (*Instance, *sqlgraph.CreateSpec, error) {
 	var (
 		_node = &Instance{config: _c.config}
 		_spec = sqlgraph.NewCreateSpec(instance.Table, sqlgraph.NewFieldSpec(instance.FieldID, field.TypeInt))
 	)
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(instance.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
 	if value, ok := _c.mutation.Provider(); ok {
 		_spec.SetField(instance.FieldProvider, field.TypeString, value)
 		_node.Provider = value
@@ -299,18 +437,42 @@ func (_c *InstanceCreate) createSpec() (*Instance, *sqlgraph.CreateSpec) {
 		_spec.SetField(instance.FieldVolumeID, field.TypeString, value)
 		_node.VolumeID = value
 	}
+	if value, ok := _c.mutation.Class(); ok {
+		_spec.SetField(instance.FieldClass, field.TypeString, value)
+		_node.Class = value
+	}
+	if value, ok := _c.mutation.TemplateID(); ok {
+		_spec.SetField(instance.FieldTemplateID, field.TypeInt, value)
+		_node.TemplateID = &value
+	}
 	if value, ok := _c.mutation.NetbirdConfig(); ok {
 		_spec.SetField(instance.FieldNetbirdConfig, field.TypeString, value)
 		_node.NetbirdConfig = value
 	}
 	if value, ok := _c.mutation.AgentSecret(); ok {
-		_spec.SetField(instance.FieldAgentSecret, field.TypeString, value)
+		vv, err := instance.ValueScanner.AgentSecret.Value(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		_spec.SetField(instance.FieldAgentSecret, field.TypeString, vv)
 		_node.AgentSecret = value
 	}
 	if value, ok := _c.mutation.LastActivityAt(); ok {
 		_spec.SetField(instance.FieldLastActivityAt, field.TypeTime, value)
 		_node.LastActivityAt = &value
 	}
+	if value, ok := _c.mutation.SnapshotIds(); ok {
+		_spec.SetField(instance.FieldSnapshotIds, field.TypeJSON, value)
+		_node.SnapshotIds = value
+	}
+	if value, ok := _c.mutation.PausedAt(); ok {
+		_spec.SetField(instance.FieldPausedAt, field.TypeTime, value)
+		_node.PausedAt = &value
+	}
+	if value, ok := _c.mutation.TeamAccess(); ok {
+		_spec.SetField(instance.FieldTeamAccess, field.TypeEnum, value)
+		_node.TeamAccess = value
+	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(instance.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -336,7 +498,40 @@ func (_c *InstanceCreate) createSpec() (*Instance, *sqlgraph.CreateSpec) {
 		_node.user_instances = &nodes[0]
 		_spec.Edges = append(_spec.Edges, edge)
 	}
-	return _node, _spec
+	if nodes := _c.mutation.OrganizationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   instance.OrganizationTable,
+			Columns: []string{instance.OrganizationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.OrganizationID = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.ConversationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   instance.ConversationsTable,
+			Columns: []string{instance.ConversationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec, nil
 }
 
 // InstanceCreateBulk is the builder for creating many Instance entities in bulk.
@@ -368,7 +563,10 @@ func (_c *InstanceCreateBulk) Save(ctx context.Context) ([]*Instance, error) {
 				}
 				builder.mutation = mutation
 				var err error
-				nodes[i], specs[i] = builder.createSpec()
+				nodes[i], specs[i], err = builder.createSpec()
+				if err != nil {
+					return nil, err
+				}
 				if i < len(mutators)-1 {
 					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
 				} else {
@@ -10,8 +10,11 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/conversation"
 	"github.com/logan/cloudcode/internal/ent/instance"
+	"github.com/logan/cloudcode/internal/ent/organization"
 	"github.com/logan/cloudcode/internal/ent/predicate"
 	"github.com/logan/cloudcode/internal/ent/user"
 )
@@ -29,6 +32,20 @@ func (_u *InstanceUpdate) Where(ps ...predicate.Instance) *InstanceUpdate {
 	return _u
 }
 
+// SetName sets the "name" field.
+func (_u *InstanceUpdate) SetName(v string) *InstanceUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *InstanceUpdate) SetNillableName(v *string) *InstanceUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
 // SetProvider sets the "provider" field.
 func (_u *InstanceUpdate) SetProvider(v string) *InstanceUpdate {
 	_u.mutation.SetProvider(v)
@@ -138,6 +155,53 @@ func (_u *InstanceUpdate) ClearVolumeID() *InstanceUpdate {
 	return _u
 }
 
+// SetClass sets the "class" field.
+func (_u *InstanceUpdate) SetClass(v string) *InstanceUpdate {
+	_u.mutation.SetClass(v)
+	return _u
+}
+
+// SetNillableClass sets the "class" field if the given value is not nil.
+func (_u *InstanceUpdate) SetNillableClass(v *string) *InstanceUpdate {
+	if v != nil {
+		_u.SetClass(*v)
+	}
+	return _u
+}
+
+// ClearClass clears the value of the "class" field.
+func (_u *InstanceUpdate) ClearClass() *InstanceUpdate {
+	_u.mutation.ClearClass()
+	return _u
+}
+
+// SetTemplateID sets the "template_id" field.
+func (_u *InstanceUpdate) SetTemplateID(v int) *InstanceUpdate {
+	_u.mutation.ResetTemplateID()
+	_u.mutation.SetTemplateID(v)
+	return _u
+}
+
+// SetNillableTemplateID sets the "template_id" field if the given value is not nil.
+func (_u *InstanceUpdate) SetNillableTemplateID(v *int) *InstanceUpdate {
+	if v != nil {
+		_u.SetTemplateID(*v)
+	}
+	return _u
+}
+
+// AddTemplateID adds value to the "template_id" field.
+func (_u *InstanceUpdate) AddTemplateID(v int) *InstanceUpdate {
+	_u.mutation.AddTemplateID(v)
+	return _u
+}
+
+// ClearTemplateID clears the value of the "template_id" field.
+func (_u *InstanceUpdate) ClearTemplateID() *InstanceUpdate {
+	_u.mutation.ClearTemplateID()
+	return _u
+}
+
 // SetNetbirdConfig sets the "netbird_config" field.
 func (_u *InstanceUpdate) SetNetbirdConfig(v string) *InstanceUpdate {
 	_u.mutation.SetNetbirdConfig(v)
@@ -198,6 +262,78 @@ func (_u *InstanceUpdate) ClearLastActivityAt() *InstanceUpdate {
 	return _u
 }
 
+// SetSnapshotIds sets the "snapshot_ids" field.
+func (_u *InstanceUpdate) SetSnapshotIds(v []string) *InstanceUpdate {
+	_u.mutation.SetSnapshotIds(v)
+	return _u
+}
+
+// AppendSnapshotIds appends value to the "snapshot_ids" field.
+func (_u *InstanceUpdate) AppendSnapshotIds(v []string) *InstanceUpdate {
+	_u.mutation.AppendSnapshotIds(v)
+	return _u
+}
+
+// ClearSnapshotIds clears the value of the "snapshot_ids" field.
+func (_u *InstanceUpdate) ClearSnapshotIds() *InstanceUpdate {
+	_u.mutation.ClearSnapshotIds()
+	return _u
+}
+
+// SetPausedAt sets the "paused_at" field.
+func (_u *InstanceUpdate) SetPausedAt(v time.Time) *InstanceUpdate {
+	_u.mutation.SetPausedAt(v)
+	return _u
+}
+
+// SetNillablePausedAt sets the "paused_at" field if the given value is not nil.
+func (_u *InstanceUpdate) SetNillablePausedAt(v *time.Time) *InstanceUpdate {
+	if v != nil {
+		_u.SetPausedAt(*v)
+	}
+	return _u
+}
+
+// ClearPausedAt clears the value of the "paused_at" field.
+func (_u *InstanceUpdate) ClearPausedAt() *InstanceUpdate {
+	_u.mutation.ClearPausedAt()
+	return _u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_u *InstanceUpdate) SetOrganizationID(v int) *InstanceUpdate {
+	_u.mutation.SetOrganizationID(v)
+	return _u
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (_u *InstanceUpdate) SetNillableOrganizationID(v *int) *InstanceUpdate {
+	if v != nil {
+		_u.SetOrganizationID(*v)
+	}
+	return _u
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (_u *InstanceUpdate) ClearOrganizationID() *InstanceUpdate {
+	_u.mutation.ClearOrganizationID()
+	return _u
+}
+
+// SetTeamAccess sets the "team_access" field.
+func (_u *InstanceUpdate) SetTeamAccess(v instance.TeamAccess) *InstanceUpdate {
+	_u.mutation.SetTeamAccess(v)
+	return _u
+}
+
+// SetNillableTeamAccess sets the "team_access" field if the given value is not nil.
+func (_u *InstanceUpdate) SetNillableTeamAccess(v *instance.TeamAccess) *InstanceUpdate {
+	if v != nil {
+		_u.SetTeamAccess(*v)
+	}
+	return _u
+}
+
 // SetUpdatedAt sets the "updated_at" field.
 func (_u *InstanceUpdate) SetUpdatedAt(v time.Time) *InstanceUpdate {
 	_u.mutation.SetUpdatedAt(v)
@@ -215,6 +351,26 @@ func (_u *InstanceUpdate) SetOwner(v *User) *InstanceUpdate {
 	return _u.SetOwnerID(v.ID)
 }
 
+// SetOrganization sets the "organization" edge to the Organization entity.
+func (_u *InstanceUpdate) SetOrganization(v *Organization) *InstanceUpdate {
+	return _u.SetOrganizationID(v.ID)
+}
+
+// AddConversationIDs adds the "conversations" edge to the Conversation entity by IDs.
+func (_u *InstanceUpdate) AddConversationIDs(ids ...int) *InstanceUpdate {
+	_u.mutation.AddConversationIDs(ids...)
+	return _u
+}
+
+// AddConversations adds the "conversations" edges to the Conversation entity.
+func (_u *InstanceUpdate) AddConversations(v ...*Conversation) *InstanceUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddConversationIDs(ids...)
+}
+
 // Mutation returns the InstanceMutation object of the builder.
 func (_u *InstanceUpdate) Mutation() *InstanceMutation {
 	return _u.mutation
@@ -226,6 +382,33 @@ func (_u *InstanceUpdate) ClearOwner() *InstanceUpdate {
 	return _u
 }
 
+// ClearOrganization clears the "organization" edge to the Organization entity.
+func (_u *InstanceUpdate) ClearOrganization() *InstanceUpdate {
+	_u.mutation.ClearOrganization()
+	return _u
+}
+
+// ClearConversations clears all "conversations" edges to the Conversation entity.
+func (_u *InstanceUpdate) ClearConversations() *InstanceUpdate {
+	_u.mutation.ClearConversations()
+	return _u
+}
+
+// RemoveConversationIDs removes the "conversations" edge to Conversation entities by IDs.
+func (_u *InstanceUpdate) RemoveConversationIDs(ids ...int) *InstanceUpdate {
+	_u.mutation.RemoveConversationIDs(ids...)
+	return _u
+}
+
+// RemoveConversations removes "conversations" edges to Conversation entities.
+func (_u *InstanceUpdate) RemoveConversations(v ...*Conversation) *InstanceUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveConversationIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *InstanceUpdate) Save(ctx context.Context) (int, error) {
 	_u.defaults()
@@ -274,6 +457,11 @@ func (_u *InstanceUpdate) check() error {
 			return &ValidationError{Name: "provider_id", err: fmt.Errorf(`ent: validator failed for field "Instance.provider_id": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.TeamAccess(); ok {
+		if err := instance.TeamAccessValidator(v); err != nil {
+			return &ValidationError{Name: "team_access", err: fmt.Errorf(`ent: validator failed for field "Instance.team_access": %w`, err)}
+		}
+	}
 	if _u.mutation.OwnerCleared() && len(_u.mutation.OwnerIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Instance.owner"`)
 	}
@@ -292,6 +480,9 @@ func (_u *InstanceUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 			}
 		}
 	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(instance.FieldName, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.Provider(); ok {
 		_spec.SetField(instance.FieldProvider, field.TypeString, value)
 	}
@@ -322,6 +513,21 @@ func (_u *InstanceUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.VolumeIDCleared() {
 		_spec.ClearField(instance.FieldVolumeID, field.TypeString)
 	}
+	if value, ok := _u.mutation.Class(); ok {
+		_spec.SetField(instance.FieldClass, field.TypeString, value)
+	}
+	if _u.mutation.ClassCleared() {
+		_spec.ClearField(instance.FieldClass, field.TypeString)
+	}
+	if value, ok := _u.mutation.TemplateID(); ok {
+		_spec.SetField(instance.FieldTemplateID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTemplateID(); ok {
+		_spec.AddField(instance.FieldTemplateID, field.TypeInt, value)
+	}
+	if _u.mutation.TemplateIDCleared() {
+		_spec.ClearField(instance.FieldTemplateID, field.TypeInt)
+	}
 	if value, ok := _u.mutation.NetbirdConfig(); ok {
 		_spec.SetField(instance.FieldNetbirdConfig, field.TypeString, value)
 	}
@@ -329,7 +535,11 @@ func (_u *InstanceUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		_spec.ClearField(instance.FieldNetbirdConfig, field.TypeString)
 	}
 	if value, ok := _u.mutation.AgentSecret(); ok {
-		_spec.SetField(instance.FieldAgentSecret, field.TypeString, value)
+		vv, err := instance.ValueScanner.AgentSecret.Value(value)
+		if err != nil {
+			return 0, err
+		}
+		_spec.SetField(instance.FieldAgentSecret, field.TypeString, vv)
 	}
 	if _u.mutation.AgentSecretCleared() {
 		_spec.ClearField(instance.FieldAgentSecret, field.TypeString)
@@ -340,6 +550,26 @@ func (_u *InstanceUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.LastActivityAtCleared() {
 		_spec.ClearField(instance.FieldLastActivityAt, field.TypeTime)
 	}
+	if value, ok := _u.mutation.SnapshotIds(); ok {
+		_spec.SetField(instance.FieldSnapshotIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedSnapshotIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, instance.FieldSnapshotIds, value)
+		})
+	}
+	if _u.mutation.SnapshotIdsCleared() {
+		_spec.ClearField(instance.FieldSnapshotIds, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.PausedAt(); ok {
+		_spec.SetField(instance.FieldPausedAt, field.TypeTime, value)
+	}
+	if _u.mutation.PausedAtCleared() {
+		_spec.ClearField(instance.FieldPausedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.TeamAccess(); ok {
+		_spec.SetField(instance.FieldTeamAccess, field.TypeEnum, value)
+	}
 	if value, ok := _u.mutation.UpdatedAt(); ok {
 		_spec.SetField(instance.FieldUpdatedAt, field.TypeTime, value)
 	}
@@ -372,6 +602,80 @@ func (_u *InstanceUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.OrganizationCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   instance.OrganizationTable,
+			Columns: []string{instance.OrganizationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OrganizationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   instance.OrganizationTable,
+			Columns: []string{instance.OrganizationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ConversationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   instance.ConversationsTable,
+			Columns: []string{instance.ConversationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedConversationsIDs(); len(nodes) > 0 && !_u.mutation.ConversationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   instance.ConversationsTable,
+			Columns: []string{instance.ConversationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ConversationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   instance.ConversationsTable,
+			Columns: []string{instance.ConversationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{instance.Label}
@@ -392,6 +696,20 @@ type InstanceUpdateOne struct {
 	mutation *InstanceMutation
 }
 
+// SetName sets the "name" field.
+func (_u *InstanceUpdateOne) SetName(v string) *InstanceUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *InstanceUpdateOne) SetNillableName(v *string) *InstanceUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
 // SetProvider sets the "provider" field.
 func (_u *InstanceUpdateOne) SetProvider(v string) *InstanceUpdateOne {
 	_u.mutation.SetProvider(v)
@@ -501,6 +819,53 @@ func (_u *InstanceUpdateOne) ClearVolumeID() *InstanceUpdateOne {
 	return _u
 }
 
+// SetClass sets the "class" field.
+func (_u *InstanceUpdateOne) SetClass(v string) *InstanceUpdateOne {
+	_u.mutation.SetClass(v)
+	return _u
+}
+
+// SetNillableClass sets the "class" field if the given value is not nil.
+func (_u *InstanceUpdateOne) SetNillableClass(v *string) *InstanceUpdateOne {
+	if v != nil {
+		_u.SetClass(*v)
+	}
+	return _u
+}
+
+// ClearClass clears the value of the "class" field.
+func (_u *InstanceUpdateOne) ClearClass() *InstanceUpdateOne {
+	_u.mutation.ClearClass()
+	return _u
+}
+
+// SetTemplateID sets the "template_id" field.
+func (_u *InstanceUpdateOne) SetTemplateID(v int) *InstanceUpdateOne {
+	_u.mutation.ResetTemplateID()
+	_u.mutation.SetTemplateID(v)
+	return _u
+}
+
+// SetNillableTemplateID sets the "template_id" field if the given value is not nil.
+func (_u *InstanceUpdateOne) SetNillableTemplateID(v *int) *InstanceUpdateOne {
+	if v != nil {
+		_u.SetTemplateID(*v)
+	}
+	return _u
+}
+
+// AddTemplateID adds value to the "template_id" field.
+func (_u *InstanceUpdateOne) AddTemplateID(v int) *InstanceUpdateOne {
+	_u.mutation.AddTemplateID(v)
+	return _u
+}
+
+// ClearTemplateID clears the value of the "template_id" field.
+func (_u *InstanceUpdateOne) ClearTemplateID() *InstanceUpdateOne {
+	_u.mutation.ClearTemplateID()
+	return _u
+}
+
 // SetNetbirdConfig sets the "netbird_config" field.
 func (_u *InstanceUpdateOne) SetNetbirdConfig(v string) *InstanceUpdateOne {
 	_u.mutation.SetNetbirdConfig(v)
@@ -561,6 +926,78 @@ func (_u *InstanceUpdateOne) ClearLastActivityAt() *InstanceUpdateOne {
 	return _u
 }
 
+// SetSnapshotIds sets the "snapshot_ids" field.
+func (_u *InstanceUpdateOne) SetSnapshotIds(v []string) *InstanceUpdateOne {
+	_u.mutation.SetSnapshotIds(v)
+	return _u
+}
+
+// AppendSnapshotIds appends value to the "snapshot_ids" field.
+func (_u *InstanceUpdateOne) AppendSnapshotIds(v []string) *InstanceUpdateOne {
+	_u.mutation.AppendSnapshotIds(v)
+	return _u
+}
+
+// ClearSnapshotIds clears the value of the "snapshot_ids" field.
+func (_u *InstanceUpdateOne) ClearSnapshotIds() *InstanceUpdateOne {
+	_u.mutation.ClearSnapshotIds()
+	return _u
+}
+
+// SetPausedAt sets the "paused_at" field.
+func (_u *InstanceUpdateOne) SetPausedAt(v time.Time) *InstanceUpdateOne {
+	_u.mutation.SetPausedAt(v)
+	return _u
+}
+
+// SetNillablePausedAt sets the "paused_at" field if the given value is not nil.
+func (_u *InstanceUpdateOne) SetNillablePausedAt(v *time.Time) *InstanceUpdateOne {
+	if v != nil {
+		_u.SetPausedAt(*v)
+	}
+	return _u
+}
+
+// ClearPausedAt clears the value of the "paused_at" field.
+func (_u *InstanceUpdateOne) ClearPausedAt() *InstanceUpdateOne {
+	_u.mutation.ClearPausedAt()
+	return _u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_u *InstanceUpdateOne) SetOrganizationID(v int) *InstanceUpdateOne {
+	_u.mutation.SetOrganizationID(v)
+	return _u
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (_u *InstanceUpdateOne) SetNillableOrganizationID(v *int) *InstanceUpdateOne {
+	if v != nil {
+		_u.SetOrganizationID(*v)
+	}
+	return _u
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (_u *InstanceUpdateOne) ClearOrganizationID() *InstanceUpdateOne {
+	_u.mutation.ClearOrganizationID()
+	return _u
+}
+
+// SetTeamAccess sets the "team_access" field.
+func (_u *InstanceUpdateOne) SetTeamAccess(v instance.TeamAccess) *InstanceUpdateOne {
+	_u.mutation.SetTeamAccess(v)
+	return _u
+}
+
+// SetNillableTeamAccess sets the "team_access" field if the given value is not nil.
+func (_u *InstanceUpdateOne) SetNillableTeamAccess(v *instance.TeamAccess) *InstanceUpdateOne {
+	if v != nil {
+		_u.SetTeamAccess(*v)
+	}
+	return _u
+}
+
 // SetUpdatedAt sets the "updated_at" field.
 func (_u *InstanceUpdateOne) SetUpdatedAt(v time.Time) *InstanceUpdateOne {
 	_u.mutation.SetUpdatedAt(v)
@@ -578,6 +1015,26 @@ func (_u *InstanceUpdateOne) SetOwner(v *User) *InstanceUpdateOne {
 	return _u.SetOwnerID(v.ID)
 }
 
+// SetOrganization sets the "organization" edge to the Organization entity.
+func (_u *InstanceUpdateOne) SetOrganization(v *Organization) *InstanceUpdateOne {
+	return _u.SetOrganizationID(v.ID)
+}
+
+// AddConversationIDs adds the "conversations" edge to the Conversation entity by IDs.
+func (_u *InstanceUpdateOne) AddConversationIDs(ids ...int) *InstanceUpdateOne {
+	_u.mutation.AddConversationIDs(ids...)
+	return _u
+}
+
+// AddConversations adds the "conversations" edges to the Conversation entity.
+func (_u *InstanceUpdateOne) AddConversations(v ...*Conversation) *InstanceUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddConversationIDs(ids...)
+}
+
 // Mutation returns the InstanceMutation object of the builder.
 func (_u *InstanceUpdateOne) Mutation() *InstanceMutation {
 	return _u.mutation
@@ -589,6 +1046,33 @@ func (_u *InstanceUpdateOne) ClearOwner() *InstanceUpdateOne {
 	return _u
 }
 
+// ClearOrganization clears the "organization" edge to the Organization entity.
+func (_u *InstanceUpdateOne) ClearOrganization() *InstanceUpdateOne {
+	_u.mutation.ClearOrganization()
+	return _u
+}
+
+// ClearConversations clears all "conversations" edges to the Conversation entity.
+func (_u *InstanceUpdateOne) ClearConversations() *InstanceUpdateOne {
+	_u.mutation.ClearConversations()
+	return _u
+}
+
+// RemoveConversationIDs removes the "conversations" edge to Conversation entities by IDs.
+func (_u *InstanceUpdateOne) RemoveConversationIDs(ids ...int) *InstanceUpdateOne {
+	_u.mutation.RemoveConversationIDs(ids...)
+	return _u
+}
+
+// RemoveConversations removes "conversations" edges to Conversation entities.
+func (_u *InstanceUpdateOne) RemoveConversations(v ...*Conversation) *InstanceUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveConversationIDs(ids...)
+}
+
 // Where appends a list predicates to the InstanceUpdate builder.
 func (_u *InstanceUpdateOne) Where(ps ...predicate.Instance) *InstanceUpdateOne {
 	_u.mutation.Where(ps...)
@@ -650,6 +1134,11 @@ func (_u *InstanceUpdateOne) check() error {
 			return &ValidationError{Name: "provider_id", err: fmt.Errorf(`ent: validator failed for field "Instance.provider_id": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.TeamAccess(); ok {
+		if err := instance.TeamAccessValidator(v); err != nil {
+			return &ValidationError{Name: "team_access", err: fmt.Errorf(`ent: validator failed for field "Instance.team_access": %w`, err)}
+		}
+	}
 	if _u.mutation.OwnerCleared() && len(_u.mutation.OwnerIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Instance.owner"`)
 	}
@@ -685,6 +1174,9 @@ func (_u *InstanceUpdateOne) sqlSave(ctx context.Context) (_node *Instance, err
 			}
 		}
 	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(instance.FieldName, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.Provider(); ok {
 		_spec.SetField(instance.FieldProvider, field.TypeString, value)
 	}
@@ -715,6 +1207,21 @@ func (_u *InstanceUpdateOne) sqlSave(ctx context.Context) (_node *Instance, err
 	if _u.mutation.VolumeIDCleared() {
 		_spec.ClearField(instance.FieldVolumeID, field.TypeString)
 	}
+	if value, ok := _u.mutation.Class(); ok {
+		_spec.SetField(instance.FieldClass, field.TypeString, value)
+	}
+	if _u.mutation.ClassCleared() {
+		_spec.ClearField(instance.FieldClass, field.TypeString)
+	}
+	if value, ok := _u.mutation.TemplateID(); ok {
+		_spec.SetField(instance.FieldTemplateID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTemplateID(); ok {
+		_spec.AddField(instance.FieldTemplateID, field.TypeInt, value)
+	}
+	if _u.mutation.TemplateIDCleared() {
+		_spec.ClearField(instance.FieldTemplateID, field.TypeInt)
+	}
 	if value, ok := _u.mutation.NetbirdConfig(); ok {
 		_spec.SetField(instance.FieldNetbirdConfig, field.TypeString, value)
 	}
@@ -722,7 +1229,11 @@ func (_u *InstanceUpdateOne) sqlSave(ctx context.Context) (_node *Instance, err
 		_spec.ClearField(instance.FieldNetbirdConfig, field.TypeString)
 	}
 	if value, ok := _u.mutation.AgentSecret(); ok {
-		_spec.SetField(instance.FieldAgentSecret, field.TypeString, value)
+		vv, err := instance.ValueScanner.AgentSecret.Value(value)
+		if err != nil {
+			return nil, err
+		}
+		_spec.SetField(instance.FieldAgentSecret, field.TypeString, vv)
 	}
 	if _u.mutation.AgentSecretCleared() {
 		_spec.ClearField(instance.FieldAgentSecret, field.TypeString)
@@ -733,6 +1244,26 @@ func (_u *InstanceUpdateOne) sqlSave(ctx context.Context) (_node *Instance, err
 	if _u.mutation.LastActivityAtCleared() {
 		_spec.ClearField(instance.FieldLastActivityAt, field.TypeTime)
 	}
+	if value, ok := _u.mutation.SnapshotIds(); ok {
+		_spec.SetField(instance.FieldSnapshotIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedSnapshotIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, instance.FieldSnapshotIds, value)
+		})
+	}
+	if _u.mutation.SnapshotIdsCleared() {
+		_spec.ClearField(instance.FieldSnapshotIds, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.PausedAt(); ok {
+		_spec.SetField(instance.FieldPausedAt, field.TypeTime, value)
+	}
+	if _u.mutation.PausedAtCleared() {
+		_spec.ClearField(instance.FieldPausedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.TeamAccess(); ok {
+		_spec.SetField(instance.FieldTeamAccess, field.TypeEnum, value)
+	}
 	if value, ok := _u.mutation.UpdatedAt(); ok {
 		_spec.SetField(instance.FieldUpdatedAt, field.TypeTime, value)
 	}
@@ -765,6 +1296,80 @@ func (_u *InstanceUpdateOne) sqlSave(ctx context.Context) (_node *Instance, err
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.OrganizationCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   instance.OrganizationTable,
+			Columns: []string{instance.OrganizationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OrganizationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   instance.OrganizationTable,
+			Columns: []string{instance.OrganizationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ConversationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   instance.ConversationsTable,
+			Columns: []string{instance.ConversationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedConversationsIDs(); len(nodes) > 0 && !_u.mutation.ConversationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   instance.ConversationsTable,
+			Columns: []string{instance.ConversationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ConversationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   instance.ConversationsTable,
+			Columns: []string{instance.ConversationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Instance{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,1037 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/job"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// JobUpdate is the builder for updating Job entities.
+type JobUpdate struct {
+	config
+	hooks    []Hook
+	mutation *JobMutation
+}
+
+// Where appends a list predicates to the JobUpdate builder.
+func (_u *JobUpdate) Where(ps ...predicate.Job) *JobUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetType sets the "type" field.
+func (_u *JobUpdate) SetType(v string) *JobUpdate {
+	_u.mutation.SetType(v)
+	return _u
+}
+
+// SetNillableType sets the "type" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableType(v *string) *JobUpdate {
+	if v != nil {
+		_u.SetType(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *JobUpdate) SetStatus(v string) *JobUpdate {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableStatus(v *string) *JobUpdate {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetInstanceID sets the "instance_id" field.
+func (_u *JobUpdate) SetInstanceID(v int) *JobUpdate {
+	_u.mutation.ResetInstanceID()
+	_u.mutation.SetInstanceID(v)
+	return _u
+}
+
+// SetNillableInstanceID sets the "instance_id" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableInstanceID(v *int) *JobUpdate {
+	if v != nil {
+		_u.SetInstanceID(*v)
+	}
+	return _u
+}
+
+// AddInstanceID adds value to the "instance_id" field.
+func (_u *JobUpdate) AddInstanceID(v int) *JobUpdate {
+	_u.mutation.AddInstanceID(v)
+	return _u
+}
+
+// ClearInstanceID clears the value of the "instance_id" field.
+func (_u *JobUpdate) ClearInstanceID() *JobUpdate {
+	_u.mutation.ClearInstanceID()
+	return _u
+}
+
+// SetInstanceName sets the "instance_name" field.
+func (_u *JobUpdate) SetInstanceName(v string) *JobUpdate {
+	_u.mutation.SetInstanceName(v)
+	return _u
+}
+
+// SetNillableInstanceName sets the "instance_name" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableInstanceName(v *string) *JobUpdate {
+	if v != nil {
+		_u.SetInstanceName(*v)
+	}
+	return _u
+}
+
+// ClearInstanceName clears the value of the "instance_name" field.
+func (_u *JobUpdate) ClearInstanceName() *JobUpdate {
+	_u.mutation.ClearInstanceName()
+	return _u
+}
+
+// SetInstanceClass sets the "instance_class" field.
+func (_u *JobUpdate) SetInstanceClass(v string) *JobUpdate {
+	_u.mutation.SetInstanceClass(v)
+	return _u
+}
+
+// SetNillableInstanceClass sets the "instance_class" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableInstanceClass(v *string) *JobUpdate {
+	if v != nil {
+		_u.SetInstanceClass(*v)
+	}
+	return _u
+}
+
+// ClearInstanceClass clears the value of the "instance_class" field.
+func (_u *JobUpdate) ClearInstanceClass() *JobUpdate {
+	_u.mutation.ClearInstanceClass()
+	return _u
+}
+
+// SetTargetID sets the "target_id" field.
+func (_u *JobUpdate) SetTargetID(v int) *JobUpdate {
+	_u.mutation.ResetTargetID()
+	_u.mutation.SetTargetID(v)
+	return _u
+}
+
+// SetNillableTargetID sets the "target_id" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableTargetID(v *int) *JobUpdate {
+	if v != nil {
+		_u.SetTargetID(*v)
+	}
+	return _u
+}
+
+// AddTargetID adds value to the "target_id" field.
+func (_u *JobUpdate) AddTargetID(v int) *JobUpdate {
+	_u.mutation.AddTargetID(v)
+	return _u
+}
+
+// ClearTargetID clears the value of the "target_id" field.
+func (_u *JobUpdate) ClearTargetID() *JobUpdate {
+	_u.mutation.ClearTargetID()
+	return _u
+}
+
+// SetProgress sets the "progress" field.
+func (_u *JobUpdate) SetProgress(v string) *JobUpdate {
+	_u.mutation.SetProgress(v)
+	return _u
+}
+
+// SetNillableProgress sets the "progress" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableProgress(v *string) *JobUpdate {
+	if v != nil {
+		_u.SetProgress(*v)
+	}
+	return _u
+}
+
+// SetError sets the "error" field.
+func (_u *JobUpdate) SetError(v string) *JobUpdate {
+	_u.mutation.SetError(v)
+	return _u
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableError(v *string) *JobUpdate {
+	if v != nil {
+		_u.SetError(*v)
+	}
+	return _u
+}
+
+// ClearError clears the value of the "error" field.
+func (_u *JobUpdate) ClearError() *JobUpdate {
+	_u.mutation.ClearError()
+	return _u
+}
+
+// SetErrorCode sets the "error_code" field.
+func (_u *JobUpdate) SetErrorCode(v string) *JobUpdate {
+	_u.mutation.SetErrorCode(v)
+	return _u
+}
+
+// SetNillableErrorCode sets the "error_code" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableErrorCode(v *string) *JobUpdate {
+	if v != nil {
+		_u.SetErrorCode(*v)
+	}
+	return _u
+}
+
+// ClearErrorCode clears the value of the "error_code" field.
+func (_u *JobUpdate) ClearErrorCode() *JobUpdate {
+	_u.mutation.ClearErrorCode()
+	return _u
+}
+
+// SetAttempts sets the "attempts" field.
+func (_u *JobUpdate) SetAttempts(v int) *JobUpdate {
+	_u.mutation.ResetAttempts()
+	_u.mutation.SetAttempts(v)
+	return _u
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableAttempts(v *int) *JobUpdate {
+	if v != nil {
+		_u.SetAttempts(*v)
+	}
+	return _u
+}
+
+// AddAttempts adds value to the "attempts" field.
+func (_u *JobUpdate) AddAttempts(v int) *JobUpdate {
+	_u.mutation.AddAttempts(v)
+	return _u
+}
+
+// SetMaxAttempts sets the "max_attempts" field.
+func (_u *JobUpdate) SetMaxAttempts(v int) *JobUpdate {
+	_u.mutation.ResetMaxAttempts()
+	_u.mutation.SetMaxAttempts(v)
+	return _u
+}
+
+// SetNillableMaxAttempts sets the "max_attempts" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableMaxAttempts(v *int) *JobUpdate {
+	if v != nil {
+		_u.SetMaxAttempts(*v)
+	}
+	return _u
+}
+
+// AddMaxAttempts adds value to the "max_attempts" field.
+func (_u *JobUpdate) AddMaxAttempts(v int) *JobUpdate {
+	_u.mutation.AddMaxAttempts(v)
+	return _u
+}
+
+// SetRunAt sets the "run_at" field.
+func (_u *JobUpdate) SetRunAt(v time.Time) *JobUpdate {
+	_u.mutation.SetRunAt(v)
+	return _u
+}
+
+// SetNillableRunAt sets the "run_at" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableRunAt(v *time.Time) *JobUpdate {
+	if v != nil {
+		_u.SetRunAt(*v)
+	}
+	return _u
+}
+
+// SetStartedAt sets the "started_at" field.
+func (_u *JobUpdate) SetStartedAt(v time.Time) *JobUpdate {
+	_u.mutation.SetStartedAt(v)
+	return _u
+}
+
+// SetNillableStartedAt sets the "started_at" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableStartedAt(v *time.Time) *JobUpdate {
+	if v != nil {
+		_u.SetStartedAt(*v)
+	}
+	return _u
+}
+
+// ClearStartedAt clears the value of the "started_at" field.
+func (_u *JobUpdate) ClearStartedAt() *JobUpdate {
+	_u.mutation.ClearStartedAt()
+	return _u
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (_u *JobUpdate) SetFinishedAt(v time.Time) *JobUpdate {
+	_u.mutation.SetFinishedAt(v)
+	return _u
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (_u *JobUpdate) SetNillableFinishedAt(v *time.Time) *JobUpdate {
+	if v != nil {
+		_u.SetFinishedAt(*v)
+	}
+	return _u
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (_u *JobUpdate) ClearFinishedAt() *JobUpdate {
+	_u.mutation.ClearFinishedAt()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *JobUpdate) SetUpdatedAt(v time.Time) *JobUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_u *JobUpdate) SetOwnerID(id int) *JobUpdate {
+	_u.mutation.SetOwnerID(id)
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *JobUpdate) SetOwner(v *User) *JobUpdate {
+	return _u.SetOwnerID(v.ID)
+}
+
+// Mutation returns the JobMutation object of the builder.
+func (_u *JobUpdate) Mutation() *JobMutation {
+	return _u.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *JobUpdate) ClearOwner() *JobUpdate {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *JobUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *JobUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *JobUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *JobUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *JobUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := job.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *JobUpdate) check() error {
+	if v, ok := _u.mutation.GetType(); ok {
+		if err := job.TypeValidator(v); err != nil {
+			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "Job.type": %w`, err)}
+		}
+	}
+	if _u.mutation.OwnerCleared() && len(_u.mutation.OwnerIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Job.owner"`)
+	}
+	return nil
+}
+
+func (_u *JobUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(job.Table, job.Columns, sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.GetType(); ok {
+		_spec.SetField(job.FieldType, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(job.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.InstanceID(); ok {
+		_spec.SetField(job.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedInstanceID(); ok {
+		_spec.AddField(job.FieldInstanceID, field.TypeInt, value)
+	}
+	if _u.mutation.InstanceIDCleared() {
+		_spec.ClearField(job.FieldInstanceID, field.TypeInt)
+	}
+	if value, ok := _u.mutation.InstanceName(); ok {
+		_spec.SetField(job.FieldInstanceName, field.TypeString, value)
+	}
+	if _u.mutation.InstanceNameCleared() {
+		_spec.ClearField(job.FieldInstanceName, field.TypeString)
+	}
+	if value, ok := _u.mutation.InstanceClass(); ok {
+		_spec.SetField(job.FieldInstanceClass, field.TypeString, value)
+	}
+	if _u.mutation.InstanceClassCleared() {
+		_spec.ClearField(job.FieldInstanceClass, field.TypeString)
+	}
+	if value, ok := _u.mutation.TargetID(); ok {
+		_spec.SetField(job.FieldTargetID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTargetID(); ok {
+		_spec.AddField(job.FieldTargetID, field.TypeInt, value)
+	}
+	if _u.mutation.TargetIDCleared() {
+		_spec.ClearField(job.FieldTargetID, field.TypeInt)
+	}
+	if value, ok := _u.mutation.Progress(); ok {
+		_spec.SetField(job.FieldProgress, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Error(); ok {
+		_spec.SetField(job.FieldError, field.TypeString, value)
+	}
+	if _u.mutation.ErrorCleared() {
+		_spec.ClearField(job.FieldError, field.TypeString)
+	}
+	if value, ok := _u.mutation.ErrorCode(); ok {
+		_spec.SetField(job.FieldErrorCode, field.TypeString, value)
+	}
+	if _u.mutation.ErrorCodeCleared() {
+		_spec.ClearField(job.FieldErrorCode, field.TypeString)
+	}
+	if value, ok := _u.mutation.Attempts(); ok {
+		_spec.SetField(job.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedAttempts(); ok {
+		_spec.AddField(job.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MaxAttempts(); ok {
+		_spec.SetField(job.FieldMaxAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxAttempts(); ok {
+		_spec.AddField(job.FieldMaxAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.RunAt(); ok {
+		_spec.SetField(job.FieldRunAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.StartedAt(); ok {
+		_spec.SetField(job.FieldStartedAt, field.TypeTime, value)
+	}
+	if _u.mutation.StartedAtCleared() {
+		_spec.ClearField(job.FieldStartedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.FinishedAt(); ok {
+		_spec.SetField(job.FieldFinishedAt, field.TypeTime, value)
+	}
+	if _u.mutation.FinishedAtCleared() {
+		_spec.ClearField(job.FieldFinishedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(job.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   job.OwnerTable,
+			Columns: []string{job.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   job.OwnerTable,
+			Columns: []string{job.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{job.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// JobUpdateOne is the builder for updating a single Job entity.
+type JobUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *JobMutation
+}
+
+// SetType sets the "type" field.
+func (_u *JobUpdateOne) SetType(v string) *JobUpdateOne {
+	_u.mutation.SetType(v)
+	return _u
+}
+
+// SetNillableType sets the "type" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableType(v *string) *JobUpdateOne {
+	if v != nil {
+		_u.SetType(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *JobUpdateOne) SetStatus(v string) *JobUpdateOne {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableStatus(v *string) *JobUpdateOne {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetInstanceID sets the "instance_id" field.
+func (_u *JobUpdateOne) SetInstanceID(v int) *JobUpdateOne {
+	_u.mutation.ResetInstanceID()
+	_u.mutation.SetInstanceID(v)
+	return _u
+}
+
+// SetNillableInstanceID sets the "instance_id" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableInstanceID(v *int) *JobUpdateOne {
+	if v != nil {
+		_u.SetInstanceID(*v)
+	}
+	return _u
+}
+
+// AddInstanceID adds value to the "instance_id" field.
+func (_u *JobUpdateOne) AddInstanceID(v int) *JobUpdateOne {
+	_u.mutation.AddInstanceID(v)
+	return _u
+}
+
+// ClearInstanceID clears the value of the "instance_id" field.
+func (_u *JobUpdateOne) ClearInstanceID() *JobUpdateOne {
+	_u.mutation.ClearInstanceID()
+	return _u
+}
+
+// SetInstanceName sets the "instance_name" field.
+func (_u *JobUpdateOne) SetInstanceName(v string) *JobUpdateOne {
+	_u.mutation.SetInstanceName(v)
+	return _u
+}
+
+// SetNillableInstanceName sets the "instance_name" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableInstanceName(v *string) *JobUpdateOne {
+	if v != nil {
+		_u.SetInstanceName(*v)
+	}
+	return _u
+}
+
+// ClearInstanceName clears the value of the "instance_name" field.
+func (_u *JobUpdateOne) ClearInstanceName() *JobUpdateOne {
+	_u.mutation.ClearInstanceName()
+	return _u
+}
+
+// SetInstanceClass sets the "instance_class" field.
+func (_u *JobUpdateOne) SetInstanceClass(v string) *JobUpdateOne {
+	_u.mutation.SetInstanceClass(v)
+	return _u
+}
+
+// SetNillableInstanceClass sets the "instance_class" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableInstanceClass(v *string) *JobUpdateOne {
+	if v != nil {
+		_u.SetInstanceClass(*v)
+	}
+	return _u
+}
+
+// ClearInstanceClass clears the value of the "instance_class" field.
+func (_u *JobUpdateOne) ClearInstanceClass() *JobUpdateOne {
+	_u.mutation.ClearInstanceClass()
+	return _u
+}
+
+// SetTargetID sets the "target_id" field.
+func (_u *JobUpdateOne) SetTargetID(v int) *JobUpdateOne {
+	_u.mutation.ResetTargetID()
+	_u.mutation.SetTargetID(v)
+	return _u
+}
+
+// SetNillableTargetID sets the "target_id" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableTargetID(v *int) *JobUpdateOne {
+	if v != nil {
+		_u.SetTargetID(*v)
+	}
+	return _u
+}
+
+// AddTargetID adds value to the "target_id" field.
+func (_u *JobUpdateOne) AddTargetID(v int) *JobUpdateOne {
+	_u.mutation.AddTargetID(v)
+	return _u
+}
+
+// ClearTargetID clears the value of the "target_id" field.
+func (_u *JobUpdateOne) ClearTargetID() *JobUpdateOne {
+	_u.mutation.ClearTargetID()
+	return _u
+}
+
+// SetProgress sets the "progress" field.
+func (_u *JobUpdateOne) SetProgress(v string) *JobUpdateOne {
+	_u.mutation.SetProgress(v)
+	return _u
+}
+
+// SetNillableProgress sets the "progress" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableProgress(v *string) *JobUpdateOne {
+	if v != nil {
+		_u.SetProgress(*v)
+	}
+	return _u
+}
+
+// SetError sets the "error" field.
+func (_u *JobUpdateOne) SetError(v string) *JobUpdateOne {
+	_u.mutation.SetError(v)
+	return _u
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableError(v *string) *JobUpdateOne {
+	if v != nil {
+		_u.SetError(*v)
+	}
+	return _u
+}
+
+// ClearError clears the value of the "error" field.
+func (_u *JobUpdateOne) ClearError() *JobUpdateOne {
+	_u.mutation.ClearError()
+	return _u
+}
+
+// SetErrorCode sets the "error_code" field.
+func (_u *JobUpdateOne) SetErrorCode(v string) *JobUpdateOne {
+	_u.mutation.SetErrorCode(v)
+	return _u
+}
+
+// SetNillableErrorCode sets the "error_code" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableErrorCode(v *string) *JobUpdateOne {
+	if v != nil {
+		_u.SetErrorCode(*v)
+	}
+	return _u
+}
+
+// ClearErrorCode clears the value of the "error_code" field.
+func (_u *JobUpdateOne) ClearErrorCode() *JobUpdateOne {
+	_u.mutation.ClearErrorCode()
+	return _u
+}
+
+// SetAttempts sets the "attempts" field.
+func (_u *JobUpdateOne) SetAttempts(v int) *JobUpdateOne {
+	_u.mutation.ResetAttempts()
+	_u.mutation.SetAttempts(v)
+	return _u
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableAttempts(v *int) *JobUpdateOne {
+	if v != nil {
+		_u.SetAttempts(*v)
+	}
+	return _u
+}
+
+// AddAttempts adds value to the "attempts" field.
+func (_u *JobUpdateOne) AddAttempts(v int) *JobUpdateOne {
+	_u.mutation.AddAttempts(v)
+	return _u
+}
+
+// SetMaxAttempts sets the "max_attempts" field.
+func (_u *JobUpdateOne) SetMaxAttempts(v int) *JobUpdateOne {
+	_u.mutation.ResetMaxAttempts()
+	_u.mutation.SetMaxAttempts(v)
+	return _u
+}
+
+// SetNillableMaxAttempts sets the "max_attempts" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableMaxAttempts(v *int) *JobUpdateOne {
+	if v != nil {
+		_u.SetMaxAttempts(*v)
+	}
+	return _u
+}
+
+// AddMaxAttempts adds value to the "max_attempts" field.
+func (_u *JobUpdateOne) AddMaxAttempts(v int) *JobUpdateOne {
+	_u.mutation.AddMaxAttempts(v)
+	return _u
+}
+
+// SetRunAt sets the "run_at" field.
+func (_u *JobUpdateOne) SetRunAt(v time.Time) *JobUpdateOne {
+	_u.mutation.SetRunAt(v)
+	return _u
+}
+
+// SetNillableRunAt sets the "run_at" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableRunAt(v *time.Time) *JobUpdateOne {
+	if v != nil {
+		_u.SetRunAt(*v)
+	}
+	return _u
+}
+
+// SetStartedAt sets the "started_at" field.
+func (_u *JobUpdateOne) SetStartedAt(v time.Time) *JobUpdateOne {
+	_u.mutation.SetStartedAt(v)
+	return _u
+}
+
+// SetNillableStartedAt sets the "started_at" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableStartedAt(v *time.Time) *JobUpdateOne {
+	if v != nil {
+		_u.SetStartedAt(*v)
+	}
+	return _u
+}
+
+// ClearStartedAt clears the value of the "started_at" field.
+func (_u *JobUpdateOne) ClearStartedAt() *JobUpdateOne {
+	_u.mutation.ClearStartedAt()
+	return _u
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (_u *JobUpdateOne) SetFinishedAt(v time.Time) *JobUpdateOne {
+	_u.mutation.SetFinishedAt(v)
+	return _u
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (_u *JobUpdateOne) SetNillableFinishedAt(v *time.Time) *JobUpdateOne {
+	if v != nil {
+		_u.SetFinishedAt(*v)
+	}
+	return _u
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (_u *JobUpdateOne) ClearFinishedAt() *JobUpdateOne {
+	_u.mutation.ClearFinishedAt()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *JobUpdateOne) SetUpdatedAt(v time.Time) *JobUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_u *JobUpdateOne) SetOwnerID(id int) *JobUpdateOne {
+	_u.mutation.SetOwnerID(id)
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *JobUpdateOne) SetOwner(v *User) *JobUpdateOne {
+	return _u.SetOwnerID(v.ID)
+}
+
+// Mutation returns the JobMutation object of the builder.
+func (_u *JobUpdateOne) Mutation() *JobMutation {
+	return _u.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *JobUpdateOne) ClearOwner() *JobUpdateOne {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
+// Where appends a list predicates to the JobUpdate builder.
+func (_u *JobUpdateOne) Where(ps ...predicate.Job) *JobUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *JobUpdateOne) Select(field string, fields ...string) *JobUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Job entity.
+func (_u *JobUpdateOne) Save(ctx context.Context) (*Job, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *JobUpdateOne) SaveX(ctx context.Context) *Job {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *JobUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *JobUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *JobUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := job.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *JobUpdateOne) check() error {
+	if v, ok := _u.mutation.GetType(); ok {
+		if err := job.TypeValidator(v); err != nil {
+			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "Job.type": %w`, err)}
+		}
+	}
+	if _u.mutation.OwnerCleared() && len(_u.mutation.OwnerIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Job.owner"`)
+	}
+	return nil
+}
+
+func (_u *JobUpdateOne) sqlSave(ctx context.Context) (_node *Job, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(job.Table, job.Columns, sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Job.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, job.FieldID)
+		for _, f := range fields {
+			if !job.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != job.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.GetType(); ok {
+		_spec.SetField(job.FieldType, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(job.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.InstanceID(); ok {
+		_spec.SetField(job.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedInstanceID(); ok {
+		_spec.AddField(job.FieldInstanceID, field.TypeInt, value)
+	}
+	if _u.mutation.InstanceIDCleared() {
+		_spec.ClearField(job.FieldInstanceID, field.TypeInt)
+	}
+	if value, ok := _u.mutation.InstanceName(); ok {
+		_spec.SetField(job.FieldInstanceName, field.TypeString, value)
+	}
+	if _u.mutation.InstanceNameCleared() {
+		_spec.ClearField(job.FieldInstanceName, field.TypeString)
+	}
+	if value, ok := _u.mutation.InstanceClass(); ok {
+		_spec.SetField(job.FieldInstanceClass, field.TypeString, value)
+	}
+	if _u.mutation.InstanceClassCleared() {
+		_spec.ClearField(job.FieldInstanceClass, field.TypeString)
+	}
+	if value, ok := _u.mutation.TargetID(); ok {
+		_spec.SetField(job.FieldTargetID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTargetID(); ok {
+		_spec.AddField(job.FieldTargetID, field.TypeInt, value)
+	}
+	if _u.mutation.TargetIDCleared() {
+		_spec.ClearField(job.FieldTargetID, field.TypeInt)
+	}
+	if value, ok := _u.mutation.Progress(); ok {
+		_spec.SetField(job.FieldProgress, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Error(); ok {
+		_spec.SetField(job.FieldError, field.TypeString, value)
+	}
+	if _u.mutation.ErrorCleared() {
+		_spec.ClearField(job.FieldError, field.TypeString)
+	}
+	if value, ok := _u.mutation.ErrorCode(); ok {
+		_spec.SetField(job.FieldErrorCode, field.TypeString, value)
+	}
+	if _u.mutation.ErrorCodeCleared() {
+		_spec.ClearField(job.FieldErrorCode, field.TypeString)
+	}
+	if value, ok := _u.mutation.Attempts(); ok {
+		_spec.SetField(job.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedAttempts(); ok {
+		_spec.AddField(job.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MaxAttempts(); ok {
+		_spec.SetField(job.FieldMaxAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxAttempts(); ok {
+		_spec.AddField(job.FieldMaxAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.RunAt(); ok {
+		_spec.SetField(job.FieldRunAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.StartedAt(); ok {
+		_spec.SetField(job.FieldStartedAt, field.TypeTime, value)
+	}
+	if _u.mutation.StartedAtCleared() {
+		_spec.ClearField(job.FieldStartedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.FinishedAt(); ok {
+		_spec.SetField(job.FieldFinishedAt, field.TypeTime, value)
+	}
+	if _u.mutation.FinishedAtCleared() {
+		_spec.ClearField(job.FieldFinishedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(job.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   job.OwnerTable,
+			Columns: []string{job.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   job.OwnerTable,
+			Columns: []string{job.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Job{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{job.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,177 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/logan/cloudcode/internal/ent/magiclink"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// MagicLink is the model entity for the MagicLink schema.
+type MagicLink struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// JWT ID carried in the link's token
+	Jti string `json:"jti,omitempty"`
+	// SHA-256 of the nonce cookie set on the requesting browser; empty if the link is not bound
+	NonceHash string `json:"-"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the MagicLinkQuery when eager-loading is set.
+	Edges            MagicLinkEdges `json:"edges"`
+	user_magic_links *int
+	selectValues     sql.SelectValues
+}
+
+// MagicLinkEdges holds the relations/edges for other nodes in the graph.
+type MagicLinkEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e MagicLinkEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*MagicLink) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case magiclink.FieldID:
+			values[i] = new(sql.NullInt64)
+		case magiclink.FieldJti, magiclink.FieldNonceHash:
+			values[i] = new(sql.NullString)
+		case magiclink.FieldCreatedAt, magiclink.FieldExpiresAt:
+			values[i] = new(sql.NullTime)
+		case magiclink.ForeignKeys[0]: // user_magic_links
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the MagicLink fields.
+func (_m *MagicLink) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case magiclink.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case magiclink.FieldJti:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field jti", values[i])
+			} else if value.Valid {
+				_m.Jti = value.String
+			}
+		case magiclink.FieldNonceHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field nonce_hash", values[i])
+			} else if value.Valid {
+				_m.NonceHash = value.String
+			}
+		case magiclink.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case magiclink.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				_m.ExpiresAt = value.Time
+			}
+		case magiclink.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_magic_links", value)
+			} else if value.Valid {
+				_m.user_magic_links = new(int)
+				*_m.user_magic_links = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the MagicLink.
+// This includes values selected through modifiers, order, etc.
+func (_m *MagicLink) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the MagicLink entity.
+func (_m *MagicLink) QueryUser() *UserQuery {
+	return NewMagicLinkClient(_m.config).QueryUser(_m)
+}
+
+// Update returns a builder for updating this MagicLink.
+// Note that you need to call MagicLink.Unwrap() before calling this method if this MagicLink
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *MagicLink) Update() *MagicLinkUpdateOne {
+	return NewMagicLinkClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the MagicLink entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *MagicLink) Unwrap() *MagicLink {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: MagicLink is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *MagicLink) String() string {
+	var builder strings.Builder
+	builder.WriteString("MagicLink(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("jti=")
+	builder.WriteString(_m.Jti)
+	builder.WriteString(", ")
+	builder.WriteString("nonce_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(_m.ExpiresAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// MagicLinks is a parsable slice of MagicLink.
+type MagicLinks []*MagicLink
@@ -0,0 +1,113 @@
+// Code generated by ent, DO NOT EDIT.
+
+package magiclink
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the magiclink type in the database.
+	Label = "magic_link"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldJti holds the string denoting the jti field in the database.
+	FieldJti = "jti"
+	// FieldNonceHash holds the string denoting the nonce_hash field in the database.
+	FieldNonceHash = "nonce_hash"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldExpiresAt holds the string denoting the expires_at field in the database.
+	FieldExpiresAt = "expires_at"
+	// EdgeUser holds the string denoting the user edge name in mutations.
+	EdgeUser = "user"
+	// Table holds the table name of the magiclink in the database.
+	Table = "magic_links"
+	// UserTable is the table that holds the user relation/edge.
+	UserTable = "magic_links"
+	// UserInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	UserInverseTable = "users"
+	// UserColumn is the table column denoting the user relation/edge.
+	UserColumn = "user_magic_links"
+)
+
+// Columns holds all SQL columns for magiclink fields.
+var Columns = []string{
+	FieldID,
+	FieldJti,
+	FieldNonceHash,
+	FieldCreatedAt,
+	FieldExpiresAt,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the "magic_links"
+// table and are not defined as standalone fields in the schema.
+var ForeignKeys = []string{
+	"user_magic_links",
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the MagicLink queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByJti orders the results by the jti field.
+func ByJti(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldJti, opts...).ToFunc()
+}
+
+// ByNonceHash orders the results by the nonce_hash field.
+func ByNonceHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNonceHash, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByExpiresAt orders the results by the expires_at field.
+func ByExpiresAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldExpiresAt, opts...).ToFunc()
+}
+
+// ByUserField orders the results by user field.
+func ByUserField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newUserStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newUserStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(UserInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, UserTable, UserColumn),
+	)
+}
@@ -0,0 +1,334 @@
+// Code generated by ent, DO NOT EDIT.
+
+package magiclink
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldLTE(FieldID, id))
+}
+
+// Jti applies equality check predicate on the "jti" field. It's identical to JtiEQ.
+func Jti(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldEQ(FieldJti, v))
+}
+
+// NonceHash applies equality check predicate on the "nonce_hash" field. It's identical to NonceHashEQ.
+func NonceHash(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldEQ(FieldNonceHash, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// ExpiresAt applies equality check predicate on the "expires_at" field. It's identical to ExpiresAtEQ.
+func ExpiresAt(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// JtiEQ applies the EQ predicate on the "jti" field.
+func JtiEQ(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldEQ(FieldJti, v))
+}
+
+// JtiNEQ applies the NEQ predicate on the "jti" field.
+func JtiNEQ(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldNEQ(FieldJti, v))
+}
+
+// JtiIn applies the In predicate on the "jti" field.
+func JtiIn(vs ...string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldIn(FieldJti, vs...))
+}
+
+// JtiNotIn applies the NotIn predicate on the "jti" field.
+func JtiNotIn(vs ...string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldNotIn(FieldJti, vs...))
+}
+
+// JtiGT applies the GT predicate on the "jti" field.
+func JtiGT(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldGT(FieldJti, v))
+}
+
+// JtiGTE applies the GTE predicate on the "jti" field.
+func JtiGTE(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldGTE(FieldJti, v))
+}
+
+// JtiLT applies the LT predicate on the "jti" field.
+func JtiLT(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldLT(FieldJti, v))
+}
+
+// JtiLTE applies the LTE predicate on the "jti" field.
+func JtiLTE(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldLTE(FieldJti, v))
+}
+
+// JtiContains applies the Contains predicate on the "jti" field.
+func JtiContains(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldContains(FieldJti, v))
+}
+
+// JtiHasPrefix applies the HasPrefix predicate on the "jti" field.
+func JtiHasPrefix(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldHasPrefix(FieldJti, v))
+}
+
+// JtiHasSuffix applies the HasSuffix predicate on the "jti" field.
+func JtiHasSuffix(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldHasSuffix(FieldJti, v))
+}
+
+// JtiEqualFold applies the EqualFold predicate on the "jti" field.
+func JtiEqualFold(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldEqualFold(FieldJti, v))
+}
+
+// JtiContainsFold applies the ContainsFold predicate on the "jti" field.
+func JtiContainsFold(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldContainsFold(FieldJti, v))
+}
+
+// NonceHashEQ applies the EQ predicate on the "nonce_hash" field.
+func NonceHashEQ(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldEQ(FieldNonceHash, v))
+}
+
+// NonceHashNEQ applies the NEQ predicate on the "nonce_hash" field.
+func NonceHashNEQ(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldNEQ(FieldNonceHash, v))
+}
+
+// NonceHashIn applies the In predicate on the "nonce_hash" field.
+func NonceHashIn(vs ...string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldIn(FieldNonceHash, vs...))
+}
+
+// NonceHashNotIn applies the NotIn predicate on the "nonce_hash" field.
+func NonceHashNotIn(vs ...string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldNotIn(FieldNonceHash, vs...))
+}
+
+// NonceHashGT applies the GT predicate on the "nonce_hash" field.
+func NonceHashGT(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldGT(FieldNonceHash, v))
+}
+
+// NonceHashGTE applies the GTE predicate on the "nonce_hash" field.
+func NonceHashGTE(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldGTE(FieldNonceHash, v))
+}
+
+// NonceHashLT applies the LT predicate on the "nonce_hash" field.
+func NonceHashLT(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldLT(FieldNonceHash, v))
+}
+
+// NonceHashLTE applies the LTE predicate on the "nonce_hash" field.
+func NonceHashLTE(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldLTE(FieldNonceHash, v))
+}
+
+// NonceHashContains applies the Contains predicate on the "nonce_hash" field.
+func NonceHashContains(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldContains(FieldNonceHash, v))
+}
+
+// NonceHashHasPrefix applies the HasPrefix predicate on the "nonce_hash" field.
+func NonceHashHasPrefix(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldHasPrefix(FieldNonceHash, v))
+}
+
+// NonceHashHasSuffix applies the HasSuffix predicate on the "nonce_hash" field.
+func NonceHashHasSuffix(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldHasSuffix(FieldNonceHash, v))
+}
+
+// NonceHashIsNil applies the IsNil predicate on the "nonce_hash" field.
+func NonceHashIsNil() predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldIsNull(FieldNonceHash))
+}
+
+// NonceHashNotNil applies the NotNil predicate on the "nonce_hash" field.
+func NonceHashNotNil() predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldNotNull(FieldNonceHash))
+}
+
+// NonceHashEqualFold applies the EqualFold predicate on the "nonce_hash" field.
+func NonceHashEqualFold(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldEqualFold(FieldNonceHash, v))
+}
+
+// NonceHashContainsFold applies the ContainsFold predicate on the "nonce_hash" field.
+func NonceHashContainsFold(v string) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldContainsFold(FieldNonceHash, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// ExpiresAtEQ applies the EQ predicate on the "expires_at" field.
+func ExpiresAtEQ(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtNEQ applies the NEQ predicate on the "expires_at" field.
+func ExpiresAtNEQ(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldNEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtIn applies the In predicate on the "expires_at" field.
+func ExpiresAtIn(vs ...time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtNotIn applies the NotIn predicate on the "expires_at" field.
+func ExpiresAtNotIn(vs ...time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldNotIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtGT applies the GT predicate on the "expires_at" field.
+func ExpiresAtGT(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldGT(FieldExpiresAt, v))
+}
+
+// ExpiresAtGTE applies the GTE predicate on the "expires_at" field.
+func ExpiresAtGTE(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldGTE(FieldExpiresAt, v))
+}
+
+// ExpiresAtLT applies the LT predicate on the "expires_at" field.
+func ExpiresAtLT(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldLT(FieldExpiresAt, v))
+}
+
+// ExpiresAtLTE applies the LTE predicate on the "expires_at" field.
+func ExpiresAtLTE(v time.Time) predicate.MagicLink {
+	return predicate.MagicLink(sql.FieldLTE(FieldExpiresAt, v))
+}
+
+// HasUser applies the HasEdge predicate on the "user" edge.
+func HasUser() predicate.MagicLink {
+	return predicate.MagicLink(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, UserTable, UserColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasUserWith applies the HasEdge predicate on the "user" edge with a given conditions (other predicates).
+func HasUserWith(preds ...predicate.User) predicate.MagicLink {
+	return predicate.MagicLink(func(s *sql.Selector) {
+		step := newUserStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.MagicLink) predicate.MagicLink {
+	return predicate.MagicLink(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.MagicLink) predicate.MagicLink {
+	return predicate.MagicLink(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.MagicLink) predicate.MagicLink {
+	return predicate.MagicLink(sql.NotPredicates(p))
+}
@@ -0,0 +1,278 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/magiclink"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// MagicLinkCreate is the builder for creating a MagicLink entity.
+type MagicLinkCreate struct {
+	config
+	mutation *MagicLinkMutation
+	hooks    []Hook
+}
+
+// SetJti sets the "jti" field.
+func (_c *MagicLinkCreate) SetJti(v string) *MagicLinkCreate {
+	_c.mutation.SetJti(v)
+	return _c
+}
+
+// SetNonceHash sets the "nonce_hash" field.
+func (_c *MagicLinkCreate) SetNonceHash(v string) *MagicLinkCreate {
+	_c.mutation.SetNonceHash(v)
+	return _c
+}
+
+// SetNillableNonceHash sets the "nonce_hash" field if the given value is not nil.
+func (_c *MagicLinkCreate) SetNillableNonceHash(v *string) *MagicLinkCreate {
+	if v != nil {
+		_c.SetNonceHash(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *MagicLinkCreate) SetCreatedAt(v time.Time) *MagicLinkCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *MagicLinkCreate) SetNillableCreatedAt(v *time.Time) *MagicLinkCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_c *MagicLinkCreate) SetExpiresAt(v time.Time) *MagicLinkCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_c *MagicLinkCreate) SetUserID(id int) *MagicLinkCreate {
+	_c.mutation.SetUserID(id)
+	return _c
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_c *MagicLinkCreate) SetUser(v *User) *MagicLinkCreate {
+	return _c.SetUserID(v.ID)
+}
+
+// Mutation returns the MagicLinkMutation object of the builder.
+func (_c *MagicLinkCreate) Mutation() *MagicLinkMutation {
+	return _c.mutation
+}
+
+// Save creates the MagicLink in the database.
+func (_c *MagicLinkCreate) Save(ctx context.Context) (*MagicLink, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *MagicLinkCreate) SaveX(ctx context.Context) *MagicLink {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *MagicLinkCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *MagicLinkCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *MagicLinkCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := magiclink.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *MagicLinkCreate) check() error {
+	if _, ok := _c.mutation.Jti(); !ok {
+		return &ValidationError{Name: "jti", err: errors.New(`ent: missing required field "MagicLink.jti"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "MagicLink.created_at"`)}
+	}
+	if _, ok := _c.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "MagicLink.expires_at"`)}
+	}
+	if len(_c.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "MagicLink.user"`)}
+	}
+	return nil
+}
+
+func (_c *MagicLinkCreate) sqlSave(ctx context.Context) (*MagicLink, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *MagicLinkCreate) createSpec() (*MagicLink, *sqlgraph.CreateSpec) {
+	var (
+		_node = &MagicLink{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(magiclink.Table, sqlgraph.NewFieldSpec(magiclink.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Jti(); ok {
+		_spec.SetField(magiclink.FieldJti, field.TypeString, value)
+		_node.Jti = value
+	}
+	if value, ok := _c.mutation.NonceHash(); ok {
+		_spec.SetField(magiclink.FieldNonceHash, field.TypeString, value)
+		_node.NonceHash = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(magiclink.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(magiclink.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   magiclink.UserTable,
+			Columns: []string{magiclink.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_magic_links = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// MagicLinkCreateBulk is the builder for creating many MagicLink entities in bulk.
+type MagicLinkCreateBulk struct {
+	config
+	err      error
+	builders []*MagicLinkCreate
+}
+
+// Save creates the MagicLink entities in the database.
+func (_c *MagicLinkCreateBulk) Save(ctx context.Context) ([]*MagicLink, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*MagicLink, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*MagicLinkMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *MagicLinkCreateBulk) SaveX(ctx context.Context) []*MagicLink {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *MagicLinkCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *MagicLinkCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,377 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/magiclink"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// MagicLinkUpdate is the builder for updating MagicLink entities.
+type MagicLinkUpdate struct {
+	config
+	hooks    []Hook
+	mutation *MagicLinkMutation
+}
+
+// Where appends a list predicates to the MagicLinkUpdate builder.
+func (_u *MagicLinkUpdate) Where(ps ...predicate.MagicLink) *MagicLinkUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetNonceHash sets the "nonce_hash" field.
+func (_u *MagicLinkUpdate) SetNonceHash(v string) *MagicLinkUpdate {
+	_u.mutation.SetNonceHash(v)
+	return _u
+}
+
+// SetNillableNonceHash sets the "nonce_hash" field if the given value is not nil.
+func (_u *MagicLinkUpdate) SetNillableNonceHash(v *string) *MagicLinkUpdate {
+	if v != nil {
+		_u.SetNonceHash(*v)
+	}
+	return _u
+}
+
+// ClearNonceHash clears the value of the "nonce_hash" field.
+func (_u *MagicLinkUpdate) ClearNonceHash() *MagicLinkUpdate {
+	_u.mutation.ClearNonceHash()
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *MagicLinkUpdate) SetExpiresAt(v time.Time) *MagicLinkUpdate {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *MagicLinkUpdate) SetNillableExpiresAt(v *time.Time) *MagicLinkUpdate {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_u *MagicLinkUpdate) SetUserID(id int) *MagicLinkUpdate {
+	_u.mutation.SetUserID(id)
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *MagicLinkUpdate) SetUser(v *User) *MagicLinkUpdate {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the MagicLinkMutation object of the builder.
+func (_u *MagicLinkUpdate) Mutation() *MagicLinkMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *MagicLinkUpdate) ClearUser() *MagicLinkUpdate {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *MagicLinkUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *MagicLinkUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *MagicLinkUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *MagicLinkUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *MagicLinkUpdate) check() error {
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "MagicLink.user"`)
+	}
+	return nil
+}
+
+func (_u *MagicLinkUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(magiclink.Table, magiclink.Columns, sqlgraph.NewFieldSpec(magiclink.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.NonceHash(); ok {
+		_spec.SetField(magiclink.FieldNonceHash, field.TypeString, value)
+	}
+	if _u.mutation.NonceHashCleared() {
+		_spec.ClearField(magiclink.FieldNonceHash, field.TypeString)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(magiclink.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   magiclink.UserTable,
+			Columns: []string{magiclink.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   magiclink.UserTable,
+			Columns: []string{magiclink.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{magiclink.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// MagicLinkUpdateOne is the builder for updating a single MagicLink entity.
+type MagicLinkUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *MagicLinkMutation
+}
+
+// SetNonceHash sets the "nonce_hash" field.
+func (_u *MagicLinkUpdateOne) SetNonceHash(v string) *MagicLinkUpdateOne {
+	_u.mutation.SetNonceHash(v)
+	return _u
+}
+
+// SetNillableNonceHash sets the "nonce_hash" field if the given value is not nil.
+func (_u *MagicLinkUpdateOne) SetNillableNonceHash(v *string) *MagicLinkUpdateOne {
+	if v != nil {
+		_u.SetNonceHash(*v)
+	}
+	return _u
+}
+
+// ClearNonceHash clears the value of the "nonce_hash" field.
+func (_u *MagicLinkUpdateOne) ClearNonceHash() *MagicLinkUpdateOne {
+	_u.mutation.ClearNonceHash()
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *MagicLinkUpdateOne) SetExpiresAt(v time.Time) *MagicLinkUpdateOne {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *MagicLinkUpdateOne) SetNillableExpiresAt(v *time.Time) *MagicLinkUpdateOne {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_u *MagicLinkUpdateOne) SetUserID(id int) *MagicLinkUpdateOne {
+	_u.mutation.SetUserID(id)
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *MagicLinkUpdateOne) SetUser(v *User) *MagicLinkUpdateOne {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the MagicLinkMutation object of the builder.
+func (_u *MagicLinkUpdateOne) Mutation() *MagicLinkMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *MagicLinkUpdateOne) ClearUser() *MagicLinkUpdateOne {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Where appends a list predicates to the MagicLinkUpdate builder.
+func (_u *MagicLinkUpdateOne) Where(ps ...predicate.MagicLink) *MagicLinkUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *MagicLinkUpdateOne) Select(field string, fields ...string) *MagicLinkUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated MagicLink entity.
+func (_u *MagicLinkUpdateOne) Save(ctx context.Context) (*MagicLink, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *MagicLinkUpdateOne) SaveX(ctx context.Context) *MagicLink {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *MagicLinkUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *MagicLinkUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *MagicLinkUpdateOne) check() error {
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "MagicLink.user"`)
+	}
+	return nil
+}
+
+func (_u *MagicLinkUpdateOne) sqlSave(ctx context.Context) (_node *MagicLink, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(magiclink.Table, magiclink.Columns, sqlgraph.NewFieldSpec(magiclink.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "MagicLink.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, magiclink.FieldID)
+		for _, f := range fields {
+			if !magiclink.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != magiclink.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.NonceHash(); ok {
+		_spec.SetField(magiclink.FieldNonceHash, field.TypeString, value)
+	}
+	if _u.mutation.NonceHashCleared() {
+		_spec.ClearField(magiclink.FieldNonceHash, field.TypeString)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(magiclink.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   magiclink.UserTable,
+			Columns: []string{magiclink.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   magiclink.UserTable,
+			Columns: []string{magiclink.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &MagicLink{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{magiclink.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -3,11 +3,125 @@
 package migrate
 
 import (
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/schema/field"
 )
 
 var (
+	// AccessTokensColumns holds the columns for the "access_tokens" table.
+	AccessTokensColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "token_hash", Type: field.TypeString, Unique: true},
+		{Name: "token_prefix", Type: field.TypeString},
+		{Name: "scopes", Type: field.TypeJSON},
+		{Name: "last_used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_access_tokens", Type: field.TypeInt},
+	}
+	// AccessTokensTable holds the schema information for the "access_tokens" table.
+	AccessTokensTable = &schema.Table{
+		Name:       "access_tokens",
+		Columns:    AccessTokensColumns,
+		PrimaryKey: []*schema.Column{AccessTokensColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "access_tokens_users_access_tokens",
+				Columns:    []*schema.Column{AccessTokensColumns[8]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "accesstoken_name_user_access_tokens",
+				Unique:  true,
+				Columns: []*schema.Column{AccessTokensColumns[1], AccessTokensColumns[8]},
+			},
+		},
+	}
+	// AuditEventsColumns holds the columns for the "audit_events" table.
+	AuditEventsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "actor", Type: field.TypeString},
+		{Name: "actor_id", Type: field.TypeInt, Nullable: true},
+		{Name: "action", Type: field.TypeString},
+		{Name: "target_type", Type: field.TypeString, Default: ""},
+		{Name: "target_id", Type: field.TypeString, Default: ""},
+		{Name: "subject_id", Type: field.TypeInt, Nullable: true},
+		{Name: "details", Type: field.TypeJSON, Nullable: true},
+		{Name: "ip", Type: field.TypeString, Default: ""},
+		{Name: "user_agent", Type: field.TypeString, Default: ""},
+		{Name: "request_id", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "prev_hash", Type: field.TypeString, Default: ""},
+		{Name: "hash", Type: field.TypeString},
+	}
+	// AuditEventsTable holds the schema information for the "audit_events" table.
+	AuditEventsTable = &schema.Table{
+		Name:       "audit_events",
+		Columns:    AuditEventsColumns,
+		PrimaryKey: []*schema.Column{AuditEventsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "auditevent_action",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEventsColumns[3]},
+			},
+			{
+				Name:    "auditevent_subject_id",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEventsColumns[6]},
+			},
+			{
+				Name:    "auditevent_actor_id",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEventsColumns[2]},
+			},
+			{
+				Name:    "auditevent_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEventsColumns[11]},
+			},
+		},
+	}
+	// BackupsColumns holds the columns for the "backups" table.
+	BackupsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "instance_id", Type: field.TypeInt},
+		{Name: "volume_id", Type: field.TypeString, Nullable: true},
+		{Name: "key", Type: field.TypeString},
+		{Name: "size_bytes", Type: field.TypeInt64, Default: 0},
+		{Name: "status", Type: field.TypeString, Default: "pending"},
+		{Name: "trigger", Type: field.TypeString, Default: "manual"},
+		{Name: "error", Type: field.TypeString, Nullable: true},
+		{Name: "finished_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_backups", Type: field.TypeInt},
+	}
+	// BackupsTable holds the schema information for the "backups" table.
+	BackupsTable = &schema.Table{
+		Name:       "backups",
+		Columns:    BackupsColumns,
+		PrimaryKey: []*schema.Column{BackupsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "backups_users_backups",
+				Columns:    []*schema.Column{BackupsColumns[10]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "backup_instance_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{BackupsColumns[1], BackupsColumns[9]},
+			},
+		},
+	}
 	// ChatMessagesColumns holds the columns for the "chat_messages" table.
 	ChatMessagesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
@@ -38,6 +152,7 @@ var (
 		{Name: "title", Type: field.TypeString, Default: ""},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "instance_conversations", Type: field.TypeInt, Nullable: true},
 		{Name: "user_conversations", Type: field.TypeInt},
 	}
 	// ConversationsTable holds the schema information for the "conversations" table.
@@ -47,34 +162,120 @@ var (
 		PrimaryKey: []*schema.Column{ConversationsColumns[0]},
 		ForeignKeys: []*schema.ForeignKey{
 			{
-				Symbol:     "conversations_users_conversations",
+				Symbol:     "conversations_instances_conversations",
 				Columns:    []*schema.Column{ConversationsColumns[5]},
+				RefColumns: []*schema.Column{InstancesColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+			{
+				Symbol:     "conversations_users_conversations",
+				Columns:    []*schema.Column{ConversationsColumns[6]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 		},
 		Indexes: []*schema.Index{
 			{
-				Name:    "conversation_project_path_user_conversations",
+				Name:    "conversation_project_path_user_conversations_instance_conversations",
+				Unique:  true,
+				Columns: []*schema.Column{ConversationsColumns[1], ConversationsColumns[6], ConversationsColumns[5]},
+			},
+			{
+				Name:    "conversation_project_path_user_conversations_no_instance",
 				Unique:  true,
-				Columns: []*schema.Column{ConversationsColumns[1], ConversationsColumns[5]},
+				Columns: []*schema.Column{ConversationsColumns[1], ConversationsColumns[6]},
+				Annotation: &entsql.IndexAnnotation{
+					Where: "instance_conversations IS NULL",
+				},
+			},
+		},
+	}
+	// DeviceCodesColumns holds the columns for the "device_codes" table.
+	DeviceCodesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "device_code_hash", Type: field.TypeString, Unique: true},
+		{Name: "user_code", Type: field.TypeString, Unique: true},
+		{Name: "client_id", Type: field.TypeString},
+		{Name: "status", Type: field.TypeString, Default: "pending"},
+		{Name: "interval", Type: field.TypeInt},
+		{Name: "last_polled_at", Type: field.TypeTime, Nullable: true},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_device_codes", Type: field.TypeInt, Nullable: true},
+	}
+	// DeviceCodesTable holds the schema information for the "device_codes" table.
+	DeviceCodesTable = &schema.Table{
+		Name:       "device_codes",
+		Columns:    DeviceCodesColumns,
+		PrimaryKey: []*schema.Column{DeviceCodesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "device_codes_users_device_codes",
+				Columns:    []*schema.Column{DeviceCodesColumns[9]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "devicecode_expires_at",
+				Unique:  false,
+				Columns: []*schema.Column{DeviceCodesColumns[7]},
+			},
+		},
+	}
+	// IdentitiesColumns holds the columns for the "identities" table.
+	IdentitiesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "provider", Type: field.TypeString},
+		{Name: "subject", Type: field.TypeString},
+		{Name: "email", Type: field.TypeString},
+		{Name: "last_login_at", Type: field.TypeTime},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_identities", Type: field.TypeInt},
+	}
+	// IdentitiesTable holds the schema information for the "identities" table.
+	IdentitiesTable = &schema.Table{
+		Name:       "identities",
+		Columns:    IdentitiesColumns,
+		PrimaryKey: []*schema.Column{IdentitiesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "identities_users_identities",
+				Columns:    []*schema.Column{IdentitiesColumns[6]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "identity_provider_subject",
+				Unique:  true,
+				Columns: []*schema.Column{IdentitiesColumns[1], IdentitiesColumns[2]},
 			},
 		},
 	}
 	// InstancesColumns holds the columns for the "instances" table.
 	InstancesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString, Default: "default"},
 		{Name: "provider", Type: field.TypeString},
 		{Name: "provider_id", Type: field.TypeString},
 		{Name: "host", Type: field.TypeString, Nullable: true},
 		{Name: "port", Type: field.TypeInt, Nullable: true},
 		{Name: "status", Type: field.TypeString, Default: "provisioning"},
 		{Name: "volume_id", Type: field.TypeString, Nullable: true},
+		{Name: "class", Type: field.TypeString, Nullable: true},
+		{Name: "template_id", Type: field.TypeInt, Nullable: true},
 		{Name: "netbird_config", Type: field.TypeString, Nullable: true},
 		{Name: "agent_secret", Type: field.TypeString, Nullable: true},
 		{Name: "last_activity_at", Type: field.TypeTime, Nullable: true},
+		{Name: "snapshot_ids", Type: field.TypeJSON, Nullable: true},
+		{Name: "paused_at", Type: field.TypeTime, Nullable: true},
+		{Name: "team_access", Type: field.TypeEnum, Enums: []string{"none", "read", "write"}, Default: "none"},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "organization_id", Type: field.TypeInt, Nullable: true},
 		{Name: "user_instances", Type: field.TypeInt},
 	}
 	// InstancesTable holds the schema information for the "instances" table.
@@ -83,19 +284,334 @@ var (
 		Columns:    InstancesColumns,
 		PrimaryKey: []*schema.Column{InstancesColumns[0]},
 		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "instances_organizations_instances",
+				Columns:    []*schema.Column{InstancesColumns[18]},
+				RefColumns: []*schema.Column{OrganizationsColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
 			{
 				Symbol:     "instances_users_instances",
-				Columns:    []*schema.Column{InstancesColumns[12]},
+				Columns:    []*schema.Column{InstancesColumns[19]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+	}
+	// JobsColumns holds the columns for the "jobs" table.
+	JobsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "type", Type: field.TypeString},
+		{Name: "status", Type: field.TypeString, Default: "pending"},
+		{Name: "instance_id", Type: field.TypeInt, Nullable: true},
+		{Name: "instance_name", Type: field.TypeString, Nullable: true},
+		{Name: "instance_class", Type: field.TypeString, Nullable: true},
+		{Name: "target_id", Type: field.TypeInt, Nullable: true},
+		{Name: "progress", Type: field.TypeString, Default: ""},
+		{Name: "error", Type: field.TypeString, Nullable: true},
+		{Name: "error_code", Type: field.TypeString, Nullable: true},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "max_attempts", Type: field.TypeInt, Default: 3},
+		{Name: "run_at", Type: field.TypeTime},
+		{Name: "started_at", Type: field.TypeTime, Nullable: true},
+		{Name: "finished_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "user_jobs", Type: field.TypeInt},
+	}
+	// JobsTable holds the schema information for the "jobs" table.
+	JobsTable = &schema.Table{
+		Name:       "jobs",
+		Columns:    JobsColumns,
+		PrimaryKey: []*schema.Column{JobsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "jobs_users_jobs",
+				Columns:    []*schema.Column{JobsColumns[17]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "job_status_run_at",
+				Unique:  false,
+				Columns: []*schema.Column{JobsColumns[2], JobsColumns[12]},
+			},
+		},
+	}
+	// MagicLinksColumns holds the columns for the "magic_links" table.
+	MagicLinksColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "jti", Type: field.TypeString, Unique: true},
+		{Name: "nonce_hash", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "user_magic_links", Type: field.TypeInt},
+	}
+	// MagicLinksTable holds the schema information for the "magic_links" table.
+	MagicLinksTable = &schema.Table{
+		Name:       "magic_links",
+		Columns:    MagicLinksColumns,
+		PrimaryKey: []*schema.Column{MagicLinksColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "magic_links_users_magic_links",
+				Columns:    []*schema.Column{MagicLinksColumns[5]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "magiclink_expires_at",
+				Unique:  false,
+				Columns: []*schema.Column{MagicLinksColumns[4]},
+			},
+		},
+	}
+	// MembershipsColumns holds the columns for the "memberships" table.
+	MembershipsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "role", Type: field.TypeEnum, Enums: []string{"owner", "admin", "member", "viewer"}, Default: "member"},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "organization_memberships", Type: field.TypeInt},
+		{Name: "user_memberships", Type: field.TypeInt},
+	}
+	// MembershipsTable holds the schema information for the "memberships" table.
+	MembershipsTable = &schema.Table{
+		Name:       "memberships",
+		Columns:    MembershipsColumns,
+		PrimaryKey: []*schema.Column{MembershipsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "memberships_organizations_memberships",
+				Columns:    []*schema.Column{MembershipsColumns[3]},
+				RefColumns: []*schema.Column{OrganizationsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+			{
+				Symbol:     "memberships_users_memberships",
+				Columns:    []*schema.Column{MembershipsColumns[4]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "membership_user_memberships_organization_memberships",
+				Unique:  true,
+				Columns: []*schema.Column{MembershipsColumns[4], MembershipsColumns[3]},
+			},
+		},
+	}
+	// OrganizationsColumns holds the columns for the "organizations" table.
+	OrganizationsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "stripe_customer_id", Type: field.TypeString, Unique: true, Nullable: true},
+		{Name: "stripe_subscription_id", Type: field.TypeString, Nullable: true},
+		{Name: "subscription_status", Type: field.TypeString, Default: "inactive"},
+		{Name: "plan", Type: field.TypeString, Default: "free"},
+		{Name: "terminal_recording", Type: field.TypeEnum, Enums: []string{"off", "output", "full"}, Default: "off"},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// OrganizationsTable holds the schema information for the "organizations" table.
+	OrganizationsTable = &schema.Table{
+		Name:       "organizations",
+		Columns:    OrganizationsColumns,
+		PrimaryKey: []*schema.Column{OrganizationsColumns[0]},
+	}
+	// RecordingsColumns holds the columns for the "recordings" table.
+	RecordingsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "instance_id", Type: field.TypeInt},
+		{Name: "user_id", Type: field.TypeInt},
+		{Name: "key", Type: field.TypeString},
+		{Name: "input", Type: field.TypeBool, Default: false},
+		{Name: "width", Type: field.TypeInt, Default: 80},
+		{Name: "height", Type: field.TypeInt, Default: 24},
+		{Name: "duration", Type: field.TypeFloat64, Default: 0},
+		{Name: "size_bytes", Type: field.TypeInt64, Default: 0},
+		{Name: "started_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_recordings", Type: field.TypeInt},
+	}
+	// RecordingsTable holds the schema information for the "recordings" table.
+	RecordingsTable = &schema.Table{
+		Name:       "recordings",
+		Columns:    RecordingsColumns,
+		PrimaryKey: []*schema.Column{RecordingsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "recordings_users_recordings",
+				Columns:    []*schema.Column{RecordingsColumns[12]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "recording_instance_id_started_at",
+				Unique:  false,
+				Columns: []*schema.Column{RecordingsColumns[1], RecordingsColumns[9]},
+			},
+			{
+				Name:    "recording_expires_at",
+				Unique:  false,
+				Columns: []*schema.Column{RecordingsColumns[10]},
+			},
+		},
+	}
+	// SecretsColumns holds the columns for the "secrets" table.
+	SecretsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "kind", Type: field.TypeString, Default: "env"},
+		{Name: "path", Type: field.TypeString, Nullable: true},
+		{Name: "key_id", Type: field.TypeString},
+		{Name: "wrapped_key", Type: field.TypeBytes},
+		{Name: "ciphertext", Type: field.TypeBytes},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "user_secrets", Type: field.TypeInt},
+	}
+	// SecretsTable holds the schema information for the "secrets" table.
+	SecretsTable = &schema.Table{
+		Name:       "secrets",
+		Columns:    SecretsColumns,
+		PrimaryKey: []*schema.Column{SecretsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "secrets_users_secrets",
+				Columns:    []*schema.Column{SecretsColumns[9]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "secret_name_user_secrets",
+				Unique:  true,
+				Columns: []*schema.Column{SecretsColumns[1], SecretsColumns[9]},
+			},
+			{
+				Name:    "secret_key_id",
+				Unique:  false,
+				Columns: []*schema.Column{SecretsColumns[4]},
+			},
+		},
+	}
+	// SessionsColumns holds the columns for the "sessions" table.
+	SessionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "sid", Type: field.TypeString, Unique: true},
+		{Name: "refresh_token_hash", Type: field.TypeString},
+		{Name: "previous_refresh_token_hash", Type: field.TypeString, Nullable: true},
+		{Name: "rotated_at", Type: field.TypeTime, Nullable: true},
+		{Name: "user_agent", Type: field.TypeString, Default: ""},
+		{Name: "ip", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "last_used_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "revoked_at", Type: field.TypeTime, Nullable: true},
+		{Name: "user_sessions", Type: field.TypeInt},
+	}
+	// SessionsTable holds the schema information for the "sessions" table.
+	SessionsTable = &schema.Table{
+		Name:       "sessions",
+		Columns:    SessionsColumns,
+		PrimaryKey: []*schema.Column{SessionsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "sessions_users_sessions",
+				Columns:    []*schema.Column{SessionsColumns[11]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "session_expires_at",
+				Unique:  false,
+				Columns: []*schema.Column{SessionsColumns[9]},
+			},
+		},
+	}
+	// TemplatesColumns holds the columns for the "templates" table.
+	TemplatesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "description", Type: field.TypeString, Nullable: true},
+		{Name: "image", Type: field.TypeString, Nullable: true},
+		{Name: "devcontainer", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "setup_script", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "env", Type: field.TypeJSON, Nullable: true},
+		{Name: "repos", Type: field.TypeJSON, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "user_templates", Type: field.TypeInt, Nullable: true},
+	}
+	// TemplatesTable holds the schema information for the "templates" table.
+	TemplatesTable = &schema.Table{
+		Name:       "templates",
+		Columns:    TemplatesColumns,
+		PrimaryKey: []*schema.Column{TemplatesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "templates_users_templates",
+				Columns:    []*schema.Column{TemplatesColumns[10]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "template_name_user_templates",
+				Unique:  true,
+				Columns: []*schema.Column{TemplatesColumns[1], TemplatesColumns[10]},
+			},
+		},
+	}
+	// TerminalSharesColumns holds the columns for the "terminal_shares" table.
+	TerminalSharesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "instance_id", Type: field.TypeInt},
+		{Name: "label", Type: field.TypeString, Nullable: true, Default: ""},
+		{Name: "token_hash", Type: field.TypeString, Unique: true},
+		{Name: "token_prefix", Type: field.TypeString},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "revoked_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_terminal_shares", Type: field.TypeInt},
+	}
+	// TerminalSharesTable holds the schema information for the "terminal_shares" table.
+	TerminalSharesTable = &schema.Table{
+		Name:       "terminal_shares",
+		Columns:    TerminalSharesColumns,
+		PrimaryKey: []*schema.Column{TerminalSharesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "terminal_shares_users_terminal_shares",
+				Columns:    []*schema.Column{TerminalSharesColumns[8]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "terminalshare_instance_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{TerminalSharesColumns[1], TerminalSharesColumns[7]},
+			},
+		},
 	}
 	// UsersColumns holds the columns for the "users" table.
 	UsersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
 		{Name: "email", Type: field.TypeString, Unique: true},
-		{Name: "api_key", Type: field.TypeString, Unique: true, Nullable: true},
 		{Name: "name", Type: field.TypeString, Nullable: true, Default: ""},
 		{Name: "stripe_customer_id", Type: field.TypeString, Unique: true, Nullable: true},
 		{Name: "stripe_subscription_id", Type: field.TypeString, Nullable: true},
@@ -104,6 +620,9 @@ var (
 		{Name: "usage_hours", Type: field.TypeFloat64, Default: 0},
 		{Name: "anthropic_api_key", Type: field.TypeString, Nullable: true},
 		{Name: "claude_oauth_token", Type: field.TypeString, Nullable: true},
+		{Name: "magic_link_failures", Type: field.TypeInt, Default: 0},
+		{Name: "magic_link_failed_at", Type: field.TypeTime, Nullable: true},
+		{Name: "terminal_recording", Type: field.TypeEnum, Enums: []string{"off", "output", "full"}, Default: "off"},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 	}
@@ -113,17 +632,70 @@ var (
 		Columns:    UsersColumns,
 		PrimaryKey: []*schema.Column{UsersColumns[0]},
 	}
+	// WarmInstancesColumns holds the columns for the "warm_instances" table.
+	WarmInstancesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "provider", Type: field.TypeString},
+		{Name: "provider_id", Type: field.TypeString, Unique: true},
+		{Name: "class", Type: field.TypeString, Nullable: true},
+		{Name: "host", Type: field.TypeString, Nullable: true},
+		{Name: "volume_id", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// WarmInstancesTable holds the schema information for the "warm_instances" table.
+	WarmInstancesTable = &schema.Table{
+		Name:       "warm_instances",
+		Columns:    WarmInstancesColumns,
+		PrimaryKey: []*schema.Column{WarmInstancesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "warminstance_class_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{WarmInstancesColumns[3], WarmInstancesColumns[6]},
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		AccessTokensTable,
+		AuditEventsTable,
+		BackupsTable,
 		ChatMessagesTable,
 		ConversationsTable,
+		DeviceCodesTable,
+		IdentitiesTable,
 		InstancesTable,
+		JobsTable,
+		MagicLinksTable,
+		MembershipsTable,
+		OrganizationsTable,
+		RecordingsTable,
+		SecretsTable,
+		SessionsTable,
+		TemplatesTable,
+		TerminalSharesTable,
 		UsersTable,
+		WarmInstancesTable,
 	}
 )
 
 func init() {
+	AccessTokensTable.ForeignKeys[0].RefTable = UsersTable
+	BackupsTable.ForeignKeys[0].RefTable = UsersTable
 	ChatMessagesTable.ForeignKeys[0].RefTable = ConversationsTable
-	ConversationsTable.ForeignKeys[0].RefTable = UsersTable
-	InstancesTable.ForeignKeys[0].RefTable = UsersTable
+	ConversationsTable.ForeignKeys[0].RefTable = InstancesTable
+	ConversationsTable.ForeignKeys[1].RefTable = UsersTable
+	DeviceCodesTable.ForeignKeys[0].RefTable = UsersTable
+	IdentitiesTable.ForeignKeys[0].RefTable = UsersTable
+	InstancesTable.ForeignKeys[0].RefTable = OrganizationsTable
+	InstancesTable.ForeignKeys[1].RefTable = UsersTable
+	JobsTable.ForeignKeys[0].RefTable = UsersTable
+	MagicLinksTable.ForeignKeys[0].RefTable = UsersTable
+	MembershipsTable.ForeignKeys[0].RefTable = OrganizationsTable
+	MembershipsTable.ForeignKeys[1].RefTable = UsersTable
+	RecordingsTable.ForeignKeys[0].RefTable = UsersTable
+	SecretsTable.ForeignKeys[0].RefTable = UsersTable
+	SessionsTable.ForeignKeys[0].RefTable = UsersTable
+	TemplatesTable.ForeignKeys[0].RefTable = UsersTable
+	TerminalSharesTable.ForeignKeys[0].RefTable = UsersTable
 }
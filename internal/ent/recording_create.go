@@ -0,0 +1,434 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/recording"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// RecordingCreate is the builder for creating a Recording entity.
+type RecordingCreate struct {
+	config
+	mutation *RecordingMutation
+	hooks    []Hook
+}
+
+// SetInstanceID sets the "instance_id" field.
+func (_c *RecordingCreate) SetInstanceID(v int) *RecordingCreate {
+	_c.mutation.SetInstanceID(v)
+	return _c
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *RecordingCreate) SetUserID(v int) *RecordingCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetKey sets the "key" field.
+func (_c *RecordingCreate) SetKey(v string) *RecordingCreate {
+	_c.mutation.SetKey(v)
+	return _c
+}
+
+// SetInput sets the "input" field.
+func (_c *RecordingCreate) SetInput(v bool) *RecordingCreate {
+	_c.mutation.SetInput(v)
+	return _c
+}
+
+// SetNillableInput sets the "input" field if the given value is not nil.
+func (_c *RecordingCreate) SetNillableInput(v *bool) *RecordingCreate {
+	if v != nil {
+		_c.SetInput(*v)
+	}
+	return _c
+}
+
+// SetWidth sets the "width" field.
+func (_c *RecordingCreate) SetWidth(v int) *RecordingCreate {
+	_c.mutation.SetWidth(v)
+	return _c
+}
+
+// SetNillableWidth sets the "width" field if the given value is not nil.
+func (_c *RecordingCreate) SetNillableWidth(v *int) *RecordingCreate {
+	if v != nil {
+		_c.SetWidth(*v)
+	}
+	return _c
+}
+
+// SetHeight sets the "height" field.
+func (_c *RecordingCreate) SetHeight(v int) *RecordingCreate {
+	_c.mutation.SetHeight(v)
+	return _c
+}
+
+// SetNillableHeight sets the "height" field if the given value is not nil.
+func (_c *RecordingCreate) SetNillableHeight(v *int) *RecordingCreate {
+	if v != nil {
+		_c.SetHeight(*v)
+	}
+	return _c
+}
+
+// SetDuration sets the "duration" field.
+func (_c *RecordingCreate) SetDuration(v float64) *RecordingCreate {
+	_c.mutation.SetDuration(v)
+	return _c
+}
+
+// SetNillableDuration sets the "duration" field if the given value is not nil.
+func (_c *RecordingCreate) SetNillableDuration(v *float64) *RecordingCreate {
+	if v != nil {
+		_c.SetDuration(*v)
+	}
+	return _c
+}
+
+// SetSizeBytes sets the "size_bytes" field.
+func (_c *RecordingCreate) SetSizeBytes(v int64) *RecordingCreate {
+	_c.mutation.SetSizeBytes(v)
+	return _c
+}
+
+// SetNillableSizeBytes sets the "size_bytes" field if the given value is not nil.
+func (_c *RecordingCreate) SetNillableSizeBytes(v *int64) *RecordingCreate {
+	if v != nil {
+		_c.SetSizeBytes(*v)
+	}
+	return _c
+}
+
+// SetStartedAt sets the "started_at" field.
+func (_c *RecordingCreate) SetStartedAt(v time.Time) *RecordingCreate {
+	_c.mutation.SetStartedAt(v)
+	return _c
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_c *RecordingCreate) SetExpiresAt(v time.Time) *RecordingCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_c *RecordingCreate) SetNillableExpiresAt(v *time.Time) *RecordingCreate {
+	if v != nil {
+		_c.SetExpiresAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *RecordingCreate) SetCreatedAt(v time.Time) *RecordingCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *RecordingCreate) SetNillableCreatedAt(v *time.Time) *RecordingCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_c *RecordingCreate) SetOwnerID(id int) *RecordingCreate {
+	_c.mutation.SetOwnerID(id)
+	return _c
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_c *RecordingCreate) SetOwner(v *User) *RecordingCreate {
+	return _c.SetOwnerID(v.ID)
+}
+
+// Mutation returns the RecordingMutation object of the builder.
+func (_c *RecordingCreate) Mutation() *RecordingMutation {
+	return _c.mutation
+}
+
+// Save creates the Recording in the database.
+func (_c *RecordingCreate) Save(ctx context.Context) (*Recording, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *RecordingCreate) SaveX(ctx context.Context) *Recording {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *RecordingCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *RecordingCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *RecordingCreate) defaults() {
+	if _, ok := _c.mutation.Input(); !ok {
+		v := recording.DefaultInput
+		_c.mutation.SetInput(v)
+	}
+	if _, ok := _c.mutation.Width(); !ok {
+		v := recording.DefaultWidth
+		_c.mutation.SetWidth(v)
+	}
+	if _, ok := _c.mutation.Height(); !ok {
+		v := recording.DefaultHeight
+		_c.mutation.SetHeight(v)
+	}
+	if _, ok := _c.mutation.Duration(); !ok {
+		v := recording.DefaultDuration
+		_c.mutation.SetDuration(v)
+	}
+	if _, ok := _c.mutation.SizeBytes(); !ok {
+		v := recording.DefaultSizeBytes
+		_c.mutation.SetSizeBytes(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := recording.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *RecordingCreate) check() error {
+	if _, ok := _c.mutation.InstanceID(); !ok {
+		return &ValidationError{Name: "instance_id", err: errors.New(`ent: missing required field "Recording.instance_id"`)}
+	}
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "Recording.user_id"`)}
+	}
+	if _, ok := _c.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "Recording.key"`)}
+	}
+	if v, ok := _c.mutation.Key(); ok {
+		if err := recording.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "Recording.key": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Input(); !ok {
+		return &ValidationError{Name: "input", err: errors.New(`ent: missing required field "Recording.input"`)}
+	}
+	if _, ok := _c.mutation.Width(); !ok {
+		return &ValidationError{Name: "width", err: errors.New(`ent: missing required field "Recording.width"`)}
+	}
+	if _, ok := _c.mutation.Height(); !ok {
+		return &ValidationError{Name: "height", err: errors.New(`ent: missing required field "Recording.height"`)}
+	}
+	if _, ok := _c.mutation.Duration(); !ok {
+		return &ValidationError{Name: "duration", err: errors.New(`ent: missing required field "Recording.duration"`)}
+	}
+	if _, ok := _c.mutation.SizeBytes(); !ok {
+		return &ValidationError{Name: "size_bytes", err: errors.New(`ent: missing required field "Recording.size_bytes"`)}
+	}
+	if _, ok := _c.mutation.StartedAt(); !ok {
+		return &ValidationError{Name: "started_at", err: errors.New(`ent: missing required field "Recording.started_at"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Recording.created_at"`)}
+	}
+	if len(_c.mutation.OwnerIDs()) == 0 {
+		return &ValidationError{Name: "owner", err: errors.New(`ent: missing required edge "Recording.owner"`)}
+	}
+	return nil
+}
+
+func (_c *RecordingCreate) sqlSave(ctx context.Context) (*Recording, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *RecordingCreate) createSpec() (*Recording, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Recording{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(recording.Table, sqlgraph.NewFieldSpec(recording.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.InstanceID(); ok {
+		_spec.SetField(recording.FieldInstanceID, field.TypeInt, value)
+		_node.InstanceID = value
+	}
+	if value, ok := _c.mutation.UserID(); ok {
+		_spec.SetField(recording.FieldUserID, field.TypeInt, value)
+		_node.UserID = value
+	}
+	if value, ok := _c.mutation.Key(); ok {
+		_spec.SetField(recording.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := _c.mutation.Input(); ok {
+		_spec.SetField(recording.FieldInput, field.TypeBool, value)
+		_node.Input = value
+	}
+	if value, ok := _c.mutation.Width(); ok {
+		_spec.SetField(recording.FieldWidth, field.TypeInt, value)
+		_node.Width = value
+	}
+	if value, ok := _c.mutation.Height(); ok {
+		_spec.SetField(recording.FieldHeight, field.TypeInt, value)
+		_node.Height = value
+	}
+	if value, ok := _c.mutation.Duration(); ok {
+		_spec.SetField(recording.FieldDuration, field.TypeFloat64, value)
+		_node.Duration = value
+	}
+	if value, ok := _c.mutation.SizeBytes(); ok {
+		_spec.SetField(recording.FieldSizeBytes, field.TypeInt64, value)
+		_node.SizeBytes = value
+	}
+	if value, ok := _c.mutation.StartedAt(); ok {
+		_spec.SetField(recording.FieldStartedAt, field.TypeTime, value)
+		_node.StartedAt = value
+	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(recording.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(recording.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   recording.OwnerTable,
+			Columns: []string{recording.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_recordings = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// RecordingCreateBulk is the builder for creating many Recording entities in bulk.
+type RecordingCreateBulk struct {
+	config
+	err      error
+	builders []*RecordingCreate
+}
+
+// Save creates the Recording entities in the database.
+func (_c *RecordingCreateBulk) Save(ctx context.Context) ([]*Recording, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Recording, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*RecordingMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *RecordingCreateBulk) SaveX(ctx context.Context) []*Recording {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *RecordingCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *RecordingCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
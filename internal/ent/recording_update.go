@@ -0,0 +1,779 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+	"github.com/logan/cloudcode/internal/ent/recording"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// RecordingUpdate is the builder for updating Recording entities.
+type RecordingUpdate struct {
+	config
+	hooks    []Hook
+	mutation *RecordingMutation
+}
+
+// Where appends a list predicates to the RecordingUpdate builder.
+func (_u *RecordingUpdate) Where(ps ...predicate.Recording) *RecordingUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetInstanceID sets the "instance_id" field.
+func (_u *RecordingUpdate) SetInstanceID(v int) *RecordingUpdate {
+	_u.mutation.ResetInstanceID()
+	_u.mutation.SetInstanceID(v)
+	return _u
+}
+
+// SetNillableInstanceID sets the "instance_id" field if the given value is not nil.
+func (_u *RecordingUpdate) SetNillableInstanceID(v *int) *RecordingUpdate {
+	if v != nil {
+		_u.SetInstanceID(*v)
+	}
+	return _u
+}
+
+// AddInstanceID adds value to the "instance_id" field.
+func (_u *RecordingUpdate) AddInstanceID(v int) *RecordingUpdate {
+	_u.mutation.AddInstanceID(v)
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *RecordingUpdate) SetUserID(v int) *RecordingUpdate {
+	_u.mutation.ResetUserID()
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *RecordingUpdate) SetNillableUserID(v *int) *RecordingUpdate {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// AddUserID adds value to the "user_id" field.
+func (_u *RecordingUpdate) AddUserID(v int) *RecordingUpdate {
+	_u.mutation.AddUserID(v)
+	return _u
+}
+
+// SetKey sets the "key" field.
+func (_u *RecordingUpdate) SetKey(v string) *RecordingUpdate {
+	_u.mutation.SetKey(v)
+	return _u
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (_u *RecordingUpdate) SetNillableKey(v *string) *RecordingUpdate {
+	if v != nil {
+		_u.SetKey(*v)
+	}
+	return _u
+}
+
+// SetInput sets the "input" field.
+func (_u *RecordingUpdate) SetInput(v bool) *RecordingUpdate {
+	_u.mutation.SetInput(v)
+	return _u
+}
+
+// SetNillableInput sets the "input" field if the given value is not nil.
+func (_u *RecordingUpdate) SetNillableInput(v *bool) *RecordingUpdate {
+	if v != nil {
+		_u.SetInput(*v)
+	}
+	return _u
+}
+
+// SetWidth sets the "width" field.
+func (_u *RecordingUpdate) SetWidth(v int) *RecordingUpdate {
+	_u.mutation.ResetWidth()
+	_u.mutation.SetWidth(v)
+	return _u
+}
+
+// SetNillableWidth sets the "width" field if the given value is not nil.
+func (_u *RecordingUpdate) SetNillableWidth(v *int) *RecordingUpdate {
+	if v != nil {
+		_u.SetWidth(*v)
+	}
+	return _u
+}
+
+// AddWidth adds value to the "width" field.
+func (_u *RecordingUpdate) AddWidth(v int) *RecordingUpdate {
+	_u.mutation.AddWidth(v)
+	return _u
+}
+
+// SetHeight sets the "height" field.
+func (_u *RecordingUpdate) SetHeight(v int) *RecordingUpdate {
+	_u.mutation.ResetHeight()
+	_u.mutation.SetHeight(v)
+	return _u
+}
+
+// SetNillableHeight sets the "height" field if the given value is not nil.
+func (_u *RecordingUpdate) SetNillableHeight(v *int) *RecordingUpdate {
+	if v != nil {
+		_u.SetHeight(*v)
+	}
+	return _u
+}
+
+// AddHeight adds value to the "height" field.
+func (_u *RecordingUpdate) AddHeight(v int) *RecordingUpdate {
+	_u.mutation.AddHeight(v)
+	return _u
+}
+
+// SetDuration sets the "duration" field.
+func (_u *RecordingUpdate) SetDuration(v float64) *RecordingUpdate {
+	_u.mutation.ResetDuration()
+	_u.mutation.SetDuration(v)
+	return _u
+}
+
+// SetNillableDuration sets the "duration" field if the given value is not nil.
+func (_u *RecordingUpdate) SetNillableDuration(v *float64) *RecordingUpdate {
+	if v != nil {
+		_u.SetDuration(*v)
+	}
+	return _u
+}
+
+// AddDuration adds value to the "duration" field.
+func (_u *RecordingUpdate) AddDuration(v float64) *RecordingUpdate {
+	_u.mutation.AddDuration(v)
+	return _u
+}
+
+// SetSizeBytes sets the "size_bytes" field.
+func (_u *RecordingUpdate) SetSizeBytes(v int64) *RecordingUpdate {
+	_u.mutation.ResetSizeBytes()
+	_u.mutation.SetSizeBytes(v)
+	return _u
+}
+
+// SetNillableSizeBytes sets the "size_bytes" field if the given value is not nil.
+func (_u *RecordingUpdate) SetNillableSizeBytes(v *int64) *RecordingUpdate {
+	if v != nil {
+		_u.SetSizeBytes(*v)
+	}
+	return _u
+}
+
+// AddSizeBytes adds value to the "size_bytes" field.
+func (_u *RecordingUpdate) AddSizeBytes(v int64) *RecordingUpdate {
+	_u.mutation.AddSizeBytes(v)
+	return _u
+}
+
+// SetStartedAt sets the "started_at" field.
+func (_u *RecordingUpdate) SetStartedAt(v time.Time) *RecordingUpdate {
+	_u.mutation.SetStartedAt(v)
+	return _u
+}
+
+// SetNillableStartedAt sets the "started_at" field if the given value is not nil.
+func (_u *RecordingUpdate) SetNillableStartedAt(v *time.Time) *RecordingUpdate {
+	if v != nil {
+		_u.SetStartedAt(*v)
+	}
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *RecordingUpdate) SetExpiresAt(v time.Time) *RecordingUpdate {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *RecordingUpdate) SetNillableExpiresAt(v *time.Time) *RecordingUpdate {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *RecordingUpdate) ClearExpiresAt() *RecordingUpdate {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_u *RecordingUpdate) SetOwnerID(id int) *RecordingUpdate {
+	_u.mutation.SetOwnerID(id)
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *RecordingUpdate) SetOwner(v *User) *RecordingUpdate {
+	return _u.SetOwnerID(v.ID)
+}
+
+// Mutation returns the RecordingMutation object of the builder.
+func (_u *RecordingUpdate) Mutation() *RecordingMutation {
+	return _u.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *RecordingUpdate) ClearOwner() *RecordingUpdate {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *RecordingUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *RecordingUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *RecordingUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *RecordingUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *RecordingUpdate) check() error {
+	if v, ok := _u.mutation.Key(); ok {
+		if err := recording.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "Recording.key": %w`, err)}
+		}
+	}
+	if _u.mutation.OwnerCleared() && len(_u.mutation.OwnerIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Recording.owner"`)
+	}
+	return nil
+}
+
+func (_u *RecordingUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(recording.Table, recording.Columns, sqlgraph.NewFieldSpec(recording.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.InstanceID(); ok {
+		_spec.SetField(recording.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedInstanceID(); ok {
+		_spec.AddField(recording.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.UserID(); ok {
+		_spec.SetField(recording.FieldUserID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedUserID(); ok {
+		_spec.AddField(recording.FieldUserID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Key(); ok {
+		_spec.SetField(recording.FieldKey, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Input(); ok {
+		_spec.SetField(recording.FieldInput, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Width(); ok {
+		_spec.SetField(recording.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedWidth(); ok {
+		_spec.AddField(recording.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Height(); ok {
+		_spec.SetField(recording.FieldHeight, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedHeight(); ok {
+		_spec.AddField(recording.FieldHeight, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Duration(); ok {
+		_spec.SetField(recording.FieldDuration, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedDuration(); ok {
+		_spec.AddField(recording.FieldDuration, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.SizeBytes(); ok {
+		_spec.SetField(recording.FieldSizeBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedSizeBytes(); ok {
+		_spec.AddField(recording.FieldSizeBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.StartedAt(); ok {
+		_spec.SetField(recording.FieldStartedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(recording.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.ExpiresAtCleared() {
+		_spec.ClearField(recording.FieldExpiresAt, field.TypeTime)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   recording.OwnerTable,
+			Columns: []string{recording.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   recording.OwnerTable,
+			Columns: []string{recording.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{recording.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// RecordingUpdateOne is the builder for updating a single Recording entity.
+type RecordingUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *RecordingMutation
+}
+
+// SetInstanceID sets the "instance_id" field.
+func (_u *RecordingUpdateOne) SetInstanceID(v int) *RecordingUpdateOne {
+	_u.mutation.ResetInstanceID()
+	_u.mutation.SetInstanceID(v)
+	return _u
+}
+
+// SetNillableInstanceID sets the "instance_id" field if the given value is not nil.
+func (_u *RecordingUpdateOne) SetNillableInstanceID(v *int) *RecordingUpdateOne {
+	if v != nil {
+		_u.SetInstanceID(*v)
+	}
+	return _u
+}
+
+// AddInstanceID adds value to the "instance_id" field.
+func (_u *RecordingUpdateOne) AddInstanceID(v int) *RecordingUpdateOne {
+	_u.mutation.AddInstanceID(v)
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *RecordingUpdateOne) SetUserID(v int) *RecordingUpdateOne {
+	_u.mutation.ResetUserID()
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *RecordingUpdateOne) SetNillableUserID(v *int) *RecordingUpdateOne {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// AddUserID adds value to the "user_id" field.
+func (_u *RecordingUpdateOne) AddUserID(v int) *RecordingUpdateOne {
+	_u.mutation.AddUserID(v)
+	return _u
+}
+
+// SetKey sets the "key" field.
+func (_u *RecordingUpdateOne) SetKey(v string) *RecordingUpdateOne {
+	_u.mutation.SetKey(v)
+	return _u
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (_u *RecordingUpdateOne) SetNillableKey(v *string) *RecordingUpdateOne {
+	if v != nil {
+		_u.SetKey(*v)
+	}
+	return _u
+}
+
+// SetInput sets the "input" field.
+func (_u *RecordingUpdateOne) SetInput(v bool) *RecordingUpdateOne {
+	_u.mutation.SetInput(v)
+	return _u
+}
+
+// SetNillableInput sets the "input" field if the given value is not nil.
+func (_u *RecordingUpdateOne) SetNillableInput(v *bool) *RecordingUpdateOne {
+	if v != nil {
+		_u.SetInput(*v)
+	}
+	return _u
+}
+
+// SetWidth sets the "width" field.
+func (_u *RecordingUpdateOne) SetWidth(v int) *RecordingUpdateOne {
+	_u.mutation.ResetWidth()
+	_u.mutation.SetWidth(v)
+	return _u
+}
+
+// SetNillableWidth sets the "width" field if the given value is not nil.
+func (_u *RecordingUpdateOne) SetNillableWidth(v *int) *RecordingUpdateOne {
+	if v != nil {
+		_u.SetWidth(*v)
+	}
+	return _u
+}
+
+// AddWidth adds value to the "width" field.
+func (_u *RecordingUpdateOne) AddWidth(v int) *RecordingUpdateOne {
+	_u.mutation.AddWidth(v)
+	return _u
+}
+
+// SetHeight sets the "height" field.
+func (_u *RecordingUpdateOne) SetHeight(v int) *RecordingUpdateOne {
+	_u.mutation.ResetHeight()
+	_u.mutation.SetHeight(v)
+	return _u
+}
+
+// SetNillableHeight sets the "height" field if the given value is not nil.
+func (_u *RecordingUpdateOne) SetNillableHeight(v *int) *RecordingUpdateOne {
+	if v != nil {
+		_u.SetHeight(*v)
+	}
+	return _u
+}
+
+// AddHeight adds value to the "height" field.
+func (_u *RecordingUpdateOne) AddHeight(v int) *RecordingUpdateOne {
+	_u.mutation.AddHeight(v)
+	return _u
+}
+
+// SetDuration sets the "duration" field.
+func (_u *RecordingUpdateOne) SetDuration(v float64) *RecordingUpdateOne {
+	_u.mutation.ResetDuration()
+	_u.mutation.SetDuration(v)
+	return _u
+}
+
+// SetNillableDuration sets the "duration" field if the given value is not nil.
+func (_u *RecordingUpdateOne) SetNillableDuration(v *float64) *RecordingUpdateOne {
+	if v != nil {
+		_u.SetDuration(*v)
+	}
+	return _u
+}
+
+// AddDuration adds value to the "duration" field.
+func (_u *RecordingUpdateOne) AddDuration(v float64) *RecordingUpdateOne {
+	_u.mutation.AddDuration(v)
+	return _u
+}
+
+// SetSizeBytes sets the "size_bytes" field.
+func (_u *RecordingUpdateOne) SetSizeBytes(v int64) *RecordingUpdateOne {
+	_u.mutation.ResetSizeBytes()
+	_u.mutation.SetSizeBytes(v)
+	return _u
+}
+
+// SetNillableSizeBytes sets the "size_bytes" field if the given value is not nil.
+func (_u *RecordingUpdateOne) SetNillableSizeBytes(v *int64) *RecordingUpdateOne {
+	if v != nil {
+		_u.SetSizeBytes(*v)
+	}
+	return _u
+}
+
+// AddSizeBytes adds value to the "size_bytes" field.
+func (_u *RecordingUpdateOne) AddSizeBytes(v int64) *RecordingUpdateOne {
+	_u.mutation.AddSizeBytes(v)
+	return _u
+}
+
+// SetStartedAt sets the "started_at" field.
+func (_u *RecordingUpdateOne) SetStartedAt(v time.Time) *RecordingUpdateOne {
+	_u.mutation.SetStartedAt(v)
+	return _u
+}
+
+// SetNillableStartedAt sets the "started_at" field if the given value is not nil.
+func (_u *RecordingUpdateOne) SetNillableStartedAt(v *time.Time) *RecordingUpdateOne {
+	if v != nil {
+		_u.SetStartedAt(*v)
+	}
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *RecordingUpdateOne) SetExpiresAt(v time.Time) *RecordingUpdateOne {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *RecordingUpdateOne) SetNillableExpiresAt(v *time.Time) *RecordingUpdateOne {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *RecordingUpdateOne) ClearExpiresAt() *RecordingUpdateOne {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_u *RecordingUpdateOne) SetOwnerID(id int) *RecordingUpdateOne {
+	_u.mutation.SetOwnerID(id)
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *RecordingUpdateOne) SetOwner(v *User) *RecordingUpdateOne {
+	return _u.SetOwnerID(v.ID)
+}
+
+// Mutation returns the RecordingMutation object of the builder.
+func (_u *RecordingUpdateOne) Mutation() *RecordingMutation {
+	return _u.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *RecordingUpdateOne) ClearOwner() *RecordingUpdateOne {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
+// Where appends a list predicates to the RecordingUpdate builder.
+func (_u *RecordingUpdateOne) Where(ps ...predicate.Recording) *RecordingUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *RecordingUpdateOne) Select(field string, fields ...string) *RecordingUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Recording entity.
+func (_u *RecordingUpdateOne) Save(ctx context.Context) (*Recording, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *RecordingUpdateOne) SaveX(ctx context.Context) *Recording {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *RecordingUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *RecordingUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *RecordingUpdateOne) check() error {
+	if v, ok := _u.mutation.Key(); ok {
+		if err := recording.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "Recording.key": %w`, err)}
+		}
+	}
+	if _u.mutation.OwnerCleared() && len(_u.mutation.OwnerIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Recording.owner"`)
+	}
+	return nil
+}
+
+func (_u *RecordingUpdateOne) sqlSave(ctx context.Context) (_node *Recording, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(recording.Table, recording.Columns, sqlgraph.NewFieldSpec(recording.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Recording.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, recording.FieldID)
+		for _, f := range fields {
+			if !recording.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != recording.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.InstanceID(); ok {
+		_spec.SetField(recording.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedInstanceID(); ok {
+		_spec.AddField(recording.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.UserID(); ok {
+		_spec.SetField(recording.FieldUserID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedUserID(); ok {
+		_spec.AddField(recording.FieldUserID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Key(); ok {
+		_spec.SetField(recording.FieldKey, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Input(); ok {
+		_spec.SetField(recording.FieldInput, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Width(); ok {
+		_spec.SetField(recording.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedWidth(); ok {
+		_spec.AddField(recording.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Height(); ok {
+		_spec.SetField(recording.FieldHeight, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedHeight(); ok {
+		_spec.AddField(recording.FieldHeight, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Duration(); ok {
+		_spec.SetField(recording.FieldDuration, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedDuration(); ok {
+		_spec.AddField(recording.FieldDuration, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.SizeBytes(); ok {
+		_spec.SetField(recording.FieldSizeBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedSizeBytes(); ok {
+		_spec.AddField(recording.FieldSizeBytes, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.StartedAt(); ok {
+		_spec.SetField(recording.FieldStartedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(recording.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.ExpiresAtCleared() {
+		_spec.ClearField(recording.FieldExpiresAt, field.TypeTime)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   recording.OwnerTable,
+			Columns: []string{recording.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   recording.OwnerTable,
+			Columns: []string{recording.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Recording{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{recording.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
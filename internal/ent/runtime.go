@@ -5,17 +5,100 @@ package ent
 import (
 	"time"
 
+	"github.com/logan/cloudcode/internal/ent/accesstoken"
+	"github.com/logan/cloudcode/internal/ent/auditevent"
+	"github.com/logan/cloudcode/internal/ent/backup"
 	"github.com/logan/cloudcode/internal/ent/chatmessage"
 	"github.com/logan/cloudcode/internal/ent/conversation"
+	"github.com/logan/cloudcode/internal/ent/devicecode"
+	"github.com/logan/cloudcode/internal/ent/identity"
 	"github.com/logan/cloudcode/internal/ent/instance"
+	"github.com/logan/cloudcode/internal/ent/job"
+	"github.com/logan/cloudcode/internal/ent/magiclink"
+	"github.com/logan/cloudcode/internal/ent/membership"
+	"github.com/logan/cloudcode/internal/ent/organization"
+	"github.com/logan/cloudcode/internal/ent/recording"
 	"github.com/logan/cloudcode/internal/ent/schema"
+	"github.com/logan/cloudcode/internal/ent/secret"
+	"github.com/logan/cloudcode/internal/ent/session"
+	"github.com/logan/cloudcode/internal/ent/template"
+	"github.com/logan/cloudcode/internal/ent/terminalshare"
 	"github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/ent/warminstance"
+
+	"entgo.io/ent/schema/field"
 )
 
 // The init function reads all schema descriptors with runtime code
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	accesstokenFields := schema.AccessToken{}.Fields()
+	_ = accesstokenFields
+	// accesstokenDescName is the schema descriptor for name field.
+	accesstokenDescName := accesstokenFields[0].Descriptor()
+	// accesstoken.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	accesstoken.NameValidator = accesstokenDescName.Validators[0].(func(string) error)
+	// accesstokenDescCreatedAt is the schema descriptor for created_at field.
+	accesstokenDescCreatedAt := accesstokenFields[6].Descriptor()
+	// accesstoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	accesstoken.DefaultCreatedAt = accesstokenDescCreatedAt.Default.(func() time.Time)
+	auditeventFields := schema.AuditEvent{}.Fields()
+	_ = auditeventFields
+	// auditeventDescTargetType is the schema descriptor for target_type field.
+	auditeventDescTargetType := auditeventFields[3].Descriptor()
+	// auditevent.DefaultTargetType holds the default value on creation for the target_type field.
+	auditevent.DefaultTargetType = auditeventDescTargetType.Default.(string)
+	// auditeventDescTargetID is the schema descriptor for target_id field.
+	auditeventDescTargetID := auditeventFields[4].Descriptor()
+	// auditevent.DefaultTargetID holds the default value on creation for the target_id field.
+	auditevent.DefaultTargetID = auditeventDescTargetID.Default.(string)
+	// auditeventDescIP is the schema descriptor for ip field.
+	auditeventDescIP := auditeventFields[7].Descriptor()
+	// auditevent.DefaultIP holds the default value on creation for the ip field.
+	auditevent.DefaultIP = auditeventDescIP.Default.(string)
+	// auditeventDescUserAgent is the schema descriptor for user_agent field.
+	auditeventDescUserAgent := auditeventFields[8].Descriptor()
+	// auditevent.DefaultUserAgent holds the default value on creation for the user_agent field.
+	auditevent.DefaultUserAgent = auditeventDescUserAgent.Default.(string)
+	// auditeventDescRequestID is the schema descriptor for request_id field.
+	auditeventDescRequestID := auditeventFields[9].Descriptor()
+	// auditevent.DefaultRequestID holds the default value on creation for the request_id field.
+	auditevent.DefaultRequestID = auditeventDescRequestID.Default.(string)
+	// auditeventDescCreatedAt is the schema descriptor for created_at field.
+	auditeventDescCreatedAt := auditeventFields[10].Descriptor()
+	// auditevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditevent.DefaultCreatedAt = auditeventDescCreatedAt.Default.(func() time.Time)
+	// auditeventDescPrevHash is the schema descriptor for prev_hash field.
+	auditeventDescPrevHash := auditeventFields[11].Descriptor()
+	// auditevent.DefaultPrevHash holds the default value on creation for the prev_hash field.
+	auditevent.DefaultPrevHash = auditeventDescPrevHash.Default.(string)
+	// auditeventDescHash is the schema descriptor for hash field.
+	auditeventDescHash := auditeventFields[12].Descriptor()
+	// auditevent.HashValidator is a validator for the "hash" field. It is called by the builders before save.
+	auditevent.HashValidator = auditeventDescHash.Validators[0].(func(string) error)
+	backupFields := schema.Backup{}.Fields()
+	_ = backupFields
+	// backupDescKey is the schema descriptor for key field.
+	backupDescKey := backupFields[2].Descriptor()
+	// backup.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	backup.KeyValidator = backupDescKey.Validators[0].(func(string) error)
+	// backupDescSizeBytes is the schema descriptor for size_bytes field.
+	backupDescSizeBytes := backupFields[3].Descriptor()
+	// backup.DefaultSizeBytes holds the default value on creation for the size_bytes field.
+	backup.DefaultSizeBytes = backupDescSizeBytes.Default.(int64)
+	// backupDescStatus is the schema descriptor for status field.
+	backupDescStatus := backupFields[4].Descriptor()
+	// backup.DefaultStatus holds the default value on creation for the status field.
+	backup.DefaultStatus = backupDescStatus.Default.(string)
+	// backupDescTrigger is the schema descriptor for trigger field.
+	backupDescTrigger := backupFields[5].Descriptor()
+	// backup.DefaultTrigger holds the default value on creation for the trigger field.
+	backup.DefaultTrigger = backupDescTrigger.Default.(string)
+	// backupDescCreatedAt is the schema descriptor for created_at field.
+	backupDescCreatedAt := backupFields[8].Descriptor()
+	// backup.DefaultCreatedAt holds the default value on creation for the created_at field.
+	backup.DefaultCreatedAt = backupDescCreatedAt.Default.(func() time.Time)
 	chatmessageFields := schema.ChatMessage{}.Fields()
 	_ = chatmessageFields
 	// chatmessageDescContent is the schema descriptor for content field.
@@ -46,30 +129,227 @@ func init() {
 	conversation.DefaultUpdatedAt = conversationDescUpdatedAt.Default.(func() time.Time)
 	// conversation.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
 	conversation.UpdateDefaultUpdatedAt = conversationDescUpdatedAt.UpdateDefault.(func() time.Time)
+	devicecodeFields := schema.DeviceCode{}.Fields()
+	_ = devicecodeFields
+	// devicecodeDescStatus is the schema descriptor for status field.
+	devicecodeDescStatus := devicecodeFields[3].Descriptor()
+	// devicecode.DefaultStatus holds the default value on creation for the status field.
+	devicecode.DefaultStatus = devicecodeDescStatus.Default.(string)
+	// devicecodeDescCreatedAt is the schema descriptor for created_at field.
+	devicecodeDescCreatedAt := devicecodeFields[7].Descriptor()
+	// devicecode.DefaultCreatedAt holds the default value on creation for the created_at field.
+	devicecode.DefaultCreatedAt = devicecodeDescCreatedAt.Default.(func() time.Time)
+	identityFields := schema.Identity{}.Fields()
+	_ = identityFields
+	// identityDescLastLoginAt is the schema descriptor for last_login_at field.
+	identityDescLastLoginAt := identityFields[3].Descriptor()
+	// identity.DefaultLastLoginAt holds the default value on creation for the last_login_at field.
+	identity.DefaultLastLoginAt = identityDescLastLoginAt.Default.(func() time.Time)
+	// identityDescCreatedAt is the schema descriptor for created_at field.
+	identityDescCreatedAt := identityFields[4].Descriptor()
+	// identity.DefaultCreatedAt holds the default value on creation for the created_at field.
+	identity.DefaultCreatedAt = identityDescCreatedAt.Default.(func() time.Time)
 	instanceFields := schema.Instance{}.Fields()
 	_ = instanceFields
+	// instanceDescName is the schema descriptor for name field.
+	instanceDescName := instanceFields[0].Descriptor()
+	// instance.DefaultName holds the default value on creation for the name field.
+	instance.DefaultName = instanceDescName.Default.(string)
 	// instanceDescProvider is the schema descriptor for provider field.
-	instanceDescProvider := instanceFields[0].Descriptor()
+	instanceDescProvider := instanceFields[1].Descriptor()
 	// instance.ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
 	instance.ProviderValidator = instanceDescProvider.Validators[0].(func(string) error)
 	// instanceDescProviderID is the schema descriptor for provider_id field.
-	instanceDescProviderID := instanceFields[1].Descriptor()
+	instanceDescProviderID := instanceFields[2].Descriptor()
 	// instance.ProviderIDValidator is a validator for the "provider_id" field. It is called by the builders before save.
 	instance.ProviderIDValidator = instanceDescProviderID.Validators[0].(func(string) error)
 	// instanceDescStatus is the schema descriptor for status field.
-	instanceDescStatus := instanceFields[4].Descriptor()
+	instanceDescStatus := instanceFields[5].Descriptor()
 	// instance.DefaultStatus holds the default value on creation for the status field.
 	instance.DefaultStatus = instanceDescStatus.Default.(string)
+	// instanceDescAgentSecret is the schema descriptor for agent_secret field.
+	instanceDescAgentSecret := instanceFields[10].Descriptor()
+	instance.ValueScanner.AgentSecret = instanceDescAgentSecret.ValueScanner.(field.TypeValueScanner[string])
 	// instanceDescCreatedAt is the schema descriptor for created_at field.
-	instanceDescCreatedAt := instanceFields[9].Descriptor()
+	instanceDescCreatedAt := instanceFields[16].Descriptor()
 	// instance.DefaultCreatedAt holds the default value on creation for the created_at field.
 	instance.DefaultCreatedAt = instanceDescCreatedAt.Default.(func() time.Time)
 	// instanceDescUpdatedAt is the schema descriptor for updated_at field.
-	instanceDescUpdatedAt := instanceFields[10].Descriptor()
+	instanceDescUpdatedAt := instanceFields[17].Descriptor()
 	// instance.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	instance.DefaultUpdatedAt = instanceDescUpdatedAt.Default.(func() time.Time)
 	// instance.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
 	instance.UpdateDefaultUpdatedAt = instanceDescUpdatedAt.UpdateDefault.(func() time.Time)
+	jobFields := schema.Job{}.Fields()
+	_ = jobFields
+	// jobDescType is the schema descriptor for type field.
+	jobDescType := jobFields[0].Descriptor()
+	// job.TypeValidator is a validator for the "type" field. It is called by the builders before save.
+	job.TypeValidator = jobDescType.Validators[0].(func(string) error)
+	// jobDescStatus is the schema descriptor for status field.
+	jobDescStatus := jobFields[1].Descriptor()
+	// job.DefaultStatus holds the default value on creation for the status field.
+	job.DefaultStatus = jobDescStatus.Default.(string)
+	// jobDescProgress is the schema descriptor for progress field.
+	jobDescProgress := jobFields[6].Descriptor()
+	// job.DefaultProgress holds the default value on creation for the progress field.
+	job.DefaultProgress = jobDescProgress.Default.(string)
+	// jobDescAttempts is the schema descriptor for attempts field.
+	jobDescAttempts := jobFields[9].Descriptor()
+	// job.DefaultAttempts holds the default value on creation for the attempts field.
+	job.DefaultAttempts = jobDescAttempts.Default.(int)
+	// jobDescMaxAttempts is the schema descriptor for max_attempts field.
+	jobDescMaxAttempts := jobFields[10].Descriptor()
+	// job.DefaultMaxAttempts holds the default value on creation for the max_attempts field.
+	job.DefaultMaxAttempts = jobDescMaxAttempts.Default.(int)
+	// jobDescRunAt is the schema descriptor for run_at field.
+	jobDescRunAt := jobFields[11].Descriptor()
+	// job.DefaultRunAt holds the default value on creation for the run_at field.
+	job.DefaultRunAt = jobDescRunAt.Default.(func() time.Time)
+	// jobDescCreatedAt is the schema descriptor for created_at field.
+	jobDescCreatedAt := jobFields[14].Descriptor()
+	// job.DefaultCreatedAt holds the default value on creation for the created_at field.
+	job.DefaultCreatedAt = jobDescCreatedAt.Default.(func() time.Time)
+	// jobDescUpdatedAt is the schema descriptor for updated_at field.
+	jobDescUpdatedAt := jobFields[15].Descriptor()
+	// job.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	job.DefaultUpdatedAt = jobDescUpdatedAt.Default.(func() time.Time)
+	// job.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	job.UpdateDefaultUpdatedAt = jobDescUpdatedAt.UpdateDefault.(func() time.Time)
+	magiclinkFields := schema.MagicLink{}.Fields()
+	_ = magiclinkFields
+	// magiclinkDescCreatedAt is the schema descriptor for created_at field.
+	magiclinkDescCreatedAt := magiclinkFields[2].Descriptor()
+	// magiclink.DefaultCreatedAt holds the default value on creation for the created_at field.
+	magiclink.DefaultCreatedAt = magiclinkDescCreatedAt.Default.(func() time.Time)
+	membershipFields := schema.Membership{}.Fields()
+	_ = membershipFields
+	// membershipDescCreatedAt is the schema descriptor for created_at field.
+	membershipDescCreatedAt := membershipFields[1].Descriptor()
+	// membership.DefaultCreatedAt holds the default value on creation for the created_at field.
+	membership.DefaultCreatedAt = membershipDescCreatedAt.Default.(func() time.Time)
+	organizationFields := schema.Organization{}.Fields()
+	_ = organizationFields
+	// organizationDescName is the schema descriptor for name field.
+	organizationDescName := organizationFields[0].Descriptor()
+	// organization.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	organization.NameValidator = organizationDescName.Validators[0].(func(string) error)
+	// organizationDescSubscriptionStatus is the schema descriptor for subscription_status field.
+	organizationDescSubscriptionStatus := organizationFields[3].Descriptor()
+	// organization.DefaultSubscriptionStatus holds the default value on creation for the subscription_status field.
+	organization.DefaultSubscriptionStatus = organizationDescSubscriptionStatus.Default.(string)
+	// organizationDescPlan is the schema descriptor for plan field.
+	organizationDescPlan := organizationFields[4].Descriptor()
+	// organization.DefaultPlan holds the default value on creation for the plan field.
+	organization.DefaultPlan = organizationDescPlan.Default.(string)
+	// organizationDescCreatedAt is the schema descriptor for created_at field.
+	organizationDescCreatedAt := organizationFields[6].Descriptor()
+	// organization.DefaultCreatedAt holds the default value on creation for the created_at field.
+	organization.DefaultCreatedAt = organizationDescCreatedAt.Default.(func() time.Time)
+	// organizationDescUpdatedAt is the schema descriptor for updated_at field.
+	organizationDescUpdatedAt := organizationFields[7].Descriptor()
+	// organization.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	organization.DefaultUpdatedAt = organizationDescUpdatedAt.Default.(func() time.Time)
+	// organization.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	organization.UpdateDefaultUpdatedAt = organizationDescUpdatedAt.UpdateDefault.(func() time.Time)
+	recordingFields := schema.Recording{}.Fields()
+	_ = recordingFields
+	// recordingDescKey is the schema descriptor for key field.
+	recordingDescKey := recordingFields[2].Descriptor()
+	// recording.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	recording.KeyValidator = recordingDescKey.Validators[0].(func(string) error)
+	// recordingDescInput is the schema descriptor for input field.
+	recordingDescInput := recordingFields[3].Descriptor()
+	// recording.DefaultInput holds the default value on creation for the input field.
+	recording.DefaultInput = recordingDescInput.Default.(bool)
+	// recordingDescWidth is the schema descriptor for width field.
+	recordingDescWidth := recordingFields[4].Descriptor()
+	// recording.DefaultWidth holds the default value on creation for the width field.
+	recording.DefaultWidth = recordingDescWidth.Default.(int)
+	// recordingDescHeight is the schema descriptor for height field.
+	recordingDescHeight := recordingFields[5].Descriptor()
+	// recording.DefaultHeight holds the default value on creation for the height field.
+	recording.DefaultHeight = recordingDescHeight.Default.(int)
+	// recordingDescDuration is the schema descriptor for duration field.
+	recordingDescDuration := recordingFields[6].Descriptor()
+	// recording.DefaultDuration holds the default value on creation for the duration field.
+	recording.DefaultDuration = recordingDescDuration.Default.(float64)
+	// recordingDescSizeBytes is the schema descriptor for size_bytes field.
+	recordingDescSizeBytes := recordingFields[7].Descriptor()
+	// recording.DefaultSizeBytes holds the default value on creation for the size_bytes field.
+	recording.DefaultSizeBytes = recordingDescSizeBytes.Default.(int64)
+	// recordingDescCreatedAt is the schema descriptor for created_at field.
+	recordingDescCreatedAt := recordingFields[10].Descriptor()
+	// recording.DefaultCreatedAt holds the default value on creation for the created_at field.
+	recording.DefaultCreatedAt = recordingDescCreatedAt.Default.(func() time.Time)
+	secretFields := schema.Secret{}.Fields()
+	_ = secretFields
+	// secretDescName is the schema descriptor for name field.
+	secretDescName := secretFields[0].Descriptor()
+	// secret.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	secret.NameValidator = secretDescName.Validators[0].(func(string) error)
+	// secretDescKind is the schema descriptor for kind field.
+	secretDescKind := secretFields[1].Descriptor()
+	// secret.DefaultKind holds the default value on creation for the kind field.
+	secret.DefaultKind = secretDescKind.Default.(string)
+	// secretDescKeyID is the schema descriptor for key_id field.
+	secretDescKeyID := secretFields[3].Descriptor()
+	// secret.KeyIDValidator is a validator for the "key_id" field. It is called by the builders before save.
+	secret.KeyIDValidator = secretDescKeyID.Validators[0].(func(string) error)
+	// secretDescCreatedAt is the schema descriptor for created_at field.
+	secretDescCreatedAt := secretFields[6].Descriptor()
+	// secret.DefaultCreatedAt holds the default value on creation for the created_at field.
+	secret.DefaultCreatedAt = secretDescCreatedAt.Default.(func() time.Time)
+	// secretDescUpdatedAt is the schema descriptor for updated_at field.
+	secretDescUpdatedAt := secretFields[7].Descriptor()
+	// secret.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	secret.DefaultUpdatedAt = secretDescUpdatedAt.Default.(func() time.Time)
+	// secret.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	secret.UpdateDefaultUpdatedAt = secretDescUpdatedAt.UpdateDefault.(func() time.Time)
+	sessionFields := schema.Session{}.Fields()
+	_ = sessionFields
+	// sessionDescUserAgent is the schema descriptor for user_agent field.
+	sessionDescUserAgent := sessionFields[4].Descriptor()
+	// session.DefaultUserAgent holds the default value on creation for the user_agent field.
+	session.DefaultUserAgent = sessionDescUserAgent.Default.(string)
+	// sessionDescIP is the schema descriptor for ip field.
+	sessionDescIP := sessionFields[5].Descriptor()
+	// session.DefaultIP holds the default value on creation for the ip field.
+	session.DefaultIP = sessionDescIP.Default.(string)
+	// sessionDescCreatedAt is the schema descriptor for created_at field.
+	sessionDescCreatedAt := sessionFields[6].Descriptor()
+	// session.DefaultCreatedAt holds the default value on creation for the created_at field.
+	session.DefaultCreatedAt = sessionDescCreatedAt.Default.(func() time.Time)
+	// sessionDescLastUsedAt is the schema descriptor for last_used_at field.
+	sessionDescLastUsedAt := sessionFields[7].Descriptor()
+	// session.DefaultLastUsedAt holds the default value on creation for the last_used_at field.
+	session.DefaultLastUsedAt = sessionDescLastUsedAt.Default.(func() time.Time)
+	templateFields := schema.Template{}.Fields()
+	_ = templateFields
+	// templateDescName is the schema descriptor for name field.
+	templateDescName := templateFields[0].Descriptor()
+	// template.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	template.NameValidator = templateDescName.Validators[0].(func(string) error)
+	// templateDescCreatedAt is the schema descriptor for created_at field.
+	templateDescCreatedAt := templateFields[7].Descriptor()
+	// template.DefaultCreatedAt holds the default value on creation for the created_at field.
+	template.DefaultCreatedAt = templateDescCreatedAt.Default.(func() time.Time)
+	// templateDescUpdatedAt is the schema descriptor for updated_at field.
+	templateDescUpdatedAt := templateFields[8].Descriptor()
+	// template.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	template.DefaultUpdatedAt = templateDescUpdatedAt.Default.(func() time.Time)
+	// template.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	template.UpdateDefaultUpdatedAt = templateDescUpdatedAt.UpdateDefault.(func() time.Time)
+	terminalshareFields := schema.TerminalShare{}.Fields()
+	_ = terminalshareFields
+	// terminalshareDescLabel is the schema descriptor for label field.
+	terminalshareDescLabel := terminalshareFields[1].Descriptor()
+	// terminalshare.DefaultLabel holds the default value on creation for the label field.
+	terminalshare.DefaultLabel = terminalshareDescLabel.Default.(string)
+	// terminalshareDescCreatedAt is the schema descriptor for created_at field.
+	terminalshareDescCreatedAt := terminalshareFields[6].Descriptor()
+	// terminalshare.DefaultCreatedAt holds the default value on creation for the created_at field.
+	terminalshare.DefaultCreatedAt = terminalshareDescCreatedAt.Default.(func() time.Time)
 	userFields := schema.User{}.Fields()
 	_ = userFields
 	// userDescEmail is the schema descriptor for email field.
@@ -77,29 +357,53 @@ func init() {
 	// user.EmailValidator is a validator for the "email" field. It is called by the builders before save.
 	user.EmailValidator = userDescEmail.Validators[0].(func(string) error)
 	// userDescName is the schema descriptor for name field.
-	userDescName := userFields[2].Descriptor()
+	userDescName := userFields[1].Descriptor()
 	// user.DefaultName holds the default value on creation for the name field.
 	user.DefaultName = userDescName.Default.(string)
 	// userDescSubscriptionStatus is the schema descriptor for subscription_status field.
-	userDescSubscriptionStatus := userFields[5].Descriptor()
+	userDescSubscriptionStatus := userFields[4].Descriptor()
 	// user.DefaultSubscriptionStatus holds the default value on creation for the subscription_status field.
 	user.DefaultSubscriptionStatus = userDescSubscriptionStatus.Default.(string)
 	// userDescPlan is the schema descriptor for plan field.
-	userDescPlan := userFields[6].Descriptor()
+	userDescPlan := userFields[5].Descriptor()
 	// user.DefaultPlan holds the default value on creation for the plan field.
 	user.DefaultPlan = userDescPlan.Default.(string)
 	// userDescUsageHours is the schema descriptor for usage_hours field.
-	userDescUsageHours := userFields[7].Descriptor()
+	userDescUsageHours := userFields[6].Descriptor()
 	// user.DefaultUsageHours holds the default value on creation for the usage_hours field.
 	user.DefaultUsageHours = userDescUsageHours.Default.(float64)
+	// userDescAnthropicAPIKey is the schema descriptor for anthropic_api_key field.
+	userDescAnthropicAPIKey := userFields[7].Descriptor()
+	user.ValueScanner.AnthropicAPIKey = userDescAnthropicAPIKey.ValueScanner.(field.TypeValueScanner[string])
+	// userDescClaudeOauthToken is the schema descriptor for claude_oauth_token field.
+	userDescClaudeOauthToken := userFields[8].Descriptor()
+	user.ValueScanner.ClaudeOauthToken = userDescClaudeOauthToken.ValueScanner.(field.TypeValueScanner[string])
+	// userDescMagicLinkFailures is the schema descriptor for magic_link_failures field.
+	userDescMagicLinkFailures := userFields[9].Descriptor()
+	// user.DefaultMagicLinkFailures holds the default value on creation for the magic_link_failures field.
+	user.DefaultMagicLinkFailures = userDescMagicLinkFailures.Default.(int)
 	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[10].Descriptor()
+	userDescCreatedAt := userFields[12].Descriptor()
 	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
 	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
 	// userDescUpdatedAt is the schema descriptor for updated_at field.
-	userDescUpdatedAt := userFields[11].Descriptor()
+	userDescUpdatedAt := userFields[13].Descriptor()
 	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
 	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
 	user.UpdateDefaultUpdatedAt = userDescUpdatedAt.UpdateDefault.(func() time.Time)
+	warminstanceFields := schema.WarmInstance{}.Fields()
+	_ = warminstanceFields
+	// warminstanceDescProvider is the schema descriptor for provider field.
+	warminstanceDescProvider := warminstanceFields[0].Descriptor()
+	// warminstance.ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	warminstance.ProviderValidator = warminstanceDescProvider.Validators[0].(func(string) error)
+	// warminstanceDescProviderID is the schema descriptor for provider_id field.
+	warminstanceDescProviderID := warminstanceFields[1].Descriptor()
+	// warminstance.ProviderIDValidator is a validator for the "provider_id" field. It is called by the builders before save.
+	warminstance.ProviderIDValidator = warminstanceDescProviderID.Validators[0].(func(string) error)
+	// warminstanceDescCreatedAt is the schema descriptor for created_at field.
+	warminstanceDescCreatedAt := warminstanceFields[5].Descriptor()
+	// warminstance.DefaultCreatedAt holds the default value on creation for the created_at field.
+	warminstance.DefaultCreatedAt = warminstanceDescCreatedAt.Default.(func() time.Time)
 }
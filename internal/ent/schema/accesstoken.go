@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AccessToken holds the schema definition for the AccessToken entity.
+// Only the hash of a personal access token is stored; the token itself is
+// shown once, when it is created.
+type AccessToken struct {
+	ent.Schema
+}
+
+// Fields of the AccessToken.
+func (AccessToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty(),
+		field.String("token_hash").
+			Unique().
+			Sensitive().
+			Comment("SHA-256 of the token"),
+		field.String("token_prefix").
+			Comment("Leading characters of the token, for recognising it in listings"),
+		field.Strings("scopes"),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+		field.Time("expires_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the AccessToken.
+func (AccessToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("access_tokens").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the AccessToken.
+func (AccessToken) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("name").
+			Edges("owner").
+			Unique(),
+	}
+}
@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AuditEvent holds the schema definition for the AuditEvent entity: one
+// security-relevant action. Users and targets are plain IDs rather than
+// edges so the record outlives whatever it refers to. Each event's hash
+// covers the previous event's, so editing or deleting a row breaks the
+// chain from that point on.
+type AuditEvent struct {
+	ent.Schema
+}
+
+// Fields of the AuditEvent.
+func (AuditEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("actor").
+			Immutable().
+			Comment("Who acted: admin (the API key), user or system"),
+		field.Int("actor_id").
+			Optional().
+			Nillable().
+			Immutable().
+			Comment("Acting user, if any"),
+		field.String("action").
+			Immutable().
+			Comment("Dotted action name, e.g. admin.instance.destroy"),
+		field.String("target_type").
+			Default("").
+			Immutable(),
+		field.String("target_id").
+			Default("").
+			Immutable(),
+		field.Int("subject_id").
+			Optional().
+			Nillable().
+			Immutable().
+			Comment("User the action concerns, such as an instance's owner"),
+		field.JSON("details", map[string]any{}).
+			Optional().
+			Immutable(),
+		field.String("ip").
+			Default("").
+			Immutable(),
+		field.String("user_agent").
+			Default("").
+			Immutable(),
+		field.String("request_id").
+			Default("").
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.String("prev_hash").
+			Default("").
+			Immutable().
+			Comment("Hash of the event before this one; empty for the first"),
+		field.String("hash").
+			NotEmpty().
+			Immutable().
+			Comment("Hex SHA-256 of prev_hash and this event's fields"),
+	}
+}
+
+// Indexes of the AuditEvent.
+func (AuditEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("action"),
+		index.Fields("subject_id"),
+		index.Fields("actor_id"),
+		index.Fields("created_at"),
+	}
+}
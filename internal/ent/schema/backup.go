@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Backup holds the schema definition for the Backup entity.
+type Backup struct {
+	ent.Schema
+}
+
+// Fields of the Backup.
+func (Backup) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("instance_id").
+			Comment("Instance whose volume was archived"),
+		field.String("volume_id").
+			Optional().
+			Comment("Provider volume the archive was taken from"),
+		field.String("key").
+			NotEmpty().
+			Comment("Object store key of the gzipped tar archive"),
+		field.Int64("size_bytes").
+			Default(0),
+		field.String("status").
+			Default("pending").
+			Comment("pending, succeeded or failed"),
+		field.String("trigger").
+			Default("manual").
+			Comment("manual or scheduled"),
+		field.String("error").
+			Optional(),
+		field.Time("finished_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Backup.
+func (Backup) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("backups").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Backup.
+func (Backup) Indexes() []ent.Index {
+	return []ent.Index{
+		// Listing and retention pruning per instance, newest first
+		index.Fields("instance_id", "created_at"),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
@@ -40,15 +41,27 @@ func (Conversation) Edges() []ent.Edge {
 			Unique().
 			Required(),
 		edge.To("messages", ChatMessage.Type),
+		// Set for a conversation held on an instance; members the
+		// instance is shared with can then follow it
+		edge.From("instance", Instance.Type).
+			Ref("conversations").
+			Unique(),
 	}
 }
 
 // Indexes of the Conversation.
 func (Conversation) Indexes() []ent.Index {
 	return []ent.Index{
-		// One conversation per project per user
-		index.Edges("owner").
+		// One conversation per project per user and instance
+		index.Edges("owner", "instance").
 			Fields("project_path").
 			Unique(),
+		// NULLs are distinct in the index above, so conversations held on
+		// no instance need one of their own
+		index.Edges("owner").
+			Fields("project_path").
+			Unique().
+			StorageKey("conversation_project_path_user_conversations_no_instance").
+			Annotations(entsql.IndexWhere("instance_conversations IS NULL")),
 	}
 }
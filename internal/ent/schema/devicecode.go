@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// DeviceCode holds the schema definition for the DeviceCode entity: a pending
+// OAuth 2.0 device authorization (RFC 8628). Rows are deleted once the device
+// has collected its token, or after they expire.
+type DeviceCode struct {
+	ent.Schema
+}
+
+// Fields of the DeviceCode.
+func (DeviceCode) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("device_code_hash").
+			Unique().
+			Sensitive().
+			Comment("SHA-256 of the device code the client polls with"),
+		field.String("user_code").
+			Unique().
+			Comment("Short code the user confirms in the browser, e.g. WDJB-MJHT"),
+		field.String("client_id"),
+		field.String("status").
+			Default("pending").
+			Comment("pending, approved or denied"),
+		field.Int("interval").
+			Comment("Minimum seconds between polls; raised on slow_down"),
+		field.Time("last_polled_at").
+			Optional().
+			Nillable(),
+		field.Time("expires_at"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the DeviceCode.
+func (DeviceCode) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("device_codes").
+			Unique().
+			Comment("The user who approved or denied the device"),
+	}
+}
+
+// Indexes of the DeviceCode.
+func (DeviceCode) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("expires_at"),
+	}
+}
@@ -0,0 +1,27 @@
+package schema
+
+import (
+	"database/sql"
+	"database/sql/driver"
+
+	"entgo.io/ent/schema/field"
+
+	"github.com/logan/cloudcode/internal/vault"
+)
+
+// encryptedString is the codec for string columns encrypted at rest with the
+// vault field keyring. column names the column in the ciphertext. Values
+// written before encryption was enabled are read back as they are.
+func encryptedString(column string) field.TypeValueScanner[string] {
+	return field.ValueScannerFunc[string, *sql.NullString]{
+		V: func(s string) (driver.Value, error) {
+			return vault.SealField(column, s)
+		},
+		S: func(ns *sql.NullString) (string, error) {
+			if !ns.Valid {
+				return "", nil
+			}
+			return vault.OpenField(column, ns.String)
+		},
+	}
+}
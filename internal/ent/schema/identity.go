@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Identity holds the schema definition for the Identity entity: a user's
+// account at a single sign-on provider. After the first login links it by
+// verified email, the provider's subject identifies the user even if their
+// email address changes.
+type Identity struct {
+	ent.Schema
+}
+
+// Fields of the Identity.
+func (Identity) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("provider").
+			Comment("Configured provider name, e.g. google"),
+		field.String("subject").
+			Comment("Stable user identifier at the provider"),
+		field.String("email").
+			Comment("Email address the provider last asserted"),
+		field.Time("last_login_at").
+			Default(time.Now),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Identity.
+func (Identity) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("identities").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Identity.
+func (Identity) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("provider", "subject").
+			Unique(),
+	}
+}
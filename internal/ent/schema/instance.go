@@ -16,6 +16,9 @@ type Instance struct {
 // Fields of the Instance.
 func (Instance) Fields() []ent.Field {
 	return []ent.Field{
+		field.String("name").
+			Default("default").
+			Comment("User-chosen instance name, unique among the owner's live instances"),
 		field.String("provider").
 			NotEmpty(),
 		field.String("provider_id").
@@ -28,17 +31,40 @@ func (Instance) Fields() []ent.Field {
 			Default("provisioning"),
 		field.String("volume_id").
 			Optional(),
+		field.String("class").
+			Optional().
+			Comment("Instance class name; empty for instances created before classes existed"),
+		field.Int("template_id").
+			Optional().
+			Nillable().
+			Comment("Template the instance was created from; applied on first boot only"),
 		field.String("netbird_config").
 			Optional().
 			Comment("JSON-encoded Netbird config (group ID, route ID, policy ID, setup key ID)"),
 		field.String("agent_secret").
 			Optional().
 			Sensitive().
+			ValueScanner(encryptedString("instances.agent_secret")).
 			Comment("Per-instance secret for agent authentication"),
 		field.Time("last_activity_at").
 			Optional().
 			Nillable().
 			Comment("Last detected activity timestamp for idle detection"),
+		field.Strings("snapshot_ids").
+			Optional().
+			Comment("Provider snapshot IDs taken on Pause, oldest first (snapshot-capable providers only)"),
+		field.Time("paused_at").
+			Optional().
+			Nillable().
+			Comment("When the instance was last paused; cleared on Wake"),
+		field.Int("organization_id").
+			Optional().
+			Nillable().
+			Comment("Organization the instance is shared with, if any"),
+		field.Enum("team_access").
+			Values("none", "read", "write").
+			Default("none").
+			Comment("What organization members get on the terminal and chat; admins can always manage"),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
@@ -55,5 +81,10 @@ func (Instance) Edges() []ent.Edge {
 			Ref("instances").
 			Unique().
 			Required(),
+		edge.From("organization", Organization.Type).
+			Ref("instances").
+			Field("organization_id").
+			Unique(),
+		edge.To("conversations", Conversation.Type),
 	}
 }
@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Job holds the schema definition for the Job entity.
+type Job struct {
+	ent.Schema
+}
+
+// Fields of the Job.
+func (Job) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("type").
+			NotEmpty().
+			Comment("Operation to run: create, pause, wake, destroy, resize, backup or restore"),
+		field.String("status").
+			Default("pending").
+			Comment("pending, running, succeeded or failed"),
+		field.Int("instance_id").
+			Optional().
+			Nillable().
+			Comment("Target instance; set on create once the instance row exists"),
+		field.String("instance_name").
+			Optional().
+			Comment("Name of the instance a create job provisions; empty means default"),
+		field.String("instance_class").
+			Optional().
+			Comment("Class requested by create and resize jobs"),
+		field.Int("target_id").
+			Optional().
+			Nillable().
+			Comment("Secondary target, e.g. the backup for backup and restore jobs or the template for create"),
+		field.String("progress").
+			Default("").
+			Comment("Last step reported by the running operation"),
+		field.String("error").
+			Optional().
+			Comment("Error from the most recent failed attempt"),
+		field.String("error_code").
+			Optional().
+			Comment("Machine-readable cause of the most recent failed attempt, e.g. invalid_state; empty if unclassified"),
+		field.Int("attempts").
+			Default(0),
+		field.Int("max_attempts").
+			Default(3),
+		field.Time("run_at").
+			Default(time.Now).
+			Comment("Earliest time the job may be picked up (used for retry backoff)"),
+		field.Time("started_at").
+			Optional().
+			Nillable(),
+		field.Time("finished_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Job.
+func (Job) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("jobs").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Job.
+func (Job) Indexes() []ent.Index {
+	return []ent.Index{
+		// Worker poll: next pending job by run_at
+		index.Fields("status", "run_at"),
+	}
+}
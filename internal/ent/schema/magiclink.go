@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// MagicLink holds the schema definition for the MagicLink entity: a sign-in
+// link that has been emailed and not yet used. The link's JWT names the row,
+// which is deleted when the link is used or a newer one is sent, so each link
+// works once.
+type MagicLink struct {
+	ent.Schema
+}
+
+// Fields of the MagicLink.
+func (MagicLink) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("jti").
+			Unique().
+			Immutable().
+			Comment("JWT ID carried in the link's token"),
+		field.String("nonce_hash").
+			Optional().
+			Sensitive().
+			Comment("SHA-256 of the nonce cookie set on the requesting browser; empty if the link is not bound"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("expires_at"),
+	}
+}
+
+// Edges of the MagicLink.
+func (MagicLink) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("magic_links").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the MagicLink.
+func (MagicLink) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("expires_at"),
+	}
+}
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Organization holds the schema definition for the Organization entity: a
+// team whose members share instances and, optionally, a subscription.
+type Organization struct {
+	ent.Schema
+}
+
+// Fields of the Organization.
+func (Organization) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty(),
+		field.String("stripe_customer_id").
+			Unique().
+			Optional().
+			Nillable(),
+		field.String("stripe_subscription_id").
+			Optional().
+			Nillable(),
+		field.String("subscription_status").
+			Default("inactive"),
+		field.String("plan").
+			Default("free").
+			Comment("Plan paid by the organization; members get it if it beats their own"),
+		field.Enum("terminal_recording").
+			Values("off", "output", "full").
+			Default("off").
+			Comment("Recording of terminals on shared instances; the stricter of this and the owner's applies"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Organization.
+func (Organization) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("memberships", Membership.Type),
+		edge.To("instances", Instance.Type),
+	}
+}
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Recording holds the schema definition for the Recording entity: one
+// terminal session stored as an asciicast v2 file.
+type Recording struct {
+	ent.Schema
+}
+
+// Fields of the Recording.
+func (Recording) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("instance_id").
+			Comment("Instance whose terminal was recorded"),
+		field.Int("user_id").
+			Comment("User who had the terminal open; the owner edge is the instance owner"),
+		field.String("key").
+			NotEmpty().
+			Comment("Object store key of the asciicast file"),
+		field.Bool("input").
+			Default(false).
+			Comment("Whether keystrokes were recorded as well as output"),
+		field.Int("width").
+			Default(80),
+		field.Int("height").
+			Default(24),
+		field.Float("duration").
+			Default(0).
+			Comment("Seconds from the first to the last event"),
+		field.Int64("size_bytes").
+			Default(0),
+		field.Time("started_at"),
+		field.Time("expires_at").
+			Optional().
+			Nillable().
+			Comment("When retention deletes the recording; nil keeps it"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Recording.
+func (Recording) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("recordings").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Recording.
+func (Recording) Indexes() []ent.Index {
+	return []ent.Index{
+		// Listing per instance, newest first
+		index.Fields("instance_id", "started_at"),
+		// Retention sweep
+		index.Fields("expires_at"),
+	}
+}
@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Secret holds the schema definition for the Secret entity.
+// Values are envelope-encrypted (see internal/vault); only the owner's
+// instances ever see the plaintext.
+type Secret struct {
+	ent.Schema
+}
+
+// Fields of the Secret.
+func (Secret) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty().
+			Comment("Environment variable name; also identifies file secrets"),
+		field.String("kind").
+			Default("env").
+			Comment("env (exported to shells) or file (written under the home directory)"),
+		field.String("path").
+			Optional().
+			Comment("File secrets: path relative to /home/claude"),
+		field.String("key_id").
+			NotEmpty().
+			Comment("Master key that wraps the data key"),
+		field.Bytes("wrapped_key").
+			Sensitive(),
+		field.Bytes("ciphertext").
+			Sensitive(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Secret.
+func (Secret) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("secrets").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Secret.
+func (Secret) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("name").
+			Edges("owner").
+			Unique(),
+		// Rewrapping after a master key rotation
+		index.Fields("key_id"),
+	}
+}
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Session holds the schema definition for the Session entity: one signed-in
+// browser or device. Access tokens are short-lived JWTs naming the session;
+// the session's refresh token rotates on every use, and presenting an old one
+// revokes the session.
+type Session struct {
+	ent.Schema
+}
+
+// Fields of the Session.
+func (Session) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("sid").
+			Unique().
+			Immutable().
+			Comment("Random public identifier carried in access and refresh tokens"),
+		field.String("refresh_token_hash").
+			Sensitive().
+			Comment("SHA-256 of the current refresh token secret"),
+		field.String("previous_refresh_token_hash").
+			Optional().
+			Sensitive().
+			Comment("SHA-256 of the refresh token secret the last refresh replaced"),
+		field.Time("rotated_at").
+			Optional().
+			Nillable(),
+		field.String("user_agent").
+			Default(""),
+		field.String("ip").
+			Default(""),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("last_used_at").
+			Default(time.Now),
+		field.Time("expires_at").
+			Comment("When the refresh token stops working; pushed out on each refresh"),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the Session.
+func (Session) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("sessions").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Session.
+func (Session) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("expires_at"),
+	}
+}
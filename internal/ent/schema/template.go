@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Template holds the schema definition for the Template entity.
+// A template customizes the environment new instances boot with. Templates
+// without an owner are global and offered to every user.
+type Template struct {
+	ent.Schema
+}
+
+// Fields of the Template.
+func (Template) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty(),
+		field.String("description").
+			Optional(),
+		field.String("image").
+			Optional().
+			Comment("Container image built on claude-instance; overrides the devcontainer image"),
+		field.Text("devcontainer").
+			Optional().
+			Comment("devcontainer.json supplying an image, environment and lifecycle commands"),
+		field.Text("setup_script").
+			Optional().
+			Comment("Bash run in /claude-data on first boot, after the devcontainer commands"),
+		field.JSON("env", map[string]string{}).
+			Optional().
+			Comment("Environment variables; override the devcontainer's"),
+		field.Strings("repos").
+			Optional().
+			Comment("Git URLs cloned into /claude-data on first boot"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Template.
+func (Template) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("templates").
+			Unique(),
+	}
+}
+
+// Indexes of the Template.
+func (Template) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("name").
+			Edges("owner").
+			Unique(),
+	}
+}
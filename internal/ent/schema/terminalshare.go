@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// TerminalShare holds the schema definition for the TerminalShare entity: a
+// link that lets anyone holding it watch an instance's terminal, read-only,
+// until it expires or is revoked. Only the hash of the token is stored.
+type TerminalShare struct {
+	ent.Schema
+}
+
+// Fields of the TerminalShare.
+func (TerminalShare) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("instance_id").
+			Comment("Instance whose terminal can be watched"),
+		field.String("label").
+			Optional().
+			Default("").
+			Comment("Shown to the other people in the session, e.g. who the link is for"),
+		field.String("token_hash").
+			Unique().
+			Sensitive().
+			Comment("SHA-256 of the token"),
+		field.String("token_prefix").
+			Comment("Leading characters of the token, for recognising it in listings"),
+		field.Time("expires_at"),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the TerminalShare.
+func (TerminalShare) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("creator", User.Type).
+			Ref("terminal_shares").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the TerminalShare.
+func (TerminalShare) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("instance_id", "created_at"),
+	}
+}
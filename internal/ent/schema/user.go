@@ -19,10 +19,6 @@ func (User) Fields() []ent.Field {
 		field.String("email").
 			Unique().
 			NotEmpty(),
-		field.String("api_key").
-			Unique().
-			Optional().
-			Nillable(),
 		field.String("name").
 			Optional().
 			Default(""),
@@ -41,14 +37,24 @@ func (User) Fields() []ent.Field {
 			Default(0),
 		field.String("anthropic_api_key").
 			Optional().
-			Nillable().
 			Sensitive().
+			ValueScanner(encryptedString("users.anthropic_api_key")).
 			Comment("User's Anthropic API key for Claude Code (API pay-as-you-go billing)"),
 		field.String("claude_oauth_token").
 			Optional().
-			Nillable().
 			Sensitive().
+			ValueScanner(encryptedString("users.claude_oauth_token")).
 			Comment("User's Claude.ai OAuth token for Claude Code (Pro/Max subscription billing)"),
+		field.Int("magic_link_failures").
+			Default(0).
+			Comment("Failed magic link sign-ins since magic_link_failed_at's window began"),
+		field.Time("magic_link_failed_at").
+			Optional().
+			Nillable(),
+		field.Enum("terminal_recording").
+			Values("off", "output", "full").
+			Default("off").
+			Comment("Record terminal sessions on the user's instances: output only, or output and input"),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
@@ -63,5 +69,17 @@ func (User) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("instances", Instance.Type),
 		edge.To("conversations", Conversation.Type),
+		edge.To("jobs", Job.Type),
+		edge.To("backups", Backup.Type),
+		edge.To("templates", Template.Type),
+		edge.To("secrets", Secret.Type),
+		edge.To("access_tokens", AccessToken.Type),
+		edge.To("device_codes", DeviceCode.Type),
+		edge.To("identities", Identity.Type),
+		edge.To("memberships", Membership.Type),
+		edge.To("sessions", Session.Type),
+		edge.To("magic_links", MagicLink.Type),
+		edge.To("recordings", Recording.Type),
+		edge.To("terminal_shares", TerminalShare.Type),
 	}
 }
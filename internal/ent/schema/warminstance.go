@@ -0,0 +1,45 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// WarmInstance holds the schema definition for the WarmInstance entity.
+// A warm instance is booted ahead of demand and not yet bound to a user;
+// claiming it deletes the row.
+type WarmInstance struct {
+	ent.Schema
+}
+
+// Fields of the WarmInstance.
+func (WarmInstance) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("provider").
+			NotEmpty(),
+		field.String("provider_id").
+			Unique().
+			NotEmpty(),
+		field.String("class").
+			Optional().
+			Comment("Instance class the warm instance was booted with"),
+		field.String("host").
+			Optional(),
+		field.String("volume_id").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the WarmInstance.
+func (WarmInstance) Indexes() []ent.Index {
+	return []ent.Index{
+		// Claiming takes the oldest warm instance of a class
+		index.Fields("class", "created_at"),
+	}
+}
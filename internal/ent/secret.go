@@ -0,0 +1,222 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/logan/cloudcode/internal/ent/secret"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// Secret is the model entity for the Secret schema.
+type Secret struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Environment variable name; also identifies file secrets
+	Name string `json:"name,omitempty"`
+	// env (exported to shells) or file (written under the home directory)
+	Kind string `json:"kind,omitempty"`
+	// File secrets: path relative to /home/claude
+	Path string `json:"path,omitempty"`
+	// Master key that wraps the data key
+	KeyID string `json:"key_id,omitempty"`
+	// WrappedKey holds the value of the "wrapped_key" field.
+	WrappedKey []byte `json:"-"`
+	// Ciphertext holds the value of the "ciphertext" field.
+	Ciphertext []byte `json:"-"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the SecretQuery when eager-loading is set.
+	Edges        SecretEdges `json:"edges"`
+	user_secrets *int
+	selectValues sql.SelectValues
+}
+
+// SecretEdges holds the relations/edges for other nodes in the graph.
+type SecretEdges struct {
+	// Owner holds the value of the owner edge.
+	Owner *User `json:"owner,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// OwnerOrErr returns the Owner value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e SecretEdges) OwnerOrErr() (*User, error) {
+	if e.Owner != nil {
+		return e.Owner, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "owner"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Secret) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case secret.FieldWrappedKey, secret.FieldCiphertext:
+			values[i] = new([]byte)
+		case secret.FieldID:
+			values[i] = new(sql.NullInt64)
+		case secret.FieldName, secret.FieldKind, secret.FieldPath, secret.FieldKeyID:
+			values[i] = new(sql.NullString)
+		case secret.FieldCreatedAt, secret.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case secret.ForeignKeys[0]: // user_secrets
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Secret fields.
+func (_m *Secret) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case secret.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case secret.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case secret.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				_m.Kind = value.String
+			}
+		case secret.FieldPath:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field path", values[i])
+			} else if value.Valid {
+				_m.Path = value.String
+			}
+		case secret.FieldKeyID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key_id", values[i])
+			} else if value.Valid {
+				_m.KeyID = value.String
+			}
+		case secret.FieldWrappedKey:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field wrapped_key", values[i])
+			} else if value != nil {
+				_m.WrappedKey = *value
+			}
+		case secret.FieldCiphertext:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field ciphertext", values[i])
+			} else if value != nil {
+				_m.Ciphertext = *value
+			}
+		case secret.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case secret.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		case secret.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_secrets", value)
+			} else if value.Valid {
+				_m.user_secrets = new(int)
+				*_m.user_secrets = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Secret.
+// This includes values selected through modifiers, order, etc.
+func (_m *Secret) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryOwner queries the "owner" edge of the Secret entity.
+func (_m *Secret) QueryOwner() *UserQuery {
+	return NewSecretClient(_m.config).QueryOwner(_m)
+}
+
+// Update returns a builder for updating this Secret.
+// Note that you need to call Secret.Unwrap() before calling this method if this Secret
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Secret) Update() *SecretUpdateOne {
+	return NewSecretClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Secret entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Secret) Unwrap() *Secret {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Secret is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Secret) String() string {
+	var builder strings.Builder
+	builder.WriteString("Secret(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("kind=")
+	builder.WriteString(_m.Kind)
+	builder.WriteString(", ")
+	builder.WriteString("path=")
+	builder.WriteString(_m.Path)
+	builder.WriteString(", ")
+	builder.WriteString("key_id=")
+	builder.WriteString(_m.KeyID)
+	builder.WriteString(", ")
+	builder.WriteString("wrapped_key=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("ciphertext=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Secrets is a parsable slice of Secret.
+type Secrets []*Secret
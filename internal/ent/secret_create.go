@@ -0,0 +1,364 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/secret"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// SecretCreate is the builder for creating a Secret entity.
+type SecretCreate struct {
+	config
+	mutation *SecretMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (_c *SecretCreate) SetName(v string) *SecretCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetKind sets the "kind" field.
+func (_c *SecretCreate) SetKind(v string) *SecretCreate {
+	_c.mutation.SetKind(v)
+	return _c
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (_c *SecretCreate) SetNillableKind(v *string) *SecretCreate {
+	if v != nil {
+		_c.SetKind(*v)
+	}
+	return _c
+}
+
+// SetPath sets the "path" field.
+func (_c *SecretCreate) SetPath(v string) *SecretCreate {
+	_c.mutation.SetPath(v)
+	return _c
+}
+
+// SetNillablePath sets the "path" field if the given value is not nil.
+func (_c *SecretCreate) SetNillablePath(v *string) *SecretCreate {
+	if v != nil {
+		_c.SetPath(*v)
+	}
+	return _c
+}
+
+// SetKeyID sets the "key_id" field.
+func (_c *SecretCreate) SetKeyID(v string) *SecretCreate {
+	_c.mutation.SetKeyID(v)
+	return _c
+}
+
+// SetWrappedKey sets the "wrapped_key" field.
+func (_c *SecretCreate) SetWrappedKey(v []byte) *SecretCreate {
+	_c.mutation.SetWrappedKey(v)
+	return _c
+}
+
+// SetCiphertext sets the "ciphertext" field.
+func (_c *SecretCreate) SetCiphertext(v []byte) *SecretCreate {
+	_c.mutation.SetCiphertext(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *SecretCreate) SetCreatedAt(v time.Time) *SecretCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *SecretCreate) SetNillableCreatedAt(v *time.Time) *SecretCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *SecretCreate) SetUpdatedAt(v time.Time) *SecretCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *SecretCreate) SetNillableUpdatedAt(v *time.Time) *SecretCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_c *SecretCreate) SetOwnerID(id int) *SecretCreate {
+	_c.mutation.SetOwnerID(id)
+	return _c
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_c *SecretCreate) SetOwner(v *User) *SecretCreate {
+	return _c.SetOwnerID(v.ID)
+}
+
+// Mutation returns the SecretMutation object of the builder.
+func (_c *SecretCreate) Mutation() *SecretMutation {
+	return _c.mutation
+}
+
+// Save creates the Secret in the database.
+func (_c *SecretCreate) Save(ctx context.Context) (*Secret, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *SecretCreate) SaveX(ctx context.Context) *Secret {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SecretCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SecretCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *SecretCreate) defaults() {
+	if _, ok := _c.mutation.Kind(); !ok {
+		v := secret.DefaultKind
+		_c.mutation.SetKind(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := secret.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := secret.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *SecretCreate) check() error {
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Secret.name"`)}
+	}
+	if v, ok := _c.mutation.Name(); ok {
+		if err := secret.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Secret.name": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "Secret.kind"`)}
+	}
+	if _, ok := _c.mutation.KeyID(); !ok {
+		return &ValidationError{Name: "key_id", err: errors.New(`ent: missing required field "Secret.key_id"`)}
+	}
+	if v, ok := _c.mutation.KeyID(); ok {
+		if err := secret.KeyIDValidator(v); err != nil {
+			return &ValidationError{Name: "key_id", err: fmt.Errorf(`ent: validator failed for field "Secret.key_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.WrappedKey(); !ok {
+		return &ValidationError{Name: "wrapped_key", err: errors.New(`ent: missing required field "Secret.wrapped_key"`)}
+	}
+	if _, ok := _c.mutation.Ciphertext(); !ok {
+		return &ValidationError{Name: "ciphertext", err: errors.New(`ent: missing required field "Secret.ciphertext"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Secret.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Secret.updated_at"`)}
+	}
+	if len(_c.mutation.OwnerIDs()) == 0 {
+		return &ValidationError{Name: "owner", err: errors.New(`ent: missing required edge "Secret.owner"`)}
+	}
+	return nil
+}
+
+func (_c *SecretCreate) sqlSave(ctx context.Context) (*Secret, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *SecretCreate) createSpec() (*Secret, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Secret{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(secret.Table, sqlgraph.NewFieldSpec(secret.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(secret.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.Kind(); ok {
+		_spec.SetField(secret.FieldKind, field.TypeString, value)
+		_node.Kind = value
+	}
+	if value, ok := _c.mutation.Path(); ok {
+		_spec.SetField(secret.FieldPath, field.TypeString, value)
+		_node.Path = value
+	}
+	if value, ok := _c.mutation.KeyID(); ok {
+		_spec.SetField(secret.FieldKeyID, field.TypeString, value)
+		_node.KeyID = value
+	}
+	if value, ok := _c.mutation.WrappedKey(); ok {
+		_spec.SetField(secret.FieldWrappedKey, field.TypeBytes, value)
+		_node.WrappedKey = value
+	}
+	if value, ok := _c.mutation.Ciphertext(); ok {
+		_spec.SetField(secret.FieldCiphertext, field.TypeBytes, value)
+		_node.Ciphertext = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(secret.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(secret.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if nodes := _c.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   secret.OwnerTable,
+			Columns: []string{secret.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_secrets = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// SecretCreateBulk is the builder for creating many Secret entities in bulk.
+type SecretCreateBulk struct {
+	config
+	err      error
+	builders []*SecretCreate
+}
+
+// Save creates the Secret entities in the database.
+func (_c *SecretCreateBulk) Save(ctx context.Context) ([]*Secret, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Secret, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SecretMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *SecretCreateBulk) SaveX(ctx context.Context) []*Secret {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SecretCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SecretCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
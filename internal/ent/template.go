@@ -0,0 +1,240 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/logan/cloudcode/internal/ent/template"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// Template is the model entity for the Template schema.
+type Template struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Description holds the value of the "description" field.
+	Description string `json:"description,omitempty"`
+	// Container image built on claude-instance; overrides the devcontainer image
+	Image string `json:"image,omitempty"`
+	// devcontainer.json supplying an image, environment and lifecycle commands
+	Devcontainer string `json:"devcontainer,omitempty"`
+	// Bash run in /claude-data on first boot, after the devcontainer commands
+	SetupScript string `json:"setup_script,omitempty"`
+	// Environment variables; override the devcontainer's
+	Env map[string]string `json:"env,omitempty"`
+	// Git URLs cloned into /claude-data on first boot
+	Repos []string `json:"repos,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the TemplateQuery when eager-loading is set.
+	Edges          TemplateEdges `json:"edges"`
+	user_templates *int
+	selectValues   sql.SelectValues
+}
+
+// TemplateEdges holds the relations/edges for other nodes in the graph.
+type TemplateEdges struct {
+	// Owner holds the value of the owner edge.
+	Owner *User `json:"owner,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// OwnerOrErr returns the Owner value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e TemplateEdges) OwnerOrErr() (*User, error) {
+	if e.Owner != nil {
+		return e.Owner, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "owner"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Template) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case template.FieldEnv, template.FieldRepos:
+			values[i] = new([]byte)
+		case template.FieldID:
+			values[i] = new(sql.NullInt64)
+		case template.FieldName, template.FieldDescription, template.FieldImage, template.FieldDevcontainer, template.FieldSetupScript:
+			values[i] = new(sql.NullString)
+		case template.FieldCreatedAt, template.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case template.ForeignKeys[0]: // user_templates
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Template fields.
+func (_m *Template) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case template.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case template.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case template.FieldDescription:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field description", values[i])
+			} else if value.Valid {
+				_m.Description = value.String
+			}
+		case template.FieldImage:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field image", values[i])
+			} else if value.Valid {
+				_m.Image = value.String
+			}
+		case template.FieldDevcontainer:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field devcontainer", values[i])
+			} else if value.Valid {
+				_m.Devcontainer = value.String
+			}
+		case template.FieldSetupScript:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field setup_script", values[i])
+			} else if value.Valid {
+				_m.SetupScript = value.String
+			}
+		case template.FieldEnv:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field env", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Env); err != nil {
+					return fmt.Errorf("unmarshal field env: %w", err)
+				}
+			}
+		case template.FieldRepos:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field repos", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Repos); err != nil {
+					return fmt.Errorf("unmarshal field repos: %w", err)
+				}
+			}
+		case template.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case template.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		case template.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_templates", value)
+			} else if value.Valid {
+				_m.user_templates = new(int)
+				*_m.user_templates = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Template.
+// This includes values selected through modifiers, order, etc.
+func (_m *Template) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryOwner queries the "owner" edge of the Template entity.
+func (_m *Template) QueryOwner() *UserQuery {
+	return NewTemplateClient(_m.config).QueryOwner(_m)
+}
+
+// Update returns a builder for updating this Template.
+// Note that you need to call Template.Unwrap() before calling this method if this Template
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Template) Update() *TemplateUpdateOne {
+	return NewTemplateClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Template entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Template) Unwrap() *Template {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Template is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Template) String() string {
+	var builder strings.Builder
+	builder.WriteString("Template(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("description=")
+	builder.WriteString(_m.Description)
+	builder.WriteString(", ")
+	builder.WriteString("image=")
+	builder.WriteString(_m.Image)
+	builder.WriteString(", ")
+	builder.WriteString("devcontainer=")
+	builder.WriteString(_m.Devcontainer)
+	builder.WriteString(", ")
+	builder.WriteString("setup_script=")
+	builder.WriteString(_m.SetupScript)
+	builder.WriteString(", ")
+	builder.WriteString("env=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Env))
+	builder.WriteString(", ")
+	builder.WriteString("repos=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Repos))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Templates is a parsable slice of Template.
+type Templates []*Template
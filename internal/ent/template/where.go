@@ -0,0 +1,594 @@
+// Code generated by ent, DO NOT EDIT.
+
+package template
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.Template {
+	return predicate.Template(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.Template {
+	return predicate.Template(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.Template {
+	return predicate.Template(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.Template {
+	return predicate.Template(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.Template {
+	return predicate.Template(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.Template {
+	return predicate.Template(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.Template {
+	return predicate.Template(sql.FieldLTE(FieldID, id))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldName, v))
+}
+
+// Description applies equality check predicate on the "description" field. It's identical to DescriptionEQ.
+func Description(v string) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldDescription, v))
+}
+
+// Image applies equality check predicate on the "image" field. It's identical to ImageEQ.
+func Image(v string) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldImage, v))
+}
+
+// Devcontainer applies equality check predicate on the "devcontainer" field. It's identical to DevcontainerEQ.
+func Devcontainer(v string) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldDevcontainer, v))
+}
+
+// SetupScript applies equality check predicate on the "setup_script" field. It's identical to SetupScriptEQ.
+func SetupScript(v string) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldSetupScript, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.Template {
+	return predicate.Template(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.Template {
+	return predicate.Template(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.Template {
+	return predicate.Template(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.Template {
+	return predicate.Template(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.Template {
+	return predicate.Template(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.Template {
+	return predicate.Template(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.Template {
+	return predicate.Template(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.Template {
+	return predicate.Template(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.Template {
+	return predicate.Template(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.Template {
+	return predicate.Template(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.Template {
+	return predicate.Template(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.Template {
+	return predicate.Template(sql.FieldContainsFold(FieldName, v))
+}
+
+// DescriptionEQ applies the EQ predicate on the "description" field.
+func DescriptionEQ(v string) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldDescription, v))
+}
+
+// DescriptionNEQ applies the NEQ predicate on the "description" field.
+func DescriptionNEQ(v string) predicate.Template {
+	return predicate.Template(sql.FieldNEQ(FieldDescription, v))
+}
+
+// DescriptionIn applies the In predicate on the "description" field.
+func DescriptionIn(vs ...string) predicate.Template {
+	return predicate.Template(sql.FieldIn(FieldDescription, vs...))
+}
+
+// DescriptionNotIn applies the NotIn predicate on the "description" field.
+func DescriptionNotIn(vs ...string) predicate.Template {
+	return predicate.Template(sql.FieldNotIn(FieldDescription, vs...))
+}
+
+// DescriptionGT applies the GT predicate on the "description" field.
+func DescriptionGT(v string) predicate.Template {
+	return predicate.Template(sql.FieldGT(FieldDescription, v))
+}
+
+// DescriptionGTE applies the GTE predicate on the "description" field.
+func DescriptionGTE(v string) predicate.Template {
+	return predicate.Template(sql.FieldGTE(FieldDescription, v))
+}
+
+// DescriptionLT applies the LT predicate on the "description" field.
+func DescriptionLT(v string) predicate.Template {
+	return predicate.Template(sql.FieldLT(FieldDescription, v))
+}
+
+// DescriptionLTE applies the LTE predicate on the "description" field.
+func DescriptionLTE(v string) predicate.Template {
+	return predicate.Template(sql.FieldLTE(FieldDescription, v))
+}
+
+// DescriptionContains applies the Contains predicate on the "description" field.
+func DescriptionContains(v string) predicate.Template {
+	return predicate.Template(sql.FieldContains(FieldDescription, v))
+}
+
+// DescriptionHasPrefix applies the HasPrefix predicate on the "description" field.
+func DescriptionHasPrefix(v string) predicate.Template {
+	return predicate.Template(sql.FieldHasPrefix(FieldDescription, v))
+}
+
+// DescriptionHasSuffix applies the HasSuffix predicate on the "description" field.
+func DescriptionHasSuffix(v string) predicate.Template {
+	return predicate.Template(sql.FieldHasSuffix(FieldDescription, v))
+}
+
+// DescriptionIsNil applies the IsNil predicate on the "description" field.
+func DescriptionIsNil() predicate.Template {
+	return predicate.Template(sql.FieldIsNull(FieldDescription))
+}
+
+// DescriptionNotNil applies the NotNil predicate on the "description" field.
+func DescriptionNotNil() predicate.Template {
+	return predicate.Template(sql.FieldNotNull(FieldDescription))
+}
+
+// DescriptionEqualFold applies the EqualFold predicate on the "description" field.
+func DescriptionEqualFold(v string) predicate.Template {
+	return predicate.Template(sql.FieldEqualFold(FieldDescription, v))
+}
+
+// DescriptionContainsFold applies the ContainsFold predicate on the "description" field.
+func DescriptionContainsFold(v string) predicate.Template {
+	return predicate.Template(sql.FieldContainsFold(FieldDescription, v))
+}
+
+// ImageEQ applies the EQ predicate on the "image" field.
+func ImageEQ(v string) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldImage, v))
+}
+
+// ImageNEQ applies the NEQ predicate on the "image" field.
+func ImageNEQ(v string) predicate.Template {
+	return predicate.Template(sql.FieldNEQ(FieldImage, v))
+}
+
+// ImageIn applies the In predicate on the "image" field.
+func ImageIn(vs ...string) predicate.Template {
+	return predicate.Template(sql.FieldIn(FieldImage, vs...))
+}
+
+// ImageNotIn applies the NotIn predicate on the "image" field.
+func ImageNotIn(vs ...string) predicate.Template {
+	return predicate.Template(sql.FieldNotIn(FieldImage, vs...))
+}
+
+// ImageGT applies the GT predicate on the "image" field.
+func ImageGT(v string) predicate.Template {
+	return predicate.Template(sql.FieldGT(FieldImage, v))
+}
+
+// ImageGTE applies the GTE predicate on the "image" field.
+func ImageGTE(v string) predicate.Template {
+	return predicate.Template(sql.FieldGTE(FieldImage, v))
+}
+
+// ImageLT applies the LT predicate on the "image" field.
+func ImageLT(v string) predicate.Template {
+	return predicate.Template(sql.FieldLT(FieldImage, v))
+}
+
+// ImageLTE applies the LTE predicate on the "image" field.
+func ImageLTE(v string) predicate.Template {
+	return predicate.Template(sql.FieldLTE(FieldImage, v))
+}
+
+// ImageContains applies the Contains predicate on the "image" field.
+func ImageContains(v string) predicate.Template {
+	return predicate.Template(sql.FieldContains(FieldImage, v))
+}
+
+// ImageHasPrefix applies the HasPrefix predicate on the "image" field.
+func ImageHasPrefix(v string) predicate.Template {
+	return predicate.Template(sql.FieldHasPrefix(FieldImage, v))
+}
+
+// ImageHasSuffix applies the HasSuffix predicate on the "image" field.
+func ImageHasSuffix(v string) predicate.Template {
+	return predicate.Template(sql.FieldHasSuffix(FieldImage, v))
+}
+
+// ImageIsNil applies the IsNil predicate on the "image" field.
+func ImageIsNil() predicate.Template {
+	return predicate.Template(sql.FieldIsNull(FieldImage))
+}
+
+// ImageNotNil applies the NotNil predicate on the "image" field.
+func ImageNotNil() predicate.Template {
+	return predicate.Template(sql.FieldNotNull(FieldImage))
+}
+
+// ImageEqualFold applies the EqualFold predicate on the "image" field.
+func ImageEqualFold(v string) predicate.Template {
+	return predicate.Template(sql.FieldEqualFold(FieldImage, v))
+}
+
+// ImageContainsFold applies the ContainsFold predicate on the "image" field.
+func ImageContainsFold(v string) predicate.Template {
+	return predicate.Template(sql.FieldContainsFold(FieldImage, v))
+}
+
+// DevcontainerEQ applies the EQ predicate on the "devcontainer" field.
+func DevcontainerEQ(v string) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldDevcontainer, v))
+}
+
+// DevcontainerNEQ applies the NEQ predicate on the "devcontainer" field.
+func DevcontainerNEQ(v string) predicate.Template {
+	return predicate.Template(sql.FieldNEQ(FieldDevcontainer, v))
+}
+
+// DevcontainerIn applies the In predicate on the "devcontainer" field.
+func DevcontainerIn(vs ...string) predicate.Template {
+	return predicate.Template(sql.FieldIn(FieldDevcontainer, vs...))
+}
+
+// DevcontainerNotIn applies the NotIn predicate on the "devcontainer" field.
+func DevcontainerNotIn(vs ...string) predicate.Template {
+	return predicate.Template(sql.FieldNotIn(FieldDevcontainer, vs...))
+}
+
+// DevcontainerGT applies the GT predicate on the "devcontainer" field.
+func DevcontainerGT(v string) predicate.Template {
+	return predicate.Template(sql.FieldGT(FieldDevcontainer, v))
+}
+
+// DevcontainerGTE applies the GTE predicate on the "devcontainer" field.
+func DevcontainerGTE(v string) predicate.Template {
+	return predicate.Template(sql.FieldGTE(FieldDevcontainer, v))
+}
+
+// DevcontainerLT applies the LT predicate on the "devcontainer" field.
+func DevcontainerLT(v string) predicate.Template {
+	return predicate.Template(sql.FieldLT(FieldDevcontainer, v))
+}
+
+// DevcontainerLTE applies the LTE predicate on the "devcontainer" field.
+func DevcontainerLTE(v string) predicate.Template {
+	return predicate.Template(sql.FieldLTE(FieldDevcontainer, v))
+}
+
+// DevcontainerContains applies the Contains predicate on the "devcontainer" field.
+func DevcontainerContains(v string) predicate.Template {
+	return predicate.Template(sql.FieldContains(FieldDevcontainer, v))
+}
+
+// DevcontainerHasPrefix applies the HasPrefix predicate on the "devcontainer" field.
+func DevcontainerHasPrefix(v string) predicate.Template {
+	return predicate.Template(sql.FieldHasPrefix(FieldDevcontainer, v))
+}
+
+// DevcontainerHasSuffix applies the HasSuffix predicate on the "devcontainer" field.
+func DevcontainerHasSuffix(v string) predicate.Template {
+	return predicate.Template(sql.FieldHasSuffix(FieldDevcontainer, v))
+}
+
+// DevcontainerIsNil applies the IsNil predicate on the "devcontainer" field.
+func DevcontainerIsNil() predicate.Template {
+	return predicate.Template(sql.FieldIsNull(FieldDevcontainer))
+}
+
+// DevcontainerNotNil applies the NotNil predicate on the "devcontainer" field.
+func DevcontainerNotNil() predicate.Template {
+	return predicate.Template(sql.FieldNotNull(FieldDevcontainer))
+}
+
+// DevcontainerEqualFold applies the EqualFold predicate on the "devcontainer" field.
+func DevcontainerEqualFold(v string) predicate.Template {
+	return predicate.Template(sql.FieldEqualFold(FieldDevcontainer, v))
+}
+
+// DevcontainerContainsFold applies the ContainsFold predicate on the "devcontainer" field.
+func DevcontainerContainsFold(v string) predicate.Template {
+	return predicate.Template(sql.FieldContainsFold(FieldDevcontainer, v))
+}
+
+// SetupScriptEQ applies the EQ predicate on the "setup_script" field.
+func SetupScriptEQ(v string) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldSetupScript, v))
+}
+
+// SetupScriptNEQ applies the NEQ predicate on the "setup_script" field.
+func SetupScriptNEQ(v string) predicate.Template {
+	return predicate.Template(sql.FieldNEQ(FieldSetupScript, v))
+}
+
+// SetupScriptIn applies the In predicate on the "setup_script" field.
+func SetupScriptIn(vs ...string) predicate.Template {
+	return predicate.Template(sql.FieldIn(FieldSetupScript, vs...))
+}
+
+// SetupScriptNotIn applies the NotIn predicate on the "setup_script" field.
+func SetupScriptNotIn(vs ...string) predicate.Template {
+	return predicate.Template(sql.FieldNotIn(FieldSetupScript, vs...))
+}
+
+// SetupScriptGT applies the GT predicate on the "setup_script" field.
+func SetupScriptGT(v string) predicate.Template {
+	return predicate.Template(sql.FieldGT(FieldSetupScript, v))
+}
+
+// SetupScriptGTE applies the GTE predicate on the "setup_script" field.
+func SetupScriptGTE(v string) predicate.Template {
+	return predicate.Template(sql.FieldGTE(FieldSetupScript, v))
+}
+
+// SetupScriptLT applies the LT predicate on the "setup_script" field.
+func SetupScriptLT(v string) predicate.Template {
+	return predicate.Template(sql.FieldLT(FieldSetupScript, v))
+}
+
+// SetupScriptLTE applies the LTE predicate on the "setup_script" field.
+func SetupScriptLTE(v string) predicate.Template {
+	return predicate.Template(sql.FieldLTE(FieldSetupScript, v))
+}
+
+// SetupScriptContains applies the Contains predicate on the "setup_script" field.
+func SetupScriptContains(v string) predicate.Template {
+	return predicate.Template(sql.FieldContains(FieldSetupScript, v))
+}
+
+// SetupScriptHasPrefix applies the HasPrefix predicate on the "setup_script" field.
+func SetupScriptHasPrefix(v string) predicate.Template {
+	return predicate.Template(sql.FieldHasPrefix(FieldSetupScript, v))
+}
+
+// SetupScriptHasSuffix applies the HasSuffix predicate on the "setup_script" field.
+func SetupScriptHasSuffix(v string) predicate.Template {
+	return predicate.Template(sql.FieldHasSuffix(FieldSetupScript, v))
+}
+
+// SetupScriptIsNil applies the IsNil predicate on the "setup_script" field.
+func SetupScriptIsNil() predicate.Template {
+	return predicate.Template(sql.FieldIsNull(FieldSetupScript))
+}
+
+// SetupScriptNotNil applies the NotNil predicate on the "setup_script" field.
+func SetupScriptNotNil() predicate.Template {
+	return predicate.Template(sql.FieldNotNull(FieldSetupScript))
+}
+
+// SetupScriptEqualFold applies the EqualFold predicate on the "setup_script" field.
+func SetupScriptEqualFold(v string) predicate.Template {
+	return predicate.Template(sql.FieldEqualFold(FieldSetupScript, v))
+}
+
+// SetupScriptContainsFold applies the ContainsFold predicate on the "setup_script" field.
+func SetupScriptContainsFold(v string) predicate.Template {
+	return predicate.Template(sql.FieldContainsFold(FieldSetupScript, v))
+}
+
+// EnvIsNil applies the IsNil predicate on the "env" field.
+func EnvIsNil() predicate.Template {
+	return predicate.Template(sql.FieldIsNull(FieldEnv))
+}
+
+// EnvNotNil applies the NotNil predicate on the "env" field.
+func EnvNotNil() predicate.Template {
+	return predicate.Template(sql.FieldNotNull(FieldEnv))
+}
+
+// ReposIsNil applies the IsNil predicate on the "repos" field.
+func ReposIsNil() predicate.Template {
+	return predicate.Template(sql.FieldIsNull(FieldRepos))
+}
+
+// ReposNotNil applies the NotNil predicate on the "repos" field.
+func ReposNotNil() predicate.Template {
+	return predicate.Template(sql.FieldNotNull(FieldRepos))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.Template {
+	return predicate.Template(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.Template {
+	return predicate.Template(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.Template {
+	return predicate.Template(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.Template {
+	return predicate.Template(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.Template {
+	return predicate.Template(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// HasOwner applies the HasEdge predicate on the "owner" edge.
+func HasOwner() predicate.Template {
+	return predicate.Template(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, OwnerTable, OwnerColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOwnerWith applies the HasEdge predicate on the "owner" edge with a given conditions (other predicates).
+func HasOwnerWith(preds ...predicate.User) predicate.Template {
+	return predicate.Template(func(s *sql.Selector) {
+		step := newOwnerStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Template) predicate.Template {
+	return predicate.Template(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Template) predicate.Template {
+	return predicate.Template(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Template) predicate.Template {
+	return predicate.Template(sql.NotPredicates(p))
+}
@@ -0,0 +1,684 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+	"github.com/logan/cloudcode/internal/ent/template"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// TemplateUpdate is the builder for updating Template entities.
+type TemplateUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TemplateMutation
+}
+
+// Where appends a list predicates to the TemplateUpdate builder.
+func (_u *TemplateUpdate) Where(ps ...predicate.Template) *TemplateUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *TemplateUpdate) SetName(v string) *TemplateUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *TemplateUpdate) SetNillableName(v *string) *TemplateUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *TemplateUpdate) SetDescription(v string) *TemplateUpdate {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *TemplateUpdate) SetNillableDescription(v *string) *TemplateUpdate {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *TemplateUpdate) ClearDescription() *TemplateUpdate {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetImage sets the "image" field.
+func (_u *TemplateUpdate) SetImage(v string) *TemplateUpdate {
+	_u.mutation.SetImage(v)
+	return _u
+}
+
+// SetNillableImage sets the "image" field if the given value is not nil.
+func (_u *TemplateUpdate) SetNillableImage(v *string) *TemplateUpdate {
+	if v != nil {
+		_u.SetImage(*v)
+	}
+	return _u
+}
+
+// ClearImage clears the value of the "image" field.
+func (_u *TemplateUpdate) ClearImage() *TemplateUpdate {
+	_u.mutation.ClearImage()
+	return _u
+}
+
+// SetDevcontainer sets the "devcontainer" field.
+func (_u *TemplateUpdate) SetDevcontainer(v string) *TemplateUpdate {
+	_u.mutation.SetDevcontainer(v)
+	return _u
+}
+
+// SetNillableDevcontainer sets the "devcontainer" field if the given value is not nil.
+func (_u *TemplateUpdate) SetNillableDevcontainer(v *string) *TemplateUpdate {
+	if v != nil {
+		_u.SetDevcontainer(*v)
+	}
+	return _u
+}
+
+// ClearDevcontainer clears the value of the "devcontainer" field.
+func (_u *TemplateUpdate) ClearDevcontainer() *TemplateUpdate {
+	_u.mutation.ClearDevcontainer()
+	return _u
+}
+
+// SetSetupScript sets the "setup_script" field.
+func (_u *TemplateUpdate) SetSetupScript(v string) *TemplateUpdate {
+	_u.mutation.SetSetupScript(v)
+	return _u
+}
+
+// SetNillableSetupScript sets the "setup_script" field if the given value is not nil.
+func (_u *TemplateUpdate) SetNillableSetupScript(v *string) *TemplateUpdate {
+	if v != nil {
+		_u.SetSetupScript(*v)
+	}
+	return _u
+}
+
+// ClearSetupScript clears the value of the "setup_script" field.
+func (_u *TemplateUpdate) ClearSetupScript() *TemplateUpdate {
+	_u.mutation.ClearSetupScript()
+	return _u
+}
+
+// SetEnv sets the "env" field.
+func (_u *TemplateUpdate) SetEnv(v map[string]string) *TemplateUpdate {
+	_u.mutation.SetEnv(v)
+	return _u
+}
+
+// ClearEnv clears the value of the "env" field.
+func (_u *TemplateUpdate) ClearEnv() *TemplateUpdate {
+	_u.mutation.ClearEnv()
+	return _u
+}
+
+// SetRepos sets the "repos" field.
+func (_u *TemplateUpdate) SetRepos(v []string) *TemplateUpdate {
+	_u.mutation.SetRepos(v)
+	return _u
+}
+
+// AppendRepos appends value to the "repos" field.
+func (_u *TemplateUpdate) AppendRepos(v []string) *TemplateUpdate {
+	_u.mutation.AppendRepos(v)
+	return _u
+}
+
+// ClearRepos clears the value of the "repos" field.
+func (_u *TemplateUpdate) ClearRepos() *TemplateUpdate {
+	_u.mutation.ClearRepos()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *TemplateUpdate) SetUpdatedAt(v time.Time) *TemplateUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_u *TemplateUpdate) SetOwnerID(id int) *TemplateUpdate {
+	_u.mutation.SetOwnerID(id)
+	return _u
+}
+
+// SetNillableOwnerID sets the "owner" edge to the User entity by ID if the given value is not nil.
+func (_u *TemplateUpdate) SetNillableOwnerID(id *int) *TemplateUpdate {
+	if id != nil {
+		_u = _u.SetOwnerID(*id)
+	}
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *TemplateUpdate) SetOwner(v *User) *TemplateUpdate {
+	return _u.SetOwnerID(v.ID)
+}
+
+// Mutation returns the TemplateMutation object of the builder.
+func (_u *TemplateUpdate) Mutation() *TemplateMutation {
+	return _u.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *TemplateUpdate) ClearOwner() *TemplateUpdate {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *TemplateUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TemplateUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *TemplateUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TemplateUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *TemplateUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := template.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TemplateUpdate) check() error {
+	if v, ok := _u.mutation.Name(); ok {
+		if err := template.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Template.name": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *TemplateUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(template.Table, template.Columns, sqlgraph.NewFieldSpec(template.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(template.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(template.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(template.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.Image(); ok {
+		_spec.SetField(template.FieldImage, field.TypeString, value)
+	}
+	if _u.mutation.ImageCleared() {
+		_spec.ClearField(template.FieldImage, field.TypeString)
+	}
+	if value, ok := _u.mutation.Devcontainer(); ok {
+		_spec.SetField(template.FieldDevcontainer, field.TypeString, value)
+	}
+	if _u.mutation.DevcontainerCleared() {
+		_spec.ClearField(template.FieldDevcontainer, field.TypeString)
+	}
+	if value, ok := _u.mutation.SetupScript(); ok {
+		_spec.SetField(template.FieldSetupScript, field.TypeString, value)
+	}
+	if _u.mutation.SetupScriptCleared() {
+		_spec.ClearField(template.FieldSetupScript, field.TypeString)
+	}
+	if value, ok := _u.mutation.Env(); ok {
+		_spec.SetField(template.FieldEnv, field.TypeJSON, value)
+	}
+	if _u.mutation.EnvCleared() {
+		_spec.ClearField(template.FieldEnv, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Repos(); ok {
+		_spec.SetField(template.FieldRepos, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedRepos(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, template.FieldRepos, value)
+		})
+	}
+	if _u.mutation.ReposCleared() {
+		_spec.ClearField(template.FieldRepos, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(template.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   template.OwnerTable,
+			Columns: []string{template.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   template.OwnerTable,
+			Columns: []string{template.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{template.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// TemplateUpdateOne is the builder for updating a single Template entity.
+type TemplateUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TemplateMutation
+}
+
+// SetName sets the "name" field.
+func (_u *TemplateUpdateOne) SetName(v string) *TemplateUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *TemplateUpdateOne) SetNillableName(v *string) *TemplateUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *TemplateUpdateOne) SetDescription(v string) *TemplateUpdateOne {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *TemplateUpdateOne) SetNillableDescription(v *string) *TemplateUpdateOne {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *TemplateUpdateOne) ClearDescription() *TemplateUpdateOne {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetImage sets the "image" field.
+func (_u *TemplateUpdateOne) SetImage(v string) *TemplateUpdateOne {
+	_u.mutation.SetImage(v)
+	return _u
+}
+
+// SetNillableImage sets the "image" field if the given value is not nil.
+func (_u *TemplateUpdateOne) SetNillableImage(v *string) *TemplateUpdateOne {
+	if v != nil {
+		_u.SetImage(*v)
+	}
+	return _u
+}
+
+// ClearImage clears the value of the "image" field.
+func (_u *TemplateUpdateOne) ClearImage() *TemplateUpdateOne {
+	_u.mutation.ClearImage()
+	return _u
+}
+
+// SetDevcontainer sets the "devcontainer" field.
+func (_u *TemplateUpdateOne) SetDevcontainer(v string) *TemplateUpdateOne {
+	_u.mutation.SetDevcontainer(v)
+	return _u
+}
+
+// SetNillableDevcontainer sets the "devcontainer" field if the given value is not nil.
+func (_u *TemplateUpdateOne) SetNillableDevcontainer(v *string) *TemplateUpdateOne {
+	if v != nil {
+		_u.SetDevcontainer(*v)
+	}
+	return _u
+}
+
+// ClearDevcontainer clears the value of the "devcontainer" field.
+func (_u *TemplateUpdateOne) ClearDevcontainer() *TemplateUpdateOne {
+	_u.mutation.ClearDevcontainer()
+	return _u
+}
+
+// SetSetupScript sets the "setup_script" field.
+func (_u *TemplateUpdateOne) SetSetupScript(v string) *TemplateUpdateOne {
+	_u.mutation.SetSetupScript(v)
+	return _u
+}
+
+// SetNillableSetupScript sets the "setup_script" field if the given value is not nil.
+func (_u *TemplateUpdateOne) SetNillableSetupScript(v *string) *TemplateUpdateOne {
+	if v != nil {
+		_u.SetSetupScript(*v)
+	}
+	return _u
+}
+
+// ClearSetupScript clears the value of the "setup_script" field.
+func (_u *TemplateUpdateOne) ClearSetupScript() *TemplateUpdateOne {
+	_u.mutation.ClearSetupScript()
+	return _u
+}
+
+// SetEnv sets the "env" field.
+func (_u *TemplateUpdateOne) SetEnv(v map[string]string) *TemplateUpdateOne {
+	_u.mutation.SetEnv(v)
+	return _u
+}
+
+// ClearEnv clears the value of the "env" field.
+func (_u *TemplateUpdateOne) ClearEnv() *TemplateUpdateOne {
+	_u.mutation.ClearEnv()
+	return _u
+}
+
+// SetRepos sets the "repos" field.
+func (_u *TemplateUpdateOne) SetRepos(v []string) *TemplateUpdateOne {
+	_u.mutation.SetRepos(v)
+	return _u
+}
+
+// AppendRepos appends value to the "repos" field.
+func (_u *TemplateUpdateOne) AppendRepos(v []string) *TemplateUpdateOne {
+	_u.mutation.AppendRepos(v)
+	return _u
+}
+
+// ClearRepos clears the value of the "repos" field.
+func (_u *TemplateUpdateOne) ClearRepos() *TemplateUpdateOne {
+	_u.mutation.ClearRepos()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *TemplateUpdateOne) SetUpdatedAt(v time.Time) *TemplateUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_u *TemplateUpdateOne) SetOwnerID(id int) *TemplateUpdateOne {
+	_u.mutation.SetOwnerID(id)
+	return _u
+}
+
+// SetNillableOwnerID sets the "owner" edge to the User entity by ID if the given value is not nil.
+func (_u *TemplateUpdateOne) SetNillableOwnerID(id *int) *TemplateUpdateOne {
+	if id != nil {
+		_u = _u.SetOwnerID(*id)
+	}
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *TemplateUpdateOne) SetOwner(v *User) *TemplateUpdateOne {
+	return _u.SetOwnerID(v.ID)
+}
+
+// Mutation returns the TemplateMutation object of the builder.
+func (_u *TemplateUpdateOne) Mutation() *TemplateMutation {
+	return _u.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *TemplateUpdateOne) ClearOwner() *TemplateUpdateOne {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
+// Where appends a list predicates to the TemplateUpdate builder.
+func (_u *TemplateUpdateOne) Where(ps ...predicate.Template) *TemplateUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *TemplateUpdateOne) Select(field string, fields ...string) *TemplateUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Template entity.
+func (_u *TemplateUpdateOne) Save(ctx context.Context) (*Template, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TemplateUpdateOne) SaveX(ctx context.Context) *Template {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *TemplateUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TemplateUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *TemplateUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := template.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TemplateUpdateOne) check() error {
+	if v, ok := _u.mutation.Name(); ok {
+		if err := template.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Template.name": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *TemplateUpdateOne) sqlSave(ctx context.Context) (_node *Template, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(template.Table, template.Columns, sqlgraph.NewFieldSpec(template.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Template.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, template.FieldID)
+		for _, f := range fields {
+			if !template.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != template.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(template.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(template.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(template.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.Image(); ok {
+		_spec.SetField(template.FieldImage, field.TypeString, value)
+	}
+	if _u.mutation.ImageCleared() {
+		_spec.ClearField(template.FieldImage, field.TypeString)
+	}
+	if value, ok := _u.mutation.Devcontainer(); ok {
+		_spec.SetField(template.FieldDevcontainer, field.TypeString, value)
+	}
+	if _u.mutation.DevcontainerCleared() {
+		_spec.ClearField(template.FieldDevcontainer, field.TypeString)
+	}
+	if value, ok := _u.mutation.SetupScript(); ok {
+		_spec.SetField(template.FieldSetupScript, field.TypeString, value)
+	}
+	if _u.mutation.SetupScriptCleared() {
+		_spec.ClearField(template.FieldSetupScript, field.TypeString)
+	}
+	if value, ok := _u.mutation.Env(); ok {
+		_spec.SetField(template.FieldEnv, field.TypeJSON, value)
+	}
+	if _u.mutation.EnvCleared() {
+		_spec.ClearField(template.FieldEnv, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Repos(); ok {
+		_spec.SetField(template.FieldRepos, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedRepos(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, template.FieldRepos, value)
+		})
+	}
+	if _u.mutation.ReposCleared() {
+		_spec.ClearField(template.FieldRepos, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(template.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   template.OwnerTable,
+			Columns: []string{template.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   template.OwnerTable,
+			Columns: []string{template.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Template{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{template.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
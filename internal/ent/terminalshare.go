@@ -0,0 +1,213 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/logan/cloudcode/internal/ent/terminalshare"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// TerminalShare is the model entity for the TerminalShare schema.
+type TerminalShare struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Instance whose terminal can be watched
+	InstanceID int `json:"instance_id,omitempty"`
+	// Shown to the other people in the session, e.g. who the link is for
+	Label string `json:"label,omitempty"`
+	// SHA-256 of the token
+	TokenHash string `json:"-"`
+	// Leading characters of the token, for recognising it in listings
+	TokenPrefix string `json:"token_prefix,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// RevokedAt holds the value of the "revoked_at" field.
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the TerminalShareQuery when eager-loading is set.
+	Edges                TerminalShareEdges `json:"edges"`
+	user_terminal_shares *int
+	selectValues         sql.SelectValues
+}
+
+// TerminalShareEdges holds the relations/edges for other nodes in the graph.
+type TerminalShareEdges struct {
+	// Creator holds the value of the creator edge.
+	Creator *User `json:"creator,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// CreatorOrErr returns the Creator value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e TerminalShareEdges) CreatorOrErr() (*User, error) {
+	if e.Creator != nil {
+		return e.Creator, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "creator"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*TerminalShare) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case terminalshare.FieldID, terminalshare.FieldInstanceID:
+			values[i] = new(sql.NullInt64)
+		case terminalshare.FieldLabel, terminalshare.FieldTokenHash, terminalshare.FieldTokenPrefix:
+			values[i] = new(sql.NullString)
+		case terminalshare.FieldExpiresAt, terminalshare.FieldRevokedAt, terminalshare.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case terminalshare.ForeignKeys[0]: // user_terminal_shares
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the TerminalShare fields.
+func (_m *TerminalShare) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case terminalshare.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case terminalshare.FieldInstanceID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field instance_id", values[i])
+			} else if value.Valid {
+				_m.InstanceID = int(value.Int64)
+			}
+		case terminalshare.FieldLabel:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field label", values[i])
+			} else if value.Valid {
+				_m.Label = value.String
+			}
+		case terminalshare.FieldTokenHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field token_hash", values[i])
+			} else if value.Valid {
+				_m.TokenHash = value.String
+			}
+		case terminalshare.FieldTokenPrefix:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field token_prefix", values[i])
+			} else if value.Valid {
+				_m.TokenPrefix = value.String
+			}
+		case terminalshare.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				_m.ExpiresAt = value.Time
+			}
+		case terminalshare.FieldRevokedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field revoked_at", values[i])
+			} else if value.Valid {
+				_m.RevokedAt = new(time.Time)
+				*_m.RevokedAt = value.Time
+			}
+		case terminalshare.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case terminalshare.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_terminal_shares", value)
+			} else if value.Valid {
+				_m.user_terminal_shares = new(int)
+				*_m.user_terminal_shares = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the TerminalShare.
+// This includes values selected through modifiers, order, etc.
+func (_m *TerminalShare) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryCreator queries the "creator" edge of the TerminalShare entity.
+func (_m *TerminalShare) QueryCreator() *UserQuery {
+	return NewTerminalShareClient(_m.config).QueryCreator(_m)
+}
+
+// Update returns a builder for updating this TerminalShare.
+// Note that you need to call TerminalShare.Unwrap() before calling this method if this TerminalShare
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *TerminalShare) Update() *TerminalShareUpdateOne {
+	return NewTerminalShareClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the TerminalShare entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *TerminalShare) Unwrap() *TerminalShare {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: TerminalShare is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *TerminalShare) String() string {
+	var builder strings.Builder
+	builder.WriteString("TerminalShare(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("instance_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.InstanceID))
+	builder.WriteString(", ")
+	builder.WriteString("label=")
+	builder.WriteString(_m.Label)
+	builder.WriteString(", ")
+	builder.WriteString("token_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("token_prefix=")
+	builder.WriteString(_m.TokenPrefix)
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(_m.ExpiresAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := _m.RevokedAt; v != nil {
+		builder.WriteString("revoked_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// TerminalShares is a parsable slice of TerminalShare.
+type TerminalShares []*TerminalShare
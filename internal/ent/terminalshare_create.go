@@ -0,0 +1,326 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/terminalshare"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// TerminalShareCreate is the builder for creating a TerminalShare entity.
+type TerminalShareCreate struct {
+	config
+	mutation *TerminalShareMutation
+	hooks    []Hook
+}
+
+// SetInstanceID sets the "instance_id" field.
+func (_c *TerminalShareCreate) SetInstanceID(v int) *TerminalShareCreate {
+	_c.mutation.SetInstanceID(v)
+	return _c
+}
+
+// SetLabel sets the "label" field.
+func (_c *TerminalShareCreate) SetLabel(v string) *TerminalShareCreate {
+	_c.mutation.SetLabel(v)
+	return _c
+}
+
+// SetNillableLabel sets the "label" field if the given value is not nil.
+func (_c *TerminalShareCreate) SetNillableLabel(v *string) *TerminalShareCreate {
+	if v != nil {
+		_c.SetLabel(*v)
+	}
+	return _c
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (_c *TerminalShareCreate) SetTokenHash(v string) *TerminalShareCreate {
+	_c.mutation.SetTokenHash(v)
+	return _c
+}
+
+// SetTokenPrefix sets the "token_prefix" field.
+func (_c *TerminalShareCreate) SetTokenPrefix(v string) *TerminalShareCreate {
+	_c.mutation.SetTokenPrefix(v)
+	return _c
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_c *TerminalShareCreate) SetExpiresAt(v time.Time) *TerminalShareCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (_c *TerminalShareCreate) SetRevokedAt(v time.Time) *TerminalShareCreate {
+	_c.mutation.SetRevokedAt(v)
+	return _c
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (_c *TerminalShareCreate) SetNillableRevokedAt(v *time.Time) *TerminalShareCreate {
+	if v != nil {
+		_c.SetRevokedAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *TerminalShareCreate) SetCreatedAt(v time.Time) *TerminalShareCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *TerminalShareCreate) SetNillableCreatedAt(v *time.Time) *TerminalShareCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetCreatorID sets the "creator" edge to the User entity by ID.
+func (_c *TerminalShareCreate) SetCreatorID(id int) *TerminalShareCreate {
+	_c.mutation.SetCreatorID(id)
+	return _c
+}
+
+// SetCreator sets the "creator" edge to the User entity.
+func (_c *TerminalShareCreate) SetCreator(v *User) *TerminalShareCreate {
+	return _c.SetCreatorID(v.ID)
+}
+
+// Mutation returns the TerminalShareMutation object of the builder.
+func (_c *TerminalShareCreate) Mutation() *TerminalShareMutation {
+	return _c.mutation
+}
+
+// Save creates the TerminalShare in the database.
+func (_c *TerminalShareCreate) Save(ctx context.Context) (*TerminalShare, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *TerminalShareCreate) SaveX(ctx context.Context) *TerminalShare {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TerminalShareCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TerminalShareCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *TerminalShareCreate) defaults() {
+	if _, ok := _c.mutation.Label(); !ok {
+		v := terminalshare.DefaultLabel
+		_c.mutation.SetLabel(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := terminalshare.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *TerminalShareCreate) check() error {
+	if _, ok := _c.mutation.InstanceID(); !ok {
+		return &ValidationError{Name: "instance_id", err: errors.New(`ent: missing required field "TerminalShare.instance_id"`)}
+	}
+	if _, ok := _c.mutation.TokenHash(); !ok {
+		return &ValidationError{Name: "token_hash", err: errors.New(`ent: missing required field "TerminalShare.token_hash"`)}
+	}
+	if _, ok := _c.mutation.TokenPrefix(); !ok {
+		return &ValidationError{Name: "token_prefix", err: errors.New(`ent: missing required field "TerminalShare.token_prefix"`)}
+	}
+	if _, ok := _c.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "TerminalShare.expires_at"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "TerminalShare.created_at"`)}
+	}
+	if len(_c.mutation.CreatorIDs()) == 0 {
+		return &ValidationError{Name: "creator", err: errors.New(`ent: missing required edge "TerminalShare.creator"`)}
+	}
+	return nil
+}
+
+func (_c *TerminalShareCreate) sqlSave(ctx context.Context) (*TerminalShare, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *TerminalShareCreate) createSpec() (*TerminalShare, *sqlgraph.CreateSpec) {
+	var (
+		_node = &TerminalShare{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(terminalshare.Table, sqlgraph.NewFieldSpec(terminalshare.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.InstanceID(); ok {
+		_spec.SetField(terminalshare.FieldInstanceID, field.TypeInt, value)
+		_node.InstanceID = value
+	}
+	if value, ok := _c.mutation.Label(); ok {
+		_spec.SetField(terminalshare.FieldLabel, field.TypeString, value)
+		_node.Label = value
+	}
+	if value, ok := _c.mutation.TokenHash(); ok {
+		_spec.SetField(terminalshare.FieldTokenHash, field.TypeString, value)
+		_node.TokenHash = value
+	}
+	if value, ok := _c.mutation.TokenPrefix(); ok {
+		_spec.SetField(terminalshare.FieldTokenPrefix, field.TypeString, value)
+		_node.TokenPrefix = value
+	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(terminalshare.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := _c.mutation.RevokedAt(); ok {
+		_spec.SetField(terminalshare.FieldRevokedAt, field.TypeTime, value)
+		_node.RevokedAt = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(terminalshare.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.CreatorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   terminalshare.CreatorTable,
+			Columns: []string{terminalshare.CreatorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_terminal_shares = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// TerminalShareCreateBulk is the builder for creating many TerminalShare entities in bulk.
+type TerminalShareCreateBulk struct {
+	config
+	err      error
+	builders []*TerminalShareCreate
+}
+
+// Save creates the TerminalShare entities in the database.
+func (_c *TerminalShareCreateBulk) Save(ctx context.Context) ([]*TerminalShare, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*TerminalShare, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TerminalShareMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *TerminalShareCreateBulk) SaveX(ctx context.Context) []*TerminalShare {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TerminalShareCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TerminalShareCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,551 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+	"github.com/logan/cloudcode/internal/ent/terminalshare"
+	"github.com/logan/cloudcode/internal/ent/user"
+)
+
+// TerminalShareUpdate is the builder for updating TerminalShare entities.
+type TerminalShareUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TerminalShareMutation
+}
+
+// Where appends a list predicates to the TerminalShareUpdate builder.
+func (_u *TerminalShareUpdate) Where(ps ...predicate.TerminalShare) *TerminalShareUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetInstanceID sets the "instance_id" field.
+func (_u *TerminalShareUpdate) SetInstanceID(v int) *TerminalShareUpdate {
+	_u.mutation.ResetInstanceID()
+	_u.mutation.SetInstanceID(v)
+	return _u
+}
+
+// SetNillableInstanceID sets the "instance_id" field if the given value is not nil.
+func (_u *TerminalShareUpdate) SetNillableInstanceID(v *int) *TerminalShareUpdate {
+	if v != nil {
+		_u.SetInstanceID(*v)
+	}
+	return _u
+}
+
+// AddInstanceID adds value to the "instance_id" field.
+func (_u *TerminalShareUpdate) AddInstanceID(v int) *TerminalShareUpdate {
+	_u.mutation.AddInstanceID(v)
+	return _u
+}
+
+// SetLabel sets the "label" field.
+func (_u *TerminalShareUpdate) SetLabel(v string) *TerminalShareUpdate {
+	_u.mutation.SetLabel(v)
+	return _u
+}
+
+// SetNillableLabel sets the "label" field if the given value is not nil.
+func (_u *TerminalShareUpdate) SetNillableLabel(v *string) *TerminalShareUpdate {
+	if v != nil {
+		_u.SetLabel(*v)
+	}
+	return _u
+}
+
+// ClearLabel clears the value of the "label" field.
+func (_u *TerminalShareUpdate) ClearLabel() *TerminalShareUpdate {
+	_u.mutation.ClearLabel()
+	return _u
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (_u *TerminalShareUpdate) SetTokenHash(v string) *TerminalShareUpdate {
+	_u.mutation.SetTokenHash(v)
+	return _u
+}
+
+// SetNillableTokenHash sets the "token_hash" field if the given value is not nil.
+func (_u *TerminalShareUpdate) SetNillableTokenHash(v *string) *TerminalShareUpdate {
+	if v != nil {
+		_u.SetTokenHash(*v)
+	}
+	return _u
+}
+
+// SetTokenPrefix sets the "token_prefix" field.
+func (_u *TerminalShareUpdate) SetTokenPrefix(v string) *TerminalShareUpdate {
+	_u.mutation.SetTokenPrefix(v)
+	return _u
+}
+
+// SetNillableTokenPrefix sets the "token_prefix" field if the given value is not nil.
+func (_u *TerminalShareUpdate) SetNillableTokenPrefix(v *string) *TerminalShareUpdate {
+	if v != nil {
+		_u.SetTokenPrefix(*v)
+	}
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *TerminalShareUpdate) SetExpiresAt(v time.Time) *TerminalShareUpdate {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *TerminalShareUpdate) SetNillableExpiresAt(v *time.Time) *TerminalShareUpdate {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (_u *TerminalShareUpdate) SetRevokedAt(v time.Time) *TerminalShareUpdate {
+	_u.mutation.SetRevokedAt(v)
+	return _u
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (_u *TerminalShareUpdate) SetNillableRevokedAt(v *time.Time) *TerminalShareUpdate {
+	if v != nil {
+		_u.SetRevokedAt(*v)
+	}
+	return _u
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (_u *TerminalShareUpdate) ClearRevokedAt() *TerminalShareUpdate {
+	_u.mutation.ClearRevokedAt()
+	return _u
+}
+
+// SetCreatorID sets the "creator" edge to the User entity by ID.
+func (_u *TerminalShareUpdate) SetCreatorID(id int) *TerminalShareUpdate {
+	_u.mutation.SetCreatorID(id)
+	return _u
+}
+
+// SetCreator sets the "creator" edge to the User entity.
+func (_u *TerminalShareUpdate) SetCreator(v *User) *TerminalShareUpdate {
+	return _u.SetCreatorID(v.ID)
+}
+
+// Mutation returns the TerminalShareMutation object of the builder.
+func (_u *TerminalShareUpdate) Mutation() *TerminalShareMutation {
+	return _u.mutation
+}
+
+// ClearCreator clears the "creator" edge to the User entity.
+func (_u *TerminalShareUpdate) ClearCreator() *TerminalShareUpdate {
+	_u.mutation.ClearCreator()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *TerminalShareUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TerminalShareUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *TerminalShareUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TerminalShareUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TerminalShareUpdate) check() error {
+	if _u.mutation.CreatorCleared() && len(_u.mutation.CreatorIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "TerminalShare.creator"`)
+	}
+	return nil
+}
+
+func (_u *TerminalShareUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(terminalshare.Table, terminalshare.Columns, sqlgraph.NewFieldSpec(terminalshare.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.InstanceID(); ok {
+		_spec.SetField(terminalshare.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedInstanceID(); ok {
+		_spec.AddField(terminalshare.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Label(); ok {
+		_spec.SetField(terminalshare.FieldLabel, field.TypeString, value)
+	}
+	if _u.mutation.LabelCleared() {
+		_spec.ClearField(terminalshare.FieldLabel, field.TypeString)
+	}
+	if value, ok := _u.mutation.TokenHash(); ok {
+		_spec.SetField(terminalshare.FieldTokenHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.TokenPrefix(); ok {
+		_spec.SetField(terminalshare.FieldTokenPrefix, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(terminalshare.FieldExpiresAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.RevokedAt(); ok {
+		_spec.SetField(terminalshare.FieldRevokedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RevokedAtCleared() {
+		_spec.ClearField(terminalshare.FieldRevokedAt, field.TypeTime)
+	}
+	if _u.mutation.CreatorCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   terminalshare.CreatorTable,
+			Columns: []string{terminalshare.CreatorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.CreatorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   terminalshare.CreatorTable,
+			Columns: []string{terminalshare.CreatorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{terminalshare.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// TerminalShareUpdateOne is the builder for updating a single TerminalShare entity.
+type TerminalShareUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TerminalShareMutation
+}
+
+// SetInstanceID sets the "instance_id" field.
+func (_u *TerminalShareUpdateOne) SetInstanceID(v int) *TerminalShareUpdateOne {
+	_u.mutation.ResetInstanceID()
+	_u.mutation.SetInstanceID(v)
+	return _u
+}
+
+// SetNillableInstanceID sets the "instance_id" field if the given value is not nil.
+func (_u *TerminalShareUpdateOne) SetNillableInstanceID(v *int) *TerminalShareUpdateOne {
+	if v != nil {
+		_u.SetInstanceID(*v)
+	}
+	return _u
+}
+
+// AddInstanceID adds value to the "instance_id" field.
+func (_u *TerminalShareUpdateOne) AddInstanceID(v int) *TerminalShareUpdateOne {
+	_u.mutation.AddInstanceID(v)
+	return _u
+}
+
+// SetLabel sets the "label" field.
+func (_u *TerminalShareUpdateOne) SetLabel(v string) *TerminalShareUpdateOne {
+	_u.mutation.SetLabel(v)
+	return _u
+}
+
+// SetNillableLabel sets the "label" field if the given value is not nil.
+func (_u *TerminalShareUpdateOne) SetNillableLabel(v *string) *TerminalShareUpdateOne {
+	if v != nil {
+		_u.SetLabel(*v)
+	}
+	return _u
+}
+
+// ClearLabel clears the value of the "label" field.
+func (_u *TerminalShareUpdateOne) ClearLabel() *TerminalShareUpdateOne {
+	_u.mutation.ClearLabel()
+	return _u
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (_u *TerminalShareUpdateOne) SetTokenHash(v string) *TerminalShareUpdateOne {
+	_u.mutation.SetTokenHash(v)
+	return _u
+}
+
+// SetNillableTokenHash sets the "token_hash" field if the given value is not nil.
+func (_u *TerminalShareUpdateOne) SetNillableTokenHash(v *string) *TerminalShareUpdateOne {
+	if v != nil {
+		_u.SetTokenHash(*v)
+	}
+	return _u
+}
+
+// SetTokenPrefix sets the "token_prefix" field.
+func (_u *TerminalShareUpdateOne) SetTokenPrefix(v string) *TerminalShareUpdateOne {
+	_u.mutation.SetTokenPrefix(v)
+	return _u
+}
+
+// SetNillableTokenPrefix sets the "token_prefix" field if the given value is not nil.
+func (_u *TerminalShareUpdateOne) SetNillableTokenPrefix(v *string) *TerminalShareUpdateOne {
+	if v != nil {
+		_u.SetTokenPrefix(*v)
+	}
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *TerminalShareUpdateOne) SetExpiresAt(v time.Time) *TerminalShareUpdateOne {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *TerminalShareUpdateOne) SetNillableExpiresAt(v *time.Time) *TerminalShareUpdateOne {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (_u *TerminalShareUpdateOne) SetRevokedAt(v time.Time) *TerminalShareUpdateOne {
+	_u.mutation.SetRevokedAt(v)
+	return _u
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (_u *TerminalShareUpdateOne) SetNillableRevokedAt(v *time.Time) *TerminalShareUpdateOne {
+	if v != nil {
+		_u.SetRevokedAt(*v)
+	}
+	return _u
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (_u *TerminalShareUpdateOne) ClearRevokedAt() *TerminalShareUpdateOne {
+	_u.mutation.ClearRevokedAt()
+	return _u
+}
+
+// SetCreatorID sets the "creator" edge to the User entity by ID.
+func (_u *TerminalShareUpdateOne) SetCreatorID(id int) *TerminalShareUpdateOne {
+	_u.mutation.SetCreatorID(id)
+	return _u
+}
+
+// SetCreator sets the "creator" edge to the User entity.
+func (_u *TerminalShareUpdateOne) SetCreator(v *User) *TerminalShareUpdateOne {
+	return _u.SetCreatorID(v.ID)
+}
+
+// Mutation returns the TerminalShareMutation object of the builder.
+func (_u *TerminalShareUpdateOne) Mutation() *TerminalShareMutation {
+	return _u.mutation
+}
+
+// ClearCreator clears the "creator" edge to the User entity.
+func (_u *TerminalShareUpdateOne) ClearCreator() *TerminalShareUpdateOne {
+	_u.mutation.ClearCreator()
+	return _u
+}
+
+// Where appends a list predicates to the TerminalShareUpdate builder.
+func (_u *TerminalShareUpdateOne) Where(ps ...predicate.TerminalShare) *TerminalShareUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *TerminalShareUpdateOne) Select(field string, fields ...string) *TerminalShareUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated TerminalShare entity.
+func (_u *TerminalShareUpdateOne) Save(ctx context.Context) (*TerminalShare, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TerminalShareUpdateOne) SaveX(ctx context.Context) *TerminalShare {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *TerminalShareUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TerminalShareUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TerminalShareUpdateOne) check() error {
+	if _u.mutation.CreatorCleared() && len(_u.mutation.CreatorIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "TerminalShare.creator"`)
+	}
+	return nil
+}
+
+func (_u *TerminalShareUpdateOne) sqlSave(ctx context.Context) (_node *TerminalShare, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(terminalshare.Table, terminalshare.Columns, sqlgraph.NewFieldSpec(terminalshare.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "TerminalShare.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, terminalshare.FieldID)
+		for _, f := range fields {
+			if !terminalshare.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != terminalshare.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.InstanceID(); ok {
+		_spec.SetField(terminalshare.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedInstanceID(); ok {
+		_spec.AddField(terminalshare.FieldInstanceID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Label(); ok {
+		_spec.SetField(terminalshare.FieldLabel, field.TypeString, value)
+	}
+	if _u.mutation.LabelCleared() {
+		_spec.ClearField(terminalshare.FieldLabel, field.TypeString)
+	}
+	if value, ok := _u.mutation.TokenHash(); ok {
+		_spec.SetField(terminalshare.FieldTokenHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.TokenPrefix(); ok {
+		_spec.SetField(terminalshare.FieldTokenPrefix, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(terminalshare.FieldExpiresAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.RevokedAt(); ok {
+		_spec.SetField(terminalshare.FieldRevokedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RevokedAtCleared() {
+		_spec.ClearField(terminalshare.FieldRevokedAt, field.TypeTime)
+	}
+	if _u.mutation.CreatorCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   terminalshare.CreatorTable,
+			Columns: []string{terminalshare.CreatorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.CreatorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   terminalshare.CreatorTable,
+			Columns: []string{terminalshare.CreatorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &TerminalShare{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{terminalshare.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -12,14 +12,44 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// AccessToken is the client for interacting with the AccessToken builders.
+	AccessToken *AccessTokenClient
+	// AuditEvent is the client for interacting with the AuditEvent builders.
+	AuditEvent *AuditEventClient
+	// Backup is the client for interacting with the Backup builders.
+	Backup *BackupClient
 	// ChatMessage is the client for interacting with the ChatMessage builders.
 	ChatMessage *ChatMessageClient
 	// Conversation is the client for interacting with the Conversation builders.
 	Conversation *ConversationClient
+	// DeviceCode is the client for interacting with the DeviceCode builders.
+	DeviceCode *DeviceCodeClient
+	// Identity is the client for interacting with the Identity builders.
+	Identity *IdentityClient
 	// Instance is the client for interacting with the Instance builders.
 	Instance *InstanceClient
+	// Job is the client for interacting with the Job builders.
+	Job *JobClient
+	// MagicLink is the client for interacting with the MagicLink builders.
+	MagicLink *MagicLinkClient
+	// Membership is the client for interacting with the Membership builders.
+	Membership *MembershipClient
+	// Organization is the client for interacting with the Organization builders.
+	Organization *OrganizationClient
+	// Recording is the client for interacting with the Recording builders.
+	Recording *RecordingClient
+	// Secret is the client for interacting with the Secret builders.
+	Secret *SecretClient
+	// Session is the client for interacting with the Session builders.
+	Session *SessionClient
+	// Template is the client for interacting with the Template builders.
+	Template *TemplateClient
+	// TerminalShare is the client for interacting with the TerminalShare builders.
+	TerminalShare *TerminalShareClient
 	// User is the client for interacting with the User builders.
 	User *UserClient
+	// WarmInstance is the client for interacting with the WarmInstance builders.
+	WarmInstance *WarmInstanceClient
 
 	// lazily loaded.
 	client     *Client
@@ -151,10 +181,25 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.AccessToken = NewAccessTokenClient(tx.config)
+	tx.AuditEvent = NewAuditEventClient(tx.config)
+	tx.Backup = NewBackupClient(tx.config)
 	tx.ChatMessage = NewChatMessageClient(tx.config)
 	tx.Conversation = NewConversationClient(tx.config)
+	tx.DeviceCode = NewDeviceCodeClient(tx.config)
+	tx.Identity = NewIdentityClient(tx.config)
 	tx.Instance = NewInstanceClient(tx.config)
+	tx.Job = NewJobClient(tx.config)
+	tx.MagicLink = NewMagicLinkClient(tx.config)
+	tx.Membership = NewMembershipClient(tx.config)
+	tx.Organization = NewOrganizationClient(tx.config)
+	tx.Recording = NewRecordingClient(tx.config)
+	tx.Secret = NewSecretClient(tx.config)
+	tx.Session = NewSessionClient(tx.config)
+	tx.Template = NewTemplateClient(tx.config)
+	tx.TerminalShare = NewTerminalShareClient(tx.config)
 	tx.User = NewUserClient(tx.config)
+	tx.WarmInstance = NewWarmInstanceClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -164,7 +209,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: ChatMessage.QueryXXX(), the query will be executed
+// applies a query, for example: AccessToken.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
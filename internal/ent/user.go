@@ -19,8 +19,6 @@ type User struct {
 	ID int `json:"id,omitempty"`
 	// Email holds the value of the "email" field.
 	Email string `json:"email,omitempty"`
-	// APIKey holds the value of the "api_key" field.
-	APIKey *string `json:"api_key,omitempty"`
 	// Name holds the value of the "name" field.
 	Name string `json:"name,omitempty"`
 	// StripeCustomerID holds the value of the "stripe_customer_id" field.
@@ -34,9 +32,15 @@ type User struct {
 	// UsageHours holds the value of the "usage_hours" field.
 	UsageHours float64 `json:"usage_hours,omitempty"`
 	// User's Anthropic API key for Claude Code (API pay-as-you-go billing)
-	AnthropicAPIKey *string `json:"-"`
+	AnthropicAPIKey string `json:"-"`
 	// User's Claude.ai OAuth token for Claude Code (Pro/Max subscription billing)
-	ClaudeOauthToken *string `json:"-"`
+	ClaudeOauthToken string `json:"-"`
+	// Failed magic link sign-ins since magic_link_failed_at's window began
+	MagicLinkFailures int `json:"magic_link_failures,omitempty"`
+	// MagicLinkFailedAt holds the value of the "magic_link_failed_at" field.
+	MagicLinkFailedAt *time.Time `json:"magic_link_failed_at,omitempty"`
+	// Record terminal sessions on the user's instances: output only, or output and input
+	TerminalRecording user.TerminalRecording `json:"terminal_recording,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// UpdatedAt holds the value of the "updated_at" field.
@@ -53,9 +57,33 @@ type UserEdges struct {
 	Instances []*Instance `json:"instances,omitempty"`
 	// Conversations holds the value of the conversations edge.
 	Conversations []*Conversation `json:"conversations,omitempty"`
+	// Jobs holds the value of the jobs edge.
+	Jobs []*Job `json:"jobs,omitempty"`
+	// Backups holds the value of the backups edge.
+	Backups []*Backup `json:"backups,omitempty"`
+	// Templates holds the value of the templates edge.
+	Templates []*Template `json:"templates,omitempty"`
+	// Secrets holds the value of the secrets edge.
+	Secrets []*Secret `json:"secrets,omitempty"`
+	// AccessTokens holds the value of the access_tokens edge.
+	AccessTokens []*AccessToken `json:"access_tokens,omitempty"`
+	// DeviceCodes holds the value of the device_codes edge.
+	DeviceCodes []*DeviceCode `json:"device_codes,omitempty"`
+	// Identities holds the value of the identities edge.
+	Identities []*Identity `json:"identities,omitempty"`
+	// Memberships holds the value of the memberships edge.
+	Memberships []*Membership `json:"memberships,omitempty"`
+	// Sessions holds the value of the sessions edge.
+	Sessions []*Session `json:"sessions,omitempty"`
+	// MagicLinks holds the value of the magic_links edge.
+	MagicLinks []*MagicLink `json:"magic_links,omitempty"`
+	// Recordings holds the value of the recordings edge.
+	Recordings []*Recording `json:"recordings,omitempty"`
+	// TerminalShares holds the value of the terminal_shares edge.
+	TerminalShares []*TerminalShare `json:"terminal_shares,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [2]bool
+	loadedTypes [14]bool
 }
 
 // InstancesOrErr returns the Instances value or an error if the edge
@@ -76,6 +104,114 @@ func (e UserEdges) ConversationsOrErr() ([]*Conversation, error) {
 	return nil, &NotLoadedError{edge: "conversations"}
 }
 
+// JobsOrErr returns the Jobs value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) JobsOrErr() ([]*Job, error) {
+	if e.loadedTypes[2] {
+		return e.Jobs, nil
+	}
+	return nil, &NotLoadedError{edge: "jobs"}
+}
+
+// BackupsOrErr returns the Backups value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) BackupsOrErr() ([]*Backup, error) {
+	if e.loadedTypes[3] {
+		return e.Backups, nil
+	}
+	return nil, &NotLoadedError{edge: "backups"}
+}
+
+// TemplatesOrErr returns the Templates value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) TemplatesOrErr() ([]*Template, error) {
+	if e.loadedTypes[4] {
+		return e.Templates, nil
+	}
+	return nil, &NotLoadedError{edge: "templates"}
+}
+
+// SecretsOrErr returns the Secrets value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) SecretsOrErr() ([]*Secret, error) {
+	if e.loadedTypes[5] {
+		return e.Secrets, nil
+	}
+	return nil, &NotLoadedError{edge: "secrets"}
+}
+
+// AccessTokensOrErr returns the AccessTokens value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) AccessTokensOrErr() ([]*AccessToken, error) {
+	if e.loadedTypes[6] {
+		return e.AccessTokens, nil
+	}
+	return nil, &NotLoadedError{edge: "access_tokens"}
+}
+
+// DeviceCodesOrErr returns the DeviceCodes value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) DeviceCodesOrErr() ([]*DeviceCode, error) {
+	if e.loadedTypes[7] {
+		return e.DeviceCodes, nil
+	}
+	return nil, &NotLoadedError{edge: "device_codes"}
+}
+
+// IdentitiesOrErr returns the Identities value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) IdentitiesOrErr() ([]*Identity, error) {
+	if e.loadedTypes[8] {
+		return e.Identities, nil
+	}
+	return nil, &NotLoadedError{edge: "identities"}
+}
+
+// MembershipsOrErr returns the Memberships value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) MembershipsOrErr() ([]*Membership, error) {
+	if e.loadedTypes[9] {
+		return e.Memberships, nil
+	}
+	return nil, &NotLoadedError{edge: "memberships"}
+}
+
+// SessionsOrErr returns the Sessions value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) SessionsOrErr() ([]*Session, error) {
+	if e.loadedTypes[10] {
+		return e.Sessions, nil
+	}
+	return nil, &NotLoadedError{edge: "sessions"}
+}
+
+// MagicLinksOrErr returns the MagicLinks value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) MagicLinksOrErr() ([]*MagicLink, error) {
+	if e.loadedTypes[11] {
+		return e.MagicLinks, nil
+	}
+	return nil, &NotLoadedError{edge: "magic_links"}
+}
+
+// RecordingsOrErr returns the Recordings value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) RecordingsOrErr() ([]*Recording, error) {
+	if e.loadedTypes[12] {
+		return e.Recordings, nil
+	}
+	return nil, &NotLoadedError{edge: "recordings"}
+}
+
+// TerminalSharesOrErr returns the TerminalShares value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) TerminalSharesOrErr() ([]*TerminalShare, error) {
+	if e.loadedTypes[13] {
+		return e.TerminalShares, nil
+	}
+	return nil, &NotLoadedError{edge: "terminal_shares"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*User) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -83,12 +219,16 @@ func (*User) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case user.FieldUsageHours:
 			values[i] = new(sql.NullFloat64)
-		case user.FieldID:
+		case user.FieldID, user.FieldMagicLinkFailures:
 			values[i] = new(sql.NullInt64)
-		case user.FieldEmail, user.FieldAPIKey, user.FieldName, user.FieldStripeCustomerID, user.FieldStripeSubscriptionID, user.FieldSubscriptionStatus, user.FieldPlan, user.FieldAnthropicAPIKey, user.FieldClaudeOauthToken:
+		case user.FieldEmail, user.FieldName, user.FieldStripeCustomerID, user.FieldStripeSubscriptionID, user.FieldSubscriptionStatus, user.FieldPlan, user.FieldTerminalRecording:
 			values[i] = new(sql.NullString)
-		case user.FieldCreatedAt, user.FieldUpdatedAt:
+		case user.FieldMagicLinkFailedAt, user.FieldCreatedAt, user.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
+		case user.FieldAnthropicAPIKey:
+			values[i] = user.ValueScanner.AnthropicAPIKey.ScanValue()
+		case user.FieldClaudeOauthToken:
+			values[i] = user.ValueScanner.ClaudeOauthToken.ScanValue()
 		default:
 			values[i] = new(sql.UnknownType)
 		}
@@ -116,13 +256,6 @@ func (_m *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Email = value.String
 			}
-		case user.FieldAPIKey:
-			if value, ok := values[i].(*sql.NullString); !ok {
-				return fmt.Errorf("unexpected type %T for field api_key", values[i])
-			} else if value.Valid {
-				_m.APIKey = new(string)
-				*_m.APIKey = value.String
-			}
 		case user.FieldName:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field name", values[i])
@@ -162,18 +295,35 @@ func (_m *User) assignValues(columns []string, values []any) error {
 				_m.UsageHours = value.Float64
 			}
 		case user.FieldAnthropicAPIKey:
-			if value, ok := values[i].(*sql.NullString); !ok {
-				return fmt.Errorf("unexpected type %T for field anthropic_api_key", values[i])
-			} else if value.Valid {
-				_m.AnthropicAPIKey = new(string)
-				*_m.AnthropicAPIKey = value.String
+			if value, err := user.ValueScanner.AnthropicAPIKey.FromValue(values[i]); err != nil {
+				return err
+			} else {
+				_m.AnthropicAPIKey = value
 			}
 		case user.FieldClaudeOauthToken:
+			if value, err := user.ValueScanner.ClaudeOauthToken.FromValue(values[i]); err != nil {
+				return err
+			} else {
+				_m.ClaudeOauthToken = value
+			}
+		case user.FieldMagicLinkFailures:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field magic_link_failures", values[i])
+			} else if value.Valid {
+				_m.MagicLinkFailures = int(value.Int64)
+			}
+		case user.FieldMagicLinkFailedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field magic_link_failed_at", values[i])
+			} else if value.Valid {
+				_m.MagicLinkFailedAt = new(time.Time)
+				*_m.MagicLinkFailedAt = value.Time
+			}
+		case user.FieldTerminalRecording:
 			if value, ok := values[i].(*sql.NullString); !ok {
-				return fmt.Errorf("unexpected type %T for field claude_oauth_token", values[i])
+				return fmt.Errorf("unexpected type %T for field terminal_recording", values[i])
 			} else if value.Valid {
-				_m.ClaudeOauthToken = new(string)
-				*_m.ClaudeOauthToken = value.String
+				_m.TerminalRecording = user.TerminalRecording(value.String)
 			}
 		case user.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
@@ -210,6 +360,66 @@ func (_m *User) QueryConversations() *ConversationQuery {
 	return NewUserClient(_m.config).QueryConversations(_m)
 }
 
+// QueryJobs queries the "jobs" edge of the User entity.
+func (_m *User) QueryJobs() *JobQuery {
+	return NewUserClient(_m.config).QueryJobs(_m)
+}
+
+// QueryBackups queries the "backups" edge of the User entity.
+func (_m *User) QueryBackups() *BackupQuery {
+	return NewUserClient(_m.config).QueryBackups(_m)
+}
+
+// QueryTemplates queries the "templates" edge of the User entity.
+func (_m *User) QueryTemplates() *TemplateQuery {
+	return NewUserClient(_m.config).QueryTemplates(_m)
+}
+
+// QuerySecrets queries the "secrets" edge of the User entity.
+func (_m *User) QuerySecrets() *SecretQuery {
+	return NewUserClient(_m.config).QuerySecrets(_m)
+}
+
+// QueryAccessTokens queries the "access_tokens" edge of the User entity.
+func (_m *User) QueryAccessTokens() *AccessTokenQuery {
+	return NewUserClient(_m.config).QueryAccessTokens(_m)
+}
+
+// QueryDeviceCodes queries the "device_codes" edge of the User entity.
+func (_m *User) QueryDeviceCodes() *DeviceCodeQuery {
+	return NewUserClient(_m.config).QueryDeviceCodes(_m)
+}
+
+// QueryIdentities queries the "identities" edge of the User entity.
+func (_m *User) QueryIdentities() *IdentityQuery {
+	return NewUserClient(_m.config).QueryIdentities(_m)
+}
+
+// QueryMemberships queries the "memberships" edge of the User entity.
+func (_m *User) QueryMemberships() *MembershipQuery {
+	return NewUserClient(_m.config).QueryMemberships(_m)
+}
+
+// QuerySessions queries the "sessions" edge of the User entity.
+func (_m *User) QuerySessions() *SessionQuery {
+	return NewUserClient(_m.config).QuerySessions(_m)
+}
+
+// QueryMagicLinks queries the "magic_links" edge of the User entity.
+func (_m *User) QueryMagicLinks() *MagicLinkQuery {
+	return NewUserClient(_m.config).QueryMagicLinks(_m)
+}
+
+// QueryRecordings queries the "recordings" edge of the User entity.
+func (_m *User) QueryRecordings() *RecordingQuery {
+	return NewUserClient(_m.config).QueryRecordings(_m)
+}
+
+// QueryTerminalShares queries the "terminal_shares" edge of the User entity.
+func (_m *User) QueryTerminalShares() *TerminalShareQuery {
+	return NewUserClient(_m.config).QueryTerminalShares(_m)
+}
+
 // Update returns a builder for updating this User.
 // Note that you need to call User.Unwrap() before calling this method if this User
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -236,11 +446,6 @@ func (_m *User) String() string {
 	builder.WriteString("email=")
 	builder.WriteString(_m.Email)
 	builder.WriteString(", ")
-	if v := _m.APIKey; v != nil {
-		builder.WriteString("api_key=")
-		builder.WriteString(*v)
-	}
-	builder.WriteString(", ")
 	builder.WriteString("name=")
 	builder.WriteString(_m.Name)
 	builder.WriteString(", ")
@@ -267,6 +472,17 @@ func (_m *User) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("claude_oauth_token=<sensitive>")
 	builder.WriteString(", ")
+	builder.WriteString("magic_link_failures=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MagicLinkFailures))
+	builder.WriteString(", ")
+	if v := _m.MagicLinkFailedAt; v != nil {
+		builder.WriteString("magic_link_failed_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("terminal_recording=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TerminalRecording))
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
@@ -3,10 +3,12 @@
 package user
 
 import (
+	"fmt"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
 )
 
 const (
@@ -16,8 +18,6 @@ const (
 	FieldID = "id"
 	// FieldEmail holds the string denoting the email field in the database.
 	FieldEmail = "email"
-	// FieldAPIKey holds the string denoting the api_key field in the database.
-	FieldAPIKey = "api_key"
 	// FieldName holds the string denoting the name field in the database.
 	FieldName = "name"
 	// FieldStripeCustomerID holds the string denoting the stripe_customer_id field in the database.
@@ -34,6 +34,12 @@ const (
 	FieldAnthropicAPIKey = "anthropic_api_key"
 	// FieldClaudeOauthToken holds the string denoting the claude_oauth_token field in the database.
 	FieldClaudeOauthToken = "claude_oauth_token"
+	// FieldMagicLinkFailures holds the string denoting the magic_link_failures field in the database.
+	FieldMagicLinkFailures = "magic_link_failures"
+	// FieldMagicLinkFailedAt holds the string denoting the magic_link_failed_at field in the database.
+	FieldMagicLinkFailedAt = "magic_link_failed_at"
+	// FieldTerminalRecording holds the string denoting the terminal_recording field in the database.
+	FieldTerminalRecording = "terminal_recording"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
@@ -42,6 +48,30 @@ const (
 	EdgeInstances = "instances"
 	// EdgeConversations holds the string denoting the conversations edge name in mutations.
 	EdgeConversations = "conversations"
+	// EdgeJobs holds the string denoting the jobs edge name in mutations.
+	EdgeJobs = "jobs"
+	// EdgeBackups holds the string denoting the backups edge name in mutations.
+	EdgeBackups = "backups"
+	// EdgeTemplates holds the string denoting the templates edge name in mutations.
+	EdgeTemplates = "templates"
+	// EdgeSecrets holds the string denoting the secrets edge name in mutations.
+	EdgeSecrets = "secrets"
+	// EdgeAccessTokens holds the string denoting the access_tokens edge name in mutations.
+	EdgeAccessTokens = "access_tokens"
+	// EdgeDeviceCodes holds the string denoting the device_codes edge name in mutations.
+	EdgeDeviceCodes = "device_codes"
+	// EdgeIdentities holds the string denoting the identities edge name in mutations.
+	EdgeIdentities = "identities"
+	// EdgeMemberships holds the string denoting the memberships edge name in mutations.
+	EdgeMemberships = "memberships"
+	// EdgeSessions holds the string denoting the sessions edge name in mutations.
+	EdgeSessions = "sessions"
+	// EdgeMagicLinks holds the string denoting the magic_links edge name in mutations.
+	EdgeMagicLinks = "magic_links"
+	// EdgeRecordings holds the string denoting the recordings edge name in mutations.
+	EdgeRecordings = "recordings"
+	// EdgeTerminalShares holds the string denoting the terminal_shares edge name in mutations.
+	EdgeTerminalShares = "terminal_shares"
 	// Table holds the table name of the user in the database.
 	Table = "users"
 	// InstancesTable is the table that holds the instances relation/edge.
@@ -58,13 +88,96 @@ const (
 	ConversationsInverseTable = "conversations"
 	// ConversationsColumn is the table column denoting the conversations relation/edge.
 	ConversationsColumn = "user_conversations"
+	// JobsTable is the table that holds the jobs relation/edge.
+	JobsTable = "jobs"
+	// JobsInverseTable is the table name for the Job entity.
+	// It exists in this package in order to avoid circular dependency with the "job" package.
+	JobsInverseTable = "jobs"
+	// JobsColumn is the table column denoting the jobs relation/edge.
+	JobsColumn = "user_jobs"
+	// BackupsTable is the table that holds the backups relation/edge.
+	BackupsTable = "backups"
+	// BackupsInverseTable is the table name for the Backup entity.
+	// It exists in this package in order to avoid circular dependency with the "backup" package.
+	BackupsInverseTable = "backups"
+	// BackupsColumn is the table column denoting the backups relation/edge.
+	BackupsColumn = "user_backups"
+	// TemplatesTable is the table that holds the templates relation/edge.
+	TemplatesTable = "templates"
+	// TemplatesInverseTable is the table name for the Template entity.
+	// It exists in this package in order to avoid circular dependency with the "template" package.
+	TemplatesInverseTable = "templates"
+	// TemplatesColumn is the table column denoting the templates relation/edge.
+	TemplatesColumn = "user_templates"
+	// SecretsTable is the table that holds the secrets relation/edge.
+	SecretsTable = "secrets"
+	// SecretsInverseTable is the table name for the Secret entity.
+	// It exists in this package in order to avoid circular dependency with the "secret" package.
+	SecretsInverseTable = "secrets"
+	// SecretsColumn is the table column denoting the secrets relation/edge.
+	SecretsColumn = "user_secrets"
+	// AccessTokensTable is the table that holds the access_tokens relation/edge.
+	AccessTokensTable = "access_tokens"
+	// AccessTokensInverseTable is the table name for the AccessToken entity.
+	// It exists in this package in order to avoid circular dependency with the "accesstoken" package.
+	AccessTokensInverseTable = "access_tokens"
+	// AccessTokensColumn is the table column denoting the access_tokens relation/edge.
+	AccessTokensColumn = "user_access_tokens"
+	// DeviceCodesTable is the table that holds the device_codes relation/edge.
+	DeviceCodesTable = "device_codes"
+	// DeviceCodesInverseTable is the table name for the DeviceCode entity.
+	// It exists in this package in order to avoid circular dependency with the "devicecode" package.
+	DeviceCodesInverseTable = "device_codes"
+	// DeviceCodesColumn is the table column denoting the device_codes relation/edge.
+	DeviceCodesColumn = "user_device_codes"
+	// IdentitiesTable is the table that holds the identities relation/edge.
+	IdentitiesTable = "identities"
+	// IdentitiesInverseTable is the table name for the Identity entity.
+	// It exists in this package in order to avoid circular dependency with the "identity" package.
+	IdentitiesInverseTable = "identities"
+	// IdentitiesColumn is the table column denoting the identities relation/edge.
+	IdentitiesColumn = "user_identities"
+	// MembershipsTable is the table that holds the memberships relation/edge.
+	MembershipsTable = "memberships"
+	// MembershipsInverseTable is the table name for the Membership entity.
+	// It exists in this package in order to avoid circular dependency with the "membership" package.
+	MembershipsInverseTable = "memberships"
+	// MembershipsColumn is the table column denoting the memberships relation/edge.
+	MembershipsColumn = "user_memberships"
+	// SessionsTable is the table that holds the sessions relation/edge.
+	SessionsTable = "sessions"
+	// SessionsInverseTable is the table name for the Session entity.
+	// It exists in this package in order to avoid circular dependency with the "session" package.
+	SessionsInverseTable = "sessions"
+	// SessionsColumn is the table column denoting the sessions relation/edge.
+	SessionsColumn = "user_sessions"
+	// MagicLinksTable is the table that holds the magic_links relation/edge.
+	MagicLinksTable = "magic_links"
+	// MagicLinksInverseTable is the table name for the MagicLink entity.
+	// It exists in this package in order to avoid circular dependency with the "magiclink" package.
+	MagicLinksInverseTable = "magic_links"
+	// MagicLinksColumn is the table column denoting the magic_links relation/edge.
+	MagicLinksColumn = "user_magic_links"
+	// RecordingsTable is the table that holds the recordings relation/edge.
+	RecordingsTable = "recordings"
+	// RecordingsInverseTable is the table name for the Recording entity.
+	// It exists in this package in order to avoid circular dependency with the "recording" package.
+	RecordingsInverseTable = "recordings"
+	// RecordingsColumn is the table column denoting the recordings relation/edge.
+	RecordingsColumn = "user_recordings"
+	// TerminalSharesTable is the table that holds the terminal_shares relation/edge.
+	TerminalSharesTable = "terminal_shares"
+	// TerminalSharesInverseTable is the table name for the TerminalShare entity.
+	// It exists in this package in order to avoid circular dependency with the "terminalshare" package.
+	TerminalSharesInverseTable = "terminal_shares"
+	// TerminalSharesColumn is the table column denoting the terminal_shares relation/edge.
+	TerminalSharesColumn = "user_terminal_shares"
 )
 
 // Columns holds all SQL columns for user fields.
 var Columns = []string{
 	FieldID,
 	FieldEmail,
-	FieldAPIKey,
 	FieldName,
 	FieldStripeCustomerID,
 	FieldStripeSubscriptionID,
@@ -73,6 +186,9 @@ var Columns = []string{
 	FieldUsageHours,
 	FieldAnthropicAPIKey,
 	FieldClaudeOauthToken,
+	FieldMagicLinkFailures,
+	FieldMagicLinkFailedAt,
+	FieldTerminalRecording,
 	FieldCreatedAt,
 	FieldUpdatedAt,
 }
@@ -98,14 +214,48 @@ var (
 	DefaultPlan string
 	// DefaultUsageHours holds the default value on creation for the "usage_hours" field.
 	DefaultUsageHours float64
+	// DefaultMagicLinkFailures holds the default value on creation for the "magic_link_failures" field.
+	DefaultMagicLinkFailures int
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
 	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
 	DefaultUpdatedAt func() time.Time
 	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
 	UpdateDefaultUpdatedAt func() time.Time
+	// ValueScanner of all User fields.
+	ValueScanner struct {
+		AnthropicAPIKey  field.TypeValueScanner[string]
+		ClaudeOauthToken field.TypeValueScanner[string]
+	}
+)
+
+// TerminalRecording defines the type for the "terminal_recording" enum field.
+type TerminalRecording string
+
+// TerminalRecordingOff is the default value of the TerminalRecording enum.
+const DefaultTerminalRecording = TerminalRecordingOff
+
+// TerminalRecording values.
+const (
+	TerminalRecordingOff    TerminalRecording = "off"
+	TerminalRecordingOutput TerminalRecording = "output"
+	TerminalRecordingFull   TerminalRecording = "full"
 )
 
+func (tr TerminalRecording) String() string {
+	return string(tr)
+}
+
+// TerminalRecordingValidator is a validator for the "terminal_recording" field enum values. It is called by the builders before save.
+func TerminalRecordingValidator(tr TerminalRecording) error {
+	switch tr {
+	case TerminalRecordingOff, TerminalRecordingOutput, TerminalRecordingFull:
+		return nil
+	default:
+		return fmt.Errorf("user: invalid enum value for terminal_recording field: %q", tr)
+	}
+}
+
 // OrderOption defines the ordering options for the User queries.
 type OrderOption func(*sql.Selector)
 
@@ -119,11 +269,6 @@ func ByEmail(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldEmail, opts...).ToFunc()
 }
 
-// ByAPIKey orders the results by the api_key field.
-func ByAPIKey(opts ...sql.OrderTermOption) OrderOption {
-	return sql.OrderByField(FieldAPIKey, opts...).ToFunc()
-}
-
 // ByName orders the results by the name field.
 func ByName(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldName, opts...).ToFunc()
@@ -164,6 +309,21 @@ func ByClaudeOauthToken(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldClaudeOauthToken, opts...).ToFunc()
 }
 
+// ByMagicLinkFailures orders the results by the magic_link_failures field.
+func ByMagicLinkFailures(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMagicLinkFailures, opts...).ToFunc()
+}
+
+// ByMagicLinkFailedAt orders the results by the magic_link_failed_at field.
+func ByMagicLinkFailedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMagicLinkFailedAt, opts...).ToFunc()
+}
+
+// ByTerminalRecording orders the results by the terminal_recording field.
+func ByTerminalRecording(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTerminalRecording, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -201,6 +361,174 @@ func ByConversations(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newConversationsStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// ByJobsCount orders the results by jobs count.
+func ByJobsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newJobsStep(), opts...)
+	}
+}
+
+// ByJobs orders the results by jobs terms.
+func ByJobs(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newJobsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByBackupsCount orders the results by backups count.
+func ByBackupsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newBackupsStep(), opts...)
+	}
+}
+
+// ByBackups orders the results by backups terms.
+func ByBackups(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newBackupsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByTemplatesCount orders the results by templates count.
+func ByTemplatesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newTemplatesStep(), opts...)
+	}
+}
+
+// ByTemplates orders the results by templates terms.
+func ByTemplates(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newTemplatesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// BySecretsCount orders the results by secrets count.
+func BySecretsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newSecretsStep(), opts...)
+	}
+}
+
+// BySecrets orders the results by secrets terms.
+func BySecrets(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newSecretsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByAccessTokensCount orders the results by access_tokens count.
+func ByAccessTokensCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newAccessTokensStep(), opts...)
+	}
+}
+
+// ByAccessTokens orders the results by access_tokens terms.
+func ByAccessTokens(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newAccessTokensStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByDeviceCodesCount orders the results by device_codes count.
+func ByDeviceCodesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newDeviceCodesStep(), opts...)
+	}
+}
+
+// ByDeviceCodes orders the results by device_codes terms.
+func ByDeviceCodes(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newDeviceCodesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByIdentitiesCount orders the results by identities count.
+func ByIdentitiesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newIdentitiesStep(), opts...)
+	}
+}
+
+// ByIdentities orders the results by identities terms.
+func ByIdentities(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newIdentitiesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByMembershipsCount orders the results by memberships count.
+func ByMembershipsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newMembershipsStep(), opts...)
+	}
+}
+
+// ByMemberships orders the results by memberships terms.
+func ByMemberships(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newMembershipsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// BySessionsCount orders the results by sessions count.
+func BySessionsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newSessionsStep(), opts...)
+	}
+}
+
+// BySessions orders the results by sessions terms.
+func BySessions(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newSessionsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByMagicLinksCount orders the results by magic_links count.
+func ByMagicLinksCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newMagicLinksStep(), opts...)
+	}
+}
+
+// ByMagicLinks orders the results by magic_links terms.
+func ByMagicLinks(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newMagicLinksStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByRecordingsCount orders the results by recordings count.
+func ByRecordingsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newRecordingsStep(), opts...)
+	}
+}
+
+// ByRecordings orders the results by recordings terms.
+func ByRecordings(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newRecordingsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByTerminalSharesCount orders the results by terminal_shares count.
+func ByTerminalSharesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newTerminalSharesStep(), opts...)
+	}
+}
+
+// ByTerminalShares orders the results by terminal_shares terms.
+func ByTerminalShares(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newTerminalSharesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newInstancesStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -215,3 +543,87 @@ func newConversationsStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, ConversationsTable, ConversationsColumn),
 	)
 }
+func newJobsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(JobsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, JobsTable, JobsColumn),
+	)
+}
+func newBackupsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(BackupsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, BackupsTable, BackupsColumn),
+	)
+}
+func newTemplatesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(TemplatesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, TemplatesTable, TemplatesColumn),
+	)
+}
+func newSecretsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(SecretsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, SecretsTable, SecretsColumn),
+	)
+}
+func newAccessTokensStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(AccessTokensInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, AccessTokensTable, AccessTokensColumn),
+	)
+}
+func newDeviceCodesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(DeviceCodesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, DeviceCodesTable, DeviceCodesColumn),
+	)
+}
+func newIdentitiesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(IdentitiesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, IdentitiesTable, IdentitiesColumn),
+	)
+}
+func newMembershipsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(MembershipsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, MembershipsTable, MembershipsColumn),
+	)
+}
+func newSessionsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(SessionsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, SessionsTable, SessionsColumn),
+	)
+}
+func newMagicLinksStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(MagicLinksInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, MagicLinksTable, MagicLinksColumn),
+	)
+}
+func newRecordingsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(RecordingsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, RecordingsTable, RecordingsColumn),
+	)
+}
+func newTerminalSharesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(TerminalSharesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, TerminalSharesTable, TerminalSharesColumn),
+	)
+}
@@ -3,6 +3,7 @@
 package user
 
 import (
+	"fmt"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -60,11 +61,6 @@ func Email(v string) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldEmail, v))
 }
 
-// APIKey applies equality check predicate on the "api_key" field. It's identical to APIKeyEQ.
-func APIKey(v string) predicate.User {
-	return predicate.User(sql.FieldEQ(FieldAPIKey, v))
-}
-
 // Name applies equality check predicate on the "name" field. It's identical to NameEQ.
 func Name(v string) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldName, v))
@@ -97,12 +93,24 @@ func UsageHours(v float64) predicate.User {
 
 // AnthropicAPIKey applies equality check predicate on the "anthropic_api_key" field. It's identical to AnthropicAPIKeyEQ.
 func AnthropicAPIKey(v string) predicate.User {
-	return predicate.User(sql.FieldEQ(FieldAnthropicAPIKey, v))
+	vc, err := ValueScanner.AnthropicAPIKey.Value(v)
+	return predicate.UserOrErr(sql.FieldEQ(FieldAnthropicAPIKey, vc), err)
 }
 
 // ClaudeOauthToken applies equality check predicate on the "claude_oauth_token" field. It's identical to ClaudeOauthTokenEQ.
 func ClaudeOauthToken(v string) predicate.User {
-	return predicate.User(sql.FieldEQ(FieldClaudeOauthToken, v))
+	vc, err := ValueScanner.ClaudeOauthToken.Value(v)
+	return predicate.UserOrErr(sql.FieldEQ(FieldClaudeOauthToken, vc), err)
+}
+
+// MagicLinkFailures applies equality check predicate on the "magic_link_failures" field. It's identical to MagicLinkFailuresEQ.
+func MagicLinkFailures(v int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldMagicLinkFailures, v))
+}
+
+// MagicLinkFailedAt applies equality check predicate on the "magic_link_failed_at" field. It's identical to MagicLinkFailedAtEQ.
+func MagicLinkFailedAt(v time.Time) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldMagicLinkFailedAt, v))
 }
 
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
@@ -180,81 +188,6 @@ func EmailContainsFold(v string) predicate.User {
 	return predicate.User(sql.FieldContainsFold(FieldEmail, v))
 }
 
-// APIKeyEQ applies the EQ predicate on the "api_key" field.
-func APIKeyEQ(v string) predicate.User {
-	return predicate.User(sql.FieldEQ(FieldAPIKey, v))
-}
-
-// APIKeyNEQ applies the NEQ predicate on the "api_key" field.
-func APIKeyNEQ(v string) predicate.User {
-	return predicate.User(sql.FieldNEQ(FieldAPIKey, v))
-}
-
-// APIKeyIn applies the In predicate on the "api_key" field.
-func APIKeyIn(vs ...string) predicate.User {
-	return predicate.User(sql.FieldIn(FieldAPIKey, vs...))
-}
-
-// APIKeyNotIn applies the NotIn predicate on the "api_key" field.
-func APIKeyNotIn(vs ...string) predicate.User {
-	return predicate.User(sql.FieldNotIn(FieldAPIKey, vs...))
-}
-
-// APIKeyGT applies the GT predicate on the "api_key" field.
-func APIKeyGT(v string) predicate.User {
-	return predicate.User(sql.FieldGT(FieldAPIKey, v))
-}
-
-// APIKeyGTE applies the GTE predicate on the "api_key" field.
-func APIKeyGTE(v string) predicate.User {
-	return predicate.User(sql.FieldGTE(FieldAPIKey, v))
-}
-
-// APIKeyLT applies the LT predicate on the "api_key" field.
-func APIKeyLT(v string) predicate.User {
-	return predicate.User(sql.FieldLT(FieldAPIKey, v))
-}
-
-// APIKeyLTE applies the LTE predicate on the "api_key" field.
-func APIKeyLTE(v string) predicate.User {
-	return predicate.User(sql.FieldLTE(FieldAPIKey, v))
-}
-
-// APIKeyContains applies the Contains predicate on the "api_key" field.
-func APIKeyContains(v string) predicate.User {
-	return predicate.User(sql.FieldContains(FieldAPIKey, v))
-}
-
-// APIKeyHasPrefix applies the HasPrefix predicate on the "api_key" field.
-func APIKeyHasPrefix(v string) predicate.User {
-	return predicate.User(sql.FieldHasPrefix(FieldAPIKey, v))
-}
-
-// APIKeyHasSuffix applies the HasSuffix predicate on the "api_key" field.
-func APIKeyHasSuffix(v string) predicate.User {
-	return predicate.User(sql.FieldHasSuffix(FieldAPIKey, v))
-}
-
-// APIKeyIsNil applies the IsNil predicate on the "api_key" field.
-func APIKeyIsNil() predicate.User {
-	return predicate.User(sql.FieldIsNull(FieldAPIKey))
-}
-
-// APIKeyNotNil applies the NotNil predicate on the "api_key" field.
-func APIKeyNotNil() predicate.User {
-	return predicate.User(sql.FieldNotNull(FieldAPIKey))
-}
-
-// APIKeyEqualFold applies the EqualFold predicate on the "api_key" field.
-func APIKeyEqualFold(v string) predicate.User {
-	return predicate.User(sql.FieldEqualFold(FieldAPIKey, v))
-}
-
-// APIKeyContainsFold applies the ContainsFold predicate on the "api_key" field.
-func APIKeyContainsFold(v string) predicate.User {
-	return predicate.User(sql.FieldContainsFold(FieldAPIKey, v))
-}
-
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldName, v))
@@ -652,57 +585,96 @@ func UsageHoursLTE(v float64) predicate.User {
 
 // AnthropicAPIKeyEQ applies the EQ predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyEQ(v string) predicate.User {
-	return predicate.User(sql.FieldEQ(FieldAnthropicAPIKey, v))
+	vc, err := ValueScanner.AnthropicAPIKey.Value(v)
+	return predicate.UserOrErr(sql.FieldEQ(FieldAnthropicAPIKey, vc), err)
 }
 
 // AnthropicAPIKeyNEQ applies the NEQ predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyNEQ(v string) predicate.User {
-	return predicate.User(sql.FieldNEQ(FieldAnthropicAPIKey, v))
+	vc, err := ValueScanner.AnthropicAPIKey.Value(v)
+	return predicate.UserOrErr(sql.FieldNEQ(FieldAnthropicAPIKey, vc), err)
 }
 
 // AnthropicAPIKeyIn applies the In predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyIn(vs ...string) predicate.User {
-	return predicate.User(sql.FieldIn(FieldAnthropicAPIKey, vs...))
+	var (
+		err error
+		v   = make([]any, len(vs))
+	)
+	for i := range v {
+		if v[i], err = ValueScanner.AnthropicAPIKey.Value(vs[i]); err != nil {
+			break
+		}
+	}
+	return predicate.UserOrErr(sql.FieldIn(FieldAnthropicAPIKey, v...), err)
 }
 
 // AnthropicAPIKeyNotIn applies the NotIn predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyNotIn(vs ...string) predicate.User {
-	return predicate.User(sql.FieldNotIn(FieldAnthropicAPIKey, vs...))
+	var (
+		err error
+		v   = make([]any, len(vs))
+	)
+	for i := range v {
+		if v[i], err = ValueScanner.AnthropicAPIKey.Value(vs[i]); err != nil {
+			break
+		}
+	}
+	return predicate.UserOrErr(sql.FieldNotIn(FieldAnthropicAPIKey, v...), err)
 }
 
 // AnthropicAPIKeyGT applies the GT predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyGT(v string) predicate.User {
-	return predicate.User(sql.FieldGT(FieldAnthropicAPIKey, v))
+	vc, err := ValueScanner.AnthropicAPIKey.Value(v)
+	return predicate.UserOrErr(sql.FieldGT(FieldAnthropicAPIKey, vc), err)
 }
 
 // AnthropicAPIKeyGTE applies the GTE predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyGTE(v string) predicate.User {
-	return predicate.User(sql.FieldGTE(FieldAnthropicAPIKey, v))
+	vc, err := ValueScanner.AnthropicAPIKey.Value(v)
+	return predicate.UserOrErr(sql.FieldGTE(FieldAnthropicAPIKey, vc), err)
 }
 
 // AnthropicAPIKeyLT applies the LT predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyLT(v string) predicate.User {
-	return predicate.User(sql.FieldLT(FieldAnthropicAPIKey, v))
+	vc, err := ValueScanner.AnthropicAPIKey.Value(v)
+	return predicate.UserOrErr(sql.FieldLT(FieldAnthropicAPIKey, vc), err)
 }
 
 // AnthropicAPIKeyLTE applies the LTE predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyLTE(v string) predicate.User {
-	return predicate.User(sql.FieldLTE(FieldAnthropicAPIKey, v))
+	vc, err := ValueScanner.AnthropicAPIKey.Value(v)
+	return predicate.UserOrErr(sql.FieldLTE(FieldAnthropicAPIKey, vc), err)
 }
 
 // AnthropicAPIKeyContains applies the Contains predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyContains(v string) predicate.User {
-	return predicate.User(sql.FieldContains(FieldAnthropicAPIKey, v))
+	vc, err := ValueScanner.AnthropicAPIKey.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("anthropic_api_key value is not a string: %T", vc)
+	}
+	return predicate.UserOrErr(sql.FieldContains(FieldAnthropicAPIKey, vcs), err)
 }
 
 // AnthropicAPIKeyHasPrefix applies the HasPrefix predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyHasPrefix(v string) predicate.User {
-	return predicate.User(sql.FieldHasPrefix(FieldAnthropicAPIKey, v))
+	vc, err := ValueScanner.AnthropicAPIKey.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("anthropic_api_key value is not a string: %T", vc)
+	}
+	return predicate.UserOrErr(sql.FieldHasPrefix(FieldAnthropicAPIKey, vcs), err)
 }
 
 // AnthropicAPIKeyHasSuffix applies the HasSuffix predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyHasSuffix(v string) predicate.User {
-	return predicate.User(sql.FieldHasSuffix(FieldAnthropicAPIKey, v))
+	vc, err := ValueScanner.AnthropicAPIKey.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("anthropic_api_key value is not a string: %T", vc)
+	}
+	return predicate.UserOrErr(sql.FieldHasSuffix(FieldAnthropicAPIKey, vcs), err)
 }
 
 // AnthropicAPIKeyIsNil applies the IsNil predicate on the "anthropic_api_key" field.
@@ -717,67 +689,116 @@ func AnthropicAPIKeyNotNil() predicate.User {
 
 // AnthropicAPIKeyEqualFold applies the EqualFold predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyEqualFold(v string) predicate.User {
-	return predicate.User(sql.FieldEqualFold(FieldAnthropicAPIKey, v))
+	vc, err := ValueScanner.AnthropicAPIKey.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("anthropic_api_key value is not a string: %T", vc)
+	}
+	return predicate.UserOrErr(sql.FieldEqualFold(FieldAnthropicAPIKey, vcs), err)
 }
 
 // AnthropicAPIKeyContainsFold applies the ContainsFold predicate on the "anthropic_api_key" field.
 func AnthropicAPIKeyContainsFold(v string) predicate.User {
-	return predicate.User(sql.FieldContainsFold(FieldAnthropicAPIKey, v))
+	vc, err := ValueScanner.AnthropicAPIKey.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("anthropic_api_key value is not a string: %T", vc)
+	}
+	return predicate.UserOrErr(sql.FieldContainsFold(FieldAnthropicAPIKey, vcs), err)
 }
 
 // ClaudeOauthTokenEQ applies the EQ predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenEQ(v string) predicate.User {
-	return predicate.User(sql.FieldEQ(FieldClaudeOauthToken, v))
+	vc, err := ValueScanner.ClaudeOauthToken.Value(v)
+	return predicate.UserOrErr(sql.FieldEQ(FieldClaudeOauthToken, vc), err)
 }
 
 // ClaudeOauthTokenNEQ applies the NEQ predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenNEQ(v string) predicate.User {
-	return predicate.User(sql.FieldNEQ(FieldClaudeOauthToken, v))
+	vc, err := ValueScanner.ClaudeOauthToken.Value(v)
+	return predicate.UserOrErr(sql.FieldNEQ(FieldClaudeOauthToken, vc), err)
 }
 
 // ClaudeOauthTokenIn applies the In predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenIn(vs ...string) predicate.User {
-	return predicate.User(sql.FieldIn(FieldClaudeOauthToken, vs...))
+	var (
+		err error
+		v   = make([]any, len(vs))
+	)
+	for i := range v {
+		if v[i], err = ValueScanner.ClaudeOauthToken.Value(vs[i]); err != nil {
+			break
+		}
+	}
+	return predicate.UserOrErr(sql.FieldIn(FieldClaudeOauthToken, v...), err)
 }
 
 // ClaudeOauthTokenNotIn applies the NotIn predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenNotIn(vs ...string) predicate.User {
-	return predicate.User(sql.FieldNotIn(FieldClaudeOauthToken, vs...))
+	var (
+		err error
+		v   = make([]any, len(vs))
+	)
+	for i := range v {
+		if v[i], err = ValueScanner.ClaudeOauthToken.Value(vs[i]); err != nil {
+			break
+		}
+	}
+	return predicate.UserOrErr(sql.FieldNotIn(FieldClaudeOauthToken, v...), err)
 }
 
 // ClaudeOauthTokenGT applies the GT predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenGT(v string) predicate.User {
-	return predicate.User(sql.FieldGT(FieldClaudeOauthToken, v))
+	vc, err := ValueScanner.ClaudeOauthToken.Value(v)
+	return predicate.UserOrErr(sql.FieldGT(FieldClaudeOauthToken, vc), err)
 }
 
 // ClaudeOauthTokenGTE applies the GTE predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenGTE(v string) predicate.User {
-	return predicate.User(sql.FieldGTE(FieldClaudeOauthToken, v))
+	vc, err := ValueScanner.ClaudeOauthToken.Value(v)
+	return predicate.UserOrErr(sql.FieldGTE(FieldClaudeOauthToken, vc), err)
 }
 
 // ClaudeOauthTokenLT applies the LT predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenLT(v string) predicate.User {
-	return predicate.User(sql.FieldLT(FieldClaudeOauthToken, v))
+	vc, err := ValueScanner.ClaudeOauthToken.Value(v)
+	return predicate.UserOrErr(sql.FieldLT(FieldClaudeOauthToken, vc), err)
 }
 
 // ClaudeOauthTokenLTE applies the LTE predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenLTE(v string) predicate.User {
-	return predicate.User(sql.FieldLTE(FieldClaudeOauthToken, v))
+	vc, err := ValueScanner.ClaudeOauthToken.Value(v)
+	return predicate.UserOrErr(sql.FieldLTE(FieldClaudeOauthToken, vc), err)
 }
 
 // ClaudeOauthTokenContains applies the Contains predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenContains(v string) predicate.User {
-	return predicate.User(sql.FieldContains(FieldClaudeOauthToken, v))
+	vc, err := ValueScanner.ClaudeOauthToken.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("claude_oauth_token value is not a string: %T", vc)
+	}
+	return predicate.UserOrErr(sql.FieldContains(FieldClaudeOauthToken, vcs), err)
 }
 
 // ClaudeOauthTokenHasPrefix applies the HasPrefix predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenHasPrefix(v string) predicate.User {
-	return predicate.User(sql.FieldHasPrefix(FieldClaudeOauthToken, v))
+	vc, err := ValueScanner.ClaudeOauthToken.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("claude_oauth_token value is not a string: %T", vc)
+	}
+	return predicate.UserOrErr(sql.FieldHasPrefix(FieldClaudeOauthToken, vcs), err)
 }
 
 // ClaudeOauthTokenHasSuffix applies the HasSuffix predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenHasSuffix(v string) predicate.User {
-	return predicate.User(sql.FieldHasSuffix(FieldClaudeOauthToken, v))
+	vc, err := ValueScanner.ClaudeOauthToken.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("claude_oauth_token value is not a string: %T", vc)
+	}
+	return predicate.UserOrErr(sql.FieldHasSuffix(FieldClaudeOauthToken, vcs), err)
 }
 
 // ClaudeOauthTokenIsNil applies the IsNil predicate on the "claude_oauth_token" field.
@@ -792,12 +813,132 @@ func ClaudeOauthTokenNotNil() predicate.User {
 
 // ClaudeOauthTokenEqualFold applies the EqualFold predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenEqualFold(v string) predicate.User {
-	return predicate.User(sql.FieldEqualFold(FieldClaudeOauthToken, v))
+	vc, err := ValueScanner.ClaudeOauthToken.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("claude_oauth_token value is not a string: %T", vc)
+	}
+	return predicate.UserOrErr(sql.FieldEqualFold(FieldClaudeOauthToken, vcs), err)
 }
 
 // ClaudeOauthTokenContainsFold applies the ContainsFold predicate on the "claude_oauth_token" field.
 func ClaudeOauthTokenContainsFold(v string) predicate.User {
-	return predicate.User(sql.FieldContainsFold(FieldClaudeOauthToken, v))
+	vc, err := ValueScanner.ClaudeOauthToken.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("claude_oauth_token value is not a string: %T", vc)
+	}
+	return predicate.UserOrErr(sql.FieldContainsFold(FieldClaudeOauthToken, vcs), err)
+}
+
+// MagicLinkFailuresEQ applies the EQ predicate on the "magic_link_failures" field.
+func MagicLinkFailuresEQ(v int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldMagicLinkFailures, v))
+}
+
+// MagicLinkFailuresNEQ applies the NEQ predicate on the "magic_link_failures" field.
+func MagicLinkFailuresNEQ(v int) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldMagicLinkFailures, v))
+}
+
+// MagicLinkFailuresIn applies the In predicate on the "magic_link_failures" field.
+func MagicLinkFailuresIn(vs ...int) predicate.User {
+	return predicate.User(sql.FieldIn(FieldMagicLinkFailures, vs...))
+}
+
+// MagicLinkFailuresNotIn applies the NotIn predicate on the "magic_link_failures" field.
+func MagicLinkFailuresNotIn(vs ...int) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldMagicLinkFailures, vs...))
+}
+
+// MagicLinkFailuresGT applies the GT predicate on the "magic_link_failures" field.
+func MagicLinkFailuresGT(v int) predicate.User {
+	return predicate.User(sql.FieldGT(FieldMagicLinkFailures, v))
+}
+
+// MagicLinkFailuresGTE applies the GTE predicate on the "magic_link_failures" field.
+func MagicLinkFailuresGTE(v int) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldMagicLinkFailures, v))
+}
+
+// MagicLinkFailuresLT applies the LT predicate on the "magic_link_failures" field.
+func MagicLinkFailuresLT(v int) predicate.User {
+	return predicate.User(sql.FieldLT(FieldMagicLinkFailures, v))
+}
+
+// MagicLinkFailuresLTE applies the LTE predicate on the "magic_link_failures" field.
+func MagicLinkFailuresLTE(v int) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldMagicLinkFailures, v))
+}
+
+// MagicLinkFailedAtEQ applies the EQ predicate on the "magic_link_failed_at" field.
+func MagicLinkFailedAtEQ(v time.Time) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldMagicLinkFailedAt, v))
+}
+
+// MagicLinkFailedAtNEQ applies the NEQ predicate on the "magic_link_failed_at" field.
+func MagicLinkFailedAtNEQ(v time.Time) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldMagicLinkFailedAt, v))
+}
+
+// MagicLinkFailedAtIn applies the In predicate on the "magic_link_failed_at" field.
+func MagicLinkFailedAtIn(vs ...time.Time) predicate.User {
+	return predicate.User(sql.FieldIn(FieldMagicLinkFailedAt, vs...))
+}
+
+// MagicLinkFailedAtNotIn applies the NotIn predicate on the "magic_link_failed_at" field.
+func MagicLinkFailedAtNotIn(vs ...time.Time) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldMagicLinkFailedAt, vs...))
+}
+
+// MagicLinkFailedAtGT applies the GT predicate on the "magic_link_failed_at" field.
+func MagicLinkFailedAtGT(v time.Time) predicate.User {
+	return predicate.User(sql.FieldGT(FieldMagicLinkFailedAt, v))
+}
+
+// MagicLinkFailedAtGTE applies the GTE predicate on the "magic_link_failed_at" field.
+func MagicLinkFailedAtGTE(v time.Time) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldMagicLinkFailedAt, v))
+}
+
+// MagicLinkFailedAtLT applies the LT predicate on the "magic_link_failed_at" field.
+func MagicLinkFailedAtLT(v time.Time) predicate.User {
+	return predicate.User(sql.FieldLT(FieldMagicLinkFailedAt, v))
+}
+
+// MagicLinkFailedAtLTE applies the LTE predicate on the "magic_link_failed_at" field.
+func MagicLinkFailedAtLTE(v time.Time) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldMagicLinkFailedAt, v))
+}
+
+// MagicLinkFailedAtIsNil applies the IsNil predicate on the "magic_link_failed_at" field.
+func MagicLinkFailedAtIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldMagicLinkFailedAt))
+}
+
+// MagicLinkFailedAtNotNil applies the NotNil predicate on the "magic_link_failed_at" field.
+func MagicLinkFailedAtNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldMagicLinkFailedAt))
+}
+
+// TerminalRecordingEQ applies the EQ predicate on the "terminal_recording" field.
+func TerminalRecordingEQ(v TerminalRecording) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldTerminalRecording, v))
+}
+
+// TerminalRecordingNEQ applies the NEQ predicate on the "terminal_recording" field.
+func TerminalRecordingNEQ(v TerminalRecording) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldTerminalRecording, v))
+}
+
+// TerminalRecordingIn applies the In predicate on the "terminal_recording" field.
+func TerminalRecordingIn(vs ...TerminalRecording) predicate.User {
+	return predicate.User(sql.FieldIn(FieldTerminalRecording, vs...))
+}
+
+// TerminalRecordingNotIn applies the NotIn predicate on the "terminal_recording" field.
+func TerminalRecordingNotIn(vs ...TerminalRecording) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldTerminalRecording, vs...))
 }
 
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
@@ -926,6 +1067,282 @@ func HasConversationsWith(preds ...predicate.Conversation) predicate.User {
 	})
 }
 
+// HasJobs applies the HasEdge predicate on the "jobs" edge.
+func HasJobs() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, JobsTable, JobsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasJobsWith applies the HasEdge predicate on the "jobs" edge with a given conditions (other predicates).
+func HasJobsWith(preds ...predicate.Job) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newJobsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasBackups applies the HasEdge predicate on the "backups" edge.
+func HasBackups() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, BackupsTable, BackupsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasBackupsWith applies the HasEdge predicate on the "backups" edge with a given conditions (other predicates).
+func HasBackupsWith(preds ...predicate.Backup) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newBackupsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasTemplates applies the HasEdge predicate on the "templates" edge.
+func HasTemplates() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, TemplatesTable, TemplatesColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasTemplatesWith applies the HasEdge predicate on the "templates" edge with a given conditions (other predicates).
+func HasTemplatesWith(preds ...predicate.Template) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newTemplatesStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasSecrets applies the HasEdge predicate on the "secrets" edge.
+func HasSecrets() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, SecretsTable, SecretsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasSecretsWith applies the HasEdge predicate on the "secrets" edge with a given conditions (other predicates).
+func HasSecretsWith(preds ...predicate.Secret) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newSecretsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasAccessTokens applies the HasEdge predicate on the "access_tokens" edge.
+func HasAccessTokens() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, AccessTokensTable, AccessTokensColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasAccessTokensWith applies the HasEdge predicate on the "access_tokens" edge with a given conditions (other predicates).
+func HasAccessTokensWith(preds ...predicate.AccessToken) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newAccessTokensStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasDeviceCodes applies the HasEdge predicate on the "device_codes" edge.
+func HasDeviceCodes() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, DeviceCodesTable, DeviceCodesColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasDeviceCodesWith applies the HasEdge predicate on the "device_codes" edge with a given conditions (other predicates).
+func HasDeviceCodesWith(preds ...predicate.DeviceCode) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newDeviceCodesStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasIdentities applies the HasEdge predicate on the "identities" edge.
+func HasIdentities() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, IdentitiesTable, IdentitiesColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasIdentitiesWith applies the HasEdge predicate on the "identities" edge with a given conditions (other predicates).
+func HasIdentitiesWith(preds ...predicate.Identity) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newIdentitiesStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasMemberships applies the HasEdge predicate on the "memberships" edge.
+func HasMemberships() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, MembershipsTable, MembershipsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasMembershipsWith applies the HasEdge predicate on the "memberships" edge with a given conditions (other predicates).
+func HasMembershipsWith(preds ...predicate.Membership) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newMembershipsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasSessions applies the HasEdge predicate on the "sessions" edge.
+func HasSessions() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, SessionsTable, SessionsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasSessionsWith applies the HasEdge predicate on the "sessions" edge with a given conditions (other predicates).
+func HasSessionsWith(preds ...predicate.Session) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newSessionsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasMagicLinks applies the HasEdge predicate on the "magic_links" edge.
+func HasMagicLinks() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, MagicLinksTable, MagicLinksColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasMagicLinksWith applies the HasEdge predicate on the "magic_links" edge with a given conditions (other predicates).
+func HasMagicLinksWith(preds ...predicate.MagicLink) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newMagicLinksStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasRecordings applies the HasEdge predicate on the "recordings" edge.
+func HasRecordings() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, RecordingsTable, RecordingsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasRecordingsWith applies the HasEdge predicate on the "recordings" edge with a given conditions (other predicates).
+func HasRecordingsWith(preds ...predicate.Recording) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newRecordingsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasTerminalShares applies the HasEdge predicate on the "terminal_shares" edge.
+func HasTerminalShares() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, TerminalSharesTable, TerminalSharesColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasTerminalSharesWith applies the HasEdge predicate on the "terminal_shares" edge with a given conditions (other predicates).
+func HasTerminalSharesWith(preds ...predicate.TerminalShare) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newTerminalSharesStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.User) predicate.User {
 	return predicate.User(sql.AndPredicates(predicates...))
@@ -10,8 +10,20 @@ import (
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/accesstoken"
+	"github.com/logan/cloudcode/internal/ent/backup"
 	"github.com/logan/cloudcode/internal/ent/conversation"
+	"github.com/logan/cloudcode/internal/ent/devicecode"
+	"github.com/logan/cloudcode/internal/ent/identity"
 	"github.com/logan/cloudcode/internal/ent/instance"
+	"github.com/logan/cloudcode/internal/ent/job"
+	"github.com/logan/cloudcode/internal/ent/magiclink"
+	"github.com/logan/cloudcode/internal/ent/membership"
+	"github.com/logan/cloudcode/internal/ent/recording"
+	"github.com/logan/cloudcode/internal/ent/secret"
+	"github.com/logan/cloudcode/internal/ent/session"
+	"github.com/logan/cloudcode/internal/ent/template"
+	"github.com/logan/cloudcode/internal/ent/terminalshare"
 	"github.com/logan/cloudcode/internal/ent/user"
 )
 
@@ -28,20 +40,6 @@ func (_c *UserCreate) SetEmail(v string) *UserCreate {
 	return _c
 }
 
-// SetAPIKey sets the "api_key" field.
-func (_c *UserCreate) SetAPIKey(v string) *UserCreate {
-	_c.mutation.SetAPIKey(v)
-	return _c
-}
-
-// SetNillableAPIKey sets the "api_key" field if the given value is not nil.
-func (_c *UserCreate) SetNillableAPIKey(v *string) *UserCreate {
-	if v != nil {
-		_c.SetAPIKey(*v)
-	}
-	return _c
-}
-
 // SetName sets the "name" field.
 func (_c *UserCreate) SetName(v string) *UserCreate {
 	_c.mutation.SetName(v)
@@ -154,6 +152,48 @@ func (_c *UserCreate) SetNillableClaudeOauthToken(v *string) *UserCreate {
 	return _c
 }
 
+// SetMagicLinkFailures sets the "magic_link_failures" field.
+func (_c *UserCreate) SetMagicLinkFailures(v int) *UserCreate {
+	_c.mutation.SetMagicLinkFailures(v)
+	return _c
+}
+
+// SetNillableMagicLinkFailures sets the "magic_link_failures" field if the given value is not nil.
+func (_c *UserCreate) SetNillableMagicLinkFailures(v *int) *UserCreate {
+	if v != nil {
+		_c.SetMagicLinkFailures(*v)
+	}
+	return _c
+}
+
+// SetMagicLinkFailedAt sets the "magic_link_failed_at" field.
+func (_c *UserCreate) SetMagicLinkFailedAt(v time.Time) *UserCreate {
+	_c.mutation.SetMagicLinkFailedAt(v)
+	return _c
+}
+
+// SetNillableMagicLinkFailedAt sets the "magic_link_failed_at" field if the given value is not nil.
+func (_c *UserCreate) SetNillableMagicLinkFailedAt(v *time.Time) *UserCreate {
+	if v != nil {
+		_c.SetMagicLinkFailedAt(*v)
+	}
+	return _c
+}
+
+// SetTerminalRecording sets the "terminal_recording" field.
+func (_c *UserCreate) SetTerminalRecording(v user.TerminalRecording) *UserCreate {
+	_c.mutation.SetTerminalRecording(v)
+	return _c
+}
+
+// SetNillableTerminalRecording sets the "terminal_recording" field if the given value is not nil.
+func (_c *UserCreate) SetNillableTerminalRecording(v *user.TerminalRecording) *UserCreate {
+	if v != nil {
+		_c.SetTerminalRecording(*v)
+	}
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *UserCreate) SetCreatedAt(v time.Time) *UserCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -212,6 +252,186 @@ func (_c *UserCreate) AddConversations(v ...*Conversation) *UserCreate {
 	return _c.AddConversationIDs(ids...)
 }
 
+// AddJobIDs adds the "jobs" edge to the Job entity by IDs.
+func (_c *UserCreate) AddJobIDs(ids ...int) *UserCreate {
+	_c.mutation.AddJobIDs(ids...)
+	return _c
+}
+
+// AddJobs adds the "jobs" edges to the Job entity.
+func (_c *UserCreate) AddJobs(v ...*Job) *UserCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddJobIDs(ids...)
+}
+
+// AddBackupIDs adds the "backups" edge to the Backup entity by IDs.
+func (_c *UserCreate) AddBackupIDs(ids ...int) *UserCreate {
+	_c.mutation.AddBackupIDs(ids...)
+	return _c
+}
+
+// AddBackups adds the "backups" edges to the Backup entity.
+func (_c *UserCreate) AddBackups(v ...*Backup) *UserCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddBackupIDs(ids...)
+}
+
+// AddTemplateIDs adds the "templates" edge to the Template entity by IDs.
+func (_c *UserCreate) AddTemplateIDs(ids ...int) *UserCreate {
+	_c.mutation.AddTemplateIDs(ids...)
+	return _c
+}
+
+// AddTemplates adds the "templates" edges to the Template entity.
+func (_c *UserCreate) AddTemplates(v ...*Template) *UserCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddTemplateIDs(ids...)
+}
+
+// AddSecretIDs adds the "secrets" edge to the Secret entity by IDs.
+func (_c *UserCreate) AddSecretIDs(ids ...int) *UserCreate {
+	_c.mutation.AddSecretIDs(ids...)
+	return _c
+}
+
+// AddSecrets adds the "secrets" edges to the Secret entity.
+func (_c *UserCreate) AddSecrets(v ...*Secret) *UserCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddSecretIDs(ids...)
+}
+
+// AddAccessTokenIDs adds the "access_tokens" edge to the AccessToken entity by IDs.
+func (_c *UserCreate) AddAccessTokenIDs(ids ...int) *UserCreate {
+	_c.mutation.AddAccessTokenIDs(ids...)
+	return _c
+}
+
+// AddAccessTokens adds the "access_tokens" edges to the AccessToken entity.
+func (_c *UserCreate) AddAccessTokens(v ...*AccessToken) *UserCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddAccessTokenIDs(ids...)
+}
+
+// AddDeviceCodeIDs adds the "device_codes" edge to the DeviceCode entity by IDs.
+func (_c *UserCreate) AddDeviceCodeIDs(ids ...int) *UserCreate {
+	_c.mutation.AddDeviceCodeIDs(ids...)
+	return _c
+}
+
+// AddDeviceCodes adds the "device_codes" edges to the DeviceCode entity.
+func (_c *UserCreate) AddDeviceCodes(v ...*DeviceCode) *UserCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddDeviceCodeIDs(ids...)
+}
+
+// AddIdentityIDs adds the "identities" edge to the Identity entity by IDs.
+func (_c *UserCreate) AddIdentityIDs(ids ...int) *UserCreate {
+	_c.mutation.AddIdentityIDs(ids...)
+	return _c
+}
+
+// AddIdentities adds the "identities" edges to the Identity entity.
+func (_c *UserCreate) AddIdentities(v ...*Identity) *UserCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddIdentityIDs(ids...)
+}
+
+// AddMembershipIDs adds the "memberships" edge to the Membership entity by IDs.
+func (_c *UserCreate) AddMembershipIDs(ids ...int) *UserCreate {
+	_c.mutation.AddMembershipIDs(ids...)
+	return _c
+}
+
+// AddMemberships adds the "memberships" edges to the Membership entity.
+func (_c *UserCreate) AddMemberships(v ...*Membership) *UserCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddMembershipIDs(ids...)
+}
+
+// AddSessionIDs adds the "sessions" edge to the Session entity by IDs.
+func (_c *UserCreate) AddSessionIDs(ids ...int) *UserCreate {
+	_c.mutation.AddSessionIDs(ids...)
+	return _c
+}
+
+// AddSessions adds the "sessions" edges to the Session entity.
+func (_c *UserCreate) AddSessions(v ...*Session) *UserCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddSessionIDs(ids...)
+}
+
+// AddMagicLinkIDs adds the "magic_links" edge to the MagicLink entity by IDs.
+func (_c *UserCreate) AddMagicLinkIDs(ids ...int) *UserCreate {
+	_c.mutation.AddMagicLinkIDs(ids...)
+	return _c
+}
+
+// AddMagicLinks adds the "magic_links" edges to the MagicLink entity.
+func (_c *UserCreate) AddMagicLinks(v ...*MagicLink) *UserCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddMagicLinkIDs(ids...)
+}
+
+// AddRecordingIDs adds the "recordings" edge to the Recording entity by IDs.
+func (_c *UserCreate) AddRecordingIDs(ids ...int) *UserCreate {
+	_c.mutation.AddRecordingIDs(ids...)
+	return _c
+}
+
+// AddRecordings adds the "recordings" edges to the Recording entity.
+func (_c *UserCreate) AddRecordings(v ...*Recording) *UserCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddRecordingIDs(ids...)
+}
+
+// AddTerminalShareIDs adds the "terminal_shares" edge to the TerminalShare entity by IDs.
+func (_c *UserCreate) AddTerminalShareIDs(ids ...int) *UserCreate {
+	_c.mutation.AddTerminalShareIDs(ids...)
+	return _c
+}
+
+// AddTerminalShares adds the "terminal_shares" edges to the TerminalShare entity.
+func (_c *UserCreate) AddTerminalShares(v ...*TerminalShare) *UserCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddTerminalShareIDs(ids...)
+}
+
 // Mutation returns the UserMutation object of the builder.
 func (_c *UserCreate) Mutation() *UserMutation {
 	return _c.mutation
@@ -263,6 +483,14 @@ func (_c *UserCreate) defaults() {
 		v := user.DefaultUsageHours
 		_c.mutation.SetUsageHours(v)
 	}
+	if _, ok := _c.mutation.MagicLinkFailures(); !ok {
+		v := user.DefaultMagicLinkFailures
+		_c.mutation.SetMagicLinkFailures(v)
+	}
+	if _, ok := _c.mutation.TerminalRecording(); !ok {
+		v := user.DefaultTerminalRecording
+		_c.mutation.SetTerminalRecording(v)
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		v := user.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
@@ -292,6 +520,17 @@ func (_c *UserCreate) check() error {
 	if _, ok := _c.mutation.UsageHours(); !ok {
 		return &ValidationError{Name: "usage_hours", err: errors.New(`ent: missing required field "User.usage_hours"`)}
 	}
+	if _, ok := _c.mutation.MagicLinkFailures(); !ok {
+		return &ValidationError{Name: "magic_link_failures", err: errors.New(`ent: missing required field "User.magic_link_failures"`)}
+	}
+	if _, ok := _c.mutation.TerminalRecording(); !ok {
+		return &ValidationError{Name: "terminal_recording", err: errors.New(`ent: missing required field "User.terminal_recording"`)}
+	}
+	if v, ok := _c.mutation.TerminalRecording(); ok {
+		if err := user.TerminalRecordingValidator(v); err != nil {
+			return &ValidationError{Name: "terminal_recording", err: fmt.Errorf(`ent: validator failed for field "User.terminal_recording": %w`, err)}
+		}
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "User.created_at"`)}
 	}
@@ -305,7 +544,10 @@ func (_c *UserCreate) sqlSave(ctx context.Context) (*User, error) {
 	if err := _c.check(); err != nil {
 		return nil, err
 	}
-	_node, _spec := _c.createSpec()
+	_node, _spec, err := _c.createSpec()
+	if err != nil {
+		return nil, err
+	}
 	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
 		if sqlgraph.IsConstraintError(err) {
 			err = &ConstraintError{msg: err.Error(), wrap: err}
@@ -319,7 +561,7 @@ func (_c *UserCreate) sqlSave(ctx context.Context) (*User, error) {
 	return _node, nil
 }
 
-func (_c *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
+func (_c *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec, error) {
 	var (
 		_node = &User{config: _c.config}
 		_spec = sqlgraph.NewCreateSpec(user.Table, sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt))
@@ -328,10 +570,6 @@ func (_c *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		_spec.SetField(user.FieldEmail, field.TypeString, value)
 		_node.Email = value
 	}
-	if value, ok := _c.mutation.APIKey(); ok {
-		_spec.SetField(user.FieldAPIKey, field.TypeString, value)
-		_node.APIKey = &value
-	}
 	if value, ok := _c.mutation.Name(); ok {
 		_spec.SetField(user.FieldName, field.TypeString, value)
 		_node.Name = value
@@ -357,12 +595,32 @@ func (_c *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		_node.UsageHours = value
 	}
 	if value, ok := _c.mutation.AnthropicAPIKey(); ok {
-		_spec.SetField(user.FieldAnthropicAPIKey, field.TypeString, value)
-		_node.AnthropicAPIKey = &value
+		vv, err := user.ValueScanner.AnthropicAPIKey.Value(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		_spec.SetField(user.FieldAnthropicAPIKey, field.TypeString, vv)
+		_node.AnthropicAPIKey = value
 	}
 	if value, ok := _c.mutation.ClaudeOauthToken(); ok {
-		_spec.SetField(user.FieldClaudeOauthToken, field.TypeString, value)
-		_node.ClaudeOauthToken = &value
+		vv, err := user.ValueScanner.ClaudeOauthToken.Value(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		_spec.SetField(user.FieldClaudeOauthToken, field.TypeString, vv)
+		_node.ClaudeOauthToken = value
+	}
+	if value, ok := _c.mutation.MagicLinkFailures(); ok {
+		_spec.SetField(user.FieldMagicLinkFailures, field.TypeInt, value)
+		_node.MagicLinkFailures = value
+	}
+	if value, ok := _c.mutation.MagicLinkFailedAt(); ok {
+		_spec.SetField(user.FieldMagicLinkFailedAt, field.TypeTime, value)
+		_node.MagicLinkFailedAt = &value
+	}
+	if value, ok := _c.mutation.TerminalRecording(); ok {
+		_spec.SetField(user.FieldTerminalRecording, field.TypeEnum, value)
+		_node.TerminalRecording = value
 	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(user.FieldCreatedAt, field.TypeTime, value)
@@ -404,7 +662,199 @@ func (_c *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
-	return _node, _spec
+	if nodes := _c.mutation.JobsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.JobsTable,
+			Columns: []string{user.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.BackupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.BackupsTable,
+			Columns: []string{user.BackupsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(backup.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.TemplatesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.TemplatesTable,
+			Columns: []string{user.TemplatesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(template.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.SecretsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SecretsTable,
+			Columns: []string{user.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secret.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.AccessTokensIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.AccessTokensTable,
+			Columns: []string{user.AccessTokensColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(accesstoken.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.DeviceCodesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.DeviceCodesTable,
+			Columns: []string{user.DeviceCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(devicecode.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.IdentitiesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.IdentitiesTable,
+			Columns: []string{user.IdentitiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(identity.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.MembershipsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MembershipsTable,
+			Columns: []string{user.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.SessionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SessionsTable,
+			Columns: []string{user.SessionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(session.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.MagicLinksIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MagicLinksTable,
+			Columns: []string{user.MagicLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(magiclink.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.RecordingsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.RecordingsTable,
+			Columns: []string{user.RecordingsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(recording.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.TerminalSharesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.TerminalSharesTable,
+			Columns: []string{user.TerminalSharesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(terminalshare.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec, nil
 }
 
 // UserCreateBulk is the builder for creating many User entities in bulk.
@@ -436,7 +886,10 @@ func (_c *UserCreateBulk) Save(ctx context.Context) ([]*User, error) {
 				}
 				builder.mutation = mutation
 				var err error
-				nodes[i], specs[i] = builder.createSpec()
+				nodes[i], specs[i], err = builder.createSpec()
+				if err != nil {
+					return nil, err
+				}
 				if i < len(mutators)-1 {
 					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
 				} else {
@@ -11,9 +11,21 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/accesstoken"
+	"github.com/logan/cloudcode/internal/ent/backup"
 	"github.com/logan/cloudcode/internal/ent/conversation"
+	"github.com/logan/cloudcode/internal/ent/devicecode"
+	"github.com/logan/cloudcode/internal/ent/identity"
 	"github.com/logan/cloudcode/internal/ent/instance"
+	"github.com/logan/cloudcode/internal/ent/job"
+	"github.com/logan/cloudcode/internal/ent/magiclink"
+	"github.com/logan/cloudcode/internal/ent/membership"
 	"github.com/logan/cloudcode/internal/ent/predicate"
+	"github.com/logan/cloudcode/internal/ent/recording"
+	"github.com/logan/cloudcode/internal/ent/secret"
+	"github.com/logan/cloudcode/internal/ent/session"
+	"github.com/logan/cloudcode/internal/ent/template"
+	"github.com/logan/cloudcode/internal/ent/terminalshare"
 	"github.com/logan/cloudcode/internal/ent/user"
 )
 
@@ -44,26 +56,6 @@ func (_u *UserUpdate) SetNillableEmail(v *string) *UserUpdate {
 	return _u
 }
 
-// SetAPIKey sets the "api_key" field.
-func (_u *UserUpdate) SetAPIKey(v string) *UserUpdate {
-	_u.mutation.SetAPIKey(v)
-	return _u
-}
-
-// SetNillableAPIKey sets the "api_key" field if the given value is not nil.
-func (_u *UserUpdate) SetNillableAPIKey(v *string) *UserUpdate {
-	if v != nil {
-		_u.SetAPIKey(*v)
-	}
-	return _u
-}
-
-// ClearAPIKey clears the value of the "api_key" field.
-func (_u *UserUpdate) ClearAPIKey() *UserUpdate {
-	_u.mutation.ClearAPIKey()
-	return _u
-}
-
 // SetName sets the "name" field.
 func (_u *UserUpdate) SetName(v string) *UserUpdate {
 	_u.mutation.SetName(v)
@@ -213,6 +205,61 @@ func (_u *UserUpdate) ClearClaudeOauthToken() *UserUpdate {
 	return _u
 }
 
+// SetMagicLinkFailures sets the "magic_link_failures" field.
+func (_u *UserUpdate) SetMagicLinkFailures(v int) *UserUpdate {
+	_u.mutation.ResetMagicLinkFailures()
+	_u.mutation.SetMagicLinkFailures(v)
+	return _u
+}
+
+// SetNillableMagicLinkFailures sets the "magic_link_failures" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableMagicLinkFailures(v *int) *UserUpdate {
+	if v != nil {
+		_u.SetMagicLinkFailures(*v)
+	}
+	return _u
+}
+
+// AddMagicLinkFailures adds value to the "magic_link_failures" field.
+func (_u *UserUpdate) AddMagicLinkFailures(v int) *UserUpdate {
+	_u.mutation.AddMagicLinkFailures(v)
+	return _u
+}
+
+// SetMagicLinkFailedAt sets the "magic_link_failed_at" field.
+func (_u *UserUpdate) SetMagicLinkFailedAt(v time.Time) *UserUpdate {
+	_u.mutation.SetMagicLinkFailedAt(v)
+	return _u
+}
+
+// SetNillableMagicLinkFailedAt sets the "magic_link_failed_at" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableMagicLinkFailedAt(v *time.Time) *UserUpdate {
+	if v != nil {
+		_u.SetMagicLinkFailedAt(*v)
+	}
+	return _u
+}
+
+// ClearMagicLinkFailedAt clears the value of the "magic_link_failed_at" field.
+func (_u *UserUpdate) ClearMagicLinkFailedAt() *UserUpdate {
+	_u.mutation.ClearMagicLinkFailedAt()
+	return _u
+}
+
+// SetTerminalRecording sets the "terminal_recording" field.
+func (_u *UserUpdate) SetTerminalRecording(v user.TerminalRecording) *UserUpdate {
+	_u.mutation.SetTerminalRecording(v)
+	return _u
+}
+
+// SetNillableTerminalRecording sets the "terminal_recording" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableTerminalRecording(v *user.TerminalRecording) *UserUpdate {
+	if v != nil {
+		_u.SetTerminalRecording(*v)
+	}
+	return _u
+}
+
 // SetUpdatedAt sets the "updated_at" field.
 func (_u *UserUpdate) SetUpdatedAt(v time.Time) *UserUpdate {
 	_u.mutation.SetUpdatedAt(v)
@@ -249,6 +296,186 @@ func (_u *UserUpdate) AddConversations(v ...*Conversation) *UserUpdate {
 	return _u.AddConversationIDs(ids...)
 }
 
+// AddJobIDs adds the "jobs" edge to the Job entity by IDs.
+func (_u *UserUpdate) AddJobIDs(ids ...int) *UserUpdate {
+	_u.mutation.AddJobIDs(ids...)
+	return _u
+}
+
+// AddJobs adds the "jobs" edges to the Job entity.
+func (_u *UserUpdate) AddJobs(v ...*Job) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddJobIDs(ids...)
+}
+
+// AddBackupIDs adds the "backups" edge to the Backup entity by IDs.
+func (_u *UserUpdate) AddBackupIDs(ids ...int) *UserUpdate {
+	_u.mutation.AddBackupIDs(ids...)
+	return _u
+}
+
+// AddBackups adds the "backups" edges to the Backup entity.
+func (_u *UserUpdate) AddBackups(v ...*Backup) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddBackupIDs(ids...)
+}
+
+// AddTemplateIDs adds the "templates" edge to the Template entity by IDs.
+func (_u *UserUpdate) AddTemplateIDs(ids ...int) *UserUpdate {
+	_u.mutation.AddTemplateIDs(ids...)
+	return _u
+}
+
+// AddTemplates adds the "templates" edges to the Template entity.
+func (_u *UserUpdate) AddTemplates(v ...*Template) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddTemplateIDs(ids...)
+}
+
+// AddSecretIDs adds the "secrets" edge to the Secret entity by IDs.
+func (_u *UserUpdate) AddSecretIDs(ids ...int) *UserUpdate {
+	_u.mutation.AddSecretIDs(ids...)
+	return _u
+}
+
+// AddSecrets adds the "secrets" edges to the Secret entity.
+func (_u *UserUpdate) AddSecrets(v ...*Secret) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddSecretIDs(ids...)
+}
+
+// AddAccessTokenIDs adds the "access_tokens" edge to the AccessToken entity by IDs.
+func (_u *UserUpdate) AddAccessTokenIDs(ids ...int) *UserUpdate {
+	_u.mutation.AddAccessTokenIDs(ids...)
+	return _u
+}
+
+// AddAccessTokens adds the "access_tokens" edges to the AccessToken entity.
+func (_u *UserUpdate) AddAccessTokens(v ...*AccessToken) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddAccessTokenIDs(ids...)
+}
+
+// AddDeviceCodeIDs adds the "device_codes" edge to the DeviceCode entity by IDs.
+func (_u *UserUpdate) AddDeviceCodeIDs(ids ...int) *UserUpdate {
+	_u.mutation.AddDeviceCodeIDs(ids...)
+	return _u
+}
+
+// AddDeviceCodes adds the "device_codes" edges to the DeviceCode entity.
+func (_u *UserUpdate) AddDeviceCodes(v ...*DeviceCode) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddDeviceCodeIDs(ids...)
+}
+
+// AddIdentityIDs adds the "identities" edge to the Identity entity by IDs.
+func (_u *UserUpdate) AddIdentityIDs(ids ...int) *UserUpdate {
+	_u.mutation.AddIdentityIDs(ids...)
+	return _u
+}
+
+// AddIdentities adds the "identities" edges to the Identity entity.
+func (_u *UserUpdate) AddIdentities(v ...*Identity) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddIdentityIDs(ids...)
+}
+
+// AddMembershipIDs adds the "memberships" edge to the Membership entity by IDs.
+func (_u *UserUpdate) AddMembershipIDs(ids ...int) *UserUpdate {
+	_u.mutation.AddMembershipIDs(ids...)
+	return _u
+}
+
+// AddMemberships adds the "memberships" edges to the Membership entity.
+func (_u *UserUpdate) AddMemberships(v ...*Membership) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddMembershipIDs(ids...)
+}
+
+// AddSessionIDs adds the "sessions" edge to the Session entity by IDs.
+func (_u *UserUpdate) AddSessionIDs(ids ...int) *UserUpdate {
+	_u.mutation.AddSessionIDs(ids...)
+	return _u
+}
+
+// AddSessions adds the "sessions" edges to the Session entity.
+func (_u *UserUpdate) AddSessions(v ...*Session) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddSessionIDs(ids...)
+}
+
+// AddMagicLinkIDs adds the "magic_links" edge to the MagicLink entity by IDs.
+func (_u *UserUpdate) AddMagicLinkIDs(ids ...int) *UserUpdate {
+	_u.mutation.AddMagicLinkIDs(ids...)
+	return _u
+}
+
+// AddMagicLinks adds the "magic_links" edges to the MagicLink entity.
+func (_u *UserUpdate) AddMagicLinks(v ...*MagicLink) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddMagicLinkIDs(ids...)
+}
+
+// AddRecordingIDs adds the "recordings" edge to the Recording entity by IDs.
+func (_u *UserUpdate) AddRecordingIDs(ids ...int) *UserUpdate {
+	_u.mutation.AddRecordingIDs(ids...)
+	return _u
+}
+
+// AddRecordings adds the "recordings" edges to the Recording entity.
+func (_u *UserUpdate) AddRecordings(v ...*Recording) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddRecordingIDs(ids...)
+}
+
+// AddTerminalShareIDs adds the "terminal_shares" edge to the TerminalShare entity by IDs.
+func (_u *UserUpdate) AddTerminalShareIDs(ids ...int) *UserUpdate {
+	_u.mutation.AddTerminalShareIDs(ids...)
+	return _u
+}
+
+// AddTerminalShares adds the "terminal_shares" edges to the TerminalShare entity.
+func (_u *UserUpdate) AddTerminalShares(v ...*TerminalShare) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddTerminalShareIDs(ids...)
+}
+
 // Mutation returns the UserMutation object of the builder.
 func (_u *UserUpdate) Mutation() *UserMutation {
 	return _u.mutation
@@ -296,72 +523,323 @@ func (_u *UserUpdate) RemoveConversations(v ...*Conversation) *UserUpdate {
 	return _u.RemoveConversationIDs(ids...)
 }
 
-// Save executes the query and returns the number of nodes affected by the update operation.
-func (_u *UserUpdate) Save(ctx context.Context) (int, error) {
-	_u.defaults()
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+// ClearJobs clears all "jobs" edges to the Job entity.
+func (_u *UserUpdate) ClearJobs() *UserUpdate {
+	_u.mutation.ClearJobs()
+	return _u
 }
 
-// SaveX is like Save, but panics if an error occurs.
-func (_u *UserUpdate) SaveX(ctx context.Context) int {
-	affected, err := _u.Save(ctx)
-	if err != nil {
-		panic(err)
+// RemoveJobIDs removes the "jobs" edge to Job entities by IDs.
+func (_u *UserUpdate) RemoveJobIDs(ids ...int) *UserUpdate {
+	_u.mutation.RemoveJobIDs(ids...)
+	return _u
+}
+
+// RemoveJobs removes "jobs" edges to Job entities.
+func (_u *UserUpdate) RemoveJobs(v ...*Job) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
-	return affected
+	return _u.RemoveJobIDs(ids...)
 }
 
-// Exec executes the query.
-func (_u *UserUpdate) Exec(ctx context.Context) error {
-	_, err := _u.Save(ctx)
-	return err
+// ClearBackups clears all "backups" edges to the Backup entity.
+func (_u *UserUpdate) ClearBackups() *UserUpdate {
+	_u.mutation.ClearBackups()
+	return _u
 }
 
-// ExecX is like Exec, but panics if an error occurs.
-func (_u *UserUpdate) ExecX(ctx context.Context) {
-	if err := _u.Exec(ctx); err != nil {
-		panic(err)
-	}
+// RemoveBackupIDs removes the "backups" edge to Backup entities by IDs.
+func (_u *UserUpdate) RemoveBackupIDs(ids ...int) *UserUpdate {
+	_u.mutation.RemoveBackupIDs(ids...)
+	return _u
 }
 
-// defaults sets the default values of the builder before save.
-func (_u *UserUpdate) defaults() {
-	if _, ok := _u.mutation.UpdatedAt(); !ok {
-		v := user.UpdateDefaultUpdatedAt()
-		_u.mutation.SetUpdatedAt(v)
+// RemoveBackups removes "backups" edges to Backup entities.
+func (_u *UserUpdate) RemoveBackups(v ...*Backup) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
+	return _u.RemoveBackupIDs(ids...)
 }
 
-// check runs all checks and user-defined validators on the builder.
-func (_u *UserUpdate) check() error {
-	if v, ok := _u.mutation.Email(); ok {
-		if err := user.EmailValidator(v); err != nil {
-			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "User.email": %w`, err)}
-		}
-	}
-	return nil
+// ClearTemplates clears all "templates" edges to the Template entity.
+func (_u *UserUpdate) ClearTemplates() *UserUpdate {
+	_u.mutation.ClearTemplates()
+	return _u
 }
 
-func (_u *UserUpdate) sqlSave(ctx context.Context) (_node int, err error) {
-	if err := _u.check(); err != nil {
-		return _node, err
+// RemoveTemplateIDs removes the "templates" edge to Template entities by IDs.
+func (_u *UserUpdate) RemoveTemplateIDs(ids ...int) *UserUpdate {
+	_u.mutation.RemoveTemplateIDs(ids...)
+	return _u
+}
+
+// RemoveTemplates removes "templates" edges to Template entities.
+func (_u *UserUpdate) RemoveTemplates(v ...*Template) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
-	_spec := sqlgraph.NewUpdateSpec(user.Table, user.Columns, sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt))
-	if ps := _u.mutation.predicates; len(ps) > 0 {
-		_spec.Predicate = func(selector *sql.Selector) {
-			for i := range ps {
-				ps[i](selector)
-			}
-		}
+	return _u.RemoveTemplateIDs(ids...)
+}
+
+// ClearSecrets clears all "secrets" edges to the Secret entity.
+func (_u *UserUpdate) ClearSecrets() *UserUpdate {
+	_u.mutation.ClearSecrets()
+	return _u
+}
+
+// RemoveSecretIDs removes the "secrets" edge to Secret entities by IDs.
+func (_u *UserUpdate) RemoveSecretIDs(ids ...int) *UserUpdate {
+	_u.mutation.RemoveSecretIDs(ids...)
+	return _u
+}
+
+// RemoveSecrets removes "secrets" edges to Secret entities.
+func (_u *UserUpdate) RemoveSecrets(v ...*Secret) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
-	if value, ok := _u.mutation.Email(); ok {
-		_spec.SetField(user.FieldEmail, field.TypeString, value)
+	return _u.RemoveSecretIDs(ids...)
+}
+
+// ClearAccessTokens clears all "access_tokens" edges to the AccessToken entity.
+func (_u *UserUpdate) ClearAccessTokens() *UserUpdate {
+	_u.mutation.ClearAccessTokens()
+	return _u
+}
+
+// RemoveAccessTokenIDs removes the "access_tokens" edge to AccessToken entities by IDs.
+func (_u *UserUpdate) RemoveAccessTokenIDs(ids ...int) *UserUpdate {
+	_u.mutation.RemoveAccessTokenIDs(ids...)
+	return _u
+}
+
+// RemoveAccessTokens removes "access_tokens" edges to AccessToken entities.
+func (_u *UserUpdate) RemoveAccessTokens(v ...*AccessToken) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
-	if value, ok := _u.mutation.APIKey(); ok {
-		_spec.SetField(user.FieldAPIKey, field.TypeString, value)
+	return _u.RemoveAccessTokenIDs(ids...)
+}
+
+// ClearDeviceCodes clears all "device_codes" edges to the DeviceCode entity.
+func (_u *UserUpdate) ClearDeviceCodes() *UserUpdate {
+	_u.mutation.ClearDeviceCodes()
+	return _u
+}
+
+// RemoveDeviceCodeIDs removes the "device_codes" edge to DeviceCode entities by IDs.
+func (_u *UserUpdate) RemoveDeviceCodeIDs(ids ...int) *UserUpdate {
+	_u.mutation.RemoveDeviceCodeIDs(ids...)
+	return _u
+}
+
+// RemoveDeviceCodes removes "device_codes" edges to DeviceCode entities.
+func (_u *UserUpdate) RemoveDeviceCodes(v ...*DeviceCode) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
-	if _u.mutation.APIKeyCleared() {
-		_spec.ClearField(user.FieldAPIKey, field.TypeString)
+	return _u.RemoveDeviceCodeIDs(ids...)
+}
+
+// ClearIdentities clears all "identities" edges to the Identity entity.
+func (_u *UserUpdate) ClearIdentities() *UserUpdate {
+	_u.mutation.ClearIdentities()
+	return _u
+}
+
+// RemoveIdentityIDs removes the "identities" edge to Identity entities by IDs.
+func (_u *UserUpdate) RemoveIdentityIDs(ids ...int) *UserUpdate {
+	_u.mutation.RemoveIdentityIDs(ids...)
+	return _u
+}
+
+// RemoveIdentities removes "identities" edges to Identity entities.
+func (_u *UserUpdate) RemoveIdentities(v ...*Identity) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveIdentityIDs(ids...)
+}
+
+// ClearMemberships clears all "memberships" edges to the Membership entity.
+func (_u *UserUpdate) ClearMemberships() *UserUpdate {
+	_u.mutation.ClearMemberships()
+	return _u
+}
+
+// RemoveMembershipIDs removes the "memberships" edge to Membership entities by IDs.
+func (_u *UserUpdate) RemoveMembershipIDs(ids ...int) *UserUpdate {
+	_u.mutation.RemoveMembershipIDs(ids...)
+	return _u
+}
+
+// RemoveMemberships removes "memberships" edges to Membership entities.
+func (_u *UserUpdate) RemoveMemberships(v ...*Membership) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveMembershipIDs(ids...)
+}
+
+// ClearSessions clears all "sessions" edges to the Session entity.
+func (_u *UserUpdate) ClearSessions() *UserUpdate {
+	_u.mutation.ClearSessions()
+	return _u
+}
+
+// RemoveSessionIDs removes the "sessions" edge to Session entities by IDs.
+func (_u *UserUpdate) RemoveSessionIDs(ids ...int) *UserUpdate {
+	_u.mutation.RemoveSessionIDs(ids...)
+	return _u
+}
+
+// RemoveSessions removes "sessions" edges to Session entities.
+func (_u *UserUpdate) RemoveSessions(v ...*Session) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveSessionIDs(ids...)
+}
+
+// ClearMagicLinks clears all "magic_links" edges to the MagicLink entity.
+func (_u *UserUpdate) ClearMagicLinks() *UserUpdate {
+	_u.mutation.ClearMagicLinks()
+	return _u
+}
+
+// RemoveMagicLinkIDs removes the "magic_links" edge to MagicLink entities by IDs.
+func (_u *UserUpdate) RemoveMagicLinkIDs(ids ...int) *UserUpdate {
+	_u.mutation.RemoveMagicLinkIDs(ids...)
+	return _u
+}
+
+// RemoveMagicLinks removes "magic_links" edges to MagicLink entities.
+func (_u *UserUpdate) RemoveMagicLinks(v ...*MagicLink) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveMagicLinkIDs(ids...)
+}
+
+// ClearRecordings clears all "recordings" edges to the Recording entity.
+func (_u *UserUpdate) ClearRecordings() *UserUpdate {
+	_u.mutation.ClearRecordings()
+	return _u
+}
+
+// RemoveRecordingIDs removes the "recordings" edge to Recording entities by IDs.
+func (_u *UserUpdate) RemoveRecordingIDs(ids ...int) *UserUpdate {
+	_u.mutation.RemoveRecordingIDs(ids...)
+	return _u
+}
+
+// RemoveRecordings removes "recordings" edges to Recording entities.
+func (_u *UserUpdate) RemoveRecordings(v ...*Recording) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveRecordingIDs(ids...)
+}
+
+// ClearTerminalShares clears all "terminal_shares" edges to the TerminalShare entity.
+func (_u *UserUpdate) ClearTerminalShares() *UserUpdate {
+	_u.mutation.ClearTerminalShares()
+	return _u
+}
+
+// RemoveTerminalShareIDs removes the "terminal_shares" edge to TerminalShare entities by IDs.
+func (_u *UserUpdate) RemoveTerminalShareIDs(ids ...int) *UserUpdate {
+	_u.mutation.RemoveTerminalShareIDs(ids...)
+	return _u
+}
+
+// RemoveTerminalShares removes "terminal_shares" edges to TerminalShare entities.
+func (_u *UserUpdate) RemoveTerminalShares(v ...*TerminalShare) *UserUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveTerminalShareIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *UserUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *UserUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *UserUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *UserUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *UserUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := user.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *UserUpdate) check() error {
+	if v, ok := _u.mutation.Email(); ok {
+		if err := user.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "User.email": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.TerminalRecording(); ok {
+		if err := user.TerminalRecordingValidator(v); err != nil {
+			return &ValidationError{Name: "terminal_recording", err: fmt.Errorf(`ent: validator failed for field "User.terminal_recording": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *UserUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(user.Table, user.Columns, sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Email(); ok {
+		_spec.SetField(user.FieldEmail, field.TypeString, value)
 	}
 	if value, ok := _u.mutation.Name(); ok {
 		_spec.SetField(user.FieldName, field.TypeString, value)
@@ -394,17 +872,40 @@ func (_u *UserUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		_spec.AddField(user.FieldUsageHours, field.TypeFloat64, value)
 	}
 	if value, ok := _u.mutation.AnthropicAPIKey(); ok {
-		_spec.SetField(user.FieldAnthropicAPIKey, field.TypeString, value)
+		vv, err := user.ValueScanner.AnthropicAPIKey.Value(value)
+		if err != nil {
+			return 0, err
+		}
+		_spec.SetField(user.FieldAnthropicAPIKey, field.TypeString, vv)
 	}
 	if _u.mutation.AnthropicAPIKeyCleared() {
 		_spec.ClearField(user.FieldAnthropicAPIKey, field.TypeString)
 	}
 	if value, ok := _u.mutation.ClaudeOauthToken(); ok {
-		_spec.SetField(user.FieldClaudeOauthToken, field.TypeString, value)
+		vv, err := user.ValueScanner.ClaudeOauthToken.Value(value)
+		if err != nil {
+			return 0, err
+		}
+		_spec.SetField(user.FieldClaudeOauthToken, field.TypeString, vv)
 	}
 	if _u.mutation.ClaudeOauthTokenCleared() {
 		_spec.ClearField(user.FieldClaudeOauthToken, field.TypeString)
 	}
+	if value, ok := _u.mutation.MagicLinkFailures(); ok {
+		_spec.SetField(user.FieldMagicLinkFailures, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMagicLinkFailures(); ok {
+		_spec.AddField(user.FieldMagicLinkFailures, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MagicLinkFailedAt(); ok {
+		_spec.SetField(user.FieldMagicLinkFailedAt, field.TypeTime, value)
+	}
+	if _u.mutation.MagicLinkFailedAtCleared() {
+		_spec.ClearField(user.FieldMagicLinkFailedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.TerminalRecording(); ok {
+		_spec.SetField(user.FieldTerminalRecording, field.TypeEnum, value)
+	}
 	if value, ok := _u.mutation.UpdatedAt(); ok {
 		_spec.SetField(user.FieldUpdatedAt, field.TypeTime, value)
 	}
@@ -498,456 +999,2025 @@ func (_u *UserUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
-		if _, ok := err.(*sqlgraph.NotFoundError); ok {
-			err = &NotFoundError{user.Label}
-		} else if sqlgraph.IsConstraintError(err) {
-			err = &ConstraintError{msg: err.Error(), wrap: err}
+	if _u.mutation.JobsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.JobsTable,
+			Columns: []string{user.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
 		}
-		return 0, err
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	_u.mutation.done = true
-	return _node, nil
-}
-
-// UserUpdateOne is the builder for updating a single User entity.
-type UserUpdateOne struct {
-	config
-	fields   []string
-	hooks    []Hook
-	mutation *UserMutation
-}
-
-// SetEmail sets the "email" field.
-func (_u *UserUpdateOne) SetEmail(v string) *UserUpdateOne {
-	_u.mutation.SetEmail(v)
-	return _u
-}
-
-// SetNillableEmail sets the "email" field if the given value is not nil.
-func (_u *UserUpdateOne) SetNillableEmail(v *string) *UserUpdateOne {
-	if v != nil {
-		_u.SetEmail(*v)
+	if nodes := _u.mutation.RemovedJobsIDs(); len(nodes) > 0 && !_u.mutation.JobsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.JobsTable,
+			Columns: []string{user.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	return _u
-}
-
-// SetAPIKey sets the "api_key" field.
-func (_u *UserUpdateOne) SetAPIKey(v string) *UserUpdateOne {
-	_u.mutation.SetAPIKey(v)
-	return _u
-}
-
-// SetNillableAPIKey sets the "api_key" field if the given value is not nil.
-func (_u *UserUpdateOne) SetNillableAPIKey(v *string) *UserUpdateOne {
-	if v != nil {
-		_u.SetAPIKey(*v)
+	if nodes := _u.mutation.JobsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.JobsTable,
+			Columns: []string{user.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	return _u
-}
-
-// ClearAPIKey clears the value of the "api_key" field.
-func (_u *UserUpdateOne) ClearAPIKey() *UserUpdateOne {
-	_u.mutation.ClearAPIKey()
-	return _u
-}
-
-// SetName sets the "name" field.
-func (_u *UserUpdateOne) SetName(v string) *UserUpdateOne {
-	_u.mutation.SetName(v)
-	return _u
-}
-
-// SetNillableName sets the "name" field if the given value is not nil.
-func (_u *UserUpdateOne) SetNillableName(v *string) *UserUpdateOne {
-	if v != nil {
-		_u.SetName(*v)
+	if _u.mutation.BackupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.BackupsTable,
+			Columns: []string{user.BackupsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(backup.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	return _u
-}
-
-// ClearName clears the value of the "name" field.
-func (_u *UserUpdateOne) ClearName() *UserUpdateOne {
-	_u.mutation.ClearName()
-	return _u
-}
-
-// SetStripeCustomerID sets the "stripe_customer_id" field.
-func (_u *UserUpdateOne) SetStripeCustomerID(v string) *UserUpdateOne {
-	_u.mutation.SetStripeCustomerID(v)
-	return _u
-}
-
-// SetNillableStripeCustomerID sets the "stripe_customer_id" field if the given value is not nil.
-func (_u *UserUpdateOne) SetNillableStripeCustomerID(v *string) *UserUpdateOne {
-	if v != nil {
-		_u.SetStripeCustomerID(*v)
+	if nodes := _u.mutation.RemovedBackupsIDs(); len(nodes) > 0 && !_u.mutation.BackupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.BackupsTable,
+			Columns: []string{user.BackupsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(backup.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
+	if nodes := _u.mutation.BackupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.BackupsTable,
+			Columns: []string{user.BackupsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(backup.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TemplatesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.TemplatesTable,
+			Columns: []string{user.TemplatesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(template.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedTemplatesIDs(); len(nodes) > 0 && !_u.mutation.TemplatesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.TemplatesTable,
+			Columns: []string{user.TemplatesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(template.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TemplatesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.TemplatesTable,
+			Columns: []string{user.TemplatesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(template.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.SecretsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SecretsTable,
+			Columns: []string{user.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secret.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSecretsIDs(); len(nodes) > 0 && !_u.mutation.SecretsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SecretsTable,
+			Columns: []string{user.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secret.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SecretsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SecretsTable,
+			Columns: []string{user.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secret.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.AccessTokensCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.AccessTokensTable,
+			Columns: []string{user.AccessTokensColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(accesstoken.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedAccessTokensIDs(); len(nodes) > 0 && !_u.mutation.AccessTokensCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.AccessTokensTable,
+			Columns: []string{user.AccessTokensColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(accesstoken.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.AccessTokensIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.AccessTokensTable,
+			Columns: []string{user.AccessTokensColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(accesstoken.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.DeviceCodesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.DeviceCodesTable,
+			Columns: []string{user.DeviceCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(devicecode.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedDeviceCodesIDs(); len(nodes) > 0 && !_u.mutation.DeviceCodesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.DeviceCodesTable,
+			Columns: []string{user.DeviceCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(devicecode.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.DeviceCodesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.DeviceCodesTable,
+			Columns: []string{user.DeviceCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(devicecode.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.IdentitiesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.IdentitiesTable,
+			Columns: []string{user.IdentitiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(identity.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedIdentitiesIDs(); len(nodes) > 0 && !_u.mutation.IdentitiesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.IdentitiesTable,
+			Columns: []string{user.IdentitiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(identity.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.IdentitiesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.IdentitiesTable,
+			Columns: []string{user.IdentitiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(identity.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.MembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MembershipsTable,
+			Columns: []string{user.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedMembershipsIDs(); len(nodes) > 0 && !_u.mutation.MembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MembershipsTable,
+			Columns: []string{user.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.MembershipsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MembershipsTable,
+			Columns: []string{user.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.SessionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SessionsTable,
+			Columns: []string{user.SessionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(session.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSessionsIDs(); len(nodes) > 0 && !_u.mutation.SessionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SessionsTable,
+			Columns: []string{user.SessionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(session.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SessionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SessionsTable,
+			Columns: []string{user.SessionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(session.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.MagicLinksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MagicLinksTable,
+			Columns: []string{user.MagicLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(magiclink.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedMagicLinksIDs(); len(nodes) > 0 && !_u.mutation.MagicLinksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MagicLinksTable,
+			Columns: []string{user.MagicLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(magiclink.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.MagicLinksIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MagicLinksTable,
+			Columns: []string{user.MagicLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(magiclink.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.RecordingsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.RecordingsTable,
+			Columns: []string{user.RecordingsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(recording.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedRecordingsIDs(); len(nodes) > 0 && !_u.mutation.RecordingsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.RecordingsTable,
+			Columns: []string{user.RecordingsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(recording.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RecordingsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.RecordingsTable,
+			Columns: []string{user.RecordingsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(recording.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TerminalSharesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.TerminalSharesTable,
+			Columns: []string{user.TerminalSharesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(terminalshare.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedTerminalSharesIDs(); len(nodes) > 0 && !_u.mutation.TerminalSharesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.TerminalSharesTable,
+			Columns: []string{user.TerminalSharesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(terminalshare.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TerminalSharesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.TerminalSharesTable,
+			Columns: []string{user.TerminalSharesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(terminalshare.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{user.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// UserUpdateOne is the builder for updating a single User entity.
+type UserUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *UserMutation
+}
+
+// SetEmail sets the "email" field.
+func (_u *UserUpdateOne) SetEmail(v string) *UserUpdateOne {
+	_u.mutation.SetEmail(v)
+	return _u
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableEmail(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetEmail(*v)
+	}
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *UserUpdateOne) SetName(v string) *UserUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableName(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// ClearName clears the value of the "name" field.
+func (_u *UserUpdateOne) ClearName() *UserUpdateOne {
+	_u.mutation.ClearName()
+	return _u
+}
+
+// SetStripeCustomerID sets the "stripe_customer_id" field.
+func (_u *UserUpdateOne) SetStripeCustomerID(v string) *UserUpdateOne {
+	_u.mutation.SetStripeCustomerID(v)
+	return _u
+}
+
+// SetNillableStripeCustomerID sets the "stripe_customer_id" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableStripeCustomerID(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetStripeCustomerID(*v)
+	}
+	return _u
+}
+
+// ClearStripeCustomerID clears the value of the "stripe_customer_id" field.
+func (_u *UserUpdateOne) ClearStripeCustomerID() *UserUpdateOne {
+	_u.mutation.ClearStripeCustomerID()
+	return _u
+}
+
+// SetStripeSubscriptionID sets the "stripe_subscription_id" field.
+func (_u *UserUpdateOne) SetStripeSubscriptionID(v string) *UserUpdateOne {
+	_u.mutation.SetStripeSubscriptionID(v)
+	return _u
+}
+
+// SetNillableStripeSubscriptionID sets the "stripe_subscription_id" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableStripeSubscriptionID(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetStripeSubscriptionID(*v)
+	}
+	return _u
+}
+
+// ClearStripeSubscriptionID clears the value of the "stripe_subscription_id" field.
+func (_u *UserUpdateOne) ClearStripeSubscriptionID() *UserUpdateOne {
+	_u.mutation.ClearStripeSubscriptionID()
+	return _u
+}
+
+// SetSubscriptionStatus sets the "subscription_status" field.
+func (_u *UserUpdateOne) SetSubscriptionStatus(v string) *UserUpdateOne {
+	_u.mutation.SetSubscriptionStatus(v)
+	return _u
+}
+
+// SetNillableSubscriptionStatus sets the "subscription_status" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableSubscriptionStatus(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetSubscriptionStatus(*v)
+	}
+	return _u
+}
+
+// SetPlan sets the "plan" field.
+func (_u *UserUpdateOne) SetPlan(v string) *UserUpdateOne {
+	_u.mutation.SetPlan(v)
+	return _u
+}
+
+// SetNillablePlan sets the "plan" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillablePlan(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetPlan(*v)
+	}
+	return _u
+}
+
+// SetUsageHours sets the "usage_hours" field.
+func (_u *UserUpdateOne) SetUsageHours(v float64) *UserUpdateOne {
+	_u.mutation.ResetUsageHours()
+	_u.mutation.SetUsageHours(v)
+	return _u
+}
+
+// SetNillableUsageHours sets the "usage_hours" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableUsageHours(v *float64) *UserUpdateOne {
+	if v != nil {
+		_u.SetUsageHours(*v)
+	}
+	return _u
+}
+
+// AddUsageHours adds value to the "usage_hours" field.
+func (_u *UserUpdateOne) AddUsageHours(v float64) *UserUpdateOne {
+	_u.mutation.AddUsageHours(v)
+	return _u
+}
+
+// SetAnthropicAPIKey sets the "anthropic_api_key" field.
+func (_u *UserUpdateOne) SetAnthropicAPIKey(v string) *UserUpdateOne {
+	_u.mutation.SetAnthropicAPIKey(v)
+	return _u
+}
+
+// SetNillableAnthropicAPIKey sets the "anthropic_api_key" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableAnthropicAPIKey(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetAnthropicAPIKey(*v)
+	}
+	return _u
+}
+
+// ClearAnthropicAPIKey clears the value of the "anthropic_api_key" field.
+func (_u *UserUpdateOne) ClearAnthropicAPIKey() *UserUpdateOne {
+	_u.mutation.ClearAnthropicAPIKey()
+	return _u
+}
+
+// SetClaudeOauthToken sets the "claude_oauth_token" field.
+func (_u *UserUpdateOne) SetClaudeOauthToken(v string) *UserUpdateOne {
+	_u.mutation.SetClaudeOauthToken(v)
+	return _u
+}
+
+// SetNillableClaudeOauthToken sets the "claude_oauth_token" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableClaudeOauthToken(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetClaudeOauthToken(*v)
+	}
+	return _u
+}
+
+// ClearClaudeOauthToken clears the value of the "claude_oauth_token" field.
+func (_u *UserUpdateOne) ClearClaudeOauthToken() *UserUpdateOne {
+	_u.mutation.ClearClaudeOauthToken()
+	return _u
+}
+
+// SetMagicLinkFailures sets the "magic_link_failures" field.
+func (_u *UserUpdateOne) SetMagicLinkFailures(v int) *UserUpdateOne {
+	_u.mutation.ResetMagicLinkFailures()
+	_u.mutation.SetMagicLinkFailures(v)
+	return _u
+}
+
+// SetNillableMagicLinkFailures sets the "magic_link_failures" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableMagicLinkFailures(v *int) *UserUpdateOne {
+	if v != nil {
+		_u.SetMagicLinkFailures(*v)
+	}
+	return _u
+}
+
+// AddMagicLinkFailures adds value to the "magic_link_failures" field.
+func (_u *UserUpdateOne) AddMagicLinkFailures(v int) *UserUpdateOne {
+	_u.mutation.AddMagicLinkFailures(v)
+	return _u
+}
+
+// SetMagicLinkFailedAt sets the "magic_link_failed_at" field.
+func (_u *UserUpdateOne) SetMagicLinkFailedAt(v time.Time) *UserUpdateOne {
+	_u.mutation.SetMagicLinkFailedAt(v)
+	return _u
+}
+
+// SetNillableMagicLinkFailedAt sets the "magic_link_failed_at" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableMagicLinkFailedAt(v *time.Time) *UserUpdateOne {
+	if v != nil {
+		_u.SetMagicLinkFailedAt(*v)
+	}
+	return _u
+}
+
+// ClearMagicLinkFailedAt clears the value of the "magic_link_failed_at" field.
+func (_u *UserUpdateOne) ClearMagicLinkFailedAt() *UserUpdateOne {
+	_u.mutation.ClearMagicLinkFailedAt()
+	return _u
+}
+
+// SetTerminalRecording sets the "terminal_recording" field.
+func (_u *UserUpdateOne) SetTerminalRecording(v user.TerminalRecording) *UserUpdateOne {
+	_u.mutation.SetTerminalRecording(v)
+	return _u
+}
+
+// SetNillableTerminalRecording sets the "terminal_recording" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableTerminalRecording(v *user.TerminalRecording) *UserUpdateOne {
+	if v != nil {
+		_u.SetTerminalRecording(*v)
+	}
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *UserUpdateOne) SetUpdatedAt(v time.Time) *UserUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddInstanceIDs adds the "instances" edge to the Instance entity by IDs.
+func (_u *UserUpdateOne) AddInstanceIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddInstanceIDs(ids...)
+	return _u
+}
+
+// AddInstances adds the "instances" edges to the Instance entity.
+func (_u *UserUpdateOne) AddInstances(v ...*Instance) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddInstanceIDs(ids...)
+}
+
+// AddConversationIDs adds the "conversations" edge to the Conversation entity by IDs.
+func (_u *UserUpdateOne) AddConversationIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddConversationIDs(ids...)
+	return _u
+}
+
+// AddConversations adds the "conversations" edges to the Conversation entity.
+func (_u *UserUpdateOne) AddConversations(v ...*Conversation) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddConversationIDs(ids...)
+}
+
+// AddJobIDs adds the "jobs" edge to the Job entity by IDs.
+func (_u *UserUpdateOne) AddJobIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddJobIDs(ids...)
+	return _u
+}
+
+// AddJobs adds the "jobs" edges to the Job entity.
+func (_u *UserUpdateOne) AddJobs(v ...*Job) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddJobIDs(ids...)
+}
+
+// AddBackupIDs adds the "backups" edge to the Backup entity by IDs.
+func (_u *UserUpdateOne) AddBackupIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddBackupIDs(ids...)
+	return _u
+}
+
+// AddBackups adds the "backups" edges to the Backup entity.
+func (_u *UserUpdateOne) AddBackups(v ...*Backup) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddBackupIDs(ids...)
+}
+
+// AddTemplateIDs adds the "templates" edge to the Template entity by IDs.
+func (_u *UserUpdateOne) AddTemplateIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddTemplateIDs(ids...)
 	return _u
 }
 
-// ClearStripeCustomerID clears the value of the "stripe_customer_id" field.
-func (_u *UserUpdateOne) ClearStripeCustomerID() *UserUpdateOne {
-	_u.mutation.ClearStripeCustomerID()
-	return _u
-}
-
-// SetStripeSubscriptionID sets the "stripe_subscription_id" field.
-func (_u *UserUpdateOne) SetStripeSubscriptionID(v string) *UserUpdateOne {
-	_u.mutation.SetStripeSubscriptionID(v)
+// AddTemplates adds the "templates" edges to the Template entity.
+func (_u *UserUpdateOne) AddTemplates(v ...*Template) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddTemplateIDs(ids...)
+}
+
+// AddSecretIDs adds the "secrets" edge to the Secret entity by IDs.
+func (_u *UserUpdateOne) AddSecretIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddSecretIDs(ids...)
 	return _u
 }
 
-// SetNillableStripeSubscriptionID sets the "stripe_subscription_id" field if the given value is not nil.
-func (_u *UserUpdateOne) SetNillableStripeSubscriptionID(v *string) *UserUpdateOne {
-	if v != nil {
-		_u.SetStripeSubscriptionID(*v)
+// AddSecrets adds the "secrets" edges to the Secret entity.
+func (_u *UserUpdateOne) AddSecrets(v ...*Secret) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
+	return _u.AddSecretIDs(ids...)
+}
+
+// AddAccessTokenIDs adds the "access_tokens" edge to the AccessToken entity by IDs.
+func (_u *UserUpdateOne) AddAccessTokenIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddAccessTokenIDs(ids...)
 	return _u
 }
 
-// ClearStripeSubscriptionID clears the value of the "stripe_subscription_id" field.
-func (_u *UserUpdateOne) ClearStripeSubscriptionID() *UserUpdateOne {
-	_u.mutation.ClearStripeSubscriptionID()
+// AddAccessTokens adds the "access_tokens" edges to the AccessToken entity.
+func (_u *UserUpdateOne) AddAccessTokens(v ...*AccessToken) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddAccessTokenIDs(ids...)
+}
+
+// AddDeviceCodeIDs adds the "device_codes" edge to the DeviceCode entity by IDs.
+func (_u *UserUpdateOne) AddDeviceCodeIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddDeviceCodeIDs(ids...)
 	return _u
 }
 
-// SetSubscriptionStatus sets the "subscription_status" field.
-func (_u *UserUpdateOne) SetSubscriptionStatus(v string) *UserUpdateOne {
-	_u.mutation.SetSubscriptionStatus(v)
+// AddDeviceCodes adds the "device_codes" edges to the DeviceCode entity.
+func (_u *UserUpdateOne) AddDeviceCodes(v ...*DeviceCode) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddDeviceCodeIDs(ids...)
+}
+
+// AddIdentityIDs adds the "identities" edge to the Identity entity by IDs.
+func (_u *UserUpdateOne) AddIdentityIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddIdentityIDs(ids...)
 	return _u
 }
 
-// SetNillableSubscriptionStatus sets the "subscription_status" field if the given value is not nil.
-func (_u *UserUpdateOne) SetNillableSubscriptionStatus(v *string) *UserUpdateOne {
-	if v != nil {
-		_u.SetSubscriptionStatus(*v)
+// AddIdentities adds the "identities" edges to the Identity entity.
+func (_u *UserUpdateOne) AddIdentities(v ...*Identity) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddIdentityIDs(ids...)
+}
+
+// AddMembershipIDs adds the "memberships" edge to the Membership entity by IDs.
+func (_u *UserUpdateOne) AddMembershipIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddMembershipIDs(ids...)
+	return _u
+}
+
+// AddMemberships adds the "memberships" edges to the Membership entity.
+func (_u *UserUpdateOne) AddMemberships(v ...*Membership) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
+	return _u.AddMembershipIDs(ids...)
+}
+
+// AddSessionIDs adds the "sessions" edge to the Session entity by IDs.
+func (_u *UserUpdateOne) AddSessionIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddSessionIDs(ids...)
 	return _u
 }
 
-// SetPlan sets the "plan" field.
-func (_u *UserUpdateOne) SetPlan(v string) *UserUpdateOne {
-	_u.mutation.SetPlan(v)
+// AddSessions adds the "sessions" edges to the Session entity.
+func (_u *UserUpdateOne) AddSessions(v ...*Session) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddSessionIDs(ids...)
+}
+
+// AddMagicLinkIDs adds the "magic_links" edge to the MagicLink entity by IDs.
+func (_u *UserUpdateOne) AddMagicLinkIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddMagicLinkIDs(ids...)
+	return _u
+}
+
+// AddMagicLinks adds the "magic_links" edges to the MagicLink entity.
+func (_u *UserUpdateOne) AddMagicLinks(v ...*MagicLink) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddMagicLinkIDs(ids...)
+}
+
+// AddRecordingIDs adds the "recordings" edge to the Recording entity by IDs.
+func (_u *UserUpdateOne) AddRecordingIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddRecordingIDs(ids...)
+	return _u
+}
+
+// AddRecordings adds the "recordings" edges to the Recording entity.
+func (_u *UserUpdateOne) AddRecordings(v ...*Recording) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddRecordingIDs(ids...)
+}
+
+// AddTerminalShareIDs adds the "terminal_shares" edge to the TerminalShare entity by IDs.
+func (_u *UserUpdateOne) AddTerminalShareIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.AddTerminalShareIDs(ids...)
+	return _u
+}
+
+// AddTerminalShares adds the "terminal_shares" edges to the TerminalShare entity.
+func (_u *UserUpdateOne) AddTerminalShares(v ...*TerminalShare) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddTerminalShareIDs(ids...)
+}
+
+// Mutation returns the UserMutation object of the builder.
+func (_u *UserUpdateOne) Mutation() *UserMutation {
+	return _u.mutation
+}
+
+// ClearInstances clears all "instances" edges to the Instance entity.
+func (_u *UserUpdateOne) ClearInstances() *UserUpdateOne {
+	_u.mutation.ClearInstances()
+	return _u
+}
+
+// RemoveInstanceIDs removes the "instances" edge to Instance entities by IDs.
+func (_u *UserUpdateOne) RemoveInstanceIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveInstanceIDs(ids...)
+	return _u
+}
+
+// RemoveInstances removes "instances" edges to Instance entities.
+func (_u *UserUpdateOne) RemoveInstances(v ...*Instance) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveInstanceIDs(ids...)
+}
+
+// ClearConversations clears all "conversations" edges to the Conversation entity.
+func (_u *UserUpdateOne) ClearConversations() *UserUpdateOne {
+	_u.mutation.ClearConversations()
+	return _u
+}
+
+// RemoveConversationIDs removes the "conversations" edge to Conversation entities by IDs.
+func (_u *UserUpdateOne) RemoveConversationIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveConversationIDs(ids...)
 	return _u
 }
 
-// SetNillablePlan sets the "plan" field if the given value is not nil.
-func (_u *UserUpdateOne) SetNillablePlan(v *string) *UserUpdateOne {
-	if v != nil {
-		_u.SetPlan(*v)
-	}
+// RemoveConversations removes "conversations" edges to Conversation entities.
+func (_u *UserUpdateOne) RemoveConversations(v ...*Conversation) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveConversationIDs(ids...)
+}
+
+// ClearJobs clears all "jobs" edges to the Job entity.
+func (_u *UserUpdateOne) ClearJobs() *UserUpdateOne {
+	_u.mutation.ClearJobs()
+	return _u
+}
+
+// RemoveJobIDs removes the "jobs" edge to Job entities by IDs.
+func (_u *UserUpdateOne) RemoveJobIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveJobIDs(ids...)
+	return _u
+}
+
+// RemoveJobs removes "jobs" edges to Job entities.
+func (_u *UserUpdateOne) RemoveJobs(v ...*Job) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveJobIDs(ids...)
+}
+
+// ClearBackups clears all "backups" edges to the Backup entity.
+func (_u *UserUpdateOne) ClearBackups() *UserUpdateOne {
+	_u.mutation.ClearBackups()
+	return _u
+}
+
+// RemoveBackupIDs removes the "backups" edge to Backup entities by IDs.
+func (_u *UserUpdateOne) RemoveBackupIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveBackupIDs(ids...)
+	return _u
+}
+
+// RemoveBackups removes "backups" edges to Backup entities.
+func (_u *UserUpdateOne) RemoveBackups(v ...*Backup) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveBackupIDs(ids...)
+}
+
+// ClearTemplates clears all "templates" edges to the Template entity.
+func (_u *UserUpdateOne) ClearTemplates() *UserUpdateOne {
+	_u.mutation.ClearTemplates()
+	return _u
+}
+
+// RemoveTemplateIDs removes the "templates" edge to Template entities by IDs.
+func (_u *UserUpdateOne) RemoveTemplateIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveTemplateIDs(ids...)
+	return _u
+}
+
+// RemoveTemplates removes "templates" edges to Template entities.
+func (_u *UserUpdateOne) RemoveTemplates(v ...*Template) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveTemplateIDs(ids...)
+}
+
+// ClearSecrets clears all "secrets" edges to the Secret entity.
+func (_u *UserUpdateOne) ClearSecrets() *UserUpdateOne {
+	_u.mutation.ClearSecrets()
+	return _u
+}
+
+// RemoveSecretIDs removes the "secrets" edge to Secret entities by IDs.
+func (_u *UserUpdateOne) RemoveSecretIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveSecretIDs(ids...)
+	return _u
+}
+
+// RemoveSecrets removes "secrets" edges to Secret entities.
+func (_u *UserUpdateOne) RemoveSecrets(v ...*Secret) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveSecretIDs(ids...)
+}
+
+// ClearAccessTokens clears all "access_tokens" edges to the AccessToken entity.
+func (_u *UserUpdateOne) ClearAccessTokens() *UserUpdateOne {
+	_u.mutation.ClearAccessTokens()
+	return _u
+}
+
+// RemoveAccessTokenIDs removes the "access_tokens" edge to AccessToken entities by IDs.
+func (_u *UserUpdateOne) RemoveAccessTokenIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveAccessTokenIDs(ids...)
+	return _u
+}
+
+// RemoveAccessTokens removes "access_tokens" edges to AccessToken entities.
+func (_u *UserUpdateOne) RemoveAccessTokens(v ...*AccessToken) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveAccessTokenIDs(ids...)
+}
+
+// ClearDeviceCodes clears all "device_codes" edges to the DeviceCode entity.
+func (_u *UserUpdateOne) ClearDeviceCodes() *UserUpdateOne {
+	_u.mutation.ClearDeviceCodes()
+	return _u
+}
+
+// RemoveDeviceCodeIDs removes the "device_codes" edge to DeviceCode entities by IDs.
+func (_u *UserUpdateOne) RemoveDeviceCodeIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveDeviceCodeIDs(ids...)
+	return _u
+}
+
+// RemoveDeviceCodes removes "device_codes" edges to DeviceCode entities.
+func (_u *UserUpdateOne) RemoveDeviceCodes(v ...*DeviceCode) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveDeviceCodeIDs(ids...)
+}
+
+// ClearIdentities clears all "identities" edges to the Identity entity.
+func (_u *UserUpdateOne) ClearIdentities() *UserUpdateOne {
+	_u.mutation.ClearIdentities()
+	return _u
+}
+
+// RemoveIdentityIDs removes the "identities" edge to Identity entities by IDs.
+func (_u *UserUpdateOne) RemoveIdentityIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveIdentityIDs(ids...)
+	return _u
+}
+
+// RemoveIdentities removes "identities" edges to Identity entities.
+func (_u *UserUpdateOne) RemoveIdentities(v ...*Identity) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveIdentityIDs(ids...)
+}
+
+// ClearMemberships clears all "memberships" edges to the Membership entity.
+func (_u *UserUpdateOne) ClearMemberships() *UserUpdateOne {
+	_u.mutation.ClearMemberships()
+	return _u
+}
+
+// RemoveMembershipIDs removes the "memberships" edge to Membership entities by IDs.
+func (_u *UserUpdateOne) RemoveMembershipIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveMembershipIDs(ids...)
+	return _u
+}
+
+// RemoveMemberships removes "memberships" edges to Membership entities.
+func (_u *UserUpdateOne) RemoveMemberships(v ...*Membership) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveMembershipIDs(ids...)
+}
+
+// ClearSessions clears all "sessions" edges to the Session entity.
+func (_u *UserUpdateOne) ClearSessions() *UserUpdateOne {
+	_u.mutation.ClearSessions()
+	return _u
+}
+
+// RemoveSessionIDs removes the "sessions" edge to Session entities by IDs.
+func (_u *UserUpdateOne) RemoveSessionIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveSessionIDs(ids...)
+	return _u
+}
+
+// RemoveSessions removes "sessions" edges to Session entities.
+func (_u *UserUpdateOne) RemoveSessions(v ...*Session) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveSessionIDs(ids...)
+}
+
+// ClearMagicLinks clears all "magic_links" edges to the MagicLink entity.
+func (_u *UserUpdateOne) ClearMagicLinks() *UserUpdateOne {
+	_u.mutation.ClearMagicLinks()
+	return _u
+}
+
+// RemoveMagicLinkIDs removes the "magic_links" edge to MagicLink entities by IDs.
+func (_u *UserUpdateOne) RemoveMagicLinkIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveMagicLinkIDs(ids...)
+	return _u
+}
+
+// RemoveMagicLinks removes "magic_links" edges to MagicLink entities.
+func (_u *UserUpdateOne) RemoveMagicLinks(v ...*MagicLink) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveMagicLinkIDs(ids...)
+}
+
+// ClearRecordings clears all "recordings" edges to the Recording entity.
+func (_u *UserUpdateOne) ClearRecordings() *UserUpdateOne {
+	_u.mutation.ClearRecordings()
 	return _u
 }
 
-// SetUsageHours sets the "usage_hours" field.
-func (_u *UserUpdateOne) SetUsageHours(v float64) *UserUpdateOne {
-	_u.mutation.ResetUsageHours()
-	_u.mutation.SetUsageHours(v)
+// RemoveRecordingIDs removes the "recordings" edge to Recording entities by IDs.
+func (_u *UserUpdateOne) RemoveRecordingIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveRecordingIDs(ids...)
 	return _u
 }
 
-// SetNillableUsageHours sets the "usage_hours" field if the given value is not nil.
-func (_u *UserUpdateOne) SetNillableUsageHours(v *float64) *UserUpdateOne {
-	if v != nil {
-		_u.SetUsageHours(*v)
+// RemoveRecordings removes "recordings" edges to Recording entities.
+func (_u *UserUpdateOne) RemoveRecordings(v ...*Recording) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
-	return _u
+	return _u.RemoveRecordingIDs(ids...)
 }
 
-// AddUsageHours adds value to the "usage_hours" field.
-func (_u *UserUpdateOne) AddUsageHours(v float64) *UserUpdateOne {
-	_u.mutation.AddUsageHours(v)
+// ClearTerminalShares clears all "terminal_shares" edges to the TerminalShare entity.
+func (_u *UserUpdateOne) ClearTerminalShares() *UserUpdateOne {
+	_u.mutation.ClearTerminalShares()
 	return _u
 }
 
-// SetAnthropicAPIKey sets the "anthropic_api_key" field.
-func (_u *UserUpdateOne) SetAnthropicAPIKey(v string) *UserUpdateOne {
-	_u.mutation.SetAnthropicAPIKey(v)
+// RemoveTerminalShareIDs removes the "terminal_shares" edge to TerminalShare entities by IDs.
+func (_u *UserUpdateOne) RemoveTerminalShareIDs(ids ...int) *UserUpdateOne {
+	_u.mutation.RemoveTerminalShareIDs(ids...)
 	return _u
 }
 
-// SetNillableAnthropicAPIKey sets the "anthropic_api_key" field if the given value is not nil.
-func (_u *UserUpdateOne) SetNillableAnthropicAPIKey(v *string) *UserUpdateOne {
-	if v != nil {
-		_u.SetAnthropicAPIKey(*v)
+// RemoveTerminalShares removes "terminal_shares" edges to TerminalShare entities.
+func (_u *UserUpdateOne) RemoveTerminalShares(v ...*TerminalShare) *UserUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
-	return _u
+	return _u.RemoveTerminalShareIDs(ids...)
 }
 
-// ClearAnthropicAPIKey clears the value of the "anthropic_api_key" field.
-func (_u *UserUpdateOne) ClearAnthropicAPIKey() *UserUpdateOne {
-	_u.mutation.ClearAnthropicAPIKey()
+// Where appends a list predicates to the UserUpdate builder.
+func (_u *UserUpdateOne) Where(ps ...predicate.User) *UserUpdateOne {
+	_u.mutation.Where(ps...)
 	return _u
 }
 
-// SetClaudeOauthToken sets the "claude_oauth_token" field.
-func (_u *UserUpdateOne) SetClaudeOauthToken(v string) *UserUpdateOne {
-	_u.mutation.SetClaudeOauthToken(v)
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *UserUpdateOne) Select(field string, fields ...string) *UserUpdateOne {
+	_u.fields = append([]string{field}, fields...)
 	return _u
 }
 
-// SetNillableClaudeOauthToken sets the "claude_oauth_token" field if the given value is not nil.
-func (_u *UserUpdateOne) SetNillableClaudeOauthToken(v *string) *UserUpdateOne {
-	if v != nil {
-		_u.SetClaudeOauthToken(*v)
+// Save executes the query and returns the updated User entity.
+func (_u *UserUpdateOne) Save(ctx context.Context) (*User, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *UserUpdateOne) SaveX(ctx context.Context) *User {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
 	}
-	return _u
+	return node
 }
 
-// ClearClaudeOauthToken clears the value of the "claude_oauth_token" field.
-func (_u *UserUpdateOne) ClearClaudeOauthToken() *UserUpdateOne {
-	_u.mutation.ClearClaudeOauthToken()
-	return _u
+// Exec executes the query on the entity.
+func (_u *UserUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (_u *UserUpdateOne) SetUpdatedAt(v time.Time) *UserUpdateOne {
-	_u.mutation.SetUpdatedAt(v)
-	return _u
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *UserUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
 }
 
-// AddInstanceIDs adds the "instances" edge to the Instance entity by IDs.
-func (_u *UserUpdateOne) AddInstanceIDs(ids ...int) *UserUpdateOne {
-	_u.mutation.AddInstanceIDs(ids...)
-	return _u
+// defaults sets the default values of the builder before save.
+func (_u *UserUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := user.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
 }
 
-// AddInstances adds the "instances" edges to the Instance entity.
-func (_u *UserUpdateOne) AddInstances(v ...*Instance) *UserUpdateOne {
-	ids := make([]int, len(v))
-	for i := range v {
-		ids[i] = v[i].ID
+// check runs all checks and user-defined validators on the builder.
+func (_u *UserUpdateOne) check() error {
+	if v, ok := _u.mutation.Email(); ok {
+		if err := user.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "User.email": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.TerminalRecording(); ok {
+		if err := user.TerminalRecordingValidator(v); err != nil {
+			return &ValidationError{Name: "terminal_recording", err: fmt.Errorf(`ent: validator failed for field "User.terminal_recording": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(user.Table, user.Columns, sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "User.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, user.FieldID)
+		for _, f := range fields {
+			if !user.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != user.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Email(); ok {
+		_spec.SetField(user.FieldEmail, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(user.FieldName, field.TypeString, value)
+	}
+	if _u.mutation.NameCleared() {
+		_spec.ClearField(user.FieldName, field.TypeString)
+	}
+	if value, ok := _u.mutation.StripeCustomerID(); ok {
+		_spec.SetField(user.FieldStripeCustomerID, field.TypeString, value)
+	}
+	if _u.mutation.StripeCustomerIDCleared() {
+		_spec.ClearField(user.FieldStripeCustomerID, field.TypeString)
+	}
+	if value, ok := _u.mutation.StripeSubscriptionID(); ok {
+		_spec.SetField(user.FieldStripeSubscriptionID, field.TypeString, value)
+	}
+	if _u.mutation.StripeSubscriptionIDCleared() {
+		_spec.ClearField(user.FieldStripeSubscriptionID, field.TypeString)
+	}
+	if value, ok := _u.mutation.SubscriptionStatus(); ok {
+		_spec.SetField(user.FieldSubscriptionStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Plan(); ok {
+		_spec.SetField(user.FieldPlan, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UsageHours(); ok {
+		_spec.SetField(user.FieldUsageHours, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedUsageHours(); ok {
+		_spec.AddField(user.FieldUsageHours, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AnthropicAPIKey(); ok {
+		vv, err := user.ValueScanner.AnthropicAPIKey.Value(value)
+		if err != nil {
+			return nil, err
+		}
+		_spec.SetField(user.FieldAnthropicAPIKey, field.TypeString, vv)
+	}
+	if _u.mutation.AnthropicAPIKeyCleared() {
+		_spec.ClearField(user.FieldAnthropicAPIKey, field.TypeString)
+	}
+	if value, ok := _u.mutation.ClaudeOauthToken(); ok {
+		vv, err := user.ValueScanner.ClaudeOauthToken.Value(value)
+		if err != nil {
+			return nil, err
+		}
+		_spec.SetField(user.FieldClaudeOauthToken, field.TypeString, vv)
+	}
+	if _u.mutation.ClaudeOauthTokenCleared() {
+		_spec.ClearField(user.FieldClaudeOauthToken, field.TypeString)
+	}
+	if value, ok := _u.mutation.MagicLinkFailures(); ok {
+		_spec.SetField(user.FieldMagicLinkFailures, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMagicLinkFailures(); ok {
+		_spec.AddField(user.FieldMagicLinkFailures, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MagicLinkFailedAt(); ok {
+		_spec.SetField(user.FieldMagicLinkFailedAt, field.TypeTime, value)
+	}
+	if _u.mutation.MagicLinkFailedAtCleared() {
+		_spec.ClearField(user.FieldMagicLinkFailedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.TerminalRecording(); ok {
+		_spec.SetField(user.FieldTerminalRecording, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(user.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.InstancesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.InstancesTable,
+			Columns: []string{user.InstancesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(instance.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedInstancesIDs(); len(nodes) > 0 && !_u.mutation.InstancesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.InstancesTable,
+			Columns: []string{user.InstancesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(instance.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.InstancesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.InstancesTable,
+			Columns: []string{user.InstancesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(instance.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ConversationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.ConversationsTable,
+			Columns: []string{user.ConversationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedConversationsIDs(); len(nodes) > 0 && !_u.mutation.ConversationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.ConversationsTable,
+			Columns: []string{user.ConversationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	return _u.AddInstanceIDs(ids...)
-}
-
-// AddConversationIDs adds the "conversations" edge to the Conversation entity by IDs.
-func (_u *UserUpdateOne) AddConversationIDs(ids ...int) *UserUpdateOne {
-	_u.mutation.AddConversationIDs(ids...)
-	return _u
-}
-
-// AddConversations adds the "conversations" edges to the Conversation entity.
-func (_u *UserUpdateOne) AddConversations(v ...*Conversation) *UserUpdateOne {
-	ids := make([]int, len(v))
-	for i := range v {
-		ids[i] = v[i].ID
+	if nodes := _u.mutation.ConversationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.ConversationsTable,
+			Columns: []string{user.ConversationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	return _u.AddConversationIDs(ids...)
-}
-
-// Mutation returns the UserMutation object of the builder.
-func (_u *UserUpdateOne) Mutation() *UserMutation {
-	return _u.mutation
-}
-
-// ClearInstances clears all "instances" edges to the Instance entity.
-func (_u *UserUpdateOne) ClearInstances() *UserUpdateOne {
-	_u.mutation.ClearInstances()
-	return _u
-}
-
-// RemoveInstanceIDs removes the "instances" edge to Instance entities by IDs.
-func (_u *UserUpdateOne) RemoveInstanceIDs(ids ...int) *UserUpdateOne {
-	_u.mutation.RemoveInstanceIDs(ids...)
-	return _u
-}
-
-// RemoveInstances removes "instances" edges to Instance entities.
-func (_u *UserUpdateOne) RemoveInstances(v ...*Instance) *UserUpdateOne {
-	ids := make([]int, len(v))
-	for i := range v {
-		ids[i] = v[i].ID
+	if _u.mutation.JobsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.JobsTable,
+			Columns: []string{user.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	return _u.RemoveInstanceIDs(ids...)
-}
-
-// ClearConversations clears all "conversations" edges to the Conversation entity.
-func (_u *UserUpdateOne) ClearConversations() *UserUpdateOne {
-	_u.mutation.ClearConversations()
-	return _u
-}
-
-// RemoveConversationIDs removes the "conversations" edge to Conversation entities by IDs.
-func (_u *UserUpdateOne) RemoveConversationIDs(ids ...int) *UserUpdateOne {
-	_u.mutation.RemoveConversationIDs(ids...)
-	return _u
-}
-
-// RemoveConversations removes "conversations" edges to Conversation entities.
-func (_u *UserUpdateOne) RemoveConversations(v ...*Conversation) *UserUpdateOne {
-	ids := make([]int, len(v))
-	for i := range v {
-		ids[i] = v[i].ID
+	if nodes := _u.mutation.RemovedJobsIDs(); len(nodes) > 0 && !_u.mutation.JobsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.JobsTable,
+			Columns: []string{user.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	return _u.RemoveConversationIDs(ids...)
-}
-
-// Where appends a list predicates to the UserUpdate builder.
-func (_u *UserUpdateOne) Where(ps ...predicate.User) *UserUpdateOne {
-	_u.mutation.Where(ps...)
-	return _u
-}
-
-// Select allows selecting one or more fields (columns) of the returned entity.
-// The default is selecting all fields defined in the entity schema.
-func (_u *UserUpdateOne) Select(field string, fields ...string) *UserUpdateOne {
-	_u.fields = append([]string{field}, fields...)
-	return _u
-}
-
-// Save executes the query and returns the updated User entity.
-func (_u *UserUpdateOne) Save(ctx context.Context) (*User, error) {
-	_u.defaults()
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
-}
-
-// SaveX is like Save, but panics if an error occurs.
-func (_u *UserUpdateOne) SaveX(ctx context.Context) *User {
-	node, err := _u.Save(ctx)
-	if err != nil {
-		panic(err)
+	if nodes := _u.mutation.JobsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.JobsTable,
+			Columns: []string{user.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	return node
-}
-
-// Exec executes the query on the entity.
-func (_u *UserUpdateOne) Exec(ctx context.Context) error {
-	_, err := _u.Save(ctx)
-	return err
-}
-
-// ExecX is like Exec, but panics if an error occurs.
-func (_u *UserUpdateOne) ExecX(ctx context.Context) {
-	if err := _u.Exec(ctx); err != nil {
-		panic(err)
+	if _u.mutation.BackupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.BackupsTable,
+			Columns: []string{user.BackupsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(backup.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-}
-
-// defaults sets the default values of the builder before save.
-func (_u *UserUpdateOne) defaults() {
-	if _, ok := _u.mutation.UpdatedAt(); !ok {
-		v := user.UpdateDefaultUpdatedAt()
-		_u.mutation.SetUpdatedAt(v)
+	if nodes := _u.mutation.RemovedBackupsIDs(); len(nodes) > 0 && !_u.mutation.BackupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.BackupsTable,
+			Columns: []string{user.BackupsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(backup.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-}
-
-// check runs all checks and user-defined validators on the builder.
-func (_u *UserUpdateOne) check() error {
-	if v, ok := _u.mutation.Email(); ok {
-		if err := user.EmailValidator(v); err != nil {
-			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "User.email": %w`, err)}
+	if nodes := _u.mutation.BackupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.BackupsTable,
+			Columns: []string{user.BackupsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(backup.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TemplatesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.TemplatesTable,
+			Columns: []string{user.TemplatesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(template.FieldID, field.TypeInt),
+			},
 		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	return nil
-}
-
-func (_u *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
-	if err := _u.check(); err != nil {
-		return _node, err
+	if nodes := _u.mutation.RemovedTemplatesIDs(); len(nodes) > 0 && !_u.mutation.TemplatesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.TemplatesTable,
+			Columns: []string{user.TemplatesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(template.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	_spec := sqlgraph.NewUpdateSpec(user.Table, user.Columns, sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt))
-	id, ok := _u.mutation.ID()
-	if !ok {
-		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "User.id" for update`)}
+	if nodes := _u.mutation.TemplatesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.TemplatesTable,
+			Columns: []string{user.TemplatesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(template.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	_spec.Node.ID.Value = id
-	if fields := _u.fields; len(fields) > 0 {
-		_spec.Node.Columns = make([]string, 0, len(fields))
-		_spec.Node.Columns = append(_spec.Node.Columns, user.FieldID)
-		for _, f := range fields {
-			if !user.ValidColumn(f) {
-				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
-			}
-			if f != user.FieldID {
-				_spec.Node.Columns = append(_spec.Node.Columns, f)
-			}
+	if _u.mutation.SecretsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SecretsTable,
+			Columns: []string{user.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secret.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSecretsIDs(); len(nodes) > 0 && !_u.mutation.SecretsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SecretsTable,
+			Columns: []string{user.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secret.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
 		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if ps := _u.mutation.predicates; len(ps) > 0 {
-		_spec.Predicate = func(selector *sql.Selector) {
-			for i := range ps {
-				ps[i](selector)
-			}
+	if nodes := _u.mutation.SecretsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SecretsTable,
+			Columns: []string{user.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secret.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
 		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if value, ok := _u.mutation.Email(); ok {
-		_spec.SetField(user.FieldEmail, field.TypeString, value)
+	if _u.mutation.AccessTokensCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.AccessTokensTable,
+			Columns: []string{user.AccessTokensColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(accesstoken.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if value, ok := _u.mutation.APIKey(); ok {
-		_spec.SetField(user.FieldAPIKey, field.TypeString, value)
+	if nodes := _u.mutation.RemovedAccessTokensIDs(); len(nodes) > 0 && !_u.mutation.AccessTokensCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.AccessTokensTable,
+			Columns: []string{user.AccessTokensColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(accesstoken.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if _u.mutation.APIKeyCleared() {
-		_spec.ClearField(user.FieldAPIKey, field.TypeString)
+	if nodes := _u.mutation.AccessTokensIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.AccessTokensTable,
+			Columns: []string{user.AccessTokensColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(accesstoken.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if value, ok := _u.mutation.Name(); ok {
-		_spec.SetField(user.FieldName, field.TypeString, value)
+	if _u.mutation.DeviceCodesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.DeviceCodesTable,
+			Columns: []string{user.DeviceCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(devicecode.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if _u.mutation.NameCleared() {
-		_spec.ClearField(user.FieldName, field.TypeString)
+	if nodes := _u.mutation.RemovedDeviceCodesIDs(); len(nodes) > 0 && !_u.mutation.DeviceCodesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.DeviceCodesTable,
+			Columns: []string{user.DeviceCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(devicecode.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if value, ok := _u.mutation.StripeCustomerID(); ok {
-		_spec.SetField(user.FieldStripeCustomerID, field.TypeString, value)
+	if nodes := _u.mutation.DeviceCodesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.DeviceCodesTable,
+			Columns: []string{user.DeviceCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(devicecode.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if _u.mutation.StripeCustomerIDCleared() {
-		_spec.ClearField(user.FieldStripeCustomerID, field.TypeString)
+	if _u.mutation.IdentitiesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.IdentitiesTable,
+			Columns: []string{user.IdentitiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(identity.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if value, ok := _u.mutation.StripeSubscriptionID(); ok {
-		_spec.SetField(user.FieldStripeSubscriptionID, field.TypeString, value)
+	if nodes := _u.mutation.RemovedIdentitiesIDs(); len(nodes) > 0 && !_u.mutation.IdentitiesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.IdentitiesTable,
+			Columns: []string{user.IdentitiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(identity.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if _u.mutation.StripeSubscriptionIDCleared() {
-		_spec.ClearField(user.FieldStripeSubscriptionID, field.TypeString)
+	if nodes := _u.mutation.IdentitiesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.IdentitiesTable,
+			Columns: []string{user.IdentitiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(identity.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if value, ok := _u.mutation.SubscriptionStatus(); ok {
-		_spec.SetField(user.FieldSubscriptionStatus, field.TypeString, value)
+	if _u.mutation.MembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MembershipsTable,
+			Columns: []string{user.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if value, ok := _u.mutation.Plan(); ok {
-		_spec.SetField(user.FieldPlan, field.TypeString, value)
+	if nodes := _u.mutation.RemovedMembershipsIDs(); len(nodes) > 0 && !_u.mutation.MembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MembershipsTable,
+			Columns: []string{user.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if value, ok := _u.mutation.UsageHours(); ok {
-		_spec.SetField(user.FieldUsageHours, field.TypeFloat64, value)
+	if nodes := _u.mutation.MembershipsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MembershipsTable,
+			Columns: []string{user.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if value, ok := _u.mutation.AddedUsageHours(); ok {
-		_spec.AddField(user.FieldUsageHours, field.TypeFloat64, value)
+	if _u.mutation.SessionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SessionsTable,
+			Columns: []string{user.SessionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(session.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if value, ok := _u.mutation.AnthropicAPIKey(); ok {
-		_spec.SetField(user.FieldAnthropicAPIKey, field.TypeString, value)
+	if nodes := _u.mutation.RemovedSessionsIDs(); len(nodes) > 0 && !_u.mutation.SessionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SessionsTable,
+			Columns: []string{user.SessionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(session.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if _u.mutation.AnthropicAPIKeyCleared() {
-		_spec.ClearField(user.FieldAnthropicAPIKey, field.TypeString)
+	if nodes := _u.mutation.SessionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.SessionsTable,
+			Columns: []string{user.SessionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(session.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if value, ok := _u.mutation.ClaudeOauthToken(); ok {
-		_spec.SetField(user.FieldClaudeOauthToken, field.TypeString, value)
+	if _u.mutation.MagicLinksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MagicLinksTable,
+			Columns: []string{user.MagicLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(magiclink.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if _u.mutation.ClaudeOauthTokenCleared() {
-		_spec.ClearField(user.FieldClaudeOauthToken, field.TypeString)
+	if nodes := _u.mutation.RemovedMagicLinksIDs(); len(nodes) > 0 && !_u.mutation.MagicLinksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MagicLinksTable,
+			Columns: []string{user.MagicLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(magiclink.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if value, ok := _u.mutation.UpdatedAt(); ok {
-		_spec.SetField(user.FieldUpdatedAt, field.TypeTime, value)
+	if nodes := _u.mutation.MagicLinksIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.MagicLinksTable,
+			Columns: []string{user.MagicLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(magiclink.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if _u.mutation.InstancesCleared() {
+	if _u.mutation.RecordingsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
 			Inverse: false,
-			Table:   user.InstancesTable,
-			Columns: []string{user.InstancesColumn},
+			Table:   user.RecordingsTable,
+			Columns: []string{user.RecordingsColumn},
 			Bidi:    false,
 			Target: &sqlgraph.EdgeTarget{
-				IDSpec: sqlgraph.NewFieldSpec(instance.FieldID, field.TypeInt),
+				IDSpec: sqlgraph.NewFieldSpec(recording.FieldID, field.TypeInt),
 			},
 		}
 		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if nodes := _u.mutation.RemovedInstancesIDs(); len(nodes) > 0 && !_u.mutation.InstancesCleared() {
+	if nodes := _u.mutation.RemovedRecordingsIDs(); len(nodes) > 0 && !_u.mutation.RecordingsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
 			Inverse: false,
-			Table:   user.InstancesTable,
-			Columns: []string{user.InstancesColumn},
+			Table:   user.RecordingsTable,
+			Columns: []string{user.RecordingsColumn},
 			Bidi:    false,
 			Target: &sqlgraph.EdgeTarget{
-				IDSpec: sqlgraph.NewFieldSpec(instance.FieldID, field.TypeInt),
+				IDSpec: sqlgraph.NewFieldSpec(recording.FieldID, field.TypeInt),
 			},
 		}
 		for _, k := range nodes {
@@ -955,15 +3025,15 @@ func (_u *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
 		}
 		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if nodes := _u.mutation.InstancesIDs(); len(nodes) > 0 {
+	if nodes := _u.mutation.RecordingsIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
 			Inverse: false,
-			Table:   user.InstancesTable,
-			Columns: []string{user.InstancesColumn},
+			Table:   user.RecordingsTable,
+			Columns: []string{user.RecordingsColumn},
 			Bidi:    false,
 			Target: &sqlgraph.EdgeTarget{
-				IDSpec: sqlgraph.NewFieldSpec(instance.FieldID, field.TypeInt),
+				IDSpec: sqlgraph.NewFieldSpec(recording.FieldID, field.TypeInt),
 			},
 		}
 		for _, k := range nodes {
@@ -971,28 +3041,28 @@ func (_u *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if _u.mutation.ConversationsCleared() {
+	if _u.mutation.TerminalSharesCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
 			Inverse: false,
-			Table:   user.ConversationsTable,
-			Columns: []string{user.ConversationsColumn},
+			Table:   user.TerminalSharesTable,
+			Columns: []string{user.TerminalSharesColumn},
 			Bidi:    false,
 			Target: &sqlgraph.EdgeTarget{
-				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+				IDSpec: sqlgraph.NewFieldSpec(terminalshare.FieldID, field.TypeInt),
 			},
 		}
 		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if nodes := _u.mutation.RemovedConversationsIDs(); len(nodes) > 0 && !_u.mutation.ConversationsCleared() {
+	if nodes := _u.mutation.RemovedTerminalSharesIDs(); len(nodes) > 0 && !_u.mutation.TerminalSharesCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
 			Inverse: false,
-			Table:   user.ConversationsTable,
-			Columns: []string{user.ConversationsColumn},
+			Table:   user.TerminalSharesTable,
+			Columns: []string{user.TerminalSharesColumn},
 			Bidi:    false,
 			Target: &sqlgraph.EdgeTarget{
-				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+				IDSpec: sqlgraph.NewFieldSpec(terminalshare.FieldID, field.TypeInt),
 			},
 		}
 		for _, k := range nodes {
@@ -1000,15 +3070,15 @@ func (_u *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
 		}
 		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	if nodes := _u.mutation.ConversationsIDs(); len(nodes) > 0 {
+	if nodes := _u.mutation.TerminalSharesIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
 			Inverse: false,
-			Table:   user.ConversationsTable,
-			Columns: []string{user.ConversationsColumn},
+			Table:   user.TerminalSharesTable,
+			Columns: []string{user.TerminalSharesColumn},
 			Bidi:    false,
 			Target: &sqlgraph.EdgeTarget{
-				IDSpec: sqlgraph.NewFieldSpec(conversation.FieldID, field.TypeInt),
+				IDSpec: sqlgraph.NewFieldSpec(terminalshare.FieldID, field.TypeInt),
 			},
 		}
 		for _, k := range nodes {
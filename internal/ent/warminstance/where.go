@@ -0,0 +1,495 @@
+// Code generated by ent, DO NOT EDIT.
+
+package warminstance
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLTE(FieldID, id))
+}
+
+// Provider applies equality check predicate on the "provider" field. It's identical to ProviderEQ.
+func Provider(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldProvider, v))
+}
+
+// ProviderID applies equality check predicate on the "provider_id" field. It's identical to ProviderIDEQ.
+func ProviderID(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldProviderID, v))
+}
+
+// Class applies equality check predicate on the "class" field. It's identical to ClassEQ.
+func Class(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldClass, v))
+}
+
+// Host applies equality check predicate on the "host" field. It's identical to HostEQ.
+func Host(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldHost, v))
+}
+
+// VolumeID applies equality check predicate on the "volume_id" field. It's identical to VolumeIDEQ.
+func VolumeID(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldVolumeID, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// ProviderEQ applies the EQ predicate on the "provider" field.
+func ProviderEQ(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldProvider, v))
+}
+
+// ProviderNEQ applies the NEQ predicate on the "provider" field.
+func ProviderNEQ(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNEQ(FieldProvider, v))
+}
+
+// ProviderIn applies the In predicate on the "provider" field.
+func ProviderIn(vs ...string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldIn(FieldProvider, vs...))
+}
+
+// ProviderNotIn applies the NotIn predicate on the "provider" field.
+func ProviderNotIn(vs ...string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNotIn(FieldProvider, vs...))
+}
+
+// ProviderGT applies the GT predicate on the "provider" field.
+func ProviderGT(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGT(FieldProvider, v))
+}
+
+// ProviderGTE applies the GTE predicate on the "provider" field.
+func ProviderGTE(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGTE(FieldProvider, v))
+}
+
+// ProviderLT applies the LT predicate on the "provider" field.
+func ProviderLT(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLT(FieldProvider, v))
+}
+
+// ProviderLTE applies the LTE predicate on the "provider" field.
+func ProviderLTE(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLTE(FieldProvider, v))
+}
+
+// ProviderContains applies the Contains predicate on the "provider" field.
+func ProviderContains(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldContains(FieldProvider, v))
+}
+
+// ProviderHasPrefix applies the HasPrefix predicate on the "provider" field.
+func ProviderHasPrefix(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldHasPrefix(FieldProvider, v))
+}
+
+// ProviderHasSuffix applies the HasSuffix predicate on the "provider" field.
+func ProviderHasSuffix(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldHasSuffix(FieldProvider, v))
+}
+
+// ProviderEqualFold applies the EqualFold predicate on the "provider" field.
+func ProviderEqualFold(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEqualFold(FieldProvider, v))
+}
+
+// ProviderContainsFold applies the ContainsFold predicate on the "provider" field.
+func ProviderContainsFold(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldContainsFold(FieldProvider, v))
+}
+
+// ProviderIDEQ applies the EQ predicate on the "provider_id" field.
+func ProviderIDEQ(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldProviderID, v))
+}
+
+// ProviderIDNEQ applies the NEQ predicate on the "provider_id" field.
+func ProviderIDNEQ(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNEQ(FieldProviderID, v))
+}
+
+// ProviderIDIn applies the In predicate on the "provider_id" field.
+func ProviderIDIn(vs ...string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldIn(FieldProviderID, vs...))
+}
+
+// ProviderIDNotIn applies the NotIn predicate on the "provider_id" field.
+func ProviderIDNotIn(vs ...string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNotIn(FieldProviderID, vs...))
+}
+
+// ProviderIDGT applies the GT predicate on the "provider_id" field.
+func ProviderIDGT(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGT(FieldProviderID, v))
+}
+
+// ProviderIDGTE applies the GTE predicate on the "provider_id" field.
+func ProviderIDGTE(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGTE(FieldProviderID, v))
+}
+
+// ProviderIDLT applies the LT predicate on the "provider_id" field.
+func ProviderIDLT(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLT(FieldProviderID, v))
+}
+
+// ProviderIDLTE applies the LTE predicate on the "provider_id" field.
+func ProviderIDLTE(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLTE(FieldProviderID, v))
+}
+
+// ProviderIDContains applies the Contains predicate on the "provider_id" field.
+func ProviderIDContains(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldContains(FieldProviderID, v))
+}
+
+// ProviderIDHasPrefix applies the HasPrefix predicate on the "provider_id" field.
+func ProviderIDHasPrefix(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldHasPrefix(FieldProviderID, v))
+}
+
+// ProviderIDHasSuffix applies the HasSuffix predicate on the "provider_id" field.
+func ProviderIDHasSuffix(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldHasSuffix(FieldProviderID, v))
+}
+
+// ProviderIDEqualFold applies the EqualFold predicate on the "provider_id" field.
+func ProviderIDEqualFold(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEqualFold(FieldProviderID, v))
+}
+
+// ProviderIDContainsFold applies the ContainsFold predicate on the "provider_id" field.
+func ProviderIDContainsFold(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldContainsFold(FieldProviderID, v))
+}
+
+// ClassEQ applies the EQ predicate on the "class" field.
+func ClassEQ(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldClass, v))
+}
+
+// ClassNEQ applies the NEQ predicate on the "class" field.
+func ClassNEQ(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNEQ(FieldClass, v))
+}
+
+// ClassIn applies the In predicate on the "class" field.
+func ClassIn(vs ...string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldIn(FieldClass, vs...))
+}
+
+// ClassNotIn applies the NotIn predicate on the "class" field.
+func ClassNotIn(vs ...string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNotIn(FieldClass, vs...))
+}
+
+// ClassGT applies the GT predicate on the "class" field.
+func ClassGT(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGT(FieldClass, v))
+}
+
+// ClassGTE applies the GTE predicate on the "class" field.
+func ClassGTE(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGTE(FieldClass, v))
+}
+
+// ClassLT applies the LT predicate on the "class" field.
+func ClassLT(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLT(FieldClass, v))
+}
+
+// ClassLTE applies the LTE predicate on the "class" field.
+func ClassLTE(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLTE(FieldClass, v))
+}
+
+// ClassContains applies the Contains predicate on the "class" field.
+func ClassContains(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldContains(FieldClass, v))
+}
+
+// ClassHasPrefix applies the HasPrefix predicate on the "class" field.
+func ClassHasPrefix(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldHasPrefix(FieldClass, v))
+}
+
+// ClassHasSuffix applies the HasSuffix predicate on the "class" field.
+func ClassHasSuffix(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldHasSuffix(FieldClass, v))
+}
+
+// ClassIsNil applies the IsNil predicate on the "class" field.
+func ClassIsNil() predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldIsNull(FieldClass))
+}
+
+// ClassNotNil applies the NotNil predicate on the "class" field.
+func ClassNotNil() predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNotNull(FieldClass))
+}
+
+// ClassEqualFold applies the EqualFold predicate on the "class" field.
+func ClassEqualFold(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEqualFold(FieldClass, v))
+}
+
+// ClassContainsFold applies the ContainsFold predicate on the "class" field.
+func ClassContainsFold(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldContainsFold(FieldClass, v))
+}
+
+// HostEQ applies the EQ predicate on the "host" field.
+func HostEQ(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldHost, v))
+}
+
+// HostNEQ applies the NEQ predicate on the "host" field.
+func HostNEQ(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNEQ(FieldHost, v))
+}
+
+// HostIn applies the In predicate on the "host" field.
+func HostIn(vs ...string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldIn(FieldHost, vs...))
+}
+
+// HostNotIn applies the NotIn predicate on the "host" field.
+func HostNotIn(vs ...string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNotIn(FieldHost, vs...))
+}
+
+// HostGT applies the GT predicate on the "host" field.
+func HostGT(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGT(FieldHost, v))
+}
+
+// HostGTE applies the GTE predicate on the "host" field.
+func HostGTE(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGTE(FieldHost, v))
+}
+
+// HostLT applies the LT predicate on the "host" field.
+func HostLT(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLT(FieldHost, v))
+}
+
+// HostLTE applies the LTE predicate on the "host" field.
+func HostLTE(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLTE(FieldHost, v))
+}
+
+// HostContains applies the Contains predicate on the "host" field.
+func HostContains(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldContains(FieldHost, v))
+}
+
+// HostHasPrefix applies the HasPrefix predicate on the "host" field.
+func HostHasPrefix(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldHasPrefix(FieldHost, v))
+}
+
+// HostHasSuffix applies the HasSuffix predicate on the "host" field.
+func HostHasSuffix(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldHasSuffix(FieldHost, v))
+}
+
+// HostIsNil applies the IsNil predicate on the "host" field.
+func HostIsNil() predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldIsNull(FieldHost))
+}
+
+// HostNotNil applies the NotNil predicate on the "host" field.
+func HostNotNil() predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNotNull(FieldHost))
+}
+
+// HostEqualFold applies the EqualFold predicate on the "host" field.
+func HostEqualFold(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEqualFold(FieldHost, v))
+}
+
+// HostContainsFold applies the ContainsFold predicate on the "host" field.
+func HostContainsFold(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldContainsFold(FieldHost, v))
+}
+
+// VolumeIDEQ applies the EQ predicate on the "volume_id" field.
+func VolumeIDEQ(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldVolumeID, v))
+}
+
+// VolumeIDNEQ applies the NEQ predicate on the "volume_id" field.
+func VolumeIDNEQ(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNEQ(FieldVolumeID, v))
+}
+
+// VolumeIDIn applies the In predicate on the "volume_id" field.
+func VolumeIDIn(vs ...string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldIn(FieldVolumeID, vs...))
+}
+
+// VolumeIDNotIn applies the NotIn predicate on the "volume_id" field.
+func VolumeIDNotIn(vs ...string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNotIn(FieldVolumeID, vs...))
+}
+
+// VolumeIDGT applies the GT predicate on the "volume_id" field.
+func VolumeIDGT(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGT(FieldVolumeID, v))
+}
+
+// VolumeIDGTE applies the GTE predicate on the "volume_id" field.
+func VolumeIDGTE(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGTE(FieldVolumeID, v))
+}
+
+// VolumeIDLT applies the LT predicate on the "volume_id" field.
+func VolumeIDLT(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLT(FieldVolumeID, v))
+}
+
+// VolumeIDLTE applies the LTE predicate on the "volume_id" field.
+func VolumeIDLTE(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLTE(FieldVolumeID, v))
+}
+
+// VolumeIDContains applies the Contains predicate on the "volume_id" field.
+func VolumeIDContains(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldContains(FieldVolumeID, v))
+}
+
+// VolumeIDHasPrefix applies the HasPrefix predicate on the "volume_id" field.
+func VolumeIDHasPrefix(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldHasPrefix(FieldVolumeID, v))
+}
+
+// VolumeIDHasSuffix applies the HasSuffix predicate on the "volume_id" field.
+func VolumeIDHasSuffix(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldHasSuffix(FieldVolumeID, v))
+}
+
+// VolumeIDIsNil applies the IsNil predicate on the "volume_id" field.
+func VolumeIDIsNil() predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldIsNull(FieldVolumeID))
+}
+
+// VolumeIDNotNil applies the NotNil predicate on the "volume_id" field.
+func VolumeIDNotNil() predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNotNull(FieldVolumeID))
+}
+
+// VolumeIDEqualFold applies the EqualFold predicate on the "volume_id" field.
+func VolumeIDEqualFold(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEqualFold(FieldVolumeID, v))
+}
+
+// VolumeIDContainsFold applies the ContainsFold predicate on the "volume_id" field.
+func VolumeIDContainsFold(v string) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldContainsFold(FieldVolumeID, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.WarmInstance) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.WarmInstance) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.WarmInstance) predicate.WarmInstance {
+	return predicate.WarmInstance(sql.NotPredicates(p))
+}
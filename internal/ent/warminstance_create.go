@@ -0,0 +1,292 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/warminstance"
+)
+
+// WarmInstanceCreate is the builder for creating a WarmInstance entity.
+type WarmInstanceCreate struct {
+	config
+	mutation *WarmInstanceMutation
+	hooks    []Hook
+}
+
+// SetProvider sets the "provider" field.
+func (_c *WarmInstanceCreate) SetProvider(v string) *WarmInstanceCreate {
+	_c.mutation.SetProvider(v)
+	return _c
+}
+
+// SetProviderID sets the "provider_id" field.
+func (_c *WarmInstanceCreate) SetProviderID(v string) *WarmInstanceCreate {
+	_c.mutation.SetProviderID(v)
+	return _c
+}
+
+// SetClass sets the "class" field.
+func (_c *WarmInstanceCreate) SetClass(v string) *WarmInstanceCreate {
+	_c.mutation.SetClass(v)
+	return _c
+}
+
+// SetNillableClass sets the "class" field if the given value is not nil.
+func (_c *WarmInstanceCreate) SetNillableClass(v *string) *WarmInstanceCreate {
+	if v != nil {
+		_c.SetClass(*v)
+	}
+	return _c
+}
+
+// SetHost sets the "host" field.
+func (_c *WarmInstanceCreate) SetHost(v string) *WarmInstanceCreate {
+	_c.mutation.SetHost(v)
+	return _c
+}
+
+// SetNillableHost sets the "host" field if the given value is not nil.
+func (_c *WarmInstanceCreate) SetNillableHost(v *string) *WarmInstanceCreate {
+	if v != nil {
+		_c.SetHost(*v)
+	}
+	return _c
+}
+
+// SetVolumeID sets the "volume_id" field.
+func (_c *WarmInstanceCreate) SetVolumeID(v string) *WarmInstanceCreate {
+	_c.mutation.SetVolumeID(v)
+	return _c
+}
+
+// SetNillableVolumeID sets the "volume_id" field if the given value is not nil.
+func (_c *WarmInstanceCreate) SetNillableVolumeID(v *string) *WarmInstanceCreate {
+	if v != nil {
+		_c.SetVolumeID(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *WarmInstanceCreate) SetCreatedAt(v time.Time) *WarmInstanceCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *WarmInstanceCreate) SetNillableCreatedAt(v *time.Time) *WarmInstanceCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// Mutation returns the WarmInstanceMutation object of the builder.
+func (_c *WarmInstanceCreate) Mutation() *WarmInstanceMutation {
+	return _c.mutation
+}
+
+// Save creates the WarmInstance in the database.
+func (_c *WarmInstanceCreate) Save(ctx context.Context) (*WarmInstance, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *WarmInstanceCreate) SaveX(ctx context.Context) *WarmInstance {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *WarmInstanceCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *WarmInstanceCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *WarmInstanceCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := warminstance.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *WarmInstanceCreate) check() error {
+	if _, ok := _c.mutation.Provider(); !ok {
+		return &ValidationError{Name: "provider", err: errors.New(`ent: missing required field "WarmInstance.provider"`)}
+	}
+	if v, ok := _c.mutation.Provider(); ok {
+		if err := warminstance.ProviderValidator(v); err != nil {
+			return &ValidationError{Name: "provider", err: fmt.Errorf(`ent: validator failed for field "WarmInstance.provider": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ProviderID(); !ok {
+		return &ValidationError{Name: "provider_id", err: errors.New(`ent: missing required field "WarmInstance.provider_id"`)}
+	}
+	if v, ok := _c.mutation.ProviderID(); ok {
+		if err := warminstance.ProviderIDValidator(v); err != nil {
+			return &ValidationError{Name: "provider_id", err: fmt.Errorf(`ent: validator failed for field "WarmInstance.provider_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "WarmInstance.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *WarmInstanceCreate) sqlSave(ctx context.Context) (*WarmInstance, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *WarmInstanceCreate) createSpec() (*WarmInstance, *sqlgraph.CreateSpec) {
+	var (
+		_node = &WarmInstance{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(warminstance.Table, sqlgraph.NewFieldSpec(warminstance.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Provider(); ok {
+		_spec.SetField(warminstance.FieldProvider, field.TypeString, value)
+		_node.Provider = value
+	}
+	if value, ok := _c.mutation.ProviderID(); ok {
+		_spec.SetField(warminstance.FieldProviderID, field.TypeString, value)
+		_node.ProviderID = value
+	}
+	if value, ok := _c.mutation.Class(); ok {
+		_spec.SetField(warminstance.FieldClass, field.TypeString, value)
+		_node.Class = value
+	}
+	if value, ok := _c.mutation.Host(); ok {
+		_spec.SetField(warminstance.FieldHost, field.TypeString, value)
+		_node.Host = value
+	}
+	if value, ok := _c.mutation.VolumeID(); ok {
+		_spec.SetField(warminstance.FieldVolumeID, field.TypeString, value)
+		_node.VolumeID = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(warminstance.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// WarmInstanceCreateBulk is the builder for creating many WarmInstance entities in bulk.
+type WarmInstanceCreateBulk struct {
+	config
+	err      error
+	builders []*WarmInstanceCreate
+}
+
+// Save creates the WarmInstance entities in the database.
+func (_c *WarmInstanceCreateBulk) Save(ctx context.Context) ([]*WarmInstance, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*WarmInstance, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*WarmInstanceMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *WarmInstanceCreateBulk) SaveX(ctx context.Context) []*WarmInstance {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *WarmInstanceCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *WarmInstanceCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
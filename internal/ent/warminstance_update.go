@@ -0,0 +1,435 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+	"github.com/logan/cloudcode/internal/ent/warminstance"
+)
+
+// WarmInstanceUpdate is the builder for updating WarmInstance entities.
+type WarmInstanceUpdate struct {
+	config
+	hooks    []Hook
+	mutation *WarmInstanceMutation
+}
+
+// Where appends a list predicates to the WarmInstanceUpdate builder.
+func (_u *WarmInstanceUpdate) Where(ps ...predicate.WarmInstance) *WarmInstanceUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetProvider sets the "provider" field.
+func (_u *WarmInstanceUpdate) SetProvider(v string) *WarmInstanceUpdate {
+	_u.mutation.SetProvider(v)
+	return _u
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (_u *WarmInstanceUpdate) SetNillableProvider(v *string) *WarmInstanceUpdate {
+	if v != nil {
+		_u.SetProvider(*v)
+	}
+	return _u
+}
+
+// SetProviderID sets the "provider_id" field.
+func (_u *WarmInstanceUpdate) SetProviderID(v string) *WarmInstanceUpdate {
+	_u.mutation.SetProviderID(v)
+	return _u
+}
+
+// SetNillableProviderID sets the "provider_id" field if the given value is not nil.
+func (_u *WarmInstanceUpdate) SetNillableProviderID(v *string) *WarmInstanceUpdate {
+	if v != nil {
+		_u.SetProviderID(*v)
+	}
+	return _u
+}
+
+// SetClass sets the "class" field.
+func (_u *WarmInstanceUpdate) SetClass(v string) *WarmInstanceUpdate {
+	_u.mutation.SetClass(v)
+	return _u
+}
+
+// SetNillableClass sets the "class" field if the given value is not nil.
+func (_u *WarmInstanceUpdate) SetNillableClass(v *string) *WarmInstanceUpdate {
+	if v != nil {
+		_u.SetClass(*v)
+	}
+	return _u
+}
+
+// ClearClass clears the value of the "class" field.
+func (_u *WarmInstanceUpdate) ClearClass() *WarmInstanceUpdate {
+	_u.mutation.ClearClass()
+	return _u
+}
+
+// SetHost sets the "host" field.
+func (_u *WarmInstanceUpdate) SetHost(v string) *WarmInstanceUpdate {
+	_u.mutation.SetHost(v)
+	return _u
+}
+
+// SetNillableHost sets the "host" field if the given value is not nil.
+func (_u *WarmInstanceUpdate) SetNillableHost(v *string) *WarmInstanceUpdate {
+	if v != nil {
+		_u.SetHost(*v)
+	}
+	return _u
+}
+
+// ClearHost clears the value of the "host" field.
+func (_u *WarmInstanceUpdate) ClearHost() *WarmInstanceUpdate {
+	_u.mutation.ClearHost()
+	return _u
+}
+
+// SetVolumeID sets the "volume_id" field.
+func (_u *WarmInstanceUpdate) SetVolumeID(v string) *WarmInstanceUpdate {
+	_u.mutation.SetVolumeID(v)
+	return _u
+}
+
+// SetNillableVolumeID sets the "volume_id" field if the given value is not nil.
+func (_u *WarmInstanceUpdate) SetNillableVolumeID(v *string) *WarmInstanceUpdate {
+	if v != nil {
+		_u.SetVolumeID(*v)
+	}
+	return _u
+}
+
+// ClearVolumeID clears the value of the "volume_id" field.
+func (_u *WarmInstanceUpdate) ClearVolumeID() *WarmInstanceUpdate {
+	_u.mutation.ClearVolumeID()
+	return _u
+}
+
+// Mutation returns the WarmInstanceMutation object of the builder.
+func (_u *WarmInstanceUpdate) Mutation() *WarmInstanceMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *WarmInstanceUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *WarmInstanceUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *WarmInstanceUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *WarmInstanceUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *WarmInstanceUpdate) check() error {
+	if v, ok := _u.mutation.Provider(); ok {
+		if err := warminstance.ProviderValidator(v); err != nil {
+			return &ValidationError{Name: "provider", err: fmt.Errorf(`ent: validator failed for field "WarmInstance.provider": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ProviderID(); ok {
+		if err := warminstance.ProviderIDValidator(v); err != nil {
+			return &ValidationError{Name: "provider_id", err: fmt.Errorf(`ent: validator failed for field "WarmInstance.provider_id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *WarmInstanceUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(warminstance.Table, warminstance.Columns, sqlgraph.NewFieldSpec(warminstance.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Provider(); ok {
+		_spec.SetField(warminstance.FieldProvider, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ProviderID(); ok {
+		_spec.SetField(warminstance.FieldProviderID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Class(); ok {
+		_spec.SetField(warminstance.FieldClass, field.TypeString, value)
+	}
+	if _u.mutation.ClassCleared() {
+		_spec.ClearField(warminstance.FieldClass, field.TypeString)
+	}
+	if value, ok := _u.mutation.Host(); ok {
+		_spec.SetField(warminstance.FieldHost, field.TypeString, value)
+	}
+	if _u.mutation.HostCleared() {
+		_spec.ClearField(warminstance.FieldHost, field.TypeString)
+	}
+	if value, ok := _u.mutation.VolumeID(); ok {
+		_spec.SetField(warminstance.FieldVolumeID, field.TypeString, value)
+	}
+	if _u.mutation.VolumeIDCleared() {
+		_spec.ClearField(warminstance.FieldVolumeID, field.TypeString)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{warminstance.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// WarmInstanceUpdateOne is the builder for updating a single WarmInstance entity.
+type WarmInstanceUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *WarmInstanceMutation
+}
+
+// SetProvider sets the "provider" field.
+func (_u *WarmInstanceUpdateOne) SetProvider(v string) *WarmInstanceUpdateOne {
+	_u.mutation.SetProvider(v)
+	return _u
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (_u *WarmInstanceUpdateOne) SetNillableProvider(v *string) *WarmInstanceUpdateOne {
+	if v != nil {
+		_u.SetProvider(*v)
+	}
+	return _u
+}
+
+// SetProviderID sets the "provider_id" field.
+func (_u *WarmInstanceUpdateOne) SetProviderID(v string) *WarmInstanceUpdateOne {
+	_u.mutation.SetProviderID(v)
+	return _u
+}
+
+// SetNillableProviderID sets the "provider_id" field if the given value is not nil.
+func (_u *WarmInstanceUpdateOne) SetNillableProviderID(v *string) *WarmInstanceUpdateOne {
+	if v != nil {
+		_u.SetProviderID(*v)
+	}
+	return _u
+}
+
+// SetClass sets the "class" field.
+func (_u *WarmInstanceUpdateOne) SetClass(v string) *WarmInstanceUpdateOne {
+	_u.mutation.SetClass(v)
+	return _u
+}
+
+// SetNillableClass sets the "class" field if the given value is not nil.
+func (_u *WarmInstanceUpdateOne) SetNillableClass(v *string) *WarmInstanceUpdateOne {
+	if v != nil {
+		_u.SetClass(*v)
+	}
+	return _u
+}
+
+// ClearClass clears the value of the "class" field.
+func (_u *WarmInstanceUpdateOne) ClearClass() *WarmInstanceUpdateOne {
+	_u.mutation.ClearClass()
+	return _u
+}
+
+// SetHost sets the "host" field.
+func (_u *WarmInstanceUpdateOne) SetHost(v string) *WarmInstanceUpdateOne {
+	_u.mutation.SetHost(v)
+	return _u
+}
+
+// SetNillableHost sets the "host" field if the given value is not nil.
+func (_u *WarmInstanceUpdateOne) SetNillableHost(v *string) *WarmInstanceUpdateOne {
+	if v != nil {
+		_u.SetHost(*v)
+	}
+	return _u
+}
+
+// ClearHost clears the value of the "host" field.
+func (_u *WarmInstanceUpdateOne) ClearHost() *WarmInstanceUpdateOne {
+	_u.mutation.ClearHost()
+	return _u
+}
+
+// SetVolumeID sets the "volume_id" field.
+func (_u *WarmInstanceUpdateOne) SetVolumeID(v string) *WarmInstanceUpdateOne {
+	_u.mutation.SetVolumeID(v)
+	return _u
+}
+
+// SetNillableVolumeID sets the "volume_id" field if the given value is not nil.
+func (_u *WarmInstanceUpdateOne) SetNillableVolumeID(v *string) *WarmInstanceUpdateOne {
+	if v != nil {
+		_u.SetVolumeID(*v)
+	}
+	return _u
+}
+
+// ClearVolumeID clears the value of the "volume_id" field.
+func (_u *WarmInstanceUpdateOne) ClearVolumeID() *WarmInstanceUpdateOne {
+	_u.mutation.ClearVolumeID()
+	return _u
+}
+
+// Mutation returns the WarmInstanceMutation object of the builder.
+func (_u *WarmInstanceUpdateOne) Mutation() *WarmInstanceMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the WarmInstanceUpdate builder.
+func (_u *WarmInstanceUpdateOne) Where(ps ...predicate.WarmInstance) *WarmInstanceUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *WarmInstanceUpdateOne) Select(field string, fields ...string) *WarmInstanceUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated WarmInstance entity.
+func (_u *WarmInstanceUpdateOne) Save(ctx context.Context) (*WarmInstance, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *WarmInstanceUpdateOne) SaveX(ctx context.Context) *WarmInstance {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *WarmInstanceUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *WarmInstanceUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *WarmInstanceUpdateOne) check() error {
+	if v, ok := _u.mutation.Provider(); ok {
+		if err := warminstance.ProviderValidator(v); err != nil {
+			return &ValidationError{Name: "provider", err: fmt.Errorf(`ent: validator failed for field "WarmInstance.provider": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ProviderID(); ok {
+		if err := warminstance.ProviderIDValidator(v); err != nil {
+			return &ValidationError{Name: "provider_id", err: fmt.Errorf(`ent: validator failed for field "WarmInstance.provider_id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *WarmInstanceUpdateOne) sqlSave(ctx context.Context) (_node *WarmInstance, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(warminstance.Table, warminstance.Columns, sqlgraph.NewFieldSpec(warminstance.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "WarmInstance.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, warminstance.FieldID)
+		for _, f := range fields {
+			if !warminstance.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != warminstance.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Provider(); ok {
+		_spec.SetField(warminstance.FieldProvider, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ProviderID(); ok {
+		_spec.SetField(warminstance.FieldProviderID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Class(); ok {
+		_spec.SetField(warminstance.FieldClass, field.TypeString, value)
+	}
+	if _u.mutation.ClassCleared() {
+		_spec.ClearField(warminstance.FieldClass, field.TypeString)
+	}
+	if value, ok := _u.mutation.Host(); ok {
+		_spec.SetField(warminstance.FieldHost, field.TypeString, value)
+	}
+	if _u.mutation.HostCleared() {
+		_spec.ClearField(warminstance.FieldHost, field.TypeString)
+	}
+	if value, ok := _u.mutation.VolumeID(); ok {
+		_spec.SetField(warminstance.FieldVolumeID, field.TypeString, value)
+	}
+	if _u.mutation.VolumeIDCleared() {
+		_spec.ClearField(warminstance.FieldVolumeID, field.TypeString)
+	}
+	_node = &WarmInstance{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{warminstance.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
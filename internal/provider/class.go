@@ -0,0 +1,30 @@
+package provider
+
+// Class is a named instance size. Each provider applies the part it
+// understands: container runtimes limit CPU and memory, Hetzner picks the
+// server type. The zero Class means the provider's defaults.
+type Class struct {
+	Name       string  `json:"name"`
+	CPUs       float64 `json:"cpus"`
+	MemoryMB   int64   `json:"memory_mb"`
+	ServerType string  `json:"server_type"` // Hetzner server type
+}
+
+// DefaultClasses is the built-in class catalog, smallest first. CPU and
+// memory match the Hetzner server types so a class means the same on every
+// provider.
+var DefaultClasses = []Class{
+	{Name: "small", CPUs: 2, MemoryMB: 4096, ServerType: "cx22"},
+	{Name: "medium", CPUs: 4, MemoryMB: 8192, ServerType: "cx32"},
+	{Name: "large", CPUs: 8, MemoryMB: 16384, ServerType: "cx42"},
+}
+
+// FindClass returns the class called name from classes.
+func FindClass(classes []Class, name string) (Class, bool) {
+	for _, c := range classes {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Class{}, false
+}
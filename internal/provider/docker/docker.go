@@ -3,11 +3,13 @@ package docker
 import (
 	"context"
 	"fmt"
+	"io"
 	"strconv"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
@@ -40,19 +42,45 @@ func New() (*Provider, error) {
 	return p, nil
 }
 
-func containerName(userID int) string {
-	return fmt.Sprintf("claude-%d", userID)
+// containerName and volumeName take a provider.InstanceKey, so the default
+// instance keeps the original claude-{userID} names.
+func containerName(key string) string {
+	return "claude-" + key
 }
 
-func volumeName(userID int) string {
-	return fmt.Sprintf("claude-data-%d", userID)
+func volumeName(key string) string {
+	return "claude-data-" + key
+}
+
+func labels(userID int, name string) map[string]string {
+	if name == "" {
+		name = provider.DefaultInstanceName
+	}
+	return map[string]string{
+		labelPrefix + "managed":       "true",
+		labelPrefix + "user_id":       strconv.Itoa(userID),
+		labelPrefix + "instance_name": name,
+	}
+}
+
+// instanceFromLabels returns the owner and instance name recorded on a
+// container or volume. Resources created before named instances have no name
+// label and belong to the default instance.
+func instanceFromLabels(l map[string]string) (int, string) {
+	userID, _ := strconv.Atoi(l[labelPrefix+"user_id"])
+	name := l[labelPrefix+"instance_name"]
+	if name == "" {
+		name = provider.DefaultInstanceName
+	}
+	return userID, name
 }
 
 // Create provisions a new Claude instance for the given user.
-// Docker ignores opts (no Netbird in local dev).
+// Docker ignores the Netbird setup key (no Netbird in local dev).
 func (p *Provider) Create(ctx context.Context, userID int, opts provider.CreateOptions) (*provider.Instance, error) {
-	name := containerName(userID)
-	volName := volumeName(userID)
+	key := provider.InstanceKey(userID, opts.Name)
+	name := containerName(key)
+	volName := volumeName(key)
 
 	// Check if container already exists
 	existing, err := p.findContainer(ctx, name)
@@ -69,7 +97,7 @@ func (p *Provider) Create(ctx context.Context, userID int, opts provider.CreateO
 	}
 
 	// Ensure volume exists
-	if err := p.ensureVolume(ctx, volName, userID); err != nil {
+	if err := p.ensureVolume(ctx, volName, userID, opts.Name); err != nil {
 		return nil, fmt.Errorf("ensure volume: %w", err)
 	}
 
@@ -86,16 +114,23 @@ func (p *Provider) Create(ctx context.Context, userID int, opts provider.CreateO
 		// Recognized by the @anthropic-ai/claude-code SDK for OAuth authentication.
 		envVars = append(envVars, "CLAUDE_CODE_OAUTH_TOKEN="+opts.ClaudeOAuthToken)
 	}
+	envVars = append(envVars, opts.Environment.ContainerEnv()...)
+
+	// Templates may name their own image, which must build on claude-instance
+	ref := imageTag
+	if opts.Environment.Image != "" {
+		ref = opts.Environment.Image
+		if err := p.ensureImage(ctx, ref); err != nil {
+			return nil, fmt.Errorf("ensure image: %w", err)
+		}
+	}
 
 	// Create container
 	resp, err := p.cli.ContainerCreate(ctx,
 		&container.Config{
-			Image: imageTag,
-			Env:   envVars,
-			Labels: map[string]string{
-				labelPrefix + "managed": "true",
-				labelPrefix + "user_id": strconv.Itoa(userID),
-			},
+			Image:  ref,
+			Env:    envVars,
+			Labels: labels(userID, opts.Name),
 		},
 		&container.HostConfig{
 			Binds:       []string{volName + ":/claude-data"},
@@ -103,6 +138,7 @@ func (p *Provider) Create(ctx context.Context, userID int, opts provider.CreateO
 			RestartPolicy: container.RestartPolicy{
 				Name: container.RestartPolicyUnlessStopped,
 			},
+			Resources: resources(opts.Class),
 		},
 		&network.NetworkingConfig{},
 		nil,
@@ -163,11 +199,7 @@ func (p *Provider) Status(ctx context.Context, instanceID string) (*provider.Ins
 		return nil, fmt.Errorf("inspect: %w", err)
 	}
 
-	userID := 0
-	if v, ok := info.Config.Labels[labelPrefix+"user_id"]; ok {
-		userID, _ = strconv.Atoi(v)
-	}
-
+	userID, name := instanceFromLabels(info.Config.Labels)
 	status := mapDockerState(info.State.Status)
 
 	return &provider.Instance{
@@ -177,7 +209,7 @@ func (p *Provider) Status(ctx context.Context, instanceID string) (*provider.Ins
 		ProviderID: info.ID,
 		Host:       info.Name[1:],
 		Status:     status,
-		VolumeID:   volumeName(userID),
+		VolumeID:   volumeName(provider.InstanceKey(userID, name)),
 	}, nil
 }
 
@@ -222,6 +254,34 @@ func (p *Provider) Wake(ctx context.Context, instanceID string) error {
 	return nil
 }
 
+// Resize updates the container's CPU and memory limits in place. Docker
+// applies the new limits without a restart.
+func (p *Provider) Resize(ctx context.Context, instanceID string, class provider.Class) error {
+	_, err := p.cli.ContainerUpdate(ctx, instanceID, container.UpdateConfig{Resources: resources(class)})
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return provider.ErrNotFound
+		}
+		return fmt.Errorf("update container: %w", err)
+	}
+	return nil
+}
+
+// resources maps a class to container limits. Swap is capped at the memory
+// limit so a class's memory is a hard ceiling; a zero class leaves the
+// container unlimited.
+func resources(class provider.Class) container.Resources {
+	var r container.Resources
+	if class.CPUs > 0 {
+		r.NanoCPUs = int64(class.CPUs * 1e9)
+	}
+	if class.MemoryMB > 0 {
+		r.Memory = class.MemoryMB << 20
+		r.MemorySwap = r.Memory
+	}
+	return r
+}
+
 // Activity checks if the container has active processes beyond the base set.
 // Docker: active if process count > 4 (supervisor + ttyd + node agent + sleep).
 // When a user connects, ttyd spawns zellij which adds processes.
@@ -255,6 +315,151 @@ func (p *Provider) Activity(ctx context.Context, instanceID string) (*provider.A
 	}, nil
 }
 
+// ListManaged returns all containers and volumes labeled cloudcode.managed.
+func (p *Provider) ListManaged(ctx context.Context) ([]provider.ManagedResource, error) {
+	managed := filters.NewArgs(filters.Arg("label", labelPrefix+"managed=true"))
+
+	containers, err := p.cli.ContainerList(ctx, container.ListOptions{All: true, Filters: managed})
+	if err != nil {
+		return nil, fmt.Errorf("list containers: %w", err)
+	}
+
+	var out []provider.ManagedResource
+	for _, c := range containers {
+		userID, name := instanceFromLabels(c.Labels)
+		out = append(out, provider.ManagedResource{
+			Kind:       provider.ResourceInstance,
+			ID:         c.ID,
+			ProviderID: c.ID,
+			UserID:     userID,
+			Name:       name,
+			Status:     mapDockerState(c.State),
+		})
+	}
+
+	vols, err := p.cli.VolumeList(ctx, volume.ListOptions{Filters: managed})
+	if err != nil {
+		return nil, fmt.Errorf("list volumes: %w", err)
+	}
+	for _, v := range vols.Volumes {
+		userID, name := instanceFromLabels(v.Labels)
+		out = append(out, provider.ManagedResource{
+			Kind:   provider.ResourceVolume,
+			ID:     v.Name,
+			UserID: userID,
+			Name:   name,
+		})
+	}
+
+	return out, nil
+}
+
+// RemoveManaged removes a managed container (stopping it first) or volume.
+func (p *Provider) RemoveManaged(ctx context.Context, res provider.ManagedResource) error {
+	switch res.Kind {
+	case provider.ResourceInstance:
+		return p.Destroy(ctx, res.ID)
+	case provider.ResourceVolume:
+		if err := p.cli.VolumeRemove(ctx, res.ID, false); err != nil {
+			if client.IsErrNotFound(err) {
+				return provider.ErrNotFound
+			}
+			return fmt.Errorf("remove volume: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported resource kind %q", res.Kind)
+	}
+}
+
+// ExportVolume streams the volume as a tar rooted at claude-data/ via a
+// short-lived helper container that mounts it.
+func (p *Provider) ExportVolume(ctx context.Context, volumeID string, w io.Writer) error {
+	helper, err := p.createVolumeHelper(ctx, volumeID, "true")
+	if err != nil {
+		return err
+	}
+	defer p.removeVolumeHelper(helper)
+
+	rc, _, err := p.cli.CopyFromContainer(ctx, helper, "/claude-data")
+	if err != nil {
+		return fmt.Errorf("copy from helper: %w", err)
+	}
+	defer rc.Close()
+
+	if _, err := io.Copy(w, rc); err != nil {
+		return fmt.Errorf("stream volume: %w", err)
+	}
+	return nil
+}
+
+// ImportVolume empties the volume and extracts a tar produced by ExportVolume
+// into it.
+func (p *Provider) ImportVolume(ctx context.Context, volumeID string, r io.Reader) error {
+	helper, err := p.createVolumeHelper(ctx, volumeID, "find", "/claude-data", "-mindepth", "1", "-delete")
+	if err != nil {
+		return err
+	}
+	defer p.removeVolumeHelper(helper)
+
+	// Run the wipe to completion before extracting
+	waitCh, errCh := p.cli.ContainerWait(ctx, helper, container.WaitConditionNextExit)
+	if err := p.cli.ContainerStart(ctx, helper, container.StartOptions{}); err != nil {
+		return fmt.Errorf("start helper: %w", err)
+	}
+	select {
+	case res := <-waitCh:
+		if res.StatusCode != 0 {
+			return fmt.Errorf("wipe volume: exit code %d", res.StatusCode)
+		}
+	case err := <-errCh:
+		return fmt.Errorf("wait helper: %w", err)
+	}
+
+	if err := p.cli.CopyToContainer(ctx, helper, "/", r, container.CopyToContainerOptions{}); err != nil {
+		return fmt.Errorf("copy to helper: %w", err)
+	}
+	return nil
+}
+
+// createVolumeHelper creates (without starting) a container that mounts
+// volumeID at /claude-data and runs cmd in place of the instance entrypoint.
+// The volume must already exist.
+func (p *Provider) createVolumeHelper(ctx context.Context, volumeID string, cmd ...string) (string, error) {
+	if _, err := p.cli.VolumeInspect(ctx, volumeID); err != nil {
+		if client.IsErrNotFound(err) {
+			return "", provider.ErrNotFound
+		}
+		return "", fmt.Errorf("inspect volume: %w", err)
+	}
+
+	resp, err := p.cli.ContainerCreate(ctx,
+		&container.Config{
+			Image:      imageTag,
+			Entrypoint: cmd,
+			Labels: map[string]string{
+				labelPrefix + "backup": "true",
+			},
+		},
+		&container.HostConfig{
+			Binds:       []string{volumeID + ":/claude-data"},
+			NetworkMode: "none",
+		},
+		nil, nil, "",
+	)
+	if err != nil {
+		return "", fmt.Errorf("create helper: %w", err)
+	}
+	return resp.ID, nil
+}
+
+func (p *Provider) removeVolumeHelper(id string) {
+	// Use a fresh context so cleanup still runs when the caller's was cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	_ = p.cli.ContainerRemove(ctx, id, container.RemoveOptions{Force: true})
+}
+
 func (p *Provider) ensureNetwork(ctx context.Context) error {
 	nets, err := p.cli.NetworkList(ctx, network.ListOptions{
 		Filters: filters.NewArgs(filters.Arg("name", networkName)),
@@ -277,18 +482,32 @@ func (p *Provider) ensureNetwork(ctx context.Context) error {
 	return err
 }
 
-func (p *Provider) ensureVolume(ctx context.Context, name string, userID int) error {
-	_, err := p.cli.VolumeInspect(ctx, name)
+// ensureImage pulls ref unless the daemon already has it.
+func (p *Provider) ensureImage(ctx context.Context, ref string) error {
+	_, _, err := p.cli.ImageInspectWithRaw(ctx, ref)
+	if err == nil || !client.IsErrNotFound(err) {
+		return err
+	}
+
+	rc, err := p.cli.ImagePull(ctx, ref, image.PullOptions{})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	// The pull only completes once its progress stream has been read
+	_, err = io.Copy(io.Discard, rc)
+	return err
+}
+
+func (p *Provider) ensureVolume(ctx context.Context, volName string, userID int, name string) error {
+	_, err := p.cli.VolumeInspect(ctx, volName)
 	if err == nil {
 		return nil // already exists
 	}
 
 	_, err = p.cli.VolumeCreate(ctx, volume.CreateOptions{
-		Name: name,
-		Labels: map[string]string{
-			labelPrefix + "managed": "true",
-			labelPrefix + "user_id": strconv.Itoa(userID),
-		},
+		Name:   volName,
+		Labels: labels(userID, name),
 	})
 	return err
 }
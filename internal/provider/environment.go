@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"encoding/base64"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Variables the instance entrypoint reads on first boot to apply an
+// Environment (see docker/entrypoint.sh).
+const (
+	EnvRepos = "CLOUDCODE_REPOS" // space-separated git URLs
+	EnvSetup = "CLOUDCODE_SETUP" // base64-encoded bash script
+)
+
+// Environment customizes what an instance boots with, beyond its class. The
+// zero value boots the stock claude-instance setup. Repos are cloned into
+// /claude-data and the setup script run there once per data volume.
+type Environment struct {
+	Image       string            // container image; Hetzner boots its base OS image
+	Env         map[string]string // extra environment variables
+	Repos       []string          // git URLs cloned on first boot
+	SetupScript string            // bash run on first boot, after cloning
+}
+
+// IsZero reports whether e leaves the stock setup unchanged.
+func (e Environment) IsZero() bool {
+	return e.Image == "" && len(e.Env) == 0 && len(e.Repos) == 0 && e.SetupScript == ""
+}
+
+// Vars returns e.Env plus the first-boot variables, for providers that run
+// the instance image.
+func (e Environment) Vars() map[string]string {
+	env := make(map[string]string, len(e.Env)+2)
+	maps.Copy(env, e.Env)
+	if len(e.Repos) > 0 {
+		env[EnvRepos] = strings.Join(e.Repos, " ")
+	}
+	if e.SetupScript != "" {
+		env[EnvSetup] = base64.StdEncoding.EncodeToString([]byte(e.SetupScript))
+	}
+	return env
+}
+
+// ContainerEnv returns Vars as KEY=value pairs sorted by key.
+func (e Environment) ContainerEnv() []string {
+	env := e.Vars()
+	out := make([]string, 0, len(env))
+	for _, k := range slices.Sorted(maps.Keys(env)) {
+		out = append(out, k+"="+env[k])
+	}
+	return out
+}
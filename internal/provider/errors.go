@@ -14,4 +14,7 @@ var (
 
 	// ErrProviderNotConfigured indicates the selected provider is missing required configuration.
 	ErrProviderNotConfigured = errors.New("provider not configured")
+
+	// ErrNotSupported indicates the selected provider does not implement the operation.
+	ErrNotSupported = errors.New("operation not supported by provider")
 )
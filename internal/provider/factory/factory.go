@@ -2,11 +2,15 @@ package factory
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/logan/cloudcode/internal/config"
 	"github.com/logan/cloudcode/internal/provider"
 	"github.com/logan/cloudcode/internal/provider/docker"
 	"github.com/logan/cloudcode/internal/provider/hetzner"
+	"github.com/logan/cloudcode/internal/provider/kubernetes"
+	"github.com/logan/cloudcode/internal/provider/podman"
 )
 
 // NewProvisioner creates a Provisioner based on the configured provider.
@@ -15,7 +19,33 @@ func NewProvisioner(cfg *config.Config) (provider.Provisioner, error) {
 	case "docker":
 		return docker.New()
 	case "hetzner":
-		return hetzner.New(cfg.HCloudToken, "", "")
+		retention, _ := strconv.Atoi(cfg.HetznerSnapshotRetention)
+		p, err := hetzner.New(cfg.HCloudToken, "", "", retention)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.HetznerSSHKeyName != "" {
+			key, err := os.ReadFile(cfg.HetznerSSHKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("read HETZNER_SSH_KEY_FILE: %w", err)
+			}
+			if err := p.EnablePool(cfg.HetznerSSHKeyName, key); err != nil {
+				return nil, err
+			}
+		}
+		return p, nil
+	case "podman":
+		return podman.New(podman.Options{
+			Socket: cfg.PodmanSocket,
+			UserNS: cfg.PodmanUserNS,
+		})
+	case "kubernetes":
+		return kubernetes.New(cfg.KubeConfig, kubernetes.Options{
+			Namespace:    cfg.KubeNamespace,
+			Image:        cfg.KubeImage,
+			StorageClass: cfg.KubeStorageClass,
+			VolumeSize:   cfg.KubeVolumeSize,
+		})
 	default:
 		return nil, fmt.Errorf("unknown provider %q", cfg.Provider)
 	}
@@ -1,28 +1,55 @@
 package hetzner
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"golang.org/x/crypto/ssh"
 
 	"github.com/logan/cloudcode/internal/provider"
 )
 
+const (
+	// defaultSnapshotRetention is how many pause snapshots are kept per instance
+	// when no retention is configured.
+	defaultSnapshotRetention = 3
+
+	// shutdownTimeout bounds the graceful ACPI shutdown before a snapshot;
+	// after it the server is powered off hard.
+	shutdownTimeout = 2 * time.Minute
+)
+
 // Provider implements provider.Provisioner using Hetzner Cloud via terraform-exec.
-// Each user gets an isolated Terraform workspace directory for state isolation.
+// Each instance gets an isolated Terraform workspace directory for state isolation.
+// Pause and Wake bypass Terraform and use the Hetzner Cloud API directly to
+// snapshot the server and later recreate it from that snapshot.
 type Provider struct {
-	tfBinary      string
-	workspacesDir string
-	hcloudToken   string
+	tfBinary          string
+	workspacesDir     string
+	hcloudToken       string
+	api               *hcloud.Client
+	snapshotRetention int
+	pollInterval      time.Duration
+
+	// Warm pool (see EnablePool); remote is nil until it is enabled
+	sshKeyName string
+	sshSigner  ssh.Signer
+	remote     func(ctx context.Context, host, cmd, stdin string) error
 }
 
-// New creates a new Hetzner provider.
-func New(hcloudToken, tfBinary, workspacesDir string) (*Provider, error) {
+// New creates a new Hetzner provider. snapshotRetention is the number of pause
+// snapshots kept per instance (<= 0 uses the default of 3).
+func New(hcloudToken, tfBinary, workspacesDir string, snapshotRetention int) (*Provider, error) {
 	if hcloudToken == "" {
 		return nil, fmt.Errorf("HCLOUD_TOKEN required: %w", provider.ErrProviderNotConfigured)
 	}
@@ -37,38 +64,170 @@ func New(hcloudToken, tfBinary, workspacesDir string) (*Provider, error) {
 		return nil, fmt.Errorf("create workspaces dir: %w", err)
 	}
 
+	if snapshotRetention <= 0 {
+		snapshotRetention = defaultSnapshotRetention
+	}
+
 	return &Provider{
-		tfBinary:      tfBinary,
-		workspacesDir: workspacesDir,
-		hcloudToken:   hcloudToken,
+		tfBinary:          tfBinary,
+		workspacesDir:     workspacesDir,
+		hcloudToken:       hcloudToken,
+		api:               hcloud.NewClient(hcloud.WithToken(hcloudToken), hcloud.WithApplication("cloudcode", "")),
+		snapshotRetention: snapshotRetention,
+		pollInterval:      5 * time.Second,
 	}, nil
 }
 
-func (p *Provider) userDir(userID int) string {
-	return filepath.Join(p.workspacesDir, fmt.Sprintf("user-%d", userID))
+// instanceRef identifies one of a user's instances, or an unclaimed warm
+// pool instance when pool is set. Its key (see provider.InstanceKey) names
+// the workspace, server and volume, so the default instance keeps the
+// original per-user names.
+type instanceRef struct {
+	userID int
+	name   string
+	pool   string
+}
+
+func (r instanceRef) key() string {
+	if r.pool != "" {
+		return "pool-" + r.pool
+	}
+	return provider.InstanceKey(r.userID, r.name)
+}
+
+func (r instanceRef) isDefault() bool {
+	return r.pool == "" && (r.name == "" || r.name == provider.DefaultInstanceName)
+}
+
+func (p *Provider) userDir(ref instanceRef) string {
+	if ref.pool != "" {
+		return filepath.Join(p.workspacesDir, ref.key())
+	}
+	return filepath.Join(p.workspacesDir, "user-"+ref.key())
+}
+
+func instanceName(ref instanceRef) string {
+	return "hetzner-" + ref.key()
+}
+
+// parseInstanceID parses an instance ID of the form "hetzner-{key}".
+func parseInstanceID(id string) (instanceRef, error) {
+	key, ok := strings.CutPrefix(id, "hetzner-")
+	if !ok {
+		return instanceRef{}, fmt.Errorf("parse instance ID %q: missing hetzner- prefix", id)
+	}
+	if pool, ok := strings.CutPrefix(key, "pool-"); ok && pool != "" {
+		return instanceRef{pool: pool}, nil
+	}
+	userID, name, err := provider.ParseInstanceKey(key)
+	if err != nil {
+		return instanceRef{}, fmt.Errorf("parse instance ID: %w", err)
+	}
+	return instanceRef{userID: userID, name: name}, nil
+}
+
+// refFromLabels returns the instance a server or snapshot belongs to.
+// Only named instances carry an instance_name label.
+func refFromLabels(l map[string]string) instanceRef {
+	userID, _ := strconv.Atoi(l["user_id"])
+	name := l["instance_name"]
+	if name == "" {
+		name = provider.DefaultInstanceName
+	}
+	return instanceRef{userID: userID, name: name}
+}
+
+// labels returns the labels the Terraform module and Wake put on an
+// instance's server and snapshots.
+func (r instanceRef) labels() map[string]string {
+	if r.pool != "" {
+		return map[string]string{"managed_by": "cloudcode", "pool": r.pool}
+	}
+	l := map[string]string{
+		"managed_by": "cloudcode",
+		"user_id":    strconv.Itoa(r.userID),
+	}
+	if !r.isDefault() {
+		l["instance_name"] = r.name
+	}
+	return l
+}
+
+// selector matches the labels put on the instance's server and snapshots.
+// The default instance is the one without an instance_name label.
+func (r instanceRef) selector() string {
+	if r.pool != "" {
+		return "managed_by=cloudcode,pool=" + r.pool
+	}
+	if r.isDefault() {
+		return fmt.Sprintf("managed_by=cloudcode,user_id=%d,!instance_name", r.userID)
+	}
+	return fmt.Sprintf("managed_by=cloudcode,user_id=%d,instance_name=%s", r.userID, r.name)
 }
 
 // Create provisions a new Hetzner server for the given user via Terraform.
 // If opts.NetbirdSetupKey is set, it is passed to cloud-init for Netbird enrollment.
+// The environment's variables, repos and setup script are applied by cloud-init;
+// its image is ignored since servers boot the Hetzner OS image directly.
 func (p *Provider) Create(ctx context.Context, userID int, opts provider.CreateOptions) (*provider.Instance, error) {
-	dir := p.userDir(userID)
+	ref := instanceRef{userID: userID, name: opts.Name}
+	dir := p.userDir(ref)
 
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return nil, fmt.Errorf("create user dir: %w", err)
 	}
 
-	// Write tfvars
+	vars := p.userVars(ref, opts.Class.ServerType)
+	if opts.NetbirdSetupKey != "" {
+		vars["netbird_setup_key"] = opts.NetbirdSetupKey
+	}
+	if env := opts.Environment.Vars(); len(env) > 0 {
+		vars["environment"] = env
+	}
+	if err := writeWorkspace(dir, vars); err != nil {
+		return nil, err
+	}
+	serverIP, volumeID, err := p.apply(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// The server ID changes every time Wake recreates the server from a
+	// snapshot, so the stable instance ID doubles as the provider ID.
+	return &provider.Instance{
+		ID:         instanceName(ref),
+		UserID:     userID,
+		Provider:   "hetzner",
+		ProviderID: instanceName(ref),
+		Host:       serverIP,
+		Status:     provider.StatusRunning,
+		VolumeID:   volumeID,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}, nil
+}
+
+// userVars returns the Terraform variables for a user's instance.
+func (p *Provider) userVars(ref instanceRef, serverType string) map[string]any {
 	vars := map[string]any{
-		"user_id":      userID,
+		"user_id":      ref.userID,
 		"hcloud_token": p.hcloudToken,
 	}
-	if opts.NetbirdSetupKey != "" {
-		vars["netbird_setup_key"] = opts.NetbirdSetupKey
+	if !ref.isDefault() {
+		vars["instance_name"] = ref.name
+	}
+	if serverType != "" {
+		vars["server_type"] = serverType
 	}
+	return vars
+}
+
+// writeWorkspace writes the tfvars and the root module into dir.
+func writeWorkspace(dir string, vars map[string]any) error {
 	varsJSON, _ := json.MarshalIndent(vars, "", "  ")
 	varsFile := filepath.Join(dir, "terraform.tfvars.json")
 	if err := os.WriteFile(varsFile, varsJSON, 0o600); err != nil {
-		return nil, fmt.Errorf("write tfvars: %w", err)
+		return fmt.Errorf("write tfvars: %w", err)
 	}
 
 	// Copy module reference
@@ -76,14 +235,39 @@ func (p *Provider) Create(ctx context.Context, userID int, opts provider.CreateO
 module "instance" {
   source            = "../../modules/user_instance"
   user_id           = var.user_id
+  instance_name     = var.instance_name
+  pool_id           = var.pool_id
+  server_type       = var.server_type
+  ssh_keys          = var.ssh_keys
   hcloud_token      = var.hcloud_token
   netbird_setup_key = var.netbird_setup_key
+  environment       = var.environment
 }
 
 variable "user_id" {
   type = number
 }
 
+variable "instance_name" {
+  type    = string
+  default = ""
+}
+
+variable "pool_id" {
+  type    = string
+  default = ""
+}
+
+variable "server_type" {
+  type    = string
+  default = "cx22"
+}
+
+variable "ssh_keys" {
+  type    = list(string)
+  default = []
+}
+
 variable "hcloud_token" {
   type      = string
   sensitive = true
@@ -95,6 +279,12 @@ variable "netbird_setup_key" {
   sensitive = true
 }
 
+variable "environment" {
+  type      = map(string)
+  default   = {}
+  sensitive = true
+}
+
 output "server_id" {
   value = module.instance.server_id
 }
@@ -108,69 +298,67 @@ output "volume_id" {
 }
 `)
 	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(mainTF), 0o644); err != nil {
-		return nil, fmt.Errorf("write main.tf: %w", err)
+		return fmt.Errorf("write main.tf: %w", err)
 	}
+	return nil
+}
 
-	// Init and apply
+// apply initializes and applies the workspace in dir and returns the
+// server's private IP and volume ID.
+func (p *Provider) apply(ctx context.Context, dir string) (serverIP, volumeID string, err error) {
 	tf, err := tfexec.NewTerraform(dir, p.tfBinary)
 	if err != nil {
-		return nil, fmt.Errorf("terraform init client: %w", err)
+		return "", "", fmt.Errorf("terraform init client: %w", err)
 	}
 
 	if err := tf.Init(ctx); err != nil {
-		return nil, fmt.Errorf("terraform init: %w", err)
+		return "", "", fmt.Errorf("terraform init: %w", err)
 	}
 
 	if err := tf.Apply(ctx); err != nil {
-		return nil, fmt.Errorf("terraform apply: %w", err)
+		return "", "", fmt.Errorf("terraform apply: %w", err)
 	}
 
 	// Read outputs
 	output, err := tf.Output(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("terraform output: %w", err)
+		return "", "", fmt.Errorf("terraform output: %w", err)
 	}
 
-	serverID := ""
-	if v, ok := output["server_id"]; ok {
-		json.Unmarshal(v.Value, &serverID)
-	}
-	serverIP := ""
 	if v, ok := output["server_ip"]; ok {
 		json.Unmarshal(v.Value, &serverIP)
 	}
-	volumeID := ""
 	if v, ok := output["volume_id"]; ok {
 		json.Unmarshal(v.Value, &volumeID)
 	}
-
-	return &provider.Instance{
-		ID:         fmt.Sprintf("hetzner-%d", userID),
-		UserID:     userID,
-		Provider:   "hetzner",
-		ProviderID: serverID,
-		Host:       serverIP,
-		Status:     provider.StatusRunning,
-		VolumeID:   volumeID,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
-	}, nil
+	return serverIP, volumeID, nil
 }
 
-// Destroy tears down the Hetzner server via terraform destroy.
-// The volume is preserved (Terraform module uses prevent_destroy on volume).
+// Destroy deletes the user's server and tears down the Terraform workspace.
+// A server recreated by Wake is not in Terraform state, so it is deleted
+// through the API first. Pause snapshots are kept until they are pruned or
+// garbage-collected by the reconciler.
 func (p *Provider) Destroy(ctx context.Context, instanceID string) error {
-	// instanceID format: "hetzner-{userID}" — extract userID
-	var userID int
-	if _, err := fmt.Sscanf(instanceID, "hetzner-%d", &userID); err != nil {
-		return fmt.Errorf("parse instance ID: %w", err)
+	ref, err := parseInstanceID(instanceID)
+	if err != nil {
+		return err
 	}
 
-	dir := p.userDir(userID)
+	dir := p.userDir(ref)
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		return provider.ErrNotFound
 	}
 
+	server, err := p.findServer(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if server != nil {
+		if err := p.deleteServer(ctx, server); err != nil {
+			return err
+		}
+	}
+
 	tf, err := tfexec.NewTerraform(dir, p.tfBinary)
 	if err != nil {
 		return fmt.Errorf("terraform client: %w", err)
@@ -183,47 +371,317 @@ func (p *Provider) Destroy(ctx context.Context, instanceID string) error {
 	return nil
 }
 
-// Status returns the current state of the instance by checking Terraform state.
+// Status returns the current state of the instance from the Hetzner Cloud API.
+// A workspace without a server is reported as stopped (paused).
 func (p *Provider) Status(ctx context.Context, instanceID string) (*provider.Instance, error) {
-	var userID int
-	if _, err := fmt.Sscanf(instanceID, "hetzner-%d", &userID); err != nil {
-		return nil, fmt.Errorf("parse instance ID: %w", err)
+	ref, err := parseInstanceID(instanceID)
+	if err != nil {
+		return nil, err
 	}
 
-	dir := p.userDir(userID)
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
+	if _, err := os.Stat(p.userDir(ref)); os.IsNotExist(err) {
 		return nil, provider.ErrNotFound
 	}
 
-	tf, err := tfexec.NewTerraform(dir, p.tfBinary)
+	inst := &provider.Instance{
+		ID:         instanceID,
+		UserID:     ref.userID,
+		Provider:   "hetzner",
+		ProviderID: instanceID,
+		Status:     provider.StatusStopped,
+	}
+
+	server, err := p.findServer(ctx, ref)
 	if err != nil {
-		return nil, fmt.Errorf("terraform client: %w", err)
+		return nil, err
+	}
+	if server != nil {
+		inst.Status = mapServerStatus(server.Status)
+		inst.Host = serverHost(server)
+		inst.CreatedAt = server.Created
+	}
+	return inst, nil
+}
+
+// Pause snapshots the server and deletes it (the data volume persists).
+// In production this takes a few minutes.
+func (p *Provider) Pause(ctx context.Context, instanceID string) error {
+	_, err := p.PauseSnapshot(ctx, instanceID)
+	return err
+}
+
+// PauseSnapshot shuts the server down, takes a snapshot labeled with the
+// instance's owner, name and server shape, deletes the server, and returns
+// the snapshot ID.
+func (p *Provider) PauseSnapshot(ctx context.Context, instanceID string) (string, error) {
+	ref, err := parseInstanceID(instanceID)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(p.userDir(ref)); os.IsNotExist(err) {
+		return "", provider.ErrNotFound
+	}
+
+	server, err := p.findServer(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	if server == nil {
+		return "", provider.ErrInvalidState
+	}
+
+	if err := p.shutdown(ctx, server); err != nil {
+		return "", err
+	}
+
+	labels := ref.labels()
+	if server.ServerType != nil {
+		labels["server_type"] = server.ServerType.Name
+	}
+	if server.Datacenter != nil && server.Datacenter.Location != nil {
+		labels["location"] = server.Datacenter.Location.Name
 	}
+	if len(server.PrivateNet) > 0 && server.PrivateNet[0].Network != nil {
+		labels["network_id"] = strconv.FormatInt(server.PrivateNet[0].Network.ID, 10)
+	}
+	description := fmt.Sprintf("%s paused %s", server.Name, time.Now().UTC().Format(time.RFC3339))
 
-	state, err := tf.Show(ctx)
+	res, _, err := p.api.Server.CreateImage(ctx, server, &hcloud.ServerCreateImageOpts{
+		Type:        hcloud.ImageTypeSnapshot,
+		Description: &description,
+		Labels:      labels,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("terraform show: %w", err)
+		return "", fmt.Errorf("create snapshot: %w", err)
+	}
+	if err := p.api.Action.WaitFor(ctx, res.Action); err != nil {
+		return "", fmt.Errorf("wait for snapshot: %w", err)
+	}
+
+	if err := p.deleteServer(ctx, server); err != nil {
+		return "", err
 	}
 
-	status := provider.StatusDestroyed
-	if state.Values != nil {
-		status = provider.StatusRunning
+	return strconv.FormatInt(res.Image.ID, 10), nil
+}
+
+// Wake recreates the server from the latest snapshot.
+func (p *Provider) Wake(ctx context.Context, instanceID string) error {
+	_, _, err := p.WakeSnapshot(ctx, instanceID, "")
+	return err
+}
+
+// WakeSnapshot recreates the server from snapshotID (the latest when empty),
+// reattaches the data volume, private network and SSH keys, and prunes
+// snapshots beyond the retention policy. Workspaces paused before snapshots
+// existed have none and are re-applied from the base image with Terraform
+// instead.
+func (p *Provider) WakeSnapshot(ctx context.Context, instanceID, snapshotID string) (*provider.Instance, []string, error) {
+	ref, err := parseInstanceID(instanceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dir := p.userDir(ref)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil, provider.ErrNotFound
+	}
+
+	server, err := p.findServer(ctx, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	if server != nil {
+		return nil, nil, provider.ErrInvalidState
+	}
+
+	snapshots, err := p.listSnapshots(ctx, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(snapshots) == 0 {
+		if snapshotID != "" {
+			return nil, nil, fmt.Errorf("snapshot %s: %w", snapshotID, provider.ErrNotFound)
+		}
+		inst, err := p.wakeFromTerraform(ctx, ref)
+		return inst, nil, err
+	}
+
+	image := snapshots[len(snapshots)-1]
+	if snapshotID != "" {
+		image = nil
+		for _, s := range snapshots {
+			if strconv.FormatInt(s.ID, 10) == snapshotID {
+				image = s
+				break
+			}
+		}
+		if image == nil {
+			return nil, nil, fmt.Errorf("snapshot %s: %w", snapshotID, provider.ErrNotFound)
+		}
+	}
+
+	serverType := image.Labels["server_type"]
+	if serverType == "" {
+		serverType = "cx22"
+	}
+	location := image.Labels["location"]
+	if location == "" {
+		location = "nbg1"
+	}
+
+	opts := hcloud.ServerCreateOpts{
+		Name:       "claude-" + ref.key(),
+		ServerType: &hcloud.ServerType{Name: serverType},
+		Image:      &hcloud.Image{ID: image.ID},
+		Location:   &hcloud.Location{Name: location},
+		Labels:     ref.labels(),
+	}
+	if id, err := strconv.ParseInt(image.Labels["network_id"], 10, 64); err == nil {
+		opts.Networks = []*hcloud.Network{{ID: id}}
+	}
+	if opts.SSHKeys, err = p.workspaceSSHKeys(ctx, dir); err != nil {
+		return nil, nil, err
+	}
+	volume, _, err := p.api.Volume.GetByName(ctx, "claude-data-"+ref.key())
+	if err != nil {
+		return nil, nil, fmt.Errorf("get volume: %w", err)
+	}
+	if volume != nil {
+		automount := true
+		opts.Volumes = []*hcloud.Volume{volume}
+		opts.Automount = &automount
+	}
+
+	res, _, err := p.api.Server.Create(ctx, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create server from snapshot %d: %w", image.ID, err)
+	}
+	if err := p.api.Action.WaitFor(ctx, append([]*hcloud.Action{res.Action}, res.NextActions...)...); err != nil {
+		return nil, nil, fmt.Errorf("wait for server: %w", err)
+	}
+
+	kept, err := p.pruneSnapshots(ctx, snapshots)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inst := &provider.Instance{
+		ID:         instanceName(ref),
+		UserID:     ref.userID,
+		Provider:   "hetzner",
+		ProviderID: instanceName(ref),
+		Host:       cmp.Or(serverHost(res.Server), privateIP(ref.userID)),
+		Status:     provider.StatusRunning,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if volume != nil {
+		inst.VolumeID = strconv.FormatInt(volume.ID, 10)
+	}
+	return inst, kept, nil
+}
+
+// workspaceSSHKeys returns the SSH keys the workspace in dir gives its
+// server, so a server recreated outside Terraform gets the same ones.
+func (p *Provider) workspaceSSHKeys(ctx context.Context, dir string) ([]*hcloud.SSHKey, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "terraform.tfvars.json"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read tfvars: %w", err)
+	}
+	var vars struct {
+		SSHKeys []string `json:"ssh_keys"`
+	}
+	if err := json.Unmarshal(data, &vars); err != nil {
+		return nil, fmt.Errorf("parse tfvars: %w", err)
+	}
+
+	var keys []*hcloud.SSHKey
+	for _, name := range vars.SSHKeys {
+		key, _, err := p.api.SSHKey.GetByName(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("get ssh key %s: %w", name, err)
+		}
+		if key == nil {
+			return nil, fmt.Errorf("ssh key %s: %w", name, provider.ErrNotFound)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// wakeFromTerraform re-applies the workspace, recreating the server from the
+// base image.
+func (p *Provider) wakeFromTerraform(ctx context.Context, ref instanceRef) (*provider.Instance, error) {
+	tf, err := tfexec.NewTerraform(p.userDir(ref), p.tfBinary)
+	if err != nil {
+		return nil, fmt.Errorf("terraform client: %w", err)
+	}
+
+	if err := tf.Apply(ctx); err != nil {
+		return nil, fmt.Errorf("terraform apply: %w", err)
 	}
 
 	return &provider.Instance{
-		ID:       instanceID,
-		UserID:   userID,
-		Provider: "hetzner",
-		Status:   status,
+		ID:         instanceName(ref),
+		UserID:     ref.userID,
+		Provider:   "hetzner",
+		ProviderID: instanceName(ref),
+		Host:       privateIP(ref.userID),
+		Status:     provider.StatusRunning,
 	}, nil
 }
 
-// Pause snapshots the server and destroys it (volume persists).
-// In production this takes 30-120s. Synchronous for Phase 1.
-func (p *Provider) Pause(ctx context.Context, instanceID string) error {
-	// For Phase 1, Pause == Destroy (snapshot + destroy would need Hetzner API directly)
-	// The volume persists via Terraform lifecycle rules
-	return p.Destroy(ctx, instanceID)
+// Resize moves the instance's server to class.ServerType. Hetzner only
+// changes the type of a powered-off server, so the server is shut down and
+// powered back on around the change. The disk is not upgraded, which keeps
+// the change reversible. A paused instance has no server to resize.
+func (p *Provider) Resize(ctx context.Context, instanceID string, class provider.Class) error {
+	if class.ServerType == "" {
+		return fmt.Errorf("class %q has no Hetzner server type", class.Name)
+	}
+	ref, err := parseInstanceID(instanceID)
+	if err != nil {
+		return err
+	}
+
+	server, err := p.findServer(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if server == nil {
+		return provider.ErrInvalidState
+	}
+	if server.ServerType != nil && server.ServerType.Name == class.ServerType {
+		return nil
+	}
+
+	if err := p.shutdown(ctx, server); err != nil {
+		return err
+	}
+	action, _, err := p.api.Server.ChangeType(ctx, server, hcloud.ServerChangeTypeOpts{
+		ServerType:  &hcloud.ServerType{Name: class.ServerType},
+		UpgradeDisk: false,
+	})
+	if err != nil {
+		return fmt.Errorf("change server type: %w", err)
+	}
+	if err := p.api.Action.WaitFor(ctx, action); err != nil {
+		return fmt.Errorf("wait for type change: %w", err)
+	}
+
+	action, _, err = p.api.Server.Poweron(ctx, server)
+	if err != nil {
+		return fmt.Errorf("power on server: %w", err)
+	}
+	if err := p.api.Action.WaitFor(ctx, action); err != nil {
+		return fmt.Errorf("wait for power on: %w", err)
+	}
+	return nil
 }
 
 // Activity checks if the Hetzner server is running (basic check for now).
@@ -236,27 +694,258 @@ func (p *Provider) Activity(ctx context.Context, instanceID string) (*provider.A
 	return &provider.ActivityInfo{IsActive: isActive, IsHealthy: isActive}, nil
 }
 
-// Wake recreates the server from the latest snapshot.
-func (p *Provider) Wake(ctx context.Context, instanceID string) error {
-	var userID int
-	if _, err := fmt.Sscanf(instanceID, "hetzner-%d", &userID); err != nil {
-		return fmt.Errorf("parse instance ID: %w", err)
+// ListManaged returns one workspace per instance directory, every server and
+// pause snapshot labeled managed_by=cloudcode, and a stopped instance for
+// each workspace whose server is currently snapshotted away. Unclaimed warm
+// pool servers and workspaces are left to the pool.
+func (p *Provider) ListManaged(ctx context.Context) ([]provider.ManagedResource, error) {
+	entries, err := os.ReadDir(p.workspacesDir)
+	if err != nil {
+		return nil, fmt.Errorf("read workspaces dir: %w", err)
 	}
 
-	dir := p.userDir(userID)
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		return provider.ErrNotFound
+	managed := hcloud.ListOpts{LabelSelector: "managed_by=cloudcode"}
+	servers, err := p.api.Server.AllWithOpts(ctx, hcloud.ServerListOpts{ListOpts: managed})
+	if err != nil {
+		return nil, fmt.Errorf("list servers: %w", err)
+	}
+	images, err := p.api.Image.AllWithOpts(ctx, hcloud.ImageListOpts{
+		ListOpts: managed,
+		Type:     []hcloud.ImageType{hcloud.ImageTypeSnapshot},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots: %w", err)
 	}
 
-	tf, err := tfexec.NewTerraform(dir, p.tfBinary)
+	var out []provider.ManagedResource
+	hasServer := make(map[instanceRef]bool)
+	for _, s := range servers {
+		if s.Labels["pool"] != "" {
+			continue
+		}
+		ref := refFromLabels(s.Labels)
+		hasServer[ref] = true
+		out = append(out, provider.ManagedResource{
+			Kind:       provider.ResourceInstance,
+			ID:         instanceName(ref),
+			ProviderID: instanceName(ref),
+			UserID:     ref.userID,
+			Name:       ref.name,
+			Status:     mapServerStatus(s.Status),
+		})
+	}
+
+	hasSnapshot := make(map[instanceRef]bool)
+	for _, img := range images {
+		ref := refFromLabels(img.Labels)
+		hasSnapshot[ref] = true
+		out = append(out, provider.ManagedResource{
+			Kind:   provider.ResourceSnapshot,
+			ID:     strconv.FormatInt(img.ID, 10),
+			UserID: ref.userID,
+			Name:   ref.name,
+		})
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		key, ok := strings.CutPrefix(e.Name(), "user-")
+		if !ok {
+			continue
+		}
+		userID, name, err := provider.ParseInstanceKey(key)
+		if err != nil {
+			continue
+		}
+		ref := instanceRef{userID: userID, name: name}
+
+		out = append(out, provider.ManagedResource{
+			Kind:   provider.ResourceWorkspace,
+			ID:     e.Name(),
+			UserID: userID,
+			Name:   name,
+		})
+
+		// Paused: the server is snapshotted away but the stable instance
+		// ID still identifies it
+		if !hasServer[ref] && hasSnapshot[ref] {
+			out = append(out, provider.ManagedResource{
+				Kind:       provider.ResourceInstance,
+				ID:         instanceName(ref),
+				ProviderID: instanceName(ref),
+				UserID:     userID,
+				Name:       name,
+				Status:     provider.StatusStopped,
+			})
+		}
+	}
+	return out, nil
+}
+
+// RemoveManaged destroys a server, deletes a snapshot, or destroys and
+// deletes a whole workspace.
+func (p *Provider) RemoveManaged(ctx context.Context, res provider.ManagedResource) error {
+	switch res.Kind {
+	case provider.ResourceInstance:
+		return p.Destroy(ctx, res.ID)
+	case provider.ResourceSnapshot:
+		id, err := strconv.ParseInt(res.ID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse snapshot ID: %w", err)
+		}
+		if _, err := p.api.Image.Delete(ctx, &hcloud.Image{ID: id}); err != nil {
+			if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+				return provider.ErrNotFound
+			}
+			return fmt.Errorf("delete snapshot: %w", err)
+		}
+		return nil
+	case provider.ResourceWorkspace:
+		ref := instanceRef{userID: res.UserID, name: res.Name}
+		if err := p.Destroy(ctx, instanceName(ref)); err != nil {
+			return err
+		}
+		if err := os.RemoveAll(p.userDir(ref)); err != nil {
+			return fmt.Errorf("remove workspace: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported resource kind %q", res.Kind)
+	}
+}
+
+// findServer returns the instance's server, or nil if there is none.
+func (p *Provider) findServer(ctx context.Context, ref instanceRef) (*hcloud.Server, error) {
+	servers, err := p.api.Server.AllWithOpts(ctx, hcloud.ServerListOpts{
+		ListOpts: hcloud.ListOpts{LabelSelector: ref.selector()},
+	})
 	if err != nil {
-		return fmt.Errorf("terraform client: %w", err)
+		return nil, fmt.Errorf("list servers: %w", err)
+	}
+	if len(servers) == 0 {
+		return nil, nil
 	}
+	return servers[0], nil
+}
 
-	// Re-apply to recreate
-	if err := tf.Apply(ctx); err != nil {
-		return fmt.Errorf("terraform apply: %w", err)
+// listSnapshots returns the instance's pause snapshots, oldest first.
+func (p *Provider) listSnapshots(ctx context.Context, ref instanceRef) ([]*hcloud.Image, error) {
+	images, err := p.api.Image.AllWithOpts(ctx, hcloud.ImageListOpts{
+		ListOpts: hcloud.ListOpts{LabelSelector: ref.selector()},
+		Type:     []hcloud.ImageType{hcloud.ImageTypeSnapshot},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots: %w", err)
+	}
+	sort.Slice(images, func(i, j int) bool {
+		if images[i].Created.Equal(images[j].Created) {
+			return images[i].ID < images[j].ID
+		}
+		return images[i].Created.Before(images[j].Created)
+	})
+	return images, nil
+}
+
+// pruneSnapshots deletes all but the newest snapshotRetention snapshots
+// (sorted oldest first) and returns the IDs of those kept.
+func (p *Provider) pruneSnapshots(ctx context.Context, snapshots []*hcloud.Image) ([]string, error) {
+	cut := max(len(snapshots)-p.snapshotRetention, 0)
+	for _, img := range snapshots[:cut] {
+		if _, err := p.api.Image.Delete(ctx, img); err != nil && !hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+			return nil, fmt.Errorf("delete snapshot %d: %w", img.ID, err)
+		}
+	}
+
+	kept := make([]string, 0, len(snapshots)-cut)
+	for _, img := range snapshots[cut:] {
+		kept = append(kept, strconv.FormatInt(img.ID, 10))
+	}
+	return kept, nil
+}
+
+// shutdown stops a running server gracefully so the snapshot is consistent,
+// falling back to a hard power-off after shutdownTimeout.
+func (p *Provider) shutdown(ctx context.Context, server *hcloud.Server) error {
+	if server.Status == hcloud.ServerStatusOff {
+		return nil
+	}
+
+	action, _, err := p.api.Server.Shutdown(ctx, server)
+	if err != nil {
+		return fmt.Errorf("shutdown server: %w", err)
+	}
+	if err := p.api.Action.WaitFor(ctx, action); err != nil {
+		return fmt.Errorf("wait for shutdown: %w", err)
+	}
+
+	// The shutdown action completes once the ACPI signal is sent; poll until
+	// the OS has actually powered off
+	deadline := time.Now().Add(shutdownTimeout)
+	for time.Now().Before(deadline) {
+		s, _, err := p.api.Server.GetByID(ctx, server.ID)
+		if err != nil {
+			return fmt.Errorf("get server: %w", err)
+		}
+		if s == nil {
+			return provider.ErrNotFound
+		}
+		if s.Status == hcloud.ServerStatusOff {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.pollInterval):
+		}
+	}
+
+	action, _, err = p.api.Server.Poweroff(ctx, server)
+	if err != nil {
+		return fmt.Errorf("power off server: %w", err)
 	}
+	if err := p.api.Action.WaitFor(ctx, action); err != nil {
+		return fmt.Errorf("wait for power off: %w", err)
+	}
+	return nil
+}
 
+func (p *Provider) deleteServer(ctx context.Context, server *hcloud.Server) error {
+	res, _, err := p.api.Server.DeleteWithResult(ctx, server)
+	if err != nil {
+		if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+			return nil
+		}
+		return fmt.Errorf("delete server: %w", err)
+	}
+	if err := p.api.Action.WaitFor(ctx, res.Action); err != nil {
+		return fmt.Errorf("wait for server deletion: %w", err)
+	}
 	return nil
 }
+
+// serverHost returns the server's private network IP, or "" if it has none
+// (Netbird keeps the peer address stable across snapshot restores).
+func serverHost(server *hcloud.Server) string {
+	if server == nil || len(server.PrivateNet) == 0 {
+		return ""
+	}
+	return server.PrivateNet[0].IP.String()
+}
+
+func mapServerStatus(status hcloud.ServerStatus) provider.Status {
+	switch status {
+	case hcloud.ServerStatusRunning:
+		return provider.StatusRunning
+	case hcloud.ServerStatusInitializing, hcloud.ServerStatusStarting,
+		hcloud.ServerStatusMigrating, hcloud.ServerStatusRebuilding:
+		return provider.StatusProvisioning
+	case hcloud.ServerStatusOff, hcloud.ServerStatusStopping:
+		return provider.StatusStopped
+	case hcloud.ServerStatusDeleting:
+		return provider.StatusDestroyed
+	default:
+		return provider.StatusError
+	}
+}
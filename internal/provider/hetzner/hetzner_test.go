@@ -0,0 +1,671 @@
+package hetzner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+// fakeHCloud is an in-memory stand-in for the parts of the Hetzner Cloud API
+// used by Pause and Wake. Every action it returns has already succeeded.
+type fakeHCloud struct {
+	mu        sync.Mutex
+	nextID    int64
+	servers   map[int64]*schema.Server
+	images    map[int64]*schema.Image
+	volumes   []schema.Volume
+	sshKeys   []schema.SSHKey
+	shutdowns int
+	created   []schema.ServerCreateRequest
+}
+
+func newTestProvider(t *testing.T, retention int) (*Provider, *fakeHCloud) {
+	t.Helper()
+	f := &fakeHCloud{
+		nextID:  100,
+		servers: make(map[int64]*schema.Server),
+		images:  make(map[int64]*schema.Image),
+	}
+	srv := httptest.NewServer(f.routes())
+	t.Cleanup(srv.Close)
+
+	p, err := New("test-token", "", t.TempDir(), retention)
+	if err != nil {
+		t.Fatalf("new provider: %v", err)
+	}
+	p.api = hcloud.NewClient(hcloud.WithToken("test-token"), hcloud.WithEndpoint(srv.URL))
+	p.pollInterval = time.Millisecond
+	return p, f
+}
+
+// addUser creates the user's default instance, as Create would.
+func (f *fakeHCloud) addUser(t *testing.T, p *Provider, userID int) {
+	t.Helper()
+	f.addInstance(t, p, instanceRef{userID: userID})
+}
+
+// addInstance creates the instance's workspace and a running server.
+func (f *fakeHCloud) addInstance(t *testing.T, p *Provider, ref instanceRef) {
+	t.Helper()
+	if err := os.MkdirAll(p.userDir(ref), 0o755); err != nil {
+		t.Fatalf("mkdir workspace: %v", err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	f.servers[f.nextID] = &schema.Server{
+		ID:         f.nextID,
+		Name:       "claude-" + ref.key(),
+		Status:     "running",
+		Created:    time.Now(),
+		ServerType: schema.ServerType{Name: "cx32"},
+		Datacenter: schema.Datacenter{Location: schema.Location{Name: "fsn1"}},
+		Labels:     ref.labels(),
+		PrivateNet: []schema.ServerPrivateNet{{Network: 7, IP: privateIP(ref.userID)}},
+	}
+	f.nextID++
+	f.volumes = append(f.volumes, schema.Volume{ID: f.nextID, Name: "claude-data-" + ref.key()})
+}
+
+func (f *fakeHCloud) action(command string) schema.Action {
+	f.nextID++
+	now := time.Now()
+	return schema.Action{ID: f.nextID, Status: "success", Command: command, Progress: 100, Started: now, Finished: &now}
+}
+
+func matchLabels(selector string, labels map[string]string) bool {
+	if selector == "" {
+		return true
+	}
+	for _, term := range strings.Split(selector, ",") {
+		if k, ok := strings.CutPrefix(term, "!"); ok {
+			if _, has := labels[k]; has {
+				return false
+			}
+			continue
+		}
+		k, v, _ := strings.Cut(term, "=")
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *fakeHCloud) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	writeJSON := func(w http.ResponseWriter, status int, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(v)
+	}
+	notFound := func(w http.ResponseWriter) {
+		writeJSON(w, http.StatusNotFound, schema.ErrorResponse{Error: schema.Error{Code: "not_found", Message: "not found"}})
+	}
+	pathID := func(r *http.Request) int64 {
+		id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		return id
+	}
+
+	mux.HandleFunc("GET /servers", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		resp := schema.ServerListResponse{Servers: []schema.Server{}}
+		for _, s := range f.servers {
+			if matchLabels(r.URL.Query().Get("label_selector"), s.Labels) {
+				resp.Servers = append(resp.Servers, *s)
+			}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	})
+	mux.HandleFunc("GET /servers/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		s, ok := f.servers[pathID(r)]
+		if !ok {
+			notFound(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, schema.ServerGetResponse{Server: *s})
+	})
+	mux.HandleFunc("POST /servers", func(w http.ResponseWriter, r *http.Request) {
+		var req schema.ServerCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, schema.ErrorResponse{Error: schema.Error{Code: "invalid_input", Message: err.Error()}})
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		imageID := int64(req.Image.(float64))
+		if _, ok := f.images[imageID]; !ok {
+			notFound(w)
+			return
+		}
+		f.created = append(f.created, req)
+		f.nextID++
+		s := &schema.Server{
+			ID:         f.nextID,
+			Name:       req.Name,
+			Status:     "running",
+			Created:    time.Now(),
+			ServerType: schema.ServerType{Name: req.ServerType.(string)},
+			Datacenter: schema.Datacenter{Location: schema.Location{Name: req.Location}},
+			Labels:     *req.Labels,
+			Volumes:    req.Volumes,
+		}
+		for _, n := range req.Networks {
+			s.PrivateNet = append(s.PrivateNet, schema.ServerPrivateNet{Network: n, IP: "10.0.0.2"})
+		}
+		f.servers[s.ID] = s
+		writeJSON(w, http.StatusCreated, schema.ServerCreateResponse{Server: *s, Action: f.action("create_server")})
+	})
+	mux.HandleFunc("PUT /servers/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var req schema.ServerUpdateRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		s, ok := f.servers[pathID(r)]
+		if !ok {
+			notFound(w)
+			return
+		}
+		if req.Name != "" {
+			s.Name = req.Name
+		}
+		if req.Labels != nil {
+			s.Labels = *req.Labels
+		}
+		writeJSON(w, http.StatusOK, schema.ServerUpdateResponse{Server: *s})
+	})
+	mux.HandleFunc("DELETE /servers/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.servers[pathID(r)]; !ok {
+			notFound(w)
+			return
+		}
+		delete(f.servers, pathID(r))
+		writeJSON(w, http.StatusOK, schema.ServerDeleteResponse{Action: f.action("delete_server")})
+	})
+	mux.HandleFunc("POST /servers/{id}/actions/{action}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		s, ok := f.servers[pathID(r)]
+		if !ok {
+			notFound(w)
+			return
+		}
+		switch r.PathValue("action") {
+		case "shutdown", "poweroff":
+			f.shutdowns++
+			s.Status = "off"
+			writeJSON(w, http.StatusCreated, schema.ServerActionShutdownResponse{Action: f.action("shutdown_server")})
+		case "poweron":
+			s.Status = "running"
+			writeJSON(w, http.StatusCreated, schema.ServerActionPoweronResponse{Action: f.action("start_server")})
+		case "change_type":
+			var req schema.ServerActionChangeTypeRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if s.Status != "off" {
+				writeJSON(w, http.StatusConflict, schema.ErrorResponse{Error: schema.Error{Code: "server_not_stopped", Message: "server must be off"}})
+				return
+			}
+			s.ServerType = schema.ServerType{Name: req.ServerType.(string)}
+			writeJSON(w, http.StatusCreated, schema.ServerActionChangeTypeResponse{Action: f.action("change_server_type")})
+		case "create_image":
+			var req schema.ServerActionCreateImageRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if s.Status != "off" {
+				writeJSON(w, http.StatusConflict, schema.ErrorResponse{Error: schema.Error{Code: "conflict", Message: "test expects a powered-off server"}})
+				return
+			}
+			f.nextID++
+			created := time.Now().Add(time.Duration(f.nextID) * time.Second)
+			img := &schema.Image{
+				ID:          f.nextID,
+				Status:      "available",
+				Type:        *req.Type,
+				Description: *req.Description,
+				Created:     &created,
+				Labels:      *req.Labels,
+			}
+			f.images[img.ID] = img
+			writeJSON(w, http.StatusCreated, schema.ServerActionCreateImageResponse{Image: *img, Action: f.action("create_image")})
+		default:
+			notFound(w)
+		}
+	})
+	mux.HandleFunc("GET /images", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		resp := schema.ImageListResponse{Images: []schema.Image{}}
+		for _, img := range f.images {
+			if t := r.URL.Query().Get("type"); t != "" && t != img.Type {
+				continue
+			}
+			if matchLabels(r.URL.Query().Get("label_selector"), img.Labels) {
+				resp.Images = append(resp.Images, *img)
+			}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	})
+	mux.HandleFunc("DELETE /images/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.images[pathID(r)]; !ok {
+			notFound(w)
+			return
+		}
+		delete(f.images, pathID(r))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /volumes", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		resp := schema.VolumeListResponse{Volumes: []schema.Volume{}}
+		for _, v := range f.volumes {
+			if name := r.URL.Query().Get("name"); name == "" || name == v.Name {
+				resp.Volumes = append(resp.Volumes, v)
+			}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	})
+
+	mux.HandleFunc("GET /ssh_keys", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		resp := schema.SSHKeyListResponse{SSHKeys: []schema.SSHKey{}}
+		for _, k := range f.sshKeys {
+			if name := r.URL.Query().Get("name"); name == "" || name == k.Name {
+				resp.SSHKeys = append(resp.SSHKeys, k)
+			}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	})
+
+	mux.HandleFunc("PUT /volumes/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var req schema.VolumeUpdateRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for i := range f.volumes {
+			if f.volumes[i].ID == pathID(r) {
+				f.volumes[i].Name = req.Name
+				writeJSON(w, http.StatusOK, schema.VolumeUpdateResponse{Volume: f.volumes[i]})
+				return
+			}
+		}
+		notFound(w)
+	})
+
+	return mux
+}
+
+func TestPauseSnapshot(t *testing.T) {
+	p, f := newTestProvider(t, 3)
+	ctx := context.Background()
+	f.addUser(t, p, 4)
+
+	snapshotID, err := p.PauseSnapshot(ctx, "hetzner-4")
+	if err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+
+	if f.shutdowns != 1 {
+		t.Errorf("shutdowns = %d, want 1 before snapshotting", f.shutdowns)
+	}
+	if len(f.servers) != 0 {
+		t.Errorf("server still present after pause")
+	}
+	id, _ := strconv.ParseInt(snapshotID, 10, 64)
+	img, ok := f.images[id]
+	if !ok {
+		t.Fatalf("snapshot %s not found", snapshotID)
+	}
+	if img.Type != "snapshot" || img.Labels["user_id"] != "4" || img.Labels["server_type"] != "cx32" || img.Labels["location"] != "fsn1" || img.Labels["network_id"] != "7" {
+		t.Errorf("snapshot = %+v", img)
+	}
+
+	st, err := p.Status(ctx, "hetzner-4")
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if st.Status != provider.StatusStopped {
+		t.Errorf("status = %q, want stopped", st.Status)
+	}
+
+	if _, err := p.PauseSnapshot(ctx, "hetzner-4"); !errors.Is(err, provider.ErrInvalidState) {
+		t.Errorf("second pause: got %v, want ErrInvalidState", err)
+	}
+	if _, err := p.PauseSnapshot(ctx, "hetzner-9"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("pause without workspace: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestWakeSnapshot(t *testing.T) {
+	p, f := newTestProvider(t, 3)
+	ctx := context.Background()
+	f.addUser(t, p, 4)
+	// A workspace that gives its server SSH keys keeps them across a wake
+	f.sshKeys = []schema.SSHKey{{ID: 55, Name: "pool"}}
+	if err := writeWorkspace(p.userDir(instanceRef{userID: 4}), map[string]any{"ssh_keys": []string{"pool"}}); err != nil {
+		t.Fatalf("write workspace: %v", err)
+	}
+
+	snapshotID, err := p.PauseSnapshot(ctx, "hetzner-4")
+	if err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+
+	if _, _, err := p.WakeSnapshot(ctx, "hetzner-4", "999"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("wake from unknown snapshot: got %v, want ErrNotFound", err)
+	}
+
+	inst, kept, err := p.WakeSnapshot(ctx, "hetzner-4", snapshotID)
+	if err != nil {
+		t.Fatalf("wake: %v", err)
+	}
+	if inst.ProviderID != "hetzner-4" || inst.Status != provider.StatusRunning || inst.Host != "10.0.0.2" {
+		t.Errorf("instance = %+v", inst)
+	}
+	if len(kept) != 1 || kept[0] != snapshotID {
+		t.Errorf("kept = %v, want [%s]", kept, snapshotID)
+	}
+
+	req := f.created[0]
+	if req.Image.(float64) != float64(mustParse(t, snapshotID)) {
+		t.Errorf("server created from image %v, want %s", req.Image, snapshotID)
+	}
+	if req.ServerType != "cx32" || req.Location != "fsn1" || req.Name != "claude-4" {
+		t.Errorf("create request = %+v, want original shape", req)
+	}
+	if len(req.Volumes) != 1 || req.Volumes[0] != f.volumes[0].ID || req.Automount == nil || !*req.Automount {
+		t.Errorf("volumes = %v automount = %v, want data volume attached", req.Volumes, req.Automount)
+	}
+	if len(req.Networks) != 1 || req.Networks[0] != 7 {
+		t.Errorf("networks = %v, want the paused server's", req.Networks)
+	}
+	if len(req.SSHKeys) != 1 || req.SSHKeys[0] != 55 {
+		t.Errorf("ssh keys = %v, want the workspace's", req.SSHKeys)
+	}
+
+	st, _ := p.Status(ctx, "hetzner-4")
+	if st.Status != provider.StatusRunning {
+		t.Errorf("status after wake = %q, want running", st.Status)
+	}
+	if _, _, err := p.WakeSnapshot(ctx, "hetzner-4", ""); !errors.Is(err, provider.ErrInvalidState) {
+		t.Errorf("wake while running: got %v, want ErrInvalidState", err)
+	}
+}
+
+func TestWakeSnapshotWithoutNetwork(t *testing.T) {
+	p, f := newTestProvider(t, 3)
+	ctx := context.Background()
+	f.addUser(t, p, 4)
+	for _, s := range f.servers {
+		s.PrivateNet = nil
+	}
+	if err := p.Pause(ctx, "hetzner-4"); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+
+	// The host is the one the Terraform module reports
+	inst, _, err := p.WakeSnapshot(ctx, "hetzner-4", "")
+	if err != nil {
+		t.Fatalf("wake: %v", err)
+	}
+	if inst.Host != privateIP(4) {
+		t.Errorf("host = %q, want %q", inst.Host, privateIP(4))
+	}
+	if req := f.created[0]; len(req.Networks) != 0 || len(req.SSHKeys) != 0 {
+		t.Errorf("create request = %+v, want no networks or keys", req)
+	}
+}
+
+func TestWakePrunesSnapshots(t *testing.T) {
+	p, f := newTestProvider(t, 2)
+	ctx := context.Background()
+	f.addUser(t, p, 1)
+
+	var ids []string
+	var kept []string
+	for i := 0; i < 3; i++ {
+		if err := p.Pause(ctx, "hetzner-1"); err != nil {
+			t.Fatalf("pause %d: %v", i, err)
+		}
+		snaps, _ := p.listSnapshots(ctx, instanceRef{userID: 1})
+		ids = append(ids, strconv.FormatInt(snaps[len(snaps)-1].ID, 10))
+
+		var err error
+		if _, kept, err = p.WakeSnapshot(ctx, "hetzner-1", ""); err != nil {
+			t.Fatalf("wake %d: %v", i, err)
+		}
+	}
+
+	if len(f.images) != 2 {
+		t.Errorf("%d snapshots left, want 2", len(f.images))
+	}
+	if len(kept) != 2 || kept[0] != ids[1] || kept[1] != ids[2] {
+		t.Errorf("kept = %v, want the newest two of %v", kept, ids)
+	}
+	// The latest wake must use the latest snapshot
+	if got := f.created[2].Image.(float64); got != float64(mustParse(t, ids[2])) {
+		t.Errorf("third wake used image %v, want %s", got, ids[2])
+	}
+}
+
+func TestListManagedPaused(t *testing.T) {
+	p, f := newTestProvider(t, 3)
+	ctx := context.Background()
+	f.addUser(t, p, 1)
+	f.addUser(t, p, 2)
+
+	if err := p.Pause(ctx, "hetzner-2"); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+
+	res, err := p.ListManaged(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+
+	counts := make(map[provider.ResourceKind]int)
+	for _, r := range res {
+		counts[r.Kind]++
+		if r.Kind != provider.ResourceInstance {
+			continue
+		}
+		switch r.UserID {
+		case 1:
+			if r.ProviderID != "hetzner-1" || r.Status != provider.StatusRunning {
+				t.Errorf("running instance = %+v", r)
+			}
+		case 2:
+			if r.ProviderID != "hetzner-2" || r.Status != provider.StatusStopped {
+				t.Errorf("paused instance = %+v, want stopped", r)
+			}
+		}
+	}
+	if counts[provider.ResourceInstance] != 2 || counts[provider.ResourceSnapshot] != 1 || counts[provider.ResourceWorkspace] != 2 {
+		t.Errorf("resource counts = %v", counts)
+	}
+}
+
+func TestNamedInstances(t *testing.T) {
+	p, f := newTestProvider(t, 3)
+	ctx := context.Background()
+	f.addUser(t, p, 6)
+	f.addInstance(t, p, instanceRef{userID: 6, name: "feature-x"})
+
+	// Pausing the named instance must leave the default one alone
+	snapshotID, err := p.PauseSnapshot(ctx, "hetzner-6-feature-x")
+	if err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if len(f.servers) != 1 {
+		t.Fatalf("expected the default server to survive, have %d servers", len(f.servers))
+	}
+	if img := f.images[mustParse(t, snapshotID)]; img.Labels["instance_name"] != "feature-x" {
+		t.Errorf("snapshot labels = %v, want instance_name=feature-x", img.Labels)
+	}
+	if st, _ := p.Status(ctx, "hetzner-6"); st.Status != provider.StatusRunning {
+		t.Errorf("default instance status = %q, want running", st.Status)
+	}
+
+	// The default instance has no snapshots of its own
+	if _, _, err := p.WakeSnapshot(ctx, "hetzner-6", snapshotID); !errors.Is(err, provider.ErrInvalidState) {
+		t.Errorf("expected ErrInvalidState waking a running default instance, got %v", err)
+	}
+	if _, _, err := p.WakeSnapshot(ctx, "hetzner-6-feature-x", ""); err != nil {
+		t.Fatalf("wake: %v", err)
+	}
+	req := f.created[len(f.created)-1]
+	if req.Name != "claude-6-feature-x" || (*req.Labels)["instance_name"] != "feature-x" {
+		t.Errorf("wake request name=%q labels=%v", req.Name, *req.Labels)
+	}
+
+	res, err := p.ListManaged(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, r := range res {
+		if r.Kind == provider.ResourceWorkspace {
+			names[r.Name] = true
+		}
+	}
+	if !names[provider.DefaultInstanceName] || !names["feature-x"] {
+		t.Errorf("workspace names = %v", names)
+	}
+}
+
+func TestResize(t *testing.T) {
+	p, f := newTestProvider(t, 3)
+	ctx := context.Background()
+	f.addUser(t, p, 7)
+
+	medium := provider.Class{Name: "medium", ServerType: "cx42"}
+	if err := p.Resize(ctx, "hetzner-7", medium); err != nil {
+		t.Fatalf("resize: %v", err)
+	}
+	var server *schema.Server
+	for _, s := range f.servers {
+		server = s
+	}
+	if server.ServerType.Name != "cx42" || server.Status != "running" {
+		t.Errorf("after resize: type %q status %q, want cx42 running", server.ServerType.Name, server.Status)
+	}
+
+	// Already the requested type: no shutdown
+	if err := p.Resize(ctx, "hetzner-7", medium); err != nil {
+		t.Fatalf("resize again: %v", err)
+	}
+	if f.shutdowns != 1 {
+		t.Errorf("shutdowns = %d, want 1", f.shutdowns)
+	}
+
+	if err := p.Pause(ctx, "hetzner-7"); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if err := p.Resize(ctx, "hetzner-7", provider.Class{Name: "large", ServerType: "cx52"}); !errors.Is(err, provider.ErrInvalidState) {
+		t.Errorf("resize paused: got %v, want ErrInvalidState", err)
+	}
+}
+
+func TestClaim(t *testing.T) {
+	p, f := newTestProvider(t, 3)
+	ctx := context.Background()
+	warm := instanceRef{pool: "ab12cd34"}
+	f.addInstance(t, p, warm)
+	for _, s := range f.servers {
+		s.PublicNet.IPv4.IP = "203.0.113.7"
+	}
+	f.addUser(t, p, 9)
+
+	type call struct{ host, cmd, stdin string }
+	var calls []call
+	p.remote = func(ctx context.Context, host, cmd, stdin string) error {
+		calls = append(calls, call{host, cmd, stdin})
+		return nil
+	}
+
+	// Warm servers belong to the pool, not the reconciler
+	res, err := p.ListManaged(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	for _, r := range res {
+		if r.Kind == provider.ResourceInstance && r.UserID != 9 {
+			t.Errorf("warm server listed: %+v", r)
+		}
+	}
+
+	opts := provider.CreateOptions{Name: "api", AgentSecret: "s3cret", ClaudeOAuthToken: "tok", NetbirdSetupKey: "nb-key"}
+	if _, err := p.Claim(ctx, "hetzner-9", 9, opts); err == nil {
+		t.Error("claiming a user instance: expected error")
+	}
+	if _, err := p.Claim(ctx, "hetzner-pool-ab12cd34", 9, provider.CreateOptions{}); !errors.Is(err, provider.ErrAlreadyExists) {
+		t.Errorf("claim over the default instance: got %v, want ErrAlreadyExists", err)
+	}
+
+	inst, err := p.Claim(ctx, "hetzner-pool-ab12cd34", 9, opts)
+	if err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if inst.ProviderID != "hetzner-9-api" || inst.UserID != 9 || inst.Host != "10.100.10.10" {
+		t.Errorf("claimed instance = %+v", inst)
+	}
+
+	if len(calls) != 1 || calls[0].cmd != bindScript || calls[0].host != "203.0.113.7" {
+		t.Fatalf("remote calls = %+v, want one bind on the public IP", calls)
+	}
+	want := "AGENT_SECRET=s3cret\nCLAUDE_CODE_OAUTH_TOKEN=tok\nNETBIRD_SETUP_KEY=nb-key\n"
+	if calls[0].stdin != want {
+		t.Errorf("bind input = %q, want %q", calls[0].stdin, want)
+	}
+
+	ref := instanceRef{userID: 9, name: "api"}
+	server, err := p.findServer(ctx, ref)
+	if err != nil || server == nil {
+		t.Fatalf("claimed server not found by owner labels: %v", err)
+	}
+	if server.Name != "claude-9-api" || server.Labels["pool"] != "" {
+		t.Errorf("claimed server name=%q labels=%v", server.Name, server.Labels)
+	}
+	if v, _, _ := p.api.Volume.GetByName(ctx, "claude-data-9-api"); v == nil {
+		t.Error("volume not renamed")
+	}
+	if _, err := os.Stat(p.userDir(warm)); !os.IsNotExist(err) {
+		t.Errorf("pool workspace still present: %v", err)
+	}
+	vars, err := os.ReadFile(p.userDir(ref) + "/terraform.tfvars.json")
+	if err != nil {
+		t.Fatalf("read tfvars: %v", err)
+	}
+	if strings.Contains(string(vars), "pool_id") || !strings.Contains(string(vars), `"instance_name": "api"`) {
+		t.Errorf("tfvars not rewritten for the user: %s", vars)
+	}
+}
+
+func mustParse(t *testing.T, id string) int64 {
+	t.Helper()
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		t.Fatalf("parse %q: %v", id, err)
+	}
+	return n
+}
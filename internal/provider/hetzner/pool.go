@@ -0,0 +1,279 @@
+package hetzner
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+const (
+	// bindScript is written by cloud-init on every server. It reads KEY=value
+	// lines on stdin and binds a warm server to its user.
+	bindScript = "/opt/cloudcode/bind.sh"
+
+	// readyTimeout bounds how long CreateWarm waits for cloud-init to finish.
+	readyTimeout = 15 * time.Minute
+)
+
+// EnablePool lets the provider boot warm pool servers. sshKeyName names an
+// SSH key registered in the Hetzner project that warm servers are created
+// with; privateKeyPEM is its private half, which Claim uses to bind a server
+// to its user after boot.
+func (p *Provider) EnablePool(sshKeyName string, privateKeyPEM []byte) error {
+	signer, err := ssh.ParsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return fmt.Errorf("parse pool SSH key: %w", err)
+	}
+	p.sshKeyName = sshKeyName
+	p.sshSigner = signer
+	p.remote = p.sshRun
+	return nil
+}
+
+// CreateWarm applies a Terraform workspace for a server that belongs to no
+// user and waits for cloud-init to finish, so Claim only has to bind it. Warm
+// servers carry a pool label instead of user_id and are left out of
+// ListManaged; the warm pool owns them until they are claimed.
+func (p *Provider) CreateWarm(ctx context.Context, class provider.Class) (*provider.Instance, error) {
+	if p.remote == nil {
+		return nil, fmt.Errorf("warm pool SSH key not set: %w", provider.ErrProviderNotConfigured)
+	}
+
+	id := make([]byte, 4)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("generate pool ID: %w", err)
+	}
+	ref := instanceRef{pool: hex.EncodeToString(id)}
+	dir := p.userDir(ref)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create pool dir: %w", err)
+	}
+
+	vars := map[string]any{
+		"user_id":      0,
+		"pool_id":      ref.pool,
+		"ssh_keys":     []string{p.sshKeyName},
+		"hcloud_token": p.hcloudToken,
+	}
+	if class.ServerType != "" {
+		vars["server_type"] = class.ServerType
+	}
+	if err := writeWorkspace(dir, vars); err != nil {
+		return nil, err
+	}
+	_, volumeID, err := p.apply(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.waitReady(ctx, ref); err != nil {
+		// Best-effort cleanup; a half-booted server is no use to the pool
+		_ = p.Destroy(ctx, instanceName(ref))
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	return &provider.Instance{
+		ID:         instanceName(ref),
+		Provider:   "hetzner",
+		ProviderID: instanceName(ref),
+		Status:     provider.StatusRunning,
+		VolumeID:   volumeID,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}, nil
+}
+
+// waitReady polls the warm server over SSH until cloud-init has finished.
+// SSH refuses connections for the first part of the boot.
+func (p *Provider) waitReady(ctx context.Context, ref instanceRef) error {
+	server, err := p.findServer(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if server == nil {
+		return fmt.Errorf("warm server %s: %w", ref.key(), provider.ErrNotFound)
+	}
+
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		err := p.remote(ctx, publicIP(server), "cloud-init status --wait", "")
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("wait for cloud-init: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// Claim binds a warm server to the user. The bind script installs the agent
+// secret and credentials and, given a setup key, enrolls the server in
+// Netbird. The server, volume and workspace are then renamed and relabeled
+// to what Create would have produced, so the rest of the provider treats the
+// instance like any other.
+func (p *Provider) Claim(ctx context.Context, warmID string, userID int, opts provider.CreateOptions) (*provider.Instance, error) {
+	if p.remote == nil {
+		return nil, fmt.Errorf("warm pool SSH key not set: %w", provider.ErrProviderNotConfigured)
+	}
+	warm, err := parseInstanceID(warmID)
+	if err != nil {
+		return nil, err
+	}
+	if warm.pool == "" {
+		return nil, fmt.Errorf("claim %s: not a warm pool instance", warmID)
+	}
+
+	ref := instanceRef{userID: userID, name: opts.Name}
+	dir := p.userDir(ref)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, provider.ErrAlreadyExists
+	}
+
+	server, err := p.findServer(ctx, warm)
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		return nil, provider.ErrNotFound
+	}
+	volume, _, err := p.api.Volume.GetByName(ctx, "claude-data-"+warm.key())
+	if err != nil {
+		return nil, fmt.Errorf("get volume: %w", err)
+	}
+
+	// Bind before relabeling: if it fails the server is still generic and
+	// the pool can destroy it
+	if err := p.remote(ctx, publicIP(server), bindScript, bindEnv(opts)); err != nil {
+		return nil, fmt.Errorf("bind warm server: %w", err)
+	}
+
+	if _, _, err := p.api.Server.Update(ctx, server, hcloud.ServerUpdateOpts{
+		Name:   "claude-" + ref.key(),
+		Labels: ref.labels(),
+	}); err != nil {
+		return nil, fmt.Errorf("relabel server: %w", err)
+	}
+	inst := &provider.Instance{
+		ID:         instanceName(ref),
+		UserID:     userID,
+		Provider:   "hetzner",
+		ProviderID: instanceName(ref),
+		Host:       privateIP(userID),
+		Status:     provider.StatusRunning,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if volume != nil {
+		if _, _, err := p.api.Volume.Update(ctx, volume, hcloud.VolumeUpdateOpts{
+			Name: "claude-data-" + ref.key(),
+		}); err != nil {
+			return nil, fmt.Errorf("rename volume: %w", err)
+		}
+		inst.VolumeID = strconv.FormatInt(volume.ID, 10)
+	}
+
+	// The Terraform state moves with the workspace. Later runs (Destroy, or
+	// Wake without snapshots) see the user's variables.
+	if err := os.Rename(p.userDir(warm), dir); err != nil {
+		return nil, fmt.Errorf("move workspace: %w", err)
+	}
+	serverType := ""
+	if server.ServerType != nil {
+		serverType = server.ServerType.Name
+	}
+	if err := writeWorkspace(dir, p.userVars(ref, serverType)); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+// bindEnv renders the bind script's input. Secrets travel over the SSH
+// session's stdin rather than its command line.
+func bindEnv(opts provider.CreateOptions) string {
+	var b strings.Builder
+	for _, kv := range [][2]string{
+		{"AGENT_SECRET", opts.AgentSecret},
+		{"ANTHROPIC_API_KEY", opts.AnthropicAPIKey},
+		{"CLAUDE_CODE_OAUTH_TOKEN", opts.ClaudeOAuthToken},
+		{"NETBIRD_SETUP_KEY", opts.NetbirdSetupKey},
+	} {
+		if kv[1] != "" {
+			fmt.Fprintf(&b, "%s=%s\n", kv[0], kv[1])
+		}
+	}
+	return b.String()
+}
+
+// privateIP mirrors the private_ip output of the user_instance module.
+func privateIP(userID int) string {
+	return fmt.Sprintf("10.100.%d.10", userID%250+1)
+}
+
+func publicIP(server *hcloud.Server) string {
+	if server.PublicNet.IPv4.IP == nil {
+		return ""
+	}
+	return server.PublicNet.IPv4.IP.String()
+}
+
+// sshRun runs cmd as root on host with stdin as its input.
+func (p *Provider) sshRun(ctx context.Context, host, cmd, stdin string) error {
+	if host == "" {
+		return fmt.Errorf("server has no public IPv4")
+	}
+	addr := net.JoinHostPort(host, "22")
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("dial %s: %w", addr, err)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, &ssh.ClientConfig{
+		User: "root",
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(p.sshSigner)},
+		// Fresh servers have no published host key; the address comes from
+		// the authenticated Cloud API
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("ssh handshake: %w", err)
+	}
+	client := ssh.NewClient(c, chans, reqs)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("ssh session: %w", err)
+	}
+	defer session.Close()
+
+	var out bytes.Buffer
+	session.Stdin = strings.NewReader(stdin)
+	session.Stdout = &out
+	session.Stderr = &out
+	if err := session.Run(cmd); err != nil {
+		return fmt.Errorf("%s: %w: %s", cmd, err, strings.TrimSpace(out.String()))
+	}
+	return nil
+}
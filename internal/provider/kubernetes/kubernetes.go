@@ -0,0 +1,538 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+const (
+	defaultImage      = "claude-instance:latest"
+	defaultVolumeSize = "20Gi"
+	labelPrefix       = "cloudcode."
+	containerName     = "claude"
+)
+
+// Options configures the Kubernetes provider.
+type Options struct {
+	Namespace    string // defaults to "cloudcode"
+	Image        string // defaults to claude-instance:latest
+	StorageClass string // empty uses the cluster default
+	VolumeSize   string // PVC request, defaults to 20Gi
+}
+
+// Provider implements provider.Provisioner on Kubernetes.
+// Each instance gets a single-replica StatefulSet, a headless Service for
+// stable DNS, a Secret for credentials and a PersistentVolumeClaim that
+// survives Destroy (the equivalent of Docker's claude-data-{key} volume).
+type Provider struct {
+	cs     kubernetes.Interface
+	config *rest.Config // nil in tests; exec-based activity checks are skipped
+	opts   Options
+}
+
+// New creates a Kubernetes provider. An empty kubeconfig path uses the
+// in-cluster service account.
+func New(kubeconfig string, opts Options) (*Provider, error) {
+	var (
+		cfg *rest.Config
+		err error
+	)
+	if kubeconfig == "" {
+		cfg, err = rest.InClusterConfig()
+	} else {
+		cfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes config: %w", err)
+	}
+
+	cs, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes client: %w", err)
+	}
+
+	p := NewWithClient(cs, opts)
+	p.config = cfg
+	return p, nil
+}
+
+// NewWithClient creates a provider around an existing clientset (e.g. the fake clientset in tests).
+func NewWithClient(cs kubernetes.Interface, opts Options) *Provider {
+	if opts.Namespace == "" {
+		opts.Namespace = "cloudcode"
+	}
+	if opts.Image == "" {
+		opts.Image = defaultImage
+	}
+	if opts.VolumeSize == "" {
+		opts.VolumeSize = defaultVolumeSize
+	}
+	return &Provider{cs: cs, opts: opts}
+}
+
+// instanceName, claimName and secretName take a provider.InstanceKey, so
+// the default instance keeps the original claude-{userID} names.
+func instanceName(key string) string {
+	return "claude-" + key
+}
+
+func claimName(key string) string {
+	return "claude-data-" + key
+}
+
+func secretName(key string) string {
+	return "claude-" + key + "-env"
+}
+
+func labels(userID int, name string) map[string]string {
+	if name == "" {
+		name = provider.DefaultInstanceName
+	}
+	return map[string]string{
+		labelPrefix + "managed":       "true",
+		labelPrefix + "user_id":       strconv.Itoa(userID),
+		labelPrefix + "instance_name": name,
+		labelPrefix + "instance":      provider.InstanceKey(userID, name),
+	}
+}
+
+// selector matches the pods of a single instance. StatefulSets created before
+// named instances select on user_id alone; their selectors are immutable and
+// left as they are.
+func selector(key string) map[string]string {
+	return map[string]string{labelPrefix + "instance": key}
+}
+
+// instanceKey returns the owner, name and key recorded on a managed object;
+// unnamed objects belong to the default instance.
+func instanceKey(l map[string]string) (int, string, string) {
+	userID, _ := strconv.Atoi(l[labelPrefix+"user_id"])
+	name := l[labelPrefix+"instance_name"]
+	if name == "" {
+		name = provider.DefaultInstanceName
+	}
+	return userID, name, provider.InstanceKey(userID, name)
+}
+
+// host returns the in-cluster DNS name of the instance's headless Service.
+func (p *Provider) host(name string) string {
+	return fmt.Sprintf("%s.%s.svc", name, p.opts.Namespace)
+}
+
+// Create provisions a StatefulSet, Service, Secret and PVC for the instance.
+// The instance reports provisioning until its pod becomes ready.
+func (p *Provider) Create(ctx context.Context, userID int, opts provider.CreateOptions) (*provider.Instance, error) {
+	key := provider.InstanceKey(userID, opts.Name)
+	name := instanceName(key)
+	ns := p.opts.Namespace
+	objLabels := labels(userID, opts.Name)
+
+	_, err := p.cs.AppsV1().StatefulSets(ns).Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return nil, provider.ErrAlreadyExists
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("get statefulset: %w", err)
+	}
+
+	if err := p.ensureClaim(ctx, key, objLabels); err != nil {
+		return nil, fmt.Errorf("ensure pvc: %w", err)
+	}
+
+	// Credentials go in a Secret rather than the pod spec, along with the
+	// template's variables so a setup script can carry tokens of its own
+	env := opts.Environment.Vars()
+	if opts.AgentSecret != "" {
+		env["AGENT_SECRET"] = opts.AgentSecret
+	}
+	if opts.AnthropicAPIKey != "" {
+		env["ANTHROPIC_API_KEY"] = opts.AnthropicAPIKey
+	}
+	if opts.ClaudeOAuthToken != "" {
+		env["CLAUDE_CODE_OAUTH_TOKEN"] = opts.ClaudeOAuthToken
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName(key), Namespace: ns, Labels: objLabels},
+		StringData: env,
+	}
+	_, err = p.cs.CoreV1().Secrets(ns).Create(ctx, secret, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		// Left over from a destroyed instance — refresh with the new credentials
+		_, err = p.cs.CoreV1().Secrets(ns).Update(ctx, secret, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("apply secret: %w", err)
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Labels: objLabels},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  selector(key),
+			Ports: []corev1.ServicePort{
+				{Name: "ttyd", Port: 7681},
+				{Name: "agent", Port: 3001},
+			},
+		},
+	}
+	if _, err := p.cs.CoreV1().Services(ns).Create(ctx, svc, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("create service: %w", err)
+	}
+
+	sts, err := p.cs.AppsV1().StatefulSets(ns).Create(ctx, p.statefulSet(key, objLabels, opts.Class, opts.Environment.Image), metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("create statefulset: %w", err)
+	}
+
+	return &provider.Instance{
+		ID:         name,
+		UserID:     userID,
+		Provider:   "kubernetes",
+		ProviderID: name,
+		Host:       p.host(name),
+		Status:     stateOf(sts),
+		VolumeID:   claimName(key),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}, nil
+}
+
+// statefulSet builds the instance's StatefulSet. An empty image means the
+// provider default.
+func (p *Provider) statefulSet(key string, objLabels map[string]string, class provider.Class, image string) *appsv1.StatefulSet {
+	name := instanceName(key)
+	replicas := int32(1)
+	if image == "" {
+		image = p.opts.Image
+	}
+
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: p.opts.Namespace, Labels: objLabels},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: name,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selector(key),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: objLabels},
+				Spec: corev1.PodSpec{
+					Hostname: name,
+					Containers: []corev1.Container{{
+						Name:  containerName,
+						Image: image,
+						EnvFrom: []corev1.EnvFromSource{{
+							SecretRef: &corev1.SecretEnvSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: secretName(key)},
+							},
+						}},
+						Ports: []corev1.ContainerPort{
+							{Name: "ttyd", ContainerPort: 7681},
+							{Name: "agent", ContainerPort: 3001},
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/claude-data"}},
+						Resources:    resources(class),
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "data",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName(key)},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func (p *Provider) ensureClaim(ctx context.Context, key string, objLabels map[string]string) error {
+	name := claimName(key)
+	_, err := p.cs.CoreV1().PersistentVolumeClaims(p.opts.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return nil // already exists
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	size, err := resource.ParseQuantity(p.opts.VolumeSize)
+	if err != nil {
+		return fmt.Errorf("parse volume size: %w", err)
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: p.opts.Namespace, Labels: objLabels},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+	if p.opts.StorageClass != "" {
+		pvc.Spec.StorageClassName = &p.opts.StorageClass
+	}
+	_, err = p.cs.CoreV1().PersistentVolumeClaims(p.opts.Namespace).Create(ctx, pvc, metav1.CreateOptions{})
+	return err
+}
+
+// Destroy deletes the StatefulSet, Service and Secret but preserves the PVC.
+func (p *Provider) Destroy(ctx context.Context, instanceID string) error {
+	ns := p.opts.Namespace
+	sts, err := p.cs.AppsV1().StatefulSets(ns).Get(ctx, instanceID, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return provider.ErrNotFound
+		}
+		return fmt.Errorf("get statefulset: %w", err)
+	}
+
+	if err := p.cs.AppsV1().StatefulSets(ns).Delete(ctx, instanceID, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete statefulset: %w", err)
+	}
+	if err := p.cs.CoreV1().Services(ns).Delete(ctx, instanceID, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete service: %w", err)
+	}
+	if userID, _, key := instanceKey(sts.Labels); userID != 0 {
+		if err := p.cs.CoreV1().Secrets(ns).Delete(ctx, secretName(key), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete secret: %w", err)
+		}
+	}
+	return nil
+}
+
+// Status maps the StatefulSet's replica counts to an instance status.
+func (p *Provider) Status(ctx context.Context, instanceID string) (*provider.Instance, error) {
+	sts, err := p.cs.AppsV1().StatefulSets(p.opts.Namespace).Get(ctx, instanceID, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, provider.ErrNotFound
+		}
+		return nil, fmt.Errorf("get statefulset: %w", err)
+	}
+
+	userID, _, key := instanceKey(sts.Labels)
+	return &provider.Instance{
+		ID:         sts.Name,
+		UserID:     userID,
+		Provider:   "kubernetes",
+		ProviderID: sts.Name,
+		Host:       p.host(sts.Name),
+		Status:     stateOf(sts),
+		VolumeID:   claimName(key),
+		CreatedAt:  sts.CreationTimestamp.Time,
+	}, nil
+}
+
+// Pause scales the StatefulSet to zero replicas. The PVC is kept.
+func (p *Provider) Pause(ctx context.Context, instanceID string) error {
+	return p.scale(ctx, instanceID, 0)
+}
+
+// Wake scales the StatefulSet back to one replica.
+func (p *Provider) Wake(ctx context.Context, instanceID string) error {
+	return p.scale(ctx, instanceID, 1)
+}
+
+func (p *Provider) scale(ctx context.Context, instanceID string, replicas int32) error {
+	sets := p.cs.AppsV1().StatefulSets(p.opts.Namespace)
+	sts, err := sets.Get(ctx, instanceID, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return provider.ErrNotFound
+		}
+		return fmt.Errorf("get statefulset: %w", err)
+	}
+
+	if sts.Spec.Replicas != nil && *sts.Spec.Replicas == replicas {
+		return provider.ErrInvalidState
+	}
+
+	sts.Spec.Replicas = &replicas
+	if _, err := sets.Update(ctx, sts, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("scale statefulset: %w", err)
+	}
+	return nil
+}
+
+// Resize sets the container's resource requests and limits. The StatefulSet
+// controller restarts the pod to apply them; the PVC is kept.
+func (p *Provider) Resize(ctx context.Context, instanceID string, class provider.Class) error {
+	sets := p.cs.AppsV1().StatefulSets(p.opts.Namespace)
+	sts, err := sets.Get(ctx, instanceID, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return provider.ErrNotFound
+		}
+		return fmt.Errorf("get statefulset: %w", err)
+	}
+
+	containers := sts.Spec.Template.Spec.Containers
+	for i := range containers {
+		if containers[i].Name == containerName {
+			containers[i].Resources = resources(class)
+		}
+	}
+	if _, err := sets.Update(ctx, sts, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("update statefulset: %w", err)
+	}
+	return nil
+}
+
+// resources maps a class to equal requests and limits, so the pod is
+// scheduled with the capacity the class promises.
+func resources(class provider.Class) corev1.ResourceRequirements {
+	list := corev1.ResourceList{}
+	if class.CPUs > 0 {
+		list[corev1.ResourceCPU] = *resource.NewMilliQuantity(int64(class.CPUs*1000), resource.DecimalSI)
+	}
+	if class.MemoryMB > 0 {
+		list[corev1.ResourceMemory] = *resource.NewQuantity(class.MemoryMB<<20, resource.BinarySI)
+	}
+	if len(list) == 0 {
+		return corev1.ResourceRequirements{}
+	}
+	return corev1.ResourceRequirements{Requests: list, Limits: list}
+}
+
+// Activity reports pod readiness as health. With a live cluster connection it
+// also execs `ps` in the container and treats more than 4 processes
+// (supervisor + ttyd + agent + sleep) as active, matching the Docker provider.
+func (p *Provider) Activity(ctx context.Context, instanceID string) (*provider.ActivityInfo, error) {
+	pod, err := p.cs.CoreV1().Pods(p.opts.Namespace).Get(ctx, instanceID+"-0", metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, provider.ErrNotFound
+		}
+		return nil, fmt.Errorf("get pod: %w", err)
+	}
+
+	isHealthy := podReady(pod)
+	info := &provider.ActivityInfo{IsHealthy: isHealthy, IsActive: isHealthy}
+	if !isHealthy || p.config == nil {
+		return info, nil
+	}
+
+	count, err := p.countProcesses(ctx, pod.Name)
+	if err != nil {
+		return nil, fmt.Errorf("exec ps: %w", err)
+	}
+	info.ProcessCount = count
+	info.IsActive = count > 4
+	return info, nil
+}
+
+func (p *Provider) countProcesses(ctx context.Context, podName string) (int, error) {
+	req := p.cs.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(p.opts.Namespace).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: containerName,
+			Command:   []string{"ps", "-e", "--no-headers"},
+			Stdout:    true,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(p.config, "POST", req.URL())
+	if err != nil {
+		return 0, err
+	}
+	var stdout bytes.Buffer
+	if err := exec.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout}); err != nil {
+		return 0, err
+	}
+	return len(strings.Split(strings.TrimSpace(stdout.String()), "\n")), nil
+}
+
+// ListManaged returns all labeled StatefulSets and PVCs in the namespace.
+func (p *Provider) ListManaged(ctx context.Context) ([]provider.ManagedResource, error) {
+	selector := metav1.ListOptions{LabelSelector: labelPrefix + "managed=true"}
+
+	sets, err := p.cs.AppsV1().StatefulSets(p.opts.Namespace).List(ctx, selector)
+	if err != nil {
+		return nil, fmt.Errorf("list statefulsets: %w", err)
+	}
+	var out []provider.ManagedResource
+	for i := range sets.Items {
+		sts := &sets.Items[i]
+		userID, name, _ := instanceKey(sts.Labels)
+		out = append(out, provider.ManagedResource{
+			Kind:       provider.ResourceInstance,
+			ID:         sts.Name,
+			ProviderID: sts.Name,
+			UserID:     userID,
+			Name:       name,
+			Status:     stateOf(sts),
+		})
+	}
+
+	claims, err := p.cs.CoreV1().PersistentVolumeClaims(p.opts.Namespace).List(ctx, selector)
+	if err != nil {
+		return nil, fmt.Errorf("list pvcs: %w", err)
+	}
+	for _, pvc := range claims.Items {
+		userID, name, _ := instanceKey(pvc.Labels)
+		out = append(out, provider.ManagedResource{
+			Kind:   provider.ResourceVolume,
+			ID:     pvc.Name,
+			UserID: userID,
+			Name:   name,
+		})
+	}
+	return out, nil
+}
+
+// RemoveManaged removes a managed instance (keeping its PVC) or a PVC.
+func (p *Provider) RemoveManaged(ctx context.Context, res provider.ManagedResource) error {
+	switch res.Kind {
+	case provider.ResourceInstance:
+		return p.Destroy(ctx, res.ID)
+	case provider.ResourceVolume:
+		err := p.cs.CoreV1().PersistentVolumeClaims(p.opts.Namespace).Delete(ctx, res.ID, metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			return provider.ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("delete pvc: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported resource kind %q", res.Kind)
+	}
+}
+
+func stateOf(sts *appsv1.StatefulSet) provider.Status {
+	if sts.Spec.Replicas != nil && *sts.Spec.Replicas == 0 {
+		return provider.StatusStopped
+	}
+	if sts.Status.ReadyReplicas >= 1 {
+		return provider.StatusRunning
+	}
+	return provider.StatusProvisioning
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+func newTestProvider(t *testing.T) (*Provider, *fake.Clientset) {
+	t.Helper()
+	cs := fake.NewSimpleClientset()
+	return NewWithClient(cs, Options{Namespace: "test"}), cs
+}
+
+func TestCreate(t *testing.T) {
+	p, cs := newTestProvider(t)
+	ctx := context.Background()
+
+	inst, err := p.Create(ctx, 7, provider.CreateOptions{AgentSecret: "s3cret", AnthropicAPIKey: "sk-test"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if inst.ProviderID != "claude-7" || inst.Host != "claude-7.test.svc" || inst.VolumeID != "claude-data-7" {
+		t.Errorf("unexpected instance: %+v", inst)
+	}
+	if inst.Status != provider.StatusProvisioning {
+		t.Errorf("status = %q, want provisioning until the pod is ready", inst.Status)
+	}
+
+	sts, err := cs.AppsV1().StatefulSets("test").Get(ctx, "claude-7", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get statefulset: %v", err)
+	}
+	if *sts.Spec.Replicas != 1 {
+		t.Errorf("replicas = %d, want 1", *sts.Spec.Replicas)
+	}
+	vol := sts.Spec.Template.Spec.Volumes[0]
+	if vol.PersistentVolumeClaim == nil || vol.PersistentVolumeClaim.ClaimName != "claude-data-7" {
+		t.Errorf("pod volume = %+v, want claim claude-data-7", vol)
+	}
+
+	secret, err := cs.CoreV1().Secrets("test").Get(ctx, "claude-7-env", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get secret: %v", err)
+	}
+	if secret.StringData["AGENT_SECRET"] != "s3cret" || secret.StringData["ANTHROPIC_API_KEY"] != "sk-test" {
+		t.Errorf("secret data = %v", secret.StringData)
+	}
+	if _, err := cs.CoreV1().Services("test").Get(ctx, "claude-7", metav1.GetOptions{}); err != nil {
+		t.Errorf("get service: %v", err)
+	}
+
+	if _, err := p.Create(ctx, 7, provider.CreateOptions{}); !errors.Is(err, provider.ErrAlreadyExists) {
+		t.Errorf("second create: got %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestResize(t *testing.T) {
+	p, cs := newTestProvider(t)
+	ctx := context.Background()
+
+	if _, err := p.Create(ctx, 8, provider.CreateOptions{Class: provider.Class{Name: "small", CPUs: 2, MemoryMB: 4096}}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := p.Resize(ctx, "claude-8", provider.Class{Name: "medium", CPUs: 4, MemoryMB: 8192}); err != nil {
+		t.Fatalf("resize: %v", err)
+	}
+
+	sts, _ := cs.AppsV1().StatefulSets("test").Get(ctx, "claude-8", metav1.GetOptions{})
+	res := sts.Spec.Template.Spec.Containers[0].Resources
+	if cpu := res.Limits[corev1.ResourceCPU]; cpu.MilliValue() != 4000 {
+		t.Errorf("cpu limit = %s, want 4", cpu.String())
+	}
+	if mem := res.Requests[corev1.ResourceMemory]; mem.Value() != 8192<<20 {
+		t.Errorf("memory request = %s, want 8Gi", mem.String())
+	}
+	if err := p.Resize(ctx, "claude-9", provider.Class{}); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("resize missing: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestPauseWake(t *testing.T) {
+	p, cs := newTestProvider(t)
+	ctx := context.Background()
+
+	if _, err := p.Create(ctx, 1, provider.CreateOptions{}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if err := p.Pause(ctx, "claude-1"); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	st, _ := p.Status(ctx, "claude-1")
+	if st.Status != provider.StatusStopped {
+		t.Errorf("after pause: status = %q, want stopped", st.Status)
+	}
+	if err := p.Pause(ctx, "claude-1"); !errors.Is(err, provider.ErrInvalidState) {
+		t.Errorf("double pause: got %v, want ErrInvalidState", err)
+	}
+
+	if err := p.Wake(ctx, "claude-1"); err != nil {
+		t.Fatalf("wake: %v", err)
+	}
+
+	// Simulate the controller marking the pod ready
+	sts, _ := cs.AppsV1().StatefulSets("test").Get(ctx, "claude-1", metav1.GetOptions{})
+	sts.Status.ReadyReplicas = 1
+	cs.AppsV1().StatefulSets("test").UpdateStatus(ctx, sts, metav1.UpdateOptions{})
+
+	st, _ = p.Status(ctx, "claude-1")
+	if st.Status != provider.StatusRunning {
+		t.Errorf("after wake: status = %q, want running", st.Status)
+	}
+}
+
+func TestDestroyKeepsClaim(t *testing.T) {
+	p, cs := newTestProvider(t)
+	ctx := context.Background()
+
+	if _, err := p.Create(ctx, 3, provider.CreateOptions{}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := p.Destroy(ctx, "claude-3"); err != nil {
+		t.Fatalf("destroy: %v", err)
+	}
+
+	if _, err := p.Status(ctx, "claude-3"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("status after destroy: got %v, want ErrNotFound", err)
+	}
+	if _, err := cs.CoreV1().PersistentVolumeClaims("test").Get(ctx, "claude-data-3", metav1.GetOptions{}); err != nil {
+		t.Errorf("pvc should survive destroy: %v", err)
+	}
+	if err := p.Destroy(ctx, "claude-3"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("second destroy: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestActivity(t *testing.T) {
+	p, cs := newTestProvider(t)
+	ctx := context.Background()
+
+	if _, err := p.Activity(ctx, "claude-9"); !errors.Is(err, provider.ErrNotFound) {
+		t.Fatalf("activity without pod: got %v, want ErrNotFound", err)
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "claude-9-0", Namespace: "test"},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+			{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+		}},
+	}
+	cs.CoreV1().Pods("test").Create(ctx, pod, metav1.CreateOptions{})
+
+	info, err := p.Activity(ctx, "claude-9")
+	if err != nil {
+		t.Fatalf("activity: %v", err)
+	}
+	if !info.IsHealthy || !info.IsActive {
+		t.Errorf("info = %+v, want healthy and active", info)
+	}
+}
+
+func TestListManaged(t *testing.T) {
+	p, _ := newTestProvider(t)
+	ctx := context.Background()
+
+	p.Create(ctx, 1, provider.CreateOptions{})
+	p.Create(ctx, 2, provider.CreateOptions{})
+	p.Destroy(ctx, "claude-2")
+
+	res, err := p.ListManaged(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+
+	var instances, volumes int
+	for _, r := range res {
+		switch r.Kind {
+		case provider.ResourceInstance:
+			instances++
+		case provider.ResourceVolume:
+			volumes++
+		}
+	}
+	if instances != 1 || volumes != 2 {
+		t.Errorf("got %d instances and %d volumes, want 1 and 2", instances, volumes)
+	}
+
+	if err := p.RemoveManaged(ctx, provider.ManagedResource{Kind: provider.ResourceVolume, ID: "claude-data-2"}); err != nil {
+		t.Errorf("remove volume: %v", err)
+	}
+}
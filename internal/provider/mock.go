@@ -1,8 +1,12 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -11,13 +15,22 @@ import (
 type MockProvisioner struct {
 	mu        sync.Mutex
 	instances map[string]*Instance
-	inactive  map[string]bool // tracks instances marked as inactive for testing
+	volumes   map[string]int           // volume name → user ID; survives Destroy like Docker volumes
+	data      map[string][]byte        // volume name → archived contents for Export/ImportVolume
+	classes   map[string]Class         // instance ID → class from Create or Resize
+	options   map[string]CreateOptions // instance ID → options from Create or Claim
+	inactive  map[string]bool          // tracks instances marked as inactive for testing
+	warmSeq   int
 }
 
 // NewMock creates a new MockProvisioner.
 func NewMock() *MockProvisioner {
 	return &MockProvisioner{
 		instances: make(map[string]*Instance),
+		volumes:   make(map[string]int),
+		data:      make(map[string][]byte),
+		classes:   make(map[string]Class),
+		options:   make(map[string]CreateOptions),
 		inactive:  make(map[string]bool),
 	}
 }
@@ -26,7 +39,8 @@ func (m *MockProvisioner) Create(ctx context.Context, userID int, opts CreateOpt
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	id := fmt.Sprintf("mock-%d", userID)
+	key := InstanceKey(userID, opts.Name)
+	id := "mock-" + key
 	if _, exists := m.instances[id]; exists {
 		return nil, ErrAlreadyExists
 	}
@@ -39,14 +53,70 @@ func (m *MockProvisioner) Create(ctx context.Context, userID int, opts CreateOpt
 		Host:       "localhost",
 		Port:       8080,
 		Status:     StatusRunning,
-		VolumeID:   fmt.Sprintf("mock-vol-%d", userID),
+		VolumeID:   "mock-vol-" + key,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
 	m.instances[id] = inst
+	m.classes[id] = opts.Class
+	m.options[id] = opts
+	m.volumes[inst.VolumeID] = userID
 	return inst, nil
 }
 
+// CreateWarm boots an unassigned instance with ID "mock-warm-N".
+func (m *MockProvisioner) CreateWarm(ctx context.Context, class Class) (*Instance, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.warmSeq++
+	id := fmt.Sprintf("mock-warm-%d", m.warmSeq)
+	inst := &Instance{
+		ID:         id,
+		Provider:   "mock",
+		ProviderID: id,
+		Host:       "localhost",
+		Port:       8080,
+		Status:     StatusRunning,
+		VolumeID:   "mock-vol-warm-" + strconv.Itoa(m.warmSeq),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	m.instances[id] = inst
+	m.classes[id] = class
+	return inst, nil
+}
+
+// Claim renames a warm instance to the ID Create would have given it.
+func (m *MockProvisioner) Claim(ctx context.Context, warmID string, userID int, opts CreateOptions) (*Instance, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	warm, ok := m.instances[warmID]
+	if !ok || !strings.HasPrefix(warmID, "mock-warm-") {
+		return nil, ErrNotFound
+	}
+	key := InstanceKey(userID, opts.Name)
+	id := "mock-" + key
+	if _, exists := m.instances[id]; exists {
+		return nil, ErrAlreadyExists
+	}
+
+	inst := *warm
+	inst.ID = id
+	inst.ProviderID = id
+	inst.UserID = userID
+	inst.VolumeID = "mock-vol-" + key
+	inst.UpdatedAt = time.Now()
+	m.instances[id] = &inst
+	m.classes[id] = m.classes[warmID]
+	m.options[id] = opts
+	m.volumes[inst.VolumeID] = userID
+	delete(m.instances, warmID)
+	delete(m.classes, warmID)
+	return &inst, nil
+}
+
 func (m *MockProvisioner) Destroy(ctx context.Context, instanceID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -115,9 +185,132 @@ func (m *MockProvisioner) Activity(ctx context.Context, instanceID string) (*Act
 	return &ActivityInfo{IsActive: true, IsHealthy: true, ProcessCount: 5}, nil
 }
 
+func (m *MockProvisioner) Resize(ctx context.Context, instanceID string, class Class) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.instances[instanceID]; !ok {
+		return ErrNotFound
+	}
+	m.classes[instanceID] = class
+	return nil
+}
+
+// Class returns the class an instance was created or last resized with.
+func (m *MockProvisioner) Class(instanceID string) Class {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.classes[instanceID]
+}
+
+// Options returns the options an instance was created or claimed with.
+func (m *MockProvisioner) Options(instanceID string) CreateOptions {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.options[instanceID]
+}
+
 // SetInactive marks an instance as inactive for testing.
 func (m *MockProvisioner) SetInactive(instanceID string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.inactive[instanceID] = true
 }
+
+func (m *MockProvisioner) ListManaged(ctx context.Context) ([]ManagedResource, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out []ManagedResource
+	for id, inst := range m.instances {
+		if strings.HasPrefix(id, "mock-warm-") {
+			continue // owned by the warm pool
+		}
+		_, name, _ := ParseInstanceKey(strings.TrimPrefix(id, "mock-"))
+		out = append(out, ManagedResource{
+			Kind:       ResourceInstance,
+			ID:         id,
+			ProviderID: inst.ProviderID,
+			UserID:     inst.UserID,
+			Name:       name,
+			Status:     inst.Status,
+		})
+	}
+	for id, userID := range m.volumes {
+		_, name, _ := ParseInstanceKey(strings.TrimPrefix(id, "mock-vol-"))
+		out = append(out, ManagedResource{Kind: ResourceVolume, ID: id, UserID: userID, Name: name})
+	}
+	return out, nil
+}
+
+func (m *MockProvisioner) RemoveManaged(ctx context.Context, res ManagedResource) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch res.Kind {
+	case ResourceInstance:
+		if _, ok := m.instances[res.ID]; !ok {
+			return ErrNotFound
+		}
+		delete(m.instances, res.ID)
+	case ResourceVolume:
+		if _, ok := m.volumes[res.ID]; !ok {
+			return ErrNotFound
+		}
+		delete(m.volumes, res.ID)
+	default:
+		return fmt.Errorf("unsupported resource kind %q", res.Kind)
+	}
+	return nil
+}
+
+// SetStatus overrides an instance's provider-side status for testing drift.
+func (m *MockProvisioner) SetStatus(instanceID string, status Status) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if inst, ok := m.instances[instanceID]; ok {
+		inst.Status = status
+	}
+}
+
+func (m *MockProvisioner) ExportVolume(ctx context.Context, volumeID string, w io.Writer) error {
+	m.mu.Lock()
+	if _, ok := m.volumes[volumeID]; !ok {
+		m.mu.Unlock()
+		return ErrNotFound
+	}
+	data := m.data[volumeID]
+	m.mu.Unlock()
+
+	_, err := w.Write(data)
+	return err
+}
+
+func (m *MockProvisioner) ImportVolume(ctx context.Context, volumeID string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.volumes[volumeID]; !ok {
+		return ErrNotFound
+	}
+	m.data[volumeID] = data
+	return nil
+}
+
+// SetVolumeData replaces a volume's contents for testing backups.
+func (m *MockProvisioner) SetVolumeData(volumeID string, data []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[volumeID] = bytes.Clone(data)
+}
+
+// VolumeData returns a volume's current contents for testing restores.
+func (m *MockProvisioner) VolumeData(volumeID string) []byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return bytes.Clone(m.data[volumeID])
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultInstanceName is the name of a user's first instance. Its resources
+// keep the original per-user names (claude-{userID}, claude-data-{userID}) so
+// instances created before named instances existed are still found.
+const DefaultInstanceName = "default"
+
+// instanceNameRE keeps names valid as DNS labels, Kubernetes object names and
+// Hetzner label values once prefixed. Names start with a letter so they are
+// never mistaken for a numeric instance ID.
+var instanceNameRE = regexp.MustCompile(`^[a-z]([a-z0-9-]{0,30}[a-z0-9])?$`)
+
+// ValidInstanceName reports whether name may be used as an instance name:
+// 1-32 lowercase letters, digits and hyphens, starting with a letter and not
+// ending with a hyphen.
+func ValidInstanceName(name string) bool {
+	return instanceNameRE.MatchString(name)
+}
+
+// InstanceKey returns the suffix providers use to name an instance's
+// resources: "{userID}" for the default instance and "{userID}-{name}" for
+// any other. An empty name means the default instance.
+func InstanceKey(userID int, name string) string {
+	if name == "" || name == DefaultInstanceName {
+		return strconv.Itoa(userID)
+	}
+	return fmt.Sprintf("%d-%s", userID, name)
+}
+
+// ParseInstanceKey splits a key produced by InstanceKey into the user ID and
+// instance name.
+func ParseInstanceKey(key string) (int, string, error) {
+	idPart, name, hasName := strings.Cut(key, "-")
+	userID, err := strconv.Atoi(idPart)
+	if err != nil || userID <= 0 {
+		return 0, "", fmt.Errorf("invalid instance key %q", key)
+	}
+	if !hasName {
+		return userID, DefaultInstanceName, nil
+	}
+	if !ValidInstanceName(name) {
+		return 0, "", fmt.Errorf("invalid instance key %q", key)
+	}
+	return userID, name, nil
+}
@@ -0,0 +1,642 @@
+package podman
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+const (
+	networkName = "claude-net"
+	imageTag    = "claude-instance:latest"
+	labelPrefix = "cloudcode."
+
+	// apiPrefix pins the libpod API version; Podman 4.x and 5.x both serve it.
+	apiPrefix = "/v4.0.0/libpod"
+)
+
+// Options configures the Podman provider.
+type Options struct {
+	// Socket is the Podman API socket path or unix:// URL. Empty uses
+	// $CONTAINER_HOST, then the rootless default under $XDG_RUNTIME_DIR.
+	Socket string
+
+	// UserNS is the user namespace mode for instance containers
+	// ("keep-id", "auto", "nomap"). Defaults to "keep-id".
+	UserNS string
+}
+
+// Provider implements provider.Provisioner using Podman's libpod REST API.
+// It is intended for rootless Podman: containers run in a user namespace and
+// no root daemon or Docker socket is required.
+type Provider struct {
+	http    *http.Client
+	baseURL string
+	userns  string
+}
+
+// New creates a new Podman provider connected to the API socket.
+// Eagerly ensures the claude-net network exists, like the Docker provider.
+func New(opts Options) (*Provider, error) {
+	socket, err := socketPath(opts.Socket)
+	if err != nil {
+		return nil, err
+	}
+
+	hc := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+	p := NewWithClient(hc, "http://podman", opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := p.do(ctx, http.MethodGet, "/_ping", nil, nil, nil); err != nil {
+		return nil, fmt.Errorf("podman ping %s: %w", socket, err)
+	}
+	if err := p.ensureNetwork(ctx); err != nil {
+		return nil, fmt.Errorf("ensure network on startup: %w", err)
+	}
+	return p, nil
+}
+
+// NewWithClient creates a provider that talks to the API at baseURL using hc.
+// Used by tests to point the provider at a fake Podman server.
+func NewWithClient(hc *http.Client, baseURL string, opts Options) *Provider {
+	if opts.UserNS == "" {
+		opts.UserNS = "keep-id"
+	}
+	return &Provider{
+		http:    hc,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		userns:  opts.UserNS,
+	}
+}
+
+func socketPath(socket string) (string, error) {
+	if socket == "" {
+		socket = os.Getenv("CONTAINER_HOST")
+	}
+	if socket == "" {
+		runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+		if runtimeDir == "" {
+			runtimeDir = fmt.Sprintf("/run/user/%d", os.Getuid())
+		}
+		socket = filepath.Join(runtimeDir, "podman", "podman.sock")
+	}
+	if strings.Contains(socket, "://") {
+		u, err := url.Parse(socket)
+		if err != nil {
+			return "", fmt.Errorf("parse podman socket %q: %w", socket, err)
+		}
+		if u.Scheme != "unix" {
+			return "", fmt.Errorf("unsupported podman socket scheme %q (only unix)", u.Scheme)
+		}
+		socket = u.Path
+	}
+	return socket, nil
+}
+
+// containerName and volumeName take a provider.InstanceKey, so the default
+// instance keeps the original claude-{userID} names.
+func containerName(key string) string {
+	return "claude-" + key
+}
+
+func volumeName(key string) string {
+	return "claude-data-" + key
+}
+
+func labels(userID int, name string) map[string]string {
+	if name == "" {
+		name = provider.DefaultInstanceName
+	}
+	return map[string]string{
+		labelPrefix + "managed":       "true",
+		labelPrefix + "user_id":       strconv.Itoa(userID),
+		labelPrefix + "instance_name": name,
+	}
+}
+
+// instanceFromLabels returns the owner and instance name recorded on a
+// container or volume; unnamed resources belong to the default instance.
+func instanceFromLabels(l map[string]string) (int, string) {
+	userID, _ := strconv.Atoi(l[labelPrefix+"user_id"])
+	name := l[labelPrefix+"instance_name"]
+	if name == "" {
+		name = provider.DefaultInstanceName
+	}
+	return userID, name
+}
+
+// Libpod API payloads. Only the fields this provider uses are declared.
+
+type namespace struct {
+	NSMode string `json:"nsmode"`
+}
+
+type namedVolume struct {
+	Name string `json:"Name"`
+	Dest string `json:"Dest"`
+}
+
+type containerSpec struct {
+	Name            string              `json:"name"`
+	Image           string              `json:"image"`
+	Env             map[string]string   `json:"env,omitempty"`
+	Labels          map[string]string   `json:"labels"`
+	Volumes         []namedVolume       `json:"volumes"`
+	NetNS           namespace           `json:"netns"`
+	Networks        map[string]struct{} `json:"Networks"`
+	UserNS          namespace           `json:"userns"`
+	NoNewPrivileges bool                `json:"no_new_privileges"`
+	RestartPolicy   string              `json:"restart_policy"`
+	ResourceLimits  *linuxResources     `json:"resource_limits,omitempty"`
+}
+
+// linuxResources is the subset of the OCI LinuxResources the provider sets,
+// used both in create specs and as the body of a container update.
+type linuxResources struct {
+	CPU    *cpuLimits    `json:"cpu,omitempty"`
+	Memory *memoryLimits `json:"memory,omitempty"`
+}
+
+type cpuLimits struct {
+	Quota  int64  `json:"quota"`
+	Period uint64 `json:"period"`
+}
+
+type memoryLimits struct {
+	Limit int64 `json:"limit"`
+	Swap  int64 `json:"swap"`
+}
+
+type containerInspect struct {
+	ID    string `json:"Id"`
+	Name  string `json:"Name"`
+	State struct {
+		Status  string `json:"Status"`
+		Running bool   `json:"Running"`
+		Health  *struct {
+			Status string `json:"Status"`
+		} `json:"Health,omitempty"`
+	} `json:"State"`
+	Config struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+}
+
+type containerSummary struct {
+	ID     string            `json:"Id"`
+	Names  []string          `json:"Names"`
+	State  string            `json:"State"`
+	Labels map[string]string `json:"Labels"`
+}
+
+type volumeSummary struct {
+	Name   string            `json:"Name"`
+	Labels map[string]string `json:"Labels"`
+}
+
+// Create provisions a new Claude instance for the given user.
+// Podman ignores the Netbird setup key, like Docker.
+func (p *Provider) Create(ctx context.Context, userID int, opts provider.CreateOptions) (*provider.Instance, error) {
+	key := provider.InstanceKey(userID, opts.Name)
+	name := containerName(key)
+	volName := volumeName(key)
+
+	exists, err := p.exists(ctx, "/containers/"+name+"/exists")
+	if err != nil {
+		return nil, fmt.Errorf("check container: %w", err)
+	}
+	if exists {
+		return nil, provider.ErrAlreadyExists
+	}
+
+	if err := p.ensureNetwork(ctx); err != nil {
+		return nil, fmt.Errorf("ensure network: %w", err)
+	}
+	if err := p.ensureVolume(ctx, volName, userID, opts.Name); err != nil {
+		return nil, fmt.Errorf("ensure volume: %w", err)
+	}
+
+	env := make(map[string]string)
+	if opts.AgentSecret != "" {
+		env["AGENT_SECRET"] = opts.AgentSecret
+	}
+	if opts.AnthropicAPIKey != "" {
+		env["ANTHROPIC_API_KEY"] = opts.AnthropicAPIKey
+	}
+	if opts.ClaudeOAuthToken != "" {
+		env["CLAUDE_CODE_OAUTH_TOKEN"] = opts.ClaudeOAuthToken
+	}
+	maps.Copy(env, opts.Environment.Vars())
+
+	ref := imageTag
+	if opts.Environment.Image != "" {
+		ref = opts.Environment.Image
+		if err := p.ensureImage(ctx, ref); err != nil {
+			return nil, fmt.Errorf("ensure image: %w", err)
+		}
+	}
+
+	spec := containerSpec{
+		Name:            name,
+		Image:           ref,
+		Env:             env,
+		Labels:          labels(userID, opts.Name),
+		Volumes:         []namedVolume{{Name: volName, Dest: "/claude-data"}},
+		NetNS:           namespace{NSMode: "bridge"},
+		Networks:        map[string]struct{}{networkName: {}},
+		UserNS:          namespace{NSMode: p.userns},
+		NoNewPrivileges: true,
+		RestartPolicy:   "unless-stopped",
+		ResourceLimits:  resources(opts.Class),
+	}
+	var created struct {
+		ID string `json:"Id"`
+	}
+	if err := p.do(ctx, http.MethodPost, "/containers/create", nil, spec, &created); err != nil {
+		return nil, fmt.Errorf("create container: %w", err)
+	}
+
+	if err := p.do(ctx, http.MethodPost, "/containers/"+created.ID+"/start", nil, nil, nil); err != nil {
+		return nil, fmt.Errorf("start container: %w", err)
+	}
+
+	return &provider.Instance{
+		ID:         name,
+		UserID:     userID,
+		Provider:   "podman",
+		ProviderID: created.ID,
+		Host:       name, // reachable by container name on claude-net
+		Status:     provider.StatusRunning,
+		VolumeID:   volName,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}, nil
+}
+
+// Destroy removes the container but preserves the data volume.
+func (p *Provider) Destroy(ctx context.Context, instanceID string) error {
+	info, err := p.inspect(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+
+	if info.State.Running {
+		if err := p.stop(ctx, instanceID, 10); err != nil {
+			return fmt.Errorf("stop: %w", err)
+		}
+	}
+
+	if err := p.do(ctx, http.MethodDelete, "/containers/"+instanceID, nil, nil, nil); err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	return nil
+}
+
+// Status returns the current state of the instance.
+func (p *Provider) Status(ctx context.Context, instanceID string) (*provider.Instance, error) {
+	info, err := p.inspect(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, instName := instanceFromLabels(info.Config.Labels)
+	name := strings.TrimPrefix(info.Name, "/")
+
+	return &provider.Instance{
+		ID:         name,
+		UserID:     userID,
+		Provider:   "podman",
+		ProviderID: info.ID,
+		Host:       name,
+		Status:     mapPodmanState(info.State.Status),
+		VolumeID:   volumeName(provider.InstanceKey(userID, instName)),
+	}, nil
+}
+
+// Pause stops the container without removing it.
+func (p *Provider) Pause(ctx context.Context, instanceID string) error {
+	info, err := p.inspect(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+
+	if !info.State.Running {
+		return provider.ErrInvalidState
+	}
+
+	if err := p.stop(ctx, instanceID, 30); err != nil {
+		return fmt.Errorf("stop: %w", err)
+	}
+	return nil
+}
+
+// Wake starts a previously stopped container.
+func (p *Provider) Wake(ctx context.Context, instanceID string) error {
+	info, err := p.inspect(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+
+	if info.State.Running {
+		return provider.ErrInvalidState
+	}
+
+	if err := p.do(ctx, http.MethodPost, "/containers/"+instanceID+"/start", nil, nil, nil); err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+	return nil
+}
+
+// Resize updates the container's CPU and memory limits in place.
+func (p *Provider) Resize(ctx context.Context, instanceID string, class provider.Class) error {
+	r := resources(class)
+	if r == nil {
+		r = &linuxResources{}
+	}
+	if err := p.do(ctx, http.MethodPost, "/containers/"+instanceID+"/update", nil, r, nil); err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("update container: %w", err)
+	}
+	return nil
+}
+
+// cpuPeriod is the CFS period (µs) CPU quotas are expressed against.
+const cpuPeriod = 100000
+
+// resources maps a class to container limits, or nil for the zero class.
+// As with Docker, swap is capped at the memory limit.
+func resources(class provider.Class) *linuxResources {
+	var r linuxResources
+	if class.CPUs > 0 {
+		r.CPU = &cpuLimits{Quota: int64(class.CPUs * cpuPeriod), Period: cpuPeriod}
+	}
+	if class.MemoryMB > 0 {
+		r.Memory = &memoryLimits{Limit: class.MemoryMB << 20, Swap: class.MemoryMB << 20}
+	}
+	if r.CPU == nil && r.Memory == nil {
+		return nil
+	}
+	return &r
+}
+
+// Activity checks if the container has active processes beyond the base set.
+// Same heuristic as Docker: active if process count > 4, and never active
+// while the container's healthcheck reports unhealthy.
+func (p *Provider) Activity(ctx context.Context, instanceID string) (*provider.ActivityInfo, error) {
+	var top struct {
+		Processes [][]string `json:"Processes"`
+	}
+	if err := p.do(ctx, http.MethodGet, "/containers/"+instanceID+"/top", nil, nil, &top); err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("container top: %w", err)
+	}
+
+	processCount := len(top.Processes)
+	isActive := processCount > 4
+
+	isHealthy := true
+	info, err := p.inspect(ctx, instanceID)
+	if err == nil && info.State.Health != nil && info.State.Health.Status != "" {
+		isHealthy = info.State.Health.Status == "healthy"
+		if !isHealthy {
+			isActive = false
+		}
+	}
+
+	return &provider.ActivityInfo{
+		IsActive:     isActive,
+		IsHealthy:    isHealthy,
+		ProcessCount: processCount,
+	}, nil
+}
+
+// ListManaged returns all containers and volumes labeled cloudcode.managed.
+func (p *Provider) ListManaged(ctx context.Context) ([]provider.ManagedResource, error) {
+	managed := managedFilter()
+
+	var containers []containerSummary
+	q := url.Values{"all": {"true"}, "filters": {managed}}
+	if err := p.do(ctx, http.MethodGet, "/containers/json", q, nil, &containers); err != nil {
+		return nil, fmt.Errorf("list containers: %w", err)
+	}
+
+	var out []provider.ManagedResource
+	for _, c := range containers {
+		userID, name := instanceFromLabels(c.Labels)
+		out = append(out, provider.ManagedResource{
+			Kind:       provider.ResourceInstance,
+			ID:         c.ID,
+			ProviderID: c.ID,
+			UserID:     userID,
+			Name:       name,
+			Status:     mapPodmanState(c.State),
+		})
+	}
+
+	var vols []volumeSummary
+	if err := p.do(ctx, http.MethodGet, "/volumes/json", url.Values{"filters": {managed}}, nil, &vols); err != nil {
+		return nil, fmt.Errorf("list volumes: %w", err)
+	}
+	for _, v := range vols {
+		userID, name := instanceFromLabels(v.Labels)
+		out = append(out, provider.ManagedResource{
+			Kind:   provider.ResourceVolume,
+			ID:     v.Name,
+			UserID: userID,
+			Name:   name,
+		})
+	}
+
+	return out, nil
+}
+
+// RemoveManaged removes a managed container (stopping it first) or volume.
+func (p *Provider) RemoveManaged(ctx context.Context, res provider.ManagedResource) error {
+	switch res.Kind {
+	case provider.ResourceInstance:
+		return p.Destroy(ctx, res.ID)
+	case provider.ResourceVolume:
+		if err := p.do(ctx, http.MethodDelete, "/volumes/"+res.ID, nil, nil, nil); err != nil {
+			if errors.Is(err, provider.ErrNotFound) {
+				return err
+			}
+			return fmt.Errorf("remove volume: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported resource kind %q", res.Kind)
+	}
+}
+
+func (p *Provider) ensureNetwork(ctx context.Context) error {
+	exists, err := p.exists(ctx, "/networks/"+networkName+"/exists")
+	if err != nil || exists {
+		return err
+	}
+
+	body := map[string]any{
+		"name":   networkName,
+		"driver": "bridge",
+		"labels": map[string]string{labelPrefix + "managed": "true"},
+	}
+	return p.do(ctx, http.MethodPost, "/networks/create", nil, body, nil)
+}
+
+// ensureImage pulls ref unless it is already in local storage.
+func (p *Provider) ensureImage(ctx context.Context, ref string) error {
+	exists, err := p.exists(ctx, "/images/"+ref+"/exists")
+	if err != nil || exists {
+		return err
+	}
+
+	// In quiet mode the pull stream carries a single report once it is done
+	var report struct {
+		Error string `json:"error"`
+	}
+	q := url.Values{"reference": {ref}, "quiet": {"true"}}
+	if err := p.do(ctx, http.MethodPost, "/images/pull", q, nil, &report); err != nil {
+		return err
+	}
+	if report.Error != "" {
+		return fmt.Errorf("pull %s: %s", ref, report.Error)
+	}
+	return nil
+}
+
+func (p *Provider) ensureVolume(ctx context.Context, volName string, userID int, name string) error {
+	exists, err := p.exists(ctx, "/volumes/"+volName+"/exists")
+	if err != nil || exists {
+		return err
+	}
+
+	body := map[string]any{
+		"Name":  volName,
+		"Label": labels(userID, name),
+	}
+	return p.do(ctx, http.MethodPost, "/volumes/create", nil, body, nil)
+}
+
+func (p *Provider) inspect(ctx context.Context, instanceID string) (*containerInspect, error) {
+	var info containerInspect
+	if err := p.do(ctx, http.MethodGet, "/containers/"+instanceID+"/json", nil, nil, &info); err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("inspect: %w", err)
+	}
+	return &info, nil
+}
+
+func (p *Provider) stop(ctx context.Context, instanceID string, timeout int) error {
+	q := url.Values{"timeout": {strconv.Itoa(timeout)}}
+	return p.do(ctx, http.MethodPost, "/containers/"+instanceID+"/stop", q, nil, nil)
+}
+
+// exists calls one of libpod's .../exists endpoints (204 = yes, 404 = no).
+func (p *Provider) exists(ctx context.Context, path string) (bool, error) {
+	err := p.do(ctx, http.MethodGet, path, nil, nil, nil)
+	if errors.Is(err, provider.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// do sends a libpod API request. A 404 maps to provider.ErrNotFound and a
+// 304 (already started/stopped) counts as success.
+func (p *Provider) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	u := p.baseURL + apiPrefix + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return provider.ErrNotFound
+	case resp.StatusCode == http.StatusNotModified:
+		return nil
+	case resp.StatusCode >= 300:
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(raw, &apiErr) != nil || apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(raw))
+		}
+		return fmt.Errorf("podman %s %s: %s (HTTP %d)", method, path, apiErr.Message, resp.StatusCode)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s response: %w", path, err)
+	}
+	return nil
+}
+
+func managedFilter() string {
+	b, _ := json.Marshal(map[string][]string{"label": {labelPrefix + "managed=true"}})
+	return string(b)
+}
+
+func mapPodmanState(state string) provider.Status {
+	switch state {
+	case "running":
+		return provider.StatusRunning
+	case "created", "configured", "initialized", "restarting":
+		return provider.StatusProvisioning
+	case "paused", "exited", "stopped", "dead":
+		return provider.StatusStopped
+	default:
+		return provider.StatusError
+	}
+}
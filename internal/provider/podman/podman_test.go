@@ -0,0 +1,516 @@
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+// fakePodman is an in-memory stand-in for the libpod REST API, covering the
+// endpoints the provider calls.
+type fakePodman struct {
+	mu         sync.Mutex
+	nextID     int
+	containers map[string]*fakeContainer // by ID
+	volumes    map[string]map[string]string
+	networks   map[string]bool
+	specs      []containerSpec
+	updates    map[string]linuxResources // by container name or ID as requested
+	processes  int
+}
+
+type fakeContainer struct {
+	id     string
+	name   string
+	state  string
+	labels map[string]string
+}
+
+func newFakePodman(t *testing.T) (*fakePodman, *Provider) {
+	t.Helper()
+	f := &fakePodman{
+		containers: make(map[string]*fakeContainer),
+		volumes:    make(map[string]map[string]string),
+		networks:   make(map[string]bool),
+		updates:    make(map[string]linuxResources),
+		processes:  3,
+	}
+	srv := httptest.NewServer(f.routes())
+	t.Cleanup(srv.Close)
+	return f, NewWithClient(srv.Client(), srv.URL, Options{})
+}
+
+// lookup resolves a container by ID or name. Caller holds mu.
+func (f *fakePodman) lookup(ref string) *fakeContainer {
+	if c, ok := f.containers[ref]; ok {
+		return c
+	}
+	for _, c := range f.containers {
+		if c.name == ref {
+			return c
+		}
+	}
+	return nil
+}
+
+func (f *fakePodman) routes() http.Handler {
+	mux := http.NewServeMux()
+	api := apiPrefix
+
+	notFound := func(w http.ResponseWriter, what string) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]any{"message": "no such " + what, "response": 404})
+	}
+	writeJSON := func(w http.ResponseWriter, status int, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(v)
+	}
+
+	mux.HandleFunc("GET "+api+"/_ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	})
+
+	mux.HandleFunc("GET "+api+"/networks/{name}/exists", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if !f.networks[r.PathValue("name")] {
+			notFound(w, "network")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST "+api+"/networks/create", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Name string `json:"name"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.mu.Lock()
+		f.networks[body.Name] = true
+		f.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]string{"name": body.Name})
+	})
+
+	mux.HandleFunc("GET "+api+"/volumes/{name}/exists", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.volumes[r.PathValue("name")]; !ok {
+			notFound(w, "volume")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST "+api+"/volumes/create", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Name  string            `json:"Name"`
+			Label map[string]string `json:"Label"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.mu.Lock()
+		f.volumes[body.Name] = body.Label
+		f.mu.Unlock()
+		writeJSON(w, http.StatusCreated, map[string]string{"Name": body.Name})
+	})
+	mux.HandleFunc("GET "+api+"/volumes/json", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		out := []volumeSummary{}
+		for name, labels := range f.volumes {
+			if labels[labelPrefix+"managed"] == "true" {
+				out = append(out, volumeSummary{Name: name, Labels: labels})
+			}
+		}
+		writeJSON(w, http.StatusOK, out)
+	})
+	mux.HandleFunc("DELETE "+api+"/volumes/{name}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		name := r.PathValue("name")
+		if _, ok := f.volumes[name]; !ok {
+			notFound(w, "volume")
+			return
+		}
+		for _, c := range f.containers {
+			for _, s := range f.specs {
+				if s.Name == c.name && s.Volumes[0].Name == name {
+					writeJSON(w, http.StatusConflict, map[string]string{"message": "volume is being used"})
+					return
+				}
+			}
+		}
+		delete(f.volumes, name)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("GET "+api+"/containers/{name}/exists", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.lookup(r.PathValue("name")) == nil {
+			notFound(w, "container")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST "+api+"/containers/create", func(w http.ResponseWriter, r *http.Request) {
+		var spec containerSpec
+		if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.lookup(spec.Name) != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"message": "name already in use"})
+			return
+		}
+		if !f.networks[networkName] {
+			notFound(w, "network")
+			return
+		}
+		f.nextID++
+		id := fmt.Sprintf("%064x", f.nextID)
+		f.containers[id] = &fakeContainer{id: id, name: spec.Name, state: "created", labels: spec.Labels}
+		f.specs = append(f.specs, spec)
+		writeJSON(w, http.StatusCreated, map[string]any{"Id": id, "Warnings": []string{}})
+	})
+	mux.HandleFunc("POST "+api+"/containers/{name}/start", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		c := f.lookup(r.PathValue("name"))
+		if c == nil {
+			notFound(w, "container")
+			return
+		}
+		if c.state == "running" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		c.state = "running"
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST "+api+"/containers/{name}/update", func(w http.ResponseWriter, r *http.Request) {
+		var res linuxResources
+		json.NewDecoder(r.Body).Decode(&res)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.lookup(r.PathValue("name")) == nil {
+			notFound(w, "container")
+			return
+		}
+		f.updates[r.PathValue("name")] = res
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("POST "+api+"/containers/{name}/stop", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		c := f.lookup(r.PathValue("name"))
+		if c == nil {
+			notFound(w, "container")
+			return
+		}
+		if c.state != "running" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		c.state = "exited"
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE "+api+"/containers/{name}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		c := f.lookup(r.PathValue("name"))
+		if c == nil {
+			notFound(w, "container")
+			return
+		}
+		if c.state == "running" {
+			writeJSON(w, http.StatusConflict, map[string]string{"message": "container is running"})
+			return
+		}
+		delete(f.containers, c.id)
+		for i, s := range f.specs {
+			if s.Name == c.name {
+				f.specs = append(f.specs[:i], f.specs[i+1:]...)
+				break
+			}
+		}
+		writeJSON(w, http.StatusOK, []map[string]string{{"Id": c.id}})
+	})
+	mux.HandleFunc("GET "+api+"/containers/{name}/json", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		c := f.lookup(r.PathValue("name"))
+		if c == nil {
+			notFound(w, "container")
+			return
+		}
+		var info containerInspect
+		info.ID = c.id
+		info.Name = c.name
+		info.State.Status = c.state
+		info.State.Running = c.state == "running"
+		info.Config.Labels = c.labels
+		writeJSON(w, http.StatusOK, info)
+	})
+	mux.HandleFunc("GET "+api+"/containers/{name}/top", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		c := f.lookup(r.PathValue("name"))
+		if c == nil {
+			notFound(w, "container")
+			return
+		}
+		if c.state != "running" {
+			writeJSON(w, http.StatusConflict, map[string]string{"message": "container is not running"})
+			return
+		}
+		procs := make([][]string, f.processes)
+		for i := range procs {
+			procs[i] = []string{"claude", fmt.Sprint(i + 1)}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"Titles": []string{"USER", "PID"}, "Processes": procs})
+	})
+	mux.HandleFunc("GET "+api+"/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		var filters map[string][]string
+		json.Unmarshal([]byte(r.URL.Query().Get("filters")), &filters)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		out := []containerSummary{}
+		for _, c := range f.containers {
+			keep := true
+			for _, lf := range filters["label"] {
+				k, v, _ := strings.Cut(lf, "=")
+				if c.labels[k] != v {
+					keep = false
+				}
+			}
+			if keep {
+				out = append(out, containerSummary{ID: c.id, Names: []string{c.name}, State: c.state, Labels: c.labels})
+			}
+		}
+		writeJSON(w, http.StatusOK, out)
+	})
+
+	return mux
+}
+
+func TestCreate(t *testing.T) {
+	f, p := newFakePodman(t)
+	ctx := context.Background()
+
+	inst, err := p.Create(ctx, 5, provider.CreateOptions{AgentSecret: "s3cret", ClaudeOAuthToken: "oauth"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if inst.ID != "claude-5" || inst.Host != "claude-5" || inst.VolumeID != "claude-data-5" || inst.Provider != "podman" {
+		t.Errorf("unexpected instance: %+v", inst)
+	}
+	if inst.Status != provider.StatusRunning {
+		t.Errorf("status = %q, want running", inst.Status)
+	}
+
+	if !f.networks[networkName] {
+		t.Error("claude-net network was not created")
+	}
+	if f.volumes["claude-data-5"][labelPrefix+"user_id"] != "5" {
+		t.Errorf("volume labels = %v", f.volumes["claude-data-5"])
+	}
+
+	spec := f.specs[0]
+	if spec.UserNS.NSMode != "keep-id" {
+		t.Errorf("userns = %q, want keep-id", spec.UserNS.NSMode)
+	}
+	if _, ok := spec.Networks[networkName]; !ok {
+		t.Errorf("networks = %v, want %s", spec.Networks, networkName)
+	}
+	if spec.Volumes[0].Dest != "/claude-data" {
+		t.Errorf("volume dest = %q", spec.Volumes[0].Dest)
+	}
+	if spec.Env["AGENT_SECRET"] != "s3cret" || spec.Env["CLAUDE_CODE_OAUTH_TOKEN"] != "oauth" {
+		t.Errorf("env = %v", spec.Env)
+	}
+
+	if _, err := p.Create(ctx, 5, provider.CreateOptions{}); !errors.Is(err, provider.ErrAlreadyExists) {
+		t.Errorf("second create: got %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestPauseWake(t *testing.T) {
+	_, p := newFakePodman(t)
+	ctx := context.Background()
+
+	inst, err := p.Create(ctx, 1, provider.CreateOptions{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if err := p.Pause(ctx, inst.ProviderID); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	st, err := p.Status(ctx, inst.ProviderID)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if st.Status != provider.StatusStopped || st.UserID != 1 {
+		t.Errorf("after pause: %+v, want stopped for user 1", st)
+	}
+	if err := p.Pause(ctx, inst.ProviderID); !errors.Is(err, provider.ErrInvalidState) {
+		t.Errorf("double pause: got %v, want ErrInvalidState", err)
+	}
+
+	if err := p.Wake(ctx, inst.ProviderID); err != nil {
+		t.Fatalf("wake: %v", err)
+	}
+	st, _ = p.Status(ctx, inst.ProviderID)
+	if st.Status != provider.StatusRunning {
+		t.Errorf("after wake: status = %q, want running", st.Status)
+	}
+	if err := p.Wake(ctx, inst.ProviderID); !errors.Is(err, provider.ErrInvalidState) {
+		t.Errorf("double wake: got %v, want ErrInvalidState", err)
+	}
+}
+
+func TestClassLimits(t *testing.T) {
+	f, p := newFakePodman(t)
+	ctx := context.Background()
+
+	small := provider.Class{Name: "small", CPUs: 2, MemoryMB: 4096}
+	inst, err := p.Create(ctx, 4, provider.CreateOptions{Class: small})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	limits := f.specs[0].ResourceLimits
+	if limits == nil || limits.CPU.Quota != 200000 || limits.CPU.Period != cpuPeriod || limits.Memory.Limit != 4096<<20 {
+		t.Fatalf("create limits = %+v", limits)
+	}
+
+	if err := p.Resize(ctx, inst.ProviderID, provider.Class{Name: "large", CPUs: 8, MemoryMB: 16384}); err != nil {
+		t.Fatalf("resize: %v", err)
+	}
+	got := f.updates[inst.ProviderID]
+	if got.CPU == nil || got.CPU.Quota != 800000 || got.Memory == nil || got.Memory.Limit != 16384<<20 || got.Memory.Swap != got.Memory.Limit {
+		t.Errorf("update = %+v", got)
+	}
+	if err := p.Resize(ctx, "missing", small); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("resize missing: got %v, want ErrNotFound", err)
+	}
+
+	// The zero class leaves the container unlimited
+	if _, err := p.Create(ctx, 5, provider.CreateOptions{}); err != nil {
+		t.Fatalf("create unlimited: %v", err)
+	}
+	if f.specs[1].ResourceLimits != nil {
+		t.Errorf("zero class limits = %+v, want none", f.specs[1].ResourceLimits)
+	}
+}
+
+func TestDestroyKeepsVolume(t *testing.T) {
+	f, p := newFakePodman(t)
+	ctx := context.Background()
+
+	inst, _ := p.Create(ctx, 2, provider.CreateOptions{})
+	if err := p.Destroy(ctx, inst.ProviderID); err != nil {
+		t.Fatalf("destroy: %v", err)
+	}
+
+	if _, err := p.Status(ctx, inst.ProviderID); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("status after destroy: got %v, want ErrNotFound", err)
+	}
+	if _, ok := f.volumes["claude-data-2"]; !ok {
+		t.Error("volume should survive destroy")
+	}
+	if err := p.Destroy(ctx, inst.ProviderID); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("second destroy: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestActivity(t *testing.T) {
+	f, p := newFakePodman(t)
+	ctx := context.Background()
+
+	if _, err := p.Activity(ctx, "missing"); !errors.Is(err, provider.ErrNotFound) {
+		t.Fatalf("activity on missing container: got %v, want ErrNotFound", err)
+	}
+
+	inst, _ := p.Create(ctx, 3, provider.CreateOptions{})
+	info, err := p.Activity(ctx, inst.ProviderID)
+	if err != nil {
+		t.Fatalf("activity: %v", err)
+	}
+	if info.IsActive || !info.IsHealthy || info.ProcessCount != 3 {
+		t.Errorf("idle info = %+v, want healthy, inactive, 3 processes", info)
+	}
+
+	f.mu.Lock()
+	f.processes = 7
+	f.mu.Unlock()
+	info, _ = p.Activity(ctx, inst.ProviderID)
+	if !info.IsActive {
+		t.Errorf("busy info = %+v, want active", info)
+	}
+}
+
+func TestListAndRemoveManaged(t *testing.T) {
+	f, p := newFakePodman(t)
+	ctx := context.Background()
+
+	p.Create(ctx, 1, provider.CreateOptions{})
+	gone, _ := p.Create(ctx, 2, provider.CreateOptions{})
+	p.Destroy(ctx, gone.ProviderID)
+
+	res, err := p.ListManaged(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var instances, volumes int
+	for _, r := range res {
+		switch r.Kind {
+		case provider.ResourceInstance:
+			instances++
+			if r.UserID != 1 || r.Status != provider.StatusRunning {
+				t.Errorf("instance resource = %+v", r)
+			}
+		case provider.ResourceVolume:
+			volumes++
+		}
+	}
+	if instances != 1 || volumes != 2 {
+		t.Errorf("got %d instances and %d volumes, want 1 and 2", instances, volumes)
+	}
+
+	if err := p.RemoveManaged(ctx, provider.ManagedResource{Kind: provider.ResourceVolume, ID: "claude-data-2"}); err != nil {
+		t.Errorf("remove volume: %v", err)
+	}
+	if _, ok := f.volumes["claude-data-2"]; ok {
+		t.Error("volume still present after RemoveManaged")
+	}
+}
+
+func TestSocketPath(t *testing.T) {
+	t.Setenv("CONTAINER_HOST", "")
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{"", "/run/user/1000/podman/podman.sock", false},
+		{"/tmp/podman.sock", "/tmp/podman.sock", false},
+		{"unix:///run/podman/podman.sock", "/run/podman/podman.sock", false},
+		{"ssh://core@host/run/podman.sock", "", true},
+	}
+	for _, tt := range tests {
+		got, err := socketPath(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("socketPath(%q) = %q, %v; want %q (err %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
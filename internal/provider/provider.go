@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -33,6 +34,9 @@ type Instance struct {
 // CreateOptions carries optional parameters for instance creation.
 // Docker ignores these; Hetzner passes the setup key to cloud-init.
 type CreateOptions struct {
+	Name               string // instance name; empty means DefaultInstanceName
+	Class              Class  // instance size; zero means provider defaults
+	Environment        Environment // image, env vars, repos and setup script; zero means stock
 	NetbirdSetupKey    string
 	AgentSecret        string // Per-instance secret for agent auth
 	AnthropicAPIKey    string // Anthropic API key (API pay-as-you-go billing)
@@ -67,3 +71,78 @@ type Provisioner interface {
 	// Activity returns the current activity state of an instance.
 	Activity(ctx context.Context, instanceID string) (*ActivityInfo, error)
 }
+
+// ResourceKind identifies the type of a provider-managed resource.
+type ResourceKind string
+
+const (
+	ResourceInstance  ResourceKind = "instance"  // container or server
+	ResourceVolume    ResourceKind = "volume"    // persistent user data
+	ResourceWorkspace ResourceKind = "workspace" // Terraform state directory
+	ResourceSnapshot  ResourceKind = "snapshot"  // machine image taken on Pause
+)
+
+// ManagedResource describes a resource the provider created and still tracks.
+type ManagedResource struct {
+	Kind       ResourceKind `json:"kind"`
+	ID         string       `json:"id"`          // provider handle used for removal
+	ProviderID string       `json:"provider_id"` // matches Instance.ProviderID; may be empty if the provider cannot tell
+	UserID     int          `json:"user_id"`
+	Name       string       `json:"name,omitempty"`   // instance name the resource belongs to
+	Status     Status       `json:"status,omitempty"` // only set for ResourceInstance
+}
+
+// Inventory is implemented by provisioners that can enumerate and remove the
+// resources they manage. The reconciler uses it to detect drift and orphans.
+type Inventory interface {
+	// ListManaged returns every resource carrying this provider's management marker.
+	ListManaged(ctx context.Context) ([]ManagedResource, error)
+
+	// RemoveManaged deletes a single resource returned by ListManaged.
+	RemoveManaged(ctx context.Context, res ManagedResource) error
+}
+
+// Snapshotter is implemented by provisioners whose Pause captures the whole
+// machine as an image instead of just stopping it. InstanceService uses it to
+// record snapshot IDs on the instance row.
+type Snapshotter interface {
+	// PauseSnapshot snapshots the instance, removes it, and returns the snapshot ID.
+	PauseSnapshot(ctx context.Context, instanceID string) (string, error)
+
+	// WakeSnapshot recreates the instance from snapshotID (the latest when
+	// empty), prunes snapshots beyond the retention policy, and returns the new
+	// instance along with the IDs of the snapshots still kept, oldest first.
+	WakeSnapshot(ctx context.Context, instanceID, snapshotID string) (*Instance, []string, error)
+}
+
+// Resizer is implemented by provisioners that can change the class of an
+// existing instance.
+type Resizer interface {
+	// Resize applies class to the instance. The instance may be restarted.
+	Resize(ctx context.Context, instanceID string, class Class) error
+}
+
+// Pooler is implemented by provisioners whose cold start is slow enough to
+// warrant a pool of generic, pre-booted instances. WarmPoolService keeps the
+// pool topped up and InstanceService claims from it on Create.
+type Pooler interface {
+	// CreateWarm boots an instance of the given class that belongs to no user.
+	CreateWarm(ctx context.Context, class Class) (*Instance, error)
+
+	// Claim binds the warm instance warmID to the user, injecting the agent
+	// secret and credentials from opts after boot, and returns it under the
+	// same ID Create would have given it. opts.Class and opts.Environment are
+	// ignored; warm instances boot the stock setup.
+	Claim(ctx context.Context, warmID string, userID int, opts CreateOptions) (*Instance, error)
+}
+
+// VolumeArchiver is implemented by provisioners that can stream a user data
+// volume as a tar archive. BackupService uses it to back up and restore volumes.
+type VolumeArchiver interface {
+	// ExportVolume writes the contents of volumeID to w as an uncompressed tar.
+	ExportVolume(ctx context.Context, volumeID string, w io.Writer) error
+
+	// ImportVolume replaces the contents of volumeID with the tar read from r.
+	// The instance using the volume should be stopped.
+	ImportVolume(ctx context.Context, volumeID string, r io.Reader) error
+}
@@ -160,11 +160,10 @@ func (a *ActivityService) checkInstance(ctx context.Context, inst *ent.Instance,
 	idleDuration := now.Sub(*lastActivity)
 	if idleDuration >= a.idleThreshold {
 		a.logger.Info("auto-pausing idle instance", "instance_id", inst.ID, "idle_duration", idleDuration.Round(time.Minute))
-		if err := a.provider.Pause(ctx, inst.ProviderID); err != nil {
+		if err := pauseInstance(ctx, a.provider, inst); err != nil {
 			a.logger.Error("failed to pause instance", "instance_id", inst.ID, "error", err)
 			return
 		}
-		_, _ = inst.Update().SetStatus("stopped").Save(ctx)
 		a.healthFailures.Delete(inst.ID)
 	}
 }
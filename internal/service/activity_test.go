@@ -30,7 +30,7 @@ func TestActivityService_ActiveNotPaused(t *testing.T) {
 	defer client.Close()
 
 	userID := createTestUser(t, client)
-	inst, err := instSvc.Create(context.Background(), userID)
+	inst, err := instSvc.Create(context.Background(), userID, InstanceSpec{})
 	if err != nil {
 		t.Fatalf("create: %v", err)
 	}
@@ -58,7 +58,7 @@ func TestActivityService_IdleAutoPaused(t *testing.T) {
 	defer client.Close()
 
 	userID := createTestUser(t, client)
-	inst, err := instSvc.Create(context.Background(), userID)
+	inst, err := instSvc.Create(context.Background(), userID, InstanceSpec{})
 	if err != nil {
 		t.Fatalf("create: %v", err)
 	}
@@ -89,7 +89,7 @@ func TestActivityService_RecentlyActiveNotPaused(t *testing.T) {
 	defer client.Close()
 
 	userID := createTestUser(t, client)
-	inst, err := instSvc.Create(context.Background(), userID)
+	inst, err := instSvc.Create(context.Background(), userID, InstanceSpec{})
 	if err != nil {
 		t.Fatalf("create: %v", err)
 	}
@@ -0,0 +1,478 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/logan/cloudcode/internal/ent"
+	entinstance "github.com/logan/cloudcode/internal/ent/instance"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+// Page sizes for admin listings.
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+var (
+	// ErrUserNotFound is returned when a user ID does not exist.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrInvalidPlan is returned for a plan name that is not offered.
+	ErrInvalidPlan = errors.New("invalid plan")
+	// ErrInvalidCursor is returned for a page cursor that was not issued by
+	// a listing.
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// PageRequest selects a page of a listing, newest first. Cursor is the
+// NextCursor of the previous page, or empty for the first page.
+type PageRequest struct {
+	Limit  int
+	Cursor string
+}
+
+// Page is one page of a listing.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      int    `json:"total"`
+}
+
+// limit returns the page size, clamped to maxPageSize.
+func (p PageRequest) limit() int {
+	switch {
+	case p.Limit <= 0:
+		return defaultPageSize
+	case p.Limit > maxPageSize:
+		return maxPageSize
+	}
+	return p.Limit
+}
+
+// before returns the ID the page starts below, or 0 for the first page.
+func (p PageRequest) before() (int, error) {
+	if p.Cursor == "" {
+		return 0, nil
+	}
+	id, err := strconv.Atoi(p.Cursor)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidCursor
+	}
+	return id, nil
+}
+
+// UserFilter narrows an admin user listing. Empty fields match everything.
+type UserFilter struct {
+	Email              string // case-insensitive substring
+	Plan               string
+	SubscriptionStatus string
+}
+
+// InstanceFilter narrows an admin instance listing. Zero fields match
+// everything.
+type InstanceFilter struct {
+	UserID   int
+	Status   string
+	Provider string
+}
+
+// AdminUser is a user as operators see it.
+type AdminUser struct {
+	ID                 int       `json:"id"`
+	Email              string    `json:"email"`
+	Name               string    `json:"name"`
+	Plan               string    `json:"plan"`
+	SubscriptionStatus string    `json:"subscription_status"`
+	UsageHours         float64   `json:"usage_hours"`
+	Instances          int       `json:"instances"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// AdminInstance is an instance as operators see it.
+type AdminInstance struct {
+	*InstanceResponse
+	UserID         int        `json:"user_id"`
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// BulkResult reports the jobs a bulk action queued and the instances it
+// left alone.
+type BulkResult struct {
+	Jobs    []*JobResponse `json:"jobs"`
+	Skipped []BulkSkip     `json:"skipped"`
+}
+
+// BulkSkip is an instance a bulk action did not act on.
+type BulkSkip struct {
+	InstanceID int    `json:"instance_id"`
+	Reason     string `json:"reason"`
+}
+
+// UsageReport summarizes the fleet.
+type UsageReport struct {
+	Users             int            `json:"users"`
+	UsersByPlan       map[string]int `json:"users_by_plan"`
+	InstancesByStatus map[string]int `json:"instances_by_status"`
+	UsageHours        float64        `json:"usage_hours"`
+	TopUsers          []*AdminUser   `json:"top_users"`
+}
+
+// topUsers is how many of the heaviest users a usage report lists.
+const topUsers = 10
+
+// AdminService backs the operator API. Every change it makes is recorded in
+// the audit log as done by the admin API key; instance operations are
+// recorded by the job service.
+type AdminService struct {
+	db    *ent.Client
+	jobs  *JobService
+	auth  *AuthService
+	audit *AuditService
+}
+
+// NewAdminService creates a new AdminService.
+func NewAdminService(db *ent.Client, jobs *JobService, auth *AuthService, audit *AuditService) *AdminService {
+	return &AdminService{db: db, jobs: jobs, auth: auth, audit: audit}
+}
+
+// ListUsers returns a page of users, newest first.
+func (s *AdminService) ListUsers(ctx context.Context, f UserFilter, p PageRequest) (*Page[*AdminUser], error) {
+	var where []predicate.User
+	if f.Email != "" {
+		where = append(where, entuser.EmailContainsFold(f.Email))
+	}
+	if f.Plan != "" {
+		where = append(where, entuser.PlanEQ(f.Plan))
+	}
+	if f.SubscriptionStatus != "" {
+		where = append(where, entuser.SubscriptionStatusEQ(f.SubscriptionStatus))
+	}
+	before, err := p.before()
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.User.Query().Where(where...).Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("count users: %w", err)
+	}
+	q := s.db.User.Query().Where(where...)
+	if before != 0 {
+		q = q.Where(entuser.IDLT(before))
+	}
+	users, err := q.
+		Order(ent.Desc(entuser.FieldID)).
+		Limit(p.limit() + 1).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+
+	page := &Page[*AdminUser]{Items: []*AdminUser{}, Total: total}
+	if len(users) > p.limit() {
+		users = users[:p.limit()]
+		page.NextCursor = strconv.Itoa(users[len(users)-1].ID)
+	}
+	for _, u := range users {
+		au, err := s.adminUser(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, au)
+	}
+	return page, nil
+}
+
+// GetUser returns one user.
+func (s *AdminService) GetUser(ctx context.Context, userID int) (*AdminUser, error) {
+	u, err := s.user(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.adminUser(ctx, u)
+}
+
+// SetPlan changes a user's plan, for comps and support fixes. Stripe is not
+// told, so the next subscription webhook for the user may change it back.
+func (s *AdminService) SetPlan(ctx context.Context, userID int, plan string) (*AdminUser, error) {
+	if _, ok := planRank[plan]; !ok {
+		return nil, ErrInvalidPlan
+	}
+	u, err := s.user(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	previous := u.Plan
+	u, err = u.Update().SetPlan(plan).Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("set plan: %w", err)
+	}
+	s.record(ctx, AuditEntry{
+		Action:     "admin.user.plan",
+		TargetType: "user",
+		TargetID:   strconv.Itoa(userID),
+		SubjectID:  userID,
+		Details:    map[string]any{"from": previous, "to": plan},
+	})
+	return s.adminUser(ctx, u)
+}
+
+// Impersonate starts a short support session as the user. The user sees it
+// in their session list and can revoke it.
+func (s *AdminService) Impersonate(ctx context.Context, userID int) (*SessionTokens, error) {
+	tokens, err := s.auth.Impersonate(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, AuditEntry{
+		Action:     "admin.user.impersonate",
+		TargetType: "user",
+		TargetID:   strconv.Itoa(userID),
+		SubjectID:  userID,
+	})
+	return tokens, nil
+}
+
+// ListInstances returns a page of instances, newest first. Destroyed
+// instances are included only when filtering on that status.
+func (s *AdminService) ListInstances(ctx context.Context, f InstanceFilter, p PageRequest) (*Page[*AdminInstance], error) {
+	var where []predicate.Instance
+	if f.UserID != 0 {
+		where = append(where, entinstance.HasOwnerWith(entuser.IDEQ(f.UserID)))
+	}
+	if f.Status != "" {
+		where = append(where, entinstance.StatusEQ(f.Status))
+	} else {
+		where = append(where, entinstance.StatusNEQ("destroyed"))
+	}
+	if f.Provider != "" {
+		where = append(where, entinstance.ProviderEQ(f.Provider))
+	}
+	before, err := p.before()
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.Instance.Query().Where(where...).Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("count instances: %w", err)
+	}
+	q := s.db.Instance.Query().Where(where...)
+	if before != 0 {
+		q = q.Where(entinstance.IDLT(before))
+	}
+	instances, err := q.
+		WithOwner().
+		Order(ent.Desc(entinstance.FieldID)).
+		Limit(p.limit() + 1).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list instances: %w", err)
+	}
+
+	page := &Page[*AdminInstance]{Items: []*AdminInstance{}, Total: total}
+	if len(instances) > p.limit() {
+		instances = instances[:p.limit()]
+		page.NextCursor = strconv.Itoa(instances[len(instances)-1].ID)
+	}
+	for _, inst := range instances {
+		page.Items = append(page.Items, toAdminInstance(inst))
+	}
+	return page, nil
+}
+
+// PauseInstance force-pauses a running instance.
+func (s *AdminService) PauseInstance(ctx context.Context, instanceID int) (*JobResponse, error) {
+	return s.jobs.EnqueueInstanceOp(asAdmin(ctx), JobPause, instanceID)
+}
+
+// DestroyInstance destroys an instance whoever owns it.
+func (s *AdminService) DestroyInstance(ctx context.Context, instanceID int) (*JobResponse, error) {
+	return s.jobs.EnqueueInstanceOp(asAdmin(ctx), JobDestroy, instanceID)
+}
+
+// PauseIdle pauses every running instance with no activity for idleFor.
+// Instances that never reported activity count from when they were created.
+func (s *AdminService) PauseIdle(ctx context.Context, idleFor time.Duration) (*BulkResult, error) {
+	cutoff := time.Now().Add(-idleFor)
+	instances, err := s.db.Instance.Query().
+		Where(
+			entinstance.StatusEQ("running"),
+			entinstance.Or(
+				entinstance.LastActivityAtLT(cutoff),
+				entinstance.And(entinstance.LastActivityAtIsNil(), entinstance.CreatedAtLT(cutoff)),
+			),
+		).
+		Order(ent.Asc(entinstance.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list idle instances: %w", err)
+	}
+	result := s.bulk(ctx, JobPause, instances)
+	s.record(ctx, AuditEntry{
+		Action:  "admin.instances.pause_idle",
+		Details: map[string]any{"idle_for": idleFor.String(), "jobs": len(result.Jobs), "skipped": len(result.Skipped)},
+	})
+	return result, nil
+}
+
+// DestroyUserInstances destroys all of a user's instances.
+func (s *AdminService) DestroyUserInstances(ctx context.Context, userID int) (*BulkResult, error) {
+	if _, err := s.user(ctx, userID); err != nil {
+		return nil, err
+	}
+	instances, err := s.db.Instance.Query().
+		Where(
+			entinstance.HasOwnerWith(entuser.IDEQ(userID)),
+			entinstance.StatusNEQ("destroyed"),
+		).
+		Order(ent.Asc(entinstance.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list instances: %w", err)
+	}
+	result := s.bulk(ctx, JobDestroy, instances)
+	s.record(ctx, AuditEntry{
+		Action:     "admin.user.destroy_instances",
+		TargetType: "user",
+		TargetID:   strconv.Itoa(userID),
+		SubjectID:  userID,
+		Details:    map[string]any{"jobs": len(result.Jobs), "skipped": len(result.Skipped)},
+	})
+	return result, nil
+}
+
+// Usage returns fleet-wide counts and the heaviest users.
+func (s *AdminService) Usage(ctx context.Context) (*UsageReport, error) {
+	report := &UsageReport{UsersByPlan: map[string]int{}, InstancesByStatus: map[string]int{}, TopUsers: []*AdminUser{}}
+
+	var plans []struct {
+		Plan  string  `json:"plan"`
+		Count int     `json:"count"`
+		Hours float64 `json:"sum"`
+	}
+	err := s.db.User.Query().
+		GroupBy(entuser.FieldPlan).
+		Aggregate(ent.Count(), ent.Sum(entuser.FieldUsageHours)).
+		Scan(ctx, &plans)
+	if err != nil {
+		return nil, fmt.Errorf("count users: %w", err)
+	}
+	for _, p := range plans {
+		report.UsersByPlan[p.Plan] = p.Count
+		report.Users += p.Count
+		report.UsageHours += p.Hours
+	}
+
+	var statuses []struct {
+		Status string `json:"status"`
+		Count  int    `json:"count"`
+	}
+	err = s.db.Instance.Query().
+		GroupBy(entinstance.FieldStatus).
+		Aggregate(ent.Count()).
+		Scan(ctx, &statuses)
+	if err != nil {
+		return nil, fmt.Errorf("count instances: %w", err)
+	}
+	for _, st := range statuses {
+		report.InstancesByStatus[st.Status] = st.Count
+	}
+
+	top, err := s.db.User.Query().
+		Where(entuser.UsageHoursGT(0)).
+		Order(ent.Desc(entuser.FieldUsageHours), ent.Asc(entuser.FieldID)).
+		Limit(topUsers).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list top users: %w", err)
+	}
+	for _, u := range top {
+		au, err := s.adminUser(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		report.TopUsers = append(report.TopUsers, au)
+	}
+	return report, nil
+}
+
+// bulk queues a job for each instance, skipping the ones that cannot take
+// it. The job service records each job.
+func (s *AdminService) bulk(ctx context.Context, jobType string, instances []*ent.Instance) *BulkResult {
+	result := &BulkResult{Jobs: []*JobResponse{}, Skipped: []BulkSkip{}}
+	for _, inst := range instances {
+		job, err := s.jobs.EnqueueInstanceOp(asAdmin(ctx), jobType, inst.ID)
+		if err != nil {
+			reason := err.Error()
+			switch {
+			case errors.Is(err, provider.ErrInvalidState):
+				reason = "invalid instance state for operation"
+			case errors.Is(err, provider.ErrNotFound):
+				reason = "instance not found"
+			}
+			result.Skipped = append(result.Skipped, BulkSkip{InstanceID: inst.ID, Reason: reason})
+			continue
+		}
+		result.Jobs = append(result.Jobs, job)
+	}
+	return result
+}
+
+// record writes an audit event for an admin action.
+func (s *AdminService) record(ctx context.Context, e AuditEntry) {
+	s.audit.Log(asAdmin(ctx), e)
+}
+
+// asAdmin marks ctx as acting with the admin API key, which the /admin
+// routes require.
+func asAdmin(ctx context.Context) context.Context {
+	return WithActor(ctx, ActorAdmin, 0)
+}
+
+func (s *AdminService) user(ctx context.Context, userID int) (*ent.User, error) {
+	u, err := s.db.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	return u, nil
+}
+
+func (s *AdminService) adminUser(ctx context.Context, u *ent.User) (*AdminUser, error) {
+	n, err := u.QueryInstances().Where(entinstance.StatusNEQ("destroyed")).Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("count instances: %w", err)
+	}
+	return &AdminUser{
+		ID:                 u.ID,
+		Email:              u.Email,
+		Name:               u.Name,
+		Plan:               u.Plan,
+		SubscriptionStatus: u.SubscriptionStatus,
+		UsageHours:         u.UsageHours,
+		Instances:          n,
+		CreatedAt:          u.CreatedAt,
+	}, nil
+}
+
+func toAdminInstance(inst *ent.Instance) *AdminInstance {
+	ai := &AdminInstance{
+		InstanceResponse: toResponse(inst),
+		LastActivityAt:   inst.LastActivityAt,
+		CreatedAt:        inst.CreatedAt,
+	}
+	if inst.Edges.Owner != nil {
+		ai.UserID = inst.Edges.Owner.ID
+	}
+	return ai
+}
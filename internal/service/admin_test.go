@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/auth"
+	"github.com/logan/cloudcode/internal/ent"
+	entauditevent "github.com/logan/cloudcode/internal/ent/auditevent"
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+func setupAdminTest(t *testing.T, dsn string) (*AdminService, *ent.Client) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { client.Close() })
+	audit := NewAuditService(client)
+	jobs := NewJobService(client, NewInstanceService(client, provider.NewMock(), ""), slog.Default(), 1, time.Hour)
+	jobs.SetAuditService(audit)
+	authSvc := NewAuthService(client, "test-secret", "http://api", "http://app", NewLogMailer(slog.Default()))
+	authSvc.SetAuditService(audit)
+	return NewAdminService(client, jobs, authSvc, audit), client
+}
+
+func createAdminTestInstance(t *testing.T, client *ent.Client, userID int, status string, lastActivity time.Time) *ent.Instance {
+	t.Helper()
+	return client.Instance.Create().
+		SetProvider("mock").
+		SetProviderID(fmt.Sprintf("mock-%d", time.Now().UnixNano())).
+		SetStatus(status).
+		SetLastActivityAt(lastActivity).
+		SetOwnerID(userID).
+		SaveX(context.Background())
+}
+
+func TestAdminService_ListUsers(t *testing.T) {
+	svc, client := setupAdminTest(t, "file:ent_admin_users?mode=memory&_fk=1")
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		plan := "free"
+		if i%2 == 1 {
+			plan = "pro"
+		}
+		client.User.Create().SetEmail(fmt.Sprintf("user%d@Example.com", i)).SetPlan(plan).SaveX(ctx)
+	}
+	client.User.Create().SetEmail("someone@other.org").SaveX(ctx)
+
+	// Pages run newest first and end without a cursor
+	var seen []int
+	page := PageRequest{Limit: 2}
+	for {
+		users, err := svc.ListUsers(ctx, UserFilter{Email: "EXAMPLE"}, page)
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if users.Total != 5 {
+			t.Errorf("total = %d", users.Total)
+		}
+		for _, u := range users.Items {
+			seen = append(seen, u.ID)
+		}
+		if users.NextCursor == "" {
+			break
+		}
+		page.Cursor = users.NextCursor
+	}
+	if len(seen) != 5 || seen[0] < seen[4] {
+		t.Errorf("paged IDs = %v", seen)
+	}
+
+	pro, err := svc.ListUsers(ctx, UserFilter{Plan: "pro"}, PageRequest{})
+	if err != nil || pro.Total != 2 || len(pro.Items) != 2 {
+		t.Errorf("pro users = %+v, %v", pro, err)
+	}
+	if _, err := svc.ListUsers(ctx, UserFilter{}, PageRequest{Cursor: "abc"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("bad cursor: %v", err)
+	}
+}
+
+func TestAdminService_SetPlanAndImpersonate(t *testing.T) {
+	svc, client := setupAdminTest(t, "file:ent_admin_plan?mode=memory&_fk=1")
+	ctx := WithRequestID(WithClientInfo(context.Background(), "10.0.0.9", "ops-cli"), "req-1")
+	userID := createTestUser(t, client)
+
+	if _, err := svc.SetPlan(ctx, userID, "enterprise"); !errors.Is(err, ErrInvalidPlan) {
+		t.Errorf("unknown plan: %v", err)
+	}
+	if _, err := svc.SetPlan(ctx, 9999, "pro"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("unknown user: %v", err)
+	}
+	u, err := svc.SetPlan(ctx, userID, "pro")
+	if err != nil || u.Plan != "pro" {
+		t.Fatalf("set plan = %+v, %v", u, err)
+	}
+
+	ev := client.AuditEvent.Query().Where(entauditevent.ActionEQ("admin.user.plan")).OnlyX(ctx)
+	if ev.Actor != ActorAdmin || ev.TargetID != fmt.Sprint(userID) || ev.SubjectID == nil || *ev.SubjectID != userID {
+		t.Errorf("audit event = %+v", ev)
+	}
+	if ev.IP != "10.0.0.9" || ev.UserAgent != "ops-cli" || ev.RequestID != "req-1" || ev.Details["from"] != "free" {
+		t.Errorf("audit context = %+v", ev)
+	}
+
+	tokens, err := svc.Impersonate(ctx, userID)
+	if err != nil {
+		t.Fatalf("impersonate: %v", err)
+	}
+	if tokens.RefreshToken != "" {
+		t.Error("impersonation session can be refreshed")
+	}
+	claims, err := auth.ValidateToken("test-secret", tokens.AccessToken)
+	if err != nil || claims.UserID != userID || !claims.Impersonated || !svc.auth.SessionActive(ctx, claims.SessionID) {
+		t.Fatalf("claims = %+v, %v", claims, err)
+	}
+	sessions, _ := svc.auth.ListSessions(ctx, userID, "")
+	if len(sessions) != 1 || sessions[0].UserAgent != impersonationAgent {
+		t.Errorf("user's sessions = %+v", sessions)
+	}
+	if n := client.AuditEvent.Query().Where(entauditevent.ActionEQ("admin.user.impersonate")).CountX(ctx); n != 1 {
+		t.Errorf("%d impersonation events", n)
+	}
+}
+
+func TestAdminService_BulkActions(t *testing.T) {
+	svc, client := setupAdminTest(t, "file:ent_admin_bulk?mode=memory&_fk=1")
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+	other := client.User.Create().SetEmail("other@example.com").SaveX(ctx)
+
+	now := time.Now()
+	idle := createAdminTestInstance(t, client, userID, "running", now.Add(-3*time.Hour))
+	busy := createAdminTestInstance(t, client, userID, "running", now)
+	paused := createAdminTestInstance(t, client, userID, "paused", now.Add(-3*time.Hour))
+	theirs := createAdminTestInstance(t, client, other.ID, "running", now.Add(-5*time.Hour))
+
+	result, err := svc.PauseIdle(ctx, 2*time.Hour)
+	if err != nil {
+		t.Fatalf("pause idle: %v", err)
+	}
+	if len(result.Jobs) != 2 || *result.Jobs[0].InstanceID != idle.ID || *result.Jobs[1].InstanceID != theirs.ID {
+		t.Errorf("pause idle jobs = %+v", result.Jobs)
+	}
+
+	list, err := svc.ListInstances(ctx, InstanceFilter{UserID: userID, Status: "running"}, PageRequest{})
+	if err != nil || list.Total != 2 || list.Items[0].ID != busy.ID || list.Items[0].UserID != userID {
+		t.Errorf("running instances = %+v, %v", list, err)
+	}
+
+	// Instances that cannot be destroyed are reported, not fatal. The pauses
+	// are done by then, or the instances would be busy
+	client.Job.Update().SetStatus(JobSucceeded).ExecX(ctx)
+	client.Instance.UpdateOneID(paused.ID).SetStatus("destroyed").ExecX(ctx)
+	result, err = svc.DestroyUserInstances(ctx, userID)
+	if err != nil {
+		t.Fatalf("destroy all: %v", err)
+	}
+	if len(result.Jobs) != 2 || len(result.Skipped) != 0 {
+		t.Errorf("destroy all = %+v", result)
+	}
+	if _, err := svc.DestroyUserInstances(ctx, 9999); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("unknown user: %v", err)
+	}
+	if _, err := svc.PauseInstance(ctx, paused.ID); !errors.Is(err, provider.ErrInvalidState) {
+		t.Errorf("pause destroyed: %v", err)
+	}
+
+	// Each job is recorded as the admin's, then the bulk action as a whole
+	events := client.AuditEvent.Query().Where(entauditevent.ActionEQ("instance.destroy")).AllX(ctx)
+	if len(events) != 2 {
+		t.Fatalf("%d destroy events", len(events))
+	}
+	for _, ev := range events {
+		if ev.Actor != ActorAdmin || ev.SubjectID == nil || *ev.SubjectID != userID || ev.TargetType != "instance" {
+			t.Errorf("destroy event = %+v", ev)
+		}
+	}
+	bulk := client.AuditEvent.Query().Where(entauditevent.ActionEQ("admin.user.destroy_instances")).OnlyX(ctx)
+	if bulk.Details["jobs"] != float64(2) {
+		t.Errorf("bulk event details = %v", bulk.Details)
+	}
+
+	usage, err := svc.Usage(ctx)
+	if err != nil {
+		t.Fatalf("usage: %v", err)
+	}
+	if usage.Users != 2 || usage.UsersByPlan["free"] != 2 || usage.InstancesByStatus["running"] != 3 {
+		t.Errorf("usage = %+v", usage)
+	}
+}
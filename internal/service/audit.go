@@ -0,0 +1,391 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/logan/cloudcode/internal/ent"
+	entauditevent "github.com/logan/cloudcode/internal/ent/auditevent"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+)
+
+// Audit actors.
+const (
+	ActorAdmin  = "admin"  // the admin API key
+	ActorUser   = "user"   // a signed-in user or access token
+	ActorSystem = "system" // background work
+	ActorShare  = "share"  // someone holding a terminal share link
+)
+
+// auditBatch is how many events export and verification read at a time.
+const auditBatch = 500
+
+// errStopAudit ends a walk over the audit log early.
+var errStopAudit = errors.New("stop")
+
+// AuditEntry describes an action to record. IP, user agent and request ID
+// come from the context, as does the actor if Actor is empty.
+type AuditEntry struct {
+	Actor      string
+	ActorID    int // 0 if not a user
+	Action     string
+	TargetType string
+	TargetID   string
+	SubjectID  int // user the action concerns; defaults to the acting user
+	Details    map[string]any
+}
+
+// AuditEventResponse is the API representation of an audit event.
+type AuditEventResponse struct {
+	ID         int            `json:"id"`
+	Actor      string         `json:"actor"`
+	ActorID    *int           `json:"actor_id,omitempty"`
+	Action     string         `json:"action"`
+	TargetType string         `json:"target_type,omitempty"`
+	TargetID   string         `json:"target_id,omitempty"`
+	SubjectID  *int           `json:"subject_id,omitempty"`
+	Details    map[string]any `json:"details,omitempty"`
+	IP         string         `json:"ip,omitempty"`
+	UserAgent  string         `json:"user_agent,omitempty"`
+	RequestID  string         `json:"request_id,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	PrevHash   string         `json:"prev_hash"`
+	Hash       string         `json:"hash"`
+}
+
+// AuditFilter narrows an audit query. Zero fields match everything. An
+// Action ending in "." matches every action with that prefix.
+type AuditFilter struct {
+	Actor      string
+	ActorID    int
+	Action     string
+	TargetType string
+	TargetID   string
+	SubjectID  int
+	Since      time.Time
+	Until      time.Time
+}
+
+// AuditVerification is the result of checking the hash chain.
+type AuditVerification struct {
+	Events   int    `json:"events"`
+	Valid    bool   `json:"valid"`
+	BrokenAt *int   `json:"broken_at,omitempty"` // first event that does not match
+	Head     string `json:"head,omitempty"`      // hash of the last event
+}
+
+// AuditService records security-relevant actions in a hash chain. Writes
+// are serialized in-process, so the chain assumes a single API server.
+type AuditService struct {
+	db *ent.Client
+	mu sync.Mutex
+}
+
+// NewAuditService creates a new AuditService.
+func NewAuditService(db *ent.Client) *AuditService {
+	return &AuditService{db: db}
+}
+
+type (
+	requestIDKey struct{}
+	actorKey     struct{}
+)
+
+type auditActor struct {
+	kind    string
+	id      int
+	subject int // the user a support session impersonates
+}
+
+// WithRequestID attaches the request ID to ctx, to be recorded with audit
+// events.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// WithActor attaches who is acting to ctx, for audit events that do not
+// name an actor themselves. actorID is the user's ID, or 0 for the admin.
+func WithActor(ctx context.Context, actor string, actorID int) context.Context {
+	return context.WithValue(ctx, actorKey{}, auditActor{kind: actor, id: actorID})
+}
+
+// WithImpersonation marks ctx as a support session an admin started as
+// userID. Events it records, including those naming the user as actor,
+// are the admin's, with the user as subject.
+func WithImpersonation(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, actorKey{}, auditActor{kind: ActorAdmin, subject: userID})
+}
+
+// Record stores an audit event, chained to the one before it.
+func (s *AuditService) Record(ctx context.Context, e AuditEntry) error {
+	a, ok := ctx.Value(actorKey{}).(auditActor)
+	if !ok {
+		a = auditActor{kind: ActorSystem}
+	}
+	if e.Actor == "" {
+		e.Actor, e.ActorID = a.kind, a.id
+	}
+	if a.subject != 0 && e.Actor == ActorUser && e.ActorID == a.subject {
+		e.Actor, e.ActorID = ActorAdmin, 0
+	}
+	if e.SubjectID == 0 && e.Actor == ActorUser {
+		e.SubjectID = e.ActorID
+	}
+	if e.SubjectID == 0 {
+		e.SubjectID = a.subject
+	}
+	client := clientInfoFrom(ctx)
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+
+	ev := &ent.AuditEvent{
+		Actor:      e.Actor,
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		Details:    e.Details,
+		IP:         client.ip,
+		UserAgent:  client.userAgent,
+		RequestID:  requestID,
+		// Stored times lose precision in some databases; the hash must
+		// survive the round trip
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	if e.ActorID != 0 {
+		ev.ActorID = &e.ActorID
+	}
+	if e.SubjectID != 0 {
+		ev.SubjectID = &e.SubjectID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last, err := s.db.AuditEvent.Query().
+		Order(ent.Desc(entauditevent.FieldID)).
+		Select(entauditevent.FieldHash).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return fmt.Errorf("read audit chain: %w", err)
+	}
+	if last != nil {
+		ev.PrevHash = last.Hash
+	}
+	ev.Hash, err = auditHash(ev)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.AuditEvent.Create().
+		SetActor(ev.Actor).
+		SetNillableActorID(ev.ActorID).
+		SetAction(ev.Action).
+		SetTargetType(ev.TargetType).
+		SetTargetID(ev.TargetID).
+		SetNillableSubjectID(ev.SubjectID).
+		SetDetails(ev.Details).
+		SetIP(ev.IP).
+		SetUserAgent(ev.UserAgent).
+		SetRequestID(ev.RequestID).
+		SetCreatedAt(ev.CreatedAt).
+		SetPrevHash(ev.PrevHash).
+		SetHash(ev.Hash).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("record audit event: %w", err)
+	}
+	return nil
+}
+
+// Log records an event for an action that has already happened, so a
+// failure is logged rather than returned. A nil AuditService records
+// nothing.
+func (s *AuditService) Log(ctx context.Context, e AuditEntry) {
+	if s == nil {
+		return
+	}
+	if err := s.Record(ctx, e); err != nil {
+		slog.Error("failed to record audit event", "action", e.Action, "target_id", e.TargetID, "error", err)
+	}
+}
+
+// auditHash returns the hex SHA-256 of the event's previous hash and fields.
+func auditHash(ev *ent.AuditEvent) (string, error) {
+	data, err := json.Marshal(struct {
+		PrevHash   string         `json:"prev_hash"`
+		CreatedAt  string         `json:"created_at"`
+		Actor      string         `json:"actor"`
+		ActorID    *int           `json:"actor_id"`
+		Action     string         `json:"action"`
+		TargetType string         `json:"target_type"`
+		TargetID   string         `json:"target_id"`
+		SubjectID  *int           `json:"subject_id"`
+		Details    map[string]any `json:"details"`
+		IP         string         `json:"ip"`
+		UserAgent  string         `json:"user_agent"`
+		RequestID  string         `json:"request_id"`
+	}{
+		ev.PrevHash, ev.CreatedAt.UTC().Format(time.RFC3339Nano),
+		ev.Actor, ev.ActorID, ev.Action, ev.TargetType, ev.TargetID, ev.SubjectID,
+		ev.Details, ev.IP, ev.UserAgent, ev.RequestID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("encode audit event: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// List returns a page of events matching f, newest first.
+func (s *AuditService) List(ctx context.Context, f AuditFilter, p PageRequest) (*Page[*AuditEventResponse], error) {
+	before, err := p.before()
+	if err != nil {
+		return nil, err
+	}
+	where := f.predicates()
+	total, err := s.db.AuditEvent.Query().Where(where...).Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("count audit events: %w", err)
+	}
+	q := s.db.AuditEvent.Query().Where(where...)
+	if before != 0 {
+		q = q.Where(entauditevent.IDLT(before))
+	}
+	events, err := q.
+		Order(ent.Desc(entauditevent.FieldID)).
+		Limit(p.limit() + 1).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list audit events: %w", err)
+	}
+
+	page := &Page[*AuditEventResponse]{Items: []*AuditEventResponse{}, Total: total}
+	if len(events) > p.limit() {
+		events = events[:p.limit()]
+		page.NextCursor = strconv.Itoa(events[len(events)-1].ID)
+	}
+	for _, ev := range events {
+		page.Items = append(page.Items, toAuditEventResponse(ev))
+	}
+	return page, nil
+}
+
+// Export writes the events matching f to w as JSON Lines, oldest first.
+func (s *AuditService) Export(ctx context.Context, f AuditFilter, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	where := f.predicates()
+	return s.each(ctx, where, func(ev *ent.AuditEvent) error {
+		return enc.Encode(toAuditEventResponse(ev))
+	})
+}
+
+// Verify recomputes the hash chain and reports the first event that was
+// altered, or that follows a deleted one. Removing events from the end
+// leaves a valid chain, so compare Head with one noted earlier, such as
+// the last hash of a previous export.
+func (s *AuditService) Verify(ctx context.Context) (*AuditVerification, error) {
+	result := &AuditVerification{Valid: true}
+	prev := ""
+	err := s.each(ctx, nil, func(ev *ent.AuditEvent) error {
+		result.Events++
+		sum, err := auditHash(ev)
+		if err != nil {
+			return err
+		}
+		if ev.PrevHash != prev || sum != ev.Hash {
+			result.Valid = false
+			result.BrokenAt = &ev.ID
+			return errStopAudit
+		}
+		prev = ev.Hash
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopAudit) {
+		return nil, err
+	}
+	result.Head = prev
+	return result, nil
+}
+
+// each calls fn for every event matching where, in ID order.
+func (s *AuditService) each(ctx context.Context, where []predicate.AuditEvent, fn func(*ent.AuditEvent) error) error {
+	after := 0
+	for {
+		events, err := s.db.AuditEvent.Query().
+			Where(where...).
+			Where(entauditevent.IDGT(after)).
+			Order(ent.Asc(entauditevent.FieldID)).
+			Limit(auditBatch).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("read audit events: %w", err)
+		}
+		for _, ev := range events {
+			if err := fn(ev); err != nil {
+				return err
+			}
+		}
+		if len(events) < auditBatch {
+			return nil
+		}
+		after = events[len(events)-1].ID
+	}
+}
+
+func (f AuditFilter) predicates() []predicate.AuditEvent {
+	var where []predicate.AuditEvent
+	if f.Actor != "" {
+		where = append(where, entauditevent.ActorEQ(f.Actor))
+	}
+	if f.ActorID != 0 {
+		where = append(where, entauditevent.ActorIDEQ(f.ActorID))
+	}
+	if strings.HasSuffix(f.Action, ".") {
+		where = append(where, entauditevent.ActionHasPrefix(f.Action))
+	} else if f.Action != "" {
+		where = append(where, entauditevent.ActionEQ(f.Action))
+	}
+	if f.TargetType != "" {
+		where = append(where, entauditevent.TargetTypeEQ(f.TargetType))
+	}
+	if f.TargetID != "" {
+		where = append(where, entauditevent.TargetIDEQ(f.TargetID))
+	}
+	if f.SubjectID != 0 {
+		where = append(where, entauditevent.SubjectIDEQ(f.SubjectID))
+	}
+	if !f.Since.IsZero() {
+		where = append(where, entauditevent.CreatedAtGTE(f.Since))
+	}
+	if !f.Until.IsZero() {
+		where = append(where, entauditevent.CreatedAtLT(f.Until))
+	}
+	return where
+}
+
+func toAuditEventResponse(ev *ent.AuditEvent) *AuditEventResponse {
+	return &AuditEventResponse{
+		ID:         ev.ID,
+		Actor:      ev.Actor,
+		ActorID:    ev.ActorID,
+		Action:     ev.Action,
+		TargetType: ev.TargetType,
+		TargetID:   ev.TargetID,
+		SubjectID:  ev.SubjectID,
+		Details:    ev.Details,
+		IP:         ev.IP,
+		UserAgent:  ev.UserAgent,
+		RequestID:  ev.RequestID,
+		CreatedAt:  ev.CreatedAt,
+		PrevHash:   ev.PrevHash,
+		Hash:       ev.Hash,
+	}
+}
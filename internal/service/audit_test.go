@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/ent/enttest"
+)
+
+func TestAuditService_HashChain(t *testing.T) {
+	// A shared cache lets a raw connection tamper with what ent stored
+	dsn := "file:ent_audit_chain?mode=memory&cache=shared&_fk=1"
+	client := enttest.Open(t, "sqlite3", dsn)
+	defer client.Close()
+	raw, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+	svc := NewAuditService(client)
+	ctx := WithActor(WithClientInfo(context.Background(), "10.0.0.1", "browser"), ActorUser, 7)
+
+	for i := 1; i <= 4; i++ {
+		err := svc.Record(ctx, AuditEntry{
+			Action:     "instance.pause",
+			TargetType: "instance",
+			TargetID:   strconv.Itoa(i),
+			Details:    map[string]any{"job_id": i, "note": "<&>"},
+		})
+		if err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	svc.Log(context.Background(), AuditEntry{Action: "auth.magic_link_failed", SubjectID: 9})
+
+	first := client.AuditEvent.GetX(ctx, 1)
+	if first.PrevHash != "" || first.Actor != ActorUser || first.SubjectID == nil || *first.SubjectID != 7 || first.IP != "10.0.0.1" {
+		t.Errorf("first event = %+v", first)
+	}
+	if last := client.AuditEvent.GetX(ctx, 5); last.Actor != ActorSystem || last.PrevHash != client.AuditEvent.GetX(ctx, 4).Hash {
+		t.Errorf("last event = %+v", last)
+	}
+	result, err := svc.Verify(ctx)
+	if err != nil || !result.Valid || result.Events != 5 || result.Head != client.AuditEvent.GetX(ctx, 5).Hash {
+		t.Fatalf("verify = %+v, %v", result, err)
+	}
+
+	// Rewriting history breaks the chain at the rewritten event
+	if _, err := raw.Exec("UPDATE audit_events SET target_id = '99' WHERE id = 3"); err != nil {
+		t.Fatal(err)
+	}
+	if result, _ := svc.Verify(ctx); result.Valid || result.BrokenAt == nil || *result.BrokenAt != 3 {
+		t.Errorf("after edit = %+v", result)
+	}
+	if _, err := raw.Exec("DELETE FROM audit_events WHERE id = 3"); err != nil {
+		t.Fatal(err)
+	}
+	if result, _ := svc.Verify(ctx); result.Valid || result.BrokenAt == nil || *result.BrokenAt != 4 {
+		t.Errorf("after delete = %+v", result)
+	}
+}
+
+func TestAuditService_ListAndExport(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_audit_list?mode=memory&_fk=1")
+	defer client.Close()
+	svc := NewAuditService(client)
+	ctx := context.Background()
+
+	for _, e := range []AuditEntry{
+		{Actor: ActorUser, ActorID: 1, Action: "instance.create"},
+		{Actor: ActorUser, ActorID: 1, Action: "instance.destroy"},
+		{Actor: ActorUser, ActorID: 2, Action: "instance.create"},
+		{Actor: ActorAdmin, Action: "admin.user.plan", SubjectID: 1},
+		{Actor: ActorUser, ActorID: 1, Action: "user.settings"},
+	} {
+		if err := svc.Record(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mine, err := svc.List(ctx, AuditFilter{SubjectID: 1}, PageRequest{Limit: 3})
+	if err != nil || mine.Total != 4 || len(mine.Items) != 3 || mine.NextCursor == "" {
+		t.Fatalf("subject 1 = %+v, %v", mine, err)
+	}
+	if mine.Items[0].Action != "user.settings" {
+		t.Errorf("newest = %s", mine.Items[0].Action)
+	}
+	instances, _ := svc.List(ctx, AuditFilter{Action: "instance."}, PageRequest{})
+	if instances.Total != 3 {
+		t.Errorf("instance.* = %d", instances.Total)
+	}
+	if none, _ := svc.List(ctx, AuditFilter{Since: time.Now().Add(time.Hour)}, PageRequest{}); none.Total != 0 {
+		t.Errorf("future events = %d", none.Total)
+	}
+
+	var buf bytes.Buffer
+	if err := svc.Export(ctx, AuditFilter{ActorID: 1}, &buf); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	var lines []AuditEventResponse
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var ev AuditEventResponse
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, ev)
+	}
+	if len(lines) != 3 || lines[0].Action != "instance.create" || lines[2].Action != "user.settings" || lines[0].Hash == "" {
+		t.Errorf("export = %+v", lines)
+	}
+}
+
+func TestAuditService_Impersonation(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_audit_imp?mode=memory&_fk=1")
+	defer client.Close()
+	svc := NewAuditService(client)
+	ctx := WithImpersonation(context.Background(), 7)
+
+	// Events naming the user as actor, and those naming none, are the admin's
+	for _, e := range []AuditEntry{
+		{Actor: ActorUser, ActorID: 7, Action: "instance.terminal"},
+		{Action: "instance.create"},
+		{Actor: ActorUser, ActorID: 8, Action: "org.invite"},
+	} {
+		if err := svc.Record(ctx, e); err != nil {
+			t.Fatalf("record %s: %v", e.Action, err)
+		}
+	}
+	page, err := svc.List(ctx, AuditFilter{}, PageRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]*AuditEventResponse)
+	for _, ev := range page.Items {
+		got[ev.Action] = ev
+	}
+	for _, action := range []string{"instance.terminal", "instance.create"} {
+		ev := got[action]
+		if ev.Actor != ActorAdmin || ev.ActorID != nil || ev.SubjectID == nil || *ev.SubjectID != 7 {
+			t.Errorf("%s = %+v, want the admin acting on user 7", action, ev)
+		}
+	}
+	if ev := got["org.invite"]; ev.Actor != ActorUser || *ev.ActorID != 8 {
+		t.Errorf("another user's event = %+v", ev)
+	}
+}
@@ -4,20 +4,24 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"time"
+	"strconv"
 
 	"github.com/logan/cloudcode/internal/auth"
 	"github.com/logan/cloudcode/internal/ent"
 	entuser "github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/sso"
 )
 
-// AuthService handles user authentication via magic links.
+// AuthService handles user authentication via magic links and single sign-on.
 type AuthService struct {
 	db          *ent.Client
-	jwtSecret   string
+	jwtSecret   string        // seals SSO state
+	keys        *auth.Keyring // signs and verifies JWTs
 	baseURL     string
 	frontendURL string
 	mailer      Mailer
+	providers   []sso.Provider // single sign-on, in configured order
+	audit       *AuditService  // nil records nothing
 }
 
 // NewAuthService creates a new AuthService.
@@ -25,73 +29,23 @@ func NewAuthService(db *ent.Client, jwtSecret, baseURL, frontendURL string, mail
 	return &AuthService{
 		db:          db,
 		jwtSecret:   jwtSecret,
+		keys:        auth.NewHMACKeyring(jwtSecret),
 		baseURL:     baseURL,
 		frontendURL: frontendURL,
 		mailer:      mailer,
 	}
 }
 
-// SendMagicLink finds or creates a user by email and sends a magic link.
-func (s *AuthService) SendMagicLink(ctx context.Context, email string) error {
-	// Find or create user
-	u, err := s.db.User.Query().Where(entuser.EmailEQ(email)).Only(ctx)
-	if err != nil {
-		if ent.IsNotFound(err) {
-			u, err = s.db.User.Create().
-				SetEmail(email).
-				Save(ctx)
-			if err != nil {
-				return fmt.Errorf("create user: %w", err)
-			}
-		} else {
-			return fmt.Errorf("query user: %w", err)
-		}
-	}
-
-	// Generate magic link JWT (15 min expiry)
-	token, err := auth.GenerateToken(s.jwtSecret, u.ID, u.Email, "magic_link", 15*time.Minute)
-	if err != nil {
-		return fmt.Errorf("generate token: %w", err)
-	}
-
-	link := fmt.Sprintf("%s/auth/verify?token=%s", s.baseURL, token)
-	return s.mailer.SendMagicLink(email, link)
+// SetKeyring replaces the HS256 keyring built from the JWT secret, to sign
+// with other keys or accept tokens from retired ones.
+func (s *AuthService) SetKeyring(keys *auth.Keyring) {
+	s.keys = keys
 }
 
-// VerifyMagicLink validates a magic link token and returns a session JWT.
-// It also sets an HttpOnly cookie on the response.
-func (s *AuthService) VerifyMagicLink(ctx context.Context, w http.ResponseWriter, tokenStr string) (string, error) {
-	claims, err := auth.ValidateToken(s.jwtSecret, tokenStr)
-	if err != nil {
-		return "", fmt.Errorf("invalid token: %w", err)
-	}
-	if claims.Purpose != "magic_link" {
-		return "", fmt.Errorf("invalid token purpose")
-	}
-
-	// Verify user still exists
-	u, err := s.db.User.Get(ctx, claims.UserID)
-	if err != nil {
-		return "", fmt.Errorf("user not found: %w", err)
-	}
-
-	// Generate session JWT (24h)
-	sessionToken, err := auth.GenerateToken(s.jwtSecret, u.ID, u.Email, "session", 24*time.Hour)
-	if err != nil {
-		return "", fmt.Errorf("generate session: %w", err)
-	}
-
-	// Set HttpOnly cookie
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session",
-		Value:    sessionToken,
-		Path:     "/",
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-		MaxAge:   86400, // 24 hours
-	})
-
-	return sessionToken, nil
+// SetAuditService wires in the audit log for sign-ins, sessions and
+// settings changes.
+func (s *AuthService) SetAuditService(a *AuditService) {
+	s.audit = a
 }
 
 // UserResponse is the API response for user info.
@@ -106,10 +60,10 @@ type UserResponse struct {
 	HasOAuthToken      bool    `json:"has_oauth_token"`
 }
 
-// DevLogin finds or creates a user by email, then issues a session token
-// and sets the session cookie directly — skipping the magic link email.
+// DevLogin finds or creates a user by email, then starts a session and sets
+// the session cookies directly — skipping the magic link email.
 // Only use this in development mode.
-func (s *AuthService) DevLogin(ctx context.Context, w http.ResponseWriter, email string) (string, error) {
+func (s *AuthService) DevLogin(ctx context.Context, w http.ResponseWriter, email string) (*SessionTokens, error) {
 	// Find or create user
 	u, err := s.db.User.Query().Where(entuser.EmailEQ(email)).Only(ctx)
 	if err != nil {
@@ -118,30 +72,14 @@ func (s *AuthService) DevLogin(ctx context.Context, w http.ResponseWriter, email
 				SetEmail(email).
 				Save(ctx)
 			if err != nil {
-				return "", fmt.Errorf("create user: %w", err)
+				return nil, fmt.Errorf("create user: %w", err)
 			}
 		} else {
-			return "", fmt.Errorf("query user: %w", err)
+			return nil, fmt.Errorf("query user: %w", err)
 		}
 	}
 
-	// Generate session JWT (24h)
-	sessionToken, err := auth.GenerateToken(s.jwtSecret, u.ID, u.Email, "session", 24*time.Hour)
-	if err != nil {
-		return "", fmt.Errorf("generate session: %w", err)
-	}
-
-	// Set HttpOnly cookie
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session",
-		Value:    sessionToken,
-		Path:     "/",
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-		MaxAge:   86400,
-	})
-
-	return sessionToken, nil
+	return s.issueSession(ctx, w, u)
 }
 
 // GetCurrentUser returns the user info for the given user ID.
@@ -161,8 +99,8 @@ func (s *AuthService) GetCurrentUser(ctx context.Context, userID int) (*UserResp
 		Plan:               u.Plan,
 		SubscriptionStatus: u.SubscriptionStatus,
 		UsageHours:         u.UsageHours,
-		HasAnthropicKey:    u.AnthropicAPIKey != nil && *u.AnthropicAPIKey != "",
-		HasOAuthToken:      u.ClaudeOauthToken != nil && *u.ClaudeOauthToken != "",
+		HasAnthropicKey:    u.AnthropicAPIKey != "",
+		HasOAuthToken:      u.ClaudeOauthToken != "",
 	}, nil
 }
 
@@ -171,6 +109,7 @@ type SettingsResponse struct {
 	AnthropicAPIKey string `json:"anthropic_api_key"` // masked
 	ClaudeOAuthToken string `json:"claude_oauth_token"` // masked
 	AuthMethod       string `json:"auth_method"`        // "oauth", "api_key", or "none"
+	TerminalRecording string `json:"terminal_recording"` // "off", "output" or "full"
 }
 
 // maskKey returns a masked version of a secret string.
@@ -191,14 +130,14 @@ func (s *AuthService) GetSettings(ctx context.Context, userID int) (*SettingsRes
 		return nil, fmt.Errorf("get user: %w", err)
 	}
 
-	resp := &SettingsResponse{AuthMethod: "none"}
+	resp := &SettingsResponse{AuthMethod: "none", TerminalRecording: string(u.TerminalRecording)}
 
-	if u.ClaudeOauthToken != nil && *u.ClaudeOauthToken != "" {
-		resp.ClaudeOAuthToken = maskKey(*u.ClaudeOauthToken)
+	if u.ClaudeOauthToken != "" {
+		resp.ClaudeOAuthToken = maskKey(u.ClaudeOauthToken)
 		resp.AuthMethod = "oauth"
 	}
-	if u.AnthropicAPIKey != nil && *u.AnthropicAPIKey != "" {
-		resp.AnthropicAPIKey = maskKey(*u.AnthropicAPIKey)
+	if u.AnthropicAPIKey != "" {
+		resp.AnthropicAPIKey = maskKey(u.AnthropicAPIKey)
 		if resp.AuthMethod == "none" {
 			resp.AuthMethod = "api_key"
 		}
@@ -208,9 +147,16 @@ func (s *AuthService) GetSettings(ctx context.Context, userID int) (*SettingsRes
 }
 
 // UpdateSettings saves user settings. Only non-nil fields are updated.
-func (s *AuthService) UpdateSettings(ctx context.Context, userID int, anthropicKey *string, oauthToken *string) error {
+func (s *AuthService) UpdateSettings(ctx context.Context, userID int, anthropicKey *string, oauthToken *string, terminalRecording *string) error {
 	update := s.db.User.UpdateOneID(userID)
 
+	if terminalRecording != nil {
+		if !ValidRecordingMode(*terminalRecording) {
+			return ErrInvalidRecordingMode
+		}
+		update = update.SetTerminalRecording(entuser.TerminalRecording(*terminalRecording))
+	}
+
 	if anthropicKey != nil {
 		if *anthropicKey == "" {
 			update = update.ClearAnthropicAPIKey()
@@ -231,6 +177,28 @@ func (s *AuthService) UpdateSettings(ctx context.Context, userID int, anthropicK
 	if err != nil {
 		return fmt.Errorf("update settings: %w", err)
 	}
+
+	// Which credentials changed, never their values
+	changed := map[string]any{}
+	for name, v := range map[string]*string{"anthropic_api_key": anthropicKey, "claude_oauth_token": oauthToken} {
+		switch {
+		case v == nil:
+		case *v == "":
+			changed[name] = "cleared"
+		default:
+			changed[name] = "set"
+		}
+	}
+	if terminalRecording != nil {
+		changed["terminal_recording"] = *terminalRecording
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Action:     "user.settings",
+		TargetType: "user",
+		TargetID:   strconv.Itoa(userID),
+		SubjectID:  userID,
+		Details:    changed,
+	})
 	return nil
 }
 
@@ -242,11 +210,11 @@ func (s *AuthService) GetClaudeCredentials(ctx context.Context, userID int) (str
 		return "", "", fmt.Errorf("get user: %w", err)
 	}
 	// OAuth token takes priority (uses Max/Pro subscription billing)
-	if u.ClaudeOauthToken != nil && *u.ClaudeOauthToken != "" {
-		return "CLAUDE_CODE_OAUTH_TOKEN", *u.ClaudeOauthToken, nil
+	if u.ClaudeOauthToken != "" {
+		return "CLAUDE_CODE_OAUTH_TOKEN", u.ClaudeOauthToken, nil
 	}
-	if u.AnthropicAPIKey != nil && *u.AnthropicAPIKey != "" {
-		return "ANTHROPIC_API_KEY", *u.AnthropicAPIKey, nil
+	if u.AnthropicAPIKey != "" {
+		return "ANTHROPIC_API_KEY", u.AnthropicAPIKey, nil
 	}
 	return "", "", nil
 }
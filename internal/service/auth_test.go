@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -30,7 +33,7 @@ func TestSendMagicLink_CreatesUser(t *testing.T) {
 	mailer := &mockMailer{}
 	svc := NewAuthService(client, "test-secret", "http://localhost:8080", "http://localhost:3000", mailer)
 
-	err := svc.SendMagicLink(context.Background(), "test@example.com")
+	err := svc.SendMagicLink(context.Background(), httptest.NewRecorder(), "test@example.com", false)
 	if err != nil {
 		t.Fatalf("send: %v", err)
 	}
@@ -60,10 +63,10 @@ func TestSendMagicLink_ExistingUser(t *testing.T) {
 	svc := NewAuthService(client, "test-secret", "http://localhost:8080", "http://localhost:3000", mailer)
 
 	// First call creates user
-	_ = svc.SendMagicLink(context.Background(), "test@example.com")
+	_ = svc.SendMagicLink(context.Background(), httptest.NewRecorder(), "test@example.com", false)
 
 	// Second call finds existing user
-	err := svc.SendMagicLink(context.Background(), "test@example.com")
+	err := svc.SendMagicLink(context.Background(), httptest.NewRecorder(), "test@example.com", false)
 	if err != nil {
 		t.Fatalf("send again: %v", err)
 	}
@@ -84,20 +87,19 @@ func TestVerifyMagicLink(t *testing.T) {
 	svc := NewAuthService(client, secret, "http://localhost:8080", "http://localhost:3000", mailer)
 
 	// Create user via magic link
-	_ = svc.SendMagicLink(context.Background(), "test@example.com")
+	_ = svc.SendMagicLink(context.Background(), httptest.NewRecorder(), "test@example.com", false)
 	u, _ := client.User.Query().Only(context.Background())
 
-	// Generate a magic link token directly
-	token, _ := auth.GenerateToken(secret, u.ID, u.Email, "magic_link", 15*time.Minute)
+	token := linkToken(t, mailer.lastLink)
 
 	w := httptest.NewRecorder()
-	sessionToken, err := svc.VerifyMagicLink(context.Background(), w, token)
+	sessionToken, err := svc.VerifyMagicLink(context.Background(), w, token, "")
 	if err != nil {
 		t.Fatalf("verify: %v", err)
 	}
 
 	// Validate session token
-	claims, err := auth.ValidateToken(secret, sessionToken)
+	claims, err := auth.ValidateToken(secret, sessionToken.AccessToken)
 	if err != nil {
 		t.Fatalf("validate session: %v", err)
 	}
@@ -133,14 +135,14 @@ func TestVerifyMagicLink_SessionTokenRejected(t *testing.T) {
 	svc := NewAuthService(client, secret, "http://localhost:8080", "http://localhost:3000", mailer)
 
 	// Create user
-	_ = svc.SendMagicLink(context.Background(), "test@example.com")
+	_ = svc.SendMagicLink(context.Background(), httptest.NewRecorder(), "test@example.com", false)
 	u, _ := client.User.Query().Only(context.Background())
 
 	// Try to verify with a session token (wrong purpose)
 	token, _ := auth.GenerateToken(secret, u.ID, u.Email, "session", time.Hour)
 
 	w := httptest.NewRecorder()
-	_, err := svc.VerifyMagicLink(context.Background(), w, token)
+	_, err := svc.VerifyMagicLink(context.Background(), w, token, "")
 	if err == nil {
 		t.Fatal("expected error for session token used as magic link")
 	}
@@ -153,7 +155,7 @@ func TestGetCurrentUser(t *testing.T) {
 	mailer := &mockMailer{}
 	svc := NewAuthService(client, "test-secret", "http://localhost:8080", "http://localhost:3000", mailer)
 
-	_ = svc.SendMagicLink(context.Background(), "test@example.com")
+	_ = svc.SendMagicLink(context.Background(), httptest.NewRecorder(), "test@example.com", false)
 	u, _ := client.User.Query().Only(context.Background())
 
 	resp, err := svc.GetCurrentUser(context.Background(), u.ID)
@@ -170,3 +172,126 @@ func TestGetCurrentUser(t *testing.T) {
 		t.Errorf("subscription_status = %s, want inactive", resp.SubscriptionStatus)
 	}
 }
+
+// linkToken returns the token in a magic link.
+func linkToken(t *testing.T, link string) string {
+	t.Helper()
+	u, err := url.Parse(link)
+	if err != nil || u.Query().Get("token") == "" {
+		t.Fatalf("bad magic link %q", link)
+	}
+	return u.Query().Get("token")
+}
+
+func TestVerifyMagicLink_SingleUse(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_magic_once?mode=memory&_fk=1")
+	defer client.Close()
+	mailer := &mockMailer{}
+	svc := NewAuthService(client, "test-secret", "http://localhost:8080", "http://localhost:3000", mailer)
+	ctx := context.Background()
+
+	if err := svc.SendMagicLink(ctx, httptest.NewRecorder(), "test@example.com", false); err != nil {
+		t.Fatal(err)
+	}
+	first := linkToken(t, mailer.lastLink)
+	if err := svc.SendMagicLink(ctx, httptest.NewRecorder(), "test@example.com", false); err != nil {
+		t.Fatal(err)
+	}
+	second := linkToken(t, mailer.lastLink)
+
+	if _, err := svc.VerifyMagicLink(ctx, httptest.NewRecorder(), first, ""); !errors.Is(err, ErrMagicLinkInvalid) {
+		t.Errorf("superseded link: %v", err)
+	}
+	if _, err := svc.VerifyMagicLink(ctx, httptest.NewRecorder(), second, ""); err != nil {
+		t.Fatalf("newest link: %v", err)
+	}
+	if _, err := svc.VerifyMagicLink(ctx, httptest.NewRecorder(), second, ""); !errors.Is(err, ErrMagicLinkInvalid) {
+		t.Errorf("replayed link: %v", err)
+	}
+
+	// Well-signed tokens the server never sent are refused too
+	u := client.User.Query().OnlyX(ctx)
+	forged, _ := auth.GenerateToken("test-secret", u.ID, u.Email, "magic_link", 15*time.Minute)
+	if _, err := svc.VerifyMagicLink(ctx, httptest.NewRecorder(), forged, ""); !errors.Is(err, ErrMagicLinkInvalid) {
+		t.Errorf("untracked token: %v", err)
+	}
+}
+
+func TestVerifyMagicLink_BoundToBrowser(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_magic_nonce?mode=memory&_fk=1")
+	defer client.Close()
+	mailer := &mockMailer{}
+	svc := NewAuthService(client, "test-secret", "http://localhost:8080", "http://localhost:3000", mailer)
+	ctx := context.Background()
+
+	rec := httptest.NewRecorder()
+	if err := svc.SendMagicLink(ctx, rec, "test@example.com", true); err != nil {
+		t.Fatal(err)
+	}
+	var nonce *http.Cookie
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == MagicLinkNonceCookie {
+			nonce = c
+		}
+	}
+	if nonce == nil || !nonce.HttpOnly || nonce.Path != "/auth/verify" {
+		t.Fatalf("nonce cookie = %v", nonce)
+	}
+	token := linkToken(t, mailer.lastLink)
+
+	// A forwarded link fails without the cookie, and stays usable
+	for _, other := range []string{"", "guessed"} {
+		if _, err := svc.VerifyMagicLink(ctx, httptest.NewRecorder(), token, other); !errors.Is(err, ErrMagicLinkInvalid) {
+			t.Errorf("nonce %q: %v", other, err)
+		}
+	}
+	rec = httptest.NewRecorder()
+	if _, err := svc.VerifyMagicLink(ctx, rec, token, nonce.Value); err != nil {
+		t.Fatalf("requesting browser: %v", err)
+	}
+	cleared := false
+	for _, c := range rec.Result().Cookies() {
+		cleared = cleared || (c.Name == MagicLinkNonceCookie && c.MaxAge < 0)
+	}
+	if !cleared {
+		t.Error("nonce cookie not cleared")
+	}
+	if u := client.User.Query().OnlyX(ctx); u.MagicLinkFailures != 0 || u.MagicLinkFailedAt != nil {
+		t.Errorf("failures not reset: %d", u.MagicLinkFailures)
+	}
+}
+
+func TestVerifyMagicLink_Throttled(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_magic_throttle?mode=memory&_fk=1")
+	defer client.Close()
+	mailer := &mockMailer{}
+	svc := NewAuthService(client, "test-secret", "http://localhost:8080", "http://localhost:3000", mailer)
+	ctx := context.Background()
+
+	if err := svc.SendMagicLink(ctx, httptest.NewRecorder(), "test@example.com", false); err != nil {
+		t.Fatal(err)
+	}
+	used := linkToken(t, mailer.lastLink)
+	if _, err := svc.VerifyMagicLink(ctx, httptest.NewRecorder(), used, ""); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxMagicLinkFailures; i++ {
+		if _, err := svc.VerifyMagicLink(ctx, httptest.NewRecorder(), used, ""); !errors.Is(err, ErrMagicLinkInvalid) {
+			t.Fatalf("attempt %d: %v", i, err)
+		}
+	}
+
+	// Even a good link is refused while the account is locked
+	if err := svc.SendMagicLink(ctx, httptest.NewRecorder(), "test@example.com", false); err != nil {
+		t.Fatal(err)
+	}
+	fresh := linkToken(t, mailer.lastLink)
+	if _, err := svc.VerifyMagicLink(ctx, httptest.NewRecorder(), fresh, ""); !errors.Is(err, ErrMagicLinkThrottled) {
+		t.Fatalf("locked account: %v", err)
+	}
+
+	client.User.Update().SetMagicLinkFailedAt(time.Now().Add(-magicLinkFailureWindow)).ExecX(ctx)
+	if _, err := svc.VerifyMagicLink(ctx, httptest.NewRecorder(), fresh, ""); err != nil {
+		t.Errorf("after the window: %v", err)
+	}
+}
@@ -0,0 +1,425 @@
+package service
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelTrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/logan/cloudcode/internal/backup"
+	"github.com/logan/cloudcode/internal/ent"
+	entbackup "github.com/logan/cloudcode/internal/ent/backup"
+	entinstance "github.com/logan/cloudcode/internal/ent/instance"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+var backupTracer = otel.Tracer("cloudcode/service/backup")
+
+// Backup triggers.
+const (
+	BackupManual    = "manual"
+	BackupScheduled = "scheduled"
+)
+
+// Backup statuses.
+const (
+	BackupPending   = "pending"
+	BackupSucceeded = "succeeded"
+	BackupFailed    = "failed"
+)
+
+// ErrBackupNotFound indicates the requested backup does not exist or does not
+// belong to the given instance.
+var ErrBackupNotFound = errors.New("backup not found")
+
+// BackupService archives instance data volumes to an object store and
+// restores them. Archives are gzipped tars produced by the provider's
+// VolumeArchiver. Backup and restore run as jobs; the scheduler enqueues
+// backups for users whose plan has a backup interval.
+type BackupService struct {
+	db        *ent.Client
+	archiver  provider.VolumeArchiver
+	store     backup.Store
+	jobs      *JobService
+	logger    *slog.Logger
+	schedule  map[string]time.Duration // plan → interval between scheduled backups
+	retention int                      // scheduled backups kept per instance
+	interval  time.Duration
+	stopCh    chan struct{}
+}
+
+// NewBackupService creates a new BackupService. Plans missing from schedule
+// get manual backups only; retention < 1 keeps every scheduled backup.
+func NewBackupService(
+	db *ent.Client,
+	archiver provider.VolumeArchiver,
+	store backup.Store,
+	jobs *JobService,
+	logger *slog.Logger,
+	schedule map[string]time.Duration,
+	retention int,
+	interval time.Duration,
+) *BackupService {
+	return &BackupService{
+		db:        db,
+		archiver:  archiver,
+		store:     store,
+		jobs:      jobs,
+		logger:    logger,
+		schedule:  schedule,
+		retention: retention,
+		interval:  interval,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// ParseBackupSchedule parses a comma-separated list of plan=interval pairs,
+// e.g. "starter=24h,pro=6h".
+func ParseBackupSchedule(s string) (map[string]time.Duration, error) {
+	return parsePlanDurations(s, "interval")
+}
+
+// parsePlanDurations parses a comma-separated list of plan=duration pairs.
+// what names the duration in errors.
+func parsePlanDurations(s, what string) (map[string]time.Duration, error) {
+	durations := make(map[string]time.Duration)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		plan, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid entry %q: want plan=%s", entry, what)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid %s for plan %q: %q", what, plan, value)
+		}
+		durations[strings.TrimSpace(plan)] = d
+	}
+	return durations, nil
+}
+
+// BackupResponse is the API response for a backup.
+type BackupResponse struct {
+	ID         int        `json:"id"`
+	InstanceID int        `json:"instance_id"`
+	Status     string     `json:"status"`
+	Trigger    string     `json:"trigger"`
+	SizeBytes  int64      `json:"size_bytes"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+func toBackupResponse(b *ent.Backup) *BackupResponse {
+	return &BackupResponse{
+		ID:         b.ID,
+		InstanceID: b.InstanceID,
+		Status:     b.Status,
+		Trigger:    b.Trigger,
+		SizeBytes:  b.SizeBytes,
+		Error:      b.Error,
+		CreatedAt:  b.CreatedAt,
+		FinishedAt: b.FinishedAt,
+	}
+}
+
+// List returns the backups of an instance, newest first. If userID is
+// non-zero, that user must be able to manage the instance.
+func (s *BackupService) List(ctx context.Context, instanceID int, userID int) ([]*BackupResponse, error) {
+	if _, err := authorizeInstance(ctx, s.db, instanceID, userID, AccessManage); err != nil {
+		return nil, err
+	}
+
+	backups, err := s.db.Backup.Query().
+		Where(entbackup.InstanceIDEQ(instanceID)).
+		Order(ent.Desc(entbackup.FieldCreatedAt), ent.Desc(entbackup.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list backups: %w", err)
+	}
+
+	out := make([]*BackupResponse, len(backups))
+	for i, b := range backups {
+		out[i] = toBackupResponse(b)
+	}
+	return out, nil
+}
+
+// create records a pending backup of inst. The archive is written by Run.
+func (s *BackupService) create(ctx context.Context, inst *ent.Instance, ownerID int, trigger string) (*ent.Backup, error) {
+	key := fmt.Sprintf("users/%d/instances/%d/%s.tar.gz",
+		ownerID, inst.ID, time.Now().UTC().Format("20060102T150405.000000000Z"))
+	return s.db.Backup.Create().
+		SetInstanceID(inst.ID).
+		SetVolumeID(inst.VolumeID).
+		SetKey(key).
+		SetTrigger(trigger).
+		SetOwnerID(ownerID).
+		Save(ctx)
+}
+
+// Run archives the instance volume for a pending backup. Re-running a
+// succeeded backup is a no-op, so retried jobs do not upload twice.
+func (s *BackupService) Run(ctx context.Context, backupID int) error {
+	ctx, span := backupTracer.Start(ctx, "backup.run",
+		otelTrace.WithAttributes(attribute.Int("backup_id", backupID)))
+	defer span.End()
+
+	b, err := s.db.Backup.Get(ctx, backupID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return ErrBackupNotFound
+		}
+		return fmt.Errorf("get backup: %w", err)
+	}
+	if b.Status == BackupSucceeded {
+		return nil
+	}
+	if b.VolumeID == "" {
+		return fmt.Errorf("backup %d has no volume: %w", b.ID, provider.ErrInvalidState)
+	}
+
+	ReportProgress(ctx, "archiving")
+	size, err := s.upload(ctx, b.VolumeID, b.Key)
+	if err != nil {
+		if uerr := b.Update().SetStatus(BackupFailed).SetError(err.Error()).Exec(ctx); uerr != nil {
+			s.logger.Warn("failed to record backup failure", "backup_id", b.ID, "error", uerr)
+		}
+		return fmt.Errorf("archive volume: %w", err)
+	}
+
+	err = b.Update().
+		SetStatus(BackupSucceeded).
+		SetSizeBytes(size).
+		ClearError().
+		SetFinishedAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("save backup: %w", err)
+	}
+	s.logger.Info("backup succeeded", "backup_id", b.ID, "instance_id", b.InstanceID, "size_bytes", size)
+
+	if b.Trigger == BackupScheduled {
+		ReportProgress(ctx, "pruning")
+		if err := s.prune(ctx, b.InstanceID); err != nil {
+			s.logger.Warn("failed to prune backups", "instance_id", b.InstanceID, "error", err)
+		}
+	}
+	return nil
+}
+
+// upload streams the gzipped volume archive to the store under key.
+func (s *BackupService) upload(ctx context.Context, volumeID, key string) (int64, error) {
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		gz := gzip.NewWriter(pw)
+		err := s.archiver.ExportVolume(ctx, volumeID, gz)
+		if err == nil {
+			err = gz.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	n, err := s.store.Put(ctx, key, pr)
+	// Unblock the exporter if Put returned before draining the pipe
+	pr.Close()
+	<-done
+	return n, err
+}
+
+// Restore replaces the instance volume with the contents of a backup. The
+// instance must be stopped; callers wake it afterwards.
+func (s *BackupService) Restore(ctx context.Context, instanceID, backupID int) error {
+	ctx, span := backupTracer.Start(ctx, "backup.restore",
+		otelTrace.WithAttributes(attribute.Int("instance_id", instanceID), attribute.Int("backup_id", backupID)))
+	defer span.End()
+
+	inst, err := s.db.Instance.Get(ctx, instanceID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return provider.ErrNotFound
+		}
+		return fmt.Errorf("get instance: %w", err)
+	}
+	if inst.Status != "stopped" {
+		return provider.ErrInvalidState
+	}
+
+	b, err := s.restorable(ctx, instanceID, backupID)
+	if err != nil {
+		return err
+	}
+
+	ReportProgress(ctx, "downloading")
+	rc, err := s.store.Get(ctx, b.Key)
+	if err != nil {
+		return fmt.Errorf("open archive: %w", err)
+	}
+	defer rc.Close()
+
+	gz, err := gzip.NewReader(rc)
+	if err != nil {
+		return fmt.Errorf("read archive: %w", err)
+	}
+	defer gz.Close()
+
+	ReportProgress(ctx, "restoring")
+	if err := s.archiver.ImportVolume(ctx, inst.VolumeID, gz); err != nil {
+		return fmt.Errorf("import volume: %w", err)
+	}
+	s.logger.Info("backup restored", "backup_id", b.ID, "instance_id", instanceID)
+	return nil
+}
+
+// restorable returns the backup if it belongs to the instance and completed.
+func (s *BackupService) restorable(ctx context.Context, instanceID, backupID int) (*ent.Backup, error) {
+	b, err := s.db.Backup.Query().
+		Where(entbackup.IDEQ(backupID), entbackup.InstanceIDEQ(instanceID)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrBackupNotFound
+		}
+		return nil, fmt.Errorf("get backup: %w", err)
+	}
+	if b.Status != BackupSucceeded {
+		return nil, provider.ErrInvalidState
+	}
+	return b, nil
+}
+
+// prune deletes succeeded scheduled backups of the instance beyond the
+// retention count, oldest first. Manual backups are never pruned.
+func (s *BackupService) prune(ctx context.Context, instanceID int) error {
+	if s.retention < 1 {
+		return nil
+	}
+	old, err := s.db.Backup.Query().
+		Where(
+			entbackup.InstanceIDEQ(instanceID),
+			entbackup.TriggerEQ(BackupScheduled),
+			entbackup.StatusEQ(BackupSucceeded),
+		).
+		Order(ent.Desc(entbackup.FieldCreatedAt), ent.Desc(entbackup.FieldID)).
+		Offset(s.retention).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("query old backups: %w", err)
+	}
+
+	for _, b := range old {
+		if err := s.store.Delete(ctx, b.Key); err != nil {
+			return fmt.Errorf("delete archive %d: %w", b.ID, err)
+		}
+		if err := s.db.Backup.DeleteOne(b).Exec(ctx); err != nil {
+			return fmt.Errorf("delete backup %d: %w", b.ID, err)
+		}
+	}
+	return nil
+}
+
+// Start begins the scheduling loop in a goroutine.
+func (s *BackupService) Start() {
+	go s.run()
+	s.logger.Info("backup service started", "interval", s.interval, "plans", len(s.schedule), "retention", s.retention)
+}
+
+// Stop signals the scheduling loop to stop.
+func (s *BackupService) Stop() {
+	close(s.stopCh)
+	s.logger.Info("backup service stopped")
+}
+
+func (s *BackupService) run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			if n, err := s.ScheduleDue(ctx); err != nil {
+				s.logger.Error("backup scheduling failed", "error", err)
+			} else if n > 0 {
+				s.logger.Info("scheduled backups", "count", n)
+			}
+			cancel()
+		}
+	}
+}
+
+// ScheduleDue enqueues a scheduled backup for every live instance whose
+// owner's plan interval has elapsed since its last scheduled backup.
+// Returns the number of backups enqueued.
+func (s *BackupService) ScheduleDue(ctx context.Context) (int, error) {
+	if len(s.schedule) == 0 {
+		return 0, nil
+	}
+
+	instances, err := s.db.Instance.Query().
+		Where(
+			entinstance.StatusIn("running", "stopped"),
+			entinstance.VolumeIDNEQ(""),
+		).
+		WithOwner().
+		All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("query instances: %w", err)
+	}
+
+	// Owners on an organization's paid plan are scheduled by that plan
+	plans := make(map[int]string)
+	enqueued := 0
+	for _, inst := range instances {
+		ownerID := inst.Edges.Owner.ID
+		plan, ok := plans[ownerID]
+		if !ok {
+			if plan, err = effectivePlan(ctx, s.db, ownerID); err != nil {
+				return enqueued, err
+			}
+			plans[ownerID] = plan
+		}
+		interval, ok := s.schedule[plan]
+		if !ok {
+			continue
+		}
+
+		// Pending backups count too, so a slow run is not scheduled twice
+		last, err := s.db.Backup.Query().
+			Where(
+				entbackup.InstanceIDEQ(inst.ID),
+				entbackup.TriggerEQ(BackupScheduled),
+				entbackup.StatusIn(BackupPending, BackupSucceeded),
+			).
+			Order(ent.Desc(entbackup.FieldCreatedAt)).
+			First(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			return enqueued, fmt.Errorf("query last backup: %w", err)
+		}
+		if last != nil && time.Since(last.CreatedAt) < interval {
+			continue
+		}
+
+		if _, err := s.jobs.EnqueueBackup(ctx, inst.ID, 0, BackupScheduled); err != nil {
+			s.logger.Warn("failed to enqueue scheduled backup", "instance_id", inst.ID, "error", err)
+			continue
+		}
+		enqueued++
+	}
+	return enqueued, nil
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/backup"
+	"github.com/logan/cloudcode/internal/ent"
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	entmembership "github.com/logan/cloudcode/internal/ent/membership"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+func setupBackupTest(t *testing.T, dsn string, schedule map[string]time.Duration, retention int) (*BackupService, *JobService, *provider.MockProvisioner, *ent.Client) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { client.Close() })
+
+	store, err := backup.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("local store: %v", err)
+	}
+	mock := provider.NewMock()
+	instSvc := NewInstanceService(client, mock, "")
+	jobs := NewJobService(client, instSvc, slog.Default(), 1, time.Hour)
+	jobs.retryBackoff = 0
+	backups := NewBackupService(client, mock, store, jobs, slog.Default(), schedule, retention, time.Hour)
+	jobs.SetBackupService(backups)
+	return backups, jobs, mock, client
+}
+
+func runJob(t *testing.T, jobs *JobService, id int) *JobResponse {
+	t.Helper()
+	ctx := context.Background()
+	if !jobs.RunNext(ctx) {
+		t.Fatal("expected a job to run")
+	}
+	j, err := jobs.Get(ctx, id, 0)
+	if err != nil {
+		t.Fatalf("get job: %v", err)
+	}
+	return j
+}
+
+func TestBackupService_BackupAndRestore(t *testing.T) {
+	backups, jobs, mock, client := setupBackupTest(t, "file:ent_backup?mode=memory&_fk=1", nil, 0)
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	instSvc := NewInstanceService(client, mock, "")
+	inst, err := instSvc.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	mock.SetVolumeData(inst.VolumeID, []byte("original"))
+	audit := NewAuditService(client)
+	jobs.SetAuditService(audit)
+
+	job, err := jobs.EnqueueBackup(ctx, inst.ID, userID, BackupManual)
+	if err != nil {
+		t.Fatalf("enqueue backup: %v", err)
+	}
+	if job.TargetID == nil {
+		t.Fatal("expected target_id to reference the backup")
+	}
+	events, err := audit.List(ctx, AuditFilter{Action: "instance.backup"}, PageRequest{})
+	if err != nil || events.Total != 1 {
+		t.Fatalf("backup audit events = %+v, %v; want one", events, err)
+	}
+	if d := events.Items[0].Details; d["trigger"] != BackupManual || d["backup_id"] != float64(*job.TargetID) {
+		t.Errorf("backup audit details = %v", d)
+	}
+	if got := runJob(t, jobs, job.ID); got.Status != JobSucceeded {
+		t.Fatalf("backup job status = %q (error: %s)", got.Status, got.Error)
+	}
+
+	list, err := backups.List(ctx, inst.ID, userID)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(list) != 1 || list[0].Status != BackupSucceeded || list[0].SizeBytes == 0 {
+		t.Fatalf("unexpected backups: %+v", list)
+	}
+	backupID := list[0].ID
+
+	// Restore requires a stopped instance
+	if _, err := jobs.EnqueueRestore(ctx, inst.ID, userID, backupID); !errors.Is(err, provider.ErrInvalidState) {
+		t.Fatalf("expected ErrInvalidState restoring a running instance, got %v", err)
+	}
+	if err := instSvc.Pause(ctx, inst.ID); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	mock.SetVolumeData(inst.VolumeID, []byte("clobbered"))
+
+	job, err = jobs.EnqueueRestore(ctx, inst.ID, userID, backupID)
+	if err != nil {
+		t.Fatalf("enqueue restore: %v", err)
+	}
+	if got := runJob(t, jobs, job.ID); got.Status != JobSucceeded {
+		t.Fatalf("restore job status = %q (error: %s)", got.Status, got.Error)
+	}
+
+	if got := string(mock.VolumeData(inst.VolumeID)); got != "original" {
+		t.Errorf("volume after restore = %q, want %q", got, "original")
+	}
+	row, _ := client.Instance.Get(ctx, inst.ID)
+	if row.Status != "running" {
+		t.Errorf("instance status after restore = %q, want running", row.Status)
+	}
+}
+
+func TestBackupService_OwnershipAndMissingBackup(t *testing.T) {
+	backups, jobs, mock, client := setupBackupTest(t, "file:ent_backup_owner?mode=memory&_fk=1", nil, 0)
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	inst, err := NewInstanceService(client, mock, "").Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if _, err := backups.List(ctx, inst.ID, userID+1); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("expected ErrNotFound listing another user's backups, got %v", err)
+	}
+	if _, err := jobs.EnqueueBackup(ctx, inst.ID, userID+1, BackupManual); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("expected ErrNotFound backing up another user's instance, got %v", err)
+	}
+
+	client.Instance.UpdateOneID(inst.ID).SetStatus("stopped").ExecX(ctx)
+	if _, err := jobs.EnqueueRestore(ctx, inst.ID, userID, 999); !errors.Is(err, ErrBackupNotFound) {
+		t.Errorf("expected ErrBackupNotFound, got %v", err)
+	}
+}
+
+func TestBackupService_ScheduleAndPrune(t *testing.T) {
+	schedule := map[string]time.Duration{"pro": time.Hour}
+	backups, jobs, mock, client := setupBackupTest(t, "file:ent_backup_sched?mode=memory&_fk=1", schedule, 2)
+	ctx := context.Background()
+
+	proUser := client.User.Create().SetEmail("pro@example.com").SetPlan("pro").SaveX(ctx)
+	freeUser := client.User.Create().SetEmail("free@example.com").SaveX(ctx)
+	instSvc := NewInstanceService(client, mock, "")
+	proInst, err := instSvc.Create(ctx, proUser.ID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create pro: %v", err)
+	}
+	if _, err := instSvc.Create(ctx, freeUser.ID, InstanceSpec{}); err != nil {
+		t.Fatalf("create free: %v", err)
+	}
+	// A free user on a paying organization's plan is scheduled by it
+	orgUser := client.User.Create().SetEmail("org@example.com").SaveX(ctx)
+	org := client.Organization.Create().SetName("acme").SetPlan("pro").SetSubscriptionStatus("active").SaveX(ctx)
+	client.Membership.Create().SetUser(orgUser).SetOrganization(org).SetRole(entmembership.RoleMember).SaveX(ctx)
+	if _, err := instSvc.Create(ctx, orgUser.ID, InstanceSpec{}); err != nil {
+		t.Fatalf("create org member: %v", err)
+	}
+
+	// Two earlier scheduled backups; the newest is older than the interval
+	var oldIDs []int
+	for _, age := range []time.Duration{5 * time.Hour, 3 * time.Hour} {
+		b := client.Backup.Create().
+			SetInstanceID(proInst.ID).
+			SetVolumeID(proInst.VolumeID).
+			SetKey("old/" + age.String()).
+			SetStatus(BackupSucceeded).
+			SetTrigger(BackupScheduled).
+			SetCreatedAt(time.Now().Add(-age)).
+			SetOwnerID(proUser.ID).
+			SaveX(ctx)
+		oldIDs = append(oldIDs, b.ID)
+	}
+
+	n, err := backups.ScheduleDue(ctx)
+	if err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 scheduled backups (pro and org member), got %d", n)
+	}
+	for jobs.RunNext(ctx) {
+	}
+
+	// Not due again until the interval has elapsed
+	if n, _ := backups.ScheduleDue(ctx); n != 0 {
+		t.Errorf("expected no backup before interval, got %d", n)
+	}
+
+	list, err := backups.List(ctx, proInst.ID, 0)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected retention to keep 2 backups, got %d", len(list))
+	}
+	if list[0].Status != BackupSucceeded {
+		t.Errorf("newest backup status = %q, want succeeded", list[0].Status)
+	}
+	if list[1].ID != oldIDs[1] {
+		t.Errorf("expected the oldest backup to be pruned, kept %d", list[1].ID)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/stripe/stripe-go/v82/webhook"
 
 	"github.com/logan/cloudcode/internal/ent"
+	entorganization "github.com/logan/cloudcode/internal/ent/organization"
 	entuser "github.com/logan/cloudcode/internal/ent/user"
 )
 
@@ -24,6 +25,7 @@ import (
 type BillingService struct {
 	db            *ent.Client
 	instanceSvc   *InstanceService
+	jobs          *JobService // nil runs instance operations inline
 	webhookSecret string
 	priceStarter  string
 	pricePro      string
@@ -35,6 +37,7 @@ type BillingService struct {
 func NewBillingService(
 	db *ent.Client,
 	instanceSvc *InstanceService,
+	jobs *JobService,
 	stripeKey string,
 	webhookSecret string,
 	priceStarter string,
@@ -46,6 +49,7 @@ func NewBillingService(
 	return &BillingService{
 		db:            db,
 		instanceSvc:   instanceSvc,
+		jobs:          jobs,
 		webhookSecret: webhookSecret,
 		priceStarter:  priceStarter,
 		pricePro:      pricePro,
@@ -95,6 +99,54 @@ func (s *BillingService) CreateCheckoutSession(ctx context.Context, userID int,
 		}
 	}
 
+	return s.checkoutURL(customerID, plan, map[string]string{
+		"user_id": fmt.Sprintf("%d", userID),
+		"plan":    plan,
+	})
+}
+
+// CreateOrgCheckoutSession creates a Stripe Checkout session that puts the
+// organization on the given plan. The caller checks the user may manage the
+// organization's billing.
+func (s *BillingService) CreateOrgCheckoutSession(ctx context.Context, orgID int, plan string) (string, error) {
+	ctx, span := billingTracer.Start(ctx, "billing.org_checkout",
+		otelTrace.WithAttributes(attribute.Int("org_id", orgID), attribute.String("plan", plan)))
+	defer span.End()
+
+	o, err := s.db.Organization.Get(ctx, orgID)
+	if err != nil {
+		return "", fmt.Errorf("get organization: %w", err)
+	}
+
+	customerID := ""
+	if o.StripeCustomerID != nil {
+		customerID = *o.StripeCustomerID
+	}
+	if customerID == "" {
+		params := &stripe.CustomerParams{
+			Name: stripe.String(o.Name),
+		}
+		params.AddMetadata("org_id", fmt.Sprintf("%d", o.ID))
+		c, err := customer.New(params)
+		if err != nil {
+			return "", fmt.Errorf("create customer: %w", err)
+		}
+		customerID = c.ID
+		_, err = o.Update().SetStripeCustomerID(customerID).Save(ctx)
+		if err != nil {
+			return "", fmt.Errorf("save customer id: %w", err)
+		}
+	}
+
+	return s.checkoutURL(customerID, plan, map[string]string{
+		"org_id": fmt.Sprintf("%d", orgID),
+		"plan":   plan,
+	})
+}
+
+// checkoutURL creates a subscription Checkout session for the customer and
+// returns its URL. metadata comes back on checkout.session.completed.
+func (s *BillingService) checkoutURL(customerID, plan string, metadata map[string]string) (string, error) {
 	// Select price
 	priceID := s.priceStarter
 	if plan == "pro" {
@@ -113,8 +165,9 @@ func (s *BillingService) CreateCheckoutSession(ctx context.Context, userID int,
 		SuccessURL: stripe.String(s.frontendURL + "/dashboard?checkout=success"),
 		CancelURL:  stripe.String(s.frontendURL + "/dashboard?checkout=cancel"),
 	}
-	params.AddMetadata("user_id", fmt.Sprintf("%d", userID))
-	params.AddMetadata("plan", plan)
+	for k, v := range metadata {
+		params.AddMetadata(k, v)
+	}
 
 	sess, err := session.New(params)
 	if err != nil {
@@ -162,6 +215,29 @@ func (s *BillingService) GetBillingPortalURL(ctx context.Context, userID int) (s
 	return sess.URL, nil
 }
 
+// GetOrgBillingPortalURL creates a Stripe billing portal session for the
+// organization. The caller checks the user may manage its billing.
+func (s *BillingService) GetOrgBillingPortalURL(ctx context.Context, orgID int) (string, error) {
+	o, err := s.db.Organization.Get(ctx, orgID)
+	if err != nil {
+		return "", fmt.Errorf("get organization: %w", err)
+	}
+
+	if o.StripeCustomerID == nil || *o.StripeCustomerID == "" {
+		return "", fmt.Errorf("no billing account")
+	}
+
+	sess, err := portalsession.New(&stripe.BillingPortalSessionParams{
+		Customer:  o.StripeCustomerID,
+		ReturnURL: stripe.String(s.frontendURL + "/dashboard"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("create portal: %w", err)
+	}
+
+	return sess.URL, nil
+}
+
 // HandleWebhookEvent verifies and processes a Stripe webhook event.
 func (s *BillingService) HandleWebhookEvent(payload []byte, sigHeader string) error {
 	ctx, span := billingTracer.Start(context.Background(), "billing.webhook")
@@ -209,6 +285,9 @@ func (s *BillingService) handleCheckoutCompleted(ctx context.Context, event stri
 	if plan == "" {
 		plan = "starter"
 	}
+	if orgIDStr := sess.Metadata["org_id"]; orgIDStr != "" {
+		return s.handleOrgCheckoutCompleted(ctx, &sess, orgIDStr, plan)
+	}
 
 	userID, err := ParseID(userIDStr)
 	if err != nil {
@@ -235,8 +314,14 @@ func (s *BillingService) handleCheckoutCompleted(ctx context.Context, event stri
 		return fmt.Errorf("update user: %w", err)
 	}
 
-	// Auto-provision instance
-	if _, provErr := s.instanceSvc.Create(ctx, userID); provErr != nil {
+	// Auto-provision instance (queued so the webhook returns before Stripe times out)
+	var provErr error
+	if s.jobs != nil {
+		_, provErr = s.jobs.EnqueueCreate(ctx, userID, InstanceSpec{})
+	} else {
+		_, provErr = s.instanceSvc.Create(ctx, userID, InstanceSpec{})
+	}
+	if provErr != nil {
 		s.logger.Error("auto-provision failed after checkout", "user_id", userID, "error", provErr)
 	}
 
@@ -244,6 +329,43 @@ func (s *BillingService) handleCheckoutCompleted(ctx context.Context, event stri
 	return nil
 }
 
+// handleOrgCheckoutCompleted puts the organization on the plan. Members
+// create their own instances, so nothing is provisioned.
+func (s *BillingService) handleOrgCheckoutCompleted(ctx context.Context, sess *stripe.CheckoutSession, orgIDStr, plan string) error {
+	orgID, err := ParseID(orgIDStr)
+	if err != nil {
+		return fmt.Errorf("parse org_id: %w", err)
+	}
+
+	update := s.db.Organization.UpdateOneID(orgID).
+		SetSubscriptionStatus("active").
+		SetPlan(plan)
+	if sess.Subscription != nil {
+		update = update.SetStripeSubscriptionID(sess.Subscription.ID)
+	}
+	if sess.Customer != nil {
+		update = update.SetStripeCustomerID(sess.Customer.ID)
+	}
+	if _, err := update.Save(ctx); err != nil {
+		return fmt.Errorf("update organization: %w", err)
+	}
+
+	s.logger.Info("checkout completed", "org_id", orgID, "plan", plan)
+	return nil
+}
+
+// orgByCustomer finds the organization a Stripe customer belongs to, for
+// events that match no user.
+func (s *BillingService) orgByCustomer(ctx context.Context, customerID string) (*ent.Organization, error) {
+	o, err := s.db.Organization.Query().
+		Where(entorganization.StripeCustomerID(customerID)).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find user or organization by customer: %w", err)
+	}
+	return o, nil
+}
+
 func (s *BillingService) handleSubscriptionUpdated(ctx context.Context, event stripe.Event) error {
 	var sub stripe.Subscription
 	if err := json.Unmarshal(event.Data.Raw, &sub); err != nil {
@@ -257,6 +379,21 @@ func (s *BillingService) handleSubscriptionUpdated(ctx context.Context, event st
 	u, err := s.db.User.Query().
 		Where(entuser.StripeCustomerID(sub.Customer.ID)).
 		Only(ctx)
+	if ent.IsNotFound(err) {
+		o, err := s.orgByCustomer(ctx, sub.Customer.ID)
+		if err != nil {
+			return err
+		}
+		_, err = o.Update().
+			SetSubscriptionStatus(string(sub.Status)).
+			SetStripeSubscriptionID(sub.ID).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("update subscription status: %w", err)
+		}
+		s.logger.Info("subscription updated", "org_id", o.ID, "status", sub.Status)
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("find user by customer: %w", err)
 	}
@@ -286,6 +423,9 @@ func (s *BillingService) handleSubscriptionDeleted(ctx context.Context, event st
 	u, err := s.db.User.Query().
 		Where(entuser.StripeCustomerID(sub.Customer.ID)).
 		Only(ctx)
+	if ent.IsNotFound(err) {
+		return s.handleOrgSubscriptionDeleted(ctx, sub.Customer.ID)
+	}
 	if err != nil {
 		return fmt.Errorf("find user by customer: %w", err)
 	}
@@ -298,11 +438,23 @@ func (s *BillingService) handleSubscriptionDeleted(ctx context.Context, event st
 		return fmt.Errorf("update subscription status: %w", err)
 	}
 
-	// Pause active instance
-	inst, err := s.instanceSvc.GetByUserID(ctx, u.ID)
-	if err == nil && inst.Status == "running" {
-		if pauseErr := s.instanceSvc.Pause(ctx, inst.ID); pauseErr != nil {
-			s.logger.Error("failed to pause instance on subscription delete", "user_id", u.ID, "error", pauseErr)
+	// Pause active instances
+	instances, err := s.instanceSvc.List(ctx, u.ID)
+	if err != nil {
+		s.logger.Error("failed to list instances on subscription delete", "user_id", u.ID, "error", err)
+	}
+	for _, inst := range instances {
+		if inst.Status != "running" {
+			continue
+		}
+		var pauseErr error
+		if s.jobs != nil {
+			_, pauseErr = s.jobs.EnqueueInstanceOp(ctx, JobPause, inst.ID)
+		} else {
+			pauseErr = s.instanceSvc.Pause(ctx, inst.ID)
+		}
+		if pauseErr != nil {
+			s.logger.Error("failed to pause instance on subscription delete", "user_id", u.ID, "instance_id", inst.ID, "error", pauseErr)
 		}
 	}
 
@@ -310,6 +462,28 @@ func (s *BillingService) handleSubscriptionDeleted(ctx context.Context, event st
 	return nil
 }
 
+// handleOrgSubscriptionDeleted drops the organization back to the free
+// plan. Members' instances keep running on their own plans, so nothing is
+// paused.
+func (s *BillingService) handleOrgSubscriptionDeleted(ctx context.Context, customerID string) error {
+	o, err := s.orgByCustomer(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.Update().
+		SetSubscriptionStatus("canceled").
+		SetPlan("free").
+		ClearStripeSubscriptionID().
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("update subscription status: %w", err)
+	}
+
+	s.logger.Info("subscription deleted", "org_id", o.ID)
+	return nil
+}
+
 func (s *BillingService) handlePaymentFailed(ctx context.Context, event stripe.Event) error {
 	var invoice stripe.Invoice
 	if err := json.Unmarshal(event.Data.Raw, &invoice); err != nil {
@@ -323,6 +497,17 @@ func (s *BillingService) handlePaymentFailed(ctx context.Context, event stripe.E
 	u, err := s.db.User.Query().
 		Where(entuser.StripeCustomerID(invoice.Customer.ID)).
 		Only(ctx)
+	if ent.IsNotFound(err) {
+		o, err := s.orgByCustomer(ctx, invoice.Customer.ID)
+		if err != nil {
+			return err
+		}
+		if err := o.Update().SetSubscriptionStatus("past_due").Exec(ctx); err != nil {
+			return fmt.Errorf("update subscription status: %w", err)
+		}
+		s.logger.Warn("payment failed", "org_id", o.ID)
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("find user by customer: %w", err)
 	}
@@ -99,7 +99,7 @@ func TestProcessEvent_SubscriptionDeleted(t *testing.T) {
 		Save(ctx)
 
 	// Create an instance for the user (so we can test pause)
-	_, err := svc.instanceSvc.Create(ctx, u.ID)
+	_, err := svc.instanceSvc.Create(ctx, u.ID, InstanceSpec{})
 	if err != nil {
 		t.Fatalf("create instance: %v", err)
 	}
@@ -129,7 +129,7 @@ func TestProcessEvent_SubscriptionDeleted(t *testing.T) {
 	}
 
 	// Verify instance was paused
-	inst, _ := svc.instanceSvc.GetByUserID(ctx, u.ID)
+	inst, _ := svc.instanceSvc.GetByUserID(ctx, u.ID, "")
 	if inst != nil && inst.Status != "stopped" {
 		t.Errorf("instance status = %s, want stopped", inst.Status)
 	}
@@ -168,6 +168,72 @@ func TestProcessEvent_PaymentFailed(t *testing.T) {
 	}
 }
 
+func TestProcessEvent_OrgSubscription(t *testing.T) {
+	svc, _ := newTestBillingService(t)
+	ctx := context.Background()
+
+	owner := createTestUser(t, svc.db)
+	org, err := NewOrgService(svc.db).Create(ctx, owner, "Acme")
+	if err != nil {
+		t.Fatalf("create org: %v", err)
+	}
+
+	event := func(typ string, data map[string]interface{}) stripe.Event {
+		raw, _ := json.Marshal(data)
+		return stripe.Event{Type: stripe.EventType(typ), Data: &stripe.EventData{Raw: json.RawMessage(raw)}}
+	}
+
+	// Checkout puts the organization on the plan without provisioning anything
+	if err := svc.processEvent(event("checkout.session.completed", map[string]interface{}{
+		"id":           "cs_org",
+		"customer":     map[string]interface{}{"id": "cus_org"},
+		"subscription": map[string]interface{}{"id": "sub_org"},
+		"metadata":     map[string]string{"org_id": formatID(org.ID), "plan": "pro"},
+	})); err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+	o := svc.db.Organization.GetX(ctx, org.ID)
+	if o.Plan != "pro" || o.SubscriptionStatus != "active" || o.StripeSubscriptionID == nil || *o.StripeSubscriptionID != "sub_org" {
+		t.Errorf("after checkout: plan %q, status %q", o.Plan, o.SubscriptionStatus)
+	}
+	if n := svc.db.Instance.Query().CountX(ctx); n != 0 {
+		t.Errorf("instances = %d, want 0", n)
+	}
+	if u := svc.db.User.GetX(ctx, owner); u.Plan != "free" {
+		t.Errorf("owner plan = %q, want free", u.Plan)
+	}
+
+	// Events for the organization's customer find the organization
+	if err := svc.processEvent(event("invoice.payment_failed", map[string]interface{}{
+		"id":       "in_org",
+		"customer": map[string]interface{}{"id": "cus_org"},
+	})); err != nil {
+		t.Fatalf("payment failed: %v", err)
+	}
+	if o := svc.db.Organization.GetX(ctx, org.ID); o.SubscriptionStatus != "past_due" {
+		t.Errorf("after payment failure: status %q", o.SubscriptionStatus)
+	}
+	if err := svc.processEvent(event("customer.subscription.deleted", map[string]interface{}{
+		"id":       "sub_org",
+		"customer": map[string]interface{}{"id": "cus_org"},
+		"status":   "canceled",
+	})); err != nil {
+		t.Fatalf("subscription deleted: %v", err)
+	}
+	o = svc.db.Organization.GetX(ctx, org.ID)
+	if o.Plan != "free" || o.SubscriptionStatus != "canceled" || o.StripeSubscriptionID != nil {
+		t.Errorf("after cancel: plan %q, status %q", o.Plan, o.SubscriptionStatus)
+	}
+
+	// Unknown customers are still an error
+	if err := svc.processEvent(event("invoice.payment_failed", map[string]interface{}{
+		"id":       "in_x",
+		"customer": map[string]interface{}{"id": "cus_unknown"},
+	})); err == nil {
+		t.Error("unknown customer accepted")
+	}
+}
+
 func TestReportUsage(t *testing.T) {
 	svc, _ := newTestBillingService(t)
 	ctx := context.Background()
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"path"
 	"time"
@@ -10,9 +11,15 @@ import (
 	"github.com/logan/cloudcode/internal/ent"
 	"github.com/logan/cloudcode/internal/ent/chatmessage"
 	"github.com/logan/cloudcode/internal/ent/conversation"
+	entinstance "github.com/logan/cloudcode/internal/ent/instance"
 	entuser "github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/provider"
 )
 
+// ErrConversationNotFound indicates the conversation does not exist or the
+// user cannot see it.
+var ErrConversationNotFound = errors.New("conversation not found")
+
 // ConversationService manages chat conversations and messages.
 type ConversationService struct {
 	db *ent.Client
@@ -65,20 +72,77 @@ func toChatMessageResponse(m *ent.ChatMessage) *ChatMessageResponse {
 }
 
 // GetOrCreateByProject returns the conversation for a user+project, creating it if needed.
-func (s *ConversationService) GetOrCreateByProject(ctx context.Context, userID int, projectPath string) (*ConversationResponse, error) {
+// With a non-zero instanceID the conversation is the one the instance owner
+// holds for the project on that instance, so everyone the instance is shared
+// with sees the same history; creating it then takes write access to the
+// instance. A conversation from before conversations were held on
+// instances moves to the first instance it is asked for on.
+func (s *ConversationService) GetOrCreateByProject(ctx context.Context, userID int, instanceID int, projectPath string) (*ConversationResponse, error) {
+	ownerID := userID
+	access := AccessManage
+	onInstance := conversation.Not(conversation.HasInstance())
+	if instanceID != 0 {
+		var inst *ent.Instance
+		var err error
+		access, inst, err = instanceAccess(ctx, s.db, instanceID, userID)
+		if err != nil && !errors.Is(err, provider.ErrNotFound) {
+			return nil, err
+		}
+		if access == AccessNone {
+			return nil, ErrConversationNotFound
+		}
+		ownerID = inst.Edges.Owner.ID
+		onInstance = conversation.HasInstanceWith(entinstance.IDEQ(instanceID))
+	}
+
+	find := func() (*ent.Conversation, error) {
+		conv, err := s.db.Conversation.Query().
+			Where(
+				conversation.HasOwnerWith(entuser.IDEQ(ownerID)),
+				conversation.ProjectPathEQ(projectPath),
+				onInstance,
+			).
+			Only(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			return nil, fmt.Errorf("query conversation: %w", err)
+		}
+		return conv, nil
+	}
+
 	// Try to find existing
-	conv, err := s.db.Conversation.Query().
-		Where(
-			conversation.HasOwnerWith(entuser.IDEQ(userID)),
-			conversation.ProjectPathEQ(projectPath),
-		).
-		Only(ctx)
-	if err != nil && !ent.IsNotFound(err) {
-		return nil, fmt.Errorf("query conversation: %w", err)
+	conv, err := find()
+	if err != nil {
+		return nil, err
 	}
 	if conv != nil {
 		return toConversationResponse(conv), nil
 	}
+	if access < AccessWrite {
+		return nil, ErrConversationNotFound
+	}
+
+	// Take over the owner's conversation from before instances held them
+	if instanceID != 0 {
+		n, err := s.db.Conversation.Update().
+			Where(
+				conversation.HasOwnerWith(entuser.IDEQ(ownerID)),
+				conversation.ProjectPathEQ(projectPath),
+				conversation.Not(conversation.HasInstance()),
+			).
+			SetInstanceID(instanceID).
+			Save(ctx)
+		if err != nil && !ent.IsConstraintError(err) {
+			return nil, fmt.Errorf("attach conversation: %w", err)
+		}
+		if n > 0 {
+			if conv, err = find(); err != nil {
+				return nil, err
+			}
+			if conv != nil {
+				return toConversationResponse(conv), nil
+			}
+		}
+	}
 
 	// Create new
 	title := "General"
@@ -86,11 +150,20 @@ func (s *ConversationService) GetOrCreateByProject(ctx context.Context, userID i
 		title = path.Base(projectPath)
 	}
 
-	conv, err = s.db.Conversation.Create().
+	create := s.db.Conversation.Create().
 		SetProjectPath(projectPath).
 		SetTitle(title).
-		SetOwnerID(userID).
-		Save(ctx)
+		SetOwnerID(ownerID)
+	if instanceID != 0 {
+		create = create.SetInstanceID(instanceID)
+	}
+	conv, err = create.Save(ctx)
+	if ent.IsConstraintError(err) {
+		// Created by a concurrent request
+		if conv, err = find(); err == nil && conv == nil {
+			err = ErrConversationNotFound
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("create conversation: %w", err)
 	}
@@ -116,18 +189,8 @@ func (s *ConversationService) ListByUser(ctx context.Context, userID int) ([]*Co
 
 // GetMessages returns all messages for a conversation, ordered by creation time.
 func (s *ConversationService) GetMessages(ctx context.Context, conversationID int, userID int) ([]*ChatMessageResponse, error) {
-	// Verify ownership
-	exists, err := s.db.Conversation.Query().
-		Where(
-			conversation.IDEQ(conversationID),
-			conversation.HasOwnerWith(entuser.IDEQ(userID)),
-		).
-		Exist(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("check ownership: %w", err)
-	}
-	if !exists {
-		return nil, fmt.Errorf("conversation not found")
+	if _, err := s.authorize(ctx, conversationID, userID, AccessRead); err != nil {
+		return nil, err
 	}
 
 	msgs, err := s.db.ChatMessage.Query().
@@ -147,18 +210,8 @@ func (s *ConversationService) GetMessages(ctx context.Context, conversationID in
 
 // AddMessage adds a message to a conversation. Returns the saved message.
 func (s *ConversationService) AddMessage(ctx context.Context, conversationID int, userID int, role string, content string, toolEvents *string) (*ChatMessageResponse, error) {
-	// Verify ownership
-	exists, err := s.db.Conversation.Query().
-		Where(
-			conversation.IDEQ(conversationID),
-			conversation.HasOwnerWith(entuser.IDEQ(userID)),
-		).
-		Exist(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("check ownership: %w", err)
-	}
-	if !exists {
-		return nil, fmt.Errorf("conversation not found")
+	if _, err := s.authorize(ctx, conversationID, userID, AccessWrite); err != nil {
+		return nil, err
 	}
 
 	create := s.db.ChatMessage.Create().
@@ -182,18 +235,9 @@ func (s *ConversationService) AddMessage(ctx context.Context, conversationID int
 
 // DeleteConversation deletes a conversation and all its messages.
 func (s *ConversationService) DeleteConversation(ctx context.Context, conversationID int, userID int) error {
-	// Verify ownership
-	conv, err := s.db.Conversation.Query().
-		Where(
-			conversation.IDEQ(conversationID),
-			conversation.HasOwnerWith(entuser.IDEQ(userID)),
-		).
-		Only(ctx)
+	conv, err := s.authorize(ctx, conversationID, userID, AccessManage)
 	if err != nil {
-		if ent.IsNotFound(err) {
-			return fmt.Errorf("conversation not found")
-		}
-		return fmt.Errorf("query conversation: %w", err)
+		return err
 	}
 
 	// Delete all messages first
@@ -207,3 +251,34 @@ func (s *ConversationService) DeleteConversation(ctx context.Context, conversati
 	// Delete conversation
 	return s.db.Conversation.DeleteOneID(conv.ID).Exec(ctx)
 }
+
+// authorize returns the conversation if userID has at least need access to
+// it. Owners have full access; on a conversation that follows an instance,
+// others get the access they have to the instance.
+func (s *ConversationService) authorize(ctx context.Context, conversationID, userID int, need Access) (*ent.Conversation, error) {
+	conv, err := s.db.Conversation.Query().
+		Where(conversation.IDEQ(conversationID)).
+		WithOwner().
+		WithInstance().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrConversationNotFound
+		}
+		return nil, fmt.Errorf("query conversation: %w", err)
+	}
+	if conv.Edges.Owner.ID == userID {
+		return conv, nil
+	}
+	if conv.Edges.Instance == nil {
+		return nil, ErrConversationNotFound
+	}
+	_, err = authorizeInstance(ctx, s.db, conv.Edges.Instance.ID, userID, need)
+	if errors.Is(err, provider.ErrNotFound) {
+		return nil, ErrConversationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return conv, nil
+}
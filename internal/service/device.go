@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/logan/cloudcode/internal/auth"
+	"github.com/logan/cloudcode/internal/ent"
+	entdevicecode "github.com/logan/cloudcode/internal/ent/devicecode"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+)
+
+// Device flow errors. The messages of the first five are the RFC 8628 error
+// codes the token endpoint reports.
+var (
+	ErrAuthorizationPending = errors.New("authorization_pending")
+	ErrSlowDown             = errors.New("slow_down")
+	ErrAccessDenied         = errors.New("access_denied")
+	ErrExpiredToken         = errors.New("expired_token")
+	ErrInvalidGrant         = errors.New("invalid_grant")
+	// ErrDeviceCodeNotFound indicates a user code that is unknown, expired or
+	// already decided.
+	ErrDeviceCodeNotFound = errors.New("device code not found")
+)
+
+const (
+	// DeviceCodeTTL is how long the user has to approve a device.
+	DeviceCodeTTL = 15 * time.Minute
+	// DevicePollInterval is the initial minimum interval between polls.
+	DevicePollInterval = 5 * time.Second
+
+	// userCodeAlphabet has no vowels, so codes never spell words, and no
+	// characters that are easily confused.
+	userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength   = 8
+)
+
+// DeviceAuthorization is the response to a device authorization request
+// (RFC 8628 section 3.2).
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// DeviceInfo describes a pending device for the approval page.
+type DeviceInfo struct {
+	UserCode  string    `json:"user_code"`
+	ClientID  string    `json:"client_id"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// StartDeviceAuthorization issues a device code and user code for clientID.
+// The client shows the user code and verification URI and then polls
+// PollDeviceToken with the device code.
+func (s *AuthService) StartDeviceAuthorization(ctx context.Context, clientID string) (*DeviceAuthorization, error) {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		clientID = "unknown"
+	}
+	if len(clientID) > 64 {
+		clientID = clientID[:64]
+	}
+
+	// Opportunistic cleanup; expired codes are refused anyway
+	if _, err := s.db.DeviceCode.Delete().
+		Where(entdevicecode.ExpiresAtLT(time.Now())).
+		Exec(ctx); err != nil {
+		return nil, fmt.Errorf("delete expired device codes: %w", err)
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("generate device code: %w", err)
+	}
+	deviceCode := base64.RawURLEncoding.EncodeToString(b)
+
+	// Retry on the rare user code collision
+	var userCode string
+	for attempt := 0; ; attempt++ {
+		var err error
+		userCode, err = generateUserCode()
+		if err != nil {
+			return nil, err
+		}
+		err = s.db.DeviceCode.Create().
+			SetDeviceCodeHash(auth.HashAccessToken(deviceCode)).
+			SetUserCode(userCode).
+			SetClientID(clientID).
+			SetInterval(int(DevicePollInterval / time.Second)).
+			SetExpiresAt(time.Now().Add(DeviceCodeTTL)).
+			Exec(ctx)
+		if err == nil {
+			break
+		}
+		if !ent.IsConstraintError(err) || attempt == 4 {
+			return nil, fmt.Errorf("save device code: %w", err)
+		}
+	}
+
+	uri := s.frontendURL + "/auth/device"
+	return &DeviceAuthorization{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         uri,
+		VerificationURIComplete: uri + "?user_code=" + userCode,
+		ExpiresIn:               int(DeviceCodeTTL / time.Second),
+		Interval:                int(DevicePollInterval / time.Second),
+	}, nil
+}
+
+// PollDeviceToken exchanges an approved device code for a new session's tokens.
+// Until the user decides it returns ErrAuthorizationPending, or ErrSlowDown
+// if the client polls faster than the interval, which is then raised by five
+// seconds. Once a token has been issued the device code is spent.
+func (s *AuthService) PollDeviceToken(ctx context.Context, deviceCode string) (*SessionTokens, error) {
+	dc, err := s.db.DeviceCode.Query().
+		Where(entdevicecode.DeviceCodeHashEQ(auth.HashAccessToken(deviceCode))).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrInvalidGrant
+		}
+		return nil, fmt.Errorf("query device code: %w", err)
+	}
+
+	now := time.Now()
+	if !now.Before(dc.ExpiresAt) {
+		if err := s.db.DeviceCode.DeleteOne(dc).Exec(ctx); err != nil && !ent.IsNotFound(err) {
+			return nil, fmt.Errorf("delete device code: %w", err)
+		}
+		return nil, ErrExpiredToken
+	}
+
+	update := s.db.DeviceCode.UpdateOne(dc).SetLastPolledAt(now)
+	tooSoon := dc.LastPolledAt != nil && now.Sub(*dc.LastPolledAt) < time.Duration(dc.Interval)*time.Second
+	if tooSoon {
+		update = update.AddInterval(5)
+	}
+	if err := update.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("record poll: %w", err)
+	}
+	if tooSoon {
+		return nil, ErrSlowDown
+	}
+
+	switch dc.Status {
+	case "pending":
+		return nil, ErrAuthorizationPending
+	case "denied":
+		if err := s.db.DeviceCode.DeleteOne(dc).Exec(ctx); err != nil && !ent.IsNotFound(err) {
+			return nil, fmt.Errorf("delete device code: %w", err)
+		}
+		return nil, ErrAccessDenied
+	}
+
+	// Approved: spend the code first so two polls cannot both get a token
+	n, err := s.db.DeviceCode.Delete().
+		Where(entdevicecode.IDEQ(dc.ID), entdevicecode.StatusEQ("approved")).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("spend device code: %w", err)
+	}
+	if n == 0 || dc.Edges.User == nil {
+		return nil, ErrInvalidGrant
+	}
+	return s.startSession(ctx, dc.Edges.User)
+}
+
+// LookupDevice returns the pending device with the given user code, for the
+// approval page to show before the user decides.
+func (s *AuthService) LookupDevice(ctx context.Context, userCode string) (*DeviceInfo, error) {
+	dc, err := s.db.DeviceCode.Query().
+		Where(pendingDevice(userCode)...).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrDeviceCodeNotFound
+		}
+		return nil, fmt.Errorf("query device code: %w", err)
+	}
+	return &DeviceInfo{
+		UserCode:  dc.UserCode,
+		ClientID:  dc.ClientID,
+		CreatedAt: dc.CreatedAt,
+		ExpiresAt: dc.ExpiresAt,
+	}, nil
+}
+
+// DecideDevice approves or denies the pending device with the given user
+// code on behalf of userID. An approved device receives a session for
+// userID on its next poll.
+func (s *AuthService) DecideDevice(ctx context.Context, userID int, userCode string, approve bool) error {
+	status := "denied"
+	if approve {
+		status = "approved"
+	}
+	n, err := s.db.DeviceCode.Update().
+		Where(pendingDevice(userCode)...).
+		SetStatus(status).
+		SetUserID(userID).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("update device code: %w", err)
+	}
+	if n == 0 {
+		return ErrDeviceCodeNotFound
+	}
+	return nil
+}
+
+// pendingDevice matches the undecided, unexpired device with userCode.
+func pendingDevice(userCode string) []predicate.DeviceCode {
+	return []predicate.DeviceCode{
+		entdevicecode.UserCodeEQ(normalizeUserCode(userCode)),
+		entdevicecode.StatusEQ("pending"),
+		entdevicecode.ExpiresAtGT(time.Now()),
+	}
+}
+
+// generateUserCode returns a random code formatted as XXXX-XXXX.
+func generateUserCode() (string, error) {
+	b := make([]byte, userCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate user code: %w", err)
+	}
+	code := make([]byte, 0, userCodeLength+1)
+	for i, c := range b {
+		if i == userCodeLength/2 {
+			code = append(code, '-')
+		}
+		// 256 is not a multiple of 20; the bias is negligible for a code
+		// that lives 15 minutes and is rate limited
+		code = append(code, userCodeAlphabet[int(c)%len(userCodeAlphabet)])
+	}
+	return string(code), nil
+}
+
+// normalizeUserCode accepts codes typed in any case, with or without the
+// hyphen or spaces.
+func normalizeUserCode(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(s))
+	if len(s) != userCodeLength {
+		return s
+	}
+	return s[:userCodeLength/2] + "-" + s[userCodeLength/2:]
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/auth"
+	"github.com/logan/cloudcode/internal/ent"
+	entdevicecode "github.com/logan/cloudcode/internal/ent/devicecode"
+	"github.com/logan/cloudcode/internal/ent/enttest"
+)
+
+func setupDeviceTest(t *testing.T, dsn string) (*AuthService, *ent.Client) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { client.Close() })
+	return NewAuthService(client, "test-secret", "http://api", "http://app", NewLogMailer(slog.Default())), client
+}
+
+// rewindPoll moves the last poll back so the next one respects the interval.
+func rewindPoll(t *testing.T, client *ent.Client, userCode string) {
+	t.Helper()
+	client.DeviceCode.Update().
+		Where(entdevicecode.UserCodeEQ(userCode)).
+		SetLastPolledAt(time.Now().Add(-time.Minute)).
+		ExecX(context.Background())
+}
+
+func TestDeviceFlow_Approve(t *testing.T) {
+	svc, client := setupDeviceTest(t, "file:ent_device_approve?mode=memory&_fk=1")
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	da, err := svc.StartDeviceAuthorization(ctx, "claude-cloud")
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if da.VerificationURIComplete != "http://app/auth/device?user_code="+da.UserCode || da.Interval != 5 || da.ExpiresIn != 900 {
+		t.Errorf("authorization = %+v", da)
+	}
+
+	if _, err := svc.PollDeviceToken(ctx, da.DeviceCode); !errors.Is(err, ErrAuthorizationPending) {
+		t.Fatalf("first poll: got %v, want ErrAuthorizationPending", err)
+	}
+	if _, err := svc.PollDeviceToken(ctx, da.DeviceCode); !errors.Is(err, ErrSlowDown) {
+		t.Fatalf("fast poll: got %v, want ErrSlowDown", err)
+	}
+	if dc := client.DeviceCode.Query().OnlyX(ctx); dc.Interval != 10 {
+		t.Errorf("interval after slow_down = %d, want 10", dc.Interval)
+	}
+
+	// The user types the code loosely in the browser
+	typed := strings.ToLower(strings.ReplaceAll(da.UserCode, "-", " "))
+	info, err := svc.LookupDevice(ctx, typed)
+	if err != nil || info.ClientID != "claude-cloud" {
+		t.Fatalf("lookup = %+v, %v", info, err)
+	}
+	if err := svc.DecideDevice(ctx, userID, typed, true); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if err := svc.DecideDevice(ctx, userID, typed, false); !errors.Is(err, ErrDeviceCodeNotFound) {
+		t.Errorf("second decision: got %v, want ErrDeviceCodeNotFound", err)
+	}
+
+	rewindPoll(t, client, da.UserCode)
+	tokens, err := svc.PollDeviceToken(ctx, da.DeviceCode)
+	if err != nil {
+		t.Fatalf("poll after approval: %v", err)
+	}
+	claims, err := auth.ValidateToken("test-secret", tokens.AccessToken)
+	if err != nil || claims.UserID != userID || claims.Purpose != "session" || claims.SessionID == "" {
+		t.Errorf("claims = %+v, %v", claims, err)
+	}
+	if _, err := svc.PollDeviceToken(ctx, da.DeviceCode); !errors.Is(err, ErrInvalidGrant) {
+		t.Errorf("reused device code: got %v, want ErrInvalidGrant", err)
+	}
+}
+
+func TestDeviceFlow_DenyAndExpire(t *testing.T) {
+	svc, client := setupDeviceTest(t, "file:ent_device_deny?mode=memory&_fk=1")
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	denied, err := svc.StartDeviceAuthorization(ctx, "claude-cloud")
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err := svc.DecideDevice(ctx, userID, denied.UserCode, false); err != nil {
+		t.Fatalf("deny: %v", err)
+	}
+	if _, err := svc.PollDeviceToken(ctx, denied.DeviceCode); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("denied poll: got %v, want ErrAccessDenied", err)
+	}
+
+	expired, err := svc.StartDeviceAuthorization(ctx, "claude-cloud")
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	client.DeviceCode.Update().
+		Where(entdevicecode.UserCodeEQ(expired.UserCode)).
+		SetExpiresAt(time.Now().Add(-time.Second)).
+		ExecX(ctx)
+	if err := svc.DecideDevice(ctx, userID, expired.UserCode, true); !errors.Is(err, ErrDeviceCodeNotFound) {
+		t.Errorf("approve expired: got %v, want ErrDeviceCodeNotFound", err)
+	}
+	if _, err := svc.PollDeviceToken(ctx, expired.DeviceCode); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("expired poll: got %v, want ErrExpiredToken", err)
+	}
+	if n := client.DeviceCode.Query().CountX(ctx); n != 0 {
+		t.Errorf("%d device codes left, want 0", n)
+	}
+}
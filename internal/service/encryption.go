@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/logan/cloudcode/internal/ent"
+	entinstance "github.com/logan/cloudcode/internal/ent/instance"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/vault"
+)
+
+// reencryptBatch bounds the rows loaded at a time by ReencryptColumns.
+const reencryptBatch = 100
+
+// staleColumn matches rows whose column holds a value that is plaintext or
+// encrypted with a key other than keyID. It looks at the stored value, so it
+// cannot go through the generated predicates, which encode their argument.
+func staleColumn(column, keyID string) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		c := s.C(column)
+		s.Where(sql.And(
+			sql.NotNull(c),
+			sql.NEQ(c, ""),
+			sql.Not(sql.HasPrefix(c, vault.FieldPrefix(keyID))),
+		))
+	}
+}
+
+// ReencryptColumns rewrites the encrypted columns of every row still holding
+// plaintext or a value encrypted with an old master key, so the old key can
+// be retired. It is safe to run while the server is serving traffic: each
+// update is guarded on the value still being stale, so a concurrent write is
+// never overwritten. Returns the number of values rewritten.
+func ReencryptColumns(ctx context.Context, db *ent.Client) (int, error) {
+	keyID := vault.FieldWriteKeyID()
+	if keyID == "" {
+		return 0, errors.New("column encryption is not enabled for writes")
+	}
+
+	n := 0
+	apiKey := predicate.User(staleColumn(entuser.FieldAnthropicAPIKey, keyID))
+	oauthToken := predicate.User(staleColumn(entuser.FieldClaudeOauthToken, keyID))
+	for lastID := 0; ; {
+		users, err := db.User.Query().
+			Where(entuser.IDGT(lastID), entuser.Or(apiKey, oauthToken)).
+			Order(ent.Asc(entuser.FieldID)).
+			Limit(reencryptBatch).
+			All(ctx)
+		if err != nil {
+			return n, fmt.Errorf("query users: %w", err)
+		}
+		if len(users) == 0 {
+			break
+		}
+		for _, u := range users {
+			lastID = u.ID
+			updated, err := db.User.Update().
+				Where(entuser.IDEQ(u.ID), apiKey).
+				SetAnthropicAPIKey(u.AnthropicAPIKey).
+				Save(ctx)
+			if err != nil {
+				return n, fmt.Errorf("reencrypt user %d: %w", u.ID, err)
+			}
+			n += updated
+			updated, err = db.User.Update().
+				Where(entuser.IDEQ(u.ID), oauthToken).
+				SetClaudeOauthToken(u.ClaudeOauthToken).
+				Save(ctx)
+			if err != nil {
+				return n, fmt.Errorf("reencrypt user %d: %w", u.ID, err)
+			}
+			n += updated
+		}
+	}
+
+	agentSecret := predicate.Instance(staleColumn(entinstance.FieldAgentSecret, keyID))
+	for lastID := 0; ; {
+		instances, err := db.Instance.Query().
+			Where(entinstance.IDGT(lastID), agentSecret).
+			Order(ent.Asc(entinstance.FieldID)).
+			Limit(reencryptBatch).
+			All(ctx)
+		if err != nil {
+			return n, fmt.Errorf("query instances: %w", err)
+		}
+		if len(instances) == 0 {
+			break
+		}
+		for _, inst := range instances {
+			lastID = inst.ID
+			updated, err := db.Instance.Update().
+				Where(entinstance.IDEQ(inst.ID), agentSecret).
+				SetAgentSecret(inst.AgentSecret).
+				Save(ctx)
+			if err != nil {
+				return n, fmt.Errorf("reencrypt instance %d: %w", inst.ID, err)
+			}
+			n += updated
+		}
+	}
+	return n, nil
+}
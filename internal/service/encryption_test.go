@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	"github.com/logan/cloudcode/internal/vault"
+)
+
+func TestEncryptedColumns(t *testing.T) {
+	// A shared cache lets a raw connection see what ent stored
+	dsn := "file:ent_encrypted_columns?mode=memory&cache=shared&_fk=1"
+	client := enttest.Open(t, "sqlite3", dsn)
+	defer client.Close()
+	raw, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+	stored := func(query string, id int) string {
+		t.Helper()
+		var v sql.NullString
+		if err := raw.QueryRow(query, id).Scan(&v); err != nil {
+			t.Fatalf("raw query: %v", err)
+		}
+		return v.String
+	}
+	t.Cleanup(func() { vault.SetFieldKeyring(nil, false) })
+	ctx := context.Background()
+
+	// Rows written before encryption was enabled hold plaintext
+	legacy := client.User.Create().SetEmail("legacy@example.com").
+		SetAnthropicAPIKey("sk-ant-legacy").SaveX(ctx)
+	inst := client.Instance.Create().SetProvider("mock").SetProviderID("mock-1").
+		SetAgentSecret("agent-legacy").SetOwner(legacy).SaveX(ctx)
+
+	k1 := testKeyring(t, "k1:"+testMasterKey(1))
+	vault.SetFieldKeyring(k1, true)
+	if got := client.User.GetX(ctx, legacy.ID).AnthropicAPIKey; got != "sk-ant-legacy" {
+		t.Errorf("legacy plaintext read as %q", got)
+	}
+
+	u := client.User.Create().SetEmail("new@example.com").
+		SetClaudeOauthToken("sk-ant-oat-new").SaveX(ctx)
+	if v := stored("SELECT claude_oauth_token FROM users WHERE id = ?", u.ID); !strings.HasPrefix(v, vault.FieldPrefix("k1")) {
+		t.Errorf("new token stored as %q", v)
+	}
+	if got := client.User.GetX(ctx, u.ID); got.ClaudeOauthToken != "sk-ant-oat-new" || got.AnthropicAPIKey != "" {
+		t.Errorf("read back %q, %q", got.ClaudeOauthToken, got.AnthropicAPIKey)
+	}
+
+	// Migrate the legacy rows
+	n, err := ReencryptColumns(ctx, client)
+	if err != nil || n != 2 {
+		t.Fatalf("reencrypt = %d, %v; want 2", n, err)
+	}
+	if v := stored("SELECT anthropic_api_key FROM users WHERE id = ?", legacy.ID); !strings.HasPrefix(v, vault.FieldPrefix("k1")) {
+		t.Errorf("legacy key stored as %q", v)
+	}
+	if v := stored("SELECT agent_secret FROM instances WHERE id = ?", inst.ID); strings.Contains(v, "agent-legacy") {
+		t.Errorf("agent secret stored as %q", v)
+	}
+
+	// Rotate: k2 becomes primary and k1 can be retired once rewritten
+	vault.SetFieldKeyring(testKeyring(t, "k2:"+testMasterKey(2)+",k1:"+testMasterKey(1)), true)
+	if n, err := ReencryptColumns(ctx, client); err != nil || n != 3 {
+		t.Fatalf("rotate = %d, %v; want 3", n, err)
+	}
+	if n, _ := ReencryptColumns(ctx, client); n != 0 {
+		t.Errorf("second pass rewrote %d values", n)
+	}
+	vault.SetFieldKeyring(testKeyring(t, "k2:"+testMasterKey(2)), true)
+	if got := client.Instance.GetX(ctx, inst.ID).AgentSecret; got != "agent-legacy" {
+		t.Errorf("agent secret after rotation = %q", got)
+	}
+	if got := client.User.GetX(ctx, u.ID).ClaudeOauthToken; got != "sk-ant-oat-new" {
+		t.Errorf("token after rotation = %q", got)
+	}
+}
@@ -1,12 +1,17 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -21,12 +26,46 @@ import (
 
 var tracer = otel.Tracer("cloudcode/service/instance")
 
+// defaultInstanceLimit applies to plans without an entry in the plan limits.
+const defaultInstanceLimit = 1
+
+var (
+	// ErrInvalidInstanceName indicates a name that fails provider.ValidInstanceName.
+	ErrInvalidInstanceName = errors.New("invalid instance name")
+	// ErrInstanceLimit indicates the user's plan allows no more live instances.
+	ErrInstanceLimit = errors.New("instance limit reached for plan")
+	// ErrUnknownClass indicates a class name missing from the catalog.
+	ErrUnknownClass = errors.New("unknown instance class")
+	// ErrClassNotAllowed indicates a class the user's plan does not include.
+	ErrClassNotAllowed = errors.New("instance class not allowed for plan")
+	// ErrInstanceStopped indicates an instance that must be woken first. It
+	// wraps provider.ErrNotFound for callers that do not wake instances.
+	ErrInstanceStopped = fmt.Errorf("instance is stopped: %w", provider.ErrNotFound)
+)
+
+// liveStatuses are the instance statuses that count towards a user's limit.
+var liveStatuses = []string{"provisioning", "running", "stopped"}
+
 // InstanceService bridges HTTP handlers with the provider and database.
 type InstanceService struct {
 	db              *ent.Client
 	provider        provider.Provisioner
-	netbird         *NetbirdService // nil when PROVIDER=docker
+	netbird         *NetbirdService  // nil when PROVIDER=docker
+	warmPool        *WarmPoolService // nil when no warm pool is configured
+	secrets         *SecretService   // nil when no master key is configured
+	audit           *AuditService    // nil records nothing
 	anthropicAPIKey string
+	planLimits      map[string]int // plan → max live instances
+	classes         []provider.Class
+	planClasses     map[string][]string // plan → allowed class names
+}
+
+// InstanceSpec describes the instance to create. Zero values select the
+// user's default instance and the plan's default class.
+type InstanceSpec struct {
+	Name     string `json:"name"`
+	Class    string `json:"class"`
+	Template string `json:"template"` // template ID or name; empty boots the stock environment
 }
 
 // NewInstanceService creates a new InstanceService.
@@ -39,57 +78,282 @@ func (s *InstanceService) SetNetbirdService(nb *NetbirdService) {
 	s.netbird = nb
 }
 
+// SetWarmPool wires in the optional warm pool that Create claims from before
+// cold-starting an instance.
+func (s *InstanceService) SetWarmPool(pool *WarmPoolService) {
+	s.warmPool = pool
+}
+
+// SetSecrets enables pushing the owner's secrets to instances on create and wake.
+func (s *InstanceService) SetSecrets(secrets *SecretService) {
+	s.secrets = secrets
+}
+
+// SetAuditService wires in the audit log for sharing changes and terminal
+// sessions.
+func (s *InstanceService) SetAuditService(a *AuditService) {
+	s.audit = a
+}
+
+// SetPlanLimits sets the maximum number of live instances per plan. Plans
+// without an entry are limited to one instance.
+func (s *InstanceService) SetPlanLimits(limits map[string]int) {
+	s.planLimits = limits
+}
+
+// SetClasses sets the class catalog and the classes each plan may use, in
+// the plan's order of preference; the first is the default. Plans without an
+// entry get the first class in the catalog. With an empty catalog instances
+// are created with provider defaults and cannot be resized.
+func (s *InstanceService) SetClasses(catalog []provider.Class, planClasses map[string][]string) {
+	s.classes = catalog
+	s.planClasses = planClasses
+}
+
+// ParsePlanClasses parses a comma-separated list of plan=class|class entries,
+// e.g. "free=small,pro=small|medium|large".
+func ParsePlanClasses(s string) (map[string][]string, error) {
+	plans := make(map[string][]string)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		plan, list, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid class entry %q: want plan=class|class", entry)
+		}
+		var names []string
+		for _, name := range strings.Split(list, "|") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no classes for plan %q", plan)
+		}
+		plans[strings.TrimSpace(plan)] = names
+	}
+	return plans, nil
+}
+
+// AllowedClasses returns the catalog classes the user's plan may use, default
+// first. userID 0 (admin) gets the whole catalog.
+func (s *InstanceService) AllowedClasses(ctx context.Context, userID int) ([]provider.Class, error) {
+	if userID == 0 {
+		return s.classes, nil
+	}
+	plan, err := s.userPlan(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.planCatalog(plan), nil
+}
+
+// planCatalog returns the classes allowed for plan, in the plan's order.
+func (s *InstanceService) planCatalog(plan string) []provider.Class {
+	if len(s.classes) == 0 {
+		return nil
+	}
+	names, ok := s.planClasses[plan]
+	if !ok {
+		return s.classes[:1]
+	}
+	var out []provider.Class
+	for _, name := range names {
+		if c, ok := provider.FindClass(s.classes, name); ok {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// resolveClass returns the class called name if the user's plan allows it.
+// An empty name selects the plan's default class, or the zero class when no
+// catalog is configured.
+func (s *InstanceService) resolveClass(ctx context.Context, userID int, name string) (provider.Class, error) {
+	if len(s.classes) == 0 {
+		if name != "" {
+			return provider.Class{}, ErrUnknownClass
+		}
+		return provider.Class{}, nil
+	}
+	if name != "" {
+		if _, ok := provider.FindClass(s.classes, name); !ok {
+			return provider.Class{}, ErrUnknownClass
+		}
+	}
+
+	plan, err := s.userPlan(ctx, userID)
+	if err != nil {
+		return provider.Class{}, err
+	}
+	allowed := s.planCatalog(plan)
+	if name == "" {
+		if len(allowed) == 0 {
+			return provider.Class{}, ErrClassNotAllowed
+		}
+		return allowed[0], nil
+	}
+	if c, ok := provider.FindClass(allowed, name); ok {
+		return c, nil
+	}
+	return provider.Class{}, ErrClassNotAllowed
+}
+
+// userPlan returns the plan that applies to the user: their own or, if
+// better, one an organization they belong to pays for. It returns "" if the
+// user is unknown.
+func (s *InstanceService) userPlan(ctx context.Context, userID int) (string, error) {
+	return effectivePlan(ctx, s.db, userID)
+}
+
+// ParsePlanLimits parses a comma-separated list of plan=count entries,
+// e.g. "free=1,starter=2,pro=5".
+func ParsePlanLimits(s string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		plan, count, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid limit entry %q: want plan=count", entry)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid limit for plan %q: %q", plan, count)
+		}
+		limits[strings.TrimSpace(plan)] = n
+	}
+	return limits, nil
+}
+
 // InstanceResponse is the API response for an instance.
 type InstanceResponse struct {
 	ID         int    `json:"id"`
+	Name       string `json:"name"`
 	Provider   string `json:"provider"`
 	ProviderID string `json:"provider_id"`
 	Host       string `json:"host"`
 	Port       int    `json:"port"`
 	Status     string `json:"status"`
 	VolumeID   string `json:"volume_id"`
+	Class      string `json:"class,omitempty"`
+	TemplateID *int   `json:"template_id,omitempty"`
+
+	SnapshotIDs []string   `json:"snapshot_ids,omitempty"`
+	PausedAt    *time.Time `json:"paused_at,omitempty"`
+
+	OrganizationID *int   `json:"organization_id,omitempty"`
+	TeamAccess     string `json:"team_access"`
 }
 
 func toResponse(inst *ent.Instance) *InstanceResponse {
 	return &InstanceResponse{
 		ID:         inst.ID,
+		Name:       inst.Name,
 		Provider:   inst.Provider,
 		ProviderID: inst.ProviderID,
 		Host:       inst.Host,
 		Port:       inst.Port,
 		Status:     inst.Status,
 		VolumeID:   inst.VolumeID,
+		Class:      inst.Class,
+		TemplateID: inst.TemplateID,
+
+		SnapshotIDs: inst.SnapshotIds,
+		PausedAt:    inst.PausedAt,
+
+		OrganizationID: inst.OrganizationID,
+		TeamAccess:     string(inst.TeamAccess),
 	}
 }
 
 // ConnectInfo holds the data needed to generate a connect script.
 type ConnectInfo struct {
+	ID           int
 	Provider     string
 	Host         string
 	ProviderID   string
 	Status       string
 	NetbirdConfig string
 	UserID       int
+	Name         string
 }
 
-// Create provisions a new instance for the given user.
-func (s *InstanceService) Create(ctx context.Context, userID int) (*InstanceResponse, error) {
-	ctx, span := tracer.Start(ctx, "instance.create",
-		otelTrace.WithAttributes(attribute.Int("user_id", userID)))
-	defer span.End()
+// normalizeInstanceName maps an empty name to the default instance and
+// validates any other.
+func normalizeInstanceName(name string) (string, error) {
+	if name == "" {
+		return provider.DefaultInstanceName, nil
+	}
+	if !provider.ValidInstanceName(name) {
+		return "", ErrInvalidInstanceName
+	}
+	return name, nil
+}
 
-	// Check for existing active instance
-	exists, err := s.db.Instance.Query().
+// checkCreate reports whether the user may add an instance called name.
+// pending lists the names of instances already queued for creation, which
+// count towards the plan limit alongside live instances.
+func (s *InstanceService) checkCreate(ctx context.Context, userID int, name string, pending []string) error {
+	live, err := s.db.Instance.Query().
 		Where(
 			entinstance.HasOwnerWith(entuser.IDEQ(userID)),
-			entinstance.StatusIn("provisioning", "running", "stopped"),
+			entinstance.StatusIn(liveStatuses...),
 		).
-		Exist(ctx)
+		Select(entinstance.FieldName).
+		Strings(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("query existing: %w", err)
+		return fmt.Errorf("query existing: %w", err)
 	}
-	if exists {
-		return nil, provider.ErrAlreadyExists
+	if slices.Contains(live, name) || slices.Contains(pending, name) {
+		return provider.ErrAlreadyExists
+	}
+
+	plan, err := s.userPlan(ctx, userID)
+	if err != nil {
+		return err
+	}
+	limit, ok := s.planLimits[plan]
+	if !ok {
+		limit = defaultInstanceLimit
+	}
+	if len(live)+len(pending) >= limit {
+		return ErrInstanceLimit
+	}
+	return nil
+}
+
+// Create provisions a new instance for the given user. Returns
+// provider.ErrAlreadyExists if the user has a live instance with the spec's
+// name, ErrInstanceLimit if their plan allows no more instances,
+// ErrUnknownClass or ErrClassNotAllowed for a class they cannot use, and
+// ErrTemplateNotFound for a template they cannot see.
+func (s *InstanceService) Create(ctx context.Context, userID int, spec InstanceSpec) (*InstanceResponse, error) {
+	ctx, span := tracer.Start(ctx, "instance.create",
+		otelTrace.WithAttributes(attribute.Int("user_id", userID), attribute.String("instance_name", spec.Name)))
+	defer span.End()
+
+	name, err := normalizeInstanceName(spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCreate(ctx, userID, name, nil); err != nil {
+		return nil, err
+	}
+	class, err := s.resolveClass(ctx, userID, spec.Class)
+	if err != nil {
+		return nil, err
+	}
+	var tmpl *ent.Template
+	if spec.Template != "" {
+		if tmpl, err = lookupTemplate(ctx, s.db, userID, spec.Template); err != nil {
+			return nil, err
+		}
+		span.SetAttributes(attribute.Int("template_id", tmpl.ID))
 	}
 
 	// Generate per-instance agent secret
@@ -101,15 +365,22 @@ func (s *InstanceService) Create(ctx context.Context, userID int) (*InstanceResp
 
 	// Load user credentials: per-user OAuth token or API key, with platform key as fallback
 	var opts provider.CreateOptions
+	opts.Name = name
+	opts.Class = class
 	opts.AgentSecret = agentSecret
+	if tmpl != nil {
+		if opts.Environment, err = templateEnvironment(tmpl); err != nil {
+			return nil, err
+		}
+	}
 
 	user, err := s.db.User.Get(ctx, userID)
 	if err == nil {
-		if user.ClaudeOauthToken != nil && *user.ClaudeOauthToken != "" {
-			opts.ClaudeOAuthToken = *user.ClaudeOauthToken
+		if user.ClaudeOauthToken != "" {
+			opts.ClaudeOAuthToken = user.ClaudeOauthToken
 		}
-		if user.AnthropicAPIKey != nil && *user.AnthropicAPIKey != "" {
-			opts.AnthropicAPIKey = *user.AnthropicAPIKey
+		if user.AnthropicAPIKey != "" {
+			opts.AnthropicAPIKey = user.AnthropicAPIKey
 		}
 	}
 	// Fall back to platform-wide key if user has neither
@@ -120,6 +391,7 @@ func (s *InstanceService) Create(ctx context.Context, userID int) (*InstanceResp
 
 	// Phase 1: Prepare Netbird access (Hetzner only)
 	if s.netbird != nil {
+		ReportProgress(ctx, "netbird_prepare")
 		prep, err = s.netbird.PrepareNetbirdAccess(ctx, userID)
 		if err != nil {
 			return nil, fmt.Errorf("netbird prepare: %w", err)
@@ -128,7 +400,8 @@ func (s *InstanceService) Create(ctx context.Context, userID int) (*InstanceResp
 	}
 
 	// Call provider to create
-	provInst, err := s.provider.Create(ctx, userID, opts)
+	ReportProgress(ctx, "provisioning")
+	provInst, err := s.provision(ctx, userID, opts)
 	if err != nil {
 		return nil, fmt.Errorf("provider create: %w", err)
 	}
@@ -136,6 +409,7 @@ func (s *InstanceService) Create(ctx context.Context, userID int) (*InstanceResp
 	// Phase 2: Finalize Netbird access (route + policy)
 	var netbirdConfigStr string
 	if s.netbird != nil && prep != nil {
+		ReportProgress(ctx, "netbird_finalize")
 		nbCfg, err := s.netbird.FinalizeNetbirdAccess(ctx, userID, prep)
 		if err != nil {
 			// Best-effort cleanup of provider instance
@@ -146,19 +420,25 @@ func (s *InstanceService) Create(ctx context.Context, userID int) (*InstanceResp
 	}
 
 	// Save to DB
+	ReportProgress(ctx, "saving")
 	create := s.db.Instance.Create().
+		SetName(name).
 		SetProvider(provInst.Provider).
 		SetProviderID(provInst.ProviderID).
 		SetHost(provInst.Host).
 		SetPort(provInst.Port).
 		SetStatus(string(provInst.Status)).
 		SetVolumeID(provInst.VolumeID).
+		SetClass(class.Name).
 		SetAgentSecret(agentSecret).
 		SetOwnerID(userID)
 
 	if netbirdConfigStr != "" {
 		create = create.SetNetbirdConfig(netbirdConfigStr)
 	}
+	if tmpl != nil {
+		create = create.SetTemplateID(tmpl.ID)
+	}
 
 	inst, err := create.Save(ctx)
 	if err != nil {
@@ -170,9 +450,76 @@ func (s *InstanceService) Create(ctx context.Context, userID int) (*InstanceResp
 	}
 
 	span.SetAttributes(attribute.Int("instance_id", inst.ID), attribute.String("provider", inst.Provider))
+	s.injectSecrets(ctx, inst)
 	return toResponse(inst), nil
 }
 
+// removeLeftover destroys the user's instance resource called name that no
+// live row refers to, as left by a create that died between provisioning
+// and saving the row. The volume is kept. It returns provider.ErrNotFound if
+// there is no such resource or the provider cannot list its resources.
+func (s *InstanceService) removeLeftover(ctx context.Context, userID int, name string) error {
+	inv, ok := s.provider.(provider.Inventory)
+	if !ok {
+		return provider.ErrNotFound
+	}
+	resources, err := inv.ListManaged(ctx)
+	if err != nil {
+		return fmt.Errorf("list managed: %w", err)
+	}
+	for _, res := range resources {
+		if res.Kind != provider.ResourceInstance || res.UserID != userID || res.Name != name {
+			continue
+		}
+		providerID := cmp.Or(res.ProviderID, res.ID)
+		taken, err := s.db.Instance.Query().
+			Where(entinstance.ProviderIDEQ(providerID), entinstance.StatusIn(liveStatuses...)).
+			Exist(ctx)
+		if err != nil {
+			return fmt.Errorf("query instance: %w", err)
+		}
+		if taken {
+			continue
+		}
+		if err := s.provider.Destroy(ctx, providerID); err != nil {
+			return fmt.Errorf("destroy leftover: %w", err)
+		}
+		return nil
+	}
+	return provider.ErrNotFound
+}
+
+// injectSecrets delivers the owner's secrets to a freshly booted instance.
+// Failures are recorded but do not fail the operation; a later sync retries.
+func (s *InstanceService) injectSecrets(ctx context.Context, inst *ent.Instance) {
+	if s.secrets == nil {
+		return
+	}
+	if err := s.secrets.Inject(ctx, inst); err != nil {
+		otelTrace.SpanFromContext(ctx).RecordError(err)
+		s.secrets.logger.Warn("failed to inject secrets", "instance_id", inst.ID, "error", err)
+	}
+}
+
+// provision claims a warm instance of opts.Class when the pool has one and
+// cold-starts an instance otherwise, including when the claim fails. Warm
+// instances boot the stock environment, so templated creates always cold-start.
+func (s *InstanceService) provision(ctx context.Context, userID int, opts provider.CreateOptions) (*provider.Instance, error) {
+	span := otelTrace.SpanFromContext(ctx)
+	if s.warmPool != nil && opts.Environment.IsZero() {
+		inst, err := s.warmPool.Claim(ctx, userID, opts.Class, opts)
+		if err != nil {
+			span.RecordError(err)
+		}
+		if inst != nil {
+			span.SetAttributes(attribute.Bool("warm", true))
+			return inst, nil
+		}
+	}
+	span.SetAttributes(attribute.Bool("warm", false))
+	return s.provider.Create(ctx, userID, opts)
+}
+
 // Get returns instance details by DB ID.
 func (s *InstanceService) Get(ctx context.Context, id int) (*InstanceResponse, error) {
 	inst, err := s.db.Instance.Get(ctx, id)
@@ -217,6 +564,7 @@ func (s *InstanceService) Delete(ctx context.Context, id int) error {
 
 	// Teardown Netbird if configured
 	if s.netbird != nil && inst.NetbirdConfig != "" {
+		ReportProgress(ctx, "netbird_teardown")
 		nbCfg, err := UnmarshalNetbirdConfig(inst.NetbirdConfig)
 		if err == nil && nbCfg != nil {
 			_ = s.netbird.TeardownUser(ctx, nbCfg)
@@ -224,6 +572,7 @@ func (s *InstanceService) Delete(ctx context.Context, id int) error {
 	}
 
 	// Destroy via provider using the container/instance name
+	ReportProgress(ctx, "destroying")
 	provID := inst.ProviderID
 	if err := s.provider.Destroy(ctx, provID); err != nil && !errors.Is(err, provider.ErrNotFound) {
 		return fmt.Errorf("provider destroy: %w", err)
@@ -251,12 +600,26 @@ func (s *InstanceService) Pause(ctx context.Context, id int) error {
 		return provider.ErrInvalidState
 	}
 
-	if err := s.provider.Pause(ctx, inst.ProviderID); err != nil {
+	ReportProgress(ctx, "pausing")
+	return pauseInstance(ctx, s.provider, inst)
+}
+
+// pauseInstance pauses inst via the provider and marks the row stopped.
+// For snapshot-capable providers the new snapshot ID is recorded on the row.
+func pauseInstance(ctx context.Context, prov provider.Provisioner, inst *ent.Instance) error {
+	update := inst.Update().SetStatus("stopped").SetPausedAt(time.Now())
+
+	if snap, ok := prov.(provider.Snapshotter); ok {
+		snapshotID, err := snap.PauseSnapshot(ctx, inst.ProviderID)
+		if err != nil {
+			return fmt.Errorf("provider pause: %w", err)
+		}
+		update.SetSnapshotIds(append(slices.Clone(inst.SnapshotIds), snapshotID))
+	} else if err := prov.Pause(ctx, inst.ProviderID); err != nil {
 		return fmt.Errorf("provider pause: %w", err)
 	}
 
-	_, err = inst.Update().SetStatus("stopped").Save(ctx)
-	return err
+	return update.Exec(ctx)
 }
 
 // Wake wakes a paused instance.
@@ -277,12 +640,74 @@ func (s *InstanceService) Wake(ctx context.Context, id int) error {
 		return provider.ErrInvalidState
 	}
 
-	if err := s.provider.Wake(ctx, inst.ProviderID); err != nil {
+	ReportProgress(ctx, "waking")
+	update := inst.Update().SetStatus("running").ClearPausedAt()
+
+	if snap, ok := s.provider.(provider.Snapshotter); ok {
+		// Restore from the snapshot this row recorded last, if any
+		snapshotID := ""
+		if n := len(inst.SnapshotIds); n > 0 {
+			snapshotID = inst.SnapshotIds[n-1]
+		}
+		provInst, kept, err := snap.WakeSnapshot(ctx, inst.ProviderID, snapshotID)
+		if err != nil {
+			return fmt.Errorf("provider wake: %w", err)
+		}
+		update.SetSnapshotIds(kept)
+		if provInst.Host != "" {
+			update.SetHost(provInst.Host)
+		}
+	} else if err := s.provider.Wake(ctx, inst.ProviderID); err != nil {
 		return fmt.Errorf("provider wake: %w", err)
 	}
 
-	_, err = inst.Update().SetStatus("running").Save(ctx)
-	return err
+	inst, err = update.Save(ctx)
+	if err != nil {
+		return err
+	}
+	s.injectSecrets(ctx, inst)
+	return nil
+}
+
+// Resize moves the instance to the named class, which the owner's plan must
+// allow. Providers that cannot resize return provider.ErrNotSupported.
+func (s *InstanceService) Resize(ctx context.Context, id int, className string) error {
+	ctx, span := tracer.Start(ctx, "instance.resize",
+		otelTrace.WithAttributes(attribute.Int("instance_id", id), attribute.String("class", className)))
+	defer span.End()
+
+	resizer, ok := s.provider.(provider.Resizer)
+	if !ok {
+		return provider.ErrNotSupported
+	}
+
+	inst, err := s.db.Instance.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return provider.ErrNotFound
+		}
+		return fmt.Errorf("get instance: %w", err)
+	}
+	if inst.Status != "running" && inst.Status != "stopped" {
+		return provider.ErrInvalidState
+	}
+	if className == "" {
+		return ErrUnknownClass
+	}
+	ownerID, err := inst.QueryOwner().OnlyID(ctx)
+	if err != nil {
+		return fmt.Errorf("query owner: %w", err)
+	}
+	class, err := s.resolveClass(ctx, ownerID, className)
+	if err != nil {
+		return err
+	}
+
+	ReportProgress(ctx, "resizing")
+	if err := resizer.Resize(ctx, inst.ProviderID, class); err != nil {
+		return fmt.Errorf("provider resize: %w", err)
+	}
+	return inst.Update().SetClass(class.Name).Exec(ctx)
 }
 
 // GetByProviderID looks up an instance by its provider-side ID.
@@ -299,34 +724,86 @@ func (s *InstanceService) GetByProviderID(ctx context.Context, providerID string
 	return toResponse(inst), nil
 }
 
-// GetByUserID looks up the active instance for a user.
-func (s *InstanceService) GetByUserID(ctx context.Context, userID int) (*InstanceResponse, error) {
-	inst, err := s.db.Instance.Query().
+// List returns the user's live instances, oldest first.
+func (s *InstanceService) List(ctx context.Context, userID int) ([]*InstanceResponse, error) {
+	rows, err := s.db.Instance.Query().
 		Where(
 			entinstance.HasOwnerWith(entuser.IDEQ(userID)),
-			entinstance.StatusIn("provisioning", "running", "stopped"),
+			entinstance.StatusIn(liveStatuses...),
 		).
-		Only(ctx)
+		Order(ent.Asc(entinstance.FieldCreatedAt), ent.Asc(entinstance.FieldID)).
+		All(ctx)
 	if err != nil {
-		if ent.IsNotFound(err) {
+		return nil, fmt.Errorf("list instances: %w", err)
+	}
+	out := make([]*InstanceResponse, len(rows))
+	for i, inst := range rows {
+		out[i] = toResponse(inst)
+	}
+	return out, nil
+}
+
+// selectInstance finds one of the user's instances in the given statuses.
+// selector is a numeric instance ID or an instance name; empty selects the
+// default instance, or the most recently created one if there is no default.
+func (s *InstanceService) selectInstance(ctx context.Context, userID int, selector string, statuses ...string) (*ent.Instance, error) {
+	query := s.db.Instance.Query().
+		Where(
+			entinstance.HasOwnerWith(entuser.IDEQ(userID)),
+			entinstance.StatusIn(statuses...),
+		)
+
+	if selector == "" {
+		rows, err := query.
+			Order(ent.Desc(entinstance.FieldCreatedAt), ent.Desc(entinstance.FieldID)).
+			All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
 			return nil, provider.ErrNotFound
 		}
+		for _, inst := range rows {
+			if inst.Name == provider.DefaultInstanceName {
+				return inst, nil
+			}
+		}
+		return rows[0], nil
+	}
+
+	if id, err := strconv.Atoi(selector); err == nil {
+		query = query.Where(entinstance.IDEQ(id))
+	} else {
+		query = query.Where(entinstance.NameEQ(selector))
+	}
+	inst, err := query.Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, provider.ErrNotFound
+	}
+	return inst, err
+}
+
+// GetByUserID looks up one of the user's live instances. selector is an
+// instance ID or name; empty selects the default instance.
+func (s *InstanceService) GetByUserID(ctx context.Context, userID int, selector string) (*InstanceResponse, error) {
+	inst, err := s.selectInstance(ctx, userID, selector, liveStatuses...)
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("query by user_id: %w", err)
 	}
 	return toResponse(inst), nil
 }
 
-// GetConnectInfo returns the data needed to generate a connect script.
-func (s *InstanceService) GetConnectInfo(ctx context.Context, userID int) (*ConnectInfo, error) {
-	inst, err := s.db.Instance.Query().
-		Where(
-			entinstance.HasOwnerWith(entuser.IDEQ(userID)),
-			entinstance.StatusIn("running"),
-		).
-		Only(ctx)
+// GetConnectInfo returns the data needed to generate a connect script for
+// one of the user's running or stopped instances, chosen as in GetByUserID.
+// The caller must wake a stopped one before connecting.
+func (s *InstanceService) GetConnectInfo(ctx context.Context, userID int, selector string) (*ConnectInfo, error) {
+	inst, err := s.selectInstance(ctx, userID, selector, "running", "stopped")
 	if err != nil {
-		if ent.IsNotFound(err) {
-			return nil, provider.ErrNotFound
+		if errors.Is(err, provider.ErrNotFound) {
+			return nil, err
 		}
 		return nil, fmt.Errorf("query connect info: %w", err)
 	}
@@ -337,31 +814,117 @@ func (s *InstanceService) GetConnectInfo(ctx context.Context, userID int) (*Conn
 	}
 
 	return &ConnectInfo{
+		ID:            inst.ID,
 		Provider:      inst.Provider,
 		Host:          inst.Host,
 		ProviderID:    inst.ProviderID,
 		Status:        inst.Status,
 		NetbirdConfig: inst.NetbirdConfig,
 		UserID:        owner.ID,
+		Name:          inst.Name,
 	}, nil
 }
 
-// GetInstanceHost returns the host and agent secret for an instance, verifying user ownership.
-func (s *InstanceService) GetInstanceHost(ctx context.Context, id int, userID int) (host string, agentSecret string, err error) {
-	inst, err := s.db.Instance.Query().
-		Where(
-			entinstance.IDEQ(id),
-			entinstance.HasOwnerWith(entuser.IDEQ(userID)),
-			entinstance.StatusIn("running"),
-		).
-		Only(ctx)
+// GetInstanceHost returns the host and agent secret of a running instance the
+// user can access, and how far: below AccessWrite the caller must keep the
+// session read-only. For a stopped instance it returns ErrInstanceStopped
+// along with the access, so the caller can tell whether it may wake it.
+func (s *InstanceService) GetInstanceHost(ctx context.Context, id int, userID int) (host string, agentSecret string, access Access, err error) {
+	access, inst, err := instanceAccess(ctx, s.db, id, userID)
 	if err != nil {
-		if ent.IsNotFound(err) {
-			return "", "", provider.ErrNotFound
+		return "", "", AccessNone, err
+	}
+	if access != AccessNone && inst.Status == "stopped" {
+		return "", "", access, ErrInstanceStopped
+	}
+	if access == AccessNone || inst.Status != "running" {
+		return "", "", AccessNone, provider.ErrNotFound
+	}
+	return inst.Host, inst.AgentSecret, access, nil
+}
+
+// Authorize checks that the user has at least need access to the instance.
+// It returns provider.ErrNotFound for instances the user cannot see and
+// ErrPermissionDenied when their access falls short. userID 0 is the admin.
+func (s *InstanceService) Authorize(ctx context.Context, id int, userID int, need Access) error {
+	_, err := authorizeInstance(ctx, s.db, id, userID, need)
+	return err
+}
+
+// Share shares the instance with an organization, or stops sharing it if
+// orgID is nil. teamAccess is what members get: "none", "read" or "write".
+// The caller must manage the instance, and both the caller and the owner must
+// be members of the organization.
+func (s *InstanceService) Share(ctx context.Context, id int, userID int, orgID *int, teamAccess string) (*InstanceResponse, error) {
+	if teamAccess == "" {
+		teamAccess = string(entinstance.TeamAccessNone)
+	}
+	if err := entinstance.TeamAccessValidator(entinstance.TeamAccess(teamAccess)); err != nil {
+		return nil, fmt.Errorf("%w: team access must be none, read or write", ErrInvalidMembership)
+	}
+	inst, err := authorizeInstance(ctx, s.db, id, userID, AccessManage)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerID := inst.Edges.Owner.ID
+	update := inst.Update().SetTeamAccess(entinstance.TeamAccess(teamAccess))
+	if orgID == nil {
+		update = update.ClearOrganization().SetTeamAccess(entinstance.TeamAccessNone)
+	} else {
+		for _, uid := range []int{inst.Edges.Owner.ID, userID} {
+			if uid == 0 {
+				continue
+			}
+			role, err := memberRole(ctx, s.db, *orgID, uid)
+			if err != nil {
+				return nil, err
+			}
+			if roleRank[role] < roleRank[RoleMember] {
+				return nil, fmt.Errorf("%w: the instance owner and you must be members of the organization", ErrInvalidMembership)
+			}
 		}
-		return "", "", fmt.Errorf("query instance host: %w", err)
+		update = update.SetOrganizationID(*orgID)
+	}
+	inst, err = update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("share instance: %w", err)
+	}
+	details := map[string]any{"team_access": string(inst.TeamAccess)}
+	if orgID != nil {
+		details["organization_id"] = *orgID
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Action:     "instance.share",
+		TargetType: "instance",
+		TargetID:   strconv.Itoa(id),
+		SubjectID:  ownerID,
+		Details:    details,
+	})
+	return toResponse(inst), nil
+}
+
+// TerminalOpened records that userID opened a terminal on the instance.
+func (s *InstanceService) TerminalOpened(ctx context.Context, id int, userID int, readOnly bool) {
+	if s.audit == nil {
+		return
+	}
+	ownerID, err := s.db.Instance.Query().
+		Where(entinstance.IDEQ(id)).
+		QueryOwner().
+		OnlyID(ctx)
+	if err != nil {
+		slog.Error("failed to look up instance owner for audit", "instance_id", id, "error", err)
 	}
-	return inst.Host, inst.AgentSecret, nil
+	s.audit.Log(ctx, AuditEntry{
+		Actor:      ActorUser,
+		ActorID:    userID,
+		Action:     "instance.terminal",
+		TargetType: "instance",
+		TargetID:   strconv.Itoa(id),
+		SubjectID:  ownerID,
+		Details:    map[string]any{"read_only": readOnly},
+	})
 }
 
 // ParseID converts a string ID from URL params to int.
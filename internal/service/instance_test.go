@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -23,7 +26,6 @@ func createTestUser(t *testing.T, client *ent.Client) int {
 	t.Helper()
 	u, err := client.User.Create().
 		SetEmail("test@example.com").
-		SetAPIKey("test-key-123").
 		Save(context.Background())
 	if err != nil {
 		t.Fatalf("create test user: %v", err)
@@ -37,7 +39,7 @@ func TestInstanceService_Create(t *testing.T) {
 
 	userID := createTestUser(t, client)
 
-	inst, err := svc.Create(context.Background(), userID)
+	inst, err := svc.Create(context.Background(), userID, InstanceSpec{})
 	if err != nil {
 		t.Fatalf("create: %v", err)
 	}
@@ -55,12 +57,12 @@ func TestInstanceService_DuplicateCreate(t *testing.T) {
 
 	userID := createTestUser(t, client)
 
-	_, err := svc.Create(context.Background(), userID)
+	_, err := svc.Create(context.Background(), userID, InstanceSpec{})
 	if err != nil {
 		t.Fatalf("first create: %v", err)
 	}
 
-	_, err = svc.Create(context.Background(), userID)
+	_, err = svc.Create(context.Background(), userID, InstanceSpec{})
 	if err == nil {
 		t.Fatal("expected error on duplicate create, got nil")
 	}
@@ -82,7 +84,7 @@ func TestInstanceService_PauseWake(t *testing.T) {
 
 	userID := createTestUser(t, client)
 
-	inst, err := svc.Create(context.Background(), userID)
+	inst, err := svc.Create(context.Background(), userID, InstanceSpec{})
 	if err != nil {
 		t.Fatalf("create: %v", err)
 	}
@@ -114,7 +116,7 @@ func TestInstanceService_Delete(t *testing.T) {
 
 	userID := createTestUser(t, client)
 
-	inst, err := svc.Create(context.Background(), userID)
+	inst, err := svc.Create(context.Background(), userID, InstanceSpec{})
 	if err != nil {
 		t.Fatalf("create: %v", err)
 	}
@@ -135,7 +137,7 @@ func TestInstanceService_InvalidStateTransitions(t *testing.T) {
 
 	userID := createTestUser(t, client)
 
-	inst, _ := svc.Create(context.Background(), userID)
+	inst, _ := svc.Create(context.Background(), userID, InstanceSpec{})
 
 	// Can't wake a running instance
 	if err := svc.Wake(context.Background(), inst.ID); err == nil {
@@ -148,3 +150,244 @@ func TestInstanceService_InvalidStateTransitions(t *testing.T) {
 		t.Error("expected error pausing stopped instance")
 	}
 }
+
+// snapshotMock adds provider.Snapshotter to the mock, keeping at most two
+// snapshots like a retention policy of 2 would.
+type snapshotMock struct {
+	*provider.MockProvisioner
+	next  int
+	snaps []string
+}
+
+func (m *snapshotMock) PauseSnapshot(ctx context.Context, instanceID string) (string, error) {
+	if err := m.Pause(ctx, instanceID); err != nil {
+		return "", err
+	}
+	m.next++
+	id := fmt.Sprintf("snap-%d", m.next)
+	m.snaps = append(m.snaps, id)
+	return id, nil
+}
+
+func (m *snapshotMock) WakeSnapshot(ctx context.Context, instanceID, snapshotID string) (*provider.Instance, []string, error) {
+	if snapshotID != m.snaps[len(m.snaps)-1] {
+		return nil, nil, fmt.Errorf("woke from %q, want latest", snapshotID)
+	}
+	if err := m.Wake(ctx, instanceID); err != nil {
+		return nil, nil, err
+	}
+	if len(m.snaps) > 2 {
+		m.snaps = m.snaps[len(m.snaps)-2:]
+	}
+	return &provider.Instance{Host: "10.0.0.9"}, slices.Clone(m.snaps), nil
+}
+
+func TestInstanceService_SnapshotPauseWake(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_snapshot?mode=memory&_fk=1")
+	defer client.Close()
+	svc := NewInstanceService(client, &snapshotMock{MockProvisioner: provider.NewMock()}, "")
+	ctx := context.Background()
+
+	userID := createTestUser(t, client)
+	inst, err := svc.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := svc.Pause(ctx, inst.ID); err != nil {
+			t.Fatalf("pause %d: %v", i, err)
+		}
+		row, _ := client.Instance.Get(ctx, inst.ID)
+		if row.PausedAt == nil || len(row.SnapshotIds) == 0 || row.SnapshotIds[len(row.SnapshotIds)-1] != fmt.Sprintf("snap-%d", i+1) {
+			t.Fatalf("after pause %d: paused_at=%v snapshots=%v", i, row.PausedAt, row.SnapshotIds)
+		}
+		if err := svc.Wake(ctx, inst.ID); err != nil {
+			t.Fatalf("wake %d: %v", i, err)
+		}
+	}
+
+	got, err := svc.Get(ctx, inst.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Status != "running" || got.PausedAt != nil || got.Host != "10.0.0.9" {
+		t.Errorf("after wake: %+v", got)
+	}
+	if !slices.Equal(got.SnapshotIDs, []string{"snap-2", "snap-3"}) {
+		t.Errorf("snapshot ids = %v, want pruned to [snap-2 snap-3]", got.SnapshotIDs)
+	}
+}
+
+func TestInstanceService_NamedInstances(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_named?mode=memory&_fk=1")
+	defer client.Close()
+	mock := provider.NewMock()
+	svc := NewInstanceService(client, mock, "")
+	svc.SetPlanLimits(map[string]int{"free": 1, "pro": 3})
+	ctx := context.Background()
+
+	userID := client.User.Create().SetEmail("named@example.com").SetPlan("pro").SaveX(ctx).ID
+
+	def, err := svc.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create default: %v", err)
+	}
+	feature, err := svc.Create(ctx, userID, InstanceSpec{Name: "feature-x"})
+	if err != nil {
+		t.Fatalf("create named: %v", err)
+	}
+	if def.Name != provider.DefaultInstanceName || feature.Name != "feature-x" {
+		t.Errorf("names = %q, %q", def.Name, feature.Name)
+	}
+	if def.ProviderID != fmt.Sprintf("mock-%d", userID) || feature.ProviderID != fmt.Sprintf("mock-%d-feature-x", userID) {
+		t.Errorf("provider ids = %q, %q", def.ProviderID, feature.ProviderID)
+	}
+
+	if _, err := svc.Create(ctx, userID, InstanceSpec{Name: "feature-x"}); !errors.Is(err, provider.ErrAlreadyExists) {
+		t.Errorf("duplicate name: got %v, want ErrAlreadyExists", err)
+	}
+	if _, err := svc.Create(ctx, userID, InstanceSpec{Name: "Bad_Name"}); !errors.Is(err, ErrInvalidInstanceName) {
+		t.Errorf("invalid name: got %v, want ErrInvalidInstanceName", err)
+	}
+	if _, err := svc.Create(ctx, userID, InstanceSpec{Name: "third"}); err != nil {
+		t.Fatalf("create third: %v", err)
+	}
+	if _, err := svc.Create(ctx, userID, InstanceSpec{Name: "fourth"}); !errors.Is(err, ErrInstanceLimit) {
+		t.Errorf("over limit: got %v, want ErrInstanceLimit", err)
+	}
+
+	// A destroyed instance frees both its name and its slot
+	if err := svc.Delete(ctx, feature.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := svc.Create(ctx, userID, InstanceSpec{Name: "feature-x"}); err != nil {
+		t.Fatalf("recreate after delete: %v", err)
+	}
+
+	list, err := svc.List(ctx, userID)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(list) != 3 || list[0].ID != def.ID {
+		t.Errorf("list = %+v", list)
+	}
+}
+
+func TestInstanceService_SelectInstance(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_select?mode=memory&_fk=1")
+	defer client.Close()
+	svc := NewInstanceService(client, provider.NewMock(), "")
+	svc.SetPlanLimits(map[string]int{"free": 2})
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	// Without a default instance, the newest one is picked
+	only, err := svc.Create(ctx, userID, InstanceSpec{Name: "scratch"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if got, err := svc.GetByUserID(ctx, userID, ""); err != nil || got.ID != only.ID {
+		t.Fatalf("empty selector without default: got %+v, %v", got, err)
+	}
+
+	def, err := svc.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create default: %v", err)
+	}
+	for selector, want := range map[string]int{
+		"":                           def.ID,
+		"scratch":                    only.ID,
+		fmt.Sprint(only.ID):          only.ID,
+		provider.DefaultInstanceName: def.ID,
+	} {
+		got, err := svc.GetByUserID(ctx, userID, selector)
+		if err != nil || got.ID != want {
+			t.Errorf("GetByUserID(%q) = %+v, %v; want instance %d", selector, got, err, want)
+		}
+	}
+	if _, err := svc.GetByUserID(ctx, userID, "missing"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("unknown name: got %v, want ErrNotFound", err)
+	}
+	if _, err := svc.GetByUserID(ctx, userID+1, "scratch"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("other user: got %v, want ErrNotFound", err)
+	}
+
+	info, err := svc.GetConnectInfo(ctx, userID, "scratch")
+	if err != nil {
+		t.Fatalf("connect info: %v", err)
+	}
+	if info.Name != "scratch" || info.ProviderID != only.ProviderID {
+		t.Errorf("connect info = %+v", info)
+	}
+}
+
+func TestInstanceService_Classes(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_classes?mode=memory&_fk=1")
+	defer client.Close()
+	mock := provider.NewMock()
+	svc := NewInstanceService(client, mock, "")
+	svc.SetPlanLimits(map[string]int{"free": 2, "pro": 2})
+	svc.SetClasses(provider.DefaultClasses, map[string][]string{"pro": {"medium", "large"}})
+	ctx := context.Background()
+
+	freeID := createTestUser(t, client)
+	proID := client.User.Create().SetEmail("pro@example.com").SetPlan("pro").SaveX(ctx).ID
+
+	// Plans without an entry get the first catalog class
+	inst, err := svc.Create(ctx, freeID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create free: %v", err)
+	}
+	if inst.Class != "small" || mock.Class(inst.ProviderID).MemoryMB != 4096 {
+		t.Errorf("free default class = %q (%+v), want small", inst.Class, mock.Class(inst.ProviderID))
+	}
+	if _, err := svc.Create(ctx, freeID, InstanceSpec{Name: "big", Class: "large"}); !errors.Is(err, ErrClassNotAllowed) {
+		t.Errorf("free large: got %v, want ErrClassNotAllowed", err)
+	}
+	if _, err := svc.Create(ctx, freeID, InstanceSpec{Name: "huge", Class: "xlarge"}); !errors.Is(err, ErrUnknownClass) {
+		t.Errorf("unknown class: got %v, want ErrUnknownClass", err)
+	}
+
+	// The plan's first class is its default
+	pro, err := svc.Create(ctx, proID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create pro: %v", err)
+	}
+	if pro.Class != "medium" {
+		t.Errorf("pro default class = %q, want medium", pro.Class)
+	}
+
+	if err := svc.Resize(ctx, pro.ID, "large"); err != nil {
+		t.Fatalf("resize: %v", err)
+	}
+	if got, _ := svc.Get(ctx, pro.ID); got.Class != "large" || mock.Class(pro.ProviderID).ServerType != "cx42" {
+		t.Errorf("after resize: class %q, provider class %+v", got.Class, mock.Class(pro.ProviderID))
+	}
+	if err := svc.Resize(ctx, pro.ID, "small"); !errors.Is(err, ErrClassNotAllowed) {
+		t.Errorf("resize to disallowed class: got %v, want ErrClassNotAllowed", err)
+	}
+
+	allowed, err := svc.AllowedClasses(ctx, proID)
+	if err != nil {
+		t.Fatalf("allowed: %v", err)
+	}
+	if len(allowed) != 2 || allowed[0].Name != "medium" || allowed[1].Name != "large" {
+		t.Errorf("pro classes = %+v", allowed)
+	}
+}
+
+func TestParsePlanClasses(t *testing.T) {
+	got, err := ParsePlanClasses("free=small, pro=small|medium | large")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if !slices.Equal(got["free"], []string{"small"}) || !slices.Equal(got["pro"], []string{"small", "medium", "large"}) {
+		t.Errorf("parsed = %v", got)
+	}
+	for _, bad := range []string{"free", "free=", "pro=|"} {
+		if _, err := ParsePlanClasses(bad); err == nil {
+			t.Errorf("ParsePlanClasses(%q): expected error", bad)
+		}
+	}
+}
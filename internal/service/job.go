@@ -0,0 +1,769 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelTrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/logan/cloudcode/internal/ent"
+	entjob "github.com/logan/cloudcode/internal/ent/job"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+var jobTracer = otel.Tracer("cloudcode/service/job")
+
+// Job types.
+const (
+	JobCreate  = "create"
+	JobPause   = "pause"
+	JobWake    = "wake"
+	JobDestroy = "destroy"
+	JobResize  = "resize"
+	JobBackup  = "backup"
+	JobRestore = "restore"
+)
+
+// Job statuses.
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// Job error codes, recorded alongside the error of a failed attempt so
+// callers need not match on its text.
+const (
+	JobErrorNotFound      = "not_found"
+	JobErrorAlreadyExists = "already_exists"
+	JobErrorInvalidState  = "invalid_state"
+)
+
+// jobTimeout bounds a single attempt. Hetzner terraform apply can take minutes.
+const jobTimeout = 15 * time.Minute
+
+// ErrJobNotFound indicates the requested job does not exist or is not visible to the caller.
+var ErrJobNotFound = errors.New("job not found")
+
+// JobService runs instance lifecycle operations asynchronously from a
+// database-backed queue. Jobs survive restarts: anything left "running" by a
+// previous process is re-queued on Start.
+type JobService struct {
+	db           *ent.Client
+	instances    *InstanceService
+	backups      *BackupService // nil when no backup store is configured
+	audit        *AuditService  // nil records nothing
+	logger       *slog.Logger
+	workers      int
+	pollInterval time.Duration
+	retryBackoff time.Duration
+	wakeTimeout  time.Duration
+	wakePoll     time.Duration
+	agentHealth  func(ctx context.Context, host string) error
+
+	// enqueueMu makes checking for an instance's in-flight job, or a user's
+	// plan limit and instance names, and queueing the next one atomic within
+	// this process; claimMu does the same for workers claiming jobs
+	enqueueMu sync.Mutex
+	claimMu   sync.Mutex
+
+	wakeCh chan struct{}
+	stopCh chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewJobService creates a new JobService with the given worker pool size.
+func NewJobService(db *ent.Client, instances *InstanceService, logger *slog.Logger, workers int, pollInterval time.Duration) *JobService {
+	if workers < 1 {
+		workers = 1
+	}
+	return &JobService{
+		db:           db,
+		instances:    instances,
+		logger:       logger,
+		workers:      workers,
+		pollInterval: pollInterval,
+		retryBackoff: 10 * time.Second,
+		wakeTimeout:  DefaultWakeTimeout,
+		wakePoll:     time.Second,
+		agentHealth:  checkAgentHealth,
+		wakeCh:       make(chan struct{}, 1),
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// SetBackupService wires in the optional BackupService for backup and restore jobs.
+func (s *JobService) SetBackupService(b *BackupService) {
+	s.backups = b
+}
+
+// SetAuditService wires in the audit log, which records who queued each
+// instance operation.
+func (s *JobService) SetAuditService(a *AuditService) {
+	s.audit = a
+}
+
+// auditJob records that j was queued for an instance owned by ownerID.
+// Create jobs have no instance yet, so they are recorded against the job.
+func (s *JobService) auditJob(ctx context.Context, j *ent.Job, ownerID int, details map[string]any) {
+	e := AuditEntry{Action: "instance." + j.Type, SubjectID: ownerID, Details: map[string]any{"job_id": j.ID}}
+	if j.InstanceID != nil {
+		e.TargetType, e.TargetID = "instance", strconv.Itoa(*j.InstanceID)
+	} else {
+		e.TargetType, e.TargetID = "job", strconv.Itoa(j.ID)
+	}
+	for k, v := range details {
+		e.Details[k] = v
+	}
+	s.audit.Log(ctx, e)
+}
+
+// JobResponse is the API response for a job.
+type JobResponse struct {
+	ID          int        `json:"id"`
+	Type        string     `json:"type"`
+	Status      string     `json:"status"`
+	InstanceID  *int       `json:"instance_id,omitempty"`
+	TargetID    *int       `json:"target_id,omitempty"`
+	Progress    string     `json:"progress"`
+	Error       string     `json:"error,omitempty"`
+	ErrorCode   string     `json:"error_code,omitempty"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"max_attempts"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+func toJobResponse(j *ent.Job) *JobResponse {
+	return &JobResponse{
+		ID:          j.ID,
+		Type:        j.Type,
+		Status:      j.Status,
+		InstanceID:  j.InstanceID,
+		TargetID:    j.TargetID,
+		Progress:    j.Progress,
+		Error:       j.Error,
+		ErrorCode:   j.ErrorCode,
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		CreatedAt:   j.CreatedAt,
+		StartedAt:   j.StartedAt,
+		FinishedAt:  j.FinishedAt,
+	}
+}
+
+// EnqueueCreate queues provisioning of a new instance for the user. Returns
+// provider.ErrAlreadyExists if the user already has an instance or a create
+// job in flight with the spec's name, ErrInstanceLimit if live instances plus
+// queued creates reach the plan limit, and the class and template errors
+// from InstanceService.Create.
+func (s *JobService) EnqueueCreate(ctx context.Context, userID int, spec InstanceSpec) (*JobResponse, error) {
+	name, err := normalizeInstanceName(spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.instances.resolveClass(ctx, userID, spec.Class); err != nil {
+		return nil, err
+	}
+	var templateID *int
+	if spec.Template != "" {
+		t, err := lookupTemplate(ctx, s.db, userID, spec.Template)
+		if err != nil {
+			return nil, err
+		}
+		templateID = &t.ID
+	}
+
+	s.enqueueMu.Lock()
+	defer s.enqueueMu.Unlock()
+	inflight, err := s.db.Job.Query().
+		Where(
+			entjob.HasOwnerWith(entuser.IDEQ(userID)),
+			entjob.TypeEQ(JobCreate),
+			entjob.StatusIn(JobPending, JobRunning),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query pending jobs: %w", err)
+	}
+	var pending []string
+	for _, j := range inflight {
+		// A running create may already have saved its instance row
+		if j.InstanceID != nil {
+			continue
+		}
+		pending = append(pending, cmp.Or(j.InstanceName, provider.DefaultInstanceName))
+	}
+	if err := s.instances.checkCreate(ctx, userID, name, pending); err != nil {
+		return nil, err
+	}
+
+	j, err := s.db.Job.Create().
+		SetType(JobCreate).
+		SetInstanceName(name).
+		SetInstanceClass(spec.Class).
+		SetNillableTargetID(templateID).
+		SetOwnerID(userID).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("save job: %w", err)
+	}
+	s.notify()
+	s.auditJob(ctx, j, userID, map[string]any{"name": name, "class": spec.Class, "template": spec.Template})
+	return toJobResponse(j), nil
+}
+
+// EnqueueInstanceOp queues a pause, wake or destroy of an existing instance.
+// If the same operation is already in flight it returns that job instead;
+// any other in-flight job on the instance makes it provider.ErrInvalidState.
+func (s *JobService) EnqueueInstanceOp(ctx context.Context, jobType string, instanceID int) (*JobResponse, error) {
+	switch jobType {
+	case JobPause, JobWake, JobDestroy:
+	default:
+		return nil, fmt.Errorf("unknown job type %q", jobType)
+	}
+
+	s.enqueueMu.Lock()
+	defer s.enqueueMu.Unlock()
+	busy, err := s.inflight(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if busy != nil {
+		if busy.Type == jobType {
+			return toJobResponse(busy), nil
+		}
+		return nil, provider.ErrInvalidState
+	}
+
+	inst, err := s.db.Instance.Get(ctx, instanceID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, provider.ErrNotFound
+		}
+		return nil, fmt.Errorf("get instance: %w", err)
+	}
+	if !validTransition(jobType, inst.Status) {
+		return nil, provider.ErrInvalidState
+	}
+	ownerID, err := inst.QueryOwner().OnlyID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query owner: %w", err)
+	}
+
+	j, err := s.db.Job.Create().
+		SetType(jobType).
+		SetInstanceID(instanceID).
+		SetOwnerID(ownerID).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("save job: %w", err)
+	}
+	s.notify()
+	s.auditJob(ctx, j, ownerID, nil)
+	return toJobResponse(j), nil
+}
+
+// EnqueueResize queues a move of the instance to the named class. If userID
+// is non-zero, that user must be able to manage the instance. Like the other
+// instance operations below, it returns provider.ErrInvalidState while
+// another job on the instance is in flight.
+func (s *JobService) EnqueueResize(ctx context.Context, instanceID int, userID int, class string) (*JobResponse, error) {
+	if _, ok := s.instances.provider.(provider.Resizer); !ok {
+		return nil, provider.ErrNotSupported
+	}
+	inst, err := authorizeInstance(ctx, s.db, instanceID, userID, AccessManage)
+	if err != nil {
+		return nil, err
+	}
+	if !validTransition(JobResize, inst.Status) {
+		return nil, provider.ErrInvalidState
+	}
+	s.enqueueMu.Lock()
+	defer s.enqueueMu.Unlock()
+	if busy, err := s.inflight(ctx, instanceID); err != nil {
+		return nil, err
+	} else if busy != nil {
+		return nil, provider.ErrInvalidState
+	}
+	ownerID, err := inst.QueryOwner().OnlyID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query owner: %w", err)
+	}
+	if class == "" {
+		return nil, ErrUnknownClass
+	}
+	if _, err := s.instances.resolveClass(ctx, ownerID, class); err != nil {
+		return nil, err
+	}
+
+	j, err := s.db.Job.Create().
+		SetType(JobResize).
+		SetInstanceID(instanceID).
+		SetInstanceClass(class).
+		SetOwnerID(ownerID).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("save job: %w", err)
+	}
+	s.notify()
+	s.auditJob(ctx, j, ownerID, map[string]any{"from": inst.Class, "to": class})
+	return toJobResponse(j), nil
+}
+
+// EnqueueBackup records a pending backup of the instance volume and queues the
+// job that archives it. If userID is non-zero, that user must be able to
+// manage the instance. The job's target_id is the new backup.
+func (s *JobService) EnqueueBackup(ctx context.Context, instanceID int, userID int, trigger string) (*JobResponse, error) {
+	if s.backups == nil {
+		return nil, provider.ErrProviderNotConfigured
+	}
+	inst, err := authorizeInstance(ctx, s.db, instanceID, userID, AccessManage)
+	if err != nil {
+		return nil, err
+	}
+	if !validTransition(JobBackup, inst.Status) || inst.VolumeID == "" {
+		return nil, provider.ErrInvalidState
+	}
+	s.enqueueMu.Lock()
+	defer s.enqueueMu.Unlock()
+	if busy, err := s.inflight(ctx, instanceID); err != nil {
+		return nil, err
+	} else if busy != nil {
+		return nil, provider.ErrInvalidState
+	}
+	ownerID, err := inst.QueryOwner().OnlyID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query owner: %w", err)
+	}
+
+	b, err := s.backups.create(ctx, inst, ownerID, trigger)
+	if err != nil {
+		return nil, fmt.Errorf("save backup: %w", err)
+	}
+	j, err := s.db.Job.Create().
+		SetType(JobBackup).
+		SetInstanceID(instanceID).
+		SetTargetID(b.ID).
+		SetOwnerID(ownerID).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("save job: %w", err)
+	}
+	s.auditJob(ctx, j, ownerID, map[string]any{"backup_id": b.ID, "trigger": trigger})
+	s.notify()
+	return toJobResponse(j), nil
+}
+
+// EnqueueRestore queues restoring a backup into the stopped instance's volume,
+// followed by a wake. If userID is non-zero, that user must be able to manage
+// the instance.
+func (s *JobService) EnqueueRestore(ctx context.Context, instanceID int, userID int, backupID int) (*JobResponse, error) {
+	if s.backups == nil {
+		return nil, provider.ErrProviderNotConfigured
+	}
+	inst, err := authorizeInstance(ctx, s.db, instanceID, userID, AccessManage)
+	if err != nil {
+		return nil, err
+	}
+	if !validTransition(JobRestore, inst.Status) {
+		return nil, provider.ErrInvalidState
+	}
+	s.enqueueMu.Lock()
+	defer s.enqueueMu.Unlock()
+	if busy, err := s.inflight(ctx, instanceID); err != nil {
+		return nil, err
+	} else if busy != nil {
+		return nil, provider.ErrInvalidState
+	}
+	if _, err := s.backups.restorable(ctx, instanceID, backupID); err != nil {
+		return nil, err
+	}
+	ownerID, err := inst.QueryOwner().OnlyID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query owner: %w", err)
+	}
+
+	j, err := s.db.Job.Create().
+		SetType(JobRestore).
+		SetInstanceID(instanceID).
+		SetTargetID(backupID).
+		SetOwnerID(ownerID).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("save job: %w", err)
+	}
+	s.notify()
+	s.auditJob(ctx, j, ownerID, map[string]any{"backup_id": backupID})
+	return toJobResponse(j), nil
+}
+
+// inflight returns the pending or running job on the instance, or nil.
+func (s *JobService) inflight(ctx context.Context, instanceID int) (*ent.Job, error) {
+	j, err := s.db.Job.Query().
+		Where(entjob.InstanceIDEQ(instanceID), entjob.StatusIn(JobPending, JobRunning)).
+		Order(ent.Asc(entjob.FieldID)).
+		First(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query in-flight jobs: %w", err)
+	}
+	return j, nil
+}
+
+// validTransition rejects operations that would fail against the current status
+// up front, so callers get a 409 instead of a failed job.
+func validTransition(jobType, status string) bool {
+	switch jobType {
+	case JobPause:
+		return status == "running"
+	case JobWake:
+		return status == "stopped"
+	case JobDestroy:
+		return status != "destroyed"
+	case JobResize, JobBackup:
+		return status == "running" || status == "stopped"
+	case JobRestore:
+		return status == "stopped"
+	}
+	return false
+}
+
+// Get returns a job by ID. If userID is non-zero, the job must belong to that
+// user or be on an instance the user can manage, such as a teammate's.
+func (s *JobService) Get(ctx context.Context, id int, userID int) (*JobResponse, error) {
+	j, err := s.db.Job.Query().Where(entjob.IDEQ(id)).WithOwner().Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("get job: %w", err)
+	}
+	if userID != 0 && j.Edges.Owner.ID != userID {
+		if j.InstanceID == nil {
+			return nil, ErrJobNotFound
+		}
+		_, err := authorizeInstance(ctx, s.db, *j.InstanceID, userID, AccessManage)
+		if errors.Is(err, provider.ErrNotFound) || errors.Is(err, ErrPermissionDenied) {
+			return nil, ErrJobNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return toJobResponse(j), nil
+}
+
+// Start recovers interrupted jobs and launches the worker pool.
+func (s *JobService) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	if n, err := s.recover(ctx); err != nil {
+		s.logger.Error("failed to recover interrupted jobs", "error", err)
+	} else if n > 0 {
+		s.logger.Info("re-queued interrupted jobs", "count", n)
+	}
+
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.work(ctx)
+	}
+	s.logger.Info("job service started", "workers", s.workers, "poll_interval", s.pollInterval)
+}
+
+// Stop signals workers to stop and waits for them to exit. Jobs cut short
+// are left "running" and resumed on the next Start.
+func (s *JobService) Stop() {
+	close(s.stopCh)
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+	s.logger.Info("job service stopped")
+}
+
+// recover puts jobs orphaned by a previous process back in the queue.
+func (s *JobService) recover(ctx context.Context) (int, error) {
+	return s.db.Job.Update().
+		Where(entjob.StatusEQ(JobRunning)).
+		SetStatus(JobPending).
+		SetRunAt(time.Now()).
+		Save(ctx)
+}
+
+func (s *JobService) notify() {
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *JobService) work(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the queue before sleeping
+		for s.RunNext(ctx) {
+		}
+
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+		case <-s.wakeCh:
+		}
+	}
+}
+
+// RunNext claims and executes one due job. Returns false if none was available.
+func (s *JobService) RunNext(ctx context.Context) bool {
+	j, err := s.claim(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("failed to claim job", "error", err)
+		}
+		return false
+	}
+	if j == nil {
+		return false
+	}
+	s.execute(ctx, j)
+	return true
+}
+
+// claim atomically moves the oldest due pending job to running, skipping
+// jobs on instances that already have one running.
+func (s *JobService) claim(ctx context.Context) (*ent.Job, error) {
+	s.claimMu.Lock()
+	defer s.claimMu.Unlock()
+	for {
+		j, err := s.db.Job.Query().
+			Where(
+				entjob.StatusEQ(JobPending),
+				entjob.RunAtLTE(time.Now()),
+				idleInstance,
+			).
+			Order(ent.Asc(entjob.FieldRunAt), ent.Asc(entjob.FieldID)).
+			First(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		// Conditional update so only one worker wins the job
+		n, err := s.db.Job.Update().
+			Where(entjob.IDEQ(j.ID), entjob.StatusEQ(JobPending), idleInstance).
+			SetStatus(JobRunning).
+			AddAttempts(1).
+			SetStartedAt(time.Now()).
+			Save(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if n == 1 {
+			return s.db.Job.Get(ctx, j.ID)
+		}
+	}
+}
+
+// idleInstance matches jobs on no instance, or on one with no job running,
+// so that two operations never run on an instance at once.
+func idleInstance(sel *sql.Selector) {
+	running := sql.Table(entjob.Table).As("running")
+	sel.Where(sql.Or(
+		sql.IsNull(sel.C(entjob.FieldInstanceID)),
+		sql.Not(sql.Exists(
+			sql.Select(running.C(entjob.FieldID)).
+				From(running).
+				Where(sql.And(
+					sql.ColumnsEQ(running.C(entjob.FieldInstanceID), sel.C(entjob.FieldInstanceID)),
+					sql.EQ(running.C(entjob.FieldStatus), JobRunning),
+				)),
+		)),
+	))
+}
+
+func (s *JobService) execute(ctx context.Context, j *ent.Job) {
+	ctx, span := jobTracer.Start(ctx, "job."+j.Type,
+		otelTrace.WithAttributes(attribute.Int("job_id", j.ID), attribute.Int("attempt", j.Attempts)))
+	defer span.End()
+
+	runCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+	runCtx = WithProgress(runCtx, func(step string) {
+		if _, err := s.db.Job.UpdateOneID(j.ID).SetProgress(step).Save(ctx); err != nil {
+			s.logger.Warn("failed to record job progress", "job_id", j.ID, "error", err)
+		}
+	})
+
+	instanceID, err := s.run(runCtx, j)
+
+	// Shutdown mid-job: leave it running so recover() picks it up next start
+	if ctx.Err() != nil {
+		s.logger.Info("job interrupted by shutdown", "job_id", j.ID, "type", j.Type)
+		return
+	}
+
+	now := time.Now()
+	update := s.db.Job.UpdateOneID(j.ID)
+	if instanceID != 0 {
+		update = update.SetInstanceID(instanceID)
+	}
+
+	switch {
+	case err == nil:
+		update = update.SetStatus(JobSucceeded).SetProgress("done").ClearError().ClearErrorCode().SetFinishedAt(now)
+		s.logger.Info("job succeeded", "job_id", j.ID, "type", j.Type, "attempt", j.Attempts)
+	case isPermanent(err) || j.Attempts >= j.MaxAttempts:
+		span.RecordError(err)
+		update = update.SetStatus(JobFailed).SetError(err.Error()).SetErrorCode(jobErrorCode(err)).SetFinishedAt(now)
+		s.logger.Error("job failed", "job_id", j.ID, "type", j.Type, "attempt", j.Attempts, "error", err)
+	default:
+		span.RecordError(err)
+		backoff := s.retryBackoff * time.Duration(1<<(j.Attempts-1))
+		update = update.SetStatus(JobPending).SetError(err.Error()).SetErrorCode(jobErrorCode(err)).SetRunAt(now.Add(backoff))
+		s.logger.Warn("job attempt failed, will retry", "job_id", j.ID, "type", j.Type,
+			"attempt", j.Attempts, "retry_in", backoff, "error", err)
+	}
+
+	if _, err := update.Save(ctx); err != nil {
+		s.logger.Error("failed to save job result", "job_id", j.ID, "error", err)
+	}
+}
+
+// run dispatches the job to the InstanceService (or BackupService for backup
+// and restore jobs). For create jobs it returns
+// the new instance ID so it can be recorded on the job.
+func (s *JobService) run(ctx context.Context, j *ent.Job) (int, error) {
+	if j.Type == JobCreate {
+		// A previous attempt may have saved the instance before the process died
+		if j.InstanceID != nil {
+			return *j.InstanceID, nil
+		}
+		ownerID, err := j.QueryOwner().OnlyID(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("query owner: %w", err)
+		}
+		name := cmp.Or(j.InstanceName, provider.DefaultInstanceName)
+		spec := InstanceSpec{Name: name, Class: j.InstanceClass}
+		if j.TargetID != nil {
+			spec.Template = strconv.Itoa(*j.TargetID)
+		}
+		inst, err := s.instances.Create(ctx, ownerID, spec)
+		if errors.Is(err, provider.ErrAlreadyExists) && j.Attempts > 1 {
+			// The instance was saved but the job result was not — adopt it
+			if existing, gerr := s.instances.GetByUserID(ctx, ownerID, name); gerr == nil {
+				return existing.ID, nil
+			}
+			// The process died between provisioning and saving the row. The
+			// agent secret the resource was booted with died too, so take it
+			// over by replacing it; its volume is kept
+			if rerr := s.instances.removeLeftover(ctx, ownerID, name); rerr == nil {
+				inst, err = s.instances.Create(ctx, ownerID, spec)
+			} else if !errors.Is(rerr, provider.ErrNotFound) {
+				return 0, rerr
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+		return inst.ID, nil
+	}
+
+	if j.InstanceID == nil {
+		return 0, fmt.Errorf("%s job has no instance", j.Type)
+	}
+	id := *j.InstanceID
+
+	switch j.Type {
+	case JobPause:
+		return 0, s.instances.Pause(ctx, id)
+	case JobWake:
+		return 0, s.instances.Wake(ctx, id)
+	case JobDestroy:
+		return 0, s.instances.Delete(ctx, id)
+	case JobResize:
+		return 0, s.instances.Resize(ctx, id, j.InstanceClass)
+	case JobBackup, JobRestore:
+		if s.backups == nil {
+			return 0, provider.ErrProviderNotConfigured
+		}
+		if j.TargetID == nil {
+			return 0, fmt.Errorf("%s job has no backup", j.Type)
+		}
+		if j.Type == JobBackup {
+			return 0, s.backups.Run(ctx, *j.TargetID)
+		}
+		if err := s.backups.Restore(ctx, id, *j.TargetID); err != nil {
+			return 0, err
+		}
+		return 0, s.instances.Wake(ctx, id)
+	default:
+		return 0, fmt.Errorf("unknown job type %q", j.Type)
+	}
+}
+
+// isPermanent reports whether retrying err cannot succeed.
+func isPermanent(err error) bool {
+	return errors.Is(err, provider.ErrNotFound) ||
+		errors.Is(err, provider.ErrAlreadyExists) ||
+		errors.Is(err, provider.ErrInvalidState) ||
+		errors.Is(err, provider.ErrProviderNotConfigured) ||
+		errors.Is(err, ErrBackupNotFound) ||
+		errors.Is(err, ErrInstanceLimit) ||
+		errors.Is(err, ErrInvalidInstanceName) ||
+		errors.Is(err, ErrUnknownClass) ||
+		errors.Is(err, ErrClassNotAllowed) ||
+		errors.Is(err, ErrTemplateNotFound) ||
+		errors.Is(err, ErrInvalidTemplate) ||
+		errors.Is(err, provider.ErrNotSupported)
+}
+
+// jobErrorCode returns the job error code for err, or "" if it has none.
+func jobErrorCode(err error) string {
+	switch {
+	case errors.Is(err, provider.ErrNotFound):
+		return JobErrorNotFound
+	case errors.Is(err, provider.ErrAlreadyExists):
+		return JobErrorAlreadyExists
+	case errors.Is(err, provider.ErrInvalidState):
+		return JobErrorInvalidState
+	}
+	return ""
+}
+
+type progressKey struct{}
+
+// WithProgress returns a context that carries a progress callback. Long-running
+// service methods call ReportProgress to surface their current step.
+func WithProgress(ctx context.Context, fn func(step string)) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ReportProgress invokes the progress callback on ctx, if any.
+func ReportProgress(ctx context.Context, step string) {
+	if fn, ok := ctx.Value(progressKey{}).(func(string)); ok {
+		fn(step)
+	}
+}
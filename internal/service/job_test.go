@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/logan/cloudcode/internal/ent"
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+// flakyProvisioner fails Create a fixed number of times before delegating.
+type flakyProvisioner struct {
+	provider.Provisioner
+	failures int
+}
+
+func (f *flakyProvisioner) Create(ctx context.Context, userID int, opts provider.CreateOptions) (*provider.Instance, error) {
+	if f.failures > 0 {
+		f.failures--
+		return nil, errors.New("transient provider error")
+	}
+	return f.Provisioner.Create(ctx, userID, opts)
+}
+
+func setupJobTest(t *testing.T, prov provider.Provisioner) (*JobService, *ent.Client) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	instSvc := NewInstanceService(client, prov, "")
+	jobs := NewJobService(client, instSvc, slog.Default(), 1, time.Hour)
+	jobs.retryBackoff = 0
+	return jobs, client
+}
+
+func TestJobService_CreateRunsToCompletion(t *testing.T) {
+	jobs, client := setupJobTest(t, provider.NewMock())
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	job, err := jobs.EnqueueCreate(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if job.Status != JobPending {
+		t.Errorf("got status %q, want %q", job.Status, JobPending)
+	}
+
+	if !jobs.RunNext(ctx) {
+		t.Fatal("expected a job to run")
+	}
+
+	got, err := jobs.Get(ctx, job.ID, userID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Status != JobSucceeded {
+		t.Fatalf("got status %q, want %q (error: %s)", got.Status, JobSucceeded, got.Error)
+	}
+	if got.InstanceID == nil {
+		t.Fatal("expected instance_id to be set")
+	}
+	inst, err := client.Instance.Get(ctx, *got.InstanceID)
+	if err != nil {
+		t.Fatalf("get instance: %v", err)
+	}
+	if inst.Status != "running" {
+		t.Errorf("instance status = %q, want running", inst.Status)
+	}
+}
+
+func TestJobService_DuplicateCreateRejected(t *testing.T) {
+	jobs, client := setupJobTest(t, provider.NewMock())
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	if _, err := jobs.EnqueueCreate(ctx, userID, InstanceSpec{}); err != nil {
+		t.Fatalf("first enqueue: %v", err)
+	}
+	if _, err := jobs.EnqueueCreate(ctx, userID, InstanceSpec{}); !errors.Is(err, provider.ErrAlreadyExists) {
+		t.Fatalf("second enqueue: got %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestJobService_RetriesTransientFailure(t *testing.T) {
+	jobs, client := setupJobTest(t, &flakyProvisioner{Provisioner: provider.NewMock(), failures: 1})
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	job, _ := jobs.EnqueueCreate(ctx, userID, InstanceSpec{})
+
+	jobs.RunNext(ctx)
+	got, _ := jobs.Get(ctx, job.ID, 0)
+	if got.Status != JobPending || got.Error == "" {
+		t.Fatalf("after first attempt: status %q error %q, want pending with error", got.Status, got.Error)
+	}
+
+	jobs.RunNext(ctx)
+	got, _ = jobs.Get(ctx, job.ID, 0)
+	if got.Status != JobSucceeded {
+		t.Fatalf("after retry: got status %q, want %q", got.Status, JobSucceeded)
+	}
+	if got.Attempts != 2 {
+		t.Errorf("attempts = %d, want 2", got.Attempts)
+	}
+}
+
+func TestJobService_GivesUpAfterMaxAttempts(t *testing.T) {
+	jobs, client := setupJobTest(t, &flakyProvisioner{Provisioner: provider.NewMock(), failures: 10})
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	job, _ := jobs.EnqueueCreate(ctx, userID, InstanceSpec{})
+	for jobs.RunNext(ctx) {
+	}
+
+	got, _ := jobs.Get(ctx, job.ID, 0)
+	if got.Status != JobFailed {
+		t.Fatalf("got status %q, want %q", got.Status, JobFailed)
+	}
+	if got.Attempts != got.MaxAttempts {
+		t.Errorf("attempts = %d, want %d", got.Attempts, got.MaxAttempts)
+	}
+}
+
+func TestJobService_RecoverRequeuesInterruptedJobs(t *testing.T) {
+	jobs, client := setupJobTest(t, provider.NewMock())
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	job, _ := jobs.EnqueueCreate(ctx, userID, InstanceSpec{})
+
+	// Simulate a process that claimed the job and died
+	if _, err := client.Job.UpdateOneID(job.ID).SetStatus(JobRunning).AddAttempts(1).Save(ctx); err != nil {
+		t.Fatalf("mark running: %v", err)
+	}
+	if jobs.RunNext(ctx) {
+		t.Fatal("running job should not be claimed")
+	}
+
+	n, err := jobs.recover(ctx)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("recovered %d jobs, want 1", n)
+	}
+
+	jobs.RunNext(ctx)
+	got, _ := jobs.Get(ctx, job.ID, 0)
+	if got.Status != JobSucceeded {
+		t.Fatalf("got status %q, want %q", got.Status, JobSucceeded)
+	}
+}
+
+func TestJobService_InstanceOpValidatesState(t *testing.T) {
+	jobs, client := setupJobTest(t, provider.NewMock())
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	inst, err := jobs.instances.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Running instance can't be woken
+	if _, err := jobs.EnqueueInstanceOp(ctx, JobWake, inst.ID); !errors.Is(err, provider.ErrInvalidState) {
+		t.Fatalf("wake running: got %v, want ErrInvalidState", err)
+	}
+
+	job, err := jobs.EnqueueInstanceOp(ctx, JobPause, inst.ID)
+	if err != nil {
+		t.Fatalf("enqueue pause: %v", err)
+	}
+	jobs.RunNext(ctx)
+
+	got, _ := jobs.Get(ctx, job.ID, userID)
+	if got.Status != JobSucceeded {
+		t.Fatalf("pause job status %q, want %q (error: %s)", got.Status, JobSucceeded, got.Error)
+	}
+	updated, _ := client.Instance.Get(ctx, inst.ID)
+	if updated.Status != "stopped" {
+		t.Errorf("instance status = %q, want stopped", updated.Status)
+	}
+}
+
+func TestJobService_OneJobPerInstance(t *testing.T) {
+	jobs, client := setupJobTest(t, provider.NewMock())
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	inst, err := jobs.instances.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	pause, err := jobs.EnqueueInstanceOp(ctx, JobPause, inst.ID)
+	if err != nil {
+		t.Fatalf("enqueue pause: %v", err)
+	}
+	// The same operation again is the job already queued
+	again, err := jobs.EnqueueInstanceOp(ctx, JobPause, inst.ID)
+	if err != nil || again.ID != pause.ID {
+		t.Fatalf("second pause: got %+v, %v; want job %d", again, err, pause.ID)
+	}
+	if _, err := jobs.EnqueueInstanceOp(ctx, JobDestroy, inst.ID); !errors.Is(err, provider.ErrInvalidState) {
+		t.Fatalf("destroy while pausing: got %v, want ErrInvalidState", err)
+	}
+
+	// A job queued alongside one already running waits for it
+	if _, err := client.Job.UpdateOneID(pause.ID).SetStatus(JobRunning).Save(ctx); err != nil {
+		t.Fatalf("mark running: %v", err)
+	}
+	client.Job.Create().SetType(JobDestroy).SetInstanceID(inst.ID).SetOwnerID(userID).SaveX(ctx)
+	if jobs.RunNext(ctx) {
+		t.Fatal("claimed a second job on a busy instance")
+	}
+	if _, err := client.Job.UpdateOneID(pause.ID).SetStatus(JobSucceeded).Save(ctx); err != nil {
+		t.Fatalf("mark succeeded: %v", err)
+	}
+	if !jobs.RunNext(ctx) {
+		t.Fatal("queued job not claimed once the instance was free")
+	}
+}
+
+func TestJobService_CreateRetryReplacesLeftover(t *testing.T) {
+	mock := provider.NewMock()
+	jobs, client := setupJobTest(t, mock)
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	job, err := jobs.EnqueueCreate(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	// Simulate an attempt that provisioned and then died before saving
+	if _, err := mock.Create(ctx, userID, provider.CreateOptions{Name: provider.DefaultInstanceName}); err != nil {
+		t.Fatalf("provision: %v", err)
+	}
+	if _, err := client.Job.UpdateOneID(job.ID).AddAttempts(1).Save(ctx); err != nil {
+		t.Fatalf("count attempt: %v", err)
+	}
+
+	jobs.RunNext(ctx)
+	got, _ := jobs.Get(ctx, job.ID, 0)
+	if got.Status != JobSucceeded || got.InstanceID == nil {
+		t.Fatalf("got status %q (error: %s), want %q with an instance", got.Status, got.Error, JobSucceeded)
+	}
+}
+
+func TestJobService_GetScopedToOwner(t *testing.T) {
+	jobs, client := setupJobTest(t, provider.NewMock())
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	job, _ := jobs.EnqueueCreate(ctx, userID, InstanceSpec{})
+
+	if _, err := jobs.Get(ctx, job.ID, userID+1); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("other user: got %v, want ErrJobNotFound", err)
+	}
+}
+
+func TestJobService_CreateCountsQueuedInstances(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_job_limit?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	instSvc := NewInstanceService(client, provider.NewMock(), "")
+	instSvc.SetPlanLimits(map[string]int{"free": 2})
+	jobs := NewJobService(client, instSvc, slog.Default(), 1, time.Hour)
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	if _, err := jobs.EnqueueCreate(ctx, userID, InstanceSpec{}); err != nil {
+		t.Fatalf("enqueue default: %v", err)
+	}
+	job, err := jobs.EnqueueCreate(ctx, userID, InstanceSpec{Name: "review"})
+	if err != nil {
+		t.Fatalf("enqueue named: %v", err)
+	}
+	if _, err := jobs.EnqueueCreate(ctx, userID, InstanceSpec{Name: "review"}); !errors.Is(err, provider.ErrAlreadyExists) {
+		t.Errorf("queued duplicate: got %v, want ErrAlreadyExists", err)
+	}
+	if _, err := jobs.EnqueueCreate(ctx, userID, InstanceSpec{Name: "extra"}); !errors.Is(err, ErrInstanceLimit) {
+		t.Errorf("queued over limit: got %v, want ErrInstanceLimit", err)
+	}
+
+	for jobs.RunNext(ctx) {
+	}
+	got, _ := jobs.Get(ctx, job.ID, userID)
+	if got.Status != JobSucceeded || got.InstanceID == nil {
+		t.Fatalf("named create: status %q error %q", got.Status, got.Error)
+	}
+	inst, _ := client.Instance.Get(ctx, *got.InstanceID)
+	if inst.Name != "review" {
+		t.Errorf("instance name = %q, want review", inst.Name)
+	}
+}
+
+// fixedProvisioner hides the mock's optional interfaces, like a provider
+// without resize support.
+type fixedProvisioner struct {
+	provider.Provisioner
+}
+
+func TestJobService_Resize(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_job_resize?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	mock := provider.NewMock()
+	instSvc := NewInstanceService(client, mock, "")
+	instSvc.SetClasses(provider.DefaultClasses, map[string][]string{"free": {"small", "medium"}})
+	jobs := NewJobService(client, instSvc, slog.Default(), 1, time.Hour)
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	inst, err := instSvc.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := jobs.EnqueueResize(ctx, inst.ID, userID+1, "medium"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("other user's instance: got %v, want ErrNotFound", err)
+	}
+	if _, err := jobs.EnqueueResize(ctx, inst.ID, userID, "large"); !errors.Is(err, ErrClassNotAllowed) {
+		t.Errorf("disallowed class: got %v, want ErrClassNotAllowed", err)
+	}
+
+	job, err := jobs.EnqueueResize(ctx, inst.ID, userID, "medium")
+	if err != nil {
+		t.Fatalf("enqueue resize: %v", err)
+	}
+	jobs.RunNext(ctx)
+	got, _ := jobs.Get(ctx, job.ID, userID)
+	if got.Status != JobSucceeded {
+		t.Fatalf("resize job: status %q error %q", got.Status, got.Error)
+	}
+	if mock.Class(inst.ProviderID).Name != "medium" {
+		t.Errorf("provider class = %+v, want medium", mock.Class(inst.ProviderID))
+	}
+
+	noResize := NewJobService(client, NewInstanceService(client, fixedProvisioner{mock}, ""), slog.Default(), 1, time.Hour)
+	if _, err := noResize.EnqueueResize(ctx, inst.ID, userID, "small"); !errors.Is(err, provider.ErrNotSupported) {
+		t.Errorf("provider without resize: got %v, want ErrNotSupported", err)
+	}
+}
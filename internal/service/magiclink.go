@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/logan/cloudcode/internal/auth"
+	"github.com/logan/cloudcode/internal/ent"
+	entmagiclink "github.com/logan/cloudcode/internal/ent/magiclink"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+)
+
+const (
+	// MagicLinkTTL is how long an emailed sign-in link works.
+	MagicLinkTTL = 15 * time.Minute
+	// MagicLinkNonceCookie binds a magic link to the browser that asked for
+	// it. It is only sent to the verify route.
+	MagicLinkNonceCookie = "magic_link_nonce"
+
+	// maxMagicLinkFailures failed sign-ins within magicLinkFailureWindow
+	// lock an account's magic links until the window passes without another.
+	maxMagicLinkFailures   = 5
+	magicLinkFailureWindow = 15 * time.Minute
+)
+
+var (
+	// ErrMagicLinkInvalid indicates the link is malformed, expired, already
+	// used, replaced by a newer link or opened in another browser.
+	ErrMagicLinkInvalid = errors.New("invalid or expired magic link")
+	// ErrMagicLinkThrottled indicates too many failed sign-ins for the account.
+	ErrMagicLinkThrottled = errors.New("too many failed sign-in attempts")
+)
+
+// SendMagicLink finds or creates a user by email and sends a magic link,
+// invalidating any links sent before. If bindBrowser is set, the link only
+// works in the browser w is answering, which gets a nonce cookie.
+func (s *AuthService) SendMagicLink(ctx context.Context, w http.ResponseWriter, email string, bindBrowser bool) error {
+	// Find or create user
+	u, err := s.db.User.Query().Where(entuser.EmailEQ(email)).Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			u, err = s.db.User.Create().
+				SetEmail(email).
+				Save(ctx)
+			if err != nil {
+				return fmt.Errorf("create user: %w", err)
+			}
+		} else {
+			return fmt.Errorf("query user: %w", err)
+		}
+	}
+
+	// Only the newest link works; expired links of other users go too
+	_, err = s.db.MagicLink.Delete().
+		Where(entmagiclink.Or(
+			entmagiclink.HasUserWith(entuser.IDEQ(u.ID)),
+			entmagiclink.ExpiresAtLT(time.Now()),
+		)).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("prune magic links: %w", err)
+	}
+
+	jti, err := randomToken(16)
+	if err != nil {
+		return err
+	}
+	create := s.db.MagicLink.Create().
+		SetJti(jti).
+		SetExpiresAt(time.Now().Add(MagicLinkTTL)).
+		SetUser(u)
+	var nonce string
+	if bindBrowser {
+		if nonce, err = randomToken(32); err != nil {
+			return err
+		}
+		create = create.SetNonceHash(auth.HashAccessToken(nonce))
+	}
+	if err := create.Exec(ctx); err != nil {
+		return fmt.Errorf("create magic link: %w", err)
+	}
+
+	claims := auth.NewClaims(u.ID, u.Email, "magic_link", "", MagicLinkTTL)
+	claims.ID = jti
+	token, err := s.keys.Sign(claims)
+	if err != nil {
+		return fmt.Errorf("generate token: %w", err)
+	}
+	if nonce != "" {
+		http.SetCookie(w, &http.Cookie{
+			Name:     MagicLinkNonceCookie,
+			Value:    nonce,
+			Path:     "/auth/verify",
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode, // sent when the link is opened from a mail client
+			MaxAge:   int(MagicLinkTTL.Seconds()),
+		})
+	}
+
+	link := fmt.Sprintf("%s/auth/verify?token=%s", s.baseURL, token)
+	return s.mailer.SendMagicLink(email, link)
+}
+
+// VerifyMagicLink uses up a magic link and starts a session, setting the
+// session cookies on the response. nonce is the value of the nonce cookie,
+// if the browser sent one. Failures count against the account, and too many
+// lock it with ErrMagicLinkThrottled.
+func (s *AuthService) VerifyMagicLink(ctx context.Context, w http.ResponseWriter, tokenStr, nonce string) (*SessionTokens, error) {
+	claims, err := s.keys.Validate(tokenStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMagicLinkInvalid, err)
+	}
+	if claims.Purpose != "magic_link" || claims.ID == "" {
+		return nil, ErrMagicLinkInvalid
+	}
+
+	// Verify user still exists
+	u, err := s.db.User.Get(ctx, claims.UserID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrMagicLinkInvalid
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	now := time.Now()
+	if u.MagicLinkFailures >= maxMagicLinkFailures && u.MagicLinkFailedAt != nil &&
+		now.Sub(*u.MagicLinkFailedAt) < magicLinkFailureWindow {
+		return nil, ErrMagicLinkThrottled
+	}
+
+	link, err := s.db.MagicLink.Query().
+		Where(entmagiclink.JtiEQ(claims.ID), entmagiclink.HasUserWith(entuser.IDEQ(u.ID))).
+		Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, fmt.Errorf("query magic link: %w", err)
+	}
+	ok := link != nil
+	if ok && link.NonceHash != "" {
+		ok = nonce != "" && subtle.ConstantTimeCompare([]byte(auth.HashAccessToken(nonce)), []byte(link.NonceHash)) == 1
+	}
+	if ok {
+		// Of two concurrent uses, only one deletes the link
+		n, err := s.db.MagicLink.Delete().Where(entmagiclink.IDEQ(link.ID)).Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("use magic link: %w", err)
+		}
+		ok = n == 1
+	}
+	if !ok {
+		if err := s.magicLinkFailed(ctx, u, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrMagicLinkInvalid
+	}
+
+	if u.MagicLinkFailures > 0 {
+		err := s.db.User.UpdateOne(u).
+			SetMagicLinkFailures(0).
+			ClearMagicLinkFailedAt().
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("reset sign-in failures: %w", err)
+		}
+	}
+	if nonce != "" {
+		http.SetCookie(w, &http.Cookie{Name: MagicLinkNonceCookie, Path: "/auth/verify", MaxAge: -1})
+	}
+	return s.issueSession(ctx, w, u)
+}
+
+// magicLinkFailed counts a failed sign-in against u. Counting starts over
+// once a window passes without failures.
+func (s *AuthService) magicLinkFailed(ctx context.Context, u *ent.User, now time.Time) error {
+	update := s.db.User.UpdateOne(u).SetMagicLinkFailedAt(now)
+	failures := 1
+	if u.MagicLinkFailedAt != nil && now.Sub(*u.MagicLinkFailedAt) < magicLinkFailureWindow {
+		update = update.AddMagicLinkFailures(1)
+		failures = u.MagicLinkFailures + 1
+	} else {
+		update = update.SetMagicLinkFailures(1)
+	}
+	if err := update.Exec(ctx); err != nil {
+		return fmt.Errorf("count sign-in failure: %w", err)
+	}
+	if failures == maxMagicLinkFailures {
+		slog.Warn("magic links locked after repeated failures", "user_id", u.ID)
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Actor:     ActorSystem,
+		Action:    "auth.magic_link_failed",
+		SubjectID: u.ID,
+		Details:   map[string]any{"failures": failures, "locked": failures >= maxMagicLinkFailures},
+	})
+	return nil
+}
@@ -0,0 +1,534 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/logan/cloudcode/internal/ent"
+	entinstance "github.com/logan/cloudcode/internal/ent/instance"
+	entmembership "github.com/logan/cloudcode/internal/ent/membership"
+	entorganization "github.com/logan/cloudcode/internal/ent/organization"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+var (
+	// ErrOrgNotFound indicates the organization does not exist or the caller
+	// is not a member.
+	ErrOrgNotFound = errors.New("organization not found")
+	// ErrMemberNotFound indicates the user is not a member of the organization.
+	ErrMemberNotFound = errors.New("member not found")
+	// ErrInvalidMembership wraps a description of what is wrong with a
+	// membership change.
+	ErrInvalidMembership = errors.New("invalid membership")
+	// ErrPermissionDenied indicates the caller can see the resource but their
+	// role does not allow the operation.
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// Roles in an organization, from most to least privileged.
+const (
+	RoleOwner  = "owner"  // everything, including deleting the organization
+	RoleAdmin  = "admin"  // members, billing, and managing every shared instance
+	RoleMember = "member" // shares own instances; uses shared ones per their team access
+	RoleViewer = "viewer" // read-only on shared instances
+)
+
+var roleRank = map[string]int{RoleViewer: 1, RoleMember: 2, RoleAdmin: 3, RoleOwner: 4}
+
+// planRank orders plans so a member can get the better of their own plan and
+// their organizations'.
+var planRank = map[string]int{"free": 0, "starter": 1, "pro": 2}
+
+// Access is what a user may do with an instance.
+type Access int
+
+const (
+	AccessNone   Access = iota
+	AccessRead          // view it; terminal and chat are read-only
+	AccessWrite         // use the terminal, chat and files
+	AccessManage        // lifecycle, backups and sharing
+)
+
+// instanceAccess returns what userID may do with the instance. Owners manage
+// their instances. On an instance shared with an organization, its owners and
+// admins can manage it, members get its team access, and viewers at most
+// read. userID 0 is the admin API key, which manages everything.
+func instanceAccess(ctx context.Context, db *ent.Client, instanceID, userID int) (Access, *ent.Instance, error) {
+	inst, err := db.Instance.Query().
+		Where(entinstance.IDEQ(instanceID)).
+		WithOwner().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return AccessNone, nil, provider.ErrNotFound
+		}
+		return AccessNone, nil, fmt.Errorf("get instance: %w", err)
+	}
+	if userID == 0 || inst.Edges.Owner.ID == userID {
+		return AccessManage, inst, nil
+	}
+	if inst.OrganizationID == nil {
+		return AccessNone, inst, nil
+	}
+
+	role, err := memberRole(ctx, db, *inst.OrganizationID, userID)
+	if err != nil || role == "" {
+		return AccessNone, inst, err
+	}
+	var access Access
+	switch {
+	case roleRank[role] >= roleRank[RoleAdmin]:
+		return AccessManage, inst, nil
+	case inst.TeamAccess == entinstance.TeamAccessWrite:
+		access = AccessWrite
+	case inst.TeamAccess == entinstance.TeamAccessRead:
+		access = AccessRead
+	}
+	if role == RoleViewer {
+		access = min(access, AccessRead)
+	}
+	return access, inst, nil
+}
+
+// authorizeInstance returns the instance if userID has at least need access
+// to it. Instances the user cannot see at all are reported as not found.
+func authorizeInstance(ctx context.Context, db *ent.Client, instanceID, userID int, need Access) (*ent.Instance, error) {
+	access, inst, err := instanceAccess(ctx, db, instanceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if access == AccessNone {
+		return nil, provider.ErrNotFound
+	}
+	if access < need {
+		return nil, ErrPermissionDenied
+	}
+	return inst, nil
+}
+
+// memberRole returns userID's role in the organization, or "" if they are
+// not a member.
+func memberRole(ctx context.Context, db *ent.Client, orgID, userID int) (string, error) {
+	m, err := db.Membership.Query().
+		Where(
+			entmembership.HasOrganizationWith(entorganization.IDEQ(orgID)),
+			entmembership.HasUserWith(entuser.IDEQ(userID)),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("query membership: %w", err)
+	}
+	return string(m.Role), nil
+}
+
+// effectivePlan returns the best of the user's own plan and the plans of the
+// organizations they belong to as a member or above.
+func effectivePlan(ctx context.Context, db *ent.Client, userID int) (string, error) {
+	plan, err := db.User.Query().
+		Where(entuser.IDEQ(userID)).
+		Select(entuser.FieldPlan).
+		String(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("query plan: %w", err)
+	}
+	orgPlans, err := db.Organization.Query().
+		Where(
+			entorganization.HasMembershipsWith(
+				entmembership.HasUserWith(entuser.IDEQ(userID)),
+				entmembership.RoleIn(entmembership.RoleOwner, entmembership.RoleAdmin, entmembership.RoleMember),
+			),
+			entorganization.SubscriptionStatusIn("active", "trialing"),
+		).
+		Select(entorganization.FieldPlan).
+		Strings(ctx)
+	if err != nil {
+		return "", fmt.Errorf("query organization plans: %w", err)
+	}
+	for _, p := range orgPlans {
+		if planRank[p] > planRank[plan] {
+			plan = p
+		}
+	}
+	return plan, nil
+}
+
+// OrgService manages organizations and their memberships.
+type OrgService struct {
+	db *ent.Client
+}
+
+// NewOrgService creates a new OrgService.
+func NewOrgService(db *ent.Client) *OrgService {
+	return &OrgService{db: db}
+}
+
+// OrgResponse is the API response for an organization, as seen by a member.
+type OrgResponse struct {
+	ID                 int    `json:"id"`
+	Name               string `json:"name"`
+	Plan               string `json:"plan"`
+	SubscriptionStatus string `json:"subscription_status"`
+	TerminalRecording  string `json:"terminal_recording"`
+	Role               string `json:"role"` // the caller's role
+}
+
+// MemberResponse is the API response for a membership.
+type MemberResponse struct {
+	UserID int    `json:"user_id"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+}
+
+func toOrgResponse(o *ent.Organization, role string) *OrgResponse {
+	return &OrgResponse{
+		ID:                 o.ID,
+		Name:               o.Name,
+		Plan:               o.Plan,
+		SubscriptionStatus: o.SubscriptionStatus,
+		TerminalRecording:  string(o.TerminalRecording),
+		Role:               role,
+	}
+}
+
+// Authorize returns the organization if userID is a member with at least
+// role minRole.
+func (s *OrgService) Authorize(ctx context.Context, orgID, userID int, minRole string) (*ent.Organization, error) {
+	role, err := memberRole(ctx, s.db, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" {
+		return nil, ErrOrgNotFound
+	}
+	if roleRank[role] < roleRank[minRole] {
+		return nil, ErrPermissionDenied
+	}
+	o, err := s.db.Organization.Get(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("get organization: %w", err)
+	}
+	return o, nil
+}
+
+// Create creates an organization owned by userID.
+func (s *OrgService) Create(ctx context.Context, userID int, name string) (*OrgResponse, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 64 {
+		return nil, fmt.Errorf("%w: name must be 1 to 64 characters", ErrInvalidMembership)
+	}
+
+	tx, err := s.db.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin: %w", err)
+	}
+	o, err := tx.Organization.Create().SetName(name).Save(ctx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("create organization: %w", err)
+	}
+	if err := tx.Membership.Create().
+		SetOrganization(o).
+		SetUserID(userID).
+		SetRole(entmembership.RoleOwner).
+		Exec(ctx); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("create membership: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return toOrgResponse(o, RoleOwner), nil
+}
+
+// List returns the organizations userID belongs to, oldest first.
+func (s *OrgService) List(ctx context.Context, userID int) ([]*OrgResponse, error) {
+	ms, err := s.db.Membership.Query().
+		Where(entmembership.HasUserWith(entuser.IDEQ(userID))).
+		WithOrganization().
+		Order(ent.Asc(entmembership.FieldCreatedAt), ent.Asc(entmembership.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list memberships: %w", err)
+	}
+	out := make([]*OrgResponse, len(ms))
+	for i, m := range ms {
+		out[i] = toOrgResponse(m.Edges.Organization, string(m.Role))
+	}
+	return out, nil
+}
+
+// Get returns the organization as seen by userID, who must be a member.
+func (s *OrgService) Get(ctx context.Context, orgID, userID int) (*OrgResponse, error) {
+	o, err := s.Authorize(ctx, orgID, userID, RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	role, err := memberRole(ctx, s.db, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toOrgResponse(o, role), nil
+}
+
+// SetTerminalRecording sets how terminals on instances shared with the
+// organization are recorded. Owners and admins may.
+func (s *OrgService) SetTerminalRecording(ctx context.Context, orgID, userID int, mode string) (*OrgResponse, error) {
+	if !ValidRecordingMode(mode) {
+		return nil, ErrInvalidRecordingMode
+	}
+	if _, err := s.Authorize(ctx, orgID, userID, RoleAdmin); err != nil {
+		return nil, err
+	}
+	o, err := s.db.Organization.UpdateOneID(orgID).
+		SetTerminalRecording(entorganization.TerminalRecording(mode)).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("update organization: %w", err)
+	}
+	role, err := memberRole(ctx, s.db, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toOrgResponse(o, role), nil
+}
+
+// Delete deletes the organization. Only owners may, and only once it has no
+// active subscription; its instances go back to being private.
+func (s *OrgService) Delete(ctx context.Context, orgID, userID int) error {
+	o, err := s.Authorize(ctx, orgID, userID, RoleOwner)
+	if err != nil {
+		return err
+	}
+	if o.StripeSubscriptionID != nil {
+		return fmt.Errorf("%w: cancel the subscription first", ErrInvalidMembership)
+	}
+
+	tx, err := s.db.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	if err := tx.Instance.Update().
+		Where(entinstance.OrganizationIDEQ(orgID)).
+		ClearOrganization().
+		SetTeamAccess(entinstance.TeamAccessNone).
+		Exec(ctx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("unshare instances: %w", err)
+	}
+	if _, err := tx.Membership.Delete().
+		Where(entmembership.HasOrganizationWith(entorganization.IDEQ(orgID))).
+		Exec(ctx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("delete memberships: %w", err)
+	}
+	if err := tx.Organization.DeleteOneID(orgID).Exec(ctx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("delete organization: %w", err)
+	}
+	return tx.Commit()
+}
+
+// Members lists the organization's members; any member may.
+func (s *OrgService) Members(ctx context.Context, orgID, userID int) ([]*MemberResponse, error) {
+	if _, err := s.Authorize(ctx, orgID, userID, RoleViewer); err != nil {
+		return nil, err
+	}
+	ms, err := s.db.Membership.Query().
+		Where(entmembership.HasOrganizationWith(entorganization.IDEQ(orgID))).
+		WithUser().
+		Order(ent.Asc(entmembership.FieldCreatedAt), ent.Asc(entmembership.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list members: %w", err)
+	}
+	out := make([]*MemberResponse, len(ms))
+	for i, m := range ms {
+		out[i] = &MemberResponse{
+			UserID: m.Edges.User.ID,
+			Email:  m.Edges.User.Email,
+			Name:   m.Edges.User.Name,
+			Role:   string(m.Role),
+		}
+	}
+	return out, nil
+}
+
+// AddMember adds the user with the given email, who must have signed in at
+// least once. Admins may add anyone but owners; owners may add owners.
+func (s *OrgService) AddMember(ctx context.Context, orgID, userID int, email, role string) (*MemberResponse, error) {
+	if err := s.checkGrant(ctx, orgID, userID, role); err != nil {
+		return nil, err
+	}
+	u, err := s.db.User.Query().Where(entuser.EmailEqualFold(strings.TrimSpace(email))).Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: no user with email %q; they need to sign in once first", ErrInvalidMembership, email)
+		}
+		return nil, fmt.Errorf("query user: %w", err)
+	}
+	err = s.db.Membership.Create().
+		SetOrganizationID(orgID).
+		SetUser(u).
+		SetRole(entmembership.Role(role)).
+		Exec(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, fmt.Errorf("%w: %s is already a member", ErrInvalidMembership, u.Email)
+		}
+		return nil, fmt.Errorf("create membership: %w", err)
+	}
+	return &MemberResponse{UserID: u.ID, Email: u.Email, Name: u.Name, Role: role}, nil
+}
+
+// UpdateMember changes a member's role. The same rules as AddMember apply to
+// both the old and the new role, and the last owner cannot be demoted.
+func (s *OrgService) UpdateMember(ctx context.Context, orgID, userID, memberID int, role string) error {
+	if err := s.checkGrant(ctx, orgID, userID, role); err != nil {
+		return err
+	}
+	m, err := s.membership(ctx, orgID, memberID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkGrant(ctx, orgID, userID, string(m.Role)); err != nil {
+		return err
+	}
+	if m.Role == entmembership.RoleOwner && role != RoleOwner {
+		if err := s.keepOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+	return s.db.Membership.UpdateOne(m).SetRole(entmembership.Role(role)).Exec(ctx)
+}
+
+// RemoveMember removes a member. Members may always remove themselves;
+// otherwise the rules of AddMember apply. The last owner cannot leave. The
+// member's instances stop being shared with the organization.
+func (s *OrgService) RemoveMember(ctx context.Context, orgID, userID, memberID int) error {
+	m, err := s.membership(ctx, orgID, memberID)
+	if err != nil {
+		if errors.Is(err, ErrMemberNotFound) && memberID == userID {
+			return ErrOrgNotFound
+		}
+		return err
+	}
+	if memberID != userID {
+		if err := s.checkGrant(ctx, orgID, userID, string(m.Role)); err != nil {
+			return err
+		}
+	}
+	if m.Role == entmembership.RoleOwner {
+		if err := s.keepOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	tx, err := s.db.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	if err := tx.Instance.Update().
+		Where(
+			entinstance.OrganizationIDEQ(orgID),
+			entinstance.HasOwnerWith(entuser.IDEQ(memberID)),
+		).
+		ClearOrganization().
+		SetTeamAccess(entinstance.TeamAccessNone).
+		Exec(ctx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("unshare instances: %w", err)
+	}
+	if err := tx.Membership.DeleteOneID(m.ID).Exec(ctx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("delete membership: %w", err)
+	}
+	return tx.Commit()
+}
+
+// Instances lists the organization's live shared instances the caller can
+// see: all of them for admins, those with team access for everyone else.
+func (s *OrgService) Instances(ctx context.Context, orgID, userID int) ([]*InstanceResponse, error) {
+	if _, err := s.Authorize(ctx, orgID, userID, RoleViewer); err != nil {
+		return nil, err
+	}
+	role, err := memberRole(ctx, s.db, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	q := s.db.Instance.Query().Where(
+		entinstance.OrganizationIDEQ(orgID),
+		entinstance.StatusIn(liveStatuses...),
+	)
+	if roleRank[role] < roleRank[RoleAdmin] {
+		q = q.Where(entinstance.Or(
+			entinstance.TeamAccessNEQ(entinstance.TeamAccessNone),
+			entinstance.HasOwnerWith(entuser.IDEQ(userID)),
+		))
+	}
+	rows, err := q.Order(ent.Asc(entinstance.FieldCreatedAt), ent.Asc(entinstance.FieldID)).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list instances: %w", err)
+	}
+	out := make([]*InstanceResponse, len(rows))
+	for i, inst := range rows {
+		out[i] = toResponse(inst)
+	}
+	return out, nil
+}
+
+// checkGrant checks that userID may give or take away role in the
+// organization: admins handle admins and below, owners handle everyone.
+func (s *OrgService) checkGrant(ctx context.Context, orgID, userID int, role string) error {
+	if _, ok := roleRank[role]; !ok {
+		return fmt.Errorf("%w: unknown role %q", ErrInvalidMembership, role)
+	}
+	minRole := RoleAdmin
+	if role == RoleOwner {
+		minRole = RoleOwner
+	}
+	_, err := s.Authorize(ctx, orgID, userID, minRole)
+	return err
+}
+
+func (s *OrgService) membership(ctx context.Context, orgID, memberID int) (*ent.Membership, error) {
+	m, err := s.db.Membership.Query().
+		Where(
+			entmembership.HasOrganizationWith(entorganization.IDEQ(orgID)),
+			entmembership.HasUserWith(entuser.IDEQ(memberID)),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("query membership: %w", err)
+	}
+	return m, nil
+}
+
+// keepOwner refuses to remove an owner when they are the only one.
+func (s *OrgService) keepOwner(ctx context.Context, orgID int) error {
+	owners, err := s.db.Membership.Query().
+		Where(
+			entmembership.HasOrganizationWith(entorganization.IDEQ(orgID)),
+			entmembership.RoleEQ(entmembership.RoleOwner),
+		).
+		Count(ctx)
+	if err != nil {
+		return fmt.Errorf("count owners: %w", err)
+	}
+	if owners <= 1 {
+		return fmt.Errorf("%w: an organization needs at least one owner", ErrInvalidMembership)
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/logan/cloudcode/internal/ent"
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+// setupOrgTest creates an organization owned by the test user with an admin,
+// a member and a viewer, and returns their user IDs in that order.
+func setupOrgTest(t *testing.T, dsn string) (*OrgService, *InstanceService, *ent.Client, int, []int) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { client.Close() })
+	ctx := context.Background()
+
+	orgs := NewOrgService(client)
+	owner := createTestUser(t, client)
+	org, err := orgs.Create(ctx, owner, "Acme")
+	if err != nil {
+		t.Fatalf("create org: %v", err)
+	}
+	users := []int{owner}
+	for _, m := range []struct{ email, role string }{
+		{"admin@example.com", RoleAdmin},
+		{"member@example.com", RoleMember},
+		{"viewer@example.com", RoleViewer},
+	} {
+		client.User.Create().SetEmail(m.email).SaveX(ctx)
+		added, err := orgs.AddMember(ctx, org.ID, owner, m.email, m.role)
+		if err != nil {
+			t.Fatalf("add %s: %v", m.role, err)
+		}
+		users = append(users, added.UserID)
+	}
+	return orgs, NewInstanceService(client, provider.NewMock(), ""), client, org.ID, users
+}
+
+func TestOrgService_Roles(t *testing.T) {
+	orgs, _, client, orgID, users := setupOrgTest(t, "file:ent_org_roles?mode=memory&_fk=1")
+	ctx := context.Background()
+	owner, admin, member, viewer := users[0], users[1], users[2], users[3]
+	client.User.Create().SetEmail("new@example.com").SaveX(ctx)
+
+	// Admins manage admins and below; only owners grant ownership
+	if _, err := orgs.AddMember(ctx, orgID, admin, "new@example.com", RoleOwner); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("admin adds owner: %v", err)
+	}
+	if _, err := orgs.AddMember(ctx, orgID, member, "new@example.com", RoleViewer); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("member adds viewer: %v", err)
+	}
+	if _, err := orgs.AddMember(ctx, orgID, admin, "nobody@example.com", RoleMember); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("unknown email: %v", err)
+	}
+	if _, err := orgs.AddMember(ctx, orgID, admin, "member@example.com", RoleMember); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("duplicate member: %v", err)
+	}
+	if err := orgs.UpdateMember(ctx, orgID, admin, viewer, RoleMember); err != nil {
+		t.Errorf("admin promotes viewer: %v", err)
+	}
+	if err := orgs.UpdateMember(ctx, orgID, admin, owner, RoleMember); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("admin demotes owner: %v", err)
+	}
+
+	// Outsiders cannot see the organization
+	outsider := client.User.Create().SetEmail("out@example.com").SaveX(ctx).ID
+	if _, err := orgs.Get(ctx, orgID, outsider); !errors.Is(err, ErrOrgNotFound) {
+		t.Errorf("outsider get: %v", err)
+	}
+
+	// The last owner can neither leave nor be demoted
+	if err := orgs.RemoveMember(ctx, orgID, owner, owner); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("last owner leaves: %v", err)
+	}
+	if err := orgs.UpdateMember(ctx, orgID, owner, owner, RoleAdmin); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("last owner demoted: %v", err)
+	}
+	if err := orgs.UpdateMember(ctx, orgID, owner, admin, RoleOwner); err != nil {
+		t.Fatalf("promote admin: %v", err)
+	}
+	if err := orgs.RemoveMember(ctx, orgID, owner, owner); err != nil {
+		t.Errorf("owner leaves with a co-owner: %v", err)
+	}
+
+	// Anyone may leave
+	if err := orgs.RemoveMember(ctx, orgID, member, member); err != nil {
+		t.Errorf("member leaves: %v", err)
+	}
+	list, _ := orgs.List(ctx, member)
+	if len(list) != 0 {
+		t.Errorf("member still lists %d orgs", len(list))
+	}
+	if err := orgs.Delete(ctx, orgID, viewer); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("viewer deletes org: %v", err)
+	}
+	if err := orgs.Delete(ctx, orgID, admin); err != nil {
+		t.Errorf("owner deletes org: %v", err)
+	}
+}
+
+func TestInstanceAccess_Sharing(t *testing.T) {
+	orgs, svc, client, orgID, users := setupOrgTest(t, "file:ent_org_share?mode=memory&_fk=1")
+	ctx := context.Background()
+	owner, admin, member, viewer := users[0], users[1], users[2], users[3]
+
+	inst, err := svc.Create(ctx, member, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	access := func(userID int) Access {
+		a, _, err := instanceAccess(ctx, client, inst.ID, userID)
+		if err != nil {
+			t.Fatalf("access: %v", err)
+		}
+		return a
+	}
+
+	// Private instances are invisible to teammates
+	if err := svc.Authorize(ctx, inst.ID, owner, AccessRead); !errors.Is(err, provider.ErrNotFound) {
+		t.Fatalf("private instance: %v", err)
+	}
+	if _, err := svc.Share(ctx, inst.ID, owner, &orgID, "write"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("non-owner shares: %v", err)
+	}
+	outsider := client.User.Create().SetEmail("out@example.com").SaveX(ctx).ID
+	otherOrg, _ := orgs.Create(ctx, outsider, "Other")
+	if _, err := svc.Share(ctx, inst.ID, member, &otherOrg.ID, "read"); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("share with foreign org: %v", err)
+	}
+	if _, err := svc.Share(ctx, inst.ID, member, &orgID, "admin"); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("bad team access: %v", err)
+	}
+
+	shared, err := svc.Share(ctx, inst.ID, member, &orgID, "write")
+	if err != nil {
+		t.Fatalf("share: %v", err)
+	}
+	if shared.OrganizationID == nil || *shared.OrganizationID != orgID || shared.TeamAccess != "write" {
+		t.Errorf("shared = %+v", shared)
+	}
+	for _, tc := range []struct {
+		name   string
+		userID int
+		want   Access
+	}{
+		{"owner of the org", owner, AccessManage},
+		{"admin", admin, AccessManage},
+		{"instance owner", member, AccessManage},
+		{"viewer", viewer, AccessRead},
+		{"outsider", outsider, AccessNone},
+	} {
+		if got := access(tc.userID); got != tc.want {
+			t.Errorf("%s: access = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+
+	// Read-only sharing; the proxy learns the access level from GetInstanceHost
+	third := client.User.Create().SetEmail("third@example.com").SaveX(ctx).ID
+	if _, err := orgs.AddMember(ctx, orgID, admin, "third@example.com", RoleMember); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Share(ctx, inst.ID, admin, &orgID, "read"); err != nil {
+		t.Fatalf("admin reshares: %v", err)
+	}
+	if _, _, a, err := svc.GetInstanceHost(ctx, inst.ID, third); err != nil || a != AccessRead {
+		t.Errorf("host for teammate = %d, %v", a, err)
+	}
+	if err := svc.Authorize(ctx, inst.ID, third, AccessWrite); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("write on read-only share: %v", err)
+	}
+
+	// Leaving the organization takes the owner's instances with them
+	if err := orgs.RemoveMember(ctx, orgID, member, member); err != nil {
+		t.Fatal(err)
+	}
+	if got := access(third); got != AccessNone {
+		t.Errorf("after owner left: access = %d", got)
+	}
+}
+
+func TestEffectivePlan(t *testing.T) {
+	_, _, client, orgID, users := setupOrgTest(t, "file:ent_org_plan?mode=memory&_fk=1")
+	ctx := context.Background()
+	member, viewer := users[2], users[3]
+
+	client.Organization.UpdateOneID(orgID).SetPlan("pro").SetSubscriptionStatus("past_due").ExecX(ctx)
+	if plan, _ := effectivePlan(ctx, client, member); plan != "free" {
+		t.Errorf("past due org: plan = %q", plan)
+	}
+	client.Organization.UpdateOneID(orgID).SetSubscriptionStatus("active").ExecX(ctx)
+	if plan, _ := effectivePlan(ctx, client, member); plan != "pro" {
+		t.Errorf("member of pro org: plan = %q", plan)
+	}
+	if plan, _ := effectivePlan(ctx, client, viewer); plan != "free" {
+		t.Errorf("viewer of pro org: plan = %q", plan)
+	}
+}
+
+func TestConversationService_SharedInstance(t *testing.T) {
+	_, svc, client, orgID, users := setupOrgTest(t, "file:ent_org_conv?mode=memory&_fk=1")
+	ctx := context.Background()
+	owner, member, viewer := users[0], users[2], users[3]
+	convs := NewConversationService(client)
+
+	inst, err := svc.Create(ctx, owner, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := convs.GetOrCreateByProject(ctx, member, inst.ID, "/work/app"); !errors.Is(err, ErrConversationNotFound) {
+		t.Fatalf("private instance: %v", err)
+	}
+	conv, err := convs.GetOrCreateByProject(ctx, owner, inst.ID, "/work/app")
+	if err != nil {
+		t.Fatalf("owner conversation: %v", err)
+	}
+	if _, err := svc.Share(ctx, inst.ID, owner, &orgID, "write"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Teammates join the owner's conversation
+	got, err := convs.GetOrCreateByProject(ctx, member, inst.ID, "/work/app")
+	if err != nil || got.ID != conv.ID {
+		t.Fatalf("member conversation = %+v, %v; want %d", got, err, conv.ID)
+	}
+	if _, err := convs.AddMessage(ctx, conv.ID, member, "user", "hi", nil); err != nil {
+		t.Errorf("member adds message: %v", err)
+	}
+	if _, err := convs.AddMessage(ctx, conv.ID, viewer, "user", "hi", nil); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("viewer adds message: %v", err)
+	}
+	if msgs, err := convs.GetMessages(ctx, conv.ID, viewer); err != nil || len(msgs) != 1 {
+		t.Errorf("viewer reads = %d, %v", len(msgs), err)
+	}
+	if err := convs.DeleteConversation(ctx, conv.ID, member); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("member deletes: %v", err)
+	}
+
+	// Viewers cannot start conversations
+	if _, err := convs.GetOrCreateByProject(ctx, viewer, inst.ID, "/work/other"); !errors.Is(err, ErrConversationNotFound) {
+		t.Errorf("viewer creates: %v", err)
+	}
+	// The same project on the owner's other instance, or off any instance,
+	// is a conversation of its own that teammates cannot reach
+	svc.SetPlanLimits(map[string]int{"free": 2})
+	other, err := svc.Create(ctx, owner, InstanceSpec{Name: "other"})
+	if err != nil {
+		t.Fatalf("create other: %v", err)
+	}
+	otherConv, err := convs.GetOrCreateByProject(ctx, owner, other.ID, "/work/app")
+	if err != nil || otherConv.ID == conv.ID {
+		t.Fatalf("other instance conversation = %+v, %v; want a new one", otherConv, err)
+	}
+	general, err := convs.GetOrCreateByProject(ctx, owner, 0, "/work/app")
+	if err != nil || general.ID == conv.ID || general.ID == otherConv.ID {
+		t.Fatalf("general conversation = %+v, %v; want a new one", general, err)
+	}
+	if _, err := convs.GetMessages(ctx, otherConv.ID, member); !errors.Is(err, ErrConversationNotFound) {
+		t.Errorf("member reads other instance: %v", err)
+	}
+	if got, err := convs.GetOrCreateByProject(ctx, member, inst.ID, "/work/app"); err != nil || got.ID != conv.ID {
+		t.Errorf("member conversation after owner moved = %+v, %v; want %d", got, err, conv.ID)
+	}
+}
+
+func TestConversationService_AdoptsConversationWithoutInstance(t *testing.T) {
+	_, svc, client, _, users := setupOrgTest(t, "file:ent_org_conv_adopt?mode=memory&_fk=1")
+	ctx := context.Background()
+	owner := users[0]
+	convs := NewConversationService(client)
+
+	// Kept from before conversations were held on instances
+	legacy, err := convs.GetOrCreateByProject(ctx, owner, 0, "/work/app")
+	if err != nil {
+		t.Fatalf("legacy conversation: %v", err)
+	}
+	if _, err := client.Conversation.Create().SetOwnerID(owner).SetProjectPath("/work/app").Save(ctx); !ent.IsConstraintError(err) {
+		t.Errorf("second conversation without instance: %v, want constraint error", err)
+	}
+
+	svc.SetPlanLimits(map[string]int{"free": 2})
+	inst, err := svc.Create(ctx, owner, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if got, err := convs.GetOrCreateByProject(ctx, owner, inst.ID, "/work/app"); err != nil || got.ID != legacy.ID {
+		t.Fatalf("instance conversation = %+v, %v; want %d", got, err, legacy.ID)
+	}
+	// Only the first instance takes it over
+	other, err := svc.Create(ctx, owner, InstanceSpec{Name: "other"})
+	if err != nil {
+		t.Fatalf("create other: %v", err)
+	}
+	if got, err := convs.GetOrCreateByProject(ctx, owner, other.ID, "/work/app"); err != nil || got.ID == legacy.ID {
+		t.Errorf("other instance conversation = %+v, %v; want a new one", got, err)
+	}
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/logan/cloudcode/internal/ent"
+	entinstance "github.com/logan/cloudcode/internal/ent/instance"
+	entjob "github.com/logan/cloudcode/internal/ent/job"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+var reconcileTracer = otel.Tracer("cloudcode/service/reconcile")
+var reconcileMeter = otel.Meter("cloudcode/service/reconcile")
+
+// ReconcileService periodically compares the instance table with what the
+// provider actually runs. It corrects status drift, marks rows whose resources
+// have vanished as destroyed, and reports (optionally removes) orphaned
+// resources that have no matching row.
+type ReconcileService struct {
+	db           *ent.Client
+	inventory    provider.Inventory
+	providerName string
+	logger       *slog.Logger
+	interval     time.Duration
+	dryRun       bool
+	gcOrphans    bool
+	stopCh       chan struct{}
+
+	runs           metric.Int64Counter
+	drift          metric.Int64Counter
+	orphans        metric.Int64Counter
+	orphansRemoved metric.Int64Counter
+}
+
+// NewReconcileService creates a new ReconcileService. providerName must match
+// the Instance.provider value the inventory's rows were created with.
+// In dry-run mode nothing is written; gcOrphans enables deletion of orphans.
+func NewReconcileService(
+	db *ent.Client,
+	inv provider.Inventory,
+	providerName string,
+	logger *slog.Logger,
+	interval time.Duration,
+	dryRun bool,
+	gcOrphans bool,
+) *ReconcileService {
+	s := &ReconcileService{
+		db:           db,
+		inventory:    inv,
+		providerName: providerName,
+		logger:       logger,
+		interval:     interval,
+		dryRun:       dryRun,
+		gcOrphans:    gcOrphans,
+		stopCh:       make(chan struct{}),
+	}
+	s.runs, _ = reconcileMeter.Int64Counter("cloudcode.reconcile.runs",
+		metric.WithDescription("Reconciliation passes, by result"))
+	s.drift, _ = reconcileMeter.Int64Counter("cloudcode.reconcile.drift",
+		metric.WithDescription("Instance rows found out of sync with the provider, by kind"))
+	s.orphans, _ = reconcileMeter.Int64Counter("cloudcode.reconcile.orphans",
+		metric.WithDescription("Provider resources with no matching instance row, by kind"))
+	s.orphansRemoved, _ = reconcileMeter.Int64Counter("cloudcode.reconcile.orphans_removed",
+		metric.WithDescription("Orphaned provider resources garbage-collected, by kind"))
+	return s
+}
+
+// StatusFix records a status correction applied (or proposed) to an instance row.
+type StatusFix struct {
+	InstanceID int    `json:"instance_id"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
+
+// ReconcileReport summarizes a single reconciliation pass.
+type ReconcileReport struct {
+	DryRun          bool                       `json:"dry_run"`
+	StatusFixes     []StatusFix                `json:"status_fixes"`
+	MarkedDestroyed []int                      `json:"marked_destroyed"`
+	Orphans         []provider.ManagedResource `json:"orphans"`
+	OrphansRemoved  int                        `json:"orphans_removed"`
+	Skipped         int                        `json:"skipped"` // rows with jobs in flight
+}
+
+// Start begins the reconciliation loop in a goroutine.
+func (s *ReconcileService) Start() {
+	go s.run()
+	s.logger.Info("reconcile service started", "interval", s.interval, "dry_run", s.dryRun, "gc_orphans", s.gcOrphans)
+}
+
+// Stop signals the reconciliation loop to stop.
+func (s *ReconcileService) Stop() {
+	close(s.stopCh)
+	s.logger.Info("reconcile service stopped")
+}
+
+func (s *ReconcileService) run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			if _, err := s.Reconcile(ctx); err != nil {
+				s.logger.Error("reconciliation failed", "error", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// Reconcile performs one pass and returns what it found and did.
+func (s *ReconcileService) Reconcile(ctx context.Context) (*ReconcileReport, error) {
+	ctx, span := reconcileTracer.Start(ctx, "reconcile.run")
+	defer span.End()
+
+	report, err := s.reconcile(ctx)
+	result := "ok"
+	if err != nil {
+		result = "error"
+		span.RecordError(err)
+	}
+	s.runs.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("result", result),
+		attribute.Bool("dry_run", s.dryRun),
+	))
+	return report, err
+}
+
+func (s *ReconcileService) reconcile(ctx context.Context) (*ReconcileReport, error) {
+	report := &ReconcileReport{DryRun: s.dryRun}
+	start := time.Now()
+
+	// The database is read before the provider, so every row here had its
+	// resources created by the time they are listed
+	rows, err := s.db.Instance.Query().
+		Where(entinstance.ProviderEQ(s.providerName)).
+		WithOwner().
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query instances: %w", err)
+	}
+
+	// Rows and users with lifecycle jobs in flight are mid-transition; leave them alone
+	inflight, err := s.db.Job.Query().
+		Where(entjob.StatusIn(JobPending, JobRunning)).
+		WithOwner().
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query jobs: %w", err)
+	}
+	resources, err := s.inventory.ListManaged(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list provider resources: %w", err)
+	}
+	// Resources of rows and jobs that appeared meanwhile are not orphans
+	fresh, err := s.db.Instance.Query().
+		Where(entinstance.ProviderEQ(s.providerName), entinstance.UpdatedAtGTE(start)).
+		WithOwner().
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query instances: %w", err)
+	}
+	freshJobs, err := s.db.Job.Query().
+		Where(entjob.CreatedAtGTE(start)).
+		WithOwner().
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query jobs: %w", err)
+	}
+
+	busyInstances := make(map[int]bool)
+	busyUsers := make(map[int]bool)
+	for _, j := range inflight {
+		if j.InstanceID != nil {
+			busyInstances[*j.InstanceID] = true
+		}
+		if j.Type == JobCreate && j.Edges.Owner != nil {
+			busyUsers[j.Edges.Owner.ID] = true
+		}
+	}
+	for _, row := range fresh {
+		if row.Edges.Owner != nil {
+			busyUsers[row.Edges.Owner.ID] = true
+		}
+	}
+	for _, j := range freshJobs {
+		if j.Edges.Owner != nil {
+			busyUsers[j.Edges.Owner.ID] = true
+		}
+	}
+
+	// Index provider instances by provider ID, falling back to owner and
+	// instance name when the provider can't report one
+	byProviderID := make(map[string]int)
+	byKey := make(map[string]int)
+	storedKeys := make(map[string]bool)
+	for i, res := range resources {
+		if res.Kind != provider.ResourceInstance {
+			if res.Name != "" {
+				storedKeys[provider.InstanceKey(res.UserID, res.Name)] = true
+			}
+			continue
+		}
+		if res.ProviderID != "" {
+			byProviderID[res.ProviderID] = i
+		} else {
+			byKey[provider.InstanceKey(res.UserID, res.Name)] = i
+		}
+	}
+
+	matched := make(map[int]bool)
+	liveKeys := make(map[string]bool)
+	liveUsers := make(map[int]bool)
+	for _, row := range rows {
+		ownerID := 0
+		if row.Edges.Owner != nil {
+			ownerID = row.Edges.Owner.ID
+		}
+
+		if row.Status == "destroyed" {
+			continue
+		}
+		liveKeys[provider.InstanceKey(ownerID, row.Name)] = true
+		liveUsers[ownerID] = true
+		if busyInstances[row.ID] {
+			report.Skipped++
+			continue
+		}
+
+		idx, ok := byProviderID[row.ProviderID]
+		if !ok {
+			idx, ok = byKey[provider.InstanceKey(ownerID, row.Name)]
+		}
+		if !ok && row.Status == "stopped" && storedKeys[provider.InstanceKey(ownerID, row.Name)] {
+			// A paused instance may be nothing but its volume or snapshot
+			continue
+		}
+		if !ok {
+			report.MarkedDestroyed = append(report.MarkedDestroyed, row.ID)
+			s.drift.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", "missing")))
+			s.logger.Warn("instance resources gone, marking destroyed",
+				"instance_id", row.ID, "provider_id", row.ProviderID, "dry_run", s.dryRun)
+			if !s.dryRun {
+				if err := row.Update().SetStatus("destroyed").Exec(ctx); err != nil {
+					return report, fmt.Errorf("mark instance %d destroyed: %w", row.ID, err)
+				}
+			}
+			continue
+		}
+		matched[idx] = true
+
+		res := resources[idx]
+		if res.Status == "" || string(res.Status) == row.Status {
+			continue
+		}
+		fix := StatusFix{InstanceID: row.ID, From: row.Status, To: string(res.Status)}
+		report.StatusFixes = append(report.StatusFixes, fix)
+		s.drift.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", "status")))
+		s.logger.Warn("instance status drift",
+			"instance_id", row.ID, "from", fix.From, "to", fix.To, "dry_run", s.dryRun)
+		if !s.dryRun {
+			if err := row.Update().SetStatus(fix.To).Exec(ctx); err != nil {
+				return report, fmt.Errorf("fix instance %d status: %w", row.ID, err)
+			}
+		}
+	}
+
+	for i, res := range resources {
+		if busyUsers[res.UserID] {
+			continue
+		}
+		switch res.Kind {
+		case provider.ResourceInstance:
+			// Nothing to remove when the provider only knows the owner
+			if matched[i] || res.ProviderID == "" {
+				continue
+			}
+		default:
+			// Volumes and snapshots belong to the instance of their name
+			// until it is destroyed. Without a name, only to the user
+			if res.Name != "" && liveKeys[provider.InstanceKey(res.UserID, res.Name)] {
+				continue
+			}
+			if res.Name == "" && liveUsers[res.UserID] {
+				continue
+			}
+		}
+		report.Orphans = append(report.Orphans, res)
+	}
+
+	for _, res := range report.Orphans {
+		kind := attribute.String("kind", string(res.Kind))
+		s.orphans.Add(ctx, 1, metric.WithAttributes(kind))
+		s.logger.Warn("orphaned provider resource",
+			"kind", res.Kind, "id", res.ID, "user_id", res.UserID, "gc", s.gcOrphans && !s.dryRun)
+
+		if !s.gcOrphans || s.dryRun {
+			continue
+		}
+		if err := s.inventory.RemoveManaged(ctx, res); err != nil {
+			s.logger.Error("failed to remove orphan", "kind", res.Kind, "id", res.ID, "error", err)
+			continue
+		}
+		report.OrphansRemoved++
+		s.orphansRemoved.Add(ctx, 1, metric.WithAttributes(kind))
+	}
+
+	return report, nil
+}
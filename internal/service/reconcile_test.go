@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/logan/cloudcode/internal/ent"
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+func setupReconcileTest(t *testing.T, dryRun, gc bool) (*ReconcileService, *InstanceService, *ent.Client, *provider.MockProvisioner) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent_reconcile?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	mock := provider.NewMock()
+	instSvc := NewInstanceService(client, mock, "")
+	rec := NewReconcileService(client, mock, "mock", slog.Default(), time.Minute, dryRun, gc)
+	return rec, instSvc, client, mock
+}
+
+func TestReconcile_FixesStatusDrift(t *testing.T) {
+	rec, instSvc, client, mock := setupReconcileTest(t, false, false)
+	ctx := context.Background()
+
+	userID := createTestUser(t, client)
+	inst, err := instSvc.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	mock.SetStatus(inst.ProviderID, provider.StatusStopped)
+
+	report, err := rec.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if len(report.StatusFixes) != 1 || report.StatusFixes[0].To != "stopped" {
+		t.Fatalf("status fixes = %+v, want one fix to stopped", report.StatusFixes)
+	}
+
+	row, _ := client.Instance.Get(ctx, inst.ID)
+	if row.Status != "stopped" {
+		t.Errorf("status = %q, want stopped", row.Status)
+	}
+}
+
+func TestReconcile_MarksMissingDestroyed(t *testing.T) {
+	rec, instSvc, client, mock := setupReconcileTest(t, false, false)
+	ctx := context.Background()
+
+	userID := createTestUser(t, client)
+	inst, _ := instSvc.Create(ctx, userID, InstanceSpec{})
+
+	// Container removed behind our back
+	if err := mock.Destroy(ctx, inst.ProviderID); err != nil {
+		t.Fatalf("destroy: %v", err)
+	}
+
+	report, err := rec.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if len(report.MarkedDestroyed) != 1 || report.MarkedDestroyed[0] != inst.ID {
+		t.Fatalf("marked destroyed = %v, want [%d]", report.MarkedDestroyed, inst.ID)
+	}
+
+	row, _ := client.Instance.Get(ctx, inst.ID)
+	if row.Status != "destroyed" {
+		t.Errorf("status = %q, want destroyed", row.Status)
+	}
+	// The row was live when the pass began, so its volume is not an orphan yet
+	if len(report.Orphans) != 0 {
+		t.Errorf("orphans = %+v, want none", report.Orphans)
+	}
+}
+
+func TestReconcile_DryRunChangesNothing(t *testing.T) {
+	rec, instSvc, client, mock := setupReconcileTest(t, true, true)
+	ctx := context.Background()
+
+	userID := createTestUser(t, client)
+	inst, _ := instSvc.Create(ctx, userID, InstanceSpec{})
+	mock.SetStatus(inst.ProviderID, provider.StatusStopped)
+	mock.Create(ctx, userID+100, provider.CreateOptions{}) // orphan
+
+	report, err := rec.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if !report.DryRun || len(report.StatusFixes) != 1 {
+		t.Fatalf("report = %+v, want dry run with one status fix", report)
+	}
+	if report.OrphansRemoved != 0 {
+		t.Errorf("orphans removed = %d, want 0 in dry run", report.OrphansRemoved)
+	}
+
+	row, _ := client.Instance.Get(ctx, inst.ID)
+	if row.Status != "running" {
+		t.Errorf("status = %q, want unchanged running", row.Status)
+	}
+	if _, err := mock.Status(ctx, "mock-101"); err != nil {
+		t.Errorf("orphan was removed in dry run: %v", err)
+	}
+}
+
+func TestReconcile_GarbageCollectsOrphans(t *testing.T) {
+	rec, _, client, mock := setupReconcileTest(t, false, true)
+	ctx := context.Background()
+
+	userID := createTestUser(t, client)
+	orphan, _ := mock.Create(ctx, userID, provider.CreateOptions{})
+
+	report, err := rec.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	// Container and its volume
+	if len(report.Orphans) != 2 || report.OrphansRemoved != 2 {
+		t.Fatalf("orphans = %+v removed = %d, want 2 found and removed", report.Orphans, report.OrphansRemoved)
+	}
+	if _, err := mock.Status(ctx, orphan.ID); err == nil {
+		t.Error("orphan container still present")
+	}
+}
+
+func TestReconcile_SkipsInstancesWithJobsInFlight(t *testing.T) {
+	rec, instSvc, client, mock := setupReconcileTest(t, false, false)
+	ctx := context.Background()
+
+	userID := createTestUser(t, client)
+	inst, _ := instSvc.Create(ctx, userID, InstanceSpec{})
+	mock.SetStatus(inst.ProviderID, provider.StatusStopped)
+
+	if _, err := client.Job.Create().SetType(JobPause).SetInstanceID(inst.ID).SetOwnerID(userID).Save(ctx); err != nil {
+		t.Fatalf("create job: %v", err)
+	}
+
+	report, err := rec.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if report.Skipped != 1 || len(report.StatusFixes) != 0 {
+		t.Fatalf("report = %+v, want one skipped and no fixes", report)
+	}
+}
+
+func TestReconcile_KeepsPausedInstances(t *testing.T) {
+	rec, instSvc, client, mock := setupReconcileTest(t, false, false)
+	ctx := context.Background()
+
+	userID := createTestUser(t, client)
+	inst, _ := instSvc.Create(ctx, userID, InstanceSpec{})
+	client.Instance.UpdateOneID(inst.ID).SetStatus("stopped").ExecX(ctx)
+
+	// Paused down to its volume, as providers without a stopped state do
+	if err := mock.Destroy(ctx, inst.ProviderID); err != nil {
+		t.Fatalf("destroy: %v", err)
+	}
+
+	report, err := rec.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if len(report.MarkedDestroyed) != 0 || len(report.Orphans) != 0 {
+		t.Fatalf("report = %+v, want nothing destroyed or orphaned", report)
+	}
+}
+
+func TestReconcile_CollectsStorageByInstance(t *testing.T) {
+	rec, instSvc, client, mock := setupReconcileTest(t, false, false)
+	ctx := context.Background()
+
+	instSvc.SetPlanLimits(map[string]int{"free": 2})
+	userID := createTestUser(t, client)
+	if _, err := instSvc.Create(ctx, userID, InstanceSpec{}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	gone, err := instSvc.Create(ctx, userID, InstanceSpec{Name: "scratch"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := mock.Destroy(ctx, gone.ProviderID); err != nil {
+		t.Fatalf("destroy: %v", err)
+	}
+	client.Instance.UpdateOneID(gone.ID).SetStatus("destroyed").ExecX(ctx)
+
+	// The user's other instance does not keep the destroyed one's volume
+	report, err := rec.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if len(report.Orphans) != 1 || report.Orphans[0].Name != "scratch" {
+		t.Fatalf("orphans = %+v, want the scratch volume", report.Orphans)
+	}
+}
+
+// listHook runs a function each time the provider's resources are listed.
+type listHook struct {
+	*provider.MockProvisioner
+	fn func()
+}
+
+func (l listHook) ListManaged(ctx context.Context) ([]provider.ManagedResource, error) {
+	l.fn()
+	return l.MockProvisioner.ListManaged(ctx)
+}
+
+func TestReconcile_IgnoresInstancesCreatedDuringPass(t *testing.T) {
+	_, instSvc, client, mock := setupReconcileTest(t, false, true)
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	// The row lands after the pass has read the table but before it lists
+	// the provider
+	hook := listHook{mock, func() {
+		if _, err := instSvc.Create(ctx, userID, InstanceSpec{}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}}
+	rec := NewReconcileService(client, hook, "mock", slog.Default(), time.Minute, false, true)
+
+	report, err := rec.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if len(report.Orphans) != 0 {
+		t.Fatalf("orphans = %+v, want none", report.Orphans)
+	}
+}
@@ -0,0 +1,439 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/logan/cloudcode/internal/asciicast"
+	"github.com/logan/cloudcode/internal/backup"
+	"github.com/logan/cloudcode/internal/ent"
+	entinstance "github.com/logan/cloudcode/internal/ent/instance"
+	entrecording "github.com/logan/cloudcode/internal/ent/recording"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+// Terminal recording modes, set per user and per organization.
+const (
+	RecordingOff    = "off"
+	RecordingOutput = "output" // what the terminal showed
+	RecordingFull   = "full"   // output and keystrokes
+)
+
+var recordingRank = map[string]int{RecordingOff: 0, RecordingOutput: 1, RecordingFull: 2}
+
+var (
+	// ErrRecordingNotFound indicates the requested recording does not exist
+	// or does not belong to the given instance.
+	ErrRecordingNotFound = errors.New("recording not found")
+	// ErrInvalidRecordingMode indicates a recording mode other than off,
+	// output or full.
+	ErrInvalidRecordingMode = errors.New("invalid recording mode")
+)
+
+// ValidRecordingMode reports whether mode is a terminal recording mode.
+func ValidRecordingMode(mode string) bool {
+	_, ok := recordingRank[mode]
+	return ok
+}
+
+// recordingSweepInterval is how often expired recordings are deleted.
+const recordingSweepInterval = time.Hour
+
+// RecordingService records terminal sessions as asciicast v2 files in an
+// object store. Whether a session is recorded is up to the instance owner
+// and the organization the instance is shared with; the stricter setting
+// wins. Recordings expire after their owner's plan retention.
+type RecordingService struct {
+	db        *ent.Client
+	store     backup.Store
+	logger    *slog.Logger
+	retention map[string]time.Duration // plan → how long recordings are kept
+	audit     *AuditService
+	stopCh    chan struct{}
+}
+
+// NewRecordingService creates a new RecordingService. Recordings of owners
+// whose plan is missing from retention are kept until deleted.
+func NewRecordingService(db *ent.Client, store backup.Store, logger *slog.Logger, retention map[string]time.Duration) *RecordingService {
+	return &RecordingService{
+		db:        db,
+		store:     store,
+		logger:    logger,
+		retention: retention,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// SetAuditService enables audit events for recordings.
+func (s *RecordingService) SetAuditService(audit *AuditService) {
+	s.audit = audit
+}
+
+// ParseRecordingRetention parses a comma-separated list of plan=duration
+// pairs, e.g. "free=168h,pro=2160h".
+func ParseRecordingRetention(s string) (map[string]time.Duration, error) {
+	return parsePlanDurations(s, "retention")
+}
+
+// RecordingResponse is the API response for a recording.
+type RecordingResponse struct {
+	ID         int        `json:"id"`
+	InstanceID int        `json:"instance_id"`
+	UserID     int        `json:"user_id"`
+	Input      bool       `json:"input"`
+	Width      int        `json:"width"`
+	Height     int        `json:"height"`
+	Duration   float64    `json:"duration"` // seconds
+	SizeBytes  int64      `json:"size_bytes"`
+	StartedAt  time.Time  `json:"started_at"`
+	EndedAt    time.Time  `json:"ended_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+func toRecordingResponse(r *ent.Recording) *RecordingResponse {
+	return &RecordingResponse{
+		ID:         r.ID,
+		InstanceID: r.InstanceID,
+		UserID:     r.UserID,
+		Input:      r.Input,
+		Width:      r.Width,
+		Height:     r.Height,
+		Duration:   r.Duration,
+		SizeBytes:  r.SizeBytes,
+		StartedAt:  r.StartedAt,
+		EndedAt:    r.CreatedAt,
+		ExpiresAt:  r.ExpiresAt,
+	}
+}
+
+// Mode returns how terminal sessions on the instance are recorded: the
+// stricter of its owner's setting and that of the organization it is
+// shared with.
+func (s *RecordingService) Mode(ctx context.Context, instanceID int) (string, error) {
+	inst, err := s.db.Instance.Query().
+		Where(entinstance.IDEQ(instanceID)).
+		WithOwner().
+		WithOrganization().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return "", provider.ErrNotFound
+		}
+		return "", fmt.Errorf("get instance: %w", err)
+	}
+	mode := string(inst.Edges.Owner.TerminalRecording)
+	if org := inst.Edges.Organization; org != nil && recordingRank[string(org.TerminalRecording)] > recordingRank[mode] {
+		mode = string(org.TerminalRecording)
+	}
+	return mode, nil
+}
+
+// Begin starts recording a terminal session userID opened on the instance.
+// It returns nil if the instance is not recorded. The caller feeds the
+// session to the Recorder and must Close it.
+func (s *RecordingService) Begin(ctx context.Context, instanceID, userID int) (*Recorder, error) {
+	mode, err := s.Mode(ctx, instanceID)
+	if err != nil || mode == RecordingOff {
+		return nil, err
+	}
+	f, err := os.CreateTemp("", "recording-*.cast")
+	if err != nil {
+		return nil, fmt.Errorf("create recording file: %w", err)
+	}
+	start := time.Now()
+	return &Recorder{
+		svc:        s,
+		instanceID: instanceID,
+		userID:     userID,
+		input:      mode == RecordingFull,
+		start:      start,
+		width:      80,
+		height:     24,
+		file:       f,
+		cast:       asciicast.NewWriter(f, start),
+	}, nil
+}
+
+// List returns the recordings of an instance, newest first. If userID is
+// non-zero, that user must be able to manage the instance.
+func (s *RecordingService) List(ctx context.Context, instanceID, userID int) ([]*RecordingResponse, error) {
+	if _, err := authorizeInstance(ctx, s.db, instanceID, userID, AccessManage); err != nil {
+		return nil, err
+	}
+
+	recordings, err := s.db.Recording.Query().
+		Where(entrecording.InstanceIDEQ(instanceID)).
+		Order(ent.Desc(entrecording.FieldStartedAt), ent.Desc(entrecording.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list recordings: %w", err)
+	}
+
+	out := make([]*RecordingResponse, len(recordings))
+	for i, r := range recordings {
+		out[i] = toRecordingResponse(r)
+	}
+	return out, nil
+}
+
+// Open returns a recording of the instance and its asciicast file, which
+// the caller must close. If userID is non-zero, that user must be able to
+// manage the instance. Every viewing is audited.
+func (s *RecordingService) Open(ctx context.Context, instanceID, recordingID, userID int) (*RecordingResponse, io.ReadCloser, error) {
+	if _, err := authorizeInstance(ctx, s.db, instanceID, userID, AccessManage); err != nil {
+		return nil, nil, err
+	}
+	rec, err := s.db.Recording.Query().
+		Where(entrecording.IDEQ(recordingID), entrecording.InstanceIDEQ(instanceID)).
+		WithOwner().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil, ErrRecordingNotFound
+		}
+		return nil, nil, fmt.Errorf("get recording: %w", err)
+	}
+	rc, err := s.store.Get(ctx, rec.Key)
+	if err != nil {
+		if errors.Is(err, backup.ErrNotFound) {
+			return nil, nil, ErrRecordingNotFound
+		}
+		return nil, nil, fmt.Errorf("open recording: %w", err)
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Action:     "recording.view",
+		TargetType: "recording",
+		TargetID:   strconv.Itoa(rec.ID),
+		SubjectID:  rec.Edges.Owner.ID,
+		Details:    map[string]any{"instance_id": instanceID},
+	})
+	return toRecordingResponse(rec), rc, nil
+}
+
+// save uploads a finished session and records it.
+func (s *RecordingService) save(ctx context.Context, r *Recorder) (*ent.Recording, error) {
+	inst, err := s.db.Instance.Query().
+		Where(entinstance.IDEQ(r.instanceID)).
+		WithOwner().
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get instance: %w", err)
+	}
+	owner := inst.Edges.Owner
+
+	// The header goes first but the size is only final at the end, so the
+	// events were spooled to a file
+	if _, err := r.file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("rewind recording: %w", err)
+	}
+	var head bytes.Buffer
+	hw := asciicast.NewWriter(&head, r.start)
+	err = hw.WriteHeader(asciicast.Header{
+		Width:     r.width,
+		Height:    r.height,
+		Timestamp: r.start.Unix(),
+		Duration:  r.cast.Duration(),
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	})
+	if err == nil {
+		err = hw.Flush()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("write recording header: %w", err)
+	}
+	key := fmt.Sprintf("recordings/users/%d/instances/%d/%s.cast",
+		owner.ID, inst.ID, r.start.UTC().Format("20060102T150405.000000000Z"))
+	size, err := s.store.Put(ctx, key, io.MultiReader(&head, r.file))
+	if err != nil {
+		return nil, fmt.Errorf("upload recording: %w", err)
+	}
+
+	create := s.db.Recording.Create().
+		SetInstanceID(inst.ID).
+		SetUserID(r.userID).
+		SetKey(key).
+		SetInput(r.input).
+		SetWidth(r.width).
+		SetHeight(r.height).
+		SetDuration(r.cast.Duration()).
+		SetSizeBytes(size).
+		SetStartedAt(r.start).
+		SetOwner(owner)
+	plan, err := effectivePlan(ctx, s.db, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+	if keep, ok := s.retention[plan]; ok {
+		create.SetExpiresAt(time.Now().Add(keep))
+	}
+	rec, err := create.Save(ctx)
+	if err != nil {
+		if derr := s.store.Delete(ctx, key); derr != nil {
+			s.logger.Warn("failed to delete orphaned recording", "key", key, "error", derr)
+		}
+		return nil, fmt.Errorf("save recording: %w", err)
+	}
+
+	s.audit.Log(ctx, AuditEntry{
+		Actor:      ActorUser,
+		ActorID:    r.userID,
+		Action:     "instance.recording",
+		TargetType: "recording",
+		TargetID:   strconv.Itoa(rec.ID),
+		SubjectID:  owner.ID,
+		Details: map[string]any{
+			"instance_id": inst.ID,
+			"input":       r.input,
+			"duration":    rec.Duration,
+			"size_bytes":  size,
+		},
+	})
+	return rec, nil
+}
+
+// Prune deletes recordings past their expiry and returns how many.
+func (s *RecordingService) Prune(ctx context.Context) (int, error) {
+	expired, err := s.db.Recording.Query().
+		Where(entrecording.ExpiresAtLT(time.Now())).
+		All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("query expired recordings: %w", err)
+	}
+	for i, r := range expired {
+		if err := s.store.Delete(ctx, r.Key); err != nil {
+			return i, fmt.Errorf("delete recording file %d: %w", r.ID, err)
+		}
+		if err := s.db.Recording.DeleteOne(r).Exec(ctx); err != nil {
+			return i, fmt.Errorf("delete recording %d: %w", r.ID, err)
+		}
+	}
+	return len(expired), nil
+}
+
+// Start begins the retention loop in a goroutine.
+func (s *RecordingService) Start() {
+	go s.run()
+	s.logger.Info("recording service started", "plans", len(s.retention))
+}
+
+// Stop signals the retention loop to stop.
+func (s *RecordingService) Stop() {
+	close(s.stopCh)
+	s.logger.Info("recording service stopped")
+}
+
+func (s *RecordingService) run() {
+	ticker := time.NewTicker(recordingSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			if n, err := s.Prune(ctx); err != nil {
+				s.logger.Error("recording retention failed", "error", err)
+			} else if n > 0 {
+				s.logger.Info("deleted expired recordings", "count", n)
+			}
+			cancel()
+		}
+	}
+}
+
+// Recorder captures one terminal session. Its methods are safe to call
+// from the two directions of a proxied connection at once.
+type Recorder struct {
+	svc        *RecordingService
+	instanceID int
+	userID     int
+	input      bool
+	start      time.Time
+
+	mu     sync.Mutex
+	width  int
+	height int
+	events bool // whether any event has been written
+	file   *os.File
+	cast   *asciicast.Writer
+	err    error // first write error; later events are dropped
+}
+
+// Input reports whether keystrokes are recorded.
+func (r *Recorder) Input() bool {
+	return r.input
+}
+
+// Output records data the terminal showed.
+func (r *Recorder) Output(data []byte) {
+	r.write(asciicast.Output, data)
+}
+
+// Keys records data typed into the terminal, if input is recorded.
+func (r *Recorder) Keys(data []byte) {
+	if r.input {
+		r.write(asciicast.Input, data)
+	}
+}
+
+// Resize records a new terminal size. A size set before anything was
+// shown becomes the recording's initial size.
+func (r *Recorder) Resize(cols, rows int) {
+	if cols < 1 || rows < 1 {
+		return
+	}
+	r.mu.Lock()
+	if !r.events {
+		r.width, r.height = cols, rows
+		r.mu.Unlock()
+		return
+	}
+	r.mu.Unlock()
+	r.write(asciicast.Resize, []byte(fmt.Sprintf("%dx%d", cols, rows)))
+}
+
+func (r *Recorder) write(typ string, data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.events = true
+	r.err = r.cast.Write(time.Now(), typ, data)
+}
+
+// Close ends the session and stores the recording. Sessions in which
+// nothing was shown are discarded. Events after Close are dropped.
+func (r *Recorder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defer os.Remove(r.file.Name())
+	defer r.file.Close()
+
+	err := r.err
+	if err == nil {
+		err = r.cast.Flush()
+	}
+	r.err = os.ErrClosed
+	if err != nil {
+		return fmt.Errorf("write recording: %w", err)
+	}
+	if !r.events {
+		return nil
+	}
+	rec, err := r.svc.save(ctx, r)
+	if err != nil {
+		return err
+	}
+	r.svc.logger.Info("terminal session recorded", "recording_id", rec.ID, "instance_id", r.instanceID, "size_bytes", rec.SizeBytes)
+	return nil
+}
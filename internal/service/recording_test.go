@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/asciicast"
+	"github.com/logan/cloudcode/internal/backup"
+	entauditevent "github.com/logan/cloudcode/internal/ent/auditevent"
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	entorganization "github.com/logan/cloudcode/internal/ent/organization"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+func TestRecordingService_Mode(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_recording_mode?mode=memory&_fk=1")
+	defer client.Close()
+	svc := NewRecordingService(client, nil, slog.Default(), nil)
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+	inst := client.Instance.Create().
+		SetProvider("mock").
+		SetProviderID("mock-1").
+		SetOwnerID(userID).
+		SaveX(ctx)
+
+	if mode, err := svc.Mode(ctx, inst.ID); err != nil || mode != RecordingOff {
+		t.Errorf("default mode = %q, %v", mode, err)
+	}
+	if rec, err := svc.Begin(ctx, inst.ID, userID); rec != nil || err != nil {
+		t.Errorf("begin with recording off = %v, %v", rec, err)
+	}
+
+	// The organization's setting applies when it is stricter
+	client.User.UpdateOneID(userID).SetTerminalRecording(entuser.TerminalRecordingOutput).ExecX(ctx)
+	org := client.Organization.Create().SetName("acme").SetTerminalRecording(entorganization.TerminalRecordingFull).SaveX(ctx)
+	if mode, _ := svc.Mode(ctx, inst.ID); mode != RecordingOutput {
+		t.Errorf("unshared mode = %q", mode)
+	}
+	client.Instance.UpdateOneID(inst.ID).SetOrganizationID(org.ID).ExecX(ctx)
+	if mode, _ := svc.Mode(ctx, inst.ID); mode != RecordingFull {
+		t.Errorf("shared mode = %q", mode)
+	}
+	client.Organization.UpdateOneID(org.ID).SetTerminalRecording(entorganization.TerminalRecordingOff).ExecX(ctx)
+	if mode, _ := svc.Mode(ctx, inst.ID); mode != RecordingOutput {
+		t.Errorf("mode with organization off = %q", mode)
+	}
+
+	if _, err := svc.Mode(ctx, 9999); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("unknown instance: %v", err)
+	}
+}
+
+func TestRecordingService_RecordAndReplay(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_recording_flow?mode=memory&_fk=1")
+	defer client.Close()
+	store, err := backup.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	audit := NewAuditService(client)
+	svc := NewRecordingService(client, store, slog.Default(), map[string]time.Duration{"free": time.Hour})
+	svc.SetAuditService(audit)
+	ctx := context.Background()
+
+	userID := createTestUser(t, client)
+	client.User.UpdateOneID(userID).SetTerminalRecording(entuser.TerminalRecordingFull).ExecX(ctx)
+	inst := client.Instance.Create().
+		SetProvider("mock").
+		SetProviderID("mock-1").
+		SetOwnerID(userID).
+		SaveX(ctx)
+
+	rec, err := svc.Begin(ctx, inst.ID, userID)
+	if err != nil || rec == nil || !rec.Input() {
+		t.Fatalf("begin = %v, %v", rec, err)
+	}
+	rec.Resize(100, 30)
+	rec.Output([]byte("$ "))
+	rec.Keys([]byte("ls\r"))
+	rec.Resize(120, 40)
+	rec.Output([]byte("file.txt\r\n"))
+	if err := rec.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	rec.Output([]byte("after close"))
+
+	list, err := svc.List(ctx, inst.ID, userID)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("list = %+v, %v", list, err)
+	}
+	got := list[0]
+	if got.Width != 100 || got.Height != 30 || !got.Input || got.UserID != userID || got.ExpiresAt == nil {
+		t.Errorf("recording = %+v", got)
+	}
+
+	_, rc, err := svc.Open(ctx, inst.ID, got.ID, userID)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	cast, err := asciicast.NewReader(rc)
+	if err != nil {
+		t.Fatalf("read header: %v", err)
+	}
+	if cast.Header.Width != 100 || cast.Header.Height != 30 {
+		t.Errorf("header = %+v", cast.Header)
+	}
+	var events []string
+	for {
+		e, err := cast.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("next: %v", err)
+		}
+		events = append(events, e.Type+":"+e.Data)
+	}
+	rc.Close()
+	if want := "o:$ |i:ls\r|r:120x40|o:file.txt\r\n"; strings.Join(events, "|") != want {
+		t.Errorf("events = %q", events)
+	}
+
+	other := client.User.Create().SetEmail("other@example.com").SaveX(ctx)
+	if _, _, err := svc.Open(ctx, inst.ID, got.ID, other.ID); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("stranger opened recording: %v", err)
+	}
+	if _, _, err := svc.Open(ctx, inst.ID, 9999, userID); !errors.Is(err, ErrRecordingNotFound) {
+		t.Errorf("unknown recording: %v", err)
+	}
+	for _, action := range []string{"instance.recording", "recording.view"} {
+		if n := client.AuditEvent.Query().Where(entauditevent.ActionEQ(action)).CountX(ctx); n != 1 {
+			t.Errorf("%d %s events", n, action)
+		}
+	}
+
+	// Sessions that showed nothing are not kept
+	empty, _ := svc.Begin(ctx, inst.ID, userID)
+	if err := empty.Close(ctx); err != nil {
+		t.Fatalf("close empty: %v", err)
+	}
+	if n := client.Recording.Query().CountX(ctx); n != 1 {
+		t.Errorf("%d recordings after an empty session", n)
+	}
+
+	// Expired recordings are deleted with their files
+	if n, err := svc.Prune(ctx); err != nil || n != 0 {
+		t.Errorf("prune before expiry = %d, %v", n, err)
+	}
+	r := client.Recording.GetX(ctx, got.ID)
+	client.Recording.UpdateOne(r).SetExpiresAt(time.Now().Add(-time.Minute)).ExecX(ctx)
+	if n, err := svc.Prune(ctx); err != nil || n != 1 {
+		t.Errorf("prune after expiry = %d, %v", n, err)
+	}
+	if _, err := store.Get(ctx, r.Key); !errors.Is(err, backup.ErrNotFound) {
+		t.Errorf("file after prune: %v", err)
+	}
+}
+
+func TestParseRecordingRetention(t *testing.T) {
+	retention, err := ParseRecordingRetention("free=168h, pro=2160h")
+	if err != nil || retention["free"] != 168*time.Hour || retention["pro"] != 2160*time.Hour {
+		t.Errorf("retention = %v, %v", retention, err)
+	}
+	for _, bad := range []string{"free", "free=7d", "pro=-1h"} {
+		if _, err := ParseRecordingRetention(bad); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+}
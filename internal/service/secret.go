@@ -0,0 +1,401 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelTrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/logan/cloudcode/internal/ent"
+	entinstance "github.com/logan/cloudcode/internal/ent/instance"
+	entsecret "github.com/logan/cloudcode/internal/ent/secret"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/vault"
+)
+
+var secretTracer = otel.Tracer("cloudcode/service/secret")
+
+// Secret kinds.
+const (
+	SecretEnv  = "env"
+	SecretFile = "file"
+)
+
+// maxSecretSize bounds a secret value; SSH keys and token files fit easily.
+const maxSecretSize = 64 << 10
+
+// shellEnv lists variables a secret would break shells by overriding.
+var shellEnv = []string{"HOME", "PATH", "SHELL", "USER", "PWD"}
+
+var (
+	// ErrSecretNotFound indicates the user has no secret by that name.
+	ErrSecretNotFound = errors.New("secret not found")
+	// ErrInvalidSecret wraps a description of what is wrong with a secret.
+	ErrInvalidSecret = errors.New("invalid secret")
+)
+
+// SecretService stores per-user secrets encrypted at rest and pushes them
+// to the user's running instances through the instance agent. Pushing
+// replaces the instance's whole secret set, so updates and deletes reach
+// running instances without re-creating them.
+type SecretService struct {
+	db      *ent.Client
+	keyring *vault.Keyring
+	logger  *slog.Logger
+	http    *http.Client
+	audit   *AuditService // nil records nothing
+
+	// agentURL maps an instance host to the agent's base URL.
+	agentURL func(host string) string
+	// readyTimeout bounds how long Inject waits for a booting agent.
+	readyTimeout time.Duration
+	readyPoll    time.Duration
+}
+
+// NewSecretService creates a new SecretService.
+func NewSecretService(db *ent.Client, keyring *vault.Keyring, logger *slog.Logger) *SecretService {
+	return &SecretService{
+		db:           db,
+		keyring:      keyring,
+		logger:       logger,
+		http:         &http.Client{Timeout: 15 * time.Second},
+		agentURL:     func(host string) string { return "http://" + host + ":3001" },
+		readyTimeout: 2 * time.Minute,
+		readyPoll:    2 * time.Second,
+	}
+}
+
+// SetAuditService wires in the audit log for secret changes.
+func (s *SecretService) SetAuditService(a *AuditService) {
+	s.audit = a
+}
+
+// SecretSpec is the value and placement of a secret.
+type SecretSpec struct {
+	Kind  string `json:"kind"`  // env (default) or file
+	Path  string `json:"path"`  // file secrets: relative to /home/claude
+	Value string `json:"value"` // never returned by the API
+}
+
+// SecretResponse is the API response for a secret. It never includes the value.
+type SecretResponse struct {
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind"`
+	Path      string    `json:"path,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func toSecretResponse(s *ent.Secret) *SecretResponse {
+	return &SecretResponse{
+		Name:      s.Name,
+		Kind:      s.Kind,
+		Path:      s.Path,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+// List returns the user's secrets by name, without values.
+func (s *SecretService) List(ctx context.Context, userID int) ([]*SecretResponse, error) {
+	rows, err := s.db.Secret.Query().
+		Where(entsecret.HasOwnerWith(entuser.IDEQ(userID))).
+		Order(ent.Asc(entsecret.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list secrets: %w", err)
+	}
+	out := make([]*SecretResponse, len(rows))
+	for i, row := range rows {
+		out[i] = toSecretResponse(row)
+	}
+	return out, nil
+}
+
+// Put creates or replaces the user's secret called name and pushes the new
+// set to their running instances. Push failures are logged, not returned;
+// the next Sync, create or wake delivers the secret.
+func (s *SecretService) Put(ctx context.Context, userID int, name string, spec SecretSpec) (*SecretResponse, error) {
+	if spec.Kind == "" {
+		spec.Kind = SecretEnv
+	}
+	if err := validateSecret(name, &spec); err != nil {
+		return nil, err
+	}
+	env, err := s.keyring.Seal([]byte(spec.Value))
+	if err != nil {
+		return nil, fmt.Errorf("seal secret: %w", err)
+	}
+
+	existing, err := s.db.Secret.Query().
+		Where(entsecret.NameEQ(name), entsecret.HasOwnerWith(entuser.IDEQ(userID))).
+		Only(ctx)
+	var row *ent.Secret
+	switch {
+	case err == nil:
+		row, err = existing.Update().
+			SetKind(spec.Kind).
+			SetPath(spec.Path).
+			SetKeyID(env.KeyID).
+			SetWrappedKey(env.WrappedKey).
+			SetCiphertext(env.Ciphertext).
+			Save(ctx)
+	case ent.IsNotFound(err):
+		row, err = s.db.Secret.Create().
+			SetName(name).
+			SetKind(spec.Kind).
+			SetPath(spec.Path).
+			SetKeyID(env.KeyID).
+			SetWrappedKey(env.WrappedKey).
+			SetCiphertext(env.Ciphertext).
+			SetOwnerID(userID).
+			Save(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("save secret: %w", err)
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Action:     "secret.put",
+		TargetType: "secret",
+		TargetID:   name,
+		SubjectID:  userID,
+		Details:    map[string]any{"kind": spec.Kind},
+	})
+
+	if err := s.Sync(ctx, userID); err != nil {
+		s.logger.Warn("secret saved but not pushed to every instance", "user_id", userID, "error", err)
+	}
+	return toSecretResponse(row), nil
+}
+
+// Delete removes the user's secret called name and pushes the remaining set
+// to their running instances, which removes it there too.
+func (s *SecretService) Delete(ctx context.Context, userID int, name string) error {
+	n, err := s.db.Secret.Delete().
+		Where(entsecret.NameEQ(name), entsecret.HasOwnerWith(entuser.IDEQ(userID))).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("delete secret: %w", err)
+	}
+	if n == 0 {
+		return ErrSecretNotFound
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Action:     "secret.delete",
+		TargetType: "secret",
+		TargetID:   name,
+		SubjectID:  userID,
+	})
+
+	if err := s.Sync(ctx, userID); err != nil {
+		s.logger.Warn("secret deleted but not removed from every instance", "user_id", userID, "error", err)
+	}
+	return nil
+}
+
+// validateSecret checks name and spec. File paths are cleaned in place.
+func validateSecret(name string, spec *SecretSpec) error {
+	if !envKeyPattern.MatchString(name) {
+		return fmt.Errorf("%w: name must be a valid environment variable name", ErrInvalidSecret)
+	}
+	if slices.Contains(reservedEnv, name) || slices.Contains(shellEnv, name) || strings.HasPrefix(name, "CLOUDCODE_") {
+		return fmt.Errorf("%w: %s is set by the platform", ErrInvalidSecret, name)
+	}
+	if len(spec.Value) > maxSecretSize {
+		return fmt.Errorf("%w: value exceeds %d bytes", ErrInvalidSecret, maxSecretSize)
+	}
+
+	switch spec.Kind {
+	case SecretEnv:
+		if spec.Path != "" {
+			return fmt.Errorf("%w: path is only valid for file secrets", ErrInvalidSecret)
+		}
+	case SecretFile:
+		p := path.Clean(strings.TrimPrefix(spec.Path, "~/"))
+		if spec.Path == "" || path.IsAbs(p) || p == "." || p == ".." || strings.HasPrefix(p, "../") ||
+			p == ".cloudcode" || strings.HasPrefix(p, ".cloudcode/") {
+			return fmt.Errorf("%w: path must be a file under the home directory", ErrInvalidSecret)
+		}
+		spec.Path = p
+	default:
+		return fmt.Errorf("%w: kind must be %q or %q", ErrInvalidSecret, SecretEnv, SecretFile)
+	}
+	return nil
+}
+
+// agentSecrets is the body of the agent's PUT /secrets. It replaces
+// everything a previous push installed.
+type agentSecrets struct {
+	Env   map[string]string `json:"env"`
+	Files []agentFile       `json:"files"`
+}
+
+type agentFile struct {
+	Path    string `json:"path"`
+	Content []byte `json:"content"` // base64 in JSON
+}
+
+// bundle decrypts the user's secrets into an agent payload.
+func (s *SecretService) bundle(ctx context.Context, userID int) (*agentSecrets, error) {
+	rows, err := s.db.Secret.Query().
+		Where(entsecret.HasOwnerWith(entuser.IDEQ(userID))).
+		Order(ent.Asc(entsecret.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query secrets: %w", err)
+	}
+
+	b := &agentSecrets{Env: make(map[string]string), Files: []agentFile{}}
+	for _, row := range rows {
+		value, err := s.keyring.Open(&vault.Envelope{KeyID: row.KeyID, WrappedKey: row.WrappedKey, Ciphertext: row.Ciphertext})
+		if err != nil {
+			return nil, fmt.Errorf("open secret %s: %w", row.Name, err)
+		}
+		if row.Kind == SecretFile {
+			b.Files = append(b.Files, agentFile{Path: row.Path, Content: value})
+		} else {
+			b.Env[row.Name] = string(value)
+		}
+	}
+	return b, nil
+}
+
+// Sync pushes the user's secrets to each of their running instances and
+// returns the failures joined.
+func (s *SecretService) Sync(ctx context.Context, userID int) error {
+	ctx, span := secretTracer.Start(ctx, "secret.sync",
+		otelTrace.WithAttributes(attribute.Int("user_id", userID)))
+	defer span.End()
+
+	instances, err := s.db.Instance.Query().
+		Where(
+			entinstance.HasOwnerWith(entuser.IDEQ(userID)),
+			entinstance.StatusEQ("running"),
+		).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("query instances: %w", err)
+	}
+	if len(instances) == 0 {
+		return nil
+	}
+	b, err := s.bundle(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, inst := range instances {
+		if err := s.push(ctx, inst, b); err != nil {
+			errs = append(errs, fmt.Errorf("instance %d: %w", inst.ID, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// Inject delivers the owner's secrets to an instance that has just been
+// created or woken, waiting for its agent to come up. Instances of users
+// without secrets are left alone.
+func (s *SecretService) Inject(ctx context.Context, inst *ent.Instance) error {
+	ownerID, err := inst.QueryOwner().OnlyID(ctx)
+	if err != nil {
+		return fmt.Errorf("query owner: %w", err)
+	}
+	b, err := s.bundle(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	if len(b.Env) == 0 && len(b.Files) == 0 {
+		return nil
+	}
+
+	ReportProgress(ctx, "injecting_secrets")
+	ctx, cancel := context.WithTimeout(ctx, s.readyTimeout)
+	defer cancel()
+	for {
+		err := s.push(ctx, inst, b)
+		if err == nil || !errors.Is(err, errAgentUnavailable) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("agent not ready: %w", err)
+		case <-time.After(s.readyPoll):
+		}
+	}
+}
+
+// errAgentUnavailable marks push failures worth retrying while an instance boots.
+var errAgentUnavailable = errors.New("agent unavailable")
+
+// push replaces the secrets installed on inst.
+func (s *SecretService) push(ctx context.Context, inst *ent.Instance, b *agentSecrets) error {
+	body, err := json.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("marshal secrets: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.agentURL(inst.Host)+"/secrets", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+inst.AgentSecret)
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errAgentUnavailable, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 500:
+		return fmt.Errorf("%w: HTTP %d", errAgentUnavailable, resp.StatusCode)
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("agent rejected secrets: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Rewrap rewraps every data key not under the keyring's primary key, so
+// the old master key can be retired. Returns how many secrets changed.
+func (s *SecretService) Rewrap(ctx context.Context) (int, error) {
+	rows, err := s.db.Secret.Query().
+		Where(entsecret.KeyIDNEQ(s.keyring.PrimaryID())).
+		All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("query secrets: %w", err)
+	}
+
+	n := 0
+	for _, row := range rows {
+		env, _, err := s.keyring.Rewrap(&vault.Envelope{KeyID: row.KeyID, WrappedKey: row.WrappedKey, Ciphertext: row.Ciphertext})
+		if err != nil {
+			return n, fmt.Errorf("rewrap secret %d: %w", row.ID, err)
+		}
+		// Guard on key_id so a concurrent Put is not overwritten
+		updated, err := s.db.Secret.Update().
+			Where(entsecret.IDEQ(row.ID), entsecret.KeyIDEQ(row.KeyID)).
+			SetKeyID(env.KeyID).
+			SetWrappedKey(env.WrappedKey).
+			Save(ctx)
+		if err != nil {
+			return n, fmt.Errorf("save secret %d: %w", row.ID, err)
+		}
+		n += updated
+	}
+	return n, nil
+}
@@ -0,0 +1,424 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/logan/cloudcode/internal/auth"
+	"github.com/logan/cloudcode/internal/ent"
+	"github.com/logan/cloudcode/internal/ent/predicate"
+	entsession "github.com/logan/cloudcode/internal/ent/session"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+)
+
+const (
+	// AccessTokenTTL is how long a session JWT is valid. Revoking a session
+	// takes effect at once for requests checked against it; this bounds
+	// how long a copied token is useful anywhere else.
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL is how long a session lasts without being used. Each
+	// refresh starts it again.
+	RefreshTokenTTL = 30 * 24 * time.Hour
+	// refreshRaceWindow is how long after a rotation the replaced refresh
+	// token is taken to be a concurrent refresh, such as a second browser
+	// tab, rather than a stolen copy.
+	refreshRaceWindow = 30 * time.Second
+
+	// impersonationTTL is how long a support session started from the admin
+	// API lasts. It cannot be refreshed.
+	impersonationTTL = time.Hour
+	// impersonationAgent is the user agent support sessions are listed
+	// under, so the user can see and revoke them.
+	impersonationAgent = "Support access (admin impersonation)"
+
+	// RefreshCookie carries the browser's refresh token. It is only sent to
+	// the auth routes.
+	RefreshCookie = "refresh_token"
+)
+
+var (
+	// ErrSessionNotFound indicates the session does not exist, has ended or
+	// belongs to someone else.
+	ErrSessionNotFound = errors.New("session not found")
+	// ErrRefreshInvalid indicates the refresh token is unknown, expired,
+	// revoked or has already been used.
+	ErrRefreshInvalid = errors.New("invalid refresh token")
+	// ErrRefreshRaced indicates the refresh token was just replaced by a
+	// concurrent refresh, whose tokens the caller should use instead.
+	ErrRefreshRaced = errors.New("refresh token already rotated by a concurrent refresh")
+)
+
+// SessionTokens are the credentials for a session.
+type SessionTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int // seconds until the access token expires
+}
+
+// SessionResponse is the API response for a signed-in device.
+type SessionResponse struct {
+	ID         int       `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"` // the session making the request
+}
+
+type clientInfoKey struct{}
+
+type clientInfo struct {
+	ip, userAgent string
+}
+
+// WithClientInfo attaches the caller's IP address and user agent to ctx, to
+// be recorded on sessions started or refreshed with it.
+func WithClientInfo(ctx context.Context, ip, userAgent string) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, clientInfo{ip: ip, userAgent: userAgent})
+}
+
+func clientInfoFrom(ctx context.Context) clientInfo {
+	c, _ := ctx.Value(clientInfoKey{}).(clientInfo)
+	if len(c.userAgent) > 512 {
+		c.userAgent = c.userAgent[:512]
+	}
+	return c
+}
+
+// startSession creates a session for u and returns its first tokens.
+func (s *AuthService) startSession(ctx context.Context, u *ent.User) (*SessionTokens, error) {
+	// Sessions past their expiry are dead either way
+	if _, err := s.db.Session.Delete().
+		Where(entsession.ExpiresAtLT(time.Now())).
+		Exec(ctx); err != nil {
+		return nil, fmt.Errorf("prune sessions: %w", err)
+	}
+
+	sid, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	client := clientInfoFrom(ctx)
+	sess, err := s.db.Session.Create().
+		SetSid(sid).
+		SetRefreshTokenHash(auth.HashAccessToken(secret)).
+		SetUserAgent(client.userAgent).
+		SetIP(client.ip).
+		SetExpiresAt(time.Now().Add(RefreshTokenTTL)).
+		SetUser(u).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create session: %w", err)
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Actor:      ActorUser,
+		ActorID:    u.ID,
+		Action:     "auth.sign_in",
+		TargetType: "session",
+		TargetID:   strconv.Itoa(sess.ID),
+	})
+	return s.sessionTokens(u, sid, secret)
+}
+
+func (s *AuthService) sessionTokens(u *ent.User, sid, secret string) (*SessionTokens, error) {
+	token, err := s.keys.Sign(auth.NewClaims(u.ID, u.Email, "session", sid, AccessTokenTTL))
+	if err != nil {
+		return nil, fmt.Errorf("generate session: %w", err)
+	}
+	return &SessionTokens{
+		AccessToken:  token,
+		RefreshToken: sid + "." + secret,
+		ExpiresIn:    int(AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// issueSession starts a session for u and sets its cookies.
+func (s *AuthService) issueSession(ctx context.Context, w http.ResponseWriter, u *ent.User) (*SessionTokens, error) {
+	tokens, err := s.startSession(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	setSessionCookies(w, tokens)
+	return tokens, nil
+}
+
+// setSessionCookies sets the access token cookie for every route and the
+// refresh token cookie for the auth routes. Both are HttpOnly.
+func setSessionCookies(w http.ResponseWriter, tokens *SessionTokens) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "session",
+		Value:    tokens.AccessToken,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   tokens.ExpiresIn,
+	})
+	http.SetCookie(w, &http.Cookie{
+		Name:     RefreshCookie,
+		Value:    tokens.RefreshToken,
+		Path:     "/auth/",
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   int(RefreshTokenTTL.Seconds()),
+	})
+}
+
+// ClearSessionCookies removes the session cookies from the browser.
+func ClearSessionCookies(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{Name: "session", Path: "/", MaxAge: -1})
+	http.SetCookie(w, &http.Cookie{Name: RefreshCookie, Path: "/auth/", MaxAge: -1})
+}
+
+// RefreshSession exchanges a refresh token for new tokens, rotating the
+// refresh token, and sets the session cookies if w is non-nil. Presenting a
+// refresh token that has already been replaced means two parties hold it,
+// so the session is revoked, unless it was replaced moments ago by a
+// concurrent refresh, which gives ErrRefreshRaced.
+func (s *AuthService) RefreshSession(ctx context.Context, w http.ResponseWriter, refreshToken string) (*SessionTokens, error) {
+	sid, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || sid == "" || secret == "" {
+		return nil, ErrRefreshInvalid
+	}
+	sess, err := s.db.Session.Query().
+		Where(entsession.SidEQ(sid)).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrRefreshInvalid
+		}
+		return nil, fmt.Errorf("query session: %w", err)
+	}
+	now := time.Now()
+	if sess.RevokedAt != nil || now.After(sess.ExpiresAt) {
+		return nil, ErrRefreshInvalid
+	}
+
+	hash := auth.HashAccessToken(secret)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(sess.RefreshTokenHash)) != 1 {
+		raced := sess.PreviousRefreshTokenHash != "" && sess.RotatedAt != nil &&
+			now.Sub(*sess.RotatedAt) < refreshRaceWindow &&
+			subtle.ConstantTimeCompare([]byte(hash), []byte(sess.PreviousRefreshTokenHash)) == 1
+		if raced {
+			return nil, ErrRefreshRaced
+		}
+		slog.Warn("refresh token reuse, revoking session", "user_id", sess.Edges.User.ID, "session_id", sess.ID)
+		if err := s.db.Session.UpdateOne(sess).SetRevokedAt(now).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("revoke session: %w", err)
+		}
+		return nil, ErrRefreshInvalid
+	}
+
+	next, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	client := clientInfoFrom(ctx)
+	update := s.db.Session.Update().
+		// Only one of two concurrent refreshes may rotate
+		Where(entsession.IDEQ(sess.ID), entsession.RefreshTokenHashEQ(hash)).
+		SetRefreshTokenHash(auth.HashAccessToken(next)).
+		SetPreviousRefreshTokenHash(hash).
+		SetRotatedAt(now).
+		SetLastUsedAt(now).
+		SetExpiresAt(now.Add(RefreshTokenTTL))
+	if client.ip != "" {
+		update = update.SetIP(client.ip).SetUserAgent(client.userAgent)
+	}
+	n, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("rotate refresh token: %w", err)
+	}
+	if n == 0 {
+		return nil, ErrRefreshRaced
+	}
+
+	tokens, err := s.sessionTokens(sess.Edges.User, sid, next)
+	if err != nil {
+		return nil, err
+	}
+	if w != nil {
+		setSessionCookies(w, tokens)
+	}
+	return tokens, nil
+}
+
+// Impersonate starts a session as the user for support staff. It has no
+// refresh token, ends after impersonationTTL and appears in the user's
+// session list. Its token is marked as impersonated, so it cannot hand out
+// credentials and what it does is audited as the admin's.
+func (s *AuthService) Impersonate(ctx context.Context, userID int) (*SessionTokens, error) {
+	u, err := s.db.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	sid, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	// The refresh token hash is for a secret nobody holds
+	unused, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	err = s.db.Session.Create().
+		SetSid(sid).
+		SetRefreshTokenHash(auth.HashAccessToken(unused)).
+		SetUserAgent(impersonationAgent).
+		SetIP(clientInfoFrom(ctx).ip).
+		SetExpiresAt(time.Now().Add(impersonationTTL)).
+		SetUser(u).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create session: %w", err)
+	}
+	claims := auth.NewClaims(u.ID, u.Email, "session", sid, impersonationTTL)
+	claims.Impersonated = true
+	token, err := s.keys.Sign(claims)
+	if err != nil {
+		return nil, fmt.Errorf("generate session: %w", err)
+	}
+	return &SessionTokens{AccessToken: token, ExpiresIn: int(impersonationTTL.Seconds())}, nil
+}
+
+// SessionActive reports whether the session exists and has been neither
+// revoked nor left to expire.
+func (s *AuthService) SessionActive(ctx context.Context, sessionID string) bool {
+	ok, err := s.db.Session.Query().
+		Where(activeSession(), entsession.SidEQ(sessionID)).
+		Exist(ctx)
+	if err != nil {
+		slog.Error("check session", "error", err)
+		return false
+	}
+	return ok
+}
+
+// Logout revokes the session the refresh token belongs to and clears the
+// session cookies.
+func (s *AuthService) Logout(ctx context.Context, w http.ResponseWriter, refreshToken string) error {
+	ClearSessionCookies(w)
+	sid, _, _ := strings.Cut(refreshToken, ".")
+	if sid == "" {
+		return nil
+	}
+	sess, err := s.db.Session.Query().
+		Where(entsession.SidEQ(sid), entsession.RevokedAtIsNil()).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("get session: %w", err)
+	}
+	if err := sess.Update().SetRevokedAt(time.Now()).Exec(ctx); err != nil {
+		return fmt.Errorf("revoke session: %w", err)
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Actor:      ActorUser,
+		ActorID:    sess.Edges.User.ID,
+		Action:     "auth.sign_out",
+		TargetType: "session",
+		TargetID:   strconv.Itoa(sess.ID),
+	})
+	return nil
+}
+
+// ListSessions returns the user's live sessions, most recently used first.
+// currentSID marks the session making the request.
+func (s *AuthService) ListSessions(ctx context.Context, userID int, currentSID string) ([]*SessionResponse, error) {
+	rows, err := s.db.Session.Query().
+		Where(activeSession(), entsession.HasUserWith(entuser.IDEQ(userID))).
+		Order(ent.Desc(entsession.FieldLastUsedAt), ent.Desc(entsession.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	out := make([]*SessionResponse, len(rows))
+	for i, sess := range rows {
+		out[i] = &SessionResponse{
+			ID:         sess.ID,
+			UserAgent:  sess.UserAgent,
+			IP:         sess.IP,
+			CreatedAt:  sess.CreatedAt,
+			LastUsedAt: sess.LastUsedAt,
+			ExpiresAt:  sess.ExpiresAt,
+			Current:    sess.Sid == currentSID,
+		}
+	}
+	return out, nil
+}
+
+// RevokeSession signs out one of the user's sessions.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, id int) error {
+	n, err := s.db.Session.Update().
+		Where(activeSession(), entsession.IDEQ(id), entsession.HasUserWith(entuser.IDEQ(userID))).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("revoke session: %w", err)
+	}
+	if n == 0 {
+		return ErrSessionNotFound
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Action:     "session.revoke",
+		TargetType: "session",
+		TargetID:   strconv.Itoa(id),
+		SubjectID:  userID,
+	})
+	return nil
+}
+
+// RevokeOtherSessions signs out all of the user's sessions except keepSID,
+// returning how many were revoked.
+func (s *AuthService) RevokeOtherSessions(ctx context.Context, userID int, keepSID string) (int, error) {
+	n, err := s.db.Session.Update().
+		Where(
+			activeSession(),
+			entsession.HasUserWith(entuser.IDEQ(userID)),
+			entsession.SidNEQ(keepSID),
+		).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("revoke sessions: %w", err)
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Action:    "session.revoke_others",
+		SubjectID: userID,
+		Details:   map[string]any{"revoked": n},
+	})
+	return n, nil
+}
+
+func activeSession() predicate.Session {
+	return entsession.And(entsession.RevokedAtIsNil(), entsession.ExpiresAtGT(time.Now()))
+}
+
+// randomToken returns n random bytes, base64url encoded.
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
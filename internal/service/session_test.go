@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/auth"
+	entsession "github.com/logan/cloudcode/internal/ent/session"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+)
+
+func TestRefreshSession_Rotation(t *testing.T) {
+	svc, client := setupDeviceTest(t, "file:ent_session_rotate?mode=memory&_fk=1")
+	ctx := WithClientInfo(context.Background(), "10.0.0.1", "curl/8.0")
+	u := client.User.GetX(ctx, createTestUser(t, client))
+
+	first, err := svc.startSession(ctx, u)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	claims, err := auth.ValidateToken("test-secret", first.AccessToken)
+	if err != nil || claims.SessionID == "" {
+		t.Fatalf("claims = %+v, %v", claims, err)
+	}
+	if !svc.SessionActive(ctx, claims.SessionID) {
+		t.Fatal("new session inactive")
+	}
+
+	rec := httptest.NewRecorder()
+	second, err := svc.RefreshSession(ctx, rec, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Error("refresh token not rotated")
+	}
+	if len(rec.Result().Cookies()) != 2 {
+		t.Errorf("cookies = %v", rec.Result().Cookies())
+	}
+	if c, _ := auth.ValidateToken("test-secret", second.AccessToken); c == nil || c.SessionID != claims.SessionID {
+		t.Errorf("refreshed claims = %+v", c)
+	}
+
+	// A second tab refreshing with the old token moments later is told to
+	// use the new tokens, and the session is left alone
+	if _, err := svc.RefreshSession(ctx, nil, first.RefreshToken); !errors.Is(err, ErrRefreshRaced) {
+		t.Errorf("raced refresh: %v", err)
+	}
+	if !svc.SessionActive(ctx, claims.SessionID) {
+		t.Fatal("session revoked by a concurrent refresh")
+	}
+
+	// Replaying it later means it leaked, so the session ends
+	client.Session.Update().
+		Where(entsession.SidEQ(claims.SessionID)).
+		SetRotatedAt(time.Now().Add(-time.Minute)).
+		ExecX(ctx)
+	if _, err := svc.RefreshSession(ctx, nil, first.RefreshToken); !errors.Is(err, ErrRefreshInvalid) {
+		t.Errorf("replayed refresh: %v", err)
+	}
+	if svc.SessionActive(ctx, claims.SessionID) {
+		t.Error("session survived refresh token reuse")
+	}
+	if _, err := svc.RefreshSession(ctx, nil, second.RefreshToken); !errors.Is(err, ErrRefreshInvalid) {
+		t.Errorf("refresh after revocation: %v", err)
+	}
+
+	for _, bad := range []string{"", "nodot", claims.SessionID + ".", "unknown.secret"} {
+		if _, err := svc.RefreshSession(ctx, nil, bad); !errors.Is(err, ErrRefreshInvalid) {
+			t.Errorf("refresh(%q) = %v", bad, err)
+		}
+	}
+}
+
+func TestSessions_ListAndRevoke(t *testing.T) {
+	svc, client := setupDeviceTest(t, "file:ent_session_list?mode=memory&_fk=1")
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+	u := client.User.GetX(ctx, userID)
+	other := client.User.Create().SetEmail("other@example.com").SaveX(ctx)
+
+	var sids []string
+	for i := 0; i < 3; i++ {
+		tokens, err := svc.startSession(WithClientInfo(ctx, "10.0.0.1", "browser"), u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, _ := auth.ValidateToken("test-secret", tokens.AccessToken)
+		sids = append(sids, c.SessionID)
+	}
+	theirs, _ := svc.startSession(ctx, other)
+
+	list, err := svc.ListSessions(ctx, userID, sids[0])
+	if err != nil || len(list) != 3 {
+		t.Fatalf("list = %d, %v", len(list), err)
+	}
+	current := 0
+	for _, s := range list {
+		if s.Current {
+			current++
+		}
+		if s.IP != "10.0.0.1" || s.UserAgent != "browser" {
+			t.Errorf("client info = %q %q", s.IP, s.UserAgent)
+		}
+	}
+	if current != 1 {
+		t.Errorf("%d sessions marked current", current)
+	}
+
+	// Other users' sessions are not found
+	theirID := client.Session.Query().Where(entsession.HasUserWith(entuser.IDEQ(other.ID))).OnlyIDX(ctx)
+	if err := svc.RevokeSession(ctx, userID, theirID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("revoke another user's session: %v", err)
+	}
+
+	if err := svc.RevokeSession(ctx, userID, list[0].ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if err := svc.RevokeSession(ctx, userID, list[0].ID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("revoke twice: %v", err)
+	}
+	n, err := svc.RevokeOtherSessions(ctx, userID, sids[0])
+	if err != nil {
+		t.Fatalf("revoke others: %v", err)
+	}
+	if !svc.SessionActive(ctx, sids[0]) {
+		t.Error("current session revoked")
+	}
+	list, _ = svc.ListSessions(ctx, userID, sids[0])
+	if len(list) != 1 || !list[0].Current {
+		t.Errorf("after revoking others: %d sessions (revoked %d)", len(list), n)
+	}
+
+	// Logout ends the session its refresh token belongs to
+	rec := httptest.NewRecorder()
+	if err := svc.Logout(ctx, rec, theirs.RefreshToken); err != nil {
+		t.Fatalf("logout: %v", err)
+	}
+	if _, err := svc.RefreshSession(ctx, nil, theirs.RefreshToken); !errors.Is(err, ErrRefreshInvalid) {
+		t.Errorf("refresh after logout: %v", err)
+	}
+	for _, c := range rec.Result().Cookies() {
+		if c.MaxAge >= 0 {
+			t.Errorf("cookie %s not cleared", c.Name)
+		}
+	}
+}
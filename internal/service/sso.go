@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/logan/cloudcode/internal/ent"
+	entidentity "github.com/logan/cloudcode/internal/ent/identity"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/sso"
+)
+
+// Single sign-on errors.
+var (
+	ErrSSOProviderNotFound = errors.New("sso provider not found")
+	// ErrSSOStateInvalid indicates a callback that does not belong to a login
+	// started by this browser, or one that took too long.
+	ErrSSOStateInvalid = errors.New("invalid or expired sso login")
+	// ErrSSODomainNotAllowed indicates an email outside the provider's
+	// allowed domains.
+	ErrSSODomainNotAllowed = errors.New("email domain not allowed")
+)
+
+// SSOStateTTL is how long the user has to finish signing in at the provider.
+const SSOStateTTL = 10 * time.Minute
+
+// SSOProviderInfo describes a provider for the login page.
+type SSOProviderInfo struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// SetSSOProviders enables single sign-on through the given providers.
+func (s *AuthService) SetSSOProviders(providers []sso.Provider) {
+	s.providers = providers
+}
+
+// SSOProviders lists the configured providers.
+func (s *AuthService) SSOProviders() []SSOProviderInfo {
+	out := make([]SSOProviderInfo, len(s.providers))
+	for i, p := range s.providers {
+		out[i] = SSOProviderInfo{Name: p.Config().Name, Kind: p.Config().Kind}
+	}
+	return out
+}
+
+func (s *AuthService) ssoProvider(name string) (sso.Provider, error) {
+	for _, p := range s.providers {
+		if p.Config().Name == name {
+			return p, nil
+		}
+	}
+	return nil, ErrSSOProviderNotFound
+}
+
+// StartSSO begins a login with the named provider. It returns the URL to
+// send the browser to and the sealed login state, which the caller must hand
+// back to CompleteSSO from a cookie. redirect is the frontend path to land
+// on afterwards.
+func (s *AuthService) StartSSO(ctx context.Context, name, redirect string) (string, string, error) {
+	p, err := s.ssoProvider(name)
+	if err != nil {
+		return "", "", err
+	}
+	st, err := sso.NewState(name, safeRedirect(redirect))
+	if err != nil {
+		return "", "", err
+	}
+	authURL, err := p.AuthCodeURL(ctx, st.State, st.Nonce, st.Verifier)
+	if err != nil {
+		return "", "", fmt.Errorf("sso %s: %w", name, err)
+	}
+	sealed, err := st.Seal(s.jwtSecret, SSOStateTTL)
+	if err != nil {
+		return "", "", fmt.Errorf("seal sso state: %w", err)
+	}
+	return authURL, sealed, nil
+}
+
+// CompleteSSO finishes a login at the provider's callback: it checks the
+// returned state against the sealed one, redeems the code, links the
+// identity to a user and starts a session. It returns the session token and
+// the frontend path to redirect to.
+func (s *AuthService) CompleteSSO(ctx context.Context, w http.ResponseWriter, name, sealed, state, code string) (string, string, error) {
+	p, err := s.ssoProvider(name)
+	if err != nil {
+		return "", "", err
+	}
+	st, err := sso.OpenState(s.jwtSecret, sealed)
+	if err != nil || st.Provider != name || st.State != state || code == "" {
+		return "", "", ErrSSOStateInvalid
+	}
+
+	id, err := p.Exchange(ctx, code, st.Nonce, st.Verifier)
+	if err != nil {
+		return "", "", fmt.Errorf("sso %s: %w", name, err)
+	}
+	u, err := s.linkIdentity(ctx, p.Config(), id)
+	if err != nil {
+		return "", "", err
+	}
+	tokens, err := s.issueSession(ctx, w, u)
+	if err != nil {
+		return "", "", err
+	}
+	return tokens.AccessToken, st.Redirect, nil
+}
+
+// linkIdentity returns the user an identity belongs to. An identity seen
+// before is found by its subject; a new one is linked to the account with
+// its email address, which the provider must have verified, creating the
+// account if there is none.
+func (s *AuthService) linkIdentity(ctx context.Context, cfg sso.Config, id *sso.Identity) (*ent.User, error) {
+	if len(cfg.AllowedDomains) > 0 && (!id.EmailVerified || !cfg.AllowsEmail(id.Email)) {
+		return nil, ErrSSODomainNotAllowed
+	}
+
+	existing, err := s.db.Identity.Query().
+		Where(entidentity.ProviderEQ(cfg.Name), entidentity.SubjectEQ(id.Subject)).
+		WithUser().
+		Only(ctx)
+	if err == nil {
+		if err := s.db.Identity.UpdateOne(existing).
+			SetEmail(id.Email).
+			SetLastLoginAt(time.Now()).
+			Exec(ctx); err != nil {
+			return nil, fmt.Errorf("update identity: %w", err)
+		}
+		return existing.Edges.User, nil
+	}
+	if !ent.IsNotFound(err) {
+		return nil, fmt.Errorf("query identity: %w", err)
+	}
+
+	if !id.EmailVerified || id.Email == "" {
+		return nil, sso.ErrEmailNotVerified
+	}
+	u, err := s.db.User.Query().Where(entuser.EmailEqualFold(id.Email)).Only(ctx)
+	if err != nil {
+		if !ent.IsNotFound(err) {
+			return nil, fmt.Errorf("query user: %w", err)
+		}
+		u, err = s.db.User.Create().
+			SetEmail(strings.ToLower(id.Email)).
+			SetName(id.Name).
+			Save(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("create user: %w", err)
+		}
+	}
+	err = s.db.Identity.Create().
+		SetProvider(cfg.Name).
+		SetSubject(id.Subject).
+		SetEmail(id.Email).
+		SetUser(u).
+		Exec(ctx)
+	// A constraint error means a concurrent login linked it first
+	if err != nil && !ent.IsConstraintError(err) {
+		return nil, fmt.Errorf("create identity: %w", err)
+	}
+	return u, nil
+}
+
+// safeRedirect keeps post-login redirects on the frontend: only absolute
+// paths are accepted, and not protocol-relative ones.
+func safeRedirect(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.ContainsAny(path, "\\\r\n") {
+		return "/dashboard"
+	}
+	return path
+}
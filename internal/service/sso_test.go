@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/logan/cloudcode/internal/auth"
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	"github.com/logan/cloudcode/internal/sso"
+	"github.com/logan/cloudcode/internal/sso/ssotest"
+)
+
+func setupSSOTest(t *testing.T, dsn string, allowed ...string) (*AuthService, *ssotest.Issuer) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { client.Close() })
+	issuer := ssotest.NewIssuer(t)
+	p, err := sso.New(sso.Config{
+		Name:           "okta",
+		Issuer:         issuer.URL,
+		ClientID:       issuer.ClientID,
+		ClientSecret:   issuer.ClientSecret,
+		RedirectURL:    "http://api/auth/sso/okta/callback",
+		AllowedDomains: allowed,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := NewAuthService(client, "test-secret", "http://api", "http://app", NewLogMailer(slog.Default()))
+	svc.SetSSOProviders([]sso.Provider{p})
+	return svc, issuer
+}
+
+// ssoLogin runs a login as u and returns the session's user ID.
+func ssoLogin(t *testing.T, svc *AuthService, issuer *ssotest.Issuer, u ssotest.User) (int, error) {
+	t.Helper()
+	ctx := context.Background()
+	authURL, sealed, err := svc.StartSSO(ctx, "okta", "/dashboard/settings")
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	code, state := issuer.Authorize(t, authURL, u)
+	token, redirect, err := svc.CompleteSSO(ctx, httptest.NewRecorder(), "okta", sealed, state, code)
+	if err != nil {
+		return 0, err
+	}
+	if redirect != "/dashboard/settings" {
+		t.Errorf("redirect = %q", redirect)
+	}
+	claims, err := auth.ValidateToken("test-secret", token)
+	if err != nil || claims.Purpose != "session" {
+		t.Fatalf("session token: %v", err)
+	}
+	return claims.UserID, nil
+}
+
+func TestSSOLinksByVerifiedEmail(t *testing.T) {
+	svc, issuer := setupSSOTest(t, "file:ent_sso_link?mode=memory&_fk=1")
+	ctx := context.Background()
+	existing := createTestUser(t, svc.db)
+
+	// First login links to the account with the same email
+	alice := ssotest.User{Subject: "okta-1", Email: "Test@Example.com", EmailVerified: true, Name: "Test"}
+	userID, err := ssoLogin(t, svc, issuer, alice)
+	if err != nil || userID != existing {
+		t.Fatalf("login = %d, %v; want %d", userID, err, existing)
+	}
+
+	// Later logins follow the subject even when the email changes
+	alice.Email, alice.EmailVerified = "renamed@example.com", false
+	if userID, err := ssoLogin(t, svc, issuer, alice); err != nil || userID != existing {
+		t.Fatalf("relogin = %d, %v; want %d", userID, err, existing)
+	}
+	if n := svc.db.Identity.Query().CountX(ctx); n != 1 {
+		t.Errorf("identities = %d, want 1", n)
+	}
+
+	// An unverified email cannot claim an account
+	mallory := ssotest.User{Subject: "okta-2", Email: "test@example.com", EmailVerified: false}
+	if _, err := ssoLogin(t, svc, issuer, mallory); !errors.Is(err, sso.ErrEmailNotVerified) {
+		t.Errorf("unverified login: %v", err)
+	}
+
+	// A new verified email creates an account
+	bob := ssotest.User{Subject: "okta-3", Email: "bob@example.com", EmailVerified: true, Name: "Bob"}
+	userID, err = ssoLogin(t, svc, issuer, bob)
+	if err != nil || userID == existing {
+		t.Fatalf("new user login = %d, %v", userID, err)
+	}
+	if u := svc.db.User.GetX(ctx, userID); u.Email != "bob@example.com" || u.Name != "Bob" {
+		t.Errorf("created user = %q %q", u.Email, u.Name)
+	}
+}
+
+func TestSSOAllowedDomains(t *testing.T) {
+	svc, issuer := setupSSOTest(t, "file:ent_sso_domains?mode=memory&_fk=1", "corp.example")
+	if _, err := ssoLogin(t, svc, issuer, ssotest.User{Subject: "1", Email: "a@corp.example", EmailVerified: true}); err != nil {
+		t.Errorf("corp login: %v", err)
+	}
+	if _, err := ssoLogin(t, svc, issuer, ssotest.User{Subject: "2", Email: "a@gmail.com", EmailVerified: true}); !errors.Is(err, ErrSSODomainNotAllowed) {
+		t.Errorf("outside login: %v", err)
+	}
+}
+
+func TestSSOState(t *testing.T) {
+	svc, issuer := setupSSOTest(t, "file:ent_sso_state?mode=memory&_fk=1")
+	ctx := context.Background()
+	if _, _, err := svc.StartSSO(ctx, "nope", ""); !errors.Is(err, ErrSSOProviderNotFound) {
+		t.Errorf("unknown provider: %v", err)
+	}
+
+	authURL, sealed, err := svc.StartSSO(ctx, "okta", "https://evil.example/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, state := issuer.Authorize(t, authURL, ssotest.User{Subject: "1", Email: "a@example.com", EmailVerified: true})
+
+	// A callback must carry the state of the login this browser started
+	_, otherSealed, _ := svc.StartSSO(ctx, "okta", "")
+	for _, tc := range []struct{ sealed, state string }{
+		{otherSealed, state},
+		{"", state},
+		{sealed, "forged"},
+	} {
+		if _, _, err := svc.CompleteSSO(ctx, httptest.NewRecorder(), "okta", tc.sealed, tc.state, code); !errors.Is(err, ErrSSOStateInvalid) {
+			t.Errorf("complete(%q) = %v, want ErrSSOStateInvalid", tc.state, err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	_, redirect, err := svc.CompleteSSO(ctx, rec, "okta", sealed, state, code)
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if redirect != "/dashboard" {
+		t.Errorf("offsite redirect kept: %q", redirect)
+	}
+	if c := rec.Result().Cookies(); len(c) != 2 || c[0].Name != "session" || c[1].Name != RefreshCookie || !c[0].HttpOnly || !c[1].HttpOnly {
+		t.Errorf("cookies = %v", c)
+	}
+}
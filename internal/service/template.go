@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/logan/cloudcode/internal/devcontainer"
+	"github.com/logan/cloudcode/internal/ent"
+	enttemplate "github.com/logan/cloudcode/internal/ent/template"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+var (
+	// ErrTemplateNotFound indicates the template does not exist or is not
+	// visible to the caller.
+	ErrTemplateNotFound = errors.New("template not found")
+	// ErrTemplateExists indicates the caller already has a template by that name.
+	ErrTemplateExists = errors.New("template already exists")
+	// ErrTemplateReadOnly indicates a user tried to delete a global template.
+	ErrTemplateReadOnly = errors.New("global templates can only be changed by an admin")
+	// ErrInvalidTemplate wraps a description of what is wrong with a template.
+	ErrInvalidTemplate = errors.New("invalid template")
+)
+
+var (
+	envKeyPattern  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	repoURLPattern = regexp.MustCompile(`^(https?://|ssh://|git@)\S+$`)
+)
+
+// reservedEnv lists variables a template may not set: the credentials
+// injected by InstanceService and the first-boot variables.
+var reservedEnv = []string{
+	"AGENT_SECRET",
+	"ANTHROPIC_API_KEY",
+	"CLAUDE_CODE_OAUTH_TOKEN",
+	provider.EnvRepos,
+	provider.EnvSetup,
+}
+
+// TemplateService manages instance environment templates. Users create
+// templates of their own; admin-created templates are global.
+type TemplateService struct {
+	db *ent.Client
+}
+
+// NewTemplateService creates a new TemplateService.
+func NewTemplateService(db *ent.Client) *TemplateService {
+	return &TemplateService{db: db}
+}
+
+// TemplateSpec describes a template to create. A devcontainer.json may supply
+// the image, variables and setup commands; the other fields add to or
+// override it.
+type TemplateSpec struct {
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	Image        string            `json:"image"`
+	Devcontainer string            `json:"devcontainer"`
+	SetupScript  string            `json:"setup_script"`
+	Env          map[string]string `json:"env"`
+	Repos        []string          `json:"repos"`
+}
+
+// TemplateResponse is the API response for a template.
+type TemplateResponse struct {
+	ID           int               `json:"id"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description,omitempty"`
+	Image        string            `json:"image,omitempty"`
+	Devcontainer string            `json:"devcontainer,omitempty"`
+	SetupScript  string            `json:"setup_script,omitempty"`
+	Env          map[string]string `json:"env,omitempty"`
+	Repos        []string          `json:"repos,omitempty"`
+	Global       bool              `json:"global"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// toTemplateResponse expects t to have been loaded with its owner edge.
+func toTemplateResponse(t *ent.Template) *TemplateResponse {
+	return &TemplateResponse{
+		ID:           t.ID,
+		Name:         t.Name,
+		Description:  t.Description,
+		Image:        t.Image,
+		Devcontainer: t.Devcontainer,
+		SetupScript:  t.SetupScript,
+		Env:          t.Env,
+		Repos:        t.Repos,
+		Global:       t.Edges.Owner == nil,
+		CreatedAt:    t.CreatedAt,
+	}
+}
+
+// visibleTemplates queries the templates userID may use: global ones and
+// their own. Admin callers (userID 0) see every template.
+func visibleTemplates(db *ent.Client, userID int) *ent.TemplateQuery {
+	q := db.Template.Query().WithOwner()
+	if userID != 0 {
+		q = q.Where(enttemplate.Or(
+			enttemplate.Not(enttemplate.HasOwner()),
+			enttemplate.HasOwnerWith(entuser.IDEQ(userID)),
+		))
+	}
+	return q
+}
+
+// List returns the templates visible to the user, by name.
+func (s *TemplateService) List(ctx context.Context, userID int) ([]*TemplateResponse, error) {
+	rows, err := visibleTemplates(s.db, userID).
+		Order(ent.Asc(enttemplate.FieldName), ent.Asc(enttemplate.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list templates: %w", err)
+	}
+	out := make([]*TemplateResponse, len(rows))
+	for i, t := range rows {
+		out[i] = toTemplateResponse(t)
+	}
+	return out, nil
+}
+
+// Get returns a template visible to the user.
+func (s *TemplateService) Get(ctx context.Context, userID, id int) (*TemplateResponse, error) {
+	t, err := lookupTemplate(ctx, s.db, userID, strconv.Itoa(id))
+	if err != nil {
+		return nil, err
+	}
+	return toTemplateResponse(t), nil
+}
+
+// Create validates and saves a template owned by userID, or a global one
+// when userID is 0. Returns ErrInvalidTemplate (wrapped with the reason) or
+// ErrTemplateExists.
+func (s *TemplateService) Create(ctx context.Context, userID int, spec TemplateSpec) (*TemplateResponse, error) {
+	spec.Name = strings.TrimSpace(spec.Name)
+	if err := validateTemplate(spec); err != nil {
+		return nil, err
+	}
+
+	owner := enttemplate.Not(enttemplate.HasOwner())
+	if userID != 0 {
+		owner = enttemplate.HasOwnerWith(entuser.IDEQ(userID))
+	}
+	exists, err := s.db.Template.Query().
+		Where(enttemplate.NameEQ(spec.Name), owner).
+		Exist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query existing: %w", err)
+	}
+	if exists {
+		return nil, ErrTemplateExists
+	}
+
+	create := s.db.Template.Create().
+		SetName(spec.Name).
+		SetDescription(spec.Description).
+		SetImage(spec.Image).
+		SetDevcontainer(spec.Devcontainer).
+		SetSetupScript(spec.SetupScript).
+		SetEnv(spec.Env).
+		SetRepos(spec.Repos)
+	if userID != 0 {
+		create = create.SetOwnerID(userID)
+	}
+	t, err := create.Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, ErrTemplateExists
+		}
+		return nil, fmt.Errorf("save template: %w", err)
+	}
+	if userID != 0 {
+		t.Edges.Owner = &ent.User{ID: userID}
+	}
+	return toTemplateResponse(t), nil
+}
+
+// Delete removes a template. Users may delete only their own templates;
+// admin callers (userID 0) may delete any. Instances created from the
+// template keep their environment.
+func (s *TemplateService) Delete(ctx context.Context, userID, id int) error {
+	t, err := lookupTemplate(ctx, s.db, userID, strconv.Itoa(id))
+	if err != nil {
+		return err
+	}
+	if userID != 0 && t.Edges.Owner == nil {
+		return ErrTemplateReadOnly
+	}
+	if err := s.db.Template.DeleteOne(t).Exec(ctx); err != nil {
+		return fmt.Errorf("delete template: %w", err)
+	}
+	return nil
+}
+
+// validateTemplate checks the fields of spec and the environment they
+// combine into.
+func validateTemplate(spec TemplateSpec) error {
+	if !provider.ValidInstanceName(spec.Name) {
+		return fmt.Errorf("%w: name must be up to 32 lowercase letters, digits and hyphens, starting with a letter", ErrInvalidTemplate)
+	}
+	if strings.ContainsAny(spec.Image, " \t\n") {
+		return fmt.Errorf("%w: image %q", ErrInvalidTemplate, spec.Image)
+	}
+	for _, repo := range spec.Repos {
+		if !repoURLPattern.MatchString(repo) {
+			return fmt.Errorf("%w: repo %q is not an http(s), ssh or git@ URL", ErrInvalidTemplate, repo)
+		}
+	}
+
+	env, err := templateEnvironment(&ent.Template{
+		Image:        spec.Image,
+		Devcontainer: spec.Devcontainer,
+		SetupScript:  spec.SetupScript,
+		Env:          spec.Env,
+	})
+	if err != nil {
+		return err
+	}
+	for key := range env.Env {
+		if !envKeyPattern.MatchString(key) {
+			return fmt.Errorf("%w: invalid variable name %q", ErrInvalidTemplate, key)
+		}
+		if slices.Contains(reservedEnv, key) {
+			return fmt.Errorf("%w: variable %s is set by the platform", ErrInvalidTemplate, key)
+		}
+	}
+	return nil
+}
+
+// lookupTemplate finds a template visible to userID. selector is a numeric
+// template ID or a name; a user's own template shadows a global one of the
+// same name.
+func lookupTemplate(ctx context.Context, db *ent.Client, userID int, selector string) (*ent.Template, error) {
+	q := visibleTemplates(db, userID)
+	if id, err := strconv.Atoi(selector); err == nil {
+		q = q.Where(enttemplate.IDEQ(id))
+	} else {
+		q = q.Where(enttemplate.NameEQ(selector))
+	}
+
+	rows, err := q.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query template: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, ErrTemplateNotFound
+	}
+	for _, t := range rows {
+		if t.Edges.Owner != nil {
+			return t, nil
+		}
+	}
+	return rows[0], nil
+}
+
+// templateEnvironment combines a template's devcontainer.json with its own
+// fields: the template image and variables win, and its setup script runs
+// after the devcontainer commands.
+func templateEnvironment(t *ent.Template) (provider.Environment, error) {
+	env := provider.Environment{
+		Env:   make(map[string]string),
+		Repos: t.Repos,
+	}
+	if t.Devcontainer != "" {
+		dc, err := devcontainer.Parse([]byte(t.Devcontainer))
+		if err != nil {
+			return provider.Environment{}, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+		}
+		env.Image = dc.Image
+		env.Env = dc.Env()
+		env.SetupScript = dc.SetupScript()
+	}
+	if t.Image != "" {
+		env.Image = t.Image
+	}
+	maps.Copy(env.Env, t.Env)
+	env.SetupScript += t.SetupScript
+	return env, nil
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+func TestTemplateService_CreateValidates(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_template_validate?mode=memory&_fk=1")
+	defer client.Close()
+	svc := NewTemplateService(client)
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	for name, spec := range map[string]TemplateSpec{
+		"bad name":       {Name: "Go Dev"},
+		"bad repo":       {Name: "go", Repos: []string{"file:///etc"}},
+		"reserved env":   {Name: "go", Env: map[string]string{"ANTHROPIC_API_KEY": "x"}},
+		"bad env key":    {Name: "go", Env: map[string]string{"1X": "x"}},
+		"devcontainer":   {Name: "go", Devcontainer: `{"build": {"dockerfile": "Dockerfile"}}`},
+		"reserved in dc": {Name: "go", Devcontainer: `{"remoteEnv": {"AGENT_SECRET": "x"}}`},
+	} {
+		if _, err := svc.Create(ctx, userID, spec); !errors.Is(err, ErrInvalidTemplate) {
+			t.Errorf("%s: got %v, want ErrInvalidTemplate", name, err)
+		}
+	}
+
+	spec := TemplateSpec{Name: "go", Repos: []string{"https://github.com/golang/example.git"}}
+	if _, err := svc.Create(ctx, userID, spec); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := svc.Create(ctx, userID, spec); !errors.Is(err, ErrTemplateExists) {
+		t.Errorf("duplicate: got %v, want ErrTemplateExists", err)
+	}
+	// The same name is free among global templates
+	if _, err := svc.Create(ctx, 0, spec); err != nil {
+		t.Errorf("create global: %v", err)
+	}
+}
+
+func TestTemplateService_Visibility(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_template_visibility?mode=memory&_fk=1")
+	defer client.Close()
+	svc := NewTemplateService(client)
+	ctx := context.Background()
+	alice := createTestUser(t, client)
+	bob := client.User.Create().SetEmail("bob@example.com").SaveX(ctx).ID
+
+	global, err := svc.Create(ctx, 0, TemplateSpec{Name: "node", Image: "claude-node:20"})
+	if err != nil {
+		t.Fatalf("create global: %v", err)
+	}
+	own, err := svc.Create(ctx, alice, TemplateSpec{Name: "node", SetupScript: "npm ci\n"})
+	if err != nil {
+		t.Fatalf("create own: %v", err)
+	}
+	if !global.Global || own.Global {
+		t.Errorf("global flags = %v, %v", global.Global, own.Global)
+	}
+
+	visible := func(userID int) []int {
+		list, err := svc.List(ctx, userID)
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		var ids []int
+		for _, tmpl := range list {
+			ids = append(ids, tmpl.ID)
+		}
+		return ids
+	}
+	if got := visible(alice); !slices.Equal(got, []int{global.ID, own.ID}) {
+		t.Errorf("alice sees %v", got)
+	}
+	if got := visible(bob); !slices.Equal(got, []int{global.ID}) {
+		t.Errorf("bob sees %v", got)
+	}
+	if _, err := svc.Get(ctx, bob, own.ID); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("bob get alice's: got %v, want ErrTemplateNotFound", err)
+	}
+
+	// A user's template shadows the global one of the same name
+	if tmpl, err := lookupTemplate(ctx, client, alice, "node"); err != nil || tmpl.ID != own.ID {
+		t.Errorf("alice resolves node to %v (err %v), want %d", tmpl, err, own.ID)
+	}
+	if tmpl, err := lookupTemplate(ctx, client, bob, "node"); err != nil || tmpl.ID != global.ID {
+		t.Errorf("bob resolves node to %v (err %v), want %d", tmpl, err, global.ID)
+	}
+
+	if err := svc.Delete(ctx, alice, global.ID); !errors.Is(err, ErrTemplateReadOnly) {
+		t.Errorf("user delete global: got %v, want ErrTemplateReadOnly", err)
+	}
+	if err := svc.Delete(ctx, bob, own.ID); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("bob delete alice's: got %v, want ErrTemplateNotFound", err)
+	}
+	if err := svc.Delete(ctx, 0, global.ID); err != nil {
+		t.Errorf("admin delete: %v", err)
+	}
+}
+
+func TestTemplateEnvironment(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_template_env?mode=memory&_fk=1")
+	defer client.Close()
+	tmpl := client.Template.Create().
+		SetName("go").
+		SetDevcontainer(`{
+			"image": "claude-go:1.24",
+			"containerEnv": {"GOFLAGS": "-mod=mod", "EDITOR": "vi"},
+			"postCreateCommand": "go mod download",
+		}`).
+		SetSetupScript("make tools\n").
+		SetEnv(map[string]string{"EDITOR": "nvim"}).
+		SetRepos([]string{"git@github.com:acme/api.git"}).
+		SaveX(context.Background())
+
+	env, err := templateEnvironment(tmpl)
+	if err != nil {
+		t.Fatalf("environment: %v", err)
+	}
+	if env.Image != "claude-go:1.24" {
+		t.Errorf("image = %q", env.Image)
+	}
+	if env.Env["GOFLAGS"] != "-mod=mod" || env.Env["EDITOR"] != "nvim" {
+		t.Errorf("env = %v", env.Env)
+	}
+	if env.SetupScript != "go mod download\nmake tools\n" {
+		t.Errorf("setup script = %q", env.SetupScript)
+	}
+	if !slices.Equal(env.Repos, []string{"git@github.com:acme/api.git"}) {
+		t.Errorf("repos = %v", env.Repos)
+	}
+}
+
+func TestJobService_CreateWithTemplate(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_template_create?mode=memory&_fk=1")
+	defer client.Close()
+	mock := provider.NewMock()
+	ctx := context.Background()
+
+	pool, err := NewWarmPoolService(client, mock, slog.Default(), provider.DefaultClasses,
+		map[string]int{"small": 1}, time.Hour)
+	if err != nil {
+		t.Fatalf("new pool: %v", err)
+	}
+	instSvc := NewInstanceService(client, mock, "")
+	instSvc.SetClasses(provider.DefaultClasses, nil)
+	instSvc.SetWarmPool(pool)
+	if _, err := pool.Refill(ctx); err != nil {
+		t.Fatalf("refill: %v", err)
+	}
+	jobs := NewJobService(client, instSvc, slog.Default(), 1, time.Hour)
+	userID := createTestUser(t, client)
+
+	if _, err := jobs.EnqueueCreate(ctx, userID, InstanceSpec{Template: "missing"}); !errors.Is(err, ErrTemplateNotFound) {
+		t.Fatalf("unknown template: got %v, want ErrTemplateNotFound", err)
+	}
+
+	tmpl, err := NewTemplateService(client).Create(ctx, userID, TemplateSpec{
+		Name:        "api",
+		Env:         map[string]string{"STAGE": "dev"},
+		Repos:       []string{"https://github.com/acme/api.git"},
+		SetupScript: "make deps\n",
+	})
+	if err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+	job, err := jobs.EnqueueCreate(ctx, userID, InstanceSpec{Template: "api"})
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if !jobs.RunNext(ctx) {
+		t.Fatal("expected a job to run")
+	}
+	got, err := jobs.Get(ctx, job.ID, userID)
+	if err != nil || got.Status != JobSucceeded {
+		t.Fatalf("job = %+v, err %v", got, err)
+	}
+
+	inst := client.Instance.GetX(ctx, *got.InstanceID)
+	if inst.TemplateID == nil || *inst.TemplateID != tmpl.ID {
+		t.Errorf("template_id = %v, want %d", inst.TemplateID, tmpl.ID)
+	}
+	env := mock.Options(inst.ProviderID).Environment
+	if env.Env["STAGE"] != "dev" || env.SetupScript != "make deps\n" || len(env.Repos) != 1 {
+		t.Errorf("environment = %+v", env)
+	}
+	// Warm instances boot the stock environment, so the pool is left alone
+	if n := client.WarmInstance.Query().CountX(ctx); n != 1 {
+		t.Errorf("warm instances = %d, want 1", n)
+	}
+}
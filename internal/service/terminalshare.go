@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/logan/cloudcode/internal/auth"
+	"github.com/logan/cloudcode/internal/ent"
+	entinstance "github.com/logan/cloudcode/internal/ent/instance"
+	entterminalshare "github.com/logan/cloudcode/internal/ent/terminalshare"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+var (
+	// ErrTerminalShareNotFound indicates the share does not exist or belongs
+	// to another instance.
+	ErrTerminalShareNotFound = errors.New("terminal share not found")
+	// ErrInvalidTerminalShare wraps a description of what is wrong with a
+	// share request.
+	ErrInvalidTerminalShare = errors.New("invalid terminal share")
+	// ErrShareRejected indicates a presented share token is unknown, expired
+	// or revoked.
+	ErrShareRejected = errors.New("invalid or expired share link")
+)
+
+const (
+	// DefaultTerminalShareTTL is how long a share link works if the owner
+	// does not say.
+	DefaultTerminalShareTTL = time.Hour
+	// MaxTerminalShareTTL bounds how long a share link can work.
+	MaxTerminalShareTTL = 24 * time.Hour
+	// maxTerminalShares bounds the live share links of an instance.
+	maxTerminalShares = 20
+)
+
+// TerminalShareResponse is the API response for a share link. It never
+// carries the token.
+type TerminalShareResponse struct {
+	ID         int        `json:"id"`
+	InstanceID int        `json:"instance_id"`
+	Label      string     `json:"label"`
+	Prefix     string     `json:"prefix"`
+	CreatedBy  int        `json:"created_by"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedTerminalShareResponse is returned once, on creation, with the
+// token itself.
+type CreatedTerminalShareResponse struct {
+	TerminalShareResponse
+	Token string `json:"token"`
+}
+
+// TerminalShareGrant is what a share token lets its holder do: watch the
+// terminal of a running instance.
+type TerminalShareGrant struct {
+	ShareID    int
+	InstanceID int
+	Label      string
+	ExpiresAt  time.Time
+	Host       string
+}
+
+func toTerminalShareResponse(s *ent.TerminalShare) TerminalShareResponse {
+	return TerminalShareResponse{
+		ID:         s.ID,
+		InstanceID: s.InstanceID,
+		Label:      s.Label,
+		Prefix:     s.TokenPrefix,
+		CreatedBy:  s.Edges.Creator.ID,
+		ExpiresAt:  s.ExpiresAt,
+		RevokedAt:  s.RevokedAt,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+// CreateTerminalShare mints a link to watch the instance's terminal for
+// ttl (DefaultTerminalShareTTL if zero). The caller must manage the
+// instance. The returned token is not stored and cannot be shown again.
+func (s *InstanceService) CreateTerminalShare(ctx context.Context, id, userID int, label string, ttl time.Duration) (*CreatedTerminalShareResponse, error) {
+	label = strings.TrimSpace(label)
+	if len(label) > 64 {
+		return nil, fmt.Errorf("%w: label must be at most 64 characters", ErrInvalidTerminalShare)
+	}
+	if ttl == 0 {
+		ttl = DefaultTerminalShareTTL
+	}
+	if ttl < time.Minute || ttl > MaxTerminalShareTTL {
+		return nil, fmt.Errorf("%w: links last from 1m to %s", ErrInvalidTerminalShare, MaxTerminalShareTTL)
+	}
+	inst, err := authorizeInstance(ctx, s.db, id, userID, AccessManage)
+	if err != nil {
+		return nil, err
+	}
+	// The admin API key mints on the owner's behalf
+	creatorID := userID
+	if creatorID == 0 {
+		creatorID = inst.Edges.Owner.ID
+	}
+
+	now := time.Now()
+	live, err := s.db.TerminalShare.Query().
+		Where(
+			entterminalshare.InstanceIDEQ(id),
+			entterminalshare.RevokedAtIsNil(),
+			entterminalshare.ExpiresAtGT(now),
+		).
+		Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("count terminal shares: %w", err)
+	}
+	if live >= maxTerminalShares {
+		return nil, fmt.Errorf("%w: limit of %d live links reached", ErrInvalidTerminalShare, maxTerminalShares)
+	}
+
+	token, hash, err := auth.GenerateShareToken()
+	if err != nil {
+		return nil, err
+	}
+	share, err := s.db.TerminalShare.Create().
+		SetInstanceID(id).
+		SetLabel(label).
+		SetTokenHash(hash).
+		SetTokenPrefix(token[:len(auth.ShareTokenPrefix)+6]).
+		SetExpiresAt(now.Add(ttl)).
+		SetCreatorID(creatorID).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("save terminal share: %w", err)
+	}
+	share.Edges.Creator = &ent.User{ID: creatorID}
+	s.audit.Log(ctx, AuditEntry{
+		Action:     "terminal_share.create",
+		TargetType: "terminal_share",
+		TargetID:   strconv.Itoa(share.ID),
+		SubjectID:  inst.Edges.Owner.ID,
+		Details:    map[string]any{"instance_id": id, "label": label, "expires_at": share.ExpiresAt},
+	})
+	return &CreatedTerminalShareResponse{TerminalShareResponse: toTerminalShareResponse(share), Token: token}, nil
+}
+
+// ListTerminalShares returns the instance's share links, newest first. The
+// caller must manage the instance.
+func (s *InstanceService) ListTerminalShares(ctx context.Context, id, userID int) ([]TerminalShareResponse, error) {
+	if _, err := authorizeInstance(ctx, s.db, id, userID, AccessManage); err != nil {
+		return nil, err
+	}
+	shares, err := s.db.TerminalShare.Query().
+		Where(entterminalshare.InstanceIDEQ(id)).
+		WithCreator().
+		Order(ent.Desc(entterminalshare.FieldCreatedAt), ent.Desc(entterminalshare.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list terminal shares: %w", err)
+	}
+	out := make([]TerminalShareResponse, len(shares))
+	for i, sh := range shares {
+		out[i] = toTerminalShareResponse(sh)
+	}
+	return out, nil
+}
+
+// RevokeTerminalShare stops a share link from working. The caller must
+// manage the instance; viewers already watching are the proxy's to drop.
+func (s *InstanceService) RevokeTerminalShare(ctx context.Context, id, userID, shareID int) error {
+	inst, err := authorizeInstance(ctx, s.db, id, userID, AccessManage)
+	if err != nil {
+		return err
+	}
+	n, err := s.db.TerminalShare.Update().
+		Where(
+			entterminalshare.IDEQ(shareID),
+			entterminalshare.InstanceIDEQ(id),
+			entterminalshare.RevokedAtIsNil(),
+		).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("revoke terminal share: %w", err)
+	}
+	if n == 0 {
+		exists, err := s.db.TerminalShare.Query().
+			Where(entterminalshare.IDEQ(shareID), entterminalshare.InstanceIDEQ(id)).
+			Exist(ctx)
+		if err != nil {
+			return fmt.Errorf("query terminal share: %w", err)
+		}
+		if !exists {
+			return ErrTerminalShareNotFound
+		}
+		return nil // already revoked
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Action:     "terminal_share.revoke",
+		TargetType: "terminal_share",
+		TargetID:   strconv.Itoa(shareID),
+		SubjectID:  inst.Edges.Owner.ID,
+		Details:    map[string]any{"instance_id": id},
+	})
+	return nil
+}
+
+// ResolveTerminalShare returns what a presented share token grants. It
+// returns ErrShareRejected for unknown, expired and revoked tokens, and
+// provider.ErrNotFound if the instance is not running.
+func (s *InstanceService) ResolveTerminalShare(ctx context.Context, token string) (*TerminalShareGrant, error) {
+	if !strings.HasPrefix(token, auth.ShareTokenPrefix) {
+		return nil, ErrShareRejected
+	}
+	share, err := s.db.TerminalShare.Query().
+		Where(entterminalshare.TokenHashEQ(auth.HashAccessToken(token))).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrShareRejected
+		}
+		return nil, fmt.Errorf("query terminal share: %w", err)
+	}
+	if share.RevokedAt != nil || !time.Now().Before(share.ExpiresAt) {
+		return nil, ErrShareRejected
+	}
+	inst, err := s.db.Instance.Query().
+		Where(entinstance.IDEQ(share.InstanceID), entinstance.StatusEQ("running")).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, provider.ErrNotFound
+		}
+		return nil, fmt.Errorf("get instance: %w", err)
+	}
+	return &TerminalShareGrant{
+		ShareID:    share.ID,
+		InstanceID: inst.ID,
+		Label:      share.Label,
+		ExpiresAt:  share.ExpiresAt,
+		Host:       inst.Host,
+	}, nil
+}
+
+// TerminalWatched records that someone holding a share link started
+// watching the instance's terminal.
+func (s *InstanceService) TerminalWatched(ctx context.Context, grant *TerminalShareGrant) {
+	if s.audit == nil {
+		return
+	}
+	ownerID, err := s.db.Instance.Query().
+		Where(entinstance.IDEQ(grant.InstanceID)).
+		QueryOwner().
+		OnlyID(ctx)
+	if err != nil {
+		slog.Error("failed to look up instance owner for audit", "instance_id", grant.InstanceID, "error", err)
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Actor:      ActorShare,
+		Action:     "instance.terminal",
+		TargetType: "instance",
+		TargetID:   strconv.Itoa(grant.InstanceID),
+		SubjectID:  ownerID,
+		Details:    map[string]any{"read_only": true, "share_id": grant.ShareID, "label": grant.Label},
+	})
+}
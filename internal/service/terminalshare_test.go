@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/auth"
+	entauditevent "github.com/logan/cloudcode/internal/ent/auditevent"
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+func TestInstanceService_TerminalShares(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_terminal_share?mode=memory&_fk=1")
+	defer client.Close()
+	svc := NewInstanceService(client, provider.NewMock(), "")
+	svc.SetAuditService(NewAuditService(client))
+	ctx := context.Background()
+
+	userID := createTestUser(t, client)
+	inst := client.Instance.Create().
+		SetProvider("mock").
+		SetProviderID("mock-1").
+		SetHost("10.0.0.5").
+		SetStatus("running").
+		SetOwnerID(userID).
+		SaveX(ctx)
+
+	for _, ttl := range []time.Duration{time.Second, 25 * time.Hour} {
+		if _, err := svc.CreateTerminalShare(ctx, inst.ID, userID, "", ttl); !errors.Is(err, ErrInvalidTerminalShare) {
+			t.Errorf("ttl %s: %v", ttl, err)
+		}
+	}
+	stranger := client.User.Create().SetEmail("stranger@example.com").SaveX(ctx)
+	if _, err := svc.CreateTerminalShare(ctx, inst.ID, stranger.ID, "", 0); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("stranger created a share: %v", err)
+	}
+
+	share, err := svc.CreateTerminalShare(ctx, inst.ID, userID, " pairing ", 0)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !strings.HasPrefix(share.Token, auth.ShareTokenPrefix) || !strings.HasPrefix(share.Token, share.Prefix) ||
+		share.Label != "pairing" || share.CreatedBy != userID {
+		t.Errorf("share = %+v", share)
+	}
+	if d := time.Until(share.ExpiresAt); d < 59*time.Minute || d > DefaultTerminalShareTTL {
+		t.Errorf("expires in %s", d)
+	}
+
+	grant, err := svc.ResolveTerminalShare(ctx, share.Token)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if grant.ShareID != share.ID || grant.InstanceID != inst.ID || grant.Host != "10.0.0.5" || grant.Label != "pairing" {
+		t.Errorf("grant = %+v", grant)
+	}
+	svc.TerminalWatched(ctx, grant)
+	ev := client.AuditEvent.Query().Where(entauditevent.ActionEQ("instance.terminal")).OnlyX(ctx)
+	if ev.Actor != ActorShare || ev.SubjectID == nil || *ev.SubjectID != userID {
+		t.Errorf("watch event = %+v", ev)
+	}
+	for _, token := range []string{"", "ccs_unknown", auth.AccessTokenPrefix + share.Token[len(auth.ShareTokenPrefix):]} {
+		if _, err := svc.ResolveTerminalShare(ctx, token); !errors.Is(err, ErrShareRejected) {
+			t.Errorf("resolve %q: %v", token, err)
+		}
+	}
+
+	// Links only reach running instances
+	client.Instance.UpdateOneID(inst.ID).SetStatus("stopped").ExecX(ctx)
+	if _, err := svc.ResolveTerminalShare(ctx, share.Token); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("resolve while stopped: %v", err)
+	}
+	client.Instance.UpdateOneID(inst.ID).SetStatus("running").ExecX(ctx)
+
+	if err := svc.RevokeTerminalShare(ctx, inst.ID, userID, share.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if err := svc.RevokeTerminalShare(ctx, inst.ID, userID, share.ID); err != nil {
+		t.Errorf("revoke again: %v", err)
+	}
+	if err := svc.RevokeTerminalShare(ctx, inst.ID, userID, 9999); !errors.Is(err, ErrTerminalShareNotFound) {
+		t.Errorf("revoke unknown: %v", err)
+	}
+	if _, err := svc.ResolveTerminalShare(ctx, share.Token); !errors.Is(err, ErrShareRejected) {
+		t.Errorf("resolve revoked: %v", err)
+	}
+
+	expired, _ := svc.CreateTerminalShare(ctx, inst.ID, userID, "", time.Minute)
+	client.TerminalShare.UpdateOneID(expired.ID).SetExpiresAt(time.Now().Add(-time.Second)).ExecX(ctx)
+	if _, err := svc.ResolveTerminalShare(ctx, expired.Token); !errors.Is(err, ErrShareRejected) {
+		t.Errorf("resolve expired: %v", err)
+	}
+
+	list, err := svc.ListTerminalShares(ctx, inst.ID, userID)
+	if err != nil || len(list) != 2 || list[0].ID != expired.ID || list[1].RevokedAt == nil {
+		t.Errorf("list = %+v, %v", list, err)
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/logan/cloudcode/internal/auth"
+	"github.com/logan/cloudcode/internal/ent"
+	entaccesstoken "github.com/logan/cloudcode/internal/ent/accesstoken"
+	entuser "github.com/logan/cloudcode/internal/ent/user"
+)
+
+var (
+	// ErrTokenNotFound indicates the access token does not exist or belongs to
+	// another user.
+	ErrTokenNotFound = errors.New("access token not found")
+	// ErrInvalidTokenSpec wraps a description of what is wrong with a token
+	// request.
+	ErrInvalidTokenSpec = errors.New("invalid access token")
+	// ErrTokenRejected indicates a presented token is unknown or expired.
+	ErrTokenRejected = errors.New("invalid or expired access token")
+)
+
+const (
+	// maxTokensPerUser bounds how many access tokens a user can hold.
+	maxTokensPerUser = 50
+	// tokenUseGranularity limits last_used_at writes to one per token per
+	// interval, so a busy CI job does not write on every request.
+	tokenUseGranularity = time.Minute
+)
+
+// TokenService manages personal access tokens, which let scripts and the CLI
+// call the API without a browser session.
+type TokenService struct {
+	db    *ent.Client
+	audit *AuditService // nil records nothing
+}
+
+// NewTokenService creates a new TokenService.
+func NewTokenService(db *ent.Client) *TokenService {
+	return &TokenService{db: db}
+}
+
+// SetAuditService wires in the audit log for token creation and revocation.
+func (s *TokenService) SetAuditService(a *AuditService) {
+	s.audit = a
+}
+
+// TokenSpec describes a token to create. ExpiresAt is optional.
+type TokenSpec struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// TokenResponse is the API response for a token. It never carries the token.
+type TokenResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedTokenResponse is returned once, on creation, with the token itself.
+type CreatedTokenResponse struct {
+	TokenResponse
+	Token string `json:"token"`
+}
+
+func toTokenResponse(t *ent.AccessToken) TokenResponse {
+	return TokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Prefix:     t.TokenPrefix,
+		Scopes:     t.Scopes,
+		LastUsedAt: t.LastUsedAt,
+		ExpiresAt:  t.ExpiresAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+// List returns the user's tokens, newest first.
+func (s *TokenService) List(ctx context.Context, userID int) ([]TokenResponse, error) {
+	rows, err := s.db.AccessToken.Query().
+		Where(entaccesstoken.HasOwnerWith(entuser.IDEQ(userID))).
+		Order(ent.Desc(entaccesstoken.FieldCreatedAt), ent.Desc(entaccesstoken.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list tokens: %w", err)
+	}
+	out := make([]TokenResponse, len(rows))
+	for i, t := range rows {
+		out[i] = toTokenResponse(t)
+	}
+	return out, nil
+}
+
+// Create mints a token for the user. The returned token is not stored and
+// cannot be shown again.
+func (s *TokenService) Create(ctx context.Context, userID int, spec TokenSpec) (*CreatedTokenResponse, error) {
+	spec.Name = strings.TrimSpace(spec.Name)
+	if spec.Name == "" || len(spec.Name) > 64 {
+		return nil, fmt.Errorf("%w: name must be 1 to 64 characters", ErrInvalidTokenSpec)
+	}
+	if len(spec.Scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrInvalidTokenSpec)
+	}
+	for _, scope := range spec.Scopes {
+		if !slices.Contains(auth.Scopes, scope) {
+			return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidTokenSpec, scope)
+		}
+	}
+	if spec.ExpiresAt != nil && !spec.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expires_at is in the past", ErrInvalidTokenSpec)
+	}
+
+	owned := entaccesstoken.HasOwnerWith(entuser.IDEQ(userID))
+	count, err := s.db.AccessToken.Query().Where(owned).Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("count tokens: %w", err)
+	}
+	if count >= maxTokensPerUser {
+		return nil, fmt.Errorf("%w: limit of %d tokens reached", ErrInvalidTokenSpec, maxTokensPerUser)
+	}
+
+	token, hash, err := auth.GenerateAccessToken()
+	if err != nil {
+		return nil, err
+	}
+	t, err := s.db.AccessToken.Create().
+		SetName(spec.Name).
+		SetTokenHash(hash).
+		SetTokenPrefix(token[:len(auth.AccessTokenPrefix)+6]).
+		SetScopes(slices.Compact(slices.Sorted(slices.Values(spec.Scopes)))).
+		SetNillableExpiresAt(spec.ExpiresAt).
+		SetOwnerID(userID).
+		Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, fmt.Errorf("%w: a token named %q already exists", ErrInvalidTokenSpec, spec.Name)
+		}
+		return nil, fmt.Errorf("save token: %w", err)
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Action:     "token.create",
+		TargetType: "access_token",
+		TargetID:   strconv.Itoa(t.ID),
+		SubjectID:  userID,
+		Details:    map[string]any{"name": t.Name, "scopes": t.Scopes},
+	})
+	return &CreatedTokenResponse{TokenResponse: toTokenResponse(t), Token: token}, nil
+}
+
+// Revoke deletes one of the user's tokens; it stops working immediately.
+func (s *TokenService) Revoke(ctx context.Context, userID, id int) error {
+	n, err := s.db.AccessToken.Delete().
+		Where(entaccesstoken.IDEQ(id), entaccesstoken.HasOwnerWith(entuser.IDEQ(userID))).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("delete token: %w", err)
+	}
+	if n == 0 {
+		return ErrTokenNotFound
+	}
+	s.audit.Log(ctx, AuditEntry{
+		Action:     "token.revoke",
+		TargetType: "access_token",
+		TargetID:   strconv.Itoa(id),
+		SubjectID:  userID,
+	})
+	return nil
+}
+
+// AuthenticateToken resolves a presented token to its owner and scopes, and
+// records that it was used. Returns ErrTokenRejected for unknown or expired
+// tokens.
+func (s *TokenService) AuthenticateToken(ctx context.Context, token string) (int, string, []string, error) {
+	t, err := s.db.AccessToken.Query().
+		Where(entaccesstoken.TokenHashEQ(auth.HashAccessToken(token))).
+		WithOwner().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, "", nil, ErrTokenRejected
+		}
+		return 0, "", nil, fmt.Errorf("query token: %w", err)
+	}
+	now := time.Now()
+	if t.ExpiresAt != nil && !now.Before(*t.ExpiresAt) {
+		return 0, "", nil, ErrTokenRejected
+	}
+
+	if t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) >= tokenUseGranularity {
+		if err := s.db.AccessToken.UpdateOne(t).SetLastUsedAt(now).Exec(ctx); err != nil {
+			return 0, "", nil, fmt.Errorf("record token use: %w", err)
+		}
+	}
+	return t.Edges.Owner.ID, t.Edges.Owner.Email, t.Scopes, nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/auth"
+	"github.com/logan/cloudcode/internal/ent/enttest"
+)
+
+func TestTokenService_Lifecycle(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_token_lifecycle?mode=memory&_fk=1")
+	defer client.Close()
+	svc := NewTokenService(client)
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+	bob := client.User.Create().SetEmail("bob@example.com").SaveX(ctx).ID
+
+	past := time.Now().Add(-time.Minute)
+	for name, spec := range map[string]TokenSpec{
+		"no name":     {Scopes: []string{"instances:read"}},
+		"no scopes":   {Name: "ci"},
+		"bad scope":   {Name: "ci", Scopes: []string{"admin"}},
+		"in the past": {Name: "ci", Scopes: []string{"instances:read"}, ExpiresAt: &past},
+	} {
+		if _, err := svc.Create(ctx, userID, spec); !errors.Is(err, ErrInvalidTokenSpec) {
+			t.Errorf("%s: got %v, want ErrInvalidTokenSpec", name, err)
+		}
+	}
+
+	created, err := svc.Create(ctx, userID, TokenSpec{Name: "ci", Scopes: []string{"instances:write", "instances:read"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !auth.IsAccessToken(created.Token) || created.Prefix != created.Token[:len(created.Prefix)] {
+		t.Errorf("token %q, prefix %q", created.Token, created.Prefix)
+	}
+	if _, err := svc.Create(ctx, userID, TokenSpec{Name: "ci", Scopes: []string{"instances:read"}}); !errors.Is(err, ErrInvalidTokenSpec) {
+		t.Errorf("duplicate name: got %v, want ErrInvalidTokenSpec", err)
+	}
+	if row := client.AccessToken.GetX(ctx, created.ID); row.TokenHash == created.Token {
+		t.Error("token stored in plaintext")
+	}
+
+	id, email, scopes, err := svc.AuthenticateToken(ctx, created.Token)
+	if err != nil || id != userID || email != "test@example.com" || len(scopes) != 2 {
+		t.Fatalf("authenticate = %d, %q, %v, %v", id, email, scopes, err)
+	}
+	list, err := svc.List(ctx, userID)
+	if err != nil || len(list) != 1 || list[0].LastUsedAt == nil {
+		t.Errorf("list = %+v, %v; want last_used_at set", list, err)
+	}
+	if _, _, _, err := svc.AuthenticateToken(ctx, created.Token+"x"); !errors.Is(err, ErrTokenRejected) {
+		t.Errorf("wrong token: got %v, want ErrTokenRejected", err)
+	}
+
+	if err := svc.Revoke(ctx, bob, created.ID); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("bob revoke: got %v, want ErrTokenNotFound", err)
+	}
+	if err := svc.Revoke(ctx, userID, created.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if _, _, _, err := svc.AuthenticateToken(ctx, created.Token); !errors.Is(err, ErrTokenRejected) {
+		t.Errorf("revoked token: got %v, want ErrTokenRejected", err)
+	}
+}
+
+func TestTokenService_Expiry(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_token_expiry?mode=memory&_fk=1")
+	defer client.Close()
+	svc := NewTokenService(client)
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	soon := time.Now().Add(time.Hour)
+	created, err := svc.Create(ctx, userID, TokenSpec{Name: "short", Scopes: []string{"account:read"}, ExpiresAt: &soon})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, _, _, err := svc.AuthenticateToken(ctx, created.Token); err != nil {
+		t.Fatalf("authenticate before expiry: %v", err)
+	}
+	client.AccessToken.UpdateOneID(created.ID).SetExpiresAt(time.Now().Add(-time.Second)).ExecX(ctx)
+	if _, _, _, err := svc.AuthenticateToken(ctx, created.Token); !errors.Is(err, ErrTokenRejected) {
+		t.Errorf("expired token: got %v, want ErrTokenRejected", err)
+	}
+}
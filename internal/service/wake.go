@@ -0,0 +1,169 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelTrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/logan/cloudcode/internal/ent"
+	entjob "github.com/logan/cloudcode/internal/ent/job"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+// DefaultWakeTimeout bounds how long connecting to a stopped instance waits
+// for it to wake and its agent to answer.
+const DefaultWakeTimeout = 3 * time.Minute
+
+var (
+	// ErrWakeTimeout indicates an instance woken on connect was not ready in time.
+	ErrWakeTimeout = errors.New("instance did not wake in time")
+	// ErrWakeFailed indicates the wake job of an instance woken on connect failed.
+	ErrWakeFailed = errors.New("instance failed to wake")
+)
+
+// WakeState is how far an instance woken on connect has come.
+type WakeState struct {
+	Ready bool   // the agent answers, so the caller can connect
+	Step  string // what is under way otherwise, such as "queued" or "waking"
+}
+
+// SetWakeTimeout sets how long connecting to a stopped instance waits for
+// it; DefaultWakeTimeout if never called.
+func (s *JobService) SetWakeTimeout(d time.Duration) {
+	s.wakeTimeout = d
+}
+
+// PollWake takes a stopped instance one step towards being ready for the
+// user connecting to it, without waiting: it queues a wake unless one is
+// in flight, and once the instance runs again checks that its agent
+// answers. Waking needs write access. It returns ErrWakeFailed if a wake
+// failed within the wake timeout, so callers that poll do not keep
+// retrying, and ErrWakeTimeout if the current one has taken longer.
+func (s *JobService) PollWake(ctx context.Context, id int, userID int) (*WakeState, error) {
+	inst, err := authorizeInstance(ctx, s.db, id, userID, AccessWrite)
+	if err != nil {
+		return nil, err
+	}
+	// A wake that failed because the instance was no longer stopped, such
+	// as one queued alongside another, never touched it
+	last, err := s.db.Job.Query().
+		Where(
+			entjob.InstanceIDEQ(id),
+			entjob.TypeEQ(JobWake),
+			entjob.Or(
+				entjob.StatusNEQ(JobFailed),
+				entjob.ErrorCodeIsNil(),
+				entjob.ErrorCodeNEQ(JobErrorInvalidState),
+			),
+		).
+		Order(ent.Desc(entjob.FieldCreatedAt), ent.Desc(entjob.FieldID)).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, fmt.Errorf("query wake job: %w", err)
+	}
+	recent := func(t *time.Time) bool {
+		return t != nil && time.Since(*t) < s.wakeTimeout
+	}
+
+	switch inst.Status {
+	case "stopped":
+		if last != nil && (last.Status == JobPending || last.Status == JobRunning) {
+			if time.Since(last.CreatedAt) > s.wakeTimeout {
+				return nil, ErrWakeTimeout
+			}
+			return &WakeState{Step: cmp.Or(last.Progress, "queued")}, nil
+		}
+		if last != nil && last.Status == JobFailed && recent(last.FinishedAt) {
+			return nil, fmt.Errorf("%w: %s", ErrWakeFailed, last.Error)
+		}
+		if _, err := s.EnqueueInstanceOp(ctx, JobWake, id); err != nil {
+			// Another wake may have finished since the instance was read
+			if errors.Is(err, provider.ErrInvalidState) && s.isRunning(ctx, id) {
+				return &WakeState{Step: "starting agent"}, nil
+			}
+			return nil, err
+		}
+		return &WakeState{Step: "queued"}, nil
+	case "running":
+		// Only an instance that has just woken may still be starting its
+		// agent; waiting on any other would hold up connecting to it
+		if last == nil || !recent(last.FinishedAt) || s.agentHealth(ctx, inst.Host) == nil {
+			return &WakeState{Ready: true}, nil
+		}
+		return &WakeState{Step: "starting agent"}, nil
+	default:
+		return nil, provider.ErrInvalidState
+	}
+}
+
+// isRunning reports whether instance id is running.
+func (s *JobService) isRunning(ctx context.Context, id int) bool {
+	inst, err := s.db.Instance.Get(ctx, id)
+	return err == nil && inst.Status == "running"
+}
+
+// WakeForConnect wakes a stopped instance for the user connecting to it and
+// waits until its agent answers, or for the wake timeout. Each step is
+// reported through ReportProgress as it begins.
+func (s *JobService) WakeForConnect(ctx context.Context, id int, userID int) error {
+	ctx, span := jobTracer.Start(ctx, "job.wake_for_connect",
+		otelTrace.WithAttributes(attribute.Int("instance_id", id)))
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.wakeTimeout)
+	defer cancel()
+
+	step := ""
+	for {
+		state, err := s.PollWake(ctx, id, userID)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return ErrWakeTimeout
+		}
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		if state.Ready {
+			return nil
+		}
+		if state.Step != step {
+			step = state.Step
+			ReportProgress(ctx, step)
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return ErrWakeTimeout
+			}
+			return ctx.Err()
+		case <-time.After(s.wakePoll):
+		}
+	}
+}
+
+// checkAgentHealth reports whether the agent on host answers its health
+// check.
+func checkAgentHealth(ctx context.Context, host string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+host+":3001/health", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("agent health: status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+func TestJobService_WakeForConnect(t *testing.T) {
+	jobs, client := setupJobTest(t, provider.NewMock())
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+
+	inst, err := jobs.instances.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := jobs.instances.Pause(ctx, inst.ID); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+
+	// The agent takes a couple of checks to come up
+	checks := 0
+	jobs.agentHealth = func(ctx context.Context, host string) error {
+		if checks++; checks < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	jobs.wakePoll = 10 * time.Millisecond
+
+	// Strangers cannot wake it
+	if err := jobs.WakeForConnect(ctx, inst.ID, userID+1); !errors.Is(err, provider.ErrNotFound) {
+		t.Fatalf("stranger: got %v, want ErrNotFound", err)
+	}
+
+	var steps []string
+	wctx := WithProgress(ctx, func(step string) {
+		steps = append(steps, step)
+		if step == "queued" {
+			jobs.RunNext(ctx) // stands in for the workers
+		}
+	})
+	if err := jobs.WakeForConnect(wctx, inst.ID, userID); err != nil {
+		t.Fatalf("wake for connect: %v", err)
+	}
+	if len(steps) < 2 || steps[0] != "queued" || steps[len(steps)-1] != "starting agent" {
+		t.Errorf("steps = %v", steps)
+	}
+	updated, _ := client.Instance.Get(ctx, inst.ID)
+	if updated.Status != "running" {
+		t.Errorf("instance status = %q, want running", updated.Status)
+	}
+	if checks != 3 {
+		t.Errorf("agent checked %d times, want 3", checks)
+	}
+
+	// A running instance is ready at once
+	if err := jobs.WakeForConnect(ctx, inst.ID, userID); err != nil {
+		t.Errorf("running instance: %v", err)
+	}
+}
+
+func TestJobService_PollWake(t *testing.T) {
+	jobs, client := setupJobTest(t, provider.NewMock())
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+	jobs.agentHealth = func(ctx context.Context, host string) error {
+		return errors.New("connection refused")
+	}
+
+	inst, err := jobs.instances.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Running and not recently woken: no need to wait on the agent
+	if state, err := jobs.PollWake(ctx, inst.ID, userID); err != nil || !state.Ready {
+		t.Fatalf("running: got %+v, %v", state, err)
+	}
+
+	if err := jobs.instances.Pause(ctx, inst.ID); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	for range 2 {
+		state, err := jobs.PollWake(ctx, inst.ID, userID)
+		if err != nil || state.Ready || state.Step != "queued" {
+			t.Fatalf("stopped: got %+v, %v", state, err)
+		}
+	}
+	if n := client.Job.Query().CountX(ctx); n != 1 {
+		t.Errorf("queued %d wake jobs, want 1", n)
+	}
+
+	jobs.RunNext(ctx)
+	if state, err := jobs.PollWake(ctx, inst.ID, userID); err != nil || state.Ready || state.Step != "starting agent" {
+		t.Errorf("just woken: got %+v, %v", state, err)
+	}
+
+	// A failed wake is reported rather than retried
+	if err := jobs.instances.Pause(ctx, inst.ID); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	client.Job.Create().
+		SetType(JobWake).
+		SetInstanceID(inst.ID).
+		SetOwnerID(userID).
+		SetStatus(JobFailed).
+		SetError("provider wake: boom").
+		SetFinishedAt(time.Now()).
+		SaveX(ctx)
+	if _, err := jobs.PollWake(ctx, inst.ID, userID); !errors.Is(err, ErrWakeFailed) {
+		t.Errorf("failed wake: got %v, want ErrWakeFailed", err)
+	}
+}
+
+func TestJobService_PollWakeIgnoresDuplicateWakes(t *testing.T) {
+	jobs, client := setupJobTest(t, provider.NewMock())
+	ctx := context.Background()
+	userID := createTestUser(t, client)
+	jobs.agentHealth = func(ctx context.Context, host string) error {
+		return errors.New("connection refused")
+	}
+
+	inst, err := jobs.instances.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	// A wake queued alongside the one that woke the instance fails once it
+	// finds the instance running
+	j := client.Job.Create().
+		SetType(JobWake).
+		SetInstanceID(inst.ID).
+		SetOwnerID(userID).
+		SaveX(ctx)
+	jobs.RunNext(ctx)
+	if j = client.Job.GetX(ctx, j.ID); j.Status != JobFailed || j.ErrorCode != JobErrorInvalidState {
+		t.Fatalf("duplicate wake: status %q, error code %q", j.Status, j.ErrorCode)
+	}
+
+	// It does not hold up connecting to the running instance...
+	if state, err := jobs.PollWake(ctx, inst.ID, userID); err != nil || !state.Ready {
+		t.Fatalf("running: got %+v, %v", state, err)
+	}
+
+	// ...nor count as a failed wake once it is stopped again
+	if err := jobs.instances.Pause(ctx, inst.ID); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if state, err := jobs.PollWake(ctx, inst.ID, userID); err != nil || state.Step != "queued" {
+		t.Fatalf("stopped: got %+v, %v", state, err)
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelTrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/logan/cloudcode/internal/ent"
+	entwarm "github.com/logan/cloudcode/internal/ent/warminstance"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+var warmPoolTracer = otel.Tracer("cloudcode/service/warmpool")
+
+// WarmPoolService keeps a pool of pre-booted instances that belong to no
+// user, per class, so InstanceService.Create can skip the provider's cold
+// start. Warm instances are tracked in the warm_instances table; claiming
+// one deletes its row and wakes the loop to boot a replacement.
+type WarmPoolService struct {
+	db       *ent.Client
+	prov     provider.Provisioner
+	pooler   provider.Pooler
+	logger   *slog.Logger
+	catalog  []provider.Class
+	sizes    map[string]int // class name → warm instances to keep
+	interval time.Duration
+	refillMu sync.Mutex // one refill at a time, so the pool is not overfilled
+	kick     chan struct{}
+	stopCh   chan struct{}
+}
+
+// NewWarmPoolService creates a new WarmPoolService. It returns
+// provider.ErrNotSupported if prov cannot boot warm instances.
+func NewWarmPoolService(
+	db *ent.Client,
+	prov provider.Provisioner,
+	logger *slog.Logger,
+	catalog []provider.Class,
+	sizes map[string]int,
+	interval time.Duration,
+) (*WarmPoolService, error) {
+	pooler, ok := prov.(provider.Pooler)
+	if !ok {
+		return nil, fmt.Errorf("warm pool: %w", provider.ErrNotSupported)
+	}
+	return &WarmPoolService{
+		db:       db,
+		prov:     prov,
+		pooler:   pooler,
+		logger:   logger,
+		catalog:  catalog,
+		sizes:    sizes,
+		interval: interval,
+		kick:     make(chan struct{}, 1),
+		stopCh:   make(chan struct{}),
+	}, nil
+}
+
+// ParseWarmPool parses a comma-separated list of class=count pairs, e.g.
+// "small=2,medium=1". Every class must be in catalog.
+func ParseWarmPool(s string, catalog []provider.Class) (map[string]int, error) {
+	sizes := make(map[string]int)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		class, count, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid warm pool entry %q: want class=count", entry)
+		}
+		class = strings.TrimSpace(class)
+		if _, ok := provider.FindClass(catalog, class); !ok {
+			return nil, fmt.Errorf("warm pool class %q: %w", class, ErrUnknownClass)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid count for class %q: %q", class, count)
+		}
+		sizes[class] = n
+	}
+	return sizes, nil
+}
+
+// Start begins the refill loop in a goroutine. The pool is filled right away
+// and then every interval, or as soon as an instance is claimed.
+func (s *WarmPoolService) Start() {
+	go s.run()
+	s.logger.Info("warm pool started", "sizes", s.sizes, "interval", s.interval)
+}
+
+// Stop signals the refill loop to stop. Warm instances keep running and are
+// picked up again on the next start.
+func (s *WarmPoolService) Stop() {
+	close(s.stopCh)
+	s.logger.Info("warm pool stopped")
+}
+
+func (s *WarmPoolService) run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		// Booting can take minutes per instance; bound a whole pass
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		if n, err := s.Refill(ctx); err != nil {
+			s.logger.Error("warm pool refill failed", "error", err)
+		} else if n > 0 {
+			s.logger.Info("warm pool refilled", "created", n)
+		}
+		cancel()
+
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+		case <-s.kick:
+		}
+	}
+}
+
+// Refill boots warm instances until each class has its configured count and
+// destroys the oldest surplus ones, e.g. after the pool was shrunk. It
+// returns how many instances were booted.
+func (s *WarmPoolService) Refill(ctx context.Context) (int, error) {
+	s.refillMu.Lock()
+	defer s.refillMu.Unlock()
+
+	var created int
+	for _, class := range s.catalog {
+		want := s.sizes[class.Name]
+		have, err := s.db.WarmInstance.Query().
+			Where(entwarm.ClassEQ(class.Name)).
+			Count(ctx)
+		if err != nil {
+			return created, fmt.Errorf("count warm instances: %w", err)
+		}
+
+		for ; have > want; have-- {
+			w, err := s.take(ctx, class.Name)
+			if err != nil || w == nil {
+				return created, err
+			}
+			if err := s.prov.Destroy(ctx, w.ProviderID); err != nil {
+				return created, fmt.Errorf("destroy surplus warm instance %s: %w", w.ProviderID, err)
+			}
+		}
+
+		for ; have < want; have++ {
+			inst, err := s.pooler.CreateWarm(ctx, class)
+			if err != nil {
+				return created, fmt.Errorf("create warm %s instance: %w", class.Name, err)
+			}
+			_, err = s.db.WarmInstance.Create().
+				SetProvider(inst.Provider).
+				SetProviderID(inst.ProviderID).
+				SetClass(class.Name).
+				SetHost(inst.Host).
+				SetVolumeID(inst.VolumeID).
+				Save(ctx)
+			if err != nil {
+				// Best-effort cleanup; an untracked warm instance is never claimed
+				_ = s.prov.Destroy(ctx, inst.ProviderID)
+				return created, fmt.Errorf("save warm instance: %w", err)
+			}
+			created++
+		}
+	}
+	return created, nil
+}
+
+// Claim binds a warm instance of class to the user with opts and returns it.
+// It returns nil without an error when the pool has no instance of that
+// class. A warm instance that fails to bind is destroyed.
+func (s *WarmPoolService) Claim(ctx context.Context, userID int, class provider.Class, opts provider.CreateOptions) (*provider.Instance, error) {
+	ctx, span := warmPoolTracer.Start(ctx, "warmpool.claim",
+		otelTrace.WithAttributes(attribute.Int("user_id", userID), attribute.String("class", class.Name)))
+	defer span.End()
+
+	w, err := s.take(ctx, class.Name)
+	if err != nil || w == nil {
+		return nil, err
+	}
+	s.replenish()
+	span.SetAttributes(attribute.String("warm_id", w.ProviderID))
+
+	inst, err := s.pooler.Claim(ctx, w.ProviderID, userID, opts)
+	if err != nil {
+		span.RecordError(err)
+		if derr := s.prov.Destroy(ctx, w.ProviderID); derr != nil {
+			s.logger.Error("failed to destroy unclaimable warm instance", "provider_id", w.ProviderID, "error", derr)
+		}
+		return nil, fmt.Errorf("claim warm instance %s: %w", w.ProviderID, err)
+	}
+	return inst, nil
+}
+
+// take removes and returns the oldest warm instance of class, or nil if
+// there is none. Deleting the row is the claim, so two callers never get the
+// same instance.
+func (s *WarmPoolService) take(ctx context.Context, class string) (*ent.WarmInstance, error) {
+	var skipped []int
+	for {
+		w, err := s.db.WarmInstance.Query().
+			Where(entwarm.ClassEQ(class), entwarm.IDNotIn(skipped...)).
+			Order(ent.Asc(entwarm.FieldCreatedAt), ent.Asc(entwarm.FieldID)).
+			First(ctx)
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("query warm instance: %w", err)
+		}
+		n, err := s.db.WarmInstance.Delete().
+			Where(entwarm.IDEQ(w.ID)).
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("take warm instance: %w", err)
+		}
+		if n == 1 {
+			return w, nil
+		}
+		// Taken by a concurrent claim between the query and the delete
+		skipped = append(skipped, w.ID)
+	}
+}
+
+// replenish wakes the refill loop without blocking.
+func (s *WarmPoolService) replenish() {
+	select {
+	case s.kick <- struct{}{}:
+	default:
+	}
+}
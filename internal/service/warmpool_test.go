@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/ent/enttest"
+	"github.com/logan/cloudcode/internal/provider"
+)
+
+// failingClaimer rejects every claim, like a warm server that stopped
+// answering SSH.
+type failingClaimer struct {
+	*provider.MockProvisioner
+}
+
+func (failingClaimer) Claim(ctx context.Context, warmID string, userID int, opts provider.CreateOptions) (*provider.Instance, error) {
+	return nil, errors.New("bind failed")
+}
+
+func TestWarmPool_RefillAndClaim(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_warmpool?mode=memory&_fk=1")
+	defer client.Close()
+	mock := provider.NewMock()
+	ctx := context.Background()
+
+	pool, err := NewWarmPoolService(client, mock, slog.Default(), provider.DefaultClasses,
+		map[string]int{"small": 2, "medium": 1}, time.Hour)
+	if err != nil {
+		t.Fatalf("new pool: %v", err)
+	}
+	svc := NewInstanceService(client, mock, "platform-key")
+	svc.SetClasses(provider.DefaultClasses, nil)
+	svc.SetWarmPool(pool)
+
+	if n, err := pool.Refill(ctx); err != nil || n != 3 {
+		t.Fatalf("refill: created %d, err %v; want 3", n, err)
+	}
+	if n, _ := pool.Refill(ctx); n != 0 {
+		t.Errorf("second refill created %d, want 0", n)
+	}
+
+	userID := createTestUser(t, client)
+	inst, err := svc.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if inst.ProviderID != "mock-1" {
+		t.Errorf("claimed provider ID = %q, want mock-1", inst.ProviderID)
+	}
+	opts := mock.Options(inst.ProviderID)
+	if opts.AgentSecret == "" || opts.AnthropicAPIKey != "platform-key" {
+		t.Errorf("claim did not inject credentials: %+v", opts)
+	}
+	if got := client.WarmInstance.Query().CountX(ctx); got != 2 {
+		t.Errorf("warm instances after claim = %d, want 2", got)
+	}
+
+	// The claim kicks the loop; the next pass replaces the instance
+	select {
+	case <-pool.kick:
+	default:
+		t.Error("claim did not request a refill")
+	}
+	if n, _ := pool.Refill(ctx); n != 1 {
+		t.Errorf("refill after claim created %d, want 1", n)
+	}
+
+	// Shrinking the pool destroys the surplus
+	pool.sizes = map[string]int{"small": 1}
+	if _, err := pool.Refill(ctx); err != nil {
+		t.Fatalf("shrink: %v", err)
+	}
+	if got := client.WarmInstance.Query().CountX(ctx); got != 1 {
+		t.Errorf("warm instances after shrink = %d, want 1", got)
+	}
+}
+
+func TestWarmPool_FallsBackToColdStart(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent_warmpool_fallback?mode=memory&_fk=1")
+	defer client.Close()
+	mock := provider.NewMock()
+	ctx := context.Background()
+
+	prov := failingClaimer{mock}
+	pool, err := NewWarmPoolService(client, prov, slog.Default(), provider.DefaultClasses,
+		map[string]int{"small": 1}, time.Hour)
+	if err != nil {
+		t.Fatalf("new pool: %v", err)
+	}
+	svc := NewInstanceService(client, prov, "")
+	svc.SetClasses(provider.DefaultClasses, map[string][]string{"free": {"small", "medium"}})
+	svc.SetPlanLimits(map[string]int{"free": 2})
+	svc.SetWarmPool(pool)
+	if _, err := pool.Refill(ctx); err != nil {
+		t.Fatalf("refill: %v", err)
+	}
+	userID := createTestUser(t, client)
+
+	// No warm medium instance: cold start
+	if _, err := svc.Create(ctx, userID, InstanceSpec{Name: "big", Class: "medium"}); err != nil {
+		t.Fatalf("create medium: %v", err)
+	}
+	if got := client.WarmInstance.Query().CountX(ctx); got != 1 {
+		t.Errorf("warm instances = %d, want the small one untouched", got)
+	}
+
+	// Failed claim: the warm instance is destroyed and Create still succeeds
+	inst, err := svc.Create(ctx, userID, InstanceSpec{})
+	if err != nil {
+		t.Fatalf("create small: %v", err)
+	}
+	if inst.ProviderID != "mock-1" {
+		t.Errorf("provider ID = %q, want mock-1", inst.ProviderID)
+	}
+	if got := client.WarmInstance.Query().CountX(ctx); got != 0 {
+		t.Errorf("warm instances = %d, want 0", got)
+	}
+	if _, err := mock.Status(ctx, "mock-warm-1"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("unclaimable warm instance not destroyed: %v", err)
+	}
+}
+
+func TestNewWarmPoolService_RequiresPooler(t *testing.T) {
+	_, err := NewWarmPoolService(nil, fixedProvisioner{provider.NewMock()}, slog.Default(), nil, nil, time.Hour)
+	if !errors.Is(err, provider.ErrNotSupported) {
+		t.Errorf("got %v, want ErrNotSupported", err)
+	}
+}
+
+func TestParseWarmPool(t *testing.T) {
+	got, err := ParseWarmPool("small=2, large = 1", provider.DefaultClasses)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got["small"] != 2 || got["large"] != 1 || len(got) != 2 {
+		t.Errorf("parsed = %v", got)
+	}
+	for _, bad := range []string{"small", "small=-1", "small=x", "huge=1"} {
+		if _, err := ParseWarmPool(bad, provider.DefaultClasses); err == nil {
+			t.Errorf("ParseWarmPool(%q): expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package sso
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"golang.org/x/oauth2"
+)
+
+// githubProvider signs in with a GitHub OAuth app. GitHub issues no ID
+// token, so the identity comes from its REST API: the numeric user ID is
+// the subject and the primary email must be verified.
+type githubProvider struct {
+	cfg     Config
+	oauth   *oauth2.Config
+	apiBase string
+}
+
+func newGitHubProvider(cfg Config) *githubProvider {
+	apiBase := "https://api.github.com"
+	if cfg.Issuer != defaultIssuers["github"] {
+		// GitHub Enterprise Server
+		apiBase = cfg.Issuer + "/api/v3"
+	}
+	return &githubProvider{
+		cfg: cfg,
+		oauth: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  cfg.Issuer + "/login/oauth/authorize",
+				TokenURL: cfg.Issuer + "/login/oauth/access_token",
+			},
+			RedirectURL: cfg.RedirectURL,
+			Scopes:      append([]string{"read:user", "user:email"}, cfg.Scopes...),
+		},
+		apiBase: apiBase,
+	}
+}
+
+func (p *githubProvider) Config() Config { return p.cfg }
+
+func (p *githubProvider) AuthCodeURL(_ context.Context, state, _, verifier string) (string, error) {
+	return p.oauth.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), nil
+}
+
+func (p *githubProvider) Exchange(ctx context.Context, code, _, verifier string) (*Identity, error) {
+	ctx = withClient(ctx)
+	tok, err := p.oauth.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange code: %w", err)
+	}
+	client := p.oauth.Client(ctx, tok)
+
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := p.get(ctx, client, "/user", &user); err != nil {
+		return nil, err
+	}
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := p.get(ctx, client, "/user/emails", &emails); err != nil {
+		return nil, err
+	}
+
+	id := &Identity{Subject: strconv.FormatInt(user.ID, 10), Name: user.Name}
+	if id.Name == "" {
+		id.Name = user.Login
+	}
+	for _, e := range emails {
+		if e.Primary {
+			id.Email, id.EmailVerified = e.Email, e.Verified
+		}
+	}
+	return id, nil
+}
+
+func (p *githubProvider) get(ctx context.Context, client *http.Client, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiBase+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("github %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("github %s: status %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("github %s: %w", path, err)
+	}
+	return nil
+}
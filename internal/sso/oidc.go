@@ -0,0 +1,135 @@
+package sso
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// httpClient is used for every request to a provider.
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// withClient makes the oauth2 and oidc packages use httpClient.
+func withClient(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	return oidc.ClientContext(ctx, httpClient)
+}
+
+// oidcProvider is a provider found through OpenID Connect discovery.
+type oidcProvider struct {
+	cfg Config
+
+	mu       sync.Mutex
+	provider *oidc.Provider // nil until discovery succeeds
+	oauth    *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+func newOIDCProvider(cfg Config) *oidcProvider {
+	return &oidcProvider{cfg: cfg}
+}
+
+func (p *oidcProvider) Config() Config { return p.cfg }
+
+// discover fetches the issuer's metadata on first use. A failure is not
+// cached, so the next login retries.
+func (p *oidcProvider) discover(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.provider != nil {
+		return nil
+	}
+
+	provider, err := oidc.NewProvider(withClient(ctx), p.cfg.Issuer)
+	if err != nil {
+		return fmt.Errorf("discover %s: %w", p.cfg.Issuer, err)
+	}
+	p.provider = provider
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  p.cfg.RedirectURL,
+		Scopes:       append([]string{oidc.ScopeOpenID, "email", "profile"}, p.cfg.Scopes...),
+	}
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.cfg.ClientID})
+	return nil
+}
+
+func (p *oidcProvider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	if err := p.discover(ctx); err != nil {
+		return "", err
+	}
+	return p.oauth.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// idClaims are the ID token and userinfo claims we use. Some providers send
+// email_verified as a string.
+type idClaims struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified any    `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+func (c idClaims) verified() bool {
+	return c.EmailVerified == true || c.EmailVerified == "true"
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code, nonce, verifier string) (*Identity, error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+	ctx = withClient(ctx)
+
+	tok, err := p.oauth.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange code: %w", err)
+	}
+	raw, ok := tok.Extra("id_token").(string)
+	if !ok || raw == "" {
+		return nil, fmt.Errorf("token response has no id_token")
+	}
+	idToken, err := p.verifier.Verify(ctx, raw)
+	if err != nil {
+		return nil, fmt.Errorf("verify id token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, fmt.Errorf("verify id token: nonce mismatch")
+	}
+	var claims idClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("decode id token claims: %w", err)
+	}
+
+	// Providers may leave the email out of the ID token; ask userinfo
+	if claims.Email == "" && p.provider.UserInfoEndpoint() != "" {
+		info, err := p.provider.UserInfo(ctx, oauth2.StaticTokenSource(tok))
+		if err != nil {
+			return nil, fmt.Errorf("fetch userinfo: %w", err)
+		}
+		var extra idClaims
+		if err := info.Claims(&extra); err != nil {
+			return nil, fmt.Errorf("decode userinfo: %w", err)
+		}
+		if extra.Subject != idToken.Subject {
+			return nil, fmt.Errorf("userinfo subject does not match id token")
+		}
+		claims.Email, claims.EmailVerified = extra.Email, extra.EmailVerified
+		if claims.Name == "" {
+			claims.Name = extra.Name
+		}
+	}
+
+	return &Identity{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.Email != "" && claims.verified(),
+		Name:          claims.Name,
+	}, nil
+}
@@ -0,0 +1,190 @@
+package sso
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/logan/cloudcode/internal/sso/ssotest"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	issuer := ssotest.NewIssuer(t)
+	p, err := New(Config{
+		Name:         "keycloak",
+		Issuer:       issuer.URL,
+		ClientID:     issuer.ClientID,
+		ClientSecret: issuer.ClientSecret,
+		RedirectURL:  "http://app.test/auth/sso/keycloak/callback",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	user := ssotest.User{Subject: "u-1", Email: "alice@example.com", EmailVerified: true, Name: "Alice"}
+
+	st, _ := NewState("keycloak", "")
+	authURL, err := p.AuthCodeURL(ctx, st.State, st.Nonce, st.Verifier)
+	if err != nil {
+		t.Fatalf("auth url: %v", err)
+	}
+	q, _ := url.Parse(authURL)
+	if scope := q.Query().Get("scope"); !strings.Contains(scope, "openid") || !strings.Contains(scope, "email") {
+		t.Errorf("scope = %q", scope)
+	}
+	code, state := issuer.Authorize(t, authURL, user)
+	if state != st.State {
+		t.Errorf("state = %q, want %q", state, st.State)
+	}
+	id, err := p.Exchange(ctx, code, st.Nonce, st.Verifier)
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if *id != (Identity{Subject: "u-1", Email: "alice@example.com", EmailVerified: true, Name: "Alice"}) {
+		t.Errorf("identity = %+v", id)
+	}
+
+	// Codes are single use and bound to the PKCE verifier and nonce
+	if _, err := p.Exchange(ctx, code, st.Nonce, st.Verifier); err == nil {
+		t.Error("code redeemed twice")
+	}
+	code, _ = issuer.Authorize(t, authURL, user)
+	if _, err := p.Exchange(ctx, code, st.Nonce, "wrong-verifier-wrong-verifier-wrong-verifier"); err == nil {
+		t.Error("exchange accepted the wrong verifier")
+	}
+	code, _ = issuer.Authorize(t, authURL, user)
+	if _, err := p.Exchange(ctx, code, "other-nonce", st.Verifier); err == nil || !strings.Contains(err.Error(), "nonce") {
+		t.Errorf("exchange with wrong nonce: %v", err)
+	}
+
+	// Email from userinfo when the ID token has none
+	issuer.OmitEmail = true
+	user.EmailVerified = false
+	code, _ = issuer.Authorize(t, authURL, user)
+	id, err = p.Exchange(ctx, code, st.Nonce, st.Verifier)
+	if err != nil {
+		t.Fatalf("exchange via userinfo: %v", err)
+	}
+	if id.Email != "alice@example.com" || id.EmailVerified {
+		t.Errorf("userinfo identity = %+v", id)
+	}
+}
+
+func TestOIDCDiscoveryRetries(t *testing.T) {
+	p, err := New(Config{Name: "okta", Issuer: "http://127.0.0.1:1", ClientID: "c", ClientSecret: "s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.AuthCodeURL(context.Background(), "s", "n", "v"); err == nil {
+		t.Fatal("expected discovery error")
+	}
+	if p.(*oidcProvider).provider != nil {
+		t.Error("failed discovery was cached")
+	}
+}
+
+func TestGitHubLogin(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("code") != "good" || r.PostForm.Get("code_verifier") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "bad_verification_code"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "gho_test", "token_type": "bearer"})
+	})
+	mux.HandleFunc("GET /api/v3/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gho_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": 42, "login": "octocat", "name": ""})
+	})
+	mux.HandleFunc("GET /api/v3/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"email": "old@example.com", "primary": false, "verified": true},
+			{"email": "octo@example.com", "primary": true, "verified": true},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	// A GitHub Enterprise URL; the kind follows from the name
+	p, err := New(Config{Name: "github", Issuer: srv.URL + "/", ClientID: "c", ClientSecret: "s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Config().Kind != KindGitHub {
+		t.Fatalf("kind = %q", p.Config().Kind)
+	}
+	ctx := context.Background()
+	authURL, _ := p.AuthCodeURL(ctx, "st", "", "verifier")
+	if !strings.HasPrefix(authURL, srv.URL+"/login/oauth/authorize?") || !strings.Contains(authURL, "code_challenge=") {
+		t.Errorf("auth url = %s", authURL)
+	}
+	id, err := p.Exchange(ctx, "good", "", "verifier")
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if *id != (Identity{Subject: "42", Email: "octo@example.com", EmailVerified: true, Name: "octocat"}) {
+		t.Errorf("identity = %+v", id)
+	}
+	if _, err := p.Exchange(ctx, "bad", "", "verifier"); err == nil {
+		t.Error("bad code accepted")
+	}
+}
+
+func TestNewValidates(t *testing.T) {
+	for _, cfg := range []Config{
+		{Name: "Bad Name", ClientID: "c", ClientSecret: "s", Issuer: "https://idp"},
+		{Name: "okta", ClientID: "c", ClientSecret: "s"},
+		{Name: "google", ClientID: "c"},
+		{Name: "x", Kind: "saml", ClientID: "c", ClientSecret: "s", Issuer: "https://idp"},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(%+v) succeeded", cfg)
+		}
+	}
+	p, err := New(Config{Name: "google", ClientID: "c", ClientSecret: "s", AllowedDomains: []string{"@Example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg := p.Config(); cfg.Issuer != "https://accounts.google.com" || cfg.Kind != KindOIDC {
+		t.Errorf("google defaults = %+v", cfg)
+	}
+	if !p.Config().AllowsEmail("bob@EXAMPLE.com") || p.Config().AllowsEmail("bob@example.org") {
+		t.Error("AllowsEmail does not follow the allowed domains")
+	}
+}
+
+func TestState(t *testing.T) {
+	st, err := NewState("google", "/dashboard/settings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := st.Seal("secret", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := OpenState("secret", sealed)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if *got != *st {
+		t.Errorf("state = %+v, want %+v", got, st)
+	}
+	if _, err := OpenState("other", sealed); err == nil {
+		t.Error("state opened with the wrong secret")
+	}
+	expired, _ := st.Seal("secret", -time.Minute)
+	if _, err := OpenState("secret", expired); err == nil {
+		t.Error("expired state accepted")
+	}
+}
@@ -0,0 +1,90 @@
+package vault
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptString(t *testing.T) {
+	k, _ := ParseKeyring("a:" + testKey(1))
+	enc, err := k.EncryptString("sk-ant-123", "users.anthropic_api_key")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if !strings.HasPrefix(enc, FieldPrefix("a")) || strings.Contains(enc, "sk-ant-123") {
+		t.Errorf("encrypted value %q", enc)
+	}
+	if got, err := k.DecryptString(enc, "users.anthropic_api_key"); err != nil || got != "sk-ant-123" {
+		t.Errorf("decrypt = %q, %v", got, err)
+	}
+	if _, err := k.DecryptString(enc, "users.claude_oauth_token"); err == nil {
+		t.Error("value decrypted under another column")
+	}
+
+	rotated, _ := ParseKeyring("b:" + testKey(2) + ",a:" + testKey(1))
+	if got, err := rotated.DecryptString(enc, "users.anthropic_api_key"); err != nil || got != "sk-ant-123" {
+		t.Errorf("decrypt with rotated keyring = %q, %v", got, err)
+	}
+	retired, _ := ParseKeyring("b:" + testKey(2))
+	if _, err := retired.DecryptString(enc, "users.anthropic_api_key"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("retired key: got %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestSealOpenField(t *testing.T) {
+	t.Cleanup(func() { SetFieldKeyring(nil, false) })
+	k, _ := ParseKeyring("a:" + testKey(1))
+
+	// Read-only rollout: plaintext is still written, both forms are read
+	SetFieldKeyring(k, false)
+	if v, _ := SealField("c", "secret"); v != "secret" {
+		t.Errorf("read-only seal = %q", v)
+	}
+	enc, _ := k.EncryptString("secret", "c")
+	if v, err := OpenField("c", enc); err != nil || v != "secret" {
+		t.Errorf("read-only open = %q, %v", v, err)
+	}
+
+	SetFieldKeyring(k, true)
+	if FieldWriteKeyID() != "a" {
+		t.Errorf("write key = %q", FieldWriteKeyID())
+	}
+	sealed, err := SealField("c", "secret")
+	if err != nil || !IsEncrypted(sealed) {
+		t.Fatalf("seal = %q, %v", sealed, err)
+	}
+	if v, _ := SealField("c", ""); v != "" {
+		t.Errorf("empty value sealed to %q", v)
+	}
+	if v, err := OpenField("c", "legacy-plaintext"); err != nil || v != "legacy-plaintext" {
+		t.Errorf("plaintext open = %q, %v", v, err)
+	}
+
+	SetFieldKeyring(nil, false)
+	if _, err := OpenField("c", sealed); !errors.Is(err, ErrNoKeyring) {
+		t.Errorf("open without keyring: got %v, want ErrNoKeyring", err)
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys")
+	content := "# rotated 2026-10\nb:" + testKey(2) + "\n\na:" + testKey(1) + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	k, err := FileSource(path).Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if k.PrimaryID() != "b" || len(k.keys) != 2 {
+		t.Errorf("primary %q, %d keys", k.PrimaryID(), len(k.keys))
+	}
+
+	t.Setenv("TEST_MASTER_KEYS", "a:"+testKey(1))
+	if k, err := EnvSource("TEST_MASTER_KEYS").Load(); err != nil || k.PrimaryID() != "a" {
+		t.Errorf("env source = %v, %v", k, err)
+	}
+}
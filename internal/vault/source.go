@@ -0,0 +1,50 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Source supplies the master keys for a Keyring.
+type Source interface {
+	Load() (*Keyring, error)
+}
+
+// EnvSource reads a keyring in ParseKeyring format from the named
+// environment variable.
+type EnvSource string
+
+// Load implements Source.
+func (s EnvSource) Load() (*Keyring, error) {
+	k, err := ParseKeyring(os.Getenv(string(s)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", string(s), err)
+	}
+	return k, nil
+}
+
+// FileSource reads a keyring from a file holding one id:base64key entry per
+// line, primary first. Blank lines and lines starting with # are ignored.
+type FileSource string
+
+// Load implements Source.
+func (s FileSource) Load() (*Keyring, error) {
+	data, err := os.ReadFile(string(s))
+	if err != nil {
+		return nil, fmt.Errorf("read keyring: %w", err)
+	}
+	var entries []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+	k, err := ParseKeyring(strings.Join(entries, ","))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", string(s), err)
+	}
+	return k, nil
+}
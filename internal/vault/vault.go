@@ -0,0 +1,182 @@
+// Package vault implements envelope encryption for user secrets. Each value
+// is sealed with its own random data key, and the data key is wrapped with a
+// master key from a Keyring. Rotating the master key only rewraps data keys.
+//
+// The same keyring also encrypts sensitive database columns directly; see
+// EncryptString and SetFieldKeyring.
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeySize is the length of master and data keys (AES-256).
+const KeySize = 32
+
+// ErrUnknownKey indicates an envelope wrapped with a master key the keyring
+// does not hold.
+var ErrUnknownKey = errors.New("unknown master key")
+
+// Keyring holds the master keys by ID. The primary key wraps new data keys;
+// the others are kept so existing envelopes can still be opened.
+type Keyring struct {
+	primary string
+	keys    map[string][]byte
+}
+
+// NewKeyring creates a keyring whose primary key is primaryID.
+func NewKeyring(primaryID string, keys map[string][]byte) (*Keyring, error) {
+	if _, ok := keys[primaryID]; !ok {
+		return nil, fmt.Errorf("primary key %q not in keyring", primaryID)
+	}
+	for id, key := range keys {
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("key %q: want %d bytes, got %d", id, KeySize, len(key))
+		}
+	}
+	return &Keyring{primary: primaryID, keys: keys}, nil
+}
+
+// ParseKeyring parses a comma-separated list of id:base64key pairs, e.g.
+// "2025b:...,2025a:...". The first key is the primary.
+func ParseKeyring(s string) (*Keyring, error) {
+	keys := make(map[string][]byte)
+	var primary string
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid key entry: want id:base64key")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		if _, dup := keys[id]; dup {
+			return nil, fmt.Errorf("duplicate key ID %q", id)
+		}
+		keys[id] = key
+		if primary == "" {
+			primary = id
+		}
+	}
+	if primary == "" {
+		return nil, errors.New("keyring is empty")
+	}
+	return NewKeyring(primary, keys)
+}
+
+// PrimaryID returns the ID of the key that wraps new data keys.
+func (k *Keyring) PrimaryID() string {
+	return k.primary
+}
+
+// Envelope is a sealed value: the ciphertext, its data key wrapped with the
+// master key KeyID, and nothing else needed besides the keyring to open it.
+type Envelope struct {
+	KeyID      string
+	WrappedKey []byte
+	Ciphertext []byte
+}
+
+// Seal encrypts plaintext under a fresh data key wrapped with the primary key.
+func (k *Keyring) Seal(plaintext []byte) (*Envelope, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("generate data key: %w", err)
+	}
+	ciphertext, err := encrypt(dataKey, plaintext, nil)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := encrypt(k.keys[k.primary], dataKey, []byte(k.primary))
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{KeyID: k.primary, WrappedKey: wrapped, Ciphertext: ciphertext}, nil
+}
+
+// Open decrypts an envelope.
+func (k *Keyring) Open(env *Envelope) ([]byte, error) {
+	dataKey, err := k.unwrap(env)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := decrypt(dataKey, env.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt value: %w", err)
+	}
+	return plaintext, nil
+}
+
+// Rewrap rewraps the envelope's data key with the primary key, leaving the
+// ciphertext alone. It reports false if the envelope already uses the
+// primary key.
+func (k *Keyring) Rewrap(env *Envelope) (*Envelope, bool, error) {
+	if env.KeyID == k.primary {
+		return env, false, nil
+	}
+	dataKey, err := k.unwrap(env)
+	if err != nil {
+		return nil, false, err
+	}
+	wrapped, err := encrypt(k.keys[k.primary], dataKey, []byte(k.primary))
+	if err != nil {
+		return nil, false, err
+	}
+	return &Envelope{KeyID: k.primary, WrappedKey: wrapped, Ciphertext: env.Ciphertext}, true, nil
+}
+
+func (k *Keyring) unwrap(env *Envelope) ([]byte, error) {
+	master, ok := k.keys[env.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, env.KeyID)
+	}
+	dataKey, err := decrypt(master, env.WrappedKey, []byte(env.KeyID))
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+// encrypt seals plaintext with AES-GCM and prepends the nonce.
+func encrypt(key, plaintext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func decrypt(key, sealed, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,79 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, KeySize))
+}
+
+func TestSealOpen(t *testing.T) {
+	k, err := ParseKeyring("a:" + testKey(1))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	env, err := k.Seal([]byte("ghp_secret"))
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	if env.KeyID != "a" || bytes.Contains(env.Ciphertext, []byte("ghp_secret")) {
+		t.Errorf("unexpected envelope %+v", env)
+	}
+	got, err := k.Open(env)
+	if err != nil || string(got) != "ghp_secret" {
+		t.Fatalf("open = %q, %v", got, err)
+	}
+
+	env.Ciphertext[len(env.Ciphertext)-1] ^= 1
+	if _, err := k.Open(env); err == nil {
+		t.Error("expected tampered ciphertext to fail")
+	}
+}
+
+func TestRewrap(t *testing.T) {
+	old, _ := ParseKeyring("a:" + testKey(1))
+	env, err := old.Seal([]byte("npm_token"))
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+
+	// Rotation: b becomes primary, a is kept for reading
+	rotated, err := ParseKeyring("b:" + testKey(2) + ",a:" + testKey(1))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got, err := rotated.Open(env); err != nil || string(got) != "npm_token" {
+		t.Fatalf("open with old key = %q, %v", got, err)
+	}
+	re, changed, err := rotated.Rewrap(env)
+	if err != nil || !changed || re.KeyID != "b" {
+		t.Fatalf("rewrap = %+v, %v, %v", re, changed, err)
+	}
+	if !bytes.Equal(re.Ciphertext, env.Ciphertext) {
+		t.Error("rewrap changed the ciphertext")
+	}
+	if _, changed, _ := rotated.Rewrap(re); changed {
+		t.Error("rewrap of a primary-key envelope reported a change")
+	}
+
+	// Once a is dropped only rewrapped envelopes open
+	final, _ := ParseKeyring("b:" + testKey(2))
+	if _, err := final.Open(env); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("open with dropped key: got %v, want ErrUnknownKey", err)
+	}
+	if got, err := final.Open(re); err != nil || string(got) != "npm_token" {
+		t.Errorf("open rewrapped = %q, %v", got, err)
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	for _, bad := range []string{"", "a", "a:notbase64!", "a:" + base64.StdEncoding.EncodeToString([]byte("short")), "a:" + testKey(1) + ",a:" + testKey(2)} {
+		if _, err := ParseKeyring(bad); err == nil {
+			t.Errorf("ParseKeyring(%q): expected error", bad)
+		}
+	}
+}
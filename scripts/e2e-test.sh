@@ -6,6 +6,39 @@ KEY="dev-api-key"
 
 echo "=== CloudCode E2E Test ==="
 
+# wait_job JOB_ID — polls /jobs/{id} until it finishes; prints the final job JSON.
+wait_job() {
+  local job_id=$1 body status
+  for _ in $(seq 1 120); do
+    body=$(curl -s "$API/jobs/$job_id" -H "X-API-Key: $KEY")
+    status=$(echo "$body" | python3 -c "import sys,json; print(json.load(sys.stdin).get('status',''))")
+    case "$status" in
+      succeeded) echo "$body"; return 0 ;;
+      failed) echo "$body"; return 1 ;;
+    esac
+    sleep 2
+  done
+  echo "$body"
+  return 1
+}
+
+# run_op METHOD PATH — issues a lifecycle request, expects 202 and waits for the job.
+run_op() {
+  local response http_code body job_id
+  response=$(curl -s -w "\n%{http_code}" -X "$1" "$API$2" -H "X-API-Key: $KEY")
+  http_code=$(echo "$response" | tail -1)
+  body=$(echo "$response" | sed '$d')
+  if [ "$http_code" != "202" ]; then
+    echo "FAIL (got $http_code): $body"
+    exit 1
+  fi
+  job_id=$(echo "$body" | python3 -c "import sys,json; print(json.load(sys.stdin)['id'])")
+  if ! body=$(wait_job "$job_id"); then
+    echo "FAIL (job $job_id): $body"
+    exit 1
+  fi
+}
+
 # 1. Health check
 echo -n "Health check... "
 STATUS=$(curl -s -o /dev/null -w "%{http_code}" "$API/healthz")
@@ -23,12 +56,17 @@ RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API/instances/" \
   -d '{"user_id": 1}')
 HTTP_CODE=$(echo "$RESPONSE" | tail -1)
 BODY=$(echo "$RESPONSE" | sed '$d')
-if [ "$HTTP_CODE" != "201" ]; then
+if [ "$HTTP_CODE" != "202" ]; then
   echo "FAIL (got $HTTP_CODE): $BODY"
   exit 1
 fi
-INSTANCE_ID=$(echo "$BODY" | python3 -c "import sys,json; print(json.load(sys.stdin)['id'])" 2>/dev/null || echo "$BODY" | grep -o '"id":[0-9]*' | grep -o '[0-9]*')
-echo "OK (id=$INSTANCE_ID)"
+JOB_ID=$(echo "$BODY" | python3 -c "import sys,json; print(json.load(sys.stdin)['id'])")
+if ! BODY=$(wait_job "$JOB_ID"); then
+  echo "FAIL (job $JOB_ID): $BODY"
+  exit 1
+fi
+INSTANCE_ID=$(echo "$BODY" | python3 -c "import sys,json; print(json.load(sys.stdin)['instance_id'])")
+echo "OK (id=$INSTANCE_ID, job=$JOB_ID)"
 
 # 3. Get instance
 echo -n "Get instance... "
@@ -42,32 +80,17 @@ echo "OK"
 
 # 4. Pause instance
 echo -n "Pause instance... "
-STATUS=$(curl -s -o /dev/null -w "%{http_code}" -X POST "$API/instances/$INSTANCE_ID/pause" \
-  -H "X-API-Key: $KEY")
-if [ "$STATUS" != "200" ]; then
-  echo "FAIL (got $STATUS)"
-  exit 1
-fi
+run_op POST "/instances/$INSTANCE_ID/pause"
 echo "OK"
 
 # 5. Wake instance
 echo -n "Wake instance... "
-STATUS=$(curl -s -o /dev/null -w "%{http_code}" -X POST "$API/instances/$INSTANCE_ID/wake" \
-  -H "X-API-Key: $KEY")
-if [ "$STATUS" != "200" ]; then
-  echo "FAIL (got $STATUS)"
-  exit 1
-fi
+run_op POST "/instances/$INSTANCE_ID/wake"
 echo "OK"
 
 # 6. Delete instance
 echo -n "Delete instance... "
-STATUS=$(curl -s -o /dev/null -w "%{http_code}" -X DELETE "$API/instances/$INSTANCE_ID" \
-  -H "X-API-Key: $KEY")
-if [ "$STATUS" != "200" ]; then
-  echo "FAIL (got $STATUS)"
-  exit 1
-fi
+run_op DELETE "/instances/$INSTANCE_ID"
 echo "OK"
 
 # 7. Verify deleted (should return destroyed status)
@@ -8,6 +8,7 @@
  *   GET /files/read     — File content
  *   GET /projects       — Scan for .git directories
  *   POST /projects/clone — Git clone a repository
+ *   PUT /secrets        — Install the user's secrets (pushed by the control plane)
  *
  * Auth: AGENT_SECRET env var checked via Authorization header.
  * All file ops restricted to /claude-data/.
@@ -30,7 +31,7 @@ const CREDS_PATH = "/home/claude/.claude/.credentials.json";
 let authState = { status: "checking", url: null };
 
 const app = express();
-app.use(express.json());
+app.use(express.json({ limit: "4mb" })); // PUT /secrets carries every secret at once
 
 // --- Auth middleware ---
 function authMiddleware(req, res, next) {
@@ -42,6 +43,12 @@ function authMiddleware(req, res, next) {
   next();
 }
 
+// --- GET /health ---
+// Unauthenticated so the control plane can tell when a woken instance is up
+app.get("/health", (req, res) => {
+  res.json({ status: "ok" });
+});
+
 app.use(authMiddleware);
 
 // --- Path validation ---
@@ -294,6 +301,90 @@ app.delete("/tabs/:name", (req, res) => {
   });
 });
 
+// --- PUT /secrets — Replace the secrets pushed by the control plane ---
+// Body: { env: { NAME: value }, files: [{ path, content (base64) }] }.
+// Env secrets go to ~/.cloudcode/secrets.env, which interactive shells source,
+// and into this process's environment for new chat sessions. Files left over
+// from the previous push are removed.
+const HOME_ROOT = "/home/claude";
+const SECRETS_DIR = path.join(HOME_ROOT, ".cloudcode");
+const SECRETS_ENV = path.join(SECRETS_DIR, "secrets.env");
+const SECRETS_MANIFEST = path.join(SECRETS_DIR, "secret-files.json");
+let pushedEnv = [];
+
+function homePath(userPath) {
+  const resolved = path.resolve(HOME_ROOT, userPath || "");
+  if (!resolved.startsWith(HOME_ROOT + "/") || resolved.startsWith(SECRETS_DIR + "/")) {
+    return null;
+  }
+  return resolved;
+}
+
+function shellQuote(value) {
+  return "'" + value.replace(/'/g, "'\\''") + "'";
+}
+
+// Write via a temp file so readers never see a partial secret
+function writePrivate(file, content) {
+  const tmp = file + ".tmp";
+  fs.writeFileSync(tmp, content, { mode: 0o600 });
+  fs.renameSync(tmp, file);
+}
+
+app.put("/secrets", (req, res) => {
+  const env = req.body.env || {};
+  const files = req.body.files || [];
+
+  for (const name of Object.keys(env)) {
+    if (!/^[A-Za-z_][A-Za-z0-9_]*$/.test(name)) {
+      return res.status(400).json({ error: `invalid name: ${name}` });
+    }
+  }
+  const targets = [];
+  for (const file of files) {
+    const target = homePath(file.path);
+    if (!target) {
+      return res.status(400).json({ error: `invalid path: ${file.path}` });
+    }
+    targets.push({ target, content: Buffer.from(file.content || "", "base64") });
+  }
+
+  try {
+    fs.mkdirSync(SECRETS_DIR, { recursive: true, mode: 0o700 });
+
+    let previous = [];
+    try {
+      previous = JSON.parse(fs.readFileSync(SECRETS_MANIFEST, "utf-8"));
+    } catch {
+      // First push
+    }
+    const current = targets.map((t) => t.target);
+    for (const old of previous) {
+      if (!current.includes(old) && homePath(path.relative(HOME_ROOT, old))) {
+        fs.rmSync(old, { force: true });
+      }
+    }
+
+    for (const { target, content } of targets) {
+      fs.mkdirSync(path.dirname(target), { recursive: true, mode: 0o700 });
+      writePrivate(target, content);
+    }
+    writePrivate(SECRETS_MANIFEST, JSON.stringify(current));
+
+    const lines = Object.entries(env).map(([name, value]) => `export ${name}=${shellQuote(value)}\n`);
+    writePrivate(SECRETS_ENV, lines.join(""));
+  } catch (err) {
+    return res.status(500).json({ error: err.message });
+  }
+
+  for (const name of pushedEnv) {
+    delete process.env[name];
+  }
+  Object.assign(process.env, env);
+  pushedEnv = Object.keys(env);
+  res.json({ status: "ok", env: pushedEnv.length, files: targets.length });
+});
+
 // --- GET /auth/status — Return current auth state ---
 app.get("/auth/status", (req, res) => {
   res.json(authState);
@@ -0,0 +1,34 @@
+#!/bin/bash
+# Applies an instance environment template on first boot. Clones the
+# space-separated git URLs in CLOUDCODE_REPOS into /claude-data, then runs the
+# base64-encoded script in CLOUDCODE_SETUP from there. A marker on the data
+# volume makes this run once per volume, so restarts and wakes skip it.
+set -uo pipefail
+
+DATA_DIR=/claude-data
+STATE_DIR="${DATA_DIR}/.cloudcode"
+MARKER="${STATE_DIR}/template-applied"
+
+if [ -e "$MARKER" ] || [ -z "${CLOUDCODE_REPOS:-}${CLOUDCODE_SETUP:-}" ]; then
+    exit 0
+fi
+mkdir -p "$STATE_DIR"
+
+for repo in ${CLOUDCODE_REPOS:-}; do
+    dest="${DATA_DIR}/$(basename "$repo" .git)"
+    if [ -e "$dest" ]; then
+        continue
+    fi
+    if ! git clone --quiet "$repo" "$dest"; then
+        echo "apply-template: cloning ${repo} failed" >&2
+    fi
+done
+
+if [ -n "${CLOUDCODE_SETUP:-}" ]; then
+    printf '%s' "$CLOUDCODE_SETUP" | base64 -d > "${STATE_DIR}/setup.sh"
+    if ! (cd "$DATA_DIR" && bash "${STATE_DIR}/setup.sh") > "${STATE_DIR}/setup.log" 2>&1; then
+        echo "apply-template: setup script failed, see ${STATE_DIR}/setup.log" >&2
+    fi
+fi
+
+touch "$MARKER"
@@ -91,6 +91,12 @@ else
     echo "ttyd already installed."
 fi
 
+# Secrets pushed by the control plane through the agent (PUT /secrets)
+if ! grep -q 'cloudcode/secrets.env' /home/claude/.bashrc 2>/dev/null; then
+    echo '[ -r ~/.cloudcode/secrets.env ] && . ~/.cloudcode/secrets.env' >> /home/claude/.bashrc
+    chown claude:claude /home/claude/.bashrc
+fi
+
 # Volume mount point
 mkdir -p /claude-data
 chown claude:claude /claude-data
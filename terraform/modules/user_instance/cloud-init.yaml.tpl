@@ -57,8 +57,60 @@ write_files:
       mkdir -p /claude-data
       chown claude:claude /claude-data
 
+      # Credentials installed by bind.sh, template variables written below
+      echo 'if [ -r /etc/cloudcode/instance.env ]; then set -a; . /etc/cloudcode/instance.env; set +a; fi' >> /home/claude/.profile
+      echo 'if [ -r /etc/cloudcode/environment.env ]; then set -a; . /etc/cloudcode/environment.env; set +a; fi' >> /home/claude/.profile
+
+      # Secrets pushed by the control plane through the agent (PUT /secrets)
+      echo '[ -r ~/.cloudcode/secrets.env ] && . ~/.cloudcode/secrets.env' >> /home/claude/.bashrc
+
       echo "=== CloudCode instance setup complete ==="
 
+%{ if environment_env != "" ~}
+  - path: /etc/cloudcode/environment.env
+    permissions: "0640"
+    encoding: b64
+    content: ${environment_env}
+
+%{ endif ~}
+  - path: /opt/cloudcode/apply-template.sh
+    permissions: "0755"
+    content: |
+      #!/bin/bash
+      # Applies an instance environment template on first boot. Clones the
+      # space-separated git URLs in CLOUDCODE_REPOS into /claude-data, then runs the
+      # base64-encoded script in CLOUDCODE_SETUP from there. A marker on the data
+      # volume makes this run once per volume, so restarts and wakes skip it.
+      set -uo pipefail
+
+      DATA_DIR=/claude-data
+      STATE_DIR="$${DATA_DIR}/.cloudcode"
+      MARKER="$${STATE_DIR}/template-applied"
+
+      if [ -e "$MARKER" ] || [ -z "$${CLOUDCODE_REPOS:-}$${CLOUDCODE_SETUP:-}" ]; then
+          exit 0
+      fi
+      mkdir -p "$STATE_DIR"
+
+      for repo in $${CLOUDCODE_REPOS:-}; do
+          dest="$${DATA_DIR}/$(basename "$repo" .git)"
+          if [ -e "$dest" ]; then
+              continue
+          fi
+          if ! git clone --quiet "$repo" "$dest"; then
+              echo "apply-template: cloning $${repo} failed" >&2
+          fi
+      done
+
+      if [ -n "$${CLOUDCODE_SETUP:-}" ]; then
+          printf '%s' "$CLOUDCODE_SETUP" | base64 -d > "$${STATE_DIR}/setup.sh"
+          if ! (cd "$DATA_DIR" && bash "$${STATE_DIR}/setup.sh") > "$${STATE_DIR}/setup.log" 2>&1; then
+              echo "apply-template: setup script failed, see $${STATE_DIR}/setup.log" >&2
+          fi
+      fi
+
+      touch "$MARKER"
+
   - path: /opt/cloudcode/start-session.sh
     permissions: "0755"
     content: |
@@ -71,6 +123,38 @@ write_files:
       fi
       exec zellij --session "$SESSION_NAME" --layout "$LAYOUT"
 
+  - path: /opt/cloudcode/bind.sh
+    permissions: "0700"
+    content: |
+      #!/bin/bash
+      # Binds a warm pool server to its user. Reads KEY=value lines on stdin:
+      # AGENT_SECRET, ANTHROPIC_API_KEY, CLAUDE_CODE_OAUTH_TOKEN and
+      # NETBIRD_SETUP_KEY, all optional.
+      set -euo pipefail
+      input=$(cat)
+
+      install -d -m 0755 /etc/cloudcode
+      umask 027
+      printf '%s\n' "$input" | grep -v '^NETBIRD_SETUP_KEY=' > /etc/cloudcode/instance.env || true
+      chown root:claude /etc/cloudcode/instance.env
+
+      setup_key=$(printf '%s\n' "$input" | sed -n 's/^NETBIRD_SETUP_KEY=//p')
+      if [ -n "$setup_key" ]; then
+          command -v netbird &>/dev/null || curl -fsSL https://pkgs.netbird.io/install.sh | bash
+          netbird up --setup-key "$setup_key"
+
+          # Same lockdown as a server enrolled at boot; this ends SSH access
+          ufw default deny incoming
+          ufw default allow outgoing
+          ufw allow 60000:60010/udp comment "mosh"
+          ufw allow 51820/udp comment "netbird-wireguard"
+          ufw --force enable
+      fi
+
+      # Restart the session started at boot so it picks up the credentials
+      su - claude -c "zellij kill-session claude" || true
+      su - claude -c "zellij --session claude --layout claude &"
+
   - path: /home/claude/.config/zellij/layouts/claude.kdl
     permissions: "0644"
     content: |
@@ -93,6 +177,9 @@ runcmd:
   - cp /opt/cloudcode/start-session.sh /home/claude/start-session.sh
   - chown claude:claude /home/claude/start-session.sh
   - chown -R claude:claude /home/claude/.config/zellij
+%{ if environment_env != "" ~}
+  - chown root:claude /etc/cloudcode/environment.env
+%{ endif ~}
 
   # Netbird setup (if setup key is provided)
 %{ if netbird_setup_key != "" ~}
@@ -107,6 +194,9 @@ runcmd:
   - ufw --force enable
 %{ endif ~}
 
+  # Clone the template's repos and run its setup script
+  - su - claude -c /opt/cloudcode/apply-template.sh
+
   # Start Zellij session as claude user
   - su - claude -c "zellij --session claude --layout claude &"
 
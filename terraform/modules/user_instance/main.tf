@@ -12,6 +12,24 @@ variable "user_id" {
   description = "User ID for this instance"
 }
 
+variable "instance_name" {
+  type        = string
+  default     = ""
+  description = "Instance name; empty for the user's default instance"
+}
+
+variable "pool_id" {
+  type        = string
+  default     = ""
+  description = "Warm pool ID; set for a server not yet bound to a user"
+}
+
+variable "ssh_keys" {
+  type        = list(string)
+  default     = []
+  description = "Hetzner SSH key names; warm pool servers are bound over SSH"
+}
+
 variable "hcloud_token" {
   type        = string
   sensitive   = true
@@ -43,6 +61,13 @@ variable "netbird_setup_key" {
   description = "Netbird setup key for zero-trust enrollment (empty = skip)"
 }
 
+variable "environment" {
+  type        = map(string)
+  default     = {}
+  sensitive   = true
+  description = "Template environment variables, including CLOUDCODE_REPOS and CLOUDCODE_SETUP"
+}
+
 provider "hcloud" {
   token = var.hcloud_token
 }
@@ -51,11 +76,22 @@ provider "hcloud" {
 locals {
   subnet_octet = (var.user_id % 250) + 1
   private_ip   = "10.100.${local.subnet_octet}.10"
+
+  # The default instance keeps the original per-user resource names
+  user_key     = var.instance_name == "" ? tostring(var.user_id) : "${var.user_id}-${var.instance_name}"
+  instance_key = var.pool_id == "" ? local.user_key : "pool-${var.pool_id}"
+  name_label   = var.instance_name == "" ? {} : { instance_name = var.instance_name }
+
+  # Single-quoted for bash; cloud-init writes it base64-encoded
+  environment_env = join("", [for k, v in var.environment : "${k}='${replace(v, "'", "'\\''")}'\n"])
+
+  # Warm pool servers carry a pool label instead of an owner until claimed
+  owner_labels = var.pool_id == "" ? merge({ user_id = tostring(var.user_id) }, local.name_label) : { pool = var.pool_id }
 }
 
 # Persistent volume for user data
 resource "hcloud_volume" "data" {
-  name      = "claude-data-${var.user_id}"
+  name      = "claude-data-${local.instance_key}"
   size      = 20
   location  = var.location
   format    = "ext4"
@@ -67,20 +103,21 @@ resource "hcloud_volume" "data" {
 
 # User instance server
 resource "hcloud_server" "instance" {
-  name        = "claude-${var.user_id}"
+  name        = "claude-${local.instance_key}"
   server_type = var.server_type
   image       = var.image
   location    = var.location
+  ssh_keys    = var.ssh_keys
 
   user_data = templatefile("${path.module}/cloud-init.yaml.tpl", {
     user_id           = var.user_id
     netbird_setup_key = var.netbird_setup_key
+    environment_env   = base64encode(local.environment_env)
   })
 
-  labels = {
+  labels = merge({
     managed_by = "cloudcode"
-    user_id    = tostring(var.user_id)
-  }
+  }, local.owner_labels)
 }
 
 # Attach volume to server
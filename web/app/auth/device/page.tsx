@@ -0,0 +1,153 @@
+"use client";
+
+import { Suspense, useEffect, useState } from "react";
+import { useSearchParams } from "next/navigation";
+import { api, DeviceInfo } from "@/lib/api";
+
+function DeviceContent() {
+  const searchParams = useSearchParams();
+  const [code, setCode] = useState(searchParams.get("user_code") ?? "");
+  const [device, setDevice] = useState<DeviceInfo | null>(null);
+  const [signedIn, setSignedIn] = useState<boolean | null>(null);
+  const [done, setDone] = useState("");
+  const [error, setError] = useState("");
+  const [loading, setLoading] = useState(false);
+
+  useEffect(() => {
+    api
+      .me()
+      .then(() => setSignedIn(true))
+      .catch(() => setSignedIn(false));
+  }, []);
+
+  async function handleLookup(e: React.FormEvent) {
+    e.preventDefault();
+    setError("");
+    setLoading(true);
+    try {
+      setDevice(await api.getDevice(code));
+    } catch (err) {
+      setError(err instanceof Error ? err.message : "Unknown code");
+    } finally {
+      setLoading(false);
+    }
+  }
+
+  async function decide(approve: boolean) {
+    if (!device) return;
+    setError("");
+    setLoading(true);
+    try {
+      const res = await api.decideDevice(device.user_code, approve);
+      setDone(res.status);
+    } catch (err) {
+      setError(err instanceof Error ? err.message : "Failed");
+    } finally {
+      setLoading(false);
+    }
+  }
+
+  const card = "w-full max-w-sm rounded-xl bg-white p-8 text-center ring-1 ring-gray-200";
+
+  if (signedIn === false) {
+    return (
+      <div className="flex min-h-screen items-center justify-center">
+        <div className={card}>
+          <h2 className="text-xl font-bold">Sign in first</h2>
+          <p className="mt-2 text-gray-600">
+            Sign in, then open this page again to approve your device.
+          </p>
+          <a
+            href="/auth/login"
+            className="mt-4 inline-block rounded-lg bg-gray-900 px-4 py-2 text-sm font-medium text-white hover:bg-gray-800"
+          >
+            Sign in
+          </a>
+        </div>
+      </div>
+    );
+  }
+
+  if (done) {
+    return (
+      <div className="flex min-h-screen items-center justify-center">
+        <div className={card}>
+          <h2 className="text-xl font-bold">
+            {done === "approved" ? "Device approved" : "Device denied"}
+          </h2>
+          <p className="mt-2 text-gray-600">You can close this page and return to your terminal.</p>
+        </div>
+      </div>
+    );
+  }
+
+  return (
+    <div className="flex min-h-screen items-center justify-center">
+      <div className="w-full max-w-sm rounded-xl bg-white p-8 ring-1 ring-gray-200">
+        <h2 className="text-center text-xl font-bold">Connect a device</h2>
+        {device ? (
+          <div className="mt-6 text-center">
+            <p className="text-gray-600">
+              <strong>{device.client_id}</strong> is asking to sign in as you.
+              Only approve it if you just ran <code>claude-cloud login</code> and
+              it shows this code:
+            </p>
+            <p className="mt-4 font-mono text-2xl tracking-widest">{device.user_code}</p>
+            <div className="mt-6 flex gap-2">
+              <button
+                onClick={() => decide(false)}
+                disabled={loading}
+                className="w-full rounded-lg border border-gray-300 py-2 font-medium hover:bg-gray-50 disabled:opacity-50"
+              >
+                Deny
+              </button>
+              <button
+                onClick={() => decide(true)}
+                disabled={loading}
+                className="w-full rounded-lg bg-gray-900 py-2 font-medium text-white hover:bg-gray-800 disabled:opacity-50"
+              >
+                Approve
+              </button>
+            </div>
+          </div>
+        ) : (
+          <form onSubmit={handleLookup} className="mt-6">
+            <label htmlFor="code" className="block text-sm font-medium text-gray-700">
+              Code shown in your terminal
+            </label>
+            <input
+              id="code"
+              required
+              value={code}
+              onChange={(e) => setCode(e.target.value)}
+              className="mt-1 w-full rounded-lg border border-gray-300 px-3 py-2 font-mono uppercase tracking-widest focus:border-gray-900 focus:outline-none focus:ring-1 focus:ring-gray-900"
+              placeholder="XXXX-XXXX"
+            />
+            <button
+              type="submit"
+              disabled={loading || signedIn === null}
+              className="mt-4 w-full rounded-lg bg-gray-900 py-2 font-medium text-white hover:bg-gray-800 disabled:opacity-50"
+            >
+              Continue
+            </button>
+          </form>
+        )}
+        {error && <p className="mt-2 text-center text-sm text-red-600">{error}</p>}
+      </div>
+    </div>
+  );
+}
+
+export default function DevicePage() {
+  return (
+    <Suspense
+      fallback={
+        <div className="flex min-h-screen items-center justify-center">
+          <p>Loading...</p>
+        </div>
+      }
+    >
+      <DeviceContent />
+    </Suspense>
+  );
+}
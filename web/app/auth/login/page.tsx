@@ -1,13 +1,33 @@
 "use client";
 
-import { useState } from "react";
-import { api } from "@/lib/api";
+import { useEffect, useState } from "react";
+import { api, SSOProvider } from "@/lib/api";
+
+// Error codes the SSO callback sends back to this page.
+const ssoErrors: Record<string, string> = {
+  sso_cancelled: "Sign-in was cancelled.",
+  sso_expired: "Sign-in took too long. Please try again.",
+  sso_email_unverified: "Your provider has not verified your email address.",
+  sso_domain_not_allowed: "Your email domain is not allowed to sign in.",
+  sso_failed: "Sign-in failed. Please try again.",
+};
+
+function providerLabel(name: string) {
+  return name.charAt(0).toUpperCase() + name.slice(1);
+}
 
 export default function LoginPage() {
   const [email, setEmail] = useState("");
   const [sent, setSent] = useState(false);
   const [error, setError] = useState("");
   const [loading, setLoading] = useState(false);
+  const [providers, setProviders] = useState<SSOProvider[]>([]);
+
+  useEffect(() => {
+    const code = new URLSearchParams(window.location.search).get("error");
+    if (code) setError(ssoErrors[code] || ssoErrors.sso_failed);
+    api.listSSOProviders().then(setProviders).catch(() => {});
+  }, []);
 
   async function handleSubmit(e: React.FormEvent) {
     e.preventDefault();
@@ -36,7 +56,7 @@ export default function LoginPage() {
           <h2 className="text-xl font-bold">Check your email</h2>
           <p className="mt-2 text-gray-600">
             We sent a magic link to <strong>{email}</strong>.
-            Click it to sign in.
+            Open it in this browser to sign in.
           </p>
         </div>
       </div>
@@ -47,6 +67,20 @@ export default function LoginPage() {
     <div className="flex min-h-screen items-center justify-center">
       <div className="w-full max-w-sm rounded-xl bg-white p-8 ring-1 ring-gray-200">
         <h2 className="text-center text-xl font-bold">Sign in to Claude Cloud</h2>
+        {providers.length > 0 && (
+          <div className="mt-6 space-y-2">
+            {providers.map((p) => (
+              <a
+                key={p.name}
+                href={api.ssoLoginURL(p.name)}
+                className="block w-full rounded-lg border border-gray-300 py-2 text-center font-medium hover:bg-gray-50"
+              >
+                Continue with {providerLabel(p.name)}
+              </a>
+            ))}
+            <p className="pt-2 text-center text-xs text-gray-500">or use a magic link</p>
+          </div>
+        )}
         <form onSubmit={handleSubmit} className="mt-6">
           <label htmlFor="email" className="block text-sm font-medium text-gray-700">
             Email address
@@ -29,7 +29,11 @@ function ChatPageInner() {
   const [showFiles, setShowFiles] = useState(false);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState("");
+  const [waking, setWaking] = useState<string | null>(null);
   const wsRef = useRef<WebSocket | null>(null);
+  // Where to resume from if the connection drops; see the proxy's resume event
+  const resumeRef = useRef<{ token: string; seq: number } | null>(null);
+  const resumeAttemptsRef = useRef(0);
   const messagesEndRef = useRef<HTMLDivElement>(null);
   const pendingToolEventsRef = useRef<ToolEvent[]>([]);
   const pendingTextRef = useRef("");
@@ -48,7 +52,7 @@ function ChatPageInner() {
         setInstance(inst);
 
         // Get or create conversation for this project path
-        const conv = await api.getOrCreateConversation(initialCwd);
+        const conv = await api.getOrCreateConversation(initialCwd, inst.id);
         setConversation(conv);
 
         // Load existing messages
@@ -78,7 +82,12 @@ function ChatPageInner() {
     (inst: Instance) => {
       if (wsRef.current && wsRef.current.readyState === WebSocket.OPEN) return;
 
-      const wsUrl = createAuthWsUrl(`/instances/${inst.id}/chat`);
+      const resume = resumeRef.current;
+      const wsUrl = createAuthWsUrl(
+        resume
+          ? `/instances/${inst.id}/chat?resume=${resume.token}&seq=${resume.seq}`
+          : `/instances/${inst.id}/chat`
+      );
       const ws = new WebSocket(wsUrl);
       wsRef.current = ws;
 
@@ -87,8 +96,20 @@ function ChatPageInner() {
         try {
           data = JSON.parse(event.data);
         } catch {
+          data = null;
+        }
+        if (data?.type === "resume") {
+          resumeRef.current = { token: data.token, seq: data.seq };
+          resumeAttemptsRef.current = 0;
+          return;
+        }
+        if (data?.type === "wake") {
+          setWaking(data.step === "ready" ? null : data.step);
           return;
         }
+        // Every agent frame counts towards where to resume from
+        if (resumeRef.current) resumeRef.current.seq++;
+        if (!data) return;
 
         switch (data.type) {
           case "text":
@@ -175,22 +196,36 @@ function ChatPageInner() {
         }
       };
 
-      ws.onclose = () => {
+      ws.onclose = (event) => {
+        if (wsRef.current !== ws) return;
+        // The connection dropped rather than being closed: resume it,
+        // catching up on whatever the agent sent meanwhile
+        if (!event.wasClean && resumeRef.current && resumeAttemptsRef.current < 5) {
+          resumeAttemptsRef.current++;
+          wsRef.current = null;
+          setTimeout(() => {
+            if (resumeRef.current) connectWs(inst);
+          }, 2000);
+          return;
+        }
+        resumeRef.current = null;
         setStreaming(false);
       };
 
       ws.onerror = () => {
-        setStreaming(false);
+        if (!resumeRef.current) setStreaming(false);
       };
     },
     []
   );
 
   useEffect(() => {
-    if (instance && instance.status === "running") {
+    // A stopped instance wakes when the chat connects
+    if (instance && (instance.status === "running" || instance.status === "stopped")) {
       connectWs(instance);
     }
     return () => {
+      resumeRef.current = null;
       if (wsRef.current) {
         wsRef.current.close();
         wsRef.current = null;
@@ -231,7 +266,7 @@ function ChatPageInner() {
       await api.deleteConversation(conversation.id);
       setMessages([]);
       // Create a fresh conversation
-      const conv = await api.getOrCreateConversation(cwd);
+      const conv = await api.getOrCreateConversation(cwd, instance?.id);
       setConversation(conv);
     } catch {
       // Ignore — conversation may already be gone
@@ -259,7 +294,7 @@ function ChatPageInner() {
     );
   }
 
-  if (instance.status !== "running") {
+  if (instance.status !== "running" && instance.status !== "stopped") {
     return (
       <div className="flex h-[calc(100vh-8rem)] items-center justify-center">
         <div className="text-center">
@@ -309,6 +344,11 @@ function ChatPageInner() {
             </span>
           )}
           <div className="ml-auto flex items-center gap-2">
+            {waking && (
+              <span className="text-xs text-amber-600">
+                Waking instance ({waking})...
+              </span>
+            )}
             {streaming && (
               <span className="text-xs text-gray-400">Streaming...</span>
             )}
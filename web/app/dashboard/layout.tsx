@@ -29,8 +29,8 @@ export default function DashboardLayout({
     });
   }, [router]);
 
-  function handleLogout() {
-    document.cookie = "session=; path=/; max-age=0";
+  async function handleLogout() {
+    await api.logout().catch(() => {});
     router.push("/");
   }
 
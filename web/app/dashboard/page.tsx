@@ -34,8 +34,11 @@ export default function DashboardPage() {
     setLoading(true);
     setError("");
     try {
-      const inst = await api.createInstance();
-      setInstance(inst);
+      const job = await api.createInstance();
+      const done = await api.waitForJob(job.id);
+      if (done.instance_id) {
+        setInstance(await api.getInstance(done.instance_id));
+      }
     } catch (err) {
       setError(err instanceof Error ? err.message : "Failed to provision");
     } finally {
@@ -47,7 +50,8 @@ export default function DashboardPage() {
     if (!instance) return;
     setLoading(true);
     try {
-      await api.pauseInstance(instance.id);
+      const job = await api.pauseInstance(instance.id);
+      await api.waitForJob(job.id);
       setInstance({ ...instance, status: "stopped" });
     } catch (err) {
       setError(err instanceof Error ? err.message : "Failed to pause");
@@ -60,7 +64,8 @@ export default function DashboardPage() {
     if (!instance) return;
     setLoading(true);
     try {
-      await api.wakeInstance(instance.id);
+      const job = await api.wakeInstance(instance.id);
+      await api.waitForJob(job.id);
       setInstance({ ...instance, status: "running" });
     } catch (err) {
       setError(err instanceof Error ? err.message : "Failed to wake");
@@ -74,7 +79,8 @@ export default function DashboardPage() {
     setLoading(true);
     setError("");
     try {
-      await api.deleteInstance(instance.id);
+      const job = await api.deleteInstance(instance.id);
+      await api.waitForJob(job.id);
       setInstance(null);
     } catch (err) {
       setError(err instanceof Error ? err.message : "Failed to delete");
@@ -1,7 +1,7 @@
 "use client";
 
 import { useEffect, useState } from "react";
-import { api, Settings } from "@/lib/api";
+import { api, RecordingMode, Settings } from "@/lib/api";
 
 export default function SettingsPage() {
   const [settings, setSettings] = useState<Settings | null>(null);
@@ -32,6 +32,21 @@ export default function SettingsPage() {
     }
   }
 
+  async function saveRecording(mode: RecordingMode) {
+    setSaving(true);
+    setMessage("");
+    setError("");
+    try {
+      await api.updateSettings({ terminal_recording: mode });
+      setMessage("Saved. New terminal sessions use this setting.");
+      setSettings(await api.getSettings());
+    } catch (err) {
+      setError(err instanceof Error ? err.message : "Failed to save");
+    } finally {
+      setSaving(false);
+    }
+  }
+
   const authBadge = settings?.auth_method === "oauth"
     ? { label: "Claude Max/Pro", color: "bg-purple-100 text-purple-800" }
     : settings?.auth_method === "api_key"
@@ -150,6 +165,26 @@ export default function SettingsPage() {
         </div>
       </div>
 
+      {/* Terminal recording */}
+      <div className="rounded-xl bg-white p-6 ring-1 ring-gray-200">
+        <h3 className="font-semibold">Terminal Recording</h3>
+        <p className="mt-1 text-sm text-gray-600">
+          Record terminal sessions on your instances for later replay. Anyone
+          using a recorded terminal sees a notice when it opens. An
+          organization can require recording on instances shared with it.
+        </p>
+        <select
+          value={settings?.terminal_recording ?? "off"}
+          onChange={(e) => saveRecording(e.target.value as RecordingMode)}
+          disabled={saving || !settings}
+          className="mt-4 rounded-lg border border-gray-300 px-4 py-2 text-sm focus:border-gray-500 focus:outline-none focus:ring-1 focus:ring-gray-500 disabled:opacity-50"
+        >
+          <option value="off">Off</option>
+          <option value="output">Output only</option>
+          <option value="full">Output and keystrokes</option>
+        </select>
+      </div>
+
       <p className="text-xs text-gray-400">
         If both are set, the OAuth token takes priority (uses your Max/Pro
         subscription). Credentials are injected as environment variables when
@@ -75,7 +75,8 @@ function TerminalContent() {
     );
   }
 
-  if (instance.status !== "running") {
+  // A stopped instance wakes when the terminal connects
+  if (instance.status !== "running" && instance.status !== "stopped") {
     return (
       <div className="flex h-[calc(100vh-8rem)] items-center justify-center">
         <div className="text-center">
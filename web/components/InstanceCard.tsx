@@ -56,8 +56,7 @@ export default function InstanceCard({
         <div className="mt-4">
           <p className="mb-2 text-sm font-medium text-gray-700">Connect:</p>
           <code className="block rounded bg-gray-900 p-3 text-sm text-green-400">
-            curl -fsSL {process.env.NEXT_PUBLIC_API_URL || "http://localhost:8080"}
-            /connect.sh?user_id={instance.id} | bash
+            claude-cloud connect {instance.name}
           </code>
         </div>
       )}
@@ -9,16 +9,21 @@ import { createAuthWsUrl } from "@/lib/ws";
 
 interface WebTerminalProps {
   instanceId: number;
+  // WebSocket path speaking the ttyd protocol; defaults to the live
+  // terminal. Recording replays pass their replay path.
+  path?: string;
 }
 
 type ConnectionStatus = "connecting" | "connected" | "disconnected" | "error";
 
-export default function WebTerminal({ instanceId }: WebTerminalProps) {
+export default function WebTerminal({ instanceId, path }: WebTerminalProps) {
   const termRef = useRef<HTMLDivElement>(null);
   const terminalRef = useRef<Terminal | null>(null);
   const wsRef = useRef<WebSocket | null>(null);
   const fitAddonRef = useRef<FitAddon | null>(null);
   const [status, setStatus] = useState<ConnectionStatus>("connecting");
+  // Where to resume from if the connection drops; see the proxy's resume event
+  const resumeRef = useRef<{ token: string; seq: number } | null>(null);
 
   const connect = useCallback(() => {
     if (!termRef.current) return;
@@ -30,6 +35,7 @@ export default function WebTerminal({ instanceId }: WebTerminalProps) {
     if (wsRef.current) {
       wsRef.current.close();
     }
+    resumeRef.current = null;
 
     const term = new Terminal({
       cursorBlink: true,
@@ -53,21 +59,7 @@ export default function WebTerminal({ instanceId }: WebTerminalProps) {
     terminalRef.current = term;
     fitAddonRef.current = fitAddon;
 
-    setStatus("connecting");
-
-    const wsUrl = createAuthWsUrl(`/instances/${instanceId}/terminal`);
-    const ws = new WebSocket(wsUrl, ["tty"]);
-    ws.binaryType = "arraybuffer";
-    wsRef.current = ws;
-
-    ws.onopen = () => {
-      setStatus("connected");
-      // ttyd requires an auth token as the first message, even when no auth is configured.
-      // Without this, ttyd suppresses output frames.
-      ws.send(JSON.stringify({ AuthToken: "" }));
-      // Send initial terminal dimensions — fitAddon.fit() fires before this handler
-      // is registered, so onResize never captures the initial size.
-      const { cols, rows } = term;
+    const sendResize = (ws: WebSocket, cols: number, rows: number) => {
       const resizeMsg = JSON.stringify({ columns: cols, rows: rows });
       const encoder = new TextEncoder();
       const payload = encoder.encode(resizeMsg);
@@ -77,38 +69,94 @@ export default function WebTerminal({ instanceId }: WebTerminalProps) {
       ws.send(msg.buffer);
     };
 
-    ws.onmessage = (event) => {
-      if (typeof event.data === "string") return; // Ignore text frames
-      const data = new Uint8Array(event.data as ArrayBuffer);
-      if (data.length === 0) return;
-
-      // ttyd protocol: first byte is ASCII char message type
-      // '0' (48) = output, '1' (49) = title, '2' (50) = prefs
-      const msgType = data[0];
-      const payload = data.slice(1);
-
-      switch (msgType) {
-        case 48: // '0' = output
-          term.write(payload);
-          break;
-        case 49: // '1' = title
-          break;
-        case 50: // '2' = prefs
-          break;
-      }
-    };
+    let attempts = 0;
+    const open = () => {
+      setStatus("connecting");
 
-    ws.onclose = () => {
-      setStatus("disconnected");
-    };
-
-    ws.onerror = () => {
-      setStatus("error");
+      // After a dropped connection, pick up where we left off
+      let wsPath = path ?? `/instances/${instanceId}/terminal`;
+      const resume = resumeRef.current;
+      if (resume) {
+        const separator = wsPath.includes("?") ? "&" : "?";
+        wsPath += `${separator}resume=${resume.token}&seq=${resume.seq}`;
+      }
+      const ws = new WebSocket(createAuthWsUrl(wsPath), ["tty"]);
+      ws.binaryType = "arraybuffer";
+      wsRef.current = ws;
+
+      ws.onopen = () => {
+        setStatus("connected");
+        // ttyd requires an auth token as the first message, even when no auth is configured.
+        // Without this, ttyd suppresses output frames.
+        ws.send(JSON.stringify({ AuthToken: "" }));
+        // Send initial terminal dimensions — fitAddon.fit() fires before this handler
+        // is registered, so onResize never captures the initial size.
+        sendResize(ws, term.cols, term.rows);
+      };
+
+      ws.onmessage = (event) => {
+        if (typeof event.data === "string") {
+          // Control messages from the proxy: resume info and wake progress
+          let data;
+          try {
+            data = JSON.parse(event.data);
+          } catch {
+            return;
+          }
+          if (data.type === "resume") {
+            // A resume that was not possible starts a new session
+            if (resumeRef.current && !data.resumed) term.reset();
+            resumeRef.current = { token: data.token, seq: data.seq };
+            attempts = 0;
+          } else if (data.type === "wake" && data.step !== "ready") {
+            term.write(`\x1b[33m[Waking instance: ${data.step}]\x1b[0m\r\n`);
+          }
+          return;
+        }
+        const data = new Uint8Array(event.data as ArrayBuffer);
+        if (resumeRef.current) resumeRef.current.seq++;
+        if (data.length === 0) return;
+
+        // ttyd protocol: first byte is ASCII char message type
+        // '0' (48) = output, '1' (49) = title, '2' (50) = prefs
+        const msgType = data[0];
+        const payload = data.slice(1);
+
+        switch (msgType) {
+          case 48: // '0' = output
+            term.write(payload);
+            break;
+          case 49: // '1' = title
+            break;
+          case 50: // '2' = prefs
+            break;
+        }
+      };
+
+      ws.onclose = (event) => {
+        if (wsRef.current !== ws) return; // replaced by a new connection
+        // The connection dropped rather than being closed: try to resume
+        if (!event.wasClean && resumeRef.current && attempts < 5) {
+          attempts++;
+          setStatus("connecting");
+          setTimeout(() => {
+            if (wsRef.current === ws) open();
+          }, 2000);
+          return;
+        }
+        setStatus("disconnected");
+      };
+
+      ws.onerror = () => {
+        if (!resumeRef.current) setStatus("error");
+      };
     };
+    open();
 
     // Input: ttyd expects ASCII '0' (48) followed by data
     term.onData((data) => {
-      if (ws.readyState === WebSocket.OPEN) {
+      const ws = wsRef.current;
+      if (ws && ws.readyState === WebSocket.OPEN) {
         const encoder = new TextEncoder();
         const payload = encoder.encode(data);
         const msg = new Uint8Array(payload.length + 1);
@@ -120,17 +168,12 @@ export default function WebTerminal({ instanceId }: WebTerminalProps) {
 
     // Resize: ttyd expects ASCII '1' (49) followed by JSON {columns, rows}
     term.onResize(({ cols, rows }) => {
-      if (ws.readyState === WebSocket.OPEN) {
-        const resizeMsg = JSON.stringify({ columns: cols, rows: rows });
-        const encoder = new TextEncoder();
-        const payload = encoder.encode(resizeMsg);
-        const msg = new Uint8Array(payload.length + 1);
-        msg[0] = 49; // '1' = resize
-        msg.set(payload, 1);
-        ws.send(msg.buffer);
+      const ws = wsRef.current;
+      if (ws && ws.readyState === WebSocket.OPEN) {
+        sendResize(ws, cols, rows);
       }
     });
-  }, [instanceId]);
+  }, [instanceId, path]);
 
   useEffect(() => {
     connect();
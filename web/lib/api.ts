@@ -14,16 +14,220 @@ export interface Settings {
   anthropic_api_key: string; // masked
   claude_oauth_token: string; // masked
   auth_method: "oauth" | "api_key" | "none";
+  terminal_recording: RecordingMode;
+}
+
+// off, output only, or output and keystrokes.
+export type RecordingMode = "off" | "output" | "full";
+
+// A CLI waiting for the signed-in user to approve it (device flow).
+export interface DeviceInfo {
+  user_code: string;
+  client_id: string;
+  created_at: string;
+  expires_at: string;
+}
+
+// A single sign-on provider offered on the login page.
+export interface SSOProvider {
+  name: string;
+  kind: "oidc" | "github";
 }
 
 export interface Instance {
   id: number;
+  name: string;
   provider: string;
   provider_id: string;
   host: string;
   port: number;
   status: string;
   volume_id: string;
+  class?: string;
+  template_id?: number;
+  snapshot_ids?: string[];
+  paused_at?: string;
+  organization_id?: number;
+  team_access: "none" | "read" | "write";
+}
+
+export type OrgRole = "owner" | "admin" | "member" | "viewer";
+
+export interface Organization {
+  id: number;
+  name: string;
+  plan: string;
+  subscription_status: string;
+  terminal_recording: RecordingMode;
+  role: OrgRole;
+}
+
+export interface OrgMember {
+  user_id: number;
+  email: string;
+  name: string;
+  role: OrgRole;
+}
+
+export interface Job {
+  id: number;
+  type: "create" | "pause" | "wake" | "destroy" | "resize" | "backup" | "restore";
+  status: "pending" | "running" | "succeeded" | "failed";
+  instance_id?: number;
+  target_id?: number;
+  progress: string;
+  error?: string;
+  error_code?: string;
+  attempts: number;
+  max_attempts: number;
+  created_at: string;
+  started_at?: string;
+  finished_at?: string;
+}
+
+export interface InstanceClass {
+  name: string;
+  cpus: number;
+  memory_mb: number;
+  server_type: string;
+}
+
+export interface Template {
+  id: number;
+  name: string;
+  description?: string;
+  image?: string;
+  devcontainer?: string; // devcontainer.json source
+  setup_script?: string;
+  env?: Record<string, string>;
+  repos?: string[];
+  global: boolean;
+  created_at: string;
+}
+
+export type TemplateSpec = Omit<Template, "id" | "global" | "created_at">;
+
+// Secret values are write-only; the API never returns them.
+export interface Secret {
+  name: string;
+  kind: "env" | "file";
+  path?: string; // relative to the home directory, for file secrets
+  created_at: string;
+  updated_at: string;
+}
+
+export interface SecretSpec {
+  kind?: "env" | "file";
+  path?: string;
+  value: string;
+}
+
+// Personal access tokens for scripts and the CLI. Scopes are
+// "<resource>:read" or "<resource>:write"; write implies read.
+export interface AccessToken {
+  id: number;
+  name: string;
+  prefix: string;
+  scopes: string[];
+  last_used_at?: string;
+  expires_at?: string;
+  created_at: string;
+}
+
+// A signed-in browser or CLI. Revoking one signs it out within a request.
+export interface DeviceSession {
+  id: number;
+  user_agent: string;
+  ip: string;
+  created_at: string;
+  last_used_at: string;
+  expires_at: string;
+  current: boolean;
+}
+
+export interface AuditEvent {
+  id: number;
+  actor: "admin" | "user" | "system";
+  actor_id?: number;
+  action: string;
+  target_type?: string;
+  target_id?: string;
+  subject_id?: number;
+  details?: Record<string, unknown>;
+  ip?: string;
+  user_agent?: string;
+  request_id?: string;
+  created_at: string;
+  prev_hash: string;
+  hash: string;
+}
+
+export interface Page<T> {
+  items: T[];
+  next_cursor?: string;
+  total: number;
+}
+
+export interface TokenSpec {
+  name: string;
+  scopes: string[];
+  expires_at?: string;
+}
+
+export interface Backup {
+  id: number;
+  instance_id: number;
+  status: "pending" | "succeeded" | "failed";
+  trigger: "manual" | "scheduled";
+  size_bytes: number;
+  error?: string;
+  created_at: string;
+  finished_at?: string;
+}
+
+// A recorded terminal session (asciicast v2).
+export interface Recording {
+  id: number;
+  instance_id: number;
+  user_id: number;
+  input: boolean;
+  width: number;
+  height: number;
+  duration: number; // seconds
+  size_bytes: number;
+  started_at: string;
+  ended_at: string;
+  expires_at?: string;
+}
+
+// A read-only link to watch an instance's terminal. The token is only
+// present in the response that created it.
+export interface TerminalShare {
+  id: number;
+  instance_id: number;
+  label: string;
+  prefix: string;
+  created_by: number;
+  expires_at: string;
+  revoked_at?: string;
+  created_at: string;
+  token?: string;
+}
+
+// Presence events sent as text frames on a shared terminal.
+export interface TerminalPresenceClient {
+  id: number;
+  name: string;
+  role: "writer" | "viewer";
+  guest?: boolean;
+  joined_at: string;
+}
+
+export interface TerminalPresenceEvent {
+  type: "hello" | "presence";
+  event?: "join" | "leave" | "writer";
+  client: TerminalPresenceClient;
+  clients: TerminalPresenceClient[];
 }
 
 export interface UsageSummary {
@@ -78,15 +282,42 @@ export interface ConversationInfo {
   updatedAt: string | null;
 }
 
+// Session cookies are short-lived; a 401 is retried once after exchanging
+// the refresh cookie. Concurrent requests share one refresh, since each
+// refresh token is only good once; a 409 means another tab refreshed first
+// and its cookies are already in place.
+let refreshing: Promise<boolean> | null = null;
+
+function refreshSession(): Promise<boolean> {
+  if (!refreshing) {
+    refreshing = fetch(`${API_BASE}/auth/refresh`, {
+      method: "POST",
+      credentials: "include",
+    })
+      .then((res) => res.ok || res.status === 409)
+      .catch(() => false)
+      .finally(() => {
+        refreshing = null;
+      });
+  }
+  return refreshing;
+}
+
 async function apiFetch<T>(path: string, options?: RequestInit): Promise<T> {
-  const res = await fetch(`${API_BASE}${path}`, {
-    credentials: "include",
-    headers: {
-      "Content-Type": "application/json",
-      ...options?.headers,
-    },
-    ...options,
-  });
+  const send = () =>
+    fetch(`${API_BASE}${path}`, {
+      credentials: "include",
+      headers: {
+        "Content-Type": "application/json",
+        ...options?.headers,
+      },
+      ...options,
+    });
+
+  let res = await send();
+  if (res.status === 401 && !path.startsWith("/auth/refresh") && (await refreshSession())) {
+    res = await send();
+  }
 
   if (!res.ok) {
     const body = await res.json().catch(() => ({ error: res.statusText }));
@@ -97,15 +328,20 @@ async function apiFetch<T>(path: string, options?: RequestInit): Promise<T> {
 }
 
 export const api = {
+  // The link only works in this browser (bound by a nonce cookie).
   login(email: string) {
     return apiFetch<{ message: string; token?: string }>("/auth/login", {
       method: "POST",
-      body: JSON.stringify({ email }),
+      body: JSON.stringify({ email, bind_browser: true }),
     });
   },
 
+  logout() {
+    return apiFetch<{ status: string }>("/auth/logout", { method: "POST" });
+  },
+
   verify(token: string) {
-    return apiFetch<{ token: string }>(`/auth/verify?token=${token}`, {
+    return apiFetch<{ token: string; refresh_token: string; expires_in: number }>(`/auth/verify?token=${token}`, {
       headers: { Accept: "application/json" },
     });
   },
@@ -114,39 +350,231 @@ export const api = {
     return apiFetch<User>("/auth/me");
   },
 
+  listSSOProviders() {
+    return apiFetch<SSOProvider[]>("/auth/sso/providers");
+  },
+
+  // Full-page navigation target; the API redirects to the provider.
+  ssoLoginURL(provider: string, redirect = "/dashboard") {
+    return `${API_BASE}/auth/sso/${encodeURIComponent(provider)}?redirect=${encodeURIComponent(redirect)}`;
+  },
+
+  getDevice(userCode: string) {
+    return apiFetch<DeviceInfo>(
+      `/auth/device?user_code=${encodeURIComponent(userCode)}`
+    );
+  },
+
+  decideDevice(userCode: string, approve: boolean) {
+    return apiFetch<{ status: string }>("/auth/device/approve", {
+      method: "POST",
+      body: JSON.stringify({ user_code: userCode, approve }),
+    });
+  },
+
   getInstance(id: number) {
     return apiFetch<Instance>(`/instances/${id}`);
   },
 
-  getMyInstance() {
-    return apiFetch<Instance>("/instances/mine");
+  listInstances() {
+    return apiFetch<Instance[]>("/instances");
+  },
+
+  // Selects by name or ID; omitting it returns the default instance.
+  getMyInstance(instance?: string) {
+    const params = instance ? `?instance=${encodeURIComponent(instance)}` : "";
+    return apiFetch<Instance>(`/instances/mine${params}`);
   },
 
   deleteInstance(id: number) {
-    return apiFetch<{ status: string }>(`/instances/${id}`, {
+    return apiFetch<Job>(`/instances/${id}`, {
       method: "DELETE",
     });
   },
 
-  createInstance() {
-    return apiFetch<Instance>("/instances", {
+  // template is a template ID or name; omitting it boots the stock environment.
+  createInstance(name?: string, instanceClass?: string, template?: string) {
+    return apiFetch<Job>("/instances", {
       method: "POST",
-      body: JSON.stringify({}),
+      body: JSON.stringify({
+        name: name || undefined,
+        class: instanceClass || undefined,
+        template: template || undefined,
+      }),
+    });
+  },
+
+  // Classes the current plan allows, default first.
+  listClasses() {
+    return apiFetch<InstanceClass[]>("/instances/classes");
+  },
+
+  resizeInstance(id: number, instanceClass: string) {
+    return apiFetch<Job>(`/instances/${id}/resize`, {
+      method: "POST",
+      body: JSON.stringify({ class: instanceClass }),
     });
   },
 
   pauseInstance(id: number) {
-    return apiFetch<{ status: string }>(`/instances/${id}/pause`, {
+    return apiFetch<Job>(`/instances/${id}/pause`, {
       method: "POST",
     });
   },
 
   wakeInstance(id: number) {
-    return apiFetch<{ status: string }>(`/instances/${id}/wake`, {
+    return apiFetch<Job>(`/instances/${id}/wake`, {
+      method: "POST",
+    });
+  },
+
+  // Global templates plus the user's own, by name.
+  listTemplates() {
+    return apiFetch<Template[]>("/templates");
+  },
+
+  createTemplate(spec: TemplateSpec) {
+    return apiFetch<Template>("/templates", {
+      method: "POST",
+      body: JSON.stringify(spec),
+    });
+  },
+
+  deleteTemplate(id: number) {
+    return apiFetch<{ status: string }>(`/templates/${id}`, {
+      method: "DELETE",
+    });
+  },
+
+  listTokens() {
+    return apiFetch<AccessToken[]>("/auth/tokens");
+  },
+
+  // The token is only returned here; store it before dismissing.
+  createToken(spec: TokenSpec) {
+    return apiFetch<AccessToken & { token: string }>("/auth/tokens", {
+      method: "POST",
+      body: JSON.stringify(spec),
+    });
+  },
+
+  revokeToken(id: number) {
+    return apiFetch<{ status: string }>(`/auth/tokens/${id}`, {
+      method: "DELETE",
+    });
+  },
+
+  listSessions() {
+    return apiFetch<DeviceSession[]>("/auth/sessions");
+  },
+
+  revokeSession(id: number) {
+    return apiFetch<{ status: string }>(`/auth/sessions/${id}`, {
+      method: "DELETE",
+    });
+  },
+
+  // Signs out everywhere except this browser.
+  revokeOtherSessions() {
+    return apiFetch<{ status: string; revoked: number }>("/auth/sessions", {
+      method: "DELETE",
+    });
+  },
+
+  // Events concerning the signed-in user, newest first.
+  listAuditEvents(cursor?: string) {
+    const q = cursor ? `?cursor=${encodeURIComponent(cursor)}` : "";
+    return apiFetch<Page<AuditEvent>>(`/auth/audit${q}`);
+  },
+
+  listSecrets() {
+    return apiFetch<Secret[]>("/secrets");
+  },
+
+  // Creates or rotates a secret and pushes it to running instances.
+  putSecret(name: string, spec: SecretSpec) {
+    return apiFetch<Secret>(`/secrets/${encodeURIComponent(name)}`, {
+      method: "PUT",
+      body: JSON.stringify(spec),
+    });
+  },
+
+  deleteSecret(name: string) {
+    return apiFetch<{ status: string }>(`/secrets/${encodeURIComponent(name)}`, {
+      method: "DELETE",
+    });
+  },
+
+  listBackups(instanceId: number) {
+    return apiFetch<Backup[]>(`/instances/${instanceId}/backups`);
+  },
+
+  createBackup(instanceId: number) {
+    return apiFetch<Job>(`/instances/${instanceId}/backups`, {
+      method: "POST",
+    });
+  },
+
+  // Restores into a stopped instance, then wakes it.
+  restoreBackup(instanceId: number, backupId: number) {
+    return apiFetch<Job>(`/instances/${instanceId}/backups/${backupId}/restore`, {
+      method: "POST",
+    });
+  },
+
+  listRecordings(instanceId: number) {
+    return apiFetch<Recording[]>(`/instances/${instanceId}/recordings`);
+  },
+
+  // The .cast file, playable with `asciinema play`.
+  recordingUrl(instanceId: number, recordingId: number) {
+    return `${API_BASE}/instances/${instanceId}/recordings/${recordingId}`;
+  },
+
+  // WebSocket path for WebTerminal; plays the output back in real time.
+  recordingReplayPath(instanceId: number, recordingId: number, speed = 1) {
+    return `/instances/${instanceId}/recordings/${recordingId}/replay?speed=${speed}`;
+  },
+
+  listTerminalShares(instanceId: number) {
+    return apiFetch<TerminalShare[]>(`/instances/${instanceId}/terminal/shares`);
+  },
+
+  // ttl is a Go duration from "1m" to "24h"; one hour if omitted.
+  createTerminalShare(instanceId: number, data: { label?: string; ttl?: string }) {
+    return apiFetch<TerminalShare>(`/instances/${instanceId}/terminal/shares`, {
       method: "POST",
+      body: JSON.stringify(data),
+    });
+  },
+
+  // Also disconnects anyone watching through the link.
+  revokeTerminalShare(instanceId: number, shareId: number) {
+    return apiFetch<{ status: string }>(`/instances/${instanceId}/terminal/shares/${shareId}`, {
+      method: "DELETE",
     });
   },
 
+  // The URL a share link holder's terminal connects to; no account needed.
+  sharedTerminalUrl(token: string) {
+    return `${API_BASE.replace(/^http/, "ws")}/terminal/shared?token=${encodeURIComponent(token)}`;
+  },
+
+  getJob(id: number) {
+    return apiFetch<Job>(`/jobs/${id}`);
+  },
+
+  // Polls a job until it succeeds or fails. Rejects with the job error on failure.
+  async waitForJob(id: number, onProgress?: (job: Job) => void, intervalMs = 2000) {
+    for (;;) {
+      const job = await apiFetch<Job>(`/jobs/${id}`);
+      onProgress?.(job);
+      if (job.status === "succeeded") return job;
+      if (job.status === "failed") throw new Error(job.error || `${job.type} failed`);
+      await new Promise((resolve) => setTimeout(resolve, intervalMs));
+    }
+  },
+
   getUsage() {
     return apiFetch<UsageSummary>("/billing/usage");
   },
@@ -162,11 +590,86 @@ export const api = {
     return apiFetch<{ url: string }>("/billing/portal");
   },
 
+  // Organizations
+  listOrgs() {
+    return apiFetch<Organization[]>("/orgs");
+  },
+
+  createOrg(name: string) {
+    return apiFetch<Organization>("/orgs", {
+      method: "POST",
+      body: JSON.stringify({ name }),
+    });
+  },
+
+  deleteOrg(orgId: number) {
+    return apiFetch<{ status: string }>(`/orgs/${orgId}`, { method: "DELETE" });
+  },
+
+  // Owners and admins; the stricter of this and the instance owner's applies.
+  setOrgRecording(orgId: number, mode: RecordingMode) {
+    return apiFetch<Organization>(`/orgs/${orgId}/recording`, {
+      method: "PUT",
+      body: JSON.stringify({ terminal_recording: mode }),
+    });
+  },
+
+  listOrgMembers(orgId: number) {
+    return apiFetch<OrgMember[]>(`/orgs/${orgId}/members`);
+  },
+
+  addOrgMember(orgId: number, email: string, role: OrgRole = "member") {
+    return apiFetch<OrgMember>(`/orgs/${orgId}/members`, {
+      method: "POST",
+      body: JSON.stringify({ email, role }),
+    });
+  },
+
+  updateOrgMember(orgId: number, userId: number, role: OrgRole) {
+    return apiFetch<{ status: string }>(`/orgs/${orgId}/members/${userId}`, {
+      method: "PUT",
+      body: JSON.stringify({ role }),
+    });
+  },
+
+  removeOrgMember(orgId: number, userId: number) {
+    return apiFetch<{ status: string }>(`/orgs/${orgId}/members/${userId}`, {
+      method: "DELETE",
+    });
+  },
+
+  listOrgInstances(orgId: number) {
+    return apiFetch<Instance[]>(`/orgs/${orgId}/instances`);
+  },
+
+  createOrgCheckout(orgId: number, plan: string) {
+    return apiFetch<{ url: string }>(`/orgs/${orgId}/billing/checkout`, {
+      method: "POST",
+      body: JSON.stringify({ plan }),
+    });
+  },
+
+  getOrgBillingPortal(orgId: number) {
+    return apiFetch<{ url: string }>(`/orgs/${orgId}/billing/portal`);
+  },
+
+  // Shares an instance with an organization; null stops sharing.
+  shareInstance(instanceId: number, organizationId: number | null, teamAccess: Instance["team_access"]) {
+    return apiFetch<Instance>(`/instances/${instanceId}/sharing`, {
+      method: "PUT",
+      body: JSON.stringify({ organization_id: organizationId, team_access: teamAccess }),
+    });
+  },
+
   getSettings() {
     return apiFetch<Settings>("/auth/settings");
   },
 
-  updateSettings(fields: { anthropic_api_key?: string; claude_oauth_token?: string }) {
+  updateSettings(fields: {
+    anthropic_api_key?: string;
+    claude_oauth_token?: string;
+    terminal_recording?: RecordingMode;
+  }) {
     return apiFetch<{ status: string }>("/auth/settings", {
       method: "PUT",
       body: JSON.stringify(fields),
@@ -237,9 +740,11 @@ export const api = {
   },
 
   // Conversations
-  getOrCreateConversation(projectPath: string) {
+  // With an instance, the conversation is shared with everyone who can open it.
+  getOrCreateConversation(projectPath: string, instanceId?: number) {
+    const instance = instanceId ? `&instance=${instanceId}` : "";
     return apiFetch<Conversation>(
-      `/conversations?project=${encodeURIComponent(projectPath)}`
+      `/conversations?project=${encodeURIComponent(projectPath)}${instance}`
     );
   },
 